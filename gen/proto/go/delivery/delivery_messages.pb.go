@@ -7,6 +7,7 @@
 package delivery
 
 import (
+	shared "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -22,16 +23,181 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DeliveryTimeWindow int32
+
+const (
+	DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED DeliveryTimeWindow = 0
+	DeliveryTimeWindow_DELIVERY_TIME_WINDOW_MORNING     DeliveryTimeWindow = 1
+	DeliveryTimeWindow_DELIVERY_TIME_WINDOW_14_16       DeliveryTimeWindow = 2
+	DeliveryTimeWindow_DELIVERY_TIME_WINDOW_16_18       DeliveryTimeWindow = 3
+	DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20       DeliveryTimeWindow = 4
+	DeliveryTimeWindow_DELIVERY_TIME_WINDOW_19_21       DeliveryTimeWindow = 5
+)
+
+// Enum value maps for DeliveryTimeWindow.
+var (
+	DeliveryTimeWindow_name = map[int32]string{
+		0: "DELIVERY_TIME_WINDOW_UNSPECIFIED",
+		1: "DELIVERY_TIME_WINDOW_MORNING",
+		2: "DELIVERY_TIME_WINDOW_14_16",
+		3: "DELIVERY_TIME_WINDOW_16_18",
+		4: "DELIVERY_TIME_WINDOW_18_20",
+		5: "DELIVERY_TIME_WINDOW_19_21",
+	}
+	DeliveryTimeWindow_value = map[string]int32{
+		"DELIVERY_TIME_WINDOW_UNSPECIFIED": 0,
+		"DELIVERY_TIME_WINDOW_MORNING":     1,
+		"DELIVERY_TIME_WINDOW_14_16":       2,
+		"DELIVERY_TIME_WINDOW_16_18":       3,
+		"DELIVERY_TIME_WINDOW_18_20":       4,
+		"DELIVERY_TIME_WINDOW_19_21":       5,
+	}
+)
+
+func (x DeliveryTimeWindow) Enum() *DeliveryTimeWindow {
+	p := new(DeliveryTimeWindow)
+	*p = x
+	return p
+}
+
+func (x DeliveryTimeWindow) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryTimeWindow) Descriptor() protoreflect.EnumDescriptor {
+	return file_delivery_delivery_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (DeliveryTimeWindow) Type() protoreflect.EnumType {
+	return &file_delivery_delivery_messages_proto_enumTypes[0]
+}
+
+func (x DeliveryTimeWindow) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryTimeWindow.Descriptor instead.
+func (DeliveryTimeWindow) EnumDescriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type PickupPointType int32
+
+const (
+	PickupPointType_PICKUP_POINT_TYPE_UNSPECIFIED PickupPointType = 0
+	PickupPointType_PICKUP_POINT_TYPE_KONBINI     PickupPointType = 1
+	PickupPointType_PICKUP_POINT_TYPE_LOCKER      PickupPointType = 2
+)
+
+// Enum value maps for PickupPointType.
+var (
+	PickupPointType_name = map[int32]string{
+		0: "PICKUP_POINT_TYPE_UNSPECIFIED",
+		1: "PICKUP_POINT_TYPE_KONBINI",
+		2: "PICKUP_POINT_TYPE_LOCKER",
+	}
+	PickupPointType_value = map[string]int32{
+		"PICKUP_POINT_TYPE_UNSPECIFIED": 0,
+		"PICKUP_POINT_TYPE_KONBINI":     1,
+		"PICKUP_POINT_TYPE_LOCKER":      2,
+	}
+)
+
+func (x PickupPointType) Enum() *PickupPointType {
+	p := new(PickupPointType)
+	*p = x
+	return p
+}
+
+func (x PickupPointType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PickupPointType) Descriptor() protoreflect.EnumDescriptor {
+	return file_delivery_delivery_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (PickupPointType) Type() protoreflect.EnumType {
+	return &file_delivery_delivery_messages_proto_enumTypes[1]
+}
+
+func (x PickupPointType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PickupPointType.Descriptor instead.
+func (PickupPointType) EnumDescriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type ServiceLevel int32
+
+const (
+	ServiceLevel_SERVICE_LEVEL_UNSPECIFIED  ServiceLevel = 0
+	ServiceLevel_SERVICE_LEVEL_STANDARD     ServiceLevel = 1
+	ServiceLevel_SERVICE_LEVEL_EXPRESS      ServiceLevel = 2
+	ServiceLevel_SERVICE_LEVEL_COOL_CHILLED ServiceLevel = 3
+	ServiceLevel_SERVICE_LEVEL_COOL_FROZEN  ServiceLevel = 4
+)
+
+// Enum value maps for ServiceLevel.
+var (
+	ServiceLevel_name = map[int32]string{
+		0: "SERVICE_LEVEL_UNSPECIFIED",
+		1: "SERVICE_LEVEL_STANDARD",
+		2: "SERVICE_LEVEL_EXPRESS",
+		3: "SERVICE_LEVEL_COOL_CHILLED",
+		4: "SERVICE_LEVEL_COOL_FROZEN",
+	}
+	ServiceLevel_value = map[string]int32{
+		"SERVICE_LEVEL_UNSPECIFIED":  0,
+		"SERVICE_LEVEL_STANDARD":     1,
+		"SERVICE_LEVEL_EXPRESS":      2,
+		"SERVICE_LEVEL_COOL_CHILLED": 3,
+		"SERVICE_LEVEL_COOL_FROZEN":  4,
+	}
+)
+
+func (x ServiceLevel) Enum() *ServiceLevel {
+	p := new(ServiceLevel)
+	*p = x
+	return p
+}
+
+func (x ServiceLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_delivery_delivery_messages_proto_enumTypes[2].Descriptor()
+}
+
+func (ServiceLevel) Type() protoreflect.EnumType {
+	return &file_delivery_delivery_messages_proto_enumTypes[2]
+}
+
+func (x ServiceLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceLevel.Descriptor instead.
+func (ServiceLevel) EnumDescriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{2}
+}
+
 type ShipmentStatus int32
 
 const (
-	ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED     ShipmentStatus = 0
-	ShipmentStatus_SHIPMENT_STATUS_PREPARING       ShipmentStatus = 1
-	ShipmentStatus_SHIPMENT_STATUS_SHIPPED         ShipmentStatus = 2
-	ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT      ShipmentStatus = 3
-	ShipmentStatus_SHIPMENT_STATUS_DELIVERED       ShipmentStatus = 4
-	ShipmentStatus_SHIPMENT_STATUS_CANCELLED       ShipmentStatus = 5
-	ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY ShipmentStatus = 6
+	ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED      ShipmentStatus = 0
+	ShipmentStatus_SHIPMENT_STATUS_PREPARING        ShipmentStatus = 1
+	ShipmentStatus_SHIPMENT_STATUS_SHIPPED          ShipmentStatus = 2
+	ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT       ShipmentStatus = 3
+	ShipmentStatus_SHIPMENT_STATUS_DELIVERED        ShipmentStatus = 4
+	ShipmentStatus_SHIPMENT_STATUS_CANCELLED        ShipmentStatus = 5
+	ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY  ShipmentStatus = 6
+	ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP ShipmentStatus = 7
+	ShipmentStatus_SHIPMENT_STATUS_PICKED_UP        ShipmentStatus = 8
+	ShipmentStatus_SHIPMENT_STATUS_RETURNED         ShipmentStatus = 9
 )
 
 // Enum value maps for ShipmentStatus.
@@ -44,15 +210,21 @@ var (
 		4: "SHIPMENT_STATUS_DELIVERED",
 		5: "SHIPMENT_STATUS_CANCELLED",
 		6: "SHIPMENT_STATUS_FAILED_DELIVERY",
+		7: "SHIPMENT_STATUS_READY_FOR_PICKUP",
+		8: "SHIPMENT_STATUS_PICKED_UP",
+		9: "SHIPMENT_STATUS_RETURNED",
 	}
 	ShipmentStatus_value = map[string]int32{
-		"SHIPMENT_STATUS_UNSPECIFIED":     0,
-		"SHIPMENT_STATUS_PREPARING":       1,
-		"SHIPMENT_STATUS_SHIPPED":         2,
-		"SHIPMENT_STATUS_IN_TRANSIT":      3,
-		"SHIPMENT_STATUS_DELIVERED":       4,
-		"SHIPMENT_STATUS_CANCELLED":       5,
-		"SHIPMENT_STATUS_FAILED_DELIVERY": 6,
+		"SHIPMENT_STATUS_UNSPECIFIED":      0,
+		"SHIPMENT_STATUS_PREPARING":        1,
+		"SHIPMENT_STATUS_SHIPPED":          2,
+		"SHIPMENT_STATUS_IN_TRANSIT":       3,
+		"SHIPMENT_STATUS_DELIVERED":        4,
+		"SHIPMENT_STATUS_CANCELLED":        5,
+		"SHIPMENT_STATUS_FAILED_DELIVERY":  6,
+		"SHIPMENT_STATUS_READY_FOR_PICKUP": 7,
+		"SHIPMENT_STATUS_PICKED_UP":        8,
+		"SHIPMENT_STATUS_RETURNED":         9,
 	}
 )
 
@@ -67,11 +239,11 @@ func (x ShipmentStatus) String() string {
 }
 
 func (ShipmentStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_delivery_delivery_messages_proto_enumTypes[0].Descriptor()
+	return file_delivery_delivery_messages_proto_enumTypes[3].Descriptor()
 }
 
 func (ShipmentStatus) Type() protoreflect.EnumType {
-	return &file_delivery_delivery_messages_proto_enumTypes[0]
+	return &file_delivery_delivery_messages_proto_enumTypes[3]
 }
 
 func (x ShipmentStatus) Number() protoreflect.EnumNumber {
@@ -80,7 +252,56 @@ func (x ShipmentStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ShipmentStatus.Descriptor instead.
 func (ShipmentStatus) EnumDescriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{0}
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{3}
+}
+
+type ReservationStatus int32
+
+const (
+	ReservationStatus_RESERVATION_STATUS_UNSPECIFIED ReservationStatus = 0
+	ReservationStatus_RESERVATION_STATUS_HELD        ReservationStatus = 1
+	ReservationStatus_RESERVATION_STATUS_CONFIRMED   ReservationStatus = 2
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "RESERVATION_STATUS_UNSPECIFIED",
+		1: "RESERVATION_STATUS_HELD",
+		2: "RESERVATION_STATUS_CONFIRMED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"RESERVATION_STATUS_UNSPECIFIED": 0,
+		"RESERVATION_STATUS_HELD":        1,
+		"RESERVATION_STATUS_CONFIRMED":   2,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_delivery_delivery_messages_proto_enumTypes[4].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_delivery_delivery_messages_proto_enumTypes[4]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{4}
 }
 
 type DeliverySlot struct {
@@ -93,6 +314,8 @@ type DeliverySlot struct {
 	Reserved       int32                  `protobuf:"varint,6,opt,name=reserved,proto3" json:"reserved,omitempty"`
 	Available      int32                  `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
 	Date           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=date,proto3" json:"date,omitempty"`
+	TimeWindow     DeliveryTimeWindow     `protobuf:"varint,9,opt,name=time_window,json=timeWindow,proto3,enum=shinkansen.delivery.DeliveryTimeWindow" json:"time_window,omitempty"`
+	CutoffAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=cutoff_at,json=cutoffAt,proto3" json:"cutoff_at,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -183,31 +406,47 @@ func (x *DeliverySlot) GetDate() *timestamppb.Timestamp {
 	return nil
 }
 
-type DeliveryZone struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PostalCodes   []string               `protobuf:"bytes,3,rep,name=postal_codes,json=postalCodes,proto3" json:"postal_codes,omitempty"`
-	Prefectures   []string               `protobuf:"bytes,4,rep,name=prefectures,proto3" json:"prefectures,omitempty"`
-	DeliveryDays  int32                  `protobuf:"varint,5,opt,name=delivery_days,json=deliveryDays,proto3" json:"delivery_days,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *DeliverySlot) GetTimeWindow() DeliveryTimeWindow {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED
 }
 
-func (x *DeliveryZone) Reset() {
-	*x = DeliveryZone{}
+func (x *DeliverySlot) GetCutoffAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CutoffAt
+	}
+	return nil
+}
+
+type SlotTemplate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeliveryZoneId   string                 `protobuf:"bytes,2,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
+	Weekday          int32                  `protobuf:"varint,3,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	TimeWindow       DeliveryTimeWindow     `protobuf:"varint,4,opt,name=time_window,json=timeWindow,proto3,enum=shinkansen.delivery.DeliveryTimeWindow" json:"time_window,omitempty"`
+	Capacity         int32                  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	CutoffDaysBefore int32                  `protobuf:"varint,6,opt,name=cutoff_days_before,json=cutoffDaysBefore,proto3" json:"cutoff_days_before,omitempty"`
+	CutoffTime       string                 `protobuf:"bytes,7,opt,name=cutoff_time,json=cutoffTime,proto3" json:"cutoff_time,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SlotTemplate) Reset() {
+	*x = SlotTemplate{}
 	mi := &file_delivery_delivery_messages_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeliveryZone) String() string {
+func (x *SlotTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeliveryZone) ProtoMessage() {}
+func (*SlotTemplate) ProtoMessage() {}
 
-func (x *DeliveryZone) ProtoReflect() protoreflect.Message {
+func (x *SlotTemplate) ProtoReflect() protoreflect.Message {
 	mi := &file_delivery_delivery_messages_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -219,74 +458,84 @@ func (x *DeliveryZone) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeliveryZone.ProtoReflect.Descriptor instead.
-func (*DeliveryZone) Descriptor() ([]byte, []int) {
+// Deprecated: Use SlotTemplate.ProtoReflect.Descriptor instead.
+func (*SlotTemplate) Descriptor() ([]byte, []int) {
 	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *DeliveryZone) GetId() string {
+func (x *SlotTemplate) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *DeliveryZone) GetName() string {
+func (x *SlotTemplate) GetDeliveryZoneId() string {
 	if x != nil {
-		return x.Name
+		return x.DeliveryZoneId
 	}
 	return ""
 }
 
-func (x *DeliveryZone) GetPostalCodes() []string {
+func (x *SlotTemplate) GetWeekday() int32 {
 	if x != nil {
-		return x.PostalCodes
+		return x.Weekday
 	}
-	return nil
+	return 0
 }
 
-func (x *DeliveryZone) GetPrefectures() []string {
+func (x *SlotTemplate) GetTimeWindow() DeliveryTimeWindow {
 	if x != nil {
-		return x.Prefectures
+		return x.TimeWindow
 	}
-	return nil
+	return DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED
 }
 
-func (x *DeliveryZone) GetDeliveryDays() int32 {
+func (x *SlotTemplate) GetCapacity() int32 {
 	if x != nil {
-		return x.DeliveryDays
+		return x.Capacity
 	}
 	return 0
 }
 
-type Shipment struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	OrderId             string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	TrackingNumber      string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
-	Status              ShipmentStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=shinkansen.delivery.ShipmentStatus" json:"status,omitempty"`
-	EstimatedDeliveryAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=estimated_delivery_at,json=estimatedDeliveryAt,proto3" json:"estimated_delivery_at,omitempty"`
-	ActualDeliveryAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=actual_delivery_at,json=actualDeliveryAt,proto3" json:"actual_delivery_at,omitempty"`
-	Carrier             string                 `protobuf:"bytes,7,opt,name=carrier,proto3" json:"carrier,omitempty"`
-	TrackingEvents      []*TrackingEvent       `protobuf:"bytes,8,rep,name=tracking_events,json=trackingEvents,proto3" json:"tracking_events,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *SlotTemplate) GetCutoffDaysBefore() int32 {
+	if x != nil {
+		return x.CutoffDaysBefore
+	}
+	return 0
 }
 
-func (x *Shipment) Reset() {
-	*x = Shipment{}
+func (x *SlotTemplate) GetCutoffTime() string {
+	if x != nil {
+		return x.CutoffTime
+	}
+	return ""
+}
+
+type SlotBlackout struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeliveryZoneId string                 `protobuf:"bytes,2,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
+	Date           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SlotBlackout) Reset() {
+	*x = SlotBlackout{}
 	mi := &file_delivery_delivery_messages_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Shipment) String() string {
+func (x *SlotBlackout) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Shipment) ProtoMessage() {}
+func (*SlotBlackout) ProtoMessage() {}
 
-func (x *Shipment) ProtoReflect() protoreflect.Message {
+func (x *SlotBlackout) ProtoReflect() protoreflect.Message {
 	mi := &file_delivery_delivery_messages_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -298,93 +547,3781 @@ func (x *Shipment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
-func (*Shipment) Descriptor() ([]byte, []int) {
+// Deprecated: Use SlotBlackout.ProtoReflect.Descriptor instead.
+func (*SlotBlackout) Descriptor() ([]byte, []int) {
 	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Shipment) GetId() string {
+func (x *SlotBlackout) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Shipment) GetOrderId() string {
+func (x *SlotBlackout) GetDeliveryZoneId() string {
 	if x != nil {
-		return x.OrderId
+		return x.DeliveryZoneId
 	}
 	return ""
 }
 
-func (x *Shipment) GetTrackingNumber() string {
+func (x *SlotBlackout) GetDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TrackingNumber
+		return x.Date
+	}
+	return nil
+}
+
+func (x *SlotBlackout) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *Shipment) GetStatus() ShipmentStatus {
+type ClosureDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosureDay) Reset() {
+	*x = ClosureDay{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosureDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosureDay) ProtoMessage() {}
+
+func (x *ClosureDay) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[3]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *Shipment) GetEstimatedDeliveryAt() *timestamppb.Timestamp {
+// Deprecated: Use ClosureDay.ProtoReflect.Descriptor instead.
+func (*ClosureDay) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClosureDay) GetId() string {
 	if x != nil {
-		return x.EstimatedDeliveryAt
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *Shipment) GetActualDeliveryAt() *timestamppb.Timestamp {
+func (x *ClosureDay) GetDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ActualDeliveryAt
+		return x.Date
 	}
 	return nil
 }
 
-func (x *Shipment) GetCarrier() string {
+func (x *ClosureDay) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeliveryZone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PostalCodes   []string               `protobuf:"bytes,3,rep,name=postal_codes,json=postalCodes,proto3" json:"postal_codes,omitempty"`
+	Prefectures   []string               `protobuf:"bytes,4,rep,name=prefectures,proto3" json:"prefectures,omitempty"`
+	DeliveryDays  int32                  `protobuf:"varint,5,opt,name=delivery_days,json=deliveryDays,proto3" json:"delivery_days,omitempty"`
+	Undeliverable bool                   `protobuf:"varint,6,opt,name=undeliverable,proto3" json:"undeliverable,omitempty"`
+	RemoteIsland  bool                   `protobuf:"varint,7,opt,name=remote_island,json=remoteIsland,proto3" json:"remote_island,omitempty"`
+	Carrier       string                 `protobuf:"bytes,8,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryZone) Reset() {
+	*x = DeliveryZone{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryZone) ProtoMessage() {}
+
+func (x *DeliveryZone) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryZone.ProtoReflect.Descriptor instead.
+func (*DeliveryZone) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeliveryZone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeliveryZone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeliveryZone) GetPostalCodes() []string {
+	if x != nil {
+		return x.PostalCodes
+	}
+	return nil
+}
+
+func (x *DeliveryZone) GetPrefectures() []string {
+	if x != nil {
+		return x.Prefectures
+	}
+	return nil
+}
+
+func (x *DeliveryZone) GetDeliveryDays() int32 {
+	if x != nil {
+		return x.DeliveryDays
+	}
+	return 0
+}
+
+func (x *DeliveryZone) GetUndeliverable() bool {
+	if x != nil {
+		return x.Undeliverable
+	}
+	return false
+}
+
+func (x *DeliveryZone) GetRemoteIsland() bool {
+	if x != nil {
+		return x.RemoteIsland
+	}
+	return false
+}
+
+func (x *DeliveryZone) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+type Shipment struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId             string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TrackingNumber      string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Status              ShipmentStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=shinkansen.delivery.ShipmentStatus" json:"status,omitempty"`
+	EstimatedDeliveryAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=estimated_delivery_at,json=estimatedDeliveryAt,proto3" json:"estimated_delivery_at,omitempty"`
+	ActualDeliveryAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=actual_delivery_at,json=actualDeliveryAt,proto3" json:"actual_delivery_at,omitempty"`
+	Carrier             string                 `protobuf:"bytes,7,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingEvents      []*TrackingEvent       `protobuf:"bytes,8,rep,name=tracking_events,json=trackingEvents,proto3" json:"tracking_events,omitempty"`
+	CodAmount           *shared.Money          `protobuf:"bytes,9,opt,name=cod_amount,json=codAmount,proto3" json:"cod_amount,omitempty"`
+	CollectedAmount     *shared.Money          `protobuf:"bytes,10,opt,name=collected_amount,json=collectedAmount,proto3" json:"collected_amount,omitempty"`
+	ServiceLevel        ServiceLevel           `protobuf:"varint,11,opt,name=service_level,json=serviceLevel,proto3,enum=shinkansen.delivery.ServiceLevel" json:"service_level,omitempty"`
+	Recipient           *ShipmentAddress       `protobuf:"bytes,12,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	DispatchedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=dispatched_at,json=dispatchedAt,proto3" json:"dispatched_at,omitempty"`
+	PickupPointId       string                 `protobuf:"bytes,14,opt,name=pickup_point_id,json=pickupPointId,proto3" json:"pickup_point_id,omitempty"`
+	PickupCode          string                 `protobuf:"bytes,15,opt,name=pickup_code,json=pickupCode,proto3" json:"pickup_code,omitempty"`
+	PickupDeadlineAt    *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=pickup_deadline_at,json=pickupDeadlineAt,proto3" json:"pickup_deadline_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() ShipmentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Shipment) GetEstimatedDeliveryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedDeliveryAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetActualDeliveryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActualDeliveryAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingEvents() []*TrackingEvent {
+	if x != nil {
+		return x.TrackingEvents
+	}
+	return nil
+}
+
+func (x *Shipment) GetCodAmount() *shared.Money {
+	if x != nil {
+		return x.CodAmount
+	}
+	return nil
+}
+
+func (x *Shipment) GetCollectedAmount() *shared.Money {
+	if x != nil {
+		return x.CollectedAmount
+	}
+	return nil
+}
+
+func (x *Shipment) GetServiceLevel() ServiceLevel {
+	if x != nil {
+		return x.ServiceLevel
+	}
+	return ServiceLevel_SERVICE_LEVEL_UNSPECIFIED
+}
+
+func (x *Shipment) GetRecipient() *ShipmentAddress {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *Shipment) GetDispatchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DispatchedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetPickupPointId() string {
+	if x != nil {
+		return x.PickupPointId
+	}
+	return ""
+}
+
+func (x *Shipment) GetPickupCode() string {
+	if x != nil {
+		return x.PickupCode
+	}
+	return ""
+}
+
+func (x *Shipment) GetPickupDeadlineAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PickupDeadlineAt
+	}
+	return nil
+}
+
+type ShipmentAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,3,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Prefecture    string                 `protobuf:"bytes,4,opt,name=prefecture,proto3" json:"prefecture,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	AddressLine1  string                 `protobuf:"bytes,6,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	AddressLine2  string                 `protobuf:"bytes,7,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentAddress) Reset() {
+	*x = ShipmentAddress{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentAddress) ProtoMessage() {}
+
+func (x *ShipmentAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentAddress.ProtoReflect.Descriptor instead.
+func (*ShipmentAddress) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ShipmentAddress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShipmentAddress) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *ShipmentAddress) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ShipmentAddress) GetPrefecture() string {
+	if x != nil {
+		return x.Prefecture
+	}
+	return ""
+}
+
+func (x *ShipmentAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ShipmentAddress) GetAddressLine1() string {
+	if x != nil {
+		return x.AddressLine1
+	}
+	return ""
+}
+
+func (x *ShipmentAddress) GetAddressLine2() string {
+	if x != nil {
+		return x.AddressLine2
+	}
+	return ""
+}
+
+type PickupPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type          PickupPointType        `protobuf:"varint,4,opt,name=type,proto3,enum=shinkansen.delivery.PickupPointType" json:"type,omitempty"`
+	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Prefecture    string                 `protobuf:"bytes,7,opt,name=prefecture,proto3" json:"prefecture,omitempty"`
+	City          string                 `protobuf:"bytes,8,opt,name=city,proto3" json:"city,omitempty"`
+	AddressLine1  string                 `protobuf:"bytes,9,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	AddressLine2  string                 `protobuf:"bytes,10,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
+	OpeningHours  string                 `protobuf:"bytes,11,opt,name=opening_hours,json=openingHours,proto3" json:"opening_hours,omitempty"`
+	HoldDays      int32                  `protobuf:"varint,12,opt,name=hold_days,json=holdDays,proto3" json:"hold_days,omitempty"`
+	Active        bool                   `protobuf:"varint,13,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupPoint) Reset() {
+	*x = PickupPoint{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupPoint) ProtoMessage() {}
+
+func (x *PickupPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupPoint.ProtoReflect.Descriptor instead.
+func (*PickupPoint) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PickupPoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetType() PickupPointType {
+	if x != nil {
+		return x.Type
+	}
+	return PickupPointType_PICKUP_POINT_TYPE_UNSPECIFIED
+}
+
+func (x *PickupPoint) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetPrefecture() string {
+	if x != nil {
+		return x.Prefecture
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetAddressLine1() string {
+	if x != nil {
+		return x.AddressLine1
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetAddressLine2() string {
+	if x != nil {
+		return x.AddressLine2
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetOpeningHours() string {
+	if x != nil {
+		return x.OpeningHours
+	}
+	return ""
+}
+
+func (x *PickupPoint) GetHoldDays() int32 {
+	if x != nil {
+		return x.HoldDays
+	}
+	return 0
+}
+
+func (x *PickupPoint) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type TrackingEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackingEvent) Reset() {
+	*x = TrackingEvent{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackingEvent) ProtoMessage() {}
+
+func (x *TrackingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackingEvent.ProtoReflect.Descriptor instead.
+func (*TrackingEvent) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TrackingEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *TrackingEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TrackingEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetDeliverySlotsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryZoneId string                 `protobuf:"bytes,1,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
+	Date           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDeliverySlotsRequest) Reset() {
+	*x = GetDeliverySlotsRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliverySlotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliverySlotsRequest) ProtoMessage() {}
+
+func (x *GetDeliverySlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliverySlotsRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliverySlotsRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetDeliverySlotsRequest) GetDeliveryZoneId() string {
+	if x != nil {
+		return x.DeliveryZoneId
+	}
+	return ""
+}
+
+func (x *GetDeliverySlotsRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+type GetDeliverySlotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slots         []*DeliverySlot        `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliverySlotsResponse) Reset() {
+	*x = GetDeliverySlotsResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliverySlotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliverySlotsResponse) ProtoMessage() {}
+
+func (x *GetDeliverySlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliverySlotsResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliverySlotsResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDeliverySlotsResponse) GetSlots() []*DeliverySlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type ReserveDeliverySlotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlotId        string                 `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveDeliverySlotRequest) Reset() {
+	*x = ReserveDeliverySlotRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveDeliverySlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveDeliverySlotRequest) ProtoMessage() {}
+
+func (x *ReserveDeliverySlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveDeliverySlotRequest.ProtoReflect.Descriptor instead.
+func (*ReserveDeliverySlotRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReserveDeliverySlotRequest) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *ReserveDeliverySlotRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ReserveDeliverySlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ReservedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"`
+	Status        ReservationStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=shinkansen.delivery.ReservationStatus" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveDeliverySlotResponse) Reset() {
+	*x = ReserveDeliverySlotResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveDeliverySlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveDeliverySlotResponse) ProtoMessage() {}
+
+func (x *ReserveDeliverySlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveDeliverySlotResponse.ProtoReflect.Descriptor instead.
+func (*ReserveDeliverySlotResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReserveDeliverySlotResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveDeliverySlotResponse) GetReservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReservedAt
+	}
+	return nil
+}
+
+func (x *ReserveDeliverySlotResponse) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_STATUS_UNSPECIFIED
+}
+
+func (x *ReserveDeliverySlotResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ConfirmReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationRequest) Reset() {
+	*x = ConfirmReservationRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationRequest) ProtoMessage() {}
+
+func (x *ConfirmReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConfirmReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ConfirmReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	ConfirmedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationResponse) Reset() {
+	*x = ConfirmReservationResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationResponse) ProtoMessage() {}
+
+func (x *ConfirmReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConfirmReservationResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ConfirmReservationResponse) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *ConfirmReservationResponse) GetConfirmedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return nil
+}
+
+type ReleaseDeliverySlotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDeliverySlotRequest) Reset() {
+	*x = ReleaseDeliverySlotRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDeliverySlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDeliverySlotRequest) ProtoMessage() {}
+
+func (x *ReleaseDeliverySlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDeliverySlotRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseDeliverySlotRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReleaseDeliverySlotRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ReleaseDeliverySlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Released      bool                   `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDeliverySlotResponse) Reset() {
+	*x = ReleaseDeliverySlotResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDeliverySlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDeliverySlotResponse) ProtoMessage() {}
+
+func (x *ReleaseDeliverySlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDeliverySlotResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseDeliverySlotResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReleaseDeliverySlotResponse) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+type GetShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentRequest) Reset() {
+	*x = GetShipmentRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentRequest) ProtoMessage() {}
+
+func (x *GetShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentRequest.ProtoReflect.Descriptor instead.
+func (*GetShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetShipmentRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *GetShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentResponse) Reset() {
+	*x = GetShipmentResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentResponse) ProtoMessage() {}
+
+func (x *GetShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentResponse.ProtoReflect.Descriptor instead.
+func (*GetShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type UpdateShipmentStatusRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId      string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Status          ShipmentStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=shinkansen.delivery.ShipmentStatus" json:"status,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CollectedAmount *shared.Money          `protobuf:"bytes,4,opt,name=collected_amount,json=collectedAmount,proto3" json:"collected_amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentStatusRequest) Reset() {
+	*x = UpdateShipmentStatusRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentStatusRequest) ProtoMessage() {}
+
+func (x *UpdateShipmentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateShipmentStatusRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetStatus() ShipmentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateShipmentStatusRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetCollectedAmount() *shared.Money {
+	if x != nil {
+		return x.CollectedAmount
+	}
+	return nil
+}
+
+type AddTrackingEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTrackingEventRequest) Reset() {
+	*x = AddTrackingEventRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTrackingEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTrackingEventRequest) ProtoMessage() {}
+
+func (x *AddTrackingEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTrackingEventRequest.ProtoReflect.Descriptor instead.
+func (*AddTrackingEventRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AddTrackingEventRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *AddTrackingEventRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AddTrackingEventRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *AddTrackingEventRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AddTrackingEventRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type AddTrackingEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *TrackingEvent         `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTrackingEventResponse) Reset() {
+	*x = AddTrackingEventResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTrackingEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTrackingEventResponse) ProtoMessage() {}
+
+func (x *AddTrackingEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTrackingEventResponse.ProtoReflect.Descriptor instead.
+func (*AddTrackingEventResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AddTrackingEventResponse) GetEvent() *TrackingEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type ListTrackingEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrackingEventsRequest) Reset() {
+	*x = ListTrackingEventsRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrackingEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackingEventsRequest) ProtoMessage() {}
+
+func (x *ListTrackingEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackingEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListTrackingEventsRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTrackingEventsRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *ListTrackingEventsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListTrackingEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*TrackingEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrackingEventsResponse) Reset() {
+	*x = ListTrackingEventsResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrackingEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackingEventsResponse) ProtoMessage() {}
+
+func (x *ListTrackingEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackingEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListTrackingEventsResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTrackingEventsResponse) GetEvents() []*TrackingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type DispatchShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ServiceLevel  ServiceLevel           `protobuf:"varint,2,opt,name=service_level,json=serviceLevel,proto3,enum=shinkansen.delivery.ServiceLevel" json:"service_level,omitempty"`
+	Recipient     *ShipmentAddress       `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Carrier       string                 `protobuf:"bytes,4,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	PickupPointId string                 `protobuf:"bytes,5,opt,name=pickup_point_id,json=pickupPointId,proto3" json:"pickup_point_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchShipmentRequest) Reset() {
+	*x = DispatchShipmentRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchShipmentRequest) ProtoMessage() {}
+
+func (x *DispatchShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchShipmentRequest.ProtoReflect.Descriptor instead.
+func (*DispatchShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DispatchShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *DispatchShipmentRequest) GetServiceLevel() ServiceLevel {
+	if x != nil {
+		return x.ServiceLevel
+	}
+	return ServiceLevel_SERVICE_LEVEL_UNSPECIFIED
+}
+
+func (x *DispatchShipmentRequest) GetRecipient() *ShipmentAddress {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *DispatchShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *DispatchShipmentRequest) GetPickupPointId() string {
+	if x != nil {
+		return x.PickupPointId
+	}
+	return ""
+}
+
+type DispatchShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchShipmentResponse) Reset() {
+	*x = DispatchShipmentResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchShipmentResponse) ProtoMessage() {}
+
+func (x *DispatchShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchShipmentResponse.ProtoReflect.Descriptor instead.
+func (*DispatchShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DispatchShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type CancelShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelShipmentRequest) Reset() {
+	*x = CancelShipmentRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelShipmentRequest) ProtoMessage() {}
+
+func (x *CancelShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CancelShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CancelShipmentRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+func (x *CancelShipmentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelShipmentResponse) Reset() {
+	*x = CancelShipmentResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelShipmentResponse) ProtoMessage() {}
+
+func (x *CancelShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelShipmentResponse.ProtoReflect.Descriptor instead.
+func (*CancelShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CancelShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type ValidateTrackingNumberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Carrier        string                 `protobuf:"bytes,1,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,2,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ValidateTrackingNumberRequest) Reset() {
+	*x = ValidateTrackingNumberRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTrackingNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTrackingNumberRequest) ProtoMessage() {}
+
+func (x *ValidateTrackingNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTrackingNumberRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTrackingNumberRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ValidateTrackingNumberRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *ValidateTrackingNumberRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+type ValidateTrackingNumberResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Valid          bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,2,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ValidateTrackingNumberResponse) Reset() {
+	*x = ValidateTrackingNumberResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTrackingNumberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTrackingNumberResponse) ProtoMessage() {}
+
+func (x *ValidateTrackingNumberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTrackingNumberResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTrackingNumberResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ValidateTrackingNumberResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateTrackingNumberResponse) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *ValidateTrackingNumberResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetShippingLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippingLabelRequest) Reset() {
+	*x = GetShippingLabelRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingLabelRequest) ProtoMessage() {}
+
+func (x *GetShippingLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingLabelRequest.ProtoReflect.Descriptor instead.
+func (*GetShippingLabelRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetShippingLabelRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+type GetShippingLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pdf           []byte                 `protobuf:"bytes,1,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippingLabelResponse) Reset() {
+	*x = GetShippingLabelResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingLabelResponse) ProtoMessage() {}
+
+func (x *GetShippingLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingLabelResponse.ProtoReflect.Descriptor instead.
+func (*GetShippingLabelResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetShippingLabelResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *GetShippingLabelResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type GetShippingLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Carrier       string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippingLabelsRequest) Reset() {
+	*x = GetShippingLabelsRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingLabelsRequest) ProtoMessage() {}
+
+func (x *GetShippingLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingLabelsRequest.ProtoReflect.Descriptor instead.
+func (*GetShippingLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetShippingLabelsRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetShippingLabelsRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+type GetShippingLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pdf           []byte                 `protobuf:"bytes,1,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	LabelCount    int32                  `protobuf:"varint,3,opt,name=label_count,json=labelCount,proto3" json:"label_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippingLabelsResponse) Reset() {
+	*x = GetShippingLabelsResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingLabelsResponse) ProtoMessage() {}
+
+func (x *GetShippingLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingLabelsResponse.ProtoReflect.Descriptor instead.
+func (*GetShippingLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetShippingLabelsResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *GetShippingLabelsResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GetShippingLabelsResponse) GetLabelCount() int32 {
+	if x != nil {
+		return x.LabelCount
+	}
+	return 0
+}
+
+type Parcel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SizeClass     int32                  `protobuf:"varint,1,opt,name=size_class,json=sizeClass,proto3" json:"size_class,omitempty"`
+	LengthCm      int32                  `protobuf:"varint,2,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm       int32                  `protobuf:"varint,3,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm      int32                  `protobuf:"varint,4,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	WeightGrams   int32                  `protobuf:"varint,5,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parcel) Reset() {
+	*x = Parcel{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parcel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parcel) ProtoMessage() {}
+
+func (x *Parcel) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parcel.ProtoReflect.Descriptor instead.
+func (*Parcel) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Parcel) GetSizeClass() int32 {
+	if x != nil {
+		return x.SizeClass
+	}
+	return 0
+}
+
+func (x *Parcel) GetLengthCm() int32 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *Parcel) GetWidthCm() int32 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *Parcel) GetHeightCm() int32 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
+func (x *Parcel) GetWeightGrams() int32 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+type ParcelRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SizeClass     int32                  `protobuf:"varint,1,opt,name=size_class,json=sizeClass,proto3" json:"size_class,omitempty"`
+	BaseAmount    *shared.Money          `protobuf:"bytes,2,opt,name=base_amount,json=baseAmount,proto3" json:"base_amount,omitempty"`
+	CoolSurcharge *shared.Money          `protobuf:"bytes,3,opt,name=cool_surcharge,json=coolSurcharge,proto3" json:"cool_surcharge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParcelRate) Reset() {
+	*x = ParcelRate{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParcelRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParcelRate) ProtoMessage() {}
+
+func (x *ParcelRate) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParcelRate.ProtoReflect.Descriptor instead.
+func (*ParcelRate) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ParcelRate) GetSizeClass() int32 {
+	if x != nil {
+		return x.SizeClass
+	}
+	return 0
+}
+
+func (x *ParcelRate) GetBaseAmount() *shared.Money {
+	if x != nil {
+		return x.BaseAmount
+	}
+	return nil
+}
+
+func (x *ParcelRate) GetCoolSurcharge() *shared.Money {
+	if x != nil {
+		return x.CoolSurcharge
+	}
+	return nil
+}
+
+type ShippingQuote struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Parcels               []*ParcelRate          `protobuf:"bytes,1,rep,name=parcels,proto3" json:"parcels,omitempty"`
+	BaseAmount            *shared.Money          `protobuf:"bytes,2,opt,name=base_amount,json=baseAmount,proto3" json:"base_amount,omitempty"`
+	CoolSurcharge         *shared.Money          `protobuf:"bytes,3,opt,name=cool_surcharge,json=coolSurcharge,proto3" json:"cool_surcharge,omitempty"`
+	ExpressSurcharge      *shared.Money          `protobuf:"bytes,4,opt,name=express_surcharge,json=expressSurcharge,proto3" json:"express_surcharge,omitempty"`
+	TimeWindowFee         *shared.Money          `protobuf:"bytes,5,opt,name=time_window_fee,json=timeWindowFee,proto3" json:"time_window_fee,omitempty"`
+	RemoteIslandFee       *shared.Money          `protobuf:"bytes,6,opt,name=remote_island_fee,json=remoteIslandFee,proto3" json:"remote_island_fee,omitempty"`
+	Discount              *shared.Money          `protobuf:"bytes,7,opt,name=discount,proto3" json:"discount,omitempty"`
+	Total                 *shared.Money          `protobuf:"bytes,8,opt,name=total,proto3" json:"total,omitempty"`
+	FreeShipping          bool                   `protobuf:"varint,9,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	FreeShippingRemaining *shared.Money          `protobuf:"bytes,10,opt,name=free_shipping_remaining,json=freeShippingRemaining,proto3" json:"free_shipping_remaining,omitempty"`
+	OriginPrefecture      string                 `protobuf:"bytes,11,opt,name=origin_prefecture,json=originPrefecture,proto3" json:"origin_prefecture,omitempty"`
+	DestinationPrefecture string                 `protobuf:"bytes,12,opt,name=destination_prefecture,json=destinationPrefecture,proto3" json:"destination_prefecture,omitempty"`
+	EstimatedDeliveryAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=estimated_delivery_at,json=estimatedDeliveryAt,proto3" json:"estimated_delivery_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ShippingQuote) Reset() {
+	*x = ShippingQuote{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingQuote) ProtoMessage() {}
+
+func (x *ShippingQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingQuote.ProtoReflect.Descriptor instead.
+func (*ShippingQuote) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ShippingQuote) GetParcels() []*ParcelRate {
+	if x != nil {
+		return x.Parcels
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetBaseAmount() *shared.Money {
+	if x != nil {
+		return x.BaseAmount
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetCoolSurcharge() *shared.Money {
+	if x != nil {
+		return x.CoolSurcharge
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetExpressSurcharge() *shared.Money {
+	if x != nil {
+		return x.ExpressSurcharge
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetTimeWindowFee() *shared.Money {
+	if x != nil {
+		return x.TimeWindowFee
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetRemoteIslandFee() *shared.Money {
+	if x != nil {
+		return x.RemoteIslandFee
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetDiscount() *shared.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetTotal() *shared.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *ShippingQuote) GetFreeShippingRemaining() *shared.Money {
+	if x != nil {
+		return x.FreeShippingRemaining
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetOriginPrefecture() string {
+	if x != nil {
+		return x.OriginPrefecture
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetDestinationPrefecture() string {
+	if x != nil {
+		return x.DestinationPrefecture
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetEstimatedDeliveryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedDeliveryAt
+	}
+	return nil
+}
+
+type QuoteShippingRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode       string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Prefecture       string                 `protobuf:"bytes,2,opt,name=prefecture,proto3" json:"prefecture,omitempty"`
+	OriginPrefecture string                 `protobuf:"bytes,3,opt,name=origin_prefecture,json=originPrefecture,proto3" json:"origin_prefecture,omitempty"`
+	Parcels          []*Parcel              `protobuf:"bytes,4,rep,name=parcels,proto3" json:"parcels,omitempty"`
+	ServiceLevel     ServiceLevel           `protobuf:"varint,5,opt,name=service_level,json=serviceLevel,proto3,enum=shinkansen.delivery.ServiceLevel" json:"service_level,omitempty"`
+	TimeWindow       DeliveryTimeWindow     `protobuf:"varint,6,opt,name=time_window,json=timeWindow,proto3,enum=shinkansen.delivery.DeliveryTimeWindow" json:"time_window,omitempty"`
+	DeliverySlotId   string                 `protobuf:"bytes,7,opt,name=delivery_slot_id,json=deliverySlotId,proto3" json:"delivery_slot_id,omitempty"`
+	OrderSubtotal    *shared.Money          `protobuf:"bytes,8,opt,name=order_subtotal,json=orderSubtotal,proto3" json:"order_subtotal,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *QuoteShippingRequest) Reset() {
+	*x = QuoteShippingRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingRequest) ProtoMessage() {}
+
+func (x *QuoteShippingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingRequest.ProtoReflect.Descriptor instead.
+func (*QuoteShippingRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *QuoteShippingRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *QuoteShippingRequest) GetPrefecture() string {
+	if x != nil {
+		return x.Prefecture
+	}
+	return ""
+}
+
+func (x *QuoteShippingRequest) GetOriginPrefecture() string {
+	if x != nil {
+		return x.OriginPrefecture
+	}
+	return ""
+}
+
+func (x *QuoteShippingRequest) GetParcels() []*Parcel {
+	if x != nil {
+		return x.Parcels
+	}
+	return nil
+}
+
+func (x *QuoteShippingRequest) GetServiceLevel() ServiceLevel {
+	if x != nil {
+		return x.ServiceLevel
+	}
+	return ServiceLevel_SERVICE_LEVEL_UNSPECIFIED
+}
+
+func (x *QuoteShippingRequest) GetTimeWindow() DeliveryTimeWindow {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED
+}
+
+func (x *QuoteShippingRequest) GetDeliverySlotId() string {
+	if x != nil {
+		return x.DeliverySlotId
+	}
+	return ""
+}
+
+func (x *QuoteShippingRequest) GetOrderSubtotal() *shared.Money {
+	if x != nil {
+		return x.OrderSubtotal
+	}
+	return nil
+}
+
+type QuoteShippingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *ShippingQuote         `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteShippingResponse) Reset() {
+	*x = QuoteShippingResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingResponse) ProtoMessage() {}
+
+func (x *QuoteShippingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingResponse.ProtoReflect.Descriptor instead.
+func (*QuoteShippingResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *QuoteShippingResponse) GetQuote() *ShippingQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+type RequestCashOnDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount        *shared.Money          `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestCashOnDeliveryRequest) Reset() {
+	*x = RequestCashOnDeliveryRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestCashOnDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCashOnDeliveryRequest) ProtoMessage() {}
+
+func (x *RequestCashOnDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCashOnDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*RequestCashOnDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RequestCashOnDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RequestCashOnDeliveryRequest) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type RequestCashOnDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestCashOnDeliveryResponse) Reset() {
+	*x = RequestCashOnDeliveryResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestCashOnDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCashOnDeliveryResponse) ProtoMessage() {}
+
+func (x *RequestCashOnDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCashOnDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*RequestCashOnDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RequestCashOnDeliveryResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type ResolveDeliveryZoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode    string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Prefecture    string                 `protobuf:"bytes,2,opt,name=prefecture,proto3" json:"prefecture,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDeliveryZoneRequest) Reset() {
+	*x = ResolveDeliveryZoneRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDeliveryZoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDeliveryZoneRequest) ProtoMessage() {}
+
+func (x *ResolveDeliveryZoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDeliveryZoneRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDeliveryZoneRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ResolveDeliveryZoneRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ResolveDeliveryZoneRequest) GetPrefecture() string {
+	if x != nil {
+		return x.Prefecture
+	}
+	return ""
+}
+
+type ResolveDeliveryZoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zone          *DeliveryZone          `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,2,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Prefecture    string                 `protobuf:"bytes,3,opt,name=prefecture,proto3" json:"prefecture,omitempty"`
+	Deliverable   bool                   `protobuf:"varint,4,opt,name=deliverable,proto3" json:"deliverable,omitempty"`
+	RemoteIsland  bool                   `protobuf:"varint,5,opt,name=remote_island,json=remoteIsland,proto3" json:"remote_island,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDeliveryZoneResponse) Reset() {
+	*x = ResolveDeliveryZoneResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDeliveryZoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDeliveryZoneResponse) ProtoMessage() {}
+
+func (x *ResolveDeliveryZoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDeliveryZoneResponse.ProtoReflect.Descriptor instead.
+func (*ResolveDeliveryZoneResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ResolveDeliveryZoneResponse) GetZone() *DeliveryZone {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+func (x *ResolveDeliveryZoneResponse) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ResolveDeliveryZoneResponse) GetPrefecture() string {
+	if x != nil {
+		return x.Prefecture
+	}
+	return ""
+}
+
+func (x *ResolveDeliveryZoneResponse) GetDeliverable() bool {
+	if x != nil {
+		return x.Deliverable
+	}
+	return false
+}
+
+func (x *ResolveDeliveryZoneResponse) GetRemoteIsland() bool {
+	if x != nil {
+		return x.RemoteIsland
+	}
+	return false
+}
+
+type GetDeliverySlotsForAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode    string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Prefecture    string                 `protobuf:"bytes,2,opt,name=prefecture,proto3" json:"prefecture,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliverySlotsForAddressRequest) Reset() {
+	*x = GetDeliverySlotsForAddressRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliverySlotsForAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliverySlotsForAddressRequest) ProtoMessage() {}
+
+func (x *GetDeliverySlotsForAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliverySlotsForAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliverySlotsForAddressRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetDeliverySlotsForAddressRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *GetDeliverySlotsForAddressRequest) GetPrefecture() string {
+	if x != nil {
+		return x.Prefecture
+	}
+	return ""
+}
+
+func (x *GetDeliverySlotsForAddressRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+type GetDeliverySlotsForAddressResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Zone          *ResolveDeliveryZoneResponse `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	Slots         []*DeliverySlot              `protobuf:"bytes,2,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliverySlotsForAddressResponse) Reset() {
+	*x = GetDeliverySlotsForAddressResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliverySlotsForAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliverySlotsForAddressResponse) ProtoMessage() {}
+
+func (x *GetDeliverySlotsForAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliverySlotsForAddressResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliverySlotsForAddressResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetDeliverySlotsForAddressResponse) GetZone() *ResolveDeliveryZoneResponse {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+func (x *GetDeliverySlotsForAddressResponse) GetSlots() []*DeliverySlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type ListSlotTemplatesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryZoneId string                 `protobuf:"bytes,1,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSlotTemplatesRequest) Reset() {
+	*x = ListSlotTemplatesRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSlotTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSlotTemplatesRequest) ProtoMessage() {}
+
+func (x *ListSlotTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSlotTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListSlotTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListSlotTemplatesRequest) GetDeliveryZoneId() string {
+	if x != nil {
+		return x.DeliveryZoneId
+	}
+	return ""
+}
+
+type ListSlotTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*SlotTemplate        `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSlotTemplatesResponse) Reset() {
+	*x = ListSlotTemplatesResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSlotTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSlotTemplatesResponse) ProtoMessage() {}
+
+func (x *ListSlotTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSlotTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListSlotTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListSlotTemplatesResponse) GetTemplates() []*SlotTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type CreateSlotTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SlotTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSlotTemplateRequest) Reset() {
+	*x = CreateSlotTemplateRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSlotTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSlotTemplateRequest) ProtoMessage() {}
+
+func (x *CreateSlotTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSlotTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateSlotTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateSlotTemplateRequest) GetTemplate() *SlotTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type CreateSlotTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SlotTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	SlotsCreated  int32                  `protobuf:"varint,2,opt,name=slots_created,json=slotsCreated,proto3" json:"slots_created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSlotTemplateResponse) Reset() {
+	*x = CreateSlotTemplateResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSlotTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSlotTemplateResponse) ProtoMessage() {}
+
+func (x *CreateSlotTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSlotTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateSlotTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateSlotTemplateResponse) GetTemplate() *SlotTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *CreateSlotTemplateResponse) GetSlotsCreated() int32 {
+	if x != nil {
+		return x.SlotsCreated
+	}
+	return 0
+}
+
+type UpdateSlotTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SlotTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSlotTemplateRequest) Reset() {
+	*x = UpdateSlotTemplateRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSlotTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSlotTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateSlotTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSlotTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSlotTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UpdateSlotTemplateRequest) GetTemplate() *SlotTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type UpdateSlotTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SlotTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	SlotsRemoved  int32                  `protobuf:"varint,2,opt,name=slots_removed,json=slotsRemoved,proto3" json:"slots_removed,omitempty"`
+	SlotsCreated  int32                  `protobuf:"varint,3,opt,name=slots_created,json=slotsCreated,proto3" json:"slots_created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSlotTemplateResponse) Reset() {
+	*x = UpdateSlotTemplateResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSlotTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSlotTemplateResponse) ProtoMessage() {}
+
+func (x *UpdateSlotTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSlotTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSlotTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UpdateSlotTemplateResponse) GetTemplate() *SlotTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *UpdateSlotTemplateResponse) GetSlotsRemoved() int32 {
+	if x != nil {
+		return x.SlotsRemoved
+	}
+	return 0
+}
+
+func (x *UpdateSlotTemplateResponse) GetSlotsCreated() int32 {
+	if x != nil {
+		return x.SlotsCreated
+	}
+	return 0
+}
+
+type DeleteSlotTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSlotTemplateRequest) Reset() {
+	*x = DeleteSlotTemplateRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSlotTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSlotTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteSlotTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSlotTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSlotTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteSlotTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSlotTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlotsRemoved  int32                  `protobuf:"varint,1,opt,name=slots_removed,json=slotsRemoved,proto3" json:"slots_removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSlotTemplateResponse) Reset() {
+	*x = DeleteSlotTemplateResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSlotTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSlotTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteSlotTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSlotTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSlotTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteSlotTemplateResponse) GetSlotsRemoved() int32 {
+	if x != nil {
+		return x.SlotsRemoved
+	}
+	return 0
+}
+
+type ListSlotBlackoutsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryZoneId string                 `protobuf:"bytes,1,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSlotBlackoutsRequest) Reset() {
+	*x = ListSlotBlackoutsRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSlotBlackoutsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSlotBlackoutsRequest) ProtoMessage() {}
+
+func (x *ListSlotBlackoutsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSlotBlackoutsRequest.ProtoReflect.Descriptor instead.
+func (*ListSlotBlackoutsRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListSlotBlackoutsRequest) GetDeliveryZoneId() string {
+	if x != nil {
+		return x.DeliveryZoneId
+	}
+	return ""
+}
+
+type ListSlotBlackoutsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blackouts     []*SlotBlackout        `protobuf:"bytes,1,rep,name=blackouts,proto3" json:"blackouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSlotBlackoutsResponse) Reset() {
+	*x = ListSlotBlackoutsResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSlotBlackoutsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSlotBlackoutsResponse) ProtoMessage() {}
+
+func (x *ListSlotBlackoutsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSlotBlackoutsResponse.ProtoReflect.Descriptor instead.
+func (*ListSlotBlackoutsResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListSlotBlackoutsResponse) GetBlackouts() []*SlotBlackout {
+	if x != nil {
+		return x.Blackouts
+	}
+	return nil
+}
+
+type CreateSlotBlackoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blackout      *SlotBlackout          `protobuf:"bytes,1,opt,name=blackout,proto3" json:"blackout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSlotBlackoutRequest) Reset() {
+	*x = CreateSlotBlackoutRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSlotBlackoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSlotBlackoutRequest) ProtoMessage() {}
+
+func (x *CreateSlotBlackoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSlotBlackoutRequest.ProtoReflect.Descriptor instead.
+func (*CreateSlotBlackoutRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CreateSlotBlackoutRequest) GetBlackout() *SlotBlackout {
+	if x != nil {
+		return x.Blackout
+	}
+	return nil
+}
+
+type CreateSlotBlackoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blackout      *SlotBlackout          `protobuf:"bytes,1,opt,name=blackout,proto3" json:"blackout,omitempty"`
+	SlotsRemoved  int32                  `protobuf:"varint,2,opt,name=slots_removed,json=slotsRemoved,proto3" json:"slots_removed,omitempty"`
+	ReservedSlots int32                  `protobuf:"varint,3,opt,name=reserved_slots,json=reservedSlots,proto3" json:"reserved_slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSlotBlackoutResponse) Reset() {
+	*x = CreateSlotBlackoutResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSlotBlackoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSlotBlackoutResponse) ProtoMessage() {}
+
+func (x *CreateSlotBlackoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSlotBlackoutResponse.ProtoReflect.Descriptor instead.
+func (*CreateSlotBlackoutResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CreateSlotBlackoutResponse) GetBlackout() *SlotBlackout {
+	if x != nil {
+		return x.Blackout
+	}
+	return nil
+}
+
+func (x *CreateSlotBlackoutResponse) GetSlotsRemoved() int32 {
+	if x != nil {
+		return x.SlotsRemoved
+	}
+	return 0
+}
+
+func (x *CreateSlotBlackoutResponse) GetReservedSlots() int32 {
+	if x != nil {
+		return x.ReservedSlots
+	}
+	return 0
+}
+
+type DeleteSlotBlackoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSlotBlackoutRequest) Reset() {
+	*x = DeleteSlotBlackoutRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSlotBlackoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSlotBlackoutRequest) ProtoMessage() {}
+
+func (x *DeleteSlotBlackoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSlotBlackoutRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSlotBlackoutRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeleteSlotBlackoutRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSlotBlackoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlotsCreated  int32                  `protobuf:"varint,1,opt,name=slots_created,json=slotsCreated,proto3" json:"slots_created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSlotBlackoutResponse) Reset() {
+	*x = DeleteSlotBlackoutResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSlotBlackoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSlotBlackoutResponse) ProtoMessage() {}
+
+func (x *DeleteSlotBlackoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSlotBlackoutResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSlotBlackoutResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DeleteSlotBlackoutResponse) GetSlotsCreated() int32 {
+	if x != nil {
+		return x.SlotsCreated
+	}
+	return 0
+}
+
+type ListClosureDaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClosureDaysRequest) Reset() {
+	*x = ListClosureDaysRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClosureDaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClosureDaysRequest) ProtoMessage() {}
+
+func (x *ListClosureDaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClosureDaysRequest.ProtoReflect.Descriptor instead.
+func (*ListClosureDaysRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{59}
+}
+
+type ListClosureDaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClosureDays   []*ClosureDay          `protobuf:"bytes,1,rep,name=closure_days,json=closureDays,proto3" json:"closure_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClosureDaysResponse) Reset() {
+	*x = ListClosureDaysResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClosureDaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClosureDaysResponse) ProtoMessage() {}
+
+func (x *ListClosureDaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClosureDaysResponse.ProtoReflect.Descriptor instead.
+func (*ListClosureDaysResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListClosureDaysResponse) GetClosureDays() []*ClosureDay {
+	if x != nil {
+		return x.ClosureDays
+	}
+	return nil
+}
+
+type CreateClosureDayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClosureDay    *ClosureDay            `protobuf:"bytes,1,opt,name=closure_day,json=closureDay,proto3" json:"closure_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateClosureDayRequest) Reset() {
+	*x = CreateClosureDayRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateClosureDayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateClosureDayRequest) ProtoMessage() {}
+
+func (x *CreateClosureDayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateClosureDayRequest.ProtoReflect.Descriptor instead.
+func (*CreateClosureDayRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateClosureDayRequest) GetClosureDay() *ClosureDay {
+	if x != nil {
+		return x.ClosureDay
+	}
+	return nil
+}
+
+type CreateClosureDayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClosureDay    *ClosureDay            `protobuf:"bytes,1,opt,name=closure_day,json=closureDay,proto3" json:"closure_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateClosureDayResponse) Reset() {
+	*x = CreateClosureDayResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateClosureDayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateClosureDayResponse) ProtoMessage() {}
+
+func (x *CreateClosureDayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateClosureDayResponse.ProtoReflect.Descriptor instead.
+func (*CreateClosureDayResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateClosureDayResponse) GetClosureDay() *ClosureDay {
+	if x != nil {
+		return x.ClosureDay
+	}
+	return nil
+}
+
+type DeleteClosureDayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteClosureDayRequest) Reset() {
+	*x = DeleteClosureDayRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteClosureDayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteClosureDayRequest) ProtoMessage() {}
+
+func (x *DeleteClosureDayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteClosureDayRequest.ProtoReflect.Descriptor instead.
+func (*DeleteClosureDayRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteClosureDayRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteClosureDayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteClosureDayResponse) Reset() {
+	*x = DeleteClosureDayResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteClosureDayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteClosureDayResponse) ProtoMessage() {}
+
+func (x *DeleteClosureDayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteClosureDayResponse.ProtoReflect.Descriptor instead.
+func (*DeleteClosureDayResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{64}
+}
+
+type ListPickupPointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode    string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Type          PickupPointType        `protobuf:"varint,2,opt,name=type,proto3,enum=shinkansen.delivery.PickupPointType" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPickupPointsRequest) Reset() {
+	*x = ListPickupPointsRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPickupPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPickupPointsRequest) ProtoMessage() {}
+
+func (x *ListPickupPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPickupPointsRequest.ProtoReflect.Descriptor instead.
+func (*ListPickupPointsRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListPickupPointsRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ListPickupPointsRequest) GetType() PickupPointType {
+	if x != nil {
+		return x.Type
+	}
+	return PickupPointType_PICKUP_POINT_TYPE_UNSPECIFIED
+}
+
+func (x *ListPickupPointsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPickupPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PickupPoints  []*PickupPoint         `protobuf:"bytes,1,rep,name=pickup_points,json=pickupPoints,proto3" json:"pickup_points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPickupPointsResponse) Reset() {
+	*x = ListPickupPointsResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPickupPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPickupPointsResponse) ProtoMessage() {}
+
+func (x *ListPickupPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[66]
 	if x != nil {
-		return x.Carrier
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Shipment) GetTrackingEvents() []*TrackingEvent {
+// Deprecated: Use ListPickupPointsResponse.ProtoReflect.Descriptor instead.
+func (*ListPickupPointsResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListPickupPointsResponse) GetPickupPoints() []*PickupPoint {
 	if x != nil {
-		return x.TrackingEvents
+		return x.PickupPoints
 	}
 	return nil
 }
 
-type TrackingEvent struct {
+type GetPickupPointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TrackingEvent) Reset() {
-	*x = TrackingEvent{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[3]
+func (x *GetPickupPointRequest) Reset() {
+	*x = GetPickupPointRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TrackingEvent) String() string {
+func (x *GetPickupPointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TrackingEvent) ProtoMessage() {}
+func (*GetPickupPointRequest) ProtoMessage() {}
 
-func (x *TrackingEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[3]
+func (x *GetPickupPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,69 +4332,84 @@ func (x *TrackingEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TrackingEvent.ProtoReflect.Descriptor instead.
-func (*TrackingEvent) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetPickupPointRequest.ProtoReflect.Descriptor instead.
+func (*GetPickupPointRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *TrackingEvent) GetId() string {
+func (x *GetPickupPointRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *TrackingEvent) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+type GetPickupPointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PickupPoint   *PickupPoint           `protobuf:"bytes,1,opt,name=pickup_point,json=pickupPoint,proto3" json:"pickup_point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TrackingEvent) GetLocation() string {
-	if x != nil {
-		return x.Location
-	}
-	return ""
+func (x *GetPickupPointResponse) Reset() {
+	*x = GetPickupPointResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *TrackingEvent) GetTimestamp() *timestamppb.Timestamp {
+func (x *GetPickupPointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPickupPointResponse) ProtoMessage() {}
+
+func (x *GetPickupPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[68]
 	if x != nil {
-		return x.Timestamp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TrackingEvent) GetDescription() string {
+// Deprecated: Use GetPickupPointResponse.ProtoReflect.Descriptor instead.
+func (*GetPickupPointResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetPickupPointResponse) GetPickupPoint() *PickupPoint {
 	if x != nil {
-		return x.Description
+		return x.PickupPoint
 	}
-	return ""
+	return nil
 }
 
-type GetDeliverySlotsRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	DeliveryZoneId string                 `protobuf:"bytes,1,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
-	Date           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type CreatePickupPointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PickupPoint   *PickupPoint           `protobuf:"bytes,1,opt,name=pickup_point,json=pickupPoint,proto3" json:"pickup_point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeliverySlotsRequest) Reset() {
-	*x = GetDeliverySlotsRequest{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[4]
+func (x *CreatePickupPointRequest) Reset() {
+	*x = CreatePickupPointRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeliverySlotsRequest) String() string {
+func (x *CreatePickupPointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeliverySlotsRequest) ProtoMessage() {}
+func (*CreatePickupPointRequest) ProtoMessage() {}
 
-func (x *GetDeliverySlotsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[4]
+func (x *CreatePickupPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,47 +4420,40 @@ func (x *GetDeliverySlotsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeliverySlotsRequest.ProtoReflect.Descriptor instead.
-func (*GetDeliverySlotsRequest) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *GetDeliverySlotsRequest) GetDeliveryZoneId() string {
-	if x != nil {
-		return x.DeliveryZoneId
-	}
-	return ""
+// Deprecated: Use CreatePickupPointRequest.ProtoReflect.Descriptor instead.
+func (*CreatePickupPointRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *GetDeliverySlotsRequest) GetDate() *timestamppb.Timestamp {
+func (x *CreatePickupPointRequest) GetPickupPoint() *PickupPoint {
 	if x != nil {
-		return x.Date
+		return x.PickupPoint
 	}
 	return nil
 }
 
-type GetDeliverySlotsResponse struct {
+type CreatePickupPointResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Slots         []*DeliverySlot        `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	PickupPoint   *PickupPoint           `protobuf:"bytes,1,opt,name=pickup_point,json=pickupPoint,proto3" json:"pickup_point,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeliverySlotsResponse) Reset() {
-	*x = GetDeliverySlotsResponse{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[5]
+func (x *CreatePickupPointResponse) Reset() {
+	*x = CreatePickupPointResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeliverySlotsResponse) String() string {
+func (x *CreatePickupPointResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeliverySlotsResponse) ProtoMessage() {}
+func (*CreatePickupPointResponse) ProtoMessage() {}
 
-func (x *GetDeliverySlotsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[5]
+func (x *CreatePickupPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,41 +4464,40 @@ func (x *GetDeliverySlotsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeliverySlotsResponse.ProtoReflect.Descriptor instead.
-func (*GetDeliverySlotsResponse) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CreatePickupPointResponse.ProtoReflect.Descriptor instead.
+func (*CreatePickupPointResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *GetDeliverySlotsResponse) GetSlots() []*DeliverySlot {
+func (x *CreatePickupPointResponse) GetPickupPoint() *PickupPoint {
 	if x != nil {
-		return x.Slots
+		return x.PickupPoint
 	}
 	return nil
 }
 
-type ReserveDeliverySlotRequest struct {
+type UpdatePickupPointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SlotId        string                 `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PickupPoint   *PickupPoint           `protobuf:"bytes,1,opt,name=pickup_point,json=pickupPoint,proto3" json:"pickup_point,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReserveDeliverySlotRequest) Reset() {
-	*x = ReserveDeliverySlotRequest{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[6]
+func (x *UpdatePickupPointRequest) Reset() {
+	*x = UpdatePickupPointRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveDeliverySlotRequest) String() string {
+func (x *UpdatePickupPointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveDeliverySlotRequest) ProtoMessage() {}
+func (*UpdatePickupPointRequest) ProtoMessage() {}
 
-func (x *ReserveDeliverySlotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[6]
+func (x *UpdatePickupPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,48 +4508,40 @@ func (x *ReserveDeliverySlotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveDeliverySlotRequest.ProtoReflect.Descriptor instead.
-func (*ReserveDeliverySlotRequest) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ReserveDeliverySlotRequest) GetSlotId() string {
-	if x != nil {
-		return x.SlotId
-	}
-	return ""
+// Deprecated: Use UpdatePickupPointRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePickupPointRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ReserveDeliverySlotRequest) GetOrderId() string {
+func (x *UpdatePickupPointRequest) GetPickupPoint() *PickupPoint {
 	if x != nil {
-		return x.OrderId
+		return x.PickupPoint
 	}
-	return ""
+	return nil
 }
 
-type ReserveDeliverySlotResponse struct {
+type UpdatePickupPointResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
-	ReservedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"`
+	PickupPoint   *PickupPoint           `protobuf:"bytes,1,opt,name=pickup_point,json=pickupPoint,proto3" json:"pickup_point,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReserveDeliverySlotResponse) Reset() {
-	*x = ReserveDeliverySlotResponse{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[7]
+func (x *UpdatePickupPointResponse) Reset() {
+	*x = UpdatePickupPointResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveDeliverySlotResponse) String() string {
+func (x *UpdatePickupPointResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveDeliverySlotResponse) ProtoMessage() {}
+func (*UpdatePickupPointResponse) ProtoMessage() {}
 
-func (x *ReserveDeliverySlotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[7]
+func (x *UpdatePickupPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -616,47 +4552,41 @@ func (x *ReserveDeliverySlotResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveDeliverySlotResponse.ProtoReflect.Descriptor instead.
-func (*ReserveDeliverySlotResponse) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *ReserveDeliverySlotResponse) GetReservationId() string {
-	if x != nil {
-		return x.ReservationId
-	}
-	return ""
+// Deprecated: Use UpdatePickupPointResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePickupPointResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *ReserveDeliverySlotResponse) GetReservedAt() *timestamppb.Timestamp {
+func (x *UpdatePickupPointResponse) GetPickupPoint() *PickupPoint {
 	if x != nil {
-		return x.ReservedAt
+		return x.PickupPoint
 	}
 	return nil
 }
 
-type GetShipmentRequest struct {
+type ConfirmPickupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	PickupCode    string                 `protobuf:"bytes,2,opt,name=pickup_code,json=pickupCode,proto3" json:"pickup_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetShipmentRequest) Reset() {
-	*x = GetShipmentRequest{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[8]
+func (x *ConfirmPickupRequest) Reset() {
+	*x = ConfirmPickupRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetShipmentRequest) String() string {
+func (x *ConfirmPickupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetShipmentRequest) ProtoMessage() {}
+func (*ConfirmPickupRequest) ProtoMessage() {}
 
-func (x *GetShipmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[8]
+func (x *ConfirmPickupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,40 +4597,47 @@ func (x *GetShipmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetShipmentRequest.ProtoReflect.Descriptor instead.
-func (*GetShipmentRequest) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ConfirmPickupRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPickupRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *GetShipmentRequest) GetShipmentId() string {
+func (x *ConfirmPickupRequest) GetShipmentId() string {
 	if x != nil {
 		return x.ShipmentId
 	}
 	return ""
 }
 
-type GetShipmentResponse struct {
+func (x *ConfirmPickupRequest) GetPickupCode() string {
+	if x != nil {
+		return x.PickupCode
+	}
+	return ""
+}
+
+type ConfirmPickupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetShipmentResponse) Reset() {
-	*x = GetShipmentResponse{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[9]
+func (x *ConfirmPickupResponse) Reset() {
+	*x = ConfirmPickupResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetShipmentResponse) String() string {
+func (x *ConfirmPickupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetShipmentResponse) ProtoMessage() {}
+func (*ConfirmPickupResponse) ProtoMessage() {}
 
-func (x *GetShipmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[9]
+func (x *ConfirmPickupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,42 +4648,41 @@ func (x *GetShipmentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetShipmentResponse.ProtoReflect.Descriptor instead.
-func (*GetShipmentResponse) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ConfirmPickupResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPickupResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *GetShipmentResponse) GetShipment() *Shipment {
+func (x *ConfirmPickupResponse) GetShipment() *Shipment {
 	if x != nil {
 		return x.Shipment
 	}
 	return nil
 }
 
-type UpdateShipmentStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
-	Status        ShipmentStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=shinkansen.delivery.ShipmentStatus" json:"status,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GenerateDeliverySlotsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryZoneId string                 `protobuf:"bytes,1,opt,name=delivery_zone_id,json=deliveryZoneId,proto3" json:"delivery_zone_id,omitempty"`
+	Days           int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UpdateShipmentStatusRequest) Reset() {
-	*x = UpdateShipmentStatusRequest{}
-	mi := &file_delivery_delivery_messages_proto_msgTypes[10]
+func (x *GenerateDeliverySlotsRequest) Reset() {
+	*x = GenerateDeliverySlotsRequest{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateShipmentStatusRequest) String() string {
+func (x *GenerateDeliverySlotsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateShipmentStatusRequest) ProtoMessage() {}
+func (*GenerateDeliverySlotsRequest) ProtoMessage() {}
 
-func (x *UpdateShipmentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_delivery_delivery_messages_proto_msgTypes[10]
+func (x *GenerateDeliverySlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,37 +4693,74 @@ func (x *UpdateShipmentStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateShipmentStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateShipmentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GenerateDeliverySlotsRequest.ProtoReflect.Descriptor instead.
+func (*GenerateDeliverySlotsRequest) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *UpdateShipmentStatusRequest) GetShipmentId() string {
+func (x *GenerateDeliverySlotsRequest) GetDeliveryZoneId() string {
 	if x != nil {
-		return x.ShipmentId
+		return x.DeliveryZoneId
 	}
 	return ""
 }
 
-func (x *UpdateShipmentStatusRequest) GetStatus() ShipmentStatus {
+func (x *GenerateDeliverySlotsRequest) GetDays() int32 {
 	if x != nil {
-		return x.Status
+		return x.Days
 	}
-	return ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED
+	return 0
 }
 
-func (x *UpdateShipmentStatusRequest) GetDescription() string {
+type GenerateDeliverySlotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlotsCreated  int32                  `protobuf:"varint,1,opt,name=slots_created,json=slotsCreated,proto3" json:"slots_created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateDeliverySlotsResponse) Reset() {
+	*x = GenerateDeliverySlotsResponse{}
+	mi := &file_delivery_delivery_messages_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDeliverySlotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDeliverySlotsResponse) ProtoMessage() {}
+
+func (x *GenerateDeliverySlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_delivery_delivery_messages_proto_msgTypes[76]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDeliverySlotsResponse.ProtoReflect.Descriptor instead.
+func (*GenerateDeliverySlotsResponse) Descriptor() ([]byte, []int) {
+	return file_delivery_delivery_messages_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GenerateDeliverySlotsResponse) GetSlotsCreated() int32 {
+	if x != nil {
+		return x.SlotsCreated
+	}
+	return 0
 }
 
 var File_delivery_delivery_messages_proto protoreflect.FileDescriptor
 
 const file_delivery_delivery_messages_proto_rawDesc = "" +
 	"\n" +
-	" delivery/delivery_messages.proto\x12\x13shinkansen.delivery\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x02\n" +
+	" delivery/delivery_messages.proto\x12\x13shinkansen.delivery\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13shared/common.proto\"\xc3\x03\n" +
 	"\fDeliverySlot\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
 	"\x10delivery_zone_id\x18\x02 \x01(\tR\x0edeliveryZoneId\x129\n" +
@@ -797,13 +4770,40 @@ const file_delivery_delivery_messages_proto_rawDesc = "" +
 	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\x12\x1a\n" +
 	"\breserved\x18\x06 \x01(\x05R\breserved\x12\x1c\n" +
 	"\tavailable\x18\a \x01(\x05R\tavailable\x12.\n" +
-	"\x04date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"\x9c\x01\n" +
+	"\x04date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12H\n" +
+	"\vtime_window\x18\t \x01(\x0e2'.shinkansen.delivery.DeliveryTimeWindowR\n" +
+	"timeWindow\x127\n" +
+	"\tcutoff_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bcutoffAt\"\x97\x02\n" +
+	"\fSlotTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10delivery_zone_id\x18\x02 \x01(\tR\x0edeliveryZoneId\x12\x18\n" +
+	"\aweekday\x18\x03 \x01(\x05R\aweekday\x12H\n" +
+	"\vtime_window\x18\x04 \x01(\x0e2'.shinkansen.delivery.DeliveryTimeWindowR\n" +
+	"timeWindow\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\x12,\n" +
+	"\x12cutoff_days_before\x18\x06 \x01(\x05R\x10cutoffDaysBefore\x12\x1f\n" +
+	"\vcutoff_time\x18\a \x01(\tR\n" +
+	"cutoffTime\"\x90\x01\n" +
+	"\fSlotBlackout\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10delivery_zone_id\x18\x02 \x01(\tR\x0edeliveryZoneId\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"d\n" +
+	"\n" +
+	"ClosureDay\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x81\x02\n" +
 	"\fDeliveryZone\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
 	"\fpostal_codes\x18\x03 \x03(\tR\vpostalCodes\x12 \n" +
 	"\vprefectures\x18\x04 \x03(\tR\vprefectures\x12#\n" +
-	"\rdelivery_days\x18\x05 \x01(\x05R\fdeliveryDays\"\x9c\x03\n" +
+	"\rdelivery_days\x18\x05 \x01(\x05R\fdeliveryDays\x12$\n" +
+	"\rundeliverable\x18\x06 \x01(\bR\rundeliverable\x12#\n" +
+	"\rremote_island\x18\a \x01(\bR\fremoteIsland\x12\x18\n" +
+	"\acarrier\x18\b \x01(\tR\acarrier\"\xfa\x06\n" +
 	"\bShipment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\tR\aorderId\x12'\n" +
@@ -812,7 +4812,47 @@ const file_delivery_delivery_messages_proto_rawDesc = "" +
 	"\x15estimated_delivery_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x13estimatedDeliveryAt\x12H\n" +
 	"\x12actual_delivery_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10actualDeliveryAt\x12\x18\n" +
 	"\acarrier\x18\a \x01(\tR\acarrier\x12K\n" +
-	"\x0ftracking_events\x18\b \x03(\v2\".shinkansen.delivery.TrackingEventR\x0etrackingEvents\"\xaf\x01\n" +
+	"\x0ftracking_events\x18\b \x03(\v2\".shinkansen.delivery.TrackingEventR\x0etrackingEvents\x127\n" +
+	"\n" +
+	"cod_amount\x18\t \x01(\v2\x18.shinkansen.common.MoneyR\tcodAmount\x12C\n" +
+	"\x10collected_amount\x18\n" +
+	" \x01(\v2\x18.shinkansen.common.MoneyR\x0fcollectedAmount\x12F\n" +
+	"\rservice_level\x18\v \x01(\x0e2!.shinkansen.delivery.ServiceLevelR\fserviceLevel\x12B\n" +
+	"\trecipient\x18\f \x01(\v2$.shinkansen.delivery.ShipmentAddressR\trecipient\x12?\n" +
+	"\rdispatched_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\fdispatchedAt\x12&\n" +
+	"\x0fpickup_point_id\x18\x0e \x01(\tR\rpickupPointId\x12\x1f\n" +
+	"\vpickup_code\x18\x0f \x01(\tR\n" +
+	"pickupCode\x12H\n" +
+	"\x12pickup_deadline_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x10pickupDeadlineAt\"\xda\x01\n" +
+	"\x0fShipmentAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x1f\n" +
+	"\vpostal_code\x18\x03 \x01(\tR\n" +
+	"postalCode\x12\x1e\n" +
+	"\n" +
+	"prefecture\x18\x04 \x01(\tR\n" +
+	"prefecture\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12#\n" +
+	"\raddress_line1\x18\x06 \x01(\tR\faddressLine1\x12#\n" +
+	"\raddress_line2\x18\a \x01(\tR\faddressLine2\"\x94\x03\n" +
+	"\vPickupPoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x128\n" +
+	"\x04type\x18\x04 \x01(\x0e2$.shinkansen.delivery.PickupPointTypeR\x04type\x12\x1a\n" +
+	"\boperator\x18\x05 \x01(\tR\boperator\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x1e\n" +
+	"\n" +
+	"prefecture\x18\a \x01(\tR\n" +
+	"prefecture\x12\x12\n" +
+	"\x04city\x18\b \x01(\tR\x04city\x12#\n" +
+	"\raddress_line1\x18\t \x01(\tR\faddressLine1\x12#\n" +
+	"\raddress_line2\x18\n" +
+	" \x01(\tR\faddressLine2\x12#\n" +
+	"\ropening_hours\x18\v \x01(\tR\fopeningHours\x12\x1b\n" +
+	"\thold_days\x18\f \x01(\x05R\bholdDays\x12\x16\n" +
+	"\x06active\x18\r \x01(\bR\x06active\"\xaf\x01\n" +
 	"\rTrackingEvent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
@@ -826,21 +4866,254 @@ const file_delivery_delivery_messages_proto_rawDesc = "" +
 	"\x05slots\x18\x01 \x03(\v2!.shinkansen.delivery.DeliverySlotR\x05slots\"P\n" +
 	"\x1aReserveDeliverySlotRequest\x12\x17\n" +
 	"\aslot_id\x18\x01 \x01(\tR\x06slotId\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\"\x81\x01\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"\xfc\x01\n" +
 	"\x1bReserveDeliverySlotResponse\x12%\n" +
 	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12;\n" +
 	"\vreserved_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"reservedAt\"5\n" +
+	"reservedAt\x12>\n" +
+	"\x06status\x18\x04 \x01(\x0e2&.shinkansen.delivery.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"6\n" +
+	"\x19ConfirmReservationRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x9b\x01\n" +
+	"\x1aConfirmReservationResponse\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x12=\n" +
+	"\fconfirmed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vconfirmedAt\"7\n" +
+	"\x1aReleaseDeliverySlotRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"9\n" +
+	"\x1bReleaseDeliverySlotResponse\x12\x1a\n" +
+	"\breleased\x18\x01 \x01(\bR\breleased\"P\n" +
 	"\x12GetShipmentRequest\x12\x1f\n" +
 	"\vshipment_id\x18\x01 \x01(\tR\n" +
-	"shipmentId\"P\n" +
+	"shipmentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"P\n" +
 	"\x13GetShipmentResponse\x129\n" +
-	"\bshipment\x18\x01 \x01(\v2\x1d.shinkansen.delivery.ShipmentR\bshipment\"\x9d\x01\n" +
+	"\bshipment\x18\x01 \x01(\v2\x1d.shinkansen.delivery.ShipmentR\bshipment\"\xe2\x01\n" +
 	"\x1bUpdateShipmentStatusRequest\x12\x1f\n" +
 	"\vshipment_id\x18\x01 \x01(\tR\n" +
 	"shipmentId\x12;\n" +
 	"\x06status\x18\x02 \x01(\x0e2#.shinkansen.delivery.ShipmentStatusR\x06status\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription*\xf0\x01\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12C\n" +
+	"\x10collected_amount\x18\x04 \x01(\v2\x18.shinkansen.common.MoneyR\x0fcollectedAmount\"\xca\x01\n" +
+	"\x17AddTrackingEventRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\tR\n" +
+	"shipmentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"T\n" +
+	"\x18AddTrackingEventResponse\x128\n" +
+	"\x05event\x18\x01 \x01(\v2\".shinkansen.delivery.TrackingEventR\x05event\"W\n" +
+	"\x19ListTrackingEventsRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\tR\n" +
+	"shipmentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"X\n" +
+	"\x1aListTrackingEventsResponse\x12:\n" +
+	"\x06events\x18\x01 \x03(\v2\".shinkansen.delivery.TrackingEventR\x06events\"\x82\x02\n" +
+	"\x17DispatchShipmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12F\n" +
+	"\rservice_level\x18\x02 \x01(\x0e2!.shinkansen.delivery.ServiceLevelR\fserviceLevel\x12B\n" +
+	"\trecipient\x18\x03 \x01(\v2$.shinkansen.delivery.ShipmentAddressR\trecipient\x12\x18\n" +
+	"\acarrier\x18\x04 \x01(\tR\acarrier\x12&\n" +
+	"\x0fpickup_point_id\x18\x05 \x01(\tR\rpickupPointId\"U\n" +
+	"\x18DispatchShipmentResponse\x129\n" +
+	"\bshipment\x18\x01 \x01(\v2\x1d.shinkansen.delivery.ShipmentR\bshipment\"P\n" +
+	"\x15CancelShipmentRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\tR\n" +
+	"shipmentId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"S\n" +
+	"\x16CancelShipmentResponse\x129\n" +
+	"\bshipment\x18\x01 \x01(\v2\x1d.shinkansen.delivery.ShipmentR\bshipment\"b\n" +
+	"\x1dValidateTrackingNumberRequest\x12\x18\n" +
+	"\acarrier\x18\x01 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x02 \x01(\tR\x0etrackingNumber\"w\n" +
+	"\x1eValidateTrackingNumberResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12'\n" +
+	"\x0ftracking_number\x18\x02 \x01(\tR\x0etrackingNumber\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\":\n" +
+	"\x17GetShippingLabelRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\tR\n" +
+	"shipmentId\"H\n" +
+	"\x18GetShippingLabelResponse\x12\x10\n" +
+	"\x03pdf\x18\x01 \x01(\fR\x03pdf\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"d\n" +
+	"\x18GetShippingLabelsRequest\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\"j\n" +
+	"\x19GetShippingLabelsResponse\x12\x10\n" +
+	"\x03pdf\x18\x01 \x01(\fR\x03pdf\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1f\n" +
+	"\vlabel_count\x18\x03 \x01(\x05R\n" +
+	"labelCount\"\x9f\x01\n" +
+	"\x06Parcel\x12\x1d\n" +
+	"\n" +
+	"size_class\x18\x01 \x01(\x05R\tsizeClass\x12\x1b\n" +
+	"\tlength_cm\x18\x02 \x01(\x05R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x03 \x01(\x05R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x04 \x01(\x05R\bheightCm\x12!\n" +
+	"\fweight_grams\x18\x05 \x01(\x05R\vweightGrams\"\xa7\x01\n" +
+	"\n" +
+	"ParcelRate\x12\x1d\n" +
+	"\n" +
+	"size_class\x18\x01 \x01(\x05R\tsizeClass\x129\n" +
+	"\vbase_amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\n" +
+	"baseAmount\x12?\n" +
+	"\x0ecool_surcharge\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\rcoolSurcharge\"\xa6\x06\n" +
+	"\rShippingQuote\x129\n" +
+	"\aparcels\x18\x01 \x03(\v2\x1f.shinkansen.delivery.ParcelRateR\aparcels\x129\n" +
+	"\vbase_amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\n" +
+	"baseAmount\x12?\n" +
+	"\x0ecool_surcharge\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\rcoolSurcharge\x12E\n" +
+	"\x11express_surcharge\x18\x04 \x01(\v2\x18.shinkansen.common.MoneyR\x10expressSurcharge\x12@\n" +
+	"\x0ftime_window_fee\x18\x05 \x01(\v2\x18.shinkansen.common.MoneyR\rtimeWindowFee\x12D\n" +
+	"\x11remote_island_fee\x18\x06 \x01(\v2\x18.shinkansen.common.MoneyR\x0fremoteIslandFee\x124\n" +
+	"\bdiscount\x18\a \x01(\v2\x18.shinkansen.common.MoneyR\bdiscount\x12.\n" +
+	"\x05total\x18\b \x01(\v2\x18.shinkansen.common.MoneyR\x05total\x12#\n" +
+	"\rfree_shipping\x18\t \x01(\bR\ffreeShipping\x12P\n" +
+	"\x17free_shipping_remaining\x18\n" +
+	" \x01(\v2\x18.shinkansen.common.MoneyR\x15freeShippingRemaining\x12+\n" +
+	"\x11origin_prefecture\x18\v \x01(\tR\x10originPrefecture\x125\n" +
+	"\x16destination_prefecture\x18\f \x01(\tR\x15destinationPrefecture\x12N\n" +
+	"\x15estimated_delivery_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x13estimatedDeliveryAt\"\xb8\x03\n" +
+	"\x14QuoteShippingRequest\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\x12\x1e\n" +
+	"\n" +
+	"prefecture\x18\x02 \x01(\tR\n" +
+	"prefecture\x12+\n" +
+	"\x11origin_prefecture\x18\x03 \x01(\tR\x10originPrefecture\x125\n" +
+	"\aparcels\x18\x04 \x03(\v2\x1b.shinkansen.delivery.ParcelR\aparcels\x12F\n" +
+	"\rservice_level\x18\x05 \x01(\x0e2!.shinkansen.delivery.ServiceLevelR\fserviceLevel\x12H\n" +
+	"\vtime_window\x18\x06 \x01(\x0e2'.shinkansen.delivery.DeliveryTimeWindowR\n" +
+	"timeWindow\x12(\n" +
+	"\x10delivery_slot_id\x18\a \x01(\tR\x0edeliverySlotId\x12?\n" +
+	"\x0eorder_subtotal\x18\b \x01(\v2\x18.shinkansen.common.MoneyR\rorderSubtotal\"Q\n" +
+	"\x15QuoteShippingResponse\x128\n" +
+	"\x05quote\x18\x01 \x01(\v2\".shinkansen.delivery.ShippingQuoteR\x05quote\"k\n" +
+	"\x1cRequestCashOnDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x120\n" +
+	"\x06amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\"Z\n" +
+	"\x1dRequestCashOnDeliveryResponse\x129\n" +
+	"\bshipment\x18\x01 \x01(\v2\x1d.shinkansen.delivery.ShipmentR\bshipment\"]\n" +
+	"\x1aResolveDeliveryZoneRequest\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\x12\x1e\n" +
+	"\n" +
+	"prefecture\x18\x02 \x01(\tR\n" +
+	"prefecture\"\xdc\x01\n" +
+	"\x1bResolveDeliveryZoneResponse\x125\n" +
+	"\x04zone\x18\x01 \x01(\v2!.shinkansen.delivery.DeliveryZoneR\x04zone\x12\x1f\n" +
+	"\vpostal_code\x18\x02 \x01(\tR\n" +
+	"postalCode\x12\x1e\n" +
+	"\n" +
+	"prefecture\x18\x03 \x01(\tR\n" +
+	"prefecture\x12 \n" +
+	"\vdeliverable\x18\x04 \x01(\bR\vdeliverable\x12#\n" +
+	"\rremote_island\x18\x05 \x01(\bR\fremoteIsland\"\x94\x01\n" +
+	"!GetDeliverySlotsForAddressRequest\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\x12\x1e\n" +
+	"\n" +
+	"prefecture\x18\x02 \x01(\tR\n" +
+	"prefecture\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"\xa3\x01\n" +
+	"\"GetDeliverySlotsForAddressResponse\x12D\n" +
+	"\x04zone\x18\x01 \x01(\v20.shinkansen.delivery.ResolveDeliveryZoneResponseR\x04zone\x127\n" +
+	"\x05slots\x18\x02 \x03(\v2!.shinkansen.delivery.DeliverySlotR\x05slots\"D\n" +
+	"\x18ListSlotTemplatesRequest\x12(\n" +
+	"\x10delivery_zone_id\x18\x01 \x01(\tR\x0edeliveryZoneId\"\\\n" +
+	"\x19ListSlotTemplatesResponse\x12?\n" +
+	"\ttemplates\x18\x01 \x03(\v2!.shinkansen.delivery.SlotTemplateR\ttemplates\"Z\n" +
+	"\x19CreateSlotTemplateRequest\x12=\n" +
+	"\btemplate\x18\x01 \x01(\v2!.shinkansen.delivery.SlotTemplateR\btemplate\"\x80\x01\n" +
+	"\x1aCreateSlotTemplateResponse\x12=\n" +
+	"\btemplate\x18\x01 \x01(\v2!.shinkansen.delivery.SlotTemplateR\btemplate\x12#\n" +
+	"\rslots_created\x18\x02 \x01(\x05R\fslotsCreated\"Z\n" +
+	"\x19UpdateSlotTemplateRequest\x12=\n" +
+	"\btemplate\x18\x01 \x01(\v2!.shinkansen.delivery.SlotTemplateR\btemplate\"\xa5\x01\n" +
+	"\x1aUpdateSlotTemplateResponse\x12=\n" +
+	"\btemplate\x18\x01 \x01(\v2!.shinkansen.delivery.SlotTemplateR\btemplate\x12#\n" +
+	"\rslots_removed\x18\x02 \x01(\x05R\fslotsRemoved\x12#\n" +
+	"\rslots_created\x18\x03 \x01(\x05R\fslotsCreated\"+\n" +
+	"\x19DeleteSlotTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
+	"\x1aDeleteSlotTemplateResponse\x12#\n" +
+	"\rslots_removed\x18\x01 \x01(\x05R\fslotsRemoved\"D\n" +
+	"\x18ListSlotBlackoutsRequest\x12(\n" +
+	"\x10delivery_zone_id\x18\x01 \x01(\tR\x0edeliveryZoneId\"\\\n" +
+	"\x19ListSlotBlackoutsResponse\x12?\n" +
+	"\tblackouts\x18\x01 \x03(\v2!.shinkansen.delivery.SlotBlackoutR\tblackouts\"Z\n" +
+	"\x19CreateSlotBlackoutRequest\x12=\n" +
+	"\bblackout\x18\x01 \x01(\v2!.shinkansen.delivery.SlotBlackoutR\bblackout\"\xa7\x01\n" +
+	"\x1aCreateSlotBlackoutResponse\x12=\n" +
+	"\bblackout\x18\x01 \x01(\v2!.shinkansen.delivery.SlotBlackoutR\bblackout\x12#\n" +
+	"\rslots_removed\x18\x02 \x01(\x05R\fslotsRemoved\x12%\n" +
+	"\x0ereserved_slots\x18\x03 \x01(\x05R\rreservedSlots\"+\n" +
+	"\x19DeleteSlotBlackoutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
+	"\x1aDeleteSlotBlackoutResponse\x12#\n" +
+	"\rslots_created\x18\x01 \x01(\x05R\fslotsCreated\"\x18\n" +
+	"\x16ListClosureDaysRequest\"]\n" +
+	"\x17ListClosureDaysResponse\x12B\n" +
+	"\fclosure_days\x18\x01 \x03(\v2\x1f.shinkansen.delivery.ClosureDayR\vclosureDays\"[\n" +
+	"\x17CreateClosureDayRequest\x12@\n" +
+	"\vclosure_day\x18\x01 \x01(\v2\x1f.shinkansen.delivery.ClosureDayR\n" +
+	"closureDay\"\\\n" +
+	"\x18CreateClosureDayResponse\x12@\n" +
+	"\vclosure_day\x18\x01 \x01(\v2\x1f.shinkansen.delivery.ClosureDayR\n" +
+	"closureDay\")\n" +
+	"\x17DeleteClosureDayRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18DeleteClosureDayResponse\"\x8a\x01\n" +
+	"\x17ListPickupPointsRequest\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\x128\n" +
+	"\x04type\x18\x02 \x01(\x0e2$.shinkansen.delivery.PickupPointTypeR\x04type\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"a\n" +
+	"\x18ListPickupPointsResponse\x12E\n" +
+	"\rpickup_points\x18\x01 \x03(\v2 .shinkansen.delivery.PickupPointR\fpickupPoints\"'\n" +
+	"\x15GetPickupPointRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"]\n" +
+	"\x16GetPickupPointResponse\x12C\n" +
+	"\fpickup_point\x18\x01 \x01(\v2 .shinkansen.delivery.PickupPointR\vpickupPoint\"_\n" +
+	"\x18CreatePickupPointRequest\x12C\n" +
+	"\fpickup_point\x18\x01 \x01(\v2 .shinkansen.delivery.PickupPointR\vpickupPoint\"`\n" +
+	"\x19CreatePickupPointResponse\x12C\n" +
+	"\fpickup_point\x18\x01 \x01(\v2 .shinkansen.delivery.PickupPointR\vpickupPoint\"_\n" +
+	"\x18UpdatePickupPointRequest\x12C\n" +
+	"\fpickup_point\x18\x01 \x01(\v2 .shinkansen.delivery.PickupPointR\vpickupPoint\"`\n" +
+	"\x19UpdatePickupPointResponse\x12C\n" +
+	"\fpickup_point\x18\x01 \x01(\v2 .shinkansen.delivery.PickupPointR\vpickupPoint\"X\n" +
+	"\x14ConfirmPickupRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\tR\n" +
+	"shipmentId\x12\x1f\n" +
+	"\vpickup_code\x18\x02 \x01(\tR\n" +
+	"pickupCode\"R\n" +
+	"\x15ConfirmPickupResponse\x129\n" +
+	"\bshipment\x18\x01 \x01(\v2\x1d.shinkansen.delivery.ShipmentR\bshipment\"\\\n" +
+	"\x1cGenerateDeliverySlotsRequest\x12(\n" +
+	"\x10delivery_zone_id\x18\x01 \x01(\tR\x0edeliveryZoneId\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"D\n" +
+	"\x1dGenerateDeliverySlotsResponse\x12#\n" +
+	"\rslots_created\x18\x01 \x01(\x05R\fslotsCreated*\xdc\x01\n" +
+	"\x12DeliveryTimeWindow\x12$\n" +
+	" DELIVERY_TIME_WINDOW_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cDELIVERY_TIME_WINDOW_MORNING\x10\x01\x12\x1e\n" +
+	"\x1aDELIVERY_TIME_WINDOW_14_16\x10\x02\x12\x1e\n" +
+	"\x1aDELIVERY_TIME_WINDOW_16_18\x10\x03\x12\x1e\n" +
+	"\x1aDELIVERY_TIME_WINDOW_18_20\x10\x04\x12\x1e\n" +
+	"\x1aDELIVERY_TIME_WINDOW_19_21\x10\x05*q\n" +
+	"\x0fPickupPointType\x12!\n" +
+	"\x1dPICKUP_POINT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PICKUP_POINT_TYPE_KONBINI\x10\x01\x12\x1c\n" +
+	"\x18PICKUP_POINT_TYPE_LOCKER\x10\x02*\xa3\x01\n" +
+	"\fServiceLevel\x12\x1d\n" +
+	"\x19SERVICE_LEVEL_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16SERVICE_LEVEL_STANDARD\x10\x01\x12\x19\n" +
+	"\x15SERVICE_LEVEL_EXPRESS\x10\x02\x12\x1e\n" +
+	"\x1aSERVICE_LEVEL_COOL_CHILLED\x10\x03\x12\x1d\n" +
+	"\x19SERVICE_LEVEL_COOL_FROZEN\x10\x04*\xd3\x02\n" +
 	"\x0eShipmentStatus\x12\x1f\n" +
 	"\x1bSHIPMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19SHIPMENT_STATUS_PREPARING\x10\x01\x12\x1b\n" +
@@ -848,7 +5121,14 @@ const file_delivery_delivery_messages_proto_rawDesc = "" +
 	"\x1aSHIPMENT_STATUS_IN_TRANSIT\x10\x03\x12\x1d\n" +
 	"\x19SHIPMENT_STATUS_DELIVERED\x10\x04\x12\x1d\n" +
 	"\x19SHIPMENT_STATUS_CANCELLED\x10\x05\x12#\n" +
-	"\x1fSHIPMENT_STATUS_FAILED_DELIVERY\x10\x06B>Z<github.com/afasari/shinkansen-commerce/gen/proto/go/deliveryb\x06proto3"
+	"\x1fSHIPMENT_STATUS_FAILED_DELIVERY\x10\x06\x12$\n" +
+	" SHIPMENT_STATUS_READY_FOR_PICKUP\x10\a\x12\x1d\n" +
+	"\x19SHIPMENT_STATUS_PICKED_UP\x10\b\x12\x1c\n" +
+	"\x18SHIPMENT_STATUS_RETURNED\x10\t*v\n" +
+	"\x11ReservationStatus\x12\"\n" +
+	"\x1eRESERVATION_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17RESERVATION_STATUS_HELD\x10\x01\x12 \n" +
+	"\x1cRESERVATION_STATUS_CONFIRMED\x10\x02B>Z<github.com/afasari/shinkansen-commerce/gen/proto/go/deliveryb\x06proto3"
 
 var (
 	file_delivery_delivery_messages_proto_rawDescOnce sync.Once
@@ -862,42 +5142,179 @@ func file_delivery_delivery_messages_proto_rawDescGZIP() []byte {
 	return file_delivery_delivery_messages_proto_rawDescData
 }
 
-var file_delivery_delivery_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_delivery_delivery_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_delivery_delivery_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_delivery_delivery_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
 var file_delivery_delivery_messages_proto_goTypes = []any{
-	(ShipmentStatus)(0),                 // 0: shinkansen.delivery.ShipmentStatus
-	(*DeliverySlot)(nil),                // 1: shinkansen.delivery.DeliverySlot
-	(*DeliveryZone)(nil),                // 2: shinkansen.delivery.DeliveryZone
-	(*Shipment)(nil),                    // 3: shinkansen.delivery.Shipment
-	(*TrackingEvent)(nil),               // 4: shinkansen.delivery.TrackingEvent
-	(*GetDeliverySlotsRequest)(nil),     // 5: shinkansen.delivery.GetDeliverySlotsRequest
-	(*GetDeliverySlotsResponse)(nil),    // 6: shinkansen.delivery.GetDeliverySlotsResponse
-	(*ReserveDeliverySlotRequest)(nil),  // 7: shinkansen.delivery.ReserveDeliverySlotRequest
-	(*ReserveDeliverySlotResponse)(nil), // 8: shinkansen.delivery.ReserveDeliverySlotResponse
-	(*GetShipmentRequest)(nil),          // 9: shinkansen.delivery.GetShipmentRequest
-	(*GetShipmentResponse)(nil),         // 10: shinkansen.delivery.GetShipmentResponse
-	(*UpdateShipmentStatusRequest)(nil), // 11: shinkansen.delivery.UpdateShipmentStatusRequest
-	(*timestamppb.Timestamp)(nil),       // 12: google.protobuf.Timestamp
+	(DeliveryTimeWindow)(0),                    // 0: shinkansen.delivery.DeliveryTimeWindow
+	(PickupPointType)(0),                       // 1: shinkansen.delivery.PickupPointType
+	(ServiceLevel)(0),                          // 2: shinkansen.delivery.ServiceLevel
+	(ShipmentStatus)(0),                        // 3: shinkansen.delivery.ShipmentStatus
+	(ReservationStatus)(0),                     // 4: shinkansen.delivery.ReservationStatus
+	(*DeliverySlot)(nil),                       // 5: shinkansen.delivery.DeliverySlot
+	(*SlotTemplate)(nil),                       // 6: shinkansen.delivery.SlotTemplate
+	(*SlotBlackout)(nil),                       // 7: shinkansen.delivery.SlotBlackout
+	(*ClosureDay)(nil),                         // 8: shinkansen.delivery.ClosureDay
+	(*DeliveryZone)(nil),                       // 9: shinkansen.delivery.DeliveryZone
+	(*Shipment)(nil),                           // 10: shinkansen.delivery.Shipment
+	(*ShipmentAddress)(nil),                    // 11: shinkansen.delivery.ShipmentAddress
+	(*PickupPoint)(nil),                        // 12: shinkansen.delivery.PickupPoint
+	(*TrackingEvent)(nil),                      // 13: shinkansen.delivery.TrackingEvent
+	(*GetDeliverySlotsRequest)(nil),            // 14: shinkansen.delivery.GetDeliverySlotsRequest
+	(*GetDeliverySlotsResponse)(nil),           // 15: shinkansen.delivery.GetDeliverySlotsResponse
+	(*ReserveDeliverySlotRequest)(nil),         // 16: shinkansen.delivery.ReserveDeliverySlotRequest
+	(*ReserveDeliverySlotResponse)(nil),        // 17: shinkansen.delivery.ReserveDeliverySlotResponse
+	(*ConfirmReservationRequest)(nil),          // 18: shinkansen.delivery.ConfirmReservationRequest
+	(*ConfirmReservationResponse)(nil),         // 19: shinkansen.delivery.ConfirmReservationResponse
+	(*ReleaseDeliverySlotRequest)(nil),         // 20: shinkansen.delivery.ReleaseDeliverySlotRequest
+	(*ReleaseDeliverySlotResponse)(nil),        // 21: shinkansen.delivery.ReleaseDeliverySlotResponse
+	(*GetShipmentRequest)(nil),                 // 22: shinkansen.delivery.GetShipmentRequest
+	(*GetShipmentResponse)(nil),                // 23: shinkansen.delivery.GetShipmentResponse
+	(*UpdateShipmentStatusRequest)(nil),        // 24: shinkansen.delivery.UpdateShipmentStatusRequest
+	(*AddTrackingEventRequest)(nil),            // 25: shinkansen.delivery.AddTrackingEventRequest
+	(*AddTrackingEventResponse)(nil),           // 26: shinkansen.delivery.AddTrackingEventResponse
+	(*ListTrackingEventsRequest)(nil),          // 27: shinkansen.delivery.ListTrackingEventsRequest
+	(*ListTrackingEventsResponse)(nil),         // 28: shinkansen.delivery.ListTrackingEventsResponse
+	(*DispatchShipmentRequest)(nil),            // 29: shinkansen.delivery.DispatchShipmentRequest
+	(*DispatchShipmentResponse)(nil),           // 30: shinkansen.delivery.DispatchShipmentResponse
+	(*CancelShipmentRequest)(nil),              // 31: shinkansen.delivery.CancelShipmentRequest
+	(*CancelShipmentResponse)(nil),             // 32: shinkansen.delivery.CancelShipmentResponse
+	(*ValidateTrackingNumberRequest)(nil),      // 33: shinkansen.delivery.ValidateTrackingNumberRequest
+	(*ValidateTrackingNumberResponse)(nil),     // 34: shinkansen.delivery.ValidateTrackingNumberResponse
+	(*GetShippingLabelRequest)(nil),            // 35: shinkansen.delivery.GetShippingLabelRequest
+	(*GetShippingLabelResponse)(nil),           // 36: shinkansen.delivery.GetShippingLabelResponse
+	(*GetShippingLabelsRequest)(nil),           // 37: shinkansen.delivery.GetShippingLabelsRequest
+	(*GetShippingLabelsResponse)(nil),          // 38: shinkansen.delivery.GetShippingLabelsResponse
+	(*Parcel)(nil),                             // 39: shinkansen.delivery.Parcel
+	(*ParcelRate)(nil),                         // 40: shinkansen.delivery.ParcelRate
+	(*ShippingQuote)(nil),                      // 41: shinkansen.delivery.ShippingQuote
+	(*QuoteShippingRequest)(nil),               // 42: shinkansen.delivery.QuoteShippingRequest
+	(*QuoteShippingResponse)(nil),              // 43: shinkansen.delivery.QuoteShippingResponse
+	(*RequestCashOnDeliveryRequest)(nil),       // 44: shinkansen.delivery.RequestCashOnDeliveryRequest
+	(*RequestCashOnDeliveryResponse)(nil),      // 45: shinkansen.delivery.RequestCashOnDeliveryResponse
+	(*ResolveDeliveryZoneRequest)(nil),         // 46: shinkansen.delivery.ResolveDeliveryZoneRequest
+	(*ResolveDeliveryZoneResponse)(nil),        // 47: shinkansen.delivery.ResolveDeliveryZoneResponse
+	(*GetDeliverySlotsForAddressRequest)(nil),  // 48: shinkansen.delivery.GetDeliverySlotsForAddressRequest
+	(*GetDeliverySlotsForAddressResponse)(nil), // 49: shinkansen.delivery.GetDeliverySlotsForAddressResponse
+	(*ListSlotTemplatesRequest)(nil),           // 50: shinkansen.delivery.ListSlotTemplatesRequest
+	(*ListSlotTemplatesResponse)(nil),          // 51: shinkansen.delivery.ListSlotTemplatesResponse
+	(*CreateSlotTemplateRequest)(nil),          // 52: shinkansen.delivery.CreateSlotTemplateRequest
+	(*CreateSlotTemplateResponse)(nil),         // 53: shinkansen.delivery.CreateSlotTemplateResponse
+	(*UpdateSlotTemplateRequest)(nil),          // 54: shinkansen.delivery.UpdateSlotTemplateRequest
+	(*UpdateSlotTemplateResponse)(nil),         // 55: shinkansen.delivery.UpdateSlotTemplateResponse
+	(*DeleteSlotTemplateRequest)(nil),          // 56: shinkansen.delivery.DeleteSlotTemplateRequest
+	(*DeleteSlotTemplateResponse)(nil),         // 57: shinkansen.delivery.DeleteSlotTemplateResponse
+	(*ListSlotBlackoutsRequest)(nil),           // 58: shinkansen.delivery.ListSlotBlackoutsRequest
+	(*ListSlotBlackoutsResponse)(nil),          // 59: shinkansen.delivery.ListSlotBlackoutsResponse
+	(*CreateSlotBlackoutRequest)(nil),          // 60: shinkansen.delivery.CreateSlotBlackoutRequest
+	(*CreateSlotBlackoutResponse)(nil),         // 61: shinkansen.delivery.CreateSlotBlackoutResponse
+	(*DeleteSlotBlackoutRequest)(nil),          // 62: shinkansen.delivery.DeleteSlotBlackoutRequest
+	(*DeleteSlotBlackoutResponse)(nil),         // 63: shinkansen.delivery.DeleteSlotBlackoutResponse
+	(*ListClosureDaysRequest)(nil),             // 64: shinkansen.delivery.ListClosureDaysRequest
+	(*ListClosureDaysResponse)(nil),            // 65: shinkansen.delivery.ListClosureDaysResponse
+	(*CreateClosureDayRequest)(nil),            // 66: shinkansen.delivery.CreateClosureDayRequest
+	(*CreateClosureDayResponse)(nil),           // 67: shinkansen.delivery.CreateClosureDayResponse
+	(*DeleteClosureDayRequest)(nil),            // 68: shinkansen.delivery.DeleteClosureDayRequest
+	(*DeleteClosureDayResponse)(nil),           // 69: shinkansen.delivery.DeleteClosureDayResponse
+	(*ListPickupPointsRequest)(nil),            // 70: shinkansen.delivery.ListPickupPointsRequest
+	(*ListPickupPointsResponse)(nil),           // 71: shinkansen.delivery.ListPickupPointsResponse
+	(*GetPickupPointRequest)(nil),              // 72: shinkansen.delivery.GetPickupPointRequest
+	(*GetPickupPointResponse)(nil),             // 73: shinkansen.delivery.GetPickupPointResponse
+	(*CreatePickupPointRequest)(nil),           // 74: shinkansen.delivery.CreatePickupPointRequest
+	(*CreatePickupPointResponse)(nil),          // 75: shinkansen.delivery.CreatePickupPointResponse
+	(*UpdatePickupPointRequest)(nil),           // 76: shinkansen.delivery.UpdatePickupPointRequest
+	(*UpdatePickupPointResponse)(nil),          // 77: shinkansen.delivery.UpdatePickupPointResponse
+	(*ConfirmPickupRequest)(nil),               // 78: shinkansen.delivery.ConfirmPickupRequest
+	(*ConfirmPickupResponse)(nil),              // 79: shinkansen.delivery.ConfirmPickupResponse
+	(*GenerateDeliverySlotsRequest)(nil),       // 80: shinkansen.delivery.GenerateDeliverySlotsRequest
+	(*GenerateDeliverySlotsResponse)(nil),      // 81: shinkansen.delivery.GenerateDeliverySlotsResponse
+	(*timestamppb.Timestamp)(nil),              // 82: google.protobuf.Timestamp
+	(*shared.Money)(nil),                       // 83: shinkansen.common.Money
 }
 var file_delivery_delivery_messages_proto_depIdxs = []int32{
-	12, // 0: shinkansen.delivery.DeliverySlot.start_time:type_name -> google.protobuf.Timestamp
-	12, // 1: shinkansen.delivery.DeliverySlot.end_time:type_name -> google.protobuf.Timestamp
-	12, // 2: shinkansen.delivery.DeliverySlot.date:type_name -> google.protobuf.Timestamp
-	0,  // 3: shinkansen.delivery.Shipment.status:type_name -> shinkansen.delivery.ShipmentStatus
-	12, // 4: shinkansen.delivery.Shipment.estimated_delivery_at:type_name -> google.protobuf.Timestamp
-	12, // 5: shinkansen.delivery.Shipment.actual_delivery_at:type_name -> google.protobuf.Timestamp
-	4,  // 6: shinkansen.delivery.Shipment.tracking_events:type_name -> shinkansen.delivery.TrackingEvent
-	12, // 7: shinkansen.delivery.TrackingEvent.timestamp:type_name -> google.protobuf.Timestamp
-	12, // 8: shinkansen.delivery.GetDeliverySlotsRequest.date:type_name -> google.protobuf.Timestamp
-	1,  // 9: shinkansen.delivery.GetDeliverySlotsResponse.slots:type_name -> shinkansen.delivery.DeliverySlot
-	12, // 10: shinkansen.delivery.ReserveDeliverySlotResponse.reserved_at:type_name -> google.protobuf.Timestamp
-	3,  // 11: shinkansen.delivery.GetShipmentResponse.shipment:type_name -> shinkansen.delivery.Shipment
-	0,  // 12: shinkansen.delivery.UpdateShipmentStatusRequest.status:type_name -> shinkansen.delivery.ShipmentStatus
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	82, // 0: shinkansen.delivery.DeliverySlot.start_time:type_name -> google.protobuf.Timestamp
+	82, // 1: shinkansen.delivery.DeliverySlot.end_time:type_name -> google.protobuf.Timestamp
+	82, // 2: shinkansen.delivery.DeliverySlot.date:type_name -> google.protobuf.Timestamp
+	0,  // 3: shinkansen.delivery.DeliverySlot.time_window:type_name -> shinkansen.delivery.DeliveryTimeWindow
+	82, // 4: shinkansen.delivery.DeliverySlot.cutoff_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: shinkansen.delivery.SlotTemplate.time_window:type_name -> shinkansen.delivery.DeliveryTimeWindow
+	82, // 6: shinkansen.delivery.SlotBlackout.date:type_name -> google.protobuf.Timestamp
+	82, // 7: shinkansen.delivery.ClosureDay.date:type_name -> google.protobuf.Timestamp
+	3,  // 8: shinkansen.delivery.Shipment.status:type_name -> shinkansen.delivery.ShipmentStatus
+	82, // 9: shinkansen.delivery.Shipment.estimated_delivery_at:type_name -> google.protobuf.Timestamp
+	82, // 10: shinkansen.delivery.Shipment.actual_delivery_at:type_name -> google.protobuf.Timestamp
+	13, // 11: shinkansen.delivery.Shipment.tracking_events:type_name -> shinkansen.delivery.TrackingEvent
+	83, // 12: shinkansen.delivery.Shipment.cod_amount:type_name -> shinkansen.common.Money
+	83, // 13: shinkansen.delivery.Shipment.collected_amount:type_name -> shinkansen.common.Money
+	2,  // 14: shinkansen.delivery.Shipment.service_level:type_name -> shinkansen.delivery.ServiceLevel
+	11, // 15: shinkansen.delivery.Shipment.recipient:type_name -> shinkansen.delivery.ShipmentAddress
+	82, // 16: shinkansen.delivery.Shipment.dispatched_at:type_name -> google.protobuf.Timestamp
+	82, // 17: shinkansen.delivery.Shipment.pickup_deadline_at:type_name -> google.protobuf.Timestamp
+	1,  // 18: shinkansen.delivery.PickupPoint.type:type_name -> shinkansen.delivery.PickupPointType
+	82, // 19: shinkansen.delivery.TrackingEvent.timestamp:type_name -> google.protobuf.Timestamp
+	82, // 20: shinkansen.delivery.GetDeliverySlotsRequest.date:type_name -> google.protobuf.Timestamp
+	5,  // 21: shinkansen.delivery.GetDeliverySlotsResponse.slots:type_name -> shinkansen.delivery.DeliverySlot
+	82, // 22: shinkansen.delivery.ReserveDeliverySlotResponse.reserved_at:type_name -> google.protobuf.Timestamp
+	4,  // 23: shinkansen.delivery.ReserveDeliverySlotResponse.status:type_name -> shinkansen.delivery.ReservationStatus
+	82, // 24: shinkansen.delivery.ReserveDeliverySlotResponse.expires_at:type_name -> google.protobuf.Timestamp
+	82, // 25: shinkansen.delivery.ConfirmReservationResponse.confirmed_at:type_name -> google.protobuf.Timestamp
+	10, // 26: shinkansen.delivery.GetShipmentResponse.shipment:type_name -> shinkansen.delivery.Shipment
+	3,  // 27: shinkansen.delivery.UpdateShipmentStatusRequest.status:type_name -> shinkansen.delivery.ShipmentStatus
+	83, // 28: shinkansen.delivery.UpdateShipmentStatusRequest.collected_amount:type_name -> shinkansen.common.Money
+	82, // 29: shinkansen.delivery.AddTrackingEventRequest.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 30: shinkansen.delivery.AddTrackingEventResponse.event:type_name -> shinkansen.delivery.TrackingEvent
+	13, // 31: shinkansen.delivery.ListTrackingEventsResponse.events:type_name -> shinkansen.delivery.TrackingEvent
+	2,  // 32: shinkansen.delivery.DispatchShipmentRequest.service_level:type_name -> shinkansen.delivery.ServiceLevel
+	11, // 33: shinkansen.delivery.DispatchShipmentRequest.recipient:type_name -> shinkansen.delivery.ShipmentAddress
+	10, // 34: shinkansen.delivery.DispatchShipmentResponse.shipment:type_name -> shinkansen.delivery.Shipment
+	10, // 35: shinkansen.delivery.CancelShipmentResponse.shipment:type_name -> shinkansen.delivery.Shipment
+	82, // 36: shinkansen.delivery.GetShippingLabelsRequest.date:type_name -> google.protobuf.Timestamp
+	83, // 37: shinkansen.delivery.ParcelRate.base_amount:type_name -> shinkansen.common.Money
+	83, // 38: shinkansen.delivery.ParcelRate.cool_surcharge:type_name -> shinkansen.common.Money
+	40, // 39: shinkansen.delivery.ShippingQuote.parcels:type_name -> shinkansen.delivery.ParcelRate
+	83, // 40: shinkansen.delivery.ShippingQuote.base_amount:type_name -> shinkansen.common.Money
+	83, // 41: shinkansen.delivery.ShippingQuote.cool_surcharge:type_name -> shinkansen.common.Money
+	83, // 42: shinkansen.delivery.ShippingQuote.express_surcharge:type_name -> shinkansen.common.Money
+	83, // 43: shinkansen.delivery.ShippingQuote.time_window_fee:type_name -> shinkansen.common.Money
+	83, // 44: shinkansen.delivery.ShippingQuote.remote_island_fee:type_name -> shinkansen.common.Money
+	83, // 45: shinkansen.delivery.ShippingQuote.discount:type_name -> shinkansen.common.Money
+	83, // 46: shinkansen.delivery.ShippingQuote.total:type_name -> shinkansen.common.Money
+	83, // 47: shinkansen.delivery.ShippingQuote.free_shipping_remaining:type_name -> shinkansen.common.Money
+	82, // 48: shinkansen.delivery.ShippingQuote.estimated_delivery_at:type_name -> google.protobuf.Timestamp
+	39, // 49: shinkansen.delivery.QuoteShippingRequest.parcels:type_name -> shinkansen.delivery.Parcel
+	2,  // 50: shinkansen.delivery.QuoteShippingRequest.service_level:type_name -> shinkansen.delivery.ServiceLevel
+	0,  // 51: shinkansen.delivery.QuoteShippingRequest.time_window:type_name -> shinkansen.delivery.DeliveryTimeWindow
+	83, // 52: shinkansen.delivery.QuoteShippingRequest.order_subtotal:type_name -> shinkansen.common.Money
+	41, // 53: shinkansen.delivery.QuoteShippingResponse.quote:type_name -> shinkansen.delivery.ShippingQuote
+	83, // 54: shinkansen.delivery.RequestCashOnDeliveryRequest.amount:type_name -> shinkansen.common.Money
+	10, // 55: shinkansen.delivery.RequestCashOnDeliveryResponse.shipment:type_name -> shinkansen.delivery.Shipment
+	9,  // 56: shinkansen.delivery.ResolveDeliveryZoneResponse.zone:type_name -> shinkansen.delivery.DeliveryZone
+	82, // 57: shinkansen.delivery.GetDeliverySlotsForAddressRequest.date:type_name -> google.protobuf.Timestamp
+	47, // 58: shinkansen.delivery.GetDeliverySlotsForAddressResponse.zone:type_name -> shinkansen.delivery.ResolveDeliveryZoneResponse
+	5,  // 59: shinkansen.delivery.GetDeliverySlotsForAddressResponse.slots:type_name -> shinkansen.delivery.DeliverySlot
+	6,  // 60: shinkansen.delivery.ListSlotTemplatesResponse.templates:type_name -> shinkansen.delivery.SlotTemplate
+	6,  // 61: shinkansen.delivery.CreateSlotTemplateRequest.template:type_name -> shinkansen.delivery.SlotTemplate
+	6,  // 62: shinkansen.delivery.CreateSlotTemplateResponse.template:type_name -> shinkansen.delivery.SlotTemplate
+	6,  // 63: shinkansen.delivery.UpdateSlotTemplateRequest.template:type_name -> shinkansen.delivery.SlotTemplate
+	6,  // 64: shinkansen.delivery.UpdateSlotTemplateResponse.template:type_name -> shinkansen.delivery.SlotTemplate
+	7,  // 65: shinkansen.delivery.ListSlotBlackoutsResponse.blackouts:type_name -> shinkansen.delivery.SlotBlackout
+	7,  // 66: shinkansen.delivery.CreateSlotBlackoutRequest.blackout:type_name -> shinkansen.delivery.SlotBlackout
+	7,  // 67: shinkansen.delivery.CreateSlotBlackoutResponse.blackout:type_name -> shinkansen.delivery.SlotBlackout
+	8,  // 68: shinkansen.delivery.ListClosureDaysResponse.closure_days:type_name -> shinkansen.delivery.ClosureDay
+	8,  // 69: shinkansen.delivery.CreateClosureDayRequest.closure_day:type_name -> shinkansen.delivery.ClosureDay
+	8,  // 70: shinkansen.delivery.CreateClosureDayResponse.closure_day:type_name -> shinkansen.delivery.ClosureDay
+	1,  // 71: shinkansen.delivery.ListPickupPointsRequest.type:type_name -> shinkansen.delivery.PickupPointType
+	12, // 72: shinkansen.delivery.ListPickupPointsResponse.pickup_points:type_name -> shinkansen.delivery.PickupPoint
+	12, // 73: shinkansen.delivery.GetPickupPointResponse.pickup_point:type_name -> shinkansen.delivery.PickupPoint
+	12, // 74: shinkansen.delivery.CreatePickupPointRequest.pickup_point:type_name -> shinkansen.delivery.PickupPoint
+	12, // 75: shinkansen.delivery.CreatePickupPointResponse.pickup_point:type_name -> shinkansen.delivery.PickupPoint
+	12, // 76: shinkansen.delivery.UpdatePickupPointRequest.pickup_point:type_name -> shinkansen.delivery.PickupPoint
+	12, // 77: shinkansen.delivery.UpdatePickupPointResponse.pickup_point:type_name -> shinkansen.delivery.PickupPoint
+	10, // 78: shinkansen.delivery.ConfirmPickupResponse.shipment:type_name -> shinkansen.delivery.Shipment
+	79, // [79:79] is the sub-list for method output_type
+	79, // [79:79] is the sub-list for method input_type
+	79, // [79:79] is the sub-list for extension type_name
+	79, // [79:79] is the sub-list for extension extendee
+	0,  // [0:79] is the sub-list for field type_name
 }
 
 func init() { file_delivery_delivery_messages_proto_init() }
@@ -910,8 +5327,8 @@ func file_delivery_delivery_messages_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_delivery_delivery_messages_proto_rawDesc), len(file_delivery_delivery_messages_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   11,
+			NumEnums:      5,
+			NumMessages:   77,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
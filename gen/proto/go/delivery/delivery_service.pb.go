@@ -26,37 +26,182 @@ var File_delivery_delivery_service_proto protoreflect.FileDescriptor
 
 const file_delivery_delivery_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1fdelivery/delivery_service.proto\x12\x13shinkansen.delivery\x1a delivery/delivery_messages.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x13shared/common.proto2\xdf\x04\n" +
+	"\x1fdelivery/delivery_service.proto\x12\x13shinkansen.delivery\x1a delivery/delivery_messages.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x13shared/common.proto2\xec)\n" +
 	"\x0fDeliveryService\x12\x8b\x01\n" +
-	"\x10GetDeliverySlots\x12,.shinkansen.delivery.GetDeliverySlotsRequest\x1a-.shinkansen.delivery.GetDeliverySlotsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/delivery/slots\x12\xa1\x01\n" +
-	"\x13ReserveDeliverySlot\x12/.shinkansen.delivery.ReserveDeliverySlotRequest\x1a0.shinkansen.delivery.ReserveDeliverySlotResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/delivery/slots/{slot_id}\x12\x85\x01\n" +
+	"\x10GetDeliverySlots\x12,.shinkansen.delivery.GetDeliverySlotsRequest\x1a-.shinkansen.delivery.GetDeliverySlotsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/delivery/slots\x12\x9c\x01\n" +
+	"\x13ResolveDeliveryZone\x12/.shinkansen.delivery.ResolveDeliveryZoneRequest\x1a0.shinkansen.delivery.ResolveDeliveryZoneResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/delivery/zones/resolve\x12\xb1\x01\n" +
+	"\x1aGetDeliverySlotsForAddress\x126.shinkansen.delivery.GetDeliverySlotsForAddressRequest\x1a7.shinkansen.delivery.GetDeliverySlotsForAddressResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/delivery/address-slots\x12\xb0\x01\n" +
+	"\x11ListSlotTemplates\x12-.shinkansen.delivery.ListSlotTemplatesRequest\x1a..shinkansen.delivery.ListSlotTemplatesResponse\"<\x82\xd3\xe4\x93\x026\x124/v1/delivery/zones/{delivery_zone_id}/slot-templates\x12\xa4\x01\n" +
+	"\x12CreateSlotTemplate\x12..shinkansen.delivery.CreateSlotTemplateRequest\x1a/.shinkansen.delivery.CreateSlotTemplateResponse\"-\x82\xd3\xe4\x93\x02':\btemplate\"\x1b/v1/delivery/slot-templates\x12\xb2\x01\n" +
+	"\x12UpdateSlotTemplate\x12..shinkansen.delivery.UpdateSlotTemplateRequest\x1a/.shinkansen.delivery.UpdateSlotTemplateResponse\";\x82\xd3\xe4\x93\x025:\btemplate\x1a)/v1/delivery/slot-templates/{template.id}\x12\x9f\x01\n" +
+	"\x12DeleteSlotTemplate\x12..shinkansen.delivery.DeleteSlotTemplateRequest\x1a/.shinkansen.delivery.DeleteSlotTemplateResponse\"(\x82\xd3\xe4\x93\x02\"* /v1/delivery/slot-templates/{id}\x12\x97\x01\n" +
+	"\x11ListSlotBlackouts\x12-.shinkansen.delivery.ListSlotBlackoutsRequest\x1a..shinkansen.delivery.ListSlotBlackoutsResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/delivery/slot-blackouts\x12\xa4\x01\n" +
+	"\x12CreateSlotBlackout\x12..shinkansen.delivery.CreateSlotBlackoutRequest\x1a/.shinkansen.delivery.CreateSlotBlackoutResponse\"-\x82\xd3\xe4\x93\x02':\bblackout\"\x1b/v1/delivery/slot-blackouts\x12\x9f\x01\n" +
+	"\x12DeleteSlotBlackout\x12..shinkansen.delivery.DeleteSlotBlackoutRequest\x1a/.shinkansen.delivery.DeleteSlotBlackoutResponse\"(\x82\xd3\xe4\x93\x02\"* /v1/delivery/slot-blackouts/{id}\x12\x8f\x01\n" +
+	"\x0fListClosureDays\x12+.shinkansen.delivery.ListClosureDaysRequest\x1a,.shinkansen.delivery.ListClosureDaysResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/delivery/closure-days\x12\x9f\x01\n" +
+	"\x10CreateClosureDay\x12,.shinkansen.delivery.CreateClosureDayRequest\x1a-.shinkansen.delivery.CreateClosureDayResponse\".\x82\xd3\xe4\x93\x02(:\vclosure_day\"\x19/v1/delivery/closure-days\x12\x97\x01\n" +
+	"\x10DeleteClosureDay\x12,.shinkansen.delivery.DeleteClosureDayRequest\x1a-.shinkansen.delivery.DeleteClosureDayResponse\"&\x82\xd3\xe4\x93\x02 *\x1e/v1/delivery/closure-days/{id}\x12\x93\x01\n" +
+	"\x10ListPickupPoints\x12,.shinkansen.delivery.ListPickupPointsRequest\x1a-.shinkansen.delivery.ListPickupPointsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/delivery/pickup-points\x12\x92\x01\n" +
+	"\x0eGetPickupPoint\x12*.shinkansen.delivery.GetPickupPointRequest\x1a+.shinkansen.delivery.GetPickupPointResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/delivery/pickup-points/{id}\x12\xa4\x01\n" +
+	"\x11CreatePickupPoint\x12-.shinkansen.delivery.CreatePickupPointRequest\x1a..shinkansen.delivery.CreatePickupPointResponse\"0\x82\xd3\xe4\x93\x02*:\fpickup_point\"\x1a/v1/delivery/pickup-points\x12\xb6\x01\n" +
+	"\x11UpdatePickupPoint\x12-.shinkansen.delivery.UpdatePickupPointRequest\x1a..shinkansen.delivery.UpdatePickupPointResponse\"B\x82\xd3\xe4\x93\x02<:\fpickup_point\x1a,/v1/delivery/pickup-points/{pickup_point.id}\x12\xa6\x01\n" +
+	"\x15GenerateDeliverySlots\x121.shinkansen.delivery.GenerateDeliverySlotsRequest\x1a2.shinkansen.delivery.GenerateDeliverySlotsResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/delivery/slots/generate\x12\xa1\x01\n" +
+	"\x13ReserveDeliverySlot\x12/.shinkansen.delivery.ReserveDeliverySlotRequest\x1a0.shinkansen.delivery.ReserveDeliverySlotResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/delivery/slots/{slot_id}\x12\xae\x01\n" +
+	"\x12ConfirmReservation\x12..shinkansen.delivery.ConfirmReservationRequest\x1a/.shinkansen.delivery.ConfirmReservationResponse\"7\x82\xd3\xe4\x93\x021:\x01*\",/v1/delivery/reservations/{order_id}/confirm\x12\xa6\x01\n" +
+	"\x13ReleaseDeliverySlot\x12/.shinkansen.delivery.ReleaseDeliverySlotRequest\x1a0.shinkansen.delivery.ReleaseDeliverySlotResponse\",\x82\xd3\xe4\x93\x02&*$/v1/delivery/reservations/{order_id}\x12\x85\x01\n" +
 	"\vGetShipment\x12'.shinkansen.delivery.GetShipmentRequest\x1a(.shinkansen.delivery.GetShipmentResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/shipments/{shipment_id}\x12\x91\x01\n" +
-	"\x14UpdateShipmentStatus\x120.shinkansen.delivery.UpdateShipmentStatusRequest\x1a\x18.shinkansen.common.Empty\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/shipments/{shipment_id}/statusB>Z<github.com/afasari/shinkansen-commerce/gen/proto/go/deliveryb\x06proto3"
+	"\x14UpdateShipmentStatus\x120.shinkansen.delivery.UpdateShipmentStatusRequest\x1a\x18.shinkansen.common.Empty\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/shipments/{shipment_id}/status\x12\xa7\x01\n" +
+	"\x10AddTrackingEvent\x12,.shinkansen.delivery.AddTrackingEventRequest\x1a-.shinkansen.delivery.AddTrackingEventResponse\"6\x82\xd3\xe4\x93\x020:\x01*\"+/v1/shipments/{shipment_id}/tracking-events\x12\xaa\x01\n" +
+	"\x12ListTrackingEvents\x12..shinkansen.delivery.ListTrackingEventsRequest\x1a/.shinkansen.delivery.ListTrackingEventsResponse\"3\x82\xd3\xe4\x93\x02-\x12+/v1/shipments/{shipment_id}/tracking-events\x12\x92\x01\n" +
+	"\x10DispatchShipment\x12,.shinkansen.delivery.DispatchShipmentRequest\x1a-.shinkansen.delivery.DispatchShipmentResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/shipments/dispatch\x12\x98\x01\n" +
+	"\x0eCancelShipment\x12*.shinkansen.delivery.CancelShipmentRequest\x1a+.shinkansen.delivery.CancelShipmentResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/shipments/{shipment_id}/cancel\x12\x95\x01\n" +
+	"\rConfirmPickup\x12).shinkansen.delivery.ConfirmPickupRequest\x1a*.shinkansen.delivery.ConfirmPickupResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/shipments/{shipment_id}/pickup\x12\xc4\x01\n" +
+	"\x16ValidateTrackingNumber\x122.shinkansen.delivery.ValidateTrackingNumberRequest\x1a3.shinkansen.delivery.ValidateTrackingNumberResponse\"A\x82\xd3\xe4\x93\x02;\x129/v1/carriers/{carrier}/tracking-numbers/{tracking_number}\x12\x9a\x01\n" +
+	"\x10GetShippingLabel\x12,.shinkansen.delivery.GetShippingLabelRequest\x1a-.shinkansen.delivery.GetShippingLabelResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/shipments/{shipment_id}/label\x12\x90\x01\n" +
+	"\x11GetShippingLabels\x12-.shinkansen.delivery.GetShippingLabelsRequest\x1a..shinkansen.delivery.GetShippingLabelsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/shipments/labels\x12\x85\x01\n" +
+	"\rQuoteShipping\x12).shinkansen.delivery.QuoteShippingRequest\x1a*.shinkansen.delivery.QuoteShippingResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/shipping/quote\x12\xa9\x01\n" +
+	"\x15RequestCashOnDelivery\x121.shinkansen.delivery.RequestCashOnDeliveryRequest\x1a2.shinkansen.delivery.RequestCashOnDeliveryResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/shipments/cash-on-deliveryB>Z<github.com/afasari/shinkansen-commerce/gen/proto/go/deliveryb\x06proto3"
 
 var file_delivery_delivery_service_proto_goTypes = []any{
-	(*GetDeliverySlotsRequest)(nil),     // 0: shinkansen.delivery.GetDeliverySlotsRequest
-	(*ReserveDeliverySlotRequest)(nil),  // 1: shinkansen.delivery.ReserveDeliverySlotRequest
-	(*GetShipmentRequest)(nil),          // 2: shinkansen.delivery.GetShipmentRequest
-	(*UpdateShipmentStatusRequest)(nil), // 3: shinkansen.delivery.UpdateShipmentStatusRequest
-	(*GetDeliverySlotsResponse)(nil),    // 4: shinkansen.delivery.GetDeliverySlotsResponse
-	(*ReserveDeliverySlotResponse)(nil), // 5: shinkansen.delivery.ReserveDeliverySlotResponse
-	(*GetShipmentResponse)(nil),         // 6: shinkansen.delivery.GetShipmentResponse
-	(*shared.Empty)(nil),                // 7: shinkansen.common.Empty
+	(*GetDeliverySlotsRequest)(nil),            // 0: shinkansen.delivery.GetDeliverySlotsRequest
+	(*ResolveDeliveryZoneRequest)(nil),         // 1: shinkansen.delivery.ResolveDeliveryZoneRequest
+	(*GetDeliverySlotsForAddressRequest)(nil),  // 2: shinkansen.delivery.GetDeliverySlotsForAddressRequest
+	(*ListSlotTemplatesRequest)(nil),           // 3: shinkansen.delivery.ListSlotTemplatesRequest
+	(*CreateSlotTemplateRequest)(nil),          // 4: shinkansen.delivery.CreateSlotTemplateRequest
+	(*UpdateSlotTemplateRequest)(nil),          // 5: shinkansen.delivery.UpdateSlotTemplateRequest
+	(*DeleteSlotTemplateRequest)(nil),          // 6: shinkansen.delivery.DeleteSlotTemplateRequest
+	(*ListSlotBlackoutsRequest)(nil),           // 7: shinkansen.delivery.ListSlotBlackoutsRequest
+	(*CreateSlotBlackoutRequest)(nil),          // 8: shinkansen.delivery.CreateSlotBlackoutRequest
+	(*DeleteSlotBlackoutRequest)(nil),          // 9: shinkansen.delivery.DeleteSlotBlackoutRequest
+	(*ListClosureDaysRequest)(nil),             // 10: shinkansen.delivery.ListClosureDaysRequest
+	(*CreateClosureDayRequest)(nil),            // 11: shinkansen.delivery.CreateClosureDayRequest
+	(*DeleteClosureDayRequest)(nil),            // 12: shinkansen.delivery.DeleteClosureDayRequest
+	(*ListPickupPointsRequest)(nil),            // 13: shinkansen.delivery.ListPickupPointsRequest
+	(*GetPickupPointRequest)(nil),              // 14: shinkansen.delivery.GetPickupPointRequest
+	(*CreatePickupPointRequest)(nil),           // 15: shinkansen.delivery.CreatePickupPointRequest
+	(*UpdatePickupPointRequest)(nil),           // 16: shinkansen.delivery.UpdatePickupPointRequest
+	(*GenerateDeliverySlotsRequest)(nil),       // 17: shinkansen.delivery.GenerateDeliverySlotsRequest
+	(*ReserveDeliverySlotRequest)(nil),         // 18: shinkansen.delivery.ReserveDeliverySlotRequest
+	(*ConfirmReservationRequest)(nil),          // 19: shinkansen.delivery.ConfirmReservationRequest
+	(*ReleaseDeliverySlotRequest)(nil),         // 20: shinkansen.delivery.ReleaseDeliverySlotRequest
+	(*GetShipmentRequest)(nil),                 // 21: shinkansen.delivery.GetShipmentRequest
+	(*UpdateShipmentStatusRequest)(nil),        // 22: shinkansen.delivery.UpdateShipmentStatusRequest
+	(*AddTrackingEventRequest)(nil),            // 23: shinkansen.delivery.AddTrackingEventRequest
+	(*ListTrackingEventsRequest)(nil),          // 24: shinkansen.delivery.ListTrackingEventsRequest
+	(*DispatchShipmentRequest)(nil),            // 25: shinkansen.delivery.DispatchShipmentRequest
+	(*CancelShipmentRequest)(nil),              // 26: shinkansen.delivery.CancelShipmentRequest
+	(*ConfirmPickupRequest)(nil),               // 27: shinkansen.delivery.ConfirmPickupRequest
+	(*ValidateTrackingNumberRequest)(nil),      // 28: shinkansen.delivery.ValidateTrackingNumberRequest
+	(*GetShippingLabelRequest)(nil),            // 29: shinkansen.delivery.GetShippingLabelRequest
+	(*GetShippingLabelsRequest)(nil),           // 30: shinkansen.delivery.GetShippingLabelsRequest
+	(*QuoteShippingRequest)(nil),               // 31: shinkansen.delivery.QuoteShippingRequest
+	(*RequestCashOnDeliveryRequest)(nil),       // 32: shinkansen.delivery.RequestCashOnDeliveryRequest
+	(*GetDeliverySlotsResponse)(nil),           // 33: shinkansen.delivery.GetDeliverySlotsResponse
+	(*ResolveDeliveryZoneResponse)(nil),        // 34: shinkansen.delivery.ResolveDeliveryZoneResponse
+	(*GetDeliverySlotsForAddressResponse)(nil), // 35: shinkansen.delivery.GetDeliverySlotsForAddressResponse
+	(*ListSlotTemplatesResponse)(nil),          // 36: shinkansen.delivery.ListSlotTemplatesResponse
+	(*CreateSlotTemplateResponse)(nil),         // 37: shinkansen.delivery.CreateSlotTemplateResponse
+	(*UpdateSlotTemplateResponse)(nil),         // 38: shinkansen.delivery.UpdateSlotTemplateResponse
+	(*DeleteSlotTemplateResponse)(nil),         // 39: shinkansen.delivery.DeleteSlotTemplateResponse
+	(*ListSlotBlackoutsResponse)(nil),          // 40: shinkansen.delivery.ListSlotBlackoutsResponse
+	(*CreateSlotBlackoutResponse)(nil),         // 41: shinkansen.delivery.CreateSlotBlackoutResponse
+	(*DeleteSlotBlackoutResponse)(nil),         // 42: shinkansen.delivery.DeleteSlotBlackoutResponse
+	(*ListClosureDaysResponse)(nil),            // 43: shinkansen.delivery.ListClosureDaysResponse
+	(*CreateClosureDayResponse)(nil),           // 44: shinkansen.delivery.CreateClosureDayResponse
+	(*DeleteClosureDayResponse)(nil),           // 45: shinkansen.delivery.DeleteClosureDayResponse
+	(*ListPickupPointsResponse)(nil),           // 46: shinkansen.delivery.ListPickupPointsResponse
+	(*GetPickupPointResponse)(nil),             // 47: shinkansen.delivery.GetPickupPointResponse
+	(*CreatePickupPointResponse)(nil),          // 48: shinkansen.delivery.CreatePickupPointResponse
+	(*UpdatePickupPointResponse)(nil),          // 49: shinkansen.delivery.UpdatePickupPointResponse
+	(*GenerateDeliverySlotsResponse)(nil),      // 50: shinkansen.delivery.GenerateDeliverySlotsResponse
+	(*ReserveDeliverySlotResponse)(nil),        // 51: shinkansen.delivery.ReserveDeliverySlotResponse
+	(*ConfirmReservationResponse)(nil),         // 52: shinkansen.delivery.ConfirmReservationResponse
+	(*ReleaseDeliverySlotResponse)(nil),        // 53: shinkansen.delivery.ReleaseDeliverySlotResponse
+	(*GetShipmentResponse)(nil),                // 54: shinkansen.delivery.GetShipmentResponse
+	(*shared.Empty)(nil),                       // 55: shinkansen.common.Empty
+	(*AddTrackingEventResponse)(nil),           // 56: shinkansen.delivery.AddTrackingEventResponse
+	(*ListTrackingEventsResponse)(nil),         // 57: shinkansen.delivery.ListTrackingEventsResponse
+	(*DispatchShipmentResponse)(nil),           // 58: shinkansen.delivery.DispatchShipmentResponse
+	(*CancelShipmentResponse)(nil),             // 59: shinkansen.delivery.CancelShipmentResponse
+	(*ConfirmPickupResponse)(nil),              // 60: shinkansen.delivery.ConfirmPickupResponse
+	(*ValidateTrackingNumberResponse)(nil),     // 61: shinkansen.delivery.ValidateTrackingNumberResponse
+	(*GetShippingLabelResponse)(nil),           // 62: shinkansen.delivery.GetShippingLabelResponse
+	(*GetShippingLabelsResponse)(nil),          // 63: shinkansen.delivery.GetShippingLabelsResponse
+	(*QuoteShippingResponse)(nil),              // 64: shinkansen.delivery.QuoteShippingResponse
+	(*RequestCashOnDeliveryResponse)(nil),      // 65: shinkansen.delivery.RequestCashOnDeliveryResponse
 }
 var file_delivery_delivery_service_proto_depIdxs = []int32{
-	0, // 0: shinkansen.delivery.DeliveryService.GetDeliverySlots:input_type -> shinkansen.delivery.GetDeliverySlotsRequest
-	1, // 1: shinkansen.delivery.DeliveryService.ReserveDeliverySlot:input_type -> shinkansen.delivery.ReserveDeliverySlotRequest
-	2, // 2: shinkansen.delivery.DeliveryService.GetShipment:input_type -> shinkansen.delivery.GetShipmentRequest
-	3, // 3: shinkansen.delivery.DeliveryService.UpdateShipmentStatus:input_type -> shinkansen.delivery.UpdateShipmentStatusRequest
-	4, // 4: shinkansen.delivery.DeliveryService.GetDeliverySlots:output_type -> shinkansen.delivery.GetDeliverySlotsResponse
-	5, // 5: shinkansen.delivery.DeliveryService.ReserveDeliverySlot:output_type -> shinkansen.delivery.ReserveDeliverySlotResponse
-	6, // 6: shinkansen.delivery.DeliveryService.GetShipment:output_type -> shinkansen.delivery.GetShipmentResponse
-	7, // 7: shinkansen.delivery.DeliveryService.UpdateShipmentStatus:output_type -> shinkansen.common.Empty
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: shinkansen.delivery.DeliveryService.GetDeliverySlots:input_type -> shinkansen.delivery.GetDeliverySlotsRequest
+	1,  // 1: shinkansen.delivery.DeliveryService.ResolveDeliveryZone:input_type -> shinkansen.delivery.ResolveDeliveryZoneRequest
+	2,  // 2: shinkansen.delivery.DeliveryService.GetDeliverySlotsForAddress:input_type -> shinkansen.delivery.GetDeliverySlotsForAddressRequest
+	3,  // 3: shinkansen.delivery.DeliveryService.ListSlotTemplates:input_type -> shinkansen.delivery.ListSlotTemplatesRequest
+	4,  // 4: shinkansen.delivery.DeliveryService.CreateSlotTemplate:input_type -> shinkansen.delivery.CreateSlotTemplateRequest
+	5,  // 5: shinkansen.delivery.DeliveryService.UpdateSlotTemplate:input_type -> shinkansen.delivery.UpdateSlotTemplateRequest
+	6,  // 6: shinkansen.delivery.DeliveryService.DeleteSlotTemplate:input_type -> shinkansen.delivery.DeleteSlotTemplateRequest
+	7,  // 7: shinkansen.delivery.DeliveryService.ListSlotBlackouts:input_type -> shinkansen.delivery.ListSlotBlackoutsRequest
+	8,  // 8: shinkansen.delivery.DeliveryService.CreateSlotBlackout:input_type -> shinkansen.delivery.CreateSlotBlackoutRequest
+	9,  // 9: shinkansen.delivery.DeliveryService.DeleteSlotBlackout:input_type -> shinkansen.delivery.DeleteSlotBlackoutRequest
+	10, // 10: shinkansen.delivery.DeliveryService.ListClosureDays:input_type -> shinkansen.delivery.ListClosureDaysRequest
+	11, // 11: shinkansen.delivery.DeliveryService.CreateClosureDay:input_type -> shinkansen.delivery.CreateClosureDayRequest
+	12, // 12: shinkansen.delivery.DeliveryService.DeleteClosureDay:input_type -> shinkansen.delivery.DeleteClosureDayRequest
+	13, // 13: shinkansen.delivery.DeliveryService.ListPickupPoints:input_type -> shinkansen.delivery.ListPickupPointsRequest
+	14, // 14: shinkansen.delivery.DeliveryService.GetPickupPoint:input_type -> shinkansen.delivery.GetPickupPointRequest
+	15, // 15: shinkansen.delivery.DeliveryService.CreatePickupPoint:input_type -> shinkansen.delivery.CreatePickupPointRequest
+	16, // 16: shinkansen.delivery.DeliveryService.UpdatePickupPoint:input_type -> shinkansen.delivery.UpdatePickupPointRequest
+	17, // 17: shinkansen.delivery.DeliveryService.GenerateDeliverySlots:input_type -> shinkansen.delivery.GenerateDeliverySlotsRequest
+	18, // 18: shinkansen.delivery.DeliveryService.ReserveDeliverySlot:input_type -> shinkansen.delivery.ReserveDeliverySlotRequest
+	19, // 19: shinkansen.delivery.DeliveryService.ConfirmReservation:input_type -> shinkansen.delivery.ConfirmReservationRequest
+	20, // 20: shinkansen.delivery.DeliveryService.ReleaseDeliverySlot:input_type -> shinkansen.delivery.ReleaseDeliverySlotRequest
+	21, // 21: shinkansen.delivery.DeliveryService.GetShipment:input_type -> shinkansen.delivery.GetShipmentRequest
+	22, // 22: shinkansen.delivery.DeliveryService.UpdateShipmentStatus:input_type -> shinkansen.delivery.UpdateShipmentStatusRequest
+	23, // 23: shinkansen.delivery.DeliveryService.AddTrackingEvent:input_type -> shinkansen.delivery.AddTrackingEventRequest
+	24, // 24: shinkansen.delivery.DeliveryService.ListTrackingEvents:input_type -> shinkansen.delivery.ListTrackingEventsRequest
+	25, // 25: shinkansen.delivery.DeliveryService.DispatchShipment:input_type -> shinkansen.delivery.DispatchShipmentRequest
+	26, // 26: shinkansen.delivery.DeliveryService.CancelShipment:input_type -> shinkansen.delivery.CancelShipmentRequest
+	27, // 27: shinkansen.delivery.DeliveryService.ConfirmPickup:input_type -> shinkansen.delivery.ConfirmPickupRequest
+	28, // 28: shinkansen.delivery.DeliveryService.ValidateTrackingNumber:input_type -> shinkansen.delivery.ValidateTrackingNumberRequest
+	29, // 29: shinkansen.delivery.DeliveryService.GetShippingLabel:input_type -> shinkansen.delivery.GetShippingLabelRequest
+	30, // 30: shinkansen.delivery.DeliveryService.GetShippingLabels:input_type -> shinkansen.delivery.GetShippingLabelsRequest
+	31, // 31: shinkansen.delivery.DeliveryService.QuoteShipping:input_type -> shinkansen.delivery.QuoteShippingRequest
+	32, // 32: shinkansen.delivery.DeliveryService.RequestCashOnDelivery:input_type -> shinkansen.delivery.RequestCashOnDeliveryRequest
+	33, // 33: shinkansen.delivery.DeliveryService.GetDeliverySlots:output_type -> shinkansen.delivery.GetDeliverySlotsResponse
+	34, // 34: shinkansen.delivery.DeliveryService.ResolveDeliveryZone:output_type -> shinkansen.delivery.ResolveDeliveryZoneResponse
+	35, // 35: shinkansen.delivery.DeliveryService.GetDeliverySlotsForAddress:output_type -> shinkansen.delivery.GetDeliverySlotsForAddressResponse
+	36, // 36: shinkansen.delivery.DeliveryService.ListSlotTemplates:output_type -> shinkansen.delivery.ListSlotTemplatesResponse
+	37, // 37: shinkansen.delivery.DeliveryService.CreateSlotTemplate:output_type -> shinkansen.delivery.CreateSlotTemplateResponse
+	38, // 38: shinkansen.delivery.DeliveryService.UpdateSlotTemplate:output_type -> shinkansen.delivery.UpdateSlotTemplateResponse
+	39, // 39: shinkansen.delivery.DeliveryService.DeleteSlotTemplate:output_type -> shinkansen.delivery.DeleteSlotTemplateResponse
+	40, // 40: shinkansen.delivery.DeliveryService.ListSlotBlackouts:output_type -> shinkansen.delivery.ListSlotBlackoutsResponse
+	41, // 41: shinkansen.delivery.DeliveryService.CreateSlotBlackout:output_type -> shinkansen.delivery.CreateSlotBlackoutResponse
+	42, // 42: shinkansen.delivery.DeliveryService.DeleteSlotBlackout:output_type -> shinkansen.delivery.DeleteSlotBlackoutResponse
+	43, // 43: shinkansen.delivery.DeliveryService.ListClosureDays:output_type -> shinkansen.delivery.ListClosureDaysResponse
+	44, // 44: shinkansen.delivery.DeliveryService.CreateClosureDay:output_type -> shinkansen.delivery.CreateClosureDayResponse
+	45, // 45: shinkansen.delivery.DeliveryService.DeleteClosureDay:output_type -> shinkansen.delivery.DeleteClosureDayResponse
+	46, // 46: shinkansen.delivery.DeliveryService.ListPickupPoints:output_type -> shinkansen.delivery.ListPickupPointsResponse
+	47, // 47: shinkansen.delivery.DeliveryService.GetPickupPoint:output_type -> shinkansen.delivery.GetPickupPointResponse
+	48, // 48: shinkansen.delivery.DeliveryService.CreatePickupPoint:output_type -> shinkansen.delivery.CreatePickupPointResponse
+	49, // 49: shinkansen.delivery.DeliveryService.UpdatePickupPoint:output_type -> shinkansen.delivery.UpdatePickupPointResponse
+	50, // 50: shinkansen.delivery.DeliveryService.GenerateDeliverySlots:output_type -> shinkansen.delivery.GenerateDeliverySlotsResponse
+	51, // 51: shinkansen.delivery.DeliveryService.ReserveDeliverySlot:output_type -> shinkansen.delivery.ReserveDeliverySlotResponse
+	52, // 52: shinkansen.delivery.DeliveryService.ConfirmReservation:output_type -> shinkansen.delivery.ConfirmReservationResponse
+	53, // 53: shinkansen.delivery.DeliveryService.ReleaseDeliverySlot:output_type -> shinkansen.delivery.ReleaseDeliverySlotResponse
+	54, // 54: shinkansen.delivery.DeliveryService.GetShipment:output_type -> shinkansen.delivery.GetShipmentResponse
+	55, // 55: shinkansen.delivery.DeliveryService.UpdateShipmentStatus:output_type -> shinkansen.common.Empty
+	56, // 56: shinkansen.delivery.DeliveryService.AddTrackingEvent:output_type -> shinkansen.delivery.AddTrackingEventResponse
+	57, // 57: shinkansen.delivery.DeliveryService.ListTrackingEvents:output_type -> shinkansen.delivery.ListTrackingEventsResponse
+	58, // 58: shinkansen.delivery.DeliveryService.DispatchShipment:output_type -> shinkansen.delivery.DispatchShipmentResponse
+	59, // 59: shinkansen.delivery.DeliveryService.CancelShipment:output_type -> shinkansen.delivery.CancelShipmentResponse
+	60, // 60: shinkansen.delivery.DeliveryService.ConfirmPickup:output_type -> shinkansen.delivery.ConfirmPickupResponse
+	61, // 61: shinkansen.delivery.DeliveryService.ValidateTrackingNumber:output_type -> shinkansen.delivery.ValidateTrackingNumberResponse
+	62, // 62: shinkansen.delivery.DeliveryService.GetShippingLabel:output_type -> shinkansen.delivery.GetShippingLabelResponse
+	63, // 63: shinkansen.delivery.DeliveryService.GetShippingLabels:output_type -> shinkansen.delivery.GetShippingLabelsResponse
+	64, // 64: shinkansen.delivery.DeliveryService.QuoteShipping:output_type -> shinkansen.delivery.QuoteShippingResponse
+	65, // 65: shinkansen.delivery.DeliveryService.RequestCashOnDelivery:output_type -> shinkansen.delivery.RequestCashOnDeliveryResponse
+	33, // [33:66] is the sub-list for method output_type
+	0,  // [0:33] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_delivery_delivery_service_proto_init() }
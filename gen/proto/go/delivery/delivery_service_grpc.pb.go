@@ -20,10 +20,39 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DeliveryService_GetDeliverySlots_FullMethodName     = "/shinkansen.delivery.DeliveryService/GetDeliverySlots"
-	DeliveryService_ReserveDeliverySlot_FullMethodName  = "/shinkansen.delivery.DeliveryService/ReserveDeliverySlot"
-	DeliveryService_GetShipment_FullMethodName          = "/shinkansen.delivery.DeliveryService/GetShipment"
-	DeliveryService_UpdateShipmentStatus_FullMethodName = "/shinkansen.delivery.DeliveryService/UpdateShipmentStatus"
+	DeliveryService_GetDeliverySlots_FullMethodName           = "/shinkansen.delivery.DeliveryService/GetDeliverySlots"
+	DeliveryService_ResolveDeliveryZone_FullMethodName        = "/shinkansen.delivery.DeliveryService/ResolveDeliveryZone"
+	DeliveryService_GetDeliverySlotsForAddress_FullMethodName = "/shinkansen.delivery.DeliveryService/GetDeliverySlotsForAddress"
+	DeliveryService_ListSlotTemplates_FullMethodName          = "/shinkansen.delivery.DeliveryService/ListSlotTemplates"
+	DeliveryService_CreateSlotTemplate_FullMethodName         = "/shinkansen.delivery.DeliveryService/CreateSlotTemplate"
+	DeliveryService_UpdateSlotTemplate_FullMethodName         = "/shinkansen.delivery.DeliveryService/UpdateSlotTemplate"
+	DeliveryService_DeleteSlotTemplate_FullMethodName         = "/shinkansen.delivery.DeliveryService/DeleteSlotTemplate"
+	DeliveryService_ListSlotBlackouts_FullMethodName          = "/shinkansen.delivery.DeliveryService/ListSlotBlackouts"
+	DeliveryService_CreateSlotBlackout_FullMethodName         = "/shinkansen.delivery.DeliveryService/CreateSlotBlackout"
+	DeliveryService_DeleteSlotBlackout_FullMethodName         = "/shinkansen.delivery.DeliveryService/DeleteSlotBlackout"
+	DeliveryService_ListClosureDays_FullMethodName            = "/shinkansen.delivery.DeliveryService/ListClosureDays"
+	DeliveryService_CreateClosureDay_FullMethodName           = "/shinkansen.delivery.DeliveryService/CreateClosureDay"
+	DeliveryService_DeleteClosureDay_FullMethodName           = "/shinkansen.delivery.DeliveryService/DeleteClosureDay"
+	DeliveryService_ListPickupPoints_FullMethodName           = "/shinkansen.delivery.DeliveryService/ListPickupPoints"
+	DeliveryService_GetPickupPoint_FullMethodName             = "/shinkansen.delivery.DeliveryService/GetPickupPoint"
+	DeliveryService_CreatePickupPoint_FullMethodName          = "/shinkansen.delivery.DeliveryService/CreatePickupPoint"
+	DeliveryService_UpdatePickupPoint_FullMethodName          = "/shinkansen.delivery.DeliveryService/UpdatePickupPoint"
+	DeliveryService_GenerateDeliverySlots_FullMethodName      = "/shinkansen.delivery.DeliveryService/GenerateDeliverySlots"
+	DeliveryService_ReserveDeliverySlot_FullMethodName        = "/shinkansen.delivery.DeliveryService/ReserveDeliverySlot"
+	DeliveryService_ConfirmReservation_FullMethodName         = "/shinkansen.delivery.DeliveryService/ConfirmReservation"
+	DeliveryService_ReleaseDeliverySlot_FullMethodName        = "/shinkansen.delivery.DeliveryService/ReleaseDeliverySlot"
+	DeliveryService_GetShipment_FullMethodName                = "/shinkansen.delivery.DeliveryService/GetShipment"
+	DeliveryService_UpdateShipmentStatus_FullMethodName       = "/shinkansen.delivery.DeliveryService/UpdateShipmentStatus"
+	DeliveryService_AddTrackingEvent_FullMethodName           = "/shinkansen.delivery.DeliveryService/AddTrackingEvent"
+	DeliveryService_ListTrackingEvents_FullMethodName         = "/shinkansen.delivery.DeliveryService/ListTrackingEvents"
+	DeliveryService_DispatchShipment_FullMethodName           = "/shinkansen.delivery.DeliveryService/DispatchShipment"
+	DeliveryService_CancelShipment_FullMethodName             = "/shinkansen.delivery.DeliveryService/CancelShipment"
+	DeliveryService_ConfirmPickup_FullMethodName              = "/shinkansen.delivery.DeliveryService/ConfirmPickup"
+	DeliveryService_ValidateTrackingNumber_FullMethodName     = "/shinkansen.delivery.DeliveryService/ValidateTrackingNumber"
+	DeliveryService_GetShippingLabel_FullMethodName           = "/shinkansen.delivery.DeliveryService/GetShippingLabel"
+	DeliveryService_GetShippingLabels_FullMethodName          = "/shinkansen.delivery.DeliveryService/GetShippingLabels"
+	DeliveryService_QuoteShipping_FullMethodName              = "/shinkansen.delivery.DeliveryService/QuoteShipping"
+	DeliveryService_RequestCashOnDelivery_FullMethodName      = "/shinkansen.delivery.DeliveryService/RequestCashOnDelivery"
 )
 
 // DeliveryServiceClient is the client API for DeliveryService service.
@@ -31,9 +60,38 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DeliveryServiceClient interface {
 	GetDeliverySlots(ctx context.Context, in *GetDeliverySlotsRequest, opts ...grpc.CallOption) (*GetDeliverySlotsResponse, error)
+	ResolveDeliveryZone(ctx context.Context, in *ResolveDeliveryZoneRequest, opts ...grpc.CallOption) (*ResolveDeliveryZoneResponse, error)
+	GetDeliverySlotsForAddress(ctx context.Context, in *GetDeliverySlotsForAddressRequest, opts ...grpc.CallOption) (*GetDeliverySlotsForAddressResponse, error)
+	ListSlotTemplates(ctx context.Context, in *ListSlotTemplatesRequest, opts ...grpc.CallOption) (*ListSlotTemplatesResponse, error)
+	CreateSlotTemplate(ctx context.Context, in *CreateSlotTemplateRequest, opts ...grpc.CallOption) (*CreateSlotTemplateResponse, error)
+	UpdateSlotTemplate(ctx context.Context, in *UpdateSlotTemplateRequest, opts ...grpc.CallOption) (*UpdateSlotTemplateResponse, error)
+	DeleteSlotTemplate(ctx context.Context, in *DeleteSlotTemplateRequest, opts ...grpc.CallOption) (*DeleteSlotTemplateResponse, error)
+	ListSlotBlackouts(ctx context.Context, in *ListSlotBlackoutsRequest, opts ...grpc.CallOption) (*ListSlotBlackoutsResponse, error)
+	CreateSlotBlackout(ctx context.Context, in *CreateSlotBlackoutRequest, opts ...grpc.CallOption) (*CreateSlotBlackoutResponse, error)
+	DeleteSlotBlackout(ctx context.Context, in *DeleteSlotBlackoutRequest, opts ...grpc.CallOption) (*DeleteSlotBlackoutResponse, error)
+	ListClosureDays(ctx context.Context, in *ListClosureDaysRequest, opts ...grpc.CallOption) (*ListClosureDaysResponse, error)
+	CreateClosureDay(ctx context.Context, in *CreateClosureDayRequest, opts ...grpc.CallOption) (*CreateClosureDayResponse, error)
+	DeleteClosureDay(ctx context.Context, in *DeleteClosureDayRequest, opts ...grpc.CallOption) (*DeleteClosureDayResponse, error)
+	ListPickupPoints(ctx context.Context, in *ListPickupPointsRequest, opts ...grpc.CallOption) (*ListPickupPointsResponse, error)
+	GetPickupPoint(ctx context.Context, in *GetPickupPointRequest, opts ...grpc.CallOption) (*GetPickupPointResponse, error)
+	CreatePickupPoint(ctx context.Context, in *CreatePickupPointRequest, opts ...grpc.CallOption) (*CreatePickupPointResponse, error)
+	UpdatePickupPoint(ctx context.Context, in *UpdatePickupPointRequest, opts ...grpc.CallOption) (*UpdatePickupPointResponse, error)
+	GenerateDeliverySlots(ctx context.Context, in *GenerateDeliverySlotsRequest, opts ...grpc.CallOption) (*GenerateDeliverySlotsResponse, error)
 	ReserveDeliverySlot(ctx context.Context, in *ReserveDeliverySlotRequest, opts ...grpc.CallOption) (*ReserveDeliverySlotResponse, error)
+	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error)
+	ReleaseDeliverySlot(ctx context.Context, in *ReleaseDeliverySlotRequest, opts ...grpc.CallOption) (*ReleaseDeliverySlotResponse, error)
 	GetShipment(ctx context.Context, in *GetShipmentRequest, opts ...grpc.CallOption) (*GetShipmentResponse, error)
 	UpdateShipmentStatus(ctx context.Context, in *UpdateShipmentStatusRequest, opts ...grpc.CallOption) (*shared.Empty, error)
+	AddTrackingEvent(ctx context.Context, in *AddTrackingEventRequest, opts ...grpc.CallOption) (*AddTrackingEventResponse, error)
+	ListTrackingEvents(ctx context.Context, in *ListTrackingEventsRequest, opts ...grpc.CallOption) (*ListTrackingEventsResponse, error)
+	DispatchShipment(ctx context.Context, in *DispatchShipmentRequest, opts ...grpc.CallOption) (*DispatchShipmentResponse, error)
+	CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*CancelShipmentResponse, error)
+	ConfirmPickup(ctx context.Context, in *ConfirmPickupRequest, opts ...grpc.CallOption) (*ConfirmPickupResponse, error)
+	ValidateTrackingNumber(ctx context.Context, in *ValidateTrackingNumberRequest, opts ...grpc.CallOption) (*ValidateTrackingNumberResponse, error)
+	GetShippingLabel(ctx context.Context, in *GetShippingLabelRequest, opts ...grpc.CallOption) (*GetShippingLabelResponse, error)
+	GetShippingLabels(ctx context.Context, in *GetShippingLabelsRequest, opts ...grpc.CallOption) (*GetShippingLabelsResponse, error)
+	QuoteShipping(ctx context.Context, in *QuoteShippingRequest, opts ...grpc.CallOption) (*QuoteShippingResponse, error)
+	RequestCashOnDelivery(ctx context.Context, in *RequestCashOnDeliveryRequest, opts ...grpc.CallOption) (*RequestCashOnDeliveryResponse, error)
 }
 
 type deliveryServiceClient struct {
@@ -54,6 +112,176 @@ func (c *deliveryServiceClient) GetDeliverySlots(ctx context.Context, in *GetDel
 	return out, nil
 }
 
+func (c *deliveryServiceClient) ResolveDeliveryZone(ctx context.Context, in *ResolveDeliveryZoneRequest, opts ...grpc.CallOption) (*ResolveDeliveryZoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveDeliveryZoneResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ResolveDeliveryZone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) GetDeliverySlotsForAddress(ctx context.Context, in *GetDeliverySlotsForAddressRequest, opts ...grpc.CallOption) (*GetDeliverySlotsForAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliverySlotsForAddressResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_GetDeliverySlotsForAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ListSlotTemplates(ctx context.Context, in *ListSlotTemplatesRequest, opts ...grpc.CallOption) (*ListSlotTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSlotTemplatesResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ListSlotTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) CreateSlotTemplate(ctx context.Context, in *CreateSlotTemplateRequest, opts ...grpc.CallOption) (*CreateSlotTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSlotTemplateResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_CreateSlotTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) UpdateSlotTemplate(ctx context.Context, in *UpdateSlotTemplateRequest, opts ...grpc.CallOption) (*UpdateSlotTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSlotTemplateResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_UpdateSlotTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) DeleteSlotTemplate(ctx context.Context, in *DeleteSlotTemplateRequest, opts ...grpc.CallOption) (*DeleteSlotTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSlotTemplateResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_DeleteSlotTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ListSlotBlackouts(ctx context.Context, in *ListSlotBlackoutsRequest, opts ...grpc.CallOption) (*ListSlotBlackoutsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSlotBlackoutsResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ListSlotBlackouts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) CreateSlotBlackout(ctx context.Context, in *CreateSlotBlackoutRequest, opts ...grpc.CallOption) (*CreateSlotBlackoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSlotBlackoutResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_CreateSlotBlackout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) DeleteSlotBlackout(ctx context.Context, in *DeleteSlotBlackoutRequest, opts ...grpc.CallOption) (*DeleteSlotBlackoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSlotBlackoutResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_DeleteSlotBlackout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ListClosureDays(ctx context.Context, in *ListClosureDaysRequest, opts ...grpc.CallOption) (*ListClosureDaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClosureDaysResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ListClosureDays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) CreateClosureDay(ctx context.Context, in *CreateClosureDayRequest, opts ...grpc.CallOption) (*CreateClosureDayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateClosureDayResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_CreateClosureDay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) DeleteClosureDay(ctx context.Context, in *DeleteClosureDayRequest, opts ...grpc.CallOption) (*DeleteClosureDayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteClosureDayResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_DeleteClosureDay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ListPickupPoints(ctx context.Context, in *ListPickupPointsRequest, opts ...grpc.CallOption) (*ListPickupPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPickupPointsResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ListPickupPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) GetPickupPoint(ctx context.Context, in *GetPickupPointRequest, opts ...grpc.CallOption) (*GetPickupPointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPickupPointResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_GetPickupPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) CreatePickupPoint(ctx context.Context, in *CreatePickupPointRequest, opts ...grpc.CallOption) (*CreatePickupPointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePickupPointResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_CreatePickupPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) UpdatePickupPoint(ctx context.Context, in *UpdatePickupPointRequest, opts ...grpc.CallOption) (*UpdatePickupPointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePickupPointResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_UpdatePickupPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) GenerateDeliverySlots(ctx context.Context, in *GenerateDeliverySlotsRequest, opts ...grpc.CallOption) (*GenerateDeliverySlotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateDeliverySlotsResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_GenerateDeliverySlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deliveryServiceClient) ReserveDeliverySlot(ctx context.Context, in *ReserveDeliverySlotRequest, opts ...grpc.CallOption) (*ReserveDeliverySlotResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ReserveDeliverySlotResponse)
@@ -64,6 +292,26 @@ func (c *deliveryServiceClient) ReserveDeliverySlot(ctx context.Context, in *Res
 	return out, nil
 }
 
+func (c *deliveryServiceClient) ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmReservationResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ConfirmReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ReleaseDeliverySlot(ctx context.Context, in *ReleaseDeliverySlotRequest, opts ...grpc.CallOption) (*ReleaseDeliverySlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseDeliverySlotResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ReleaseDeliverySlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deliveryServiceClient) GetShipment(ctx context.Context, in *GetShipmentRequest, opts ...grpc.CallOption) (*GetShipmentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetShipmentResponse)
@@ -84,14 +332,143 @@ func (c *deliveryServiceClient) UpdateShipmentStatus(ctx context.Context, in *Up
 	return out, nil
 }
 
+func (c *deliveryServiceClient) AddTrackingEvent(ctx context.Context, in *AddTrackingEventRequest, opts ...grpc.CallOption) (*AddTrackingEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTrackingEventResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_AddTrackingEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ListTrackingEvents(ctx context.Context, in *ListTrackingEventsRequest, opts ...grpc.CallOption) (*ListTrackingEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrackingEventsResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ListTrackingEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) DispatchShipment(ctx context.Context, in *DispatchShipmentRequest, opts ...grpc.CallOption) (*DispatchShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DispatchShipmentResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_DispatchShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*CancelShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelShipmentResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_CancelShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ConfirmPickup(ctx context.Context, in *ConfirmPickupRequest, opts ...grpc.CallOption) (*ConfirmPickupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPickupResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ConfirmPickup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) ValidateTrackingNumber(ctx context.Context, in *ValidateTrackingNumberRequest, opts ...grpc.CallOption) (*ValidateTrackingNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateTrackingNumberResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_ValidateTrackingNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) GetShippingLabel(ctx context.Context, in *GetShippingLabelRequest, opts ...grpc.CallOption) (*GetShippingLabelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShippingLabelResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_GetShippingLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) GetShippingLabels(ctx context.Context, in *GetShippingLabelsRequest, opts ...grpc.CallOption) (*GetShippingLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShippingLabelsResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_GetShippingLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) QuoteShipping(ctx context.Context, in *QuoteShippingRequest, opts ...grpc.CallOption) (*QuoteShippingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteShippingResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_QuoteShipping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryServiceClient) RequestCashOnDelivery(ctx context.Context, in *RequestCashOnDeliveryRequest, opts ...grpc.CallOption) (*RequestCashOnDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestCashOnDeliveryResponse)
+	err := c.cc.Invoke(ctx, DeliveryService_RequestCashOnDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeliveryServiceServer is the server API for DeliveryService service.
 // All implementations should embed UnimplementedDeliveryServiceServer
 // for forward compatibility.
 type DeliveryServiceServer interface {
 	GetDeliverySlots(context.Context, *GetDeliverySlotsRequest) (*GetDeliverySlotsResponse, error)
+	ResolveDeliveryZone(context.Context, *ResolveDeliveryZoneRequest) (*ResolveDeliveryZoneResponse, error)
+	GetDeliverySlotsForAddress(context.Context, *GetDeliverySlotsForAddressRequest) (*GetDeliverySlotsForAddressResponse, error)
+	ListSlotTemplates(context.Context, *ListSlotTemplatesRequest) (*ListSlotTemplatesResponse, error)
+	CreateSlotTemplate(context.Context, *CreateSlotTemplateRequest) (*CreateSlotTemplateResponse, error)
+	UpdateSlotTemplate(context.Context, *UpdateSlotTemplateRequest) (*UpdateSlotTemplateResponse, error)
+	DeleteSlotTemplate(context.Context, *DeleteSlotTemplateRequest) (*DeleteSlotTemplateResponse, error)
+	ListSlotBlackouts(context.Context, *ListSlotBlackoutsRequest) (*ListSlotBlackoutsResponse, error)
+	CreateSlotBlackout(context.Context, *CreateSlotBlackoutRequest) (*CreateSlotBlackoutResponse, error)
+	DeleteSlotBlackout(context.Context, *DeleteSlotBlackoutRequest) (*DeleteSlotBlackoutResponse, error)
+	ListClosureDays(context.Context, *ListClosureDaysRequest) (*ListClosureDaysResponse, error)
+	CreateClosureDay(context.Context, *CreateClosureDayRequest) (*CreateClosureDayResponse, error)
+	DeleteClosureDay(context.Context, *DeleteClosureDayRequest) (*DeleteClosureDayResponse, error)
+	ListPickupPoints(context.Context, *ListPickupPointsRequest) (*ListPickupPointsResponse, error)
+	GetPickupPoint(context.Context, *GetPickupPointRequest) (*GetPickupPointResponse, error)
+	CreatePickupPoint(context.Context, *CreatePickupPointRequest) (*CreatePickupPointResponse, error)
+	UpdatePickupPoint(context.Context, *UpdatePickupPointRequest) (*UpdatePickupPointResponse, error)
+	GenerateDeliverySlots(context.Context, *GenerateDeliverySlotsRequest) (*GenerateDeliverySlotsResponse, error)
 	ReserveDeliverySlot(context.Context, *ReserveDeliverySlotRequest) (*ReserveDeliverySlotResponse, error)
+	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error)
+	ReleaseDeliverySlot(context.Context, *ReleaseDeliverySlotRequest) (*ReleaseDeliverySlotResponse, error)
 	GetShipment(context.Context, *GetShipmentRequest) (*GetShipmentResponse, error)
 	UpdateShipmentStatus(context.Context, *UpdateShipmentStatusRequest) (*shared.Empty, error)
+	AddTrackingEvent(context.Context, *AddTrackingEventRequest) (*AddTrackingEventResponse, error)
+	ListTrackingEvents(context.Context, *ListTrackingEventsRequest) (*ListTrackingEventsResponse, error)
+	DispatchShipment(context.Context, *DispatchShipmentRequest) (*DispatchShipmentResponse, error)
+	CancelShipment(context.Context, *CancelShipmentRequest) (*CancelShipmentResponse, error)
+	ConfirmPickup(context.Context, *ConfirmPickupRequest) (*ConfirmPickupResponse, error)
+	ValidateTrackingNumber(context.Context, *ValidateTrackingNumberRequest) (*ValidateTrackingNumberResponse, error)
+	GetShippingLabel(context.Context, *GetShippingLabelRequest) (*GetShippingLabelResponse, error)
+	GetShippingLabels(context.Context, *GetShippingLabelsRequest) (*GetShippingLabelsResponse, error)
+	QuoteShipping(context.Context, *QuoteShippingRequest) (*QuoteShippingResponse, error)
+	RequestCashOnDelivery(context.Context, *RequestCashOnDeliveryRequest) (*RequestCashOnDeliveryResponse, error)
 }
 
 // UnimplementedDeliveryServiceServer should be embedded to have
@@ -104,15 +481,102 @@ type UnimplementedDeliveryServiceServer struct{}
 func (UnimplementedDeliveryServiceServer) GetDeliverySlots(context.Context, *GetDeliverySlotsRequest) (*GetDeliverySlotsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDeliverySlots not implemented")
 }
+func (UnimplementedDeliveryServiceServer) ResolveDeliveryZone(context.Context, *ResolveDeliveryZoneRequest) (*ResolveDeliveryZoneResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveDeliveryZone not implemented")
+}
+func (UnimplementedDeliveryServiceServer) GetDeliverySlotsForAddress(context.Context, *GetDeliverySlotsForAddressRequest) (*GetDeliverySlotsForAddressResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeliverySlotsForAddress not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ListSlotTemplates(context.Context, *ListSlotTemplatesRequest) (*ListSlotTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSlotTemplates not implemented")
+}
+func (UnimplementedDeliveryServiceServer) CreateSlotTemplate(context.Context, *CreateSlotTemplateRequest) (*CreateSlotTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSlotTemplate not implemented")
+}
+func (UnimplementedDeliveryServiceServer) UpdateSlotTemplate(context.Context, *UpdateSlotTemplateRequest) (*UpdateSlotTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSlotTemplate not implemented")
+}
+func (UnimplementedDeliveryServiceServer) DeleteSlotTemplate(context.Context, *DeleteSlotTemplateRequest) (*DeleteSlotTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSlotTemplate not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ListSlotBlackouts(context.Context, *ListSlotBlackoutsRequest) (*ListSlotBlackoutsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSlotBlackouts not implemented")
+}
+func (UnimplementedDeliveryServiceServer) CreateSlotBlackout(context.Context, *CreateSlotBlackoutRequest) (*CreateSlotBlackoutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSlotBlackout not implemented")
+}
+func (UnimplementedDeliveryServiceServer) DeleteSlotBlackout(context.Context, *DeleteSlotBlackoutRequest) (*DeleteSlotBlackoutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSlotBlackout not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ListClosureDays(context.Context, *ListClosureDaysRequest) (*ListClosureDaysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListClosureDays not implemented")
+}
+func (UnimplementedDeliveryServiceServer) CreateClosureDay(context.Context, *CreateClosureDayRequest) (*CreateClosureDayResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateClosureDay not implemented")
+}
+func (UnimplementedDeliveryServiceServer) DeleteClosureDay(context.Context, *DeleteClosureDayRequest) (*DeleteClosureDayResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteClosureDay not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ListPickupPoints(context.Context, *ListPickupPointsRequest) (*ListPickupPointsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPickupPoints not implemented")
+}
+func (UnimplementedDeliveryServiceServer) GetPickupPoint(context.Context, *GetPickupPointRequest) (*GetPickupPointResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPickupPoint not implemented")
+}
+func (UnimplementedDeliveryServiceServer) CreatePickupPoint(context.Context, *CreatePickupPointRequest) (*CreatePickupPointResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePickupPoint not implemented")
+}
+func (UnimplementedDeliveryServiceServer) UpdatePickupPoint(context.Context, *UpdatePickupPointRequest) (*UpdatePickupPointResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePickupPoint not implemented")
+}
+func (UnimplementedDeliveryServiceServer) GenerateDeliverySlots(context.Context, *GenerateDeliverySlotsRequest) (*GenerateDeliverySlotsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateDeliverySlots not implemented")
+}
 func (UnimplementedDeliveryServiceServer) ReserveDeliverySlot(context.Context, *ReserveDeliverySlotRequest) (*ReserveDeliverySlotResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReserveDeliverySlot not implemented")
 }
+func (UnimplementedDeliveryServiceServer) ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ReleaseDeliverySlot(context.Context, *ReleaseDeliverySlotRequest) (*ReleaseDeliverySlotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseDeliverySlot not implemented")
+}
 func (UnimplementedDeliveryServiceServer) GetShipment(context.Context, *GetShipmentRequest) (*GetShipmentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetShipment not implemented")
 }
 func (UnimplementedDeliveryServiceServer) UpdateShipmentStatus(context.Context, *UpdateShipmentStatusRequest) (*shared.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateShipmentStatus not implemented")
 }
+func (UnimplementedDeliveryServiceServer) AddTrackingEvent(context.Context, *AddTrackingEventRequest) (*AddTrackingEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTrackingEvent not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ListTrackingEvents(context.Context, *ListTrackingEventsRequest) (*ListTrackingEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrackingEvents not implemented")
+}
+func (UnimplementedDeliveryServiceServer) DispatchShipment(context.Context, *DispatchShipmentRequest) (*DispatchShipmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DispatchShipment not implemented")
+}
+func (UnimplementedDeliveryServiceServer) CancelShipment(context.Context, *CancelShipmentRequest) (*CancelShipmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelShipment not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ConfirmPickup(context.Context, *ConfirmPickupRequest) (*ConfirmPickupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmPickup not implemented")
+}
+func (UnimplementedDeliveryServiceServer) ValidateTrackingNumber(context.Context, *ValidateTrackingNumberRequest) (*ValidateTrackingNumberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateTrackingNumber not implemented")
+}
+func (UnimplementedDeliveryServiceServer) GetShippingLabel(context.Context, *GetShippingLabelRequest) (*GetShippingLabelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShippingLabel not implemented")
+}
+func (UnimplementedDeliveryServiceServer) GetShippingLabels(context.Context, *GetShippingLabelsRequest) (*GetShippingLabelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShippingLabels not implemented")
+}
+func (UnimplementedDeliveryServiceServer) QuoteShipping(context.Context, *QuoteShippingRequest) (*QuoteShippingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QuoteShipping not implemented")
+}
+func (UnimplementedDeliveryServiceServer) RequestCashOnDelivery(context.Context, *RequestCashOnDeliveryRequest) (*RequestCashOnDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestCashOnDelivery not implemented")
+}
 func (UnimplementedDeliveryServiceServer) testEmbeddedByValue() {}
 
 // UnsafeDeliveryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -151,75 +615,673 @@ func _DeliveryService_GetDeliverySlots_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeliveryService_ReserveDeliverySlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveDeliverySlotRequest)
+func _DeliveryService_ResolveDeliveryZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDeliveryZoneRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeliveryServiceServer).ReserveDeliverySlot(ctx, in)
+		return srv.(DeliveryServiceServer).ResolveDeliveryZone(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DeliveryService_ReserveDeliverySlot_FullMethodName,
+		FullMethod: DeliveryService_ResolveDeliveryZone_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeliveryServiceServer).ReserveDeliverySlot(ctx, req.(*ReserveDeliverySlotRequest))
+		return srv.(DeliveryServiceServer).ResolveDeliveryZone(ctx, req.(*ResolveDeliveryZoneRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeliveryService_GetShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetShipmentRequest)
+func _DeliveryService_GetDeliverySlotsForAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliverySlotsForAddressRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeliveryServiceServer).GetShipment(ctx, in)
+		return srv.(DeliveryServiceServer).GetDeliverySlotsForAddress(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DeliveryService_GetShipment_FullMethodName,
+		FullMethod: DeliveryService_GetDeliverySlotsForAddress_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeliveryServiceServer).GetShipment(ctx, req.(*GetShipmentRequest))
+		return srv.(DeliveryServiceServer).GetDeliverySlotsForAddress(ctx, req.(*GetDeliverySlotsForAddressRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeliveryService_UpdateShipmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateShipmentStatusRequest)
+func _DeliveryService_ListSlotTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSlotTemplatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeliveryServiceServer).UpdateShipmentStatus(ctx, in)
+		return srv.(DeliveryServiceServer).ListSlotTemplates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DeliveryService_UpdateShipmentStatus_FullMethodName,
+		FullMethod: DeliveryService_ListSlotTemplates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeliveryServiceServer).UpdateShipmentStatus(ctx, req.(*UpdateShipmentStatusRequest))
+		return srv.(DeliveryServiceServer).ListSlotTemplates(ctx, req.(*ListSlotTemplatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// DeliveryService_ServiceDesc is the grpc.ServiceDesc for DeliveryService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var DeliveryService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "shinkansen.delivery.DeliveryService",
-	HandlerType: (*DeliveryServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetDeliverySlots",
+func _DeliveryService_CreateSlotTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSlotTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).CreateSlotTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_CreateSlotTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).CreateSlotTemplate(ctx, req.(*CreateSlotTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_UpdateSlotTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSlotTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).UpdateSlotTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_UpdateSlotTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).UpdateSlotTemplate(ctx, req.(*UpdateSlotTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_DeleteSlotTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSlotTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).DeleteSlotTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_DeleteSlotTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).DeleteSlotTemplate(ctx, req.(*DeleteSlotTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ListSlotBlackouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSlotBlackoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ListSlotBlackouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ListSlotBlackouts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ListSlotBlackouts(ctx, req.(*ListSlotBlackoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_CreateSlotBlackout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSlotBlackoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).CreateSlotBlackout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_CreateSlotBlackout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).CreateSlotBlackout(ctx, req.(*CreateSlotBlackoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_DeleteSlotBlackout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSlotBlackoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).DeleteSlotBlackout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_DeleteSlotBlackout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).DeleteSlotBlackout(ctx, req.(*DeleteSlotBlackoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ListClosureDays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClosureDaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ListClosureDays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ListClosureDays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ListClosureDays(ctx, req.(*ListClosureDaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_CreateClosureDay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClosureDayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).CreateClosureDay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_CreateClosureDay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).CreateClosureDay(ctx, req.(*CreateClosureDayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_DeleteClosureDay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClosureDayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).DeleteClosureDay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_DeleteClosureDay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).DeleteClosureDay(ctx, req.(*DeleteClosureDayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ListPickupPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPickupPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ListPickupPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ListPickupPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ListPickupPoints(ctx, req.(*ListPickupPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_GetPickupPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPickupPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).GetPickupPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_GetPickupPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).GetPickupPoint(ctx, req.(*GetPickupPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_CreatePickupPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePickupPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).CreatePickupPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_CreatePickupPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).CreatePickupPoint(ctx, req.(*CreatePickupPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_UpdatePickupPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePickupPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).UpdatePickupPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_UpdatePickupPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).UpdatePickupPoint(ctx, req.(*UpdatePickupPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_GenerateDeliverySlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateDeliverySlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).GenerateDeliverySlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_GenerateDeliverySlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).GenerateDeliverySlots(ctx, req.(*GenerateDeliverySlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ReserveDeliverySlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveDeliverySlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ReserveDeliverySlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ReserveDeliverySlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ReserveDeliverySlot(ctx, req.(*ReserveDeliverySlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ConfirmReservation(ctx, req.(*ConfirmReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ReleaseDeliverySlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseDeliverySlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ReleaseDeliverySlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ReleaseDeliverySlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ReleaseDeliverySlot(ctx, req.(*ReleaseDeliverySlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_GetShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).GetShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_GetShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).GetShipment(ctx, req.(*GetShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_UpdateShipmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShipmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).UpdateShipmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_UpdateShipmentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).UpdateShipmentStatus(ctx, req.(*UpdateShipmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_AddTrackingEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTrackingEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).AddTrackingEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_AddTrackingEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).AddTrackingEvent(ctx, req.(*AddTrackingEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ListTrackingEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrackingEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ListTrackingEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ListTrackingEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ListTrackingEvents(ctx, req.(*ListTrackingEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_DispatchShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).DispatchShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_DispatchShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).DispatchShipment(ctx, req.(*DispatchShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_CancelShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).CancelShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_CancelShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).CancelShipment(ctx, req.(*CancelShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ConfirmPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ConfirmPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ConfirmPickup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ConfirmPickup(ctx, req.(*ConfirmPickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_ValidateTrackingNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTrackingNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).ValidateTrackingNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_ValidateTrackingNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).ValidateTrackingNumber(ctx, req.(*ValidateTrackingNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_GetShippingLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShippingLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).GetShippingLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_GetShippingLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).GetShippingLabel(ctx, req.(*GetShippingLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_GetShippingLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShippingLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).GetShippingLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_GetShippingLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).GetShippingLabels(ctx, req.(*GetShippingLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_QuoteShipping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteShippingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).QuoteShipping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_QuoteShipping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).QuoteShipping(ctx, req.(*QuoteShippingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeliveryService_RequestCashOnDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCashOnDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServiceServer).RequestCashOnDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeliveryService_RequestCashOnDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServiceServer).RequestCashOnDelivery(ctx, req.(*RequestCashOnDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeliveryService_ServiceDesc is the grpc.ServiceDesc for DeliveryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeliveryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shinkansen.delivery.DeliveryService",
+	HandlerType: (*DeliveryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDeliverySlots",
 			Handler:    _DeliveryService_GetDeliverySlots_Handler,
 		},
+		{
+			MethodName: "ResolveDeliveryZone",
+			Handler:    _DeliveryService_ResolveDeliveryZone_Handler,
+		},
+		{
+			MethodName: "GetDeliverySlotsForAddress",
+			Handler:    _DeliveryService_GetDeliverySlotsForAddress_Handler,
+		},
+		{
+			MethodName: "ListSlotTemplates",
+			Handler:    _DeliveryService_ListSlotTemplates_Handler,
+		},
+		{
+			MethodName: "CreateSlotTemplate",
+			Handler:    _DeliveryService_CreateSlotTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateSlotTemplate",
+			Handler:    _DeliveryService_UpdateSlotTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteSlotTemplate",
+			Handler:    _DeliveryService_DeleteSlotTemplate_Handler,
+		},
+		{
+			MethodName: "ListSlotBlackouts",
+			Handler:    _DeliveryService_ListSlotBlackouts_Handler,
+		},
+		{
+			MethodName: "CreateSlotBlackout",
+			Handler:    _DeliveryService_CreateSlotBlackout_Handler,
+		},
+		{
+			MethodName: "DeleteSlotBlackout",
+			Handler:    _DeliveryService_DeleteSlotBlackout_Handler,
+		},
+		{
+			MethodName: "ListClosureDays",
+			Handler:    _DeliveryService_ListClosureDays_Handler,
+		},
+		{
+			MethodName: "CreateClosureDay",
+			Handler:    _DeliveryService_CreateClosureDay_Handler,
+		},
+		{
+			MethodName: "DeleteClosureDay",
+			Handler:    _DeliveryService_DeleteClosureDay_Handler,
+		},
+		{
+			MethodName: "ListPickupPoints",
+			Handler:    _DeliveryService_ListPickupPoints_Handler,
+		},
+		{
+			MethodName: "GetPickupPoint",
+			Handler:    _DeliveryService_GetPickupPoint_Handler,
+		},
+		{
+			MethodName: "CreatePickupPoint",
+			Handler:    _DeliveryService_CreatePickupPoint_Handler,
+		},
+		{
+			MethodName: "UpdatePickupPoint",
+			Handler:    _DeliveryService_UpdatePickupPoint_Handler,
+		},
+		{
+			MethodName: "GenerateDeliverySlots",
+			Handler:    _DeliveryService_GenerateDeliverySlots_Handler,
+		},
 		{
 			MethodName: "ReserveDeliverySlot",
 			Handler:    _DeliveryService_ReserveDeliverySlot_Handler,
 		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _DeliveryService_ConfirmReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseDeliverySlot",
+			Handler:    _DeliveryService_ReleaseDeliverySlot_Handler,
+		},
 		{
 			MethodName: "GetShipment",
 			Handler:    _DeliveryService_GetShipment_Handler,
@@ -228,6 +1290,46 @@ var DeliveryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateShipmentStatus",
 			Handler:    _DeliveryService_UpdateShipmentStatus_Handler,
 		},
+		{
+			MethodName: "AddTrackingEvent",
+			Handler:    _DeliveryService_AddTrackingEvent_Handler,
+		},
+		{
+			MethodName: "ListTrackingEvents",
+			Handler:    _DeliveryService_ListTrackingEvents_Handler,
+		},
+		{
+			MethodName: "DispatchShipment",
+			Handler:    _DeliveryService_DispatchShipment_Handler,
+		},
+		{
+			MethodName: "CancelShipment",
+			Handler:    _DeliveryService_CancelShipment_Handler,
+		},
+		{
+			MethodName: "ConfirmPickup",
+			Handler:    _DeliveryService_ConfirmPickup_Handler,
+		},
+		{
+			MethodName: "ValidateTrackingNumber",
+			Handler:    _DeliveryService_ValidateTrackingNumber_Handler,
+		},
+		{
+			MethodName: "GetShippingLabel",
+			Handler:    _DeliveryService_GetShippingLabel_Handler,
+		},
+		{
+			MethodName: "GetShippingLabels",
+			Handler:    _DeliveryService_GetShippingLabels_Handler,
+		},
+		{
+			MethodName: "QuoteShipping",
+			Handler:    _DeliveryService_QuoteShipping_Handler,
+		},
+		{
+			MethodName: "RequestCashOnDelivery",
+			Handler:    _DeliveryService_RequestCashOnDelivery_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "delivery/delivery_service.proto",
@@ -113,18 +113,28 @@ const (
 	PaymentMethod_PAYMENT_METHOD_KONBINI_FAMILYMART  PaymentMethod = 4
 	PaymentMethod_PAYMENT_METHOD_PAYPAY              PaymentMethod = 5
 	PaymentMethod_PAYMENT_METHOD_RAKUTEN_PAY         PaymentMethod = 6
+	PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER       PaymentMethod = 7
+	PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY    PaymentMethod = 8
+	PaymentMethod_PAYMENT_METHOD_DEFERRED            PaymentMethod = 9
+	PaymentMethod_PAYMENT_METHOD_POINTS              PaymentMethod = 10
+	PaymentMethod_PAYMENT_METHOD_GIFT_CARD           PaymentMethod = 11
 )
 
 // Enum value maps for PaymentMethod.
 var (
 	PaymentMethod_name = map[int32]string{
-		0: "PAYMENT_METHOD_UNSPECIFIED",
-		1: "PAYMENT_METHOD_CREDIT_CARD",
-		2: "PAYMENT_METHOD_KONBINI_SEVENELEVEN",
-		3: "PAYMENT_METHOD_KONBINI_LAWSON",
-		4: "PAYMENT_METHOD_KONBINI_FAMILYMART",
-		5: "PAYMENT_METHOD_PAYPAY",
-		6: "PAYMENT_METHOD_RAKUTEN_PAY",
+		0:  "PAYMENT_METHOD_UNSPECIFIED",
+		1:  "PAYMENT_METHOD_CREDIT_CARD",
+		2:  "PAYMENT_METHOD_KONBINI_SEVENELEVEN",
+		3:  "PAYMENT_METHOD_KONBINI_LAWSON",
+		4:  "PAYMENT_METHOD_KONBINI_FAMILYMART",
+		5:  "PAYMENT_METHOD_PAYPAY",
+		6:  "PAYMENT_METHOD_RAKUTEN_PAY",
+		7:  "PAYMENT_METHOD_BANK_TRANSFER",
+		8:  "PAYMENT_METHOD_CASH_ON_DELIVERY",
+		9:  "PAYMENT_METHOD_DEFERRED",
+		10: "PAYMENT_METHOD_POINTS",
+		11: "PAYMENT_METHOD_GIFT_CARD",
 	}
 	PaymentMethod_value = map[string]int32{
 		"PAYMENT_METHOD_UNSPECIFIED":         0,
@@ -134,6 +144,11 @@ var (
 		"PAYMENT_METHOD_KONBINI_FAMILYMART":  4,
 		"PAYMENT_METHOD_PAYPAY":              5,
 		"PAYMENT_METHOD_RAKUTEN_PAY":         6,
+		"PAYMENT_METHOD_BANK_TRANSFER":       7,
+		"PAYMENT_METHOD_CASH_ON_DELIVERY":    8,
+		"PAYMENT_METHOD_DEFERRED":            9,
+		"PAYMENT_METHOD_POINTS":              10,
+		"PAYMENT_METHOD_GIFT_CARD":           11,
 	}
 )
 
@@ -182,6 +197,10 @@ type Order struct {
 	DeliverySlotId      *wrapperspb.StringValue `protobuf:"bytes,14,opt,name=delivery_slot_id,json=deliverySlotId,proto3" json:"delivery_slot_id,omitempty"`
 	EstimatedDeliveryAt *timestamppb.Timestamp  `protobuf:"bytes,15,opt,name=estimated_delivery_at,json=estimatedDeliveryAt,proto3" json:"estimated_delivery_at,omitempty"`
 	Items               []*OrderItem            `protobuf:"bytes,16,rep,name=items,proto3" json:"items,omitempty"`
+	CodFee              *shared.Money           `protobuf:"bytes,17,opt,name=cod_fee,json=codFee,proto3" json:"cod_fee,omitempty"`
+	Disputed            bool                    `protobuf:"varint,18,opt,name=disputed,proto3" json:"disputed,omitempty"`
+	ShippingFee         *shared.Money           `protobuf:"bytes,19,opt,name=shipping_fee,json=shippingFee,proto3" json:"shipping_fee,omitempty"`
+	PickupPointId       string                  `protobuf:"bytes,20,opt,name=pickup_point_id,json=pickupPointId,proto3" json:"pickup_point_id,omitempty"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -328,6 +347,34 @@ func (x *Order) GetItems() []*OrderItem {
 	return nil
 }
 
+func (x *Order) GetCodFee() *shared.Money {
+	if x != nil {
+		return x.CodFee
+	}
+	return nil
+}
+
+func (x *Order) GetDisputed() bool {
+	if x != nil {
+		return x.Disputed
+	}
+	return false
+}
+
+func (x *Order) GetShippingFee() *shared.Money {
+	if x != nil {
+		return x.ShippingFee
+	}
+	return nil
+}
+
+func (x *Order) GetPickupPointId() string {
+	if x != nil {
+		return x.PickupPointId
+	}
+	return ""
+}
+
 type OrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -520,6 +567,7 @@ type CreateOrderRequest struct {
 	PaymentMethod   PaymentMethod           `protobuf:"varint,4,opt,name=payment_method,json=paymentMethod,proto3,enum=shinkansen.order.PaymentMethod" json:"payment_method,omitempty"`
 	PointsToApply   *wrapperspb.Int64Value  `protobuf:"bytes,5,opt,name=points_to_apply,json=pointsToApply,proto3" json:"points_to_apply,omitempty"`
 	DeliverySlotId  *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=delivery_slot_id,json=deliverySlotId,proto3" json:"delivery_slot_id,omitempty"`
+	PickupPointId   string                  `protobuf:"bytes,7,opt,name=pickup_point_id,json=pickupPointId,proto3" json:"pickup_point_id,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -596,6 +644,13 @@ func (x *CreateOrderRequest) GetDeliverySlotId() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetPickupPointId() string {
+	if x != nil {
+		return x.PickupPointId
+	}
+	return ""
+}
+
 type CreateOrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -1187,6 +1242,7 @@ func (x *ReserveDeliverySlotRequest) GetSlotId() string {
 type ReserveDeliverySlotResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	HoldExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=hold_expires_at,json=holdExpiresAt,proto3" json:"hold_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1228,6 +1284,13 @@ func (x *ReserveDeliverySlotResponse) GetReservationId() string {
 	return ""
 }
 
+func (x *ReserveDeliverySlotResponse) GetHoldExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HoldExpiresAt
+	}
+	return nil
+}
+
 type CartSummary struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemCount     int32                  `protobuf:"varint,1,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
@@ -1284,7 +1347,7 @@ var File_order_order_messages_proto protoreflect.FileDescriptor
 
 const file_order_order_messages_proto_rawDesc = "" +
 	"\n" +
-	"\x1aorder/order_messages.proto\x12\x10shinkansen.order\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x13shared/common.proto\"\x84\a\n" +
+	"\x1aorder/order_messages.proto\x12\x10shinkansen.order\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x13shared/common.proto\"\xb8\b\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\forder_number\x18\x02 \x01(\tR\vorderNumber\x12\x17\n" +
@@ -1305,7 +1368,11 @@ const file_order_order_messages_proto_rawDesc = "" +
 	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12F\n" +
 	"\x10delivery_slot_id\x18\x0e \x01(\v2\x1c.google.protobuf.StringValueR\x0edeliverySlotId\x12N\n" +
 	"\x15estimated_delivery_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x13estimatedDeliveryAt\x121\n" +
-	"\x05items\x18\x10 \x03(\v2\x1b.shinkansen.order.OrderItemR\x05items\"\x8c\x02\n" +
+	"\x05items\x18\x10 \x03(\v2\x1b.shinkansen.order.OrderItemR\x05items\x121\n" +
+	"\acod_fee\x18\x11 \x01(\v2\x18.shinkansen.common.MoneyR\x06codFee\x12\x1a\n" +
+	"\bdisputed\x18\x12 \x01(\bR\bdisputed\x12;\n" +
+	"\fshipping_fee\x18\x13 \x01(\v2\x18.shinkansen.common.MoneyR\vshippingFee\x12&\n" +
+	"\x0fpickup_point_id\x18\x14 \x01(\tR\rpickupPointId\"\x8c\x02\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1328,14 +1395,15 @@ const file_order_order_messages_proto_rawDesc = "" +
 	"prefecture\x12\x12\n" +
 	"\x04city\x18\x05 \x01(\tR\x04city\x12#\n" +
 	"\raddress_line1\x18\x06 \x01(\tR\faddressLine1\x12#\n" +
-	"\raddress_line2\x18\a \x01(\tR\faddressLine2\"\x89\x03\n" +
+	"\raddress_line2\x18\a \x01(\tR\faddressLine2\"\xb1\x03\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
 	"\x05items\x18\x02 \x03(\v2!.shinkansen.order.CreateOrderItemR\x05items\x12L\n" +
 	"\x10shipping_address\x18\x03 \x01(\v2!.shinkansen.order.ShippingAddressR\x0fshippingAddress\x12F\n" +
 	"\x0epayment_method\x18\x04 \x01(\x0e2\x1f.shinkansen.order.PaymentMethodR\rpaymentMethod\x12C\n" +
 	"\x0fpoints_to_apply\x18\x05 \x01(\v2\x1b.google.protobuf.Int64ValueR\rpointsToApply\x12F\n" +
-	"\x10delivery_slot_id\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\x0edeliverySlotId\"k\n" +
+	"\x10delivery_slot_id\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\x0edeliverySlotId\x12&\n" +
+	"\x0fpickup_point_id\x18\a \x01(\tR\rpickupPointId\"k\n" +
 	"\x0fCreateOrderItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
@@ -1376,9 +1444,10 @@ const file_order_order_messages_proto_rawDesc = "" +
 	"\tyen_value\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\byenValue\"P\n" +
 	"\x1aReserveDeliverySlotRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
-	"\aslot_id\x18\x02 \x01(\tR\x06slotId\"D\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\"\x88\x01\n" +
 	"\x1bReserveDeliverySlotResponse\x12%\n" +
-	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"b\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12B\n" +
+	"\x0fhold_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rholdExpiresAt\"b\n" +
 	"\vCartSummary\x12\x1d\n" +
 	"\n" +
 	"item_count\x18\x01 \x01(\x05R\titemCount\x124\n" +
@@ -1397,7 +1466,7 @@ const file_order_order_messages_proto_rawDesc = "" +
 	"\x16ORDER_STATUS_PICKED_UP\x10\n" +
 	"\x12 \n" +
 	"\x1cORDER_STATUS_FAILED_DELIVERY\x10\v\x12\x19\n" +
-	"\x15ORDER_STATUS_RETURNED\x10\f*\xfc\x01\n" +
+	"\x15ORDER_STATUS_RETURNED\x10\f*\x99\x03\n" +
 	"\rPaymentMethod\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_CREDIT_CARD\x10\x01\x12&\n" +
@@ -1405,7 +1474,13 @@ const file_order_order_messages_proto_rawDesc = "" +
 	"\x1dPAYMENT_METHOD_KONBINI_LAWSON\x10\x03\x12%\n" +
 	"!PAYMENT_METHOD_KONBINI_FAMILYMART\x10\x04\x12\x19\n" +
 	"\x15PAYMENT_METHOD_PAYPAY\x10\x05\x12\x1e\n" +
-	"\x1aPAYMENT_METHOD_RAKUTEN_PAY\x10\x06B;Z9github.com/afasari/shinkansen-commerce/gen/proto/go/orderb\x06proto3"
+	"\x1aPAYMENT_METHOD_RAKUTEN_PAY\x10\x06\x12 \n" +
+	"\x1cPAYMENT_METHOD_BANK_TRANSFER\x10\a\x12#\n" +
+	"\x1fPAYMENT_METHOD_CASH_ON_DELIVERY\x10\b\x12\x1b\n" +
+	"\x17PAYMENT_METHOD_DEFERRED\x10\t\x12\x19\n" +
+	"\x15PAYMENT_METHOD_POINTS\x10\n" +
+	"\x12\x1c\n" +
+	"\x18PAYMENT_METHOD_GIFT_CARD\x10\vB;Z9github.com/afasari/shinkansen-commerce/gen/proto/go/orderb\x06proto3"
 
 var (
 	file_order_order_messages_proto_rawDescOnce sync.Once
@@ -1460,27 +1535,30 @@ var file_order_order_messages_proto_depIdxs = []int32{
 	21, // 9: shinkansen.order.Order.delivery_slot_id:type_name -> google.protobuf.StringValue
 	20, // 10: shinkansen.order.Order.estimated_delivery_at:type_name -> google.protobuf.Timestamp
 	3,  // 11: shinkansen.order.Order.items:type_name -> shinkansen.order.OrderItem
-	19, // 12: shinkansen.order.OrderItem.unit_price:type_name -> shinkansen.common.Money
-	19, // 13: shinkansen.order.OrderItem.total_price:type_name -> shinkansen.common.Money
-	6,  // 14: shinkansen.order.CreateOrderRequest.items:type_name -> shinkansen.order.CreateOrderItem
-	4,  // 15: shinkansen.order.CreateOrderRequest.shipping_address:type_name -> shinkansen.order.ShippingAddress
-	1,  // 16: shinkansen.order.CreateOrderRequest.payment_method:type_name -> shinkansen.order.PaymentMethod
-	22, // 17: shinkansen.order.CreateOrderRequest.points_to_apply:type_name -> google.protobuf.Int64Value
-	21, // 18: shinkansen.order.CreateOrderRequest.delivery_slot_id:type_name -> google.protobuf.StringValue
-	0,  // 19: shinkansen.order.CreateOrderResponse.status:type_name -> shinkansen.order.OrderStatus
-	2,  // 20: shinkansen.order.GetOrderResponse.order:type_name -> shinkansen.order.Order
-	21, // 21: shinkansen.order.ListOrdersRequest.status:type_name -> google.protobuf.StringValue
-	23, // 22: shinkansen.order.ListOrdersRequest.pagination:type_name -> shinkansen.common.Pagination
-	2,  // 23: shinkansen.order.ListOrdersResponse.orders:type_name -> shinkansen.order.Order
-	23, // 24: shinkansen.order.ListOrdersResponse.pagination:type_name -> shinkansen.common.Pagination
-	0,  // 25: shinkansen.order.UpdateOrderStatusRequest.status:type_name -> shinkansen.order.OrderStatus
-	19, // 26: shinkansen.order.ApplyPointsResponse.yen_value:type_name -> shinkansen.common.Money
-	19, // 27: shinkansen.order.CartSummary.subtotal:type_name -> shinkansen.common.Money
-	28, // [28:28] is the sub-list for method output_type
-	28, // [28:28] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	19, // 12: shinkansen.order.Order.cod_fee:type_name -> shinkansen.common.Money
+	19, // 13: shinkansen.order.Order.shipping_fee:type_name -> shinkansen.common.Money
+	19, // 14: shinkansen.order.OrderItem.unit_price:type_name -> shinkansen.common.Money
+	19, // 15: shinkansen.order.OrderItem.total_price:type_name -> shinkansen.common.Money
+	6,  // 16: shinkansen.order.CreateOrderRequest.items:type_name -> shinkansen.order.CreateOrderItem
+	4,  // 17: shinkansen.order.CreateOrderRequest.shipping_address:type_name -> shinkansen.order.ShippingAddress
+	1,  // 18: shinkansen.order.CreateOrderRequest.payment_method:type_name -> shinkansen.order.PaymentMethod
+	22, // 19: shinkansen.order.CreateOrderRequest.points_to_apply:type_name -> google.protobuf.Int64Value
+	21, // 20: shinkansen.order.CreateOrderRequest.delivery_slot_id:type_name -> google.protobuf.StringValue
+	0,  // 21: shinkansen.order.CreateOrderResponse.status:type_name -> shinkansen.order.OrderStatus
+	2,  // 22: shinkansen.order.GetOrderResponse.order:type_name -> shinkansen.order.Order
+	21, // 23: shinkansen.order.ListOrdersRequest.status:type_name -> google.protobuf.StringValue
+	23, // 24: shinkansen.order.ListOrdersRequest.pagination:type_name -> shinkansen.common.Pagination
+	2,  // 25: shinkansen.order.ListOrdersResponse.orders:type_name -> shinkansen.order.Order
+	23, // 26: shinkansen.order.ListOrdersResponse.pagination:type_name -> shinkansen.common.Pagination
+	0,  // 27: shinkansen.order.UpdateOrderStatusRequest.status:type_name -> shinkansen.order.OrderStatus
+	19, // 28: shinkansen.order.ApplyPointsResponse.yen_value:type_name -> shinkansen.common.Money
+	20, // 29: shinkansen.order.ReserveDeliverySlotResponse.hold_expires_at:type_name -> google.protobuf.Timestamp
+	19, // 30: shinkansen.order.CartSummary.subtotal:type_name -> shinkansen.common.Money
+	31, // [31:31] is the sub-list for method output_type
+	31, // [31:31] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_order_order_messages_proto_init() }
@@ -0,0 +1,857 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.30.2
+// source: payment/bank_transfer_messages.proto
+
+package payment
+
+import (
+	shared "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BankTransferStatus int32
+
+const (
+	BankTransferStatus_BANK_TRANSFER_STATUS_UNSPECIFIED BankTransferStatus = 0
+	BankTransferStatus_BANK_TRANSFER_STATUS_AWAITING    BankTransferStatus = 1
+	BankTransferStatus_BANK_TRANSFER_STATUS_PAID        BankTransferStatus = 2
+	BankTransferStatus_BANK_TRANSFER_STATUS_UNDERPAID   BankTransferStatus = 3
+	BankTransferStatus_BANK_TRANSFER_STATUS_OVERPAID    BankTransferStatus = 4
+	BankTransferStatus_BANK_TRANSFER_STATUS_EXPIRED     BankTransferStatus = 5
+)
+
+// Enum value maps for BankTransferStatus.
+var (
+	BankTransferStatus_name = map[int32]string{
+		0: "BANK_TRANSFER_STATUS_UNSPECIFIED",
+		1: "BANK_TRANSFER_STATUS_AWAITING",
+		2: "BANK_TRANSFER_STATUS_PAID",
+		3: "BANK_TRANSFER_STATUS_UNDERPAID",
+		4: "BANK_TRANSFER_STATUS_OVERPAID",
+		5: "BANK_TRANSFER_STATUS_EXPIRED",
+	}
+	BankTransferStatus_value = map[string]int32{
+		"BANK_TRANSFER_STATUS_UNSPECIFIED": 0,
+		"BANK_TRANSFER_STATUS_AWAITING":    1,
+		"BANK_TRANSFER_STATUS_PAID":        2,
+		"BANK_TRANSFER_STATUS_UNDERPAID":   3,
+		"BANK_TRANSFER_STATUS_OVERPAID":    4,
+		"BANK_TRANSFER_STATUS_EXPIRED":     5,
+	}
+)
+
+func (x BankTransferStatus) Enum() *BankTransferStatus {
+	p := new(BankTransferStatus)
+	*p = x
+	return p
+}
+
+func (x BankTransferStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BankTransferStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_bank_transfer_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (BankTransferStatus) Type() protoreflect.EnumType {
+	return &file_payment_bank_transfer_messages_proto_enumTypes[0]
+}
+
+func (x BankTransferStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BankTransferStatus.Descriptor instead.
+func (BankTransferStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type BankDepositMatch int32
+
+const (
+	BankDepositMatch_BANK_DEPOSIT_MATCH_UNSPECIFIED BankDepositMatch = 0
+	BankDepositMatch_BANK_DEPOSIT_MATCH_MATCHED     BankDepositMatch = 1
+	BankDepositMatch_BANK_DEPOSIT_MATCH_UNDERPAID   BankDepositMatch = 2
+	BankDepositMatch_BANK_DEPOSIT_MATCH_OVERPAID    BankDepositMatch = 3
+	BankDepositMatch_BANK_DEPOSIT_MATCH_UNMATCHED   BankDepositMatch = 4
+)
+
+// Enum value maps for BankDepositMatch.
+var (
+	BankDepositMatch_name = map[int32]string{
+		0: "BANK_DEPOSIT_MATCH_UNSPECIFIED",
+		1: "BANK_DEPOSIT_MATCH_MATCHED",
+		2: "BANK_DEPOSIT_MATCH_UNDERPAID",
+		3: "BANK_DEPOSIT_MATCH_OVERPAID",
+		4: "BANK_DEPOSIT_MATCH_UNMATCHED",
+	}
+	BankDepositMatch_value = map[string]int32{
+		"BANK_DEPOSIT_MATCH_UNSPECIFIED": 0,
+		"BANK_DEPOSIT_MATCH_MATCHED":     1,
+		"BANK_DEPOSIT_MATCH_UNDERPAID":   2,
+		"BANK_DEPOSIT_MATCH_OVERPAID":    3,
+		"BANK_DEPOSIT_MATCH_UNMATCHED":   4,
+	}
+)
+
+func (x BankDepositMatch) Enum() *BankDepositMatch {
+	p := new(BankDepositMatch)
+	*p = x
+	return p
+}
+
+func (x BankDepositMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BankDepositMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_bank_transfer_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (BankDepositMatch) Type() protoreflect.EnumType {
+	return &file_payment_bank_transfer_messages_proto_enumTypes[1]
+}
+
+func (x BankDepositMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BankDepositMatch.Descriptor instead.
+func (BankDepositMatch) EnumDescriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type BankTransferInstructions struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId      string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	BankCode       string                 `protobuf:"bytes,2,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
+	BankName       string                 `protobuf:"bytes,3,opt,name=bank_name,json=bankName,proto3" json:"bank_name,omitempty"`
+	BranchCode     string                 `protobuf:"bytes,4,opt,name=branch_code,json=branchCode,proto3" json:"branch_code,omitempty"`
+	BranchName     string                 `protobuf:"bytes,5,opt,name=branch_name,json=branchName,proto3" json:"branch_name,omitempty"`
+	AccountType    string                 `protobuf:"bytes,6,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	AccountNumber  string                 `protobuf:"bytes,7,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountHolder  string                 `protobuf:"bytes,8,opt,name=account_holder,json=accountHolder,proto3" json:"account_holder,omitempty"`
+	Amount         *shared.Money          `protobuf:"bytes,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	ReceivedAmount *shared.Money          `protobuf:"bytes,10,opt,name=received_amount,json=receivedAmount,proto3" json:"received_amount,omitempty"`
+	Deadline       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Status         BankTransferStatus     `protobuf:"varint,12,opt,name=status,proto3,enum=shinkansen.payment.BankTransferStatus" json:"status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BankTransferInstructions) Reset() {
+	*x = BankTransferInstructions{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankTransferInstructions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankTransferInstructions) ProtoMessage() {}
+
+func (x *BankTransferInstructions) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankTransferInstructions.ProtoReflect.Descriptor instead.
+func (*BankTransferInstructions) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BankTransferInstructions) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetBankName() string {
+	if x != nil {
+		return x.BankName
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetBranchCode() string {
+	if x != nil {
+		return x.BranchCode
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetBranchName() string {
+	if x != nil {
+		return x.BranchName
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetAccountHolder() string {
+	if x != nil {
+		return x.AccountHolder
+	}
+	return ""
+}
+
+func (x *BankTransferInstructions) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *BankTransferInstructions) GetReceivedAmount() *shared.Money {
+	if x != nil {
+		return x.ReceivedAmount
+	}
+	return nil
+}
+
+func (x *BankTransferInstructions) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *BankTransferInstructions) GetStatus() BankTransferStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BankTransferStatus_BANK_TRANSFER_STATUS_UNSPECIFIED
+}
+
+type BankDeposit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountNumber string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	InquiryNumber string                 `protobuf:"bytes,3,opt,name=inquiry_number,json=inquiryNumber,proto3" json:"inquiry_number,omitempty"`
+	ValueDate     string                 `protobuf:"bytes,4,opt,name=value_date,json=valueDate,proto3" json:"value_date,omitempty"`
+	Amount        *shared.Money          `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	DepositorName string                 `protobuf:"bytes,6,opt,name=depositor_name,json=depositorName,proto3" json:"depositor_name,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,7,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Match         BankDepositMatch       `protobuf:"varint,8,opt,name=match,proto3,enum=shinkansen.payment.BankDepositMatch" json:"match,omitempty"`
+	FileName      string                 `protobuf:"bytes,9,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BankDeposit) Reset() {
+	*x = BankDeposit{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankDeposit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankDeposit) ProtoMessage() {}
+
+func (x *BankDeposit) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankDeposit.ProtoReflect.Descriptor instead.
+func (*BankDeposit) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BankDeposit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetInquiryNumber() string {
+	if x != nil {
+		return x.InquiryNumber
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetValueDate() string {
+	if x != nil {
+		return x.ValueDate
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *BankDeposit) GetDepositorName() string {
+	if x != nil {
+		return x.DepositorName
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetMatch() BankDepositMatch {
+	if x != nil {
+		return x.Match
+	}
+	return BankDepositMatch_BANK_DEPOSIT_MATCH_UNSPECIFIED
+}
+
+func (x *BankDeposit) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *BankDeposit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetBankTransferInstructionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBankTransferInstructionsRequest) Reset() {
+	*x = GetBankTransferInstructionsRequest{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBankTransferInstructionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBankTransferInstructionsRequest) ProtoMessage() {}
+
+func (x *GetBankTransferInstructionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBankTransferInstructionsRequest.ProtoReflect.Descriptor instead.
+func (*GetBankTransferInstructionsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBankTransferInstructionsRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type GetBankTransferInstructionsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Instructions  *BankTransferInstructions `protobuf:"bytes,1,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBankTransferInstructionsResponse) Reset() {
+	*x = GetBankTransferInstructionsResponse{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBankTransferInstructionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBankTransferInstructionsResponse) ProtoMessage() {}
+
+func (x *GetBankTransferInstructionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBankTransferInstructionsResponse.ProtoReflect.Descriptor instead.
+func (*GetBankTransferInstructionsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBankTransferInstructionsResponse) GetInstructions() *BankTransferInstructions {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
+}
+
+type ImportBankDepositsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBankDepositsRequest) Reset() {
+	*x = ImportBankDepositsRequest{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBankDepositsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBankDepositsRequest) ProtoMessage() {}
+
+func (x *ImportBankDepositsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBankDepositsRequest.ProtoReflect.Descriptor instead.
+func (*ImportBankDepositsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportBankDepositsRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ImportBankDepositsRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ImportBankDepositsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      int32                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,2,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Matched       int32                  `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"`
+	Unmatched     int32                  `protobuf:"varint,4,opt,name=unmatched,proto3" json:"unmatched,omitempty"`
+	Deposits      []*BankDeposit         `protobuf:"bytes,5,rep,name=deposits,proto3" json:"deposits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBankDepositsResponse) Reset() {
+	*x = ImportBankDepositsResponse{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBankDepositsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBankDepositsResponse) ProtoMessage() {}
+
+func (x *ImportBankDepositsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBankDepositsResponse.ProtoReflect.Descriptor instead.
+func (*ImportBankDepositsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportBankDepositsResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportBankDepositsResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportBankDepositsResponse) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *ImportBankDepositsResponse) GetUnmatched() int32 {
+	if x != nil {
+		return x.Unmatched
+	}
+	return 0
+}
+
+func (x *ImportBankDepositsResponse) GetDeposits() []*BankDeposit {
+	if x != nil {
+		return x.Deposits
+	}
+	return nil
+}
+
+type ListBankDepositsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         BankDepositMatch       `protobuf:"varint,1,opt,name=match,proto3,enum=shinkansen.payment.BankDepositMatch" json:"match,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBankDepositsRequest) Reset() {
+	*x = ListBankDepositsRequest{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBankDepositsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBankDepositsRequest) ProtoMessage() {}
+
+func (x *ListBankDepositsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBankDepositsRequest.ProtoReflect.Descriptor instead.
+func (*ListBankDepositsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListBankDepositsRequest) GetMatch() BankDepositMatch {
+	if x != nil {
+		return x.Match
+	}
+	return BankDepositMatch_BANK_DEPOSIT_MATCH_UNSPECIFIED
+}
+
+func (x *ListBankDepositsRequest) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListBankDepositsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deposits      []*BankDeposit         `protobuf:"bytes,1,rep,name=deposits,proto3" json:"deposits,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBankDepositsResponse) Reset() {
+	*x = ListBankDepositsResponse{}
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBankDepositsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBankDepositsResponse) ProtoMessage() {}
+
+func (x *ListBankDepositsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_bank_transfer_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBankDepositsResponse.ProtoReflect.Descriptor instead.
+func (*ListBankDepositsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_bank_transfer_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListBankDepositsResponse) GetDeposits() []*BankDeposit {
+	if x != nil {
+		return x.Deposits
+	}
+	return nil
+}
+
+func (x *ListBankDepositsResponse) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_payment_bank_transfer_messages_proto protoreflect.FileDescriptor
+
+const file_payment_bank_transfer_messages_proto_rawDesc = "" +
+	"\n" +
+	"$payment/bank_transfer_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13shared/common.proto\"\x93\x04\n" +
+	"\x18BankTransferInstructions\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1b\n" +
+	"\tbank_code\x18\x02 \x01(\tR\bbankCode\x12\x1b\n" +
+	"\tbank_name\x18\x03 \x01(\tR\bbankName\x12\x1f\n" +
+	"\vbranch_code\x18\x04 \x01(\tR\n" +
+	"branchCode\x12\x1f\n" +
+	"\vbranch_name\x18\x05 \x01(\tR\n" +
+	"branchName\x12!\n" +
+	"\faccount_type\x18\x06 \x01(\tR\vaccountType\x12%\n" +
+	"\x0eaccount_number\x18\a \x01(\tR\raccountNumber\x12%\n" +
+	"\x0eaccount_holder\x18\b \x01(\tR\raccountHolder\x120\n" +
+	"\x06amount\x18\t \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12A\n" +
+	"\x0freceived_amount\x18\n" +
+	" \x01(\v2\x18.shinkansen.common.MoneyR\x0ereceivedAmount\x126\n" +
+	"\bdeadline\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12>\n" +
+	"\x06status\x18\f \x01(\x0e2&.shinkansen.payment.BankTransferStatusR\x06status\"\x96\x03\n" +
+	"\vBankDeposit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\x12%\n" +
+	"\x0einquiry_number\x18\x03 \x01(\tR\rinquiryNumber\x12\x1d\n" +
+	"\n" +
+	"value_date\x18\x04 \x01(\tR\tvalueDate\x120\n" +
+	"\x06amount\x18\x05 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12%\n" +
+	"\x0edepositor_name\x18\x06 \x01(\tR\rdepositorName\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\a \x01(\tR\tpaymentId\x12:\n" +
+	"\x05match\x18\b \x01(\x0e2$.shinkansen.payment.BankDepositMatchR\x05match\x12\x1b\n" +
+	"\tfile_name\x18\t \x01(\tR\bfileName\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"C\n" +
+	"\"GetBankTransferInstructionsRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"w\n" +
+	"#GetBankTransferInstructionsResponse\x12P\n" +
+	"\finstructions\x18\x01 \x01(\v2,.shinkansen.payment.BankTransferInstructionsR\finstructions\"R\n" +
+	"\x19ImportBankDepositsRequest\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"\xcd\x01\n" +
+	"\x1aImportBankDepositsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x05R\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x02 \x01(\x05R\n" +
+	"duplicates\x12\x18\n" +
+	"\amatched\x18\x03 \x01(\x05R\amatched\x12\x1c\n" +
+	"\tunmatched\x18\x04 \x01(\x05R\tunmatched\x12;\n" +
+	"\bdeposits\x18\x05 \x03(\v2\x1f.shinkansen.payment.BankDepositR\bdeposits\"\x94\x01\n" +
+	"\x17ListBankDepositsRequest\x12:\n" +
+	"\x05match\x18\x01 \x01(\x0e2$.shinkansen.payment.BankDepositMatchR\x05match\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"\x96\x01\n" +
+	"\x18ListBankDepositsResponse\x12;\n" +
+	"\bdeposits\x18\x01 \x03(\v2\x1f.shinkansen.payment.BankDepositR\bdeposits\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination*\xe5\x01\n" +
+	"\x12BankTransferStatus\x12$\n" +
+	" BANK_TRANSFER_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dBANK_TRANSFER_STATUS_AWAITING\x10\x01\x12\x1d\n" +
+	"\x19BANK_TRANSFER_STATUS_PAID\x10\x02\x12\"\n" +
+	"\x1eBANK_TRANSFER_STATUS_UNDERPAID\x10\x03\x12!\n" +
+	"\x1dBANK_TRANSFER_STATUS_OVERPAID\x10\x04\x12 \n" +
+	"\x1cBANK_TRANSFER_STATUS_EXPIRED\x10\x05*\xbb\x01\n" +
+	"\x10BankDepositMatch\x12\"\n" +
+	"\x1eBANK_DEPOSIT_MATCH_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aBANK_DEPOSIT_MATCH_MATCHED\x10\x01\x12 \n" +
+	"\x1cBANK_DEPOSIT_MATCH_UNDERPAID\x10\x02\x12\x1f\n" +
+	"\x1bBANK_DEPOSIT_MATCH_OVERPAID\x10\x03\x12 \n" +
+	"\x1cBANK_DEPOSIT_MATCH_UNMATCHED\x10\x04B=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+
+var (
+	file_payment_bank_transfer_messages_proto_rawDescOnce sync.Once
+	file_payment_bank_transfer_messages_proto_rawDescData []byte
+)
+
+func file_payment_bank_transfer_messages_proto_rawDescGZIP() []byte {
+	file_payment_bank_transfer_messages_proto_rawDescOnce.Do(func() {
+		file_payment_bank_transfer_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_bank_transfer_messages_proto_rawDesc), len(file_payment_bank_transfer_messages_proto_rawDesc)))
+	})
+	return file_payment_bank_transfer_messages_proto_rawDescData
+}
+
+var file_payment_bank_transfer_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_payment_bank_transfer_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_payment_bank_transfer_messages_proto_goTypes = []any{
+	(BankTransferStatus)(0),                     // 0: shinkansen.payment.BankTransferStatus
+	(BankDepositMatch)(0),                       // 1: shinkansen.payment.BankDepositMatch
+	(*BankTransferInstructions)(nil),            // 2: shinkansen.payment.BankTransferInstructions
+	(*BankDeposit)(nil),                         // 3: shinkansen.payment.BankDeposit
+	(*GetBankTransferInstructionsRequest)(nil),  // 4: shinkansen.payment.GetBankTransferInstructionsRequest
+	(*GetBankTransferInstructionsResponse)(nil), // 5: shinkansen.payment.GetBankTransferInstructionsResponse
+	(*ImportBankDepositsRequest)(nil),           // 6: shinkansen.payment.ImportBankDepositsRequest
+	(*ImportBankDepositsResponse)(nil),          // 7: shinkansen.payment.ImportBankDepositsResponse
+	(*ListBankDepositsRequest)(nil),             // 8: shinkansen.payment.ListBankDepositsRequest
+	(*ListBankDepositsResponse)(nil),            // 9: shinkansen.payment.ListBankDepositsResponse
+	(*shared.Money)(nil),                        // 10: shinkansen.common.Money
+	(*timestamppb.Timestamp)(nil),               // 11: google.protobuf.Timestamp
+	(*shared.Pagination)(nil),                   // 12: shinkansen.common.Pagination
+}
+var file_payment_bank_transfer_messages_proto_depIdxs = []int32{
+	10, // 0: shinkansen.payment.BankTransferInstructions.amount:type_name -> shinkansen.common.Money
+	10, // 1: shinkansen.payment.BankTransferInstructions.received_amount:type_name -> shinkansen.common.Money
+	11, // 2: shinkansen.payment.BankTransferInstructions.deadline:type_name -> google.protobuf.Timestamp
+	0,  // 3: shinkansen.payment.BankTransferInstructions.status:type_name -> shinkansen.payment.BankTransferStatus
+	10, // 4: shinkansen.payment.BankDeposit.amount:type_name -> shinkansen.common.Money
+	1,  // 5: shinkansen.payment.BankDeposit.match:type_name -> shinkansen.payment.BankDepositMatch
+	11, // 6: shinkansen.payment.BankDeposit.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 7: shinkansen.payment.GetBankTransferInstructionsResponse.instructions:type_name -> shinkansen.payment.BankTransferInstructions
+	3,  // 8: shinkansen.payment.ImportBankDepositsResponse.deposits:type_name -> shinkansen.payment.BankDeposit
+	1,  // 9: shinkansen.payment.ListBankDepositsRequest.match:type_name -> shinkansen.payment.BankDepositMatch
+	12, // 10: shinkansen.payment.ListBankDepositsRequest.pagination:type_name -> shinkansen.common.Pagination
+	3,  // 11: shinkansen.payment.ListBankDepositsResponse.deposits:type_name -> shinkansen.payment.BankDeposit
+	12, // 12: shinkansen.payment.ListBankDepositsResponse.pagination:type_name -> shinkansen.common.Pagination
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_payment_bank_transfer_messages_proto_init() }
+func file_payment_bank_transfer_messages_proto_init() {
+	if File_payment_bank_transfer_messages_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_bank_transfer_messages_proto_rawDesc), len(file_payment_bank_transfer_messages_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payment_bank_transfer_messages_proto_goTypes,
+		DependencyIndexes: file_payment_bank_transfer_messages_proto_depIdxs,
+		EnumInfos:         file_payment_bank_transfer_messages_proto_enumTypes,
+		MessageInfos:      file_payment_bank_transfer_messages_proto_msgTypes,
+	}.Build()
+	File_payment_bank_transfer_messages_proto = out.File
+	file_payment_bank_transfer_messages_proto_goTypes = nil
+	file_payment_bank_transfer_messages_proto_depIdxs = nil
+}
@@ -0,0 +1,465 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.30.2
+// source: payment/deferred_payment_messages.proto
+
+package payment
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreditCheckResult int32
+
+const (
+	CreditCheckResult_CREDIT_CHECK_RESULT_UNSPECIFIED CreditCheckResult = 0
+	CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED    CreditCheckResult = 1
+	CreditCheckResult_CREDIT_CHECK_RESULT_DECLINED    CreditCheckResult = 2
+	CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW      CreditCheckResult = 3
+)
+
+// Enum value maps for CreditCheckResult.
+var (
+	CreditCheckResult_name = map[int32]string{
+		0: "CREDIT_CHECK_RESULT_UNSPECIFIED",
+		1: "CREDIT_CHECK_RESULT_APPROVED",
+		2: "CREDIT_CHECK_RESULT_DECLINED",
+		3: "CREDIT_CHECK_RESULT_REVIEW",
+	}
+	CreditCheckResult_value = map[string]int32{
+		"CREDIT_CHECK_RESULT_UNSPECIFIED": 0,
+		"CREDIT_CHECK_RESULT_APPROVED":    1,
+		"CREDIT_CHECK_RESULT_DECLINED":    2,
+		"CREDIT_CHECK_RESULT_REVIEW":      3,
+	}
+)
+
+func (x CreditCheckResult) Enum() *CreditCheckResult {
+	p := new(CreditCheckResult)
+	*p = x
+	return p
+}
+
+func (x CreditCheckResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CreditCheckResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_deferred_payment_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (CreditCheckResult) Type() protoreflect.EnumType {
+	return &file_payment_deferred_payment_messages_proto_enumTypes[0]
+}
+
+func (x CreditCheckResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CreditCheckResult.Descriptor instead.
+func (CreditCheckResult) EnumDescriptor() ([]byte, []int) {
+	return file_payment_deferred_payment_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type DeferredInvoiceStatus int32
+
+const (
+	DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_UNSPECIFIED DeferredInvoiceStatus = 0
+	DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_NOT_ISSUED  DeferredInvoiceStatus = 1
+	DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_ISSUED      DeferredInvoiceStatus = 2
+	DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_PAID        DeferredInvoiceStatus = 3
+	DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_OVERDUE     DeferredInvoiceStatus = 4
+	DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_CANCELLED   DeferredInvoiceStatus = 5
+)
+
+// Enum value maps for DeferredInvoiceStatus.
+var (
+	DeferredInvoiceStatus_name = map[int32]string{
+		0: "DEFERRED_INVOICE_STATUS_UNSPECIFIED",
+		1: "DEFERRED_INVOICE_STATUS_NOT_ISSUED",
+		2: "DEFERRED_INVOICE_STATUS_ISSUED",
+		3: "DEFERRED_INVOICE_STATUS_PAID",
+		4: "DEFERRED_INVOICE_STATUS_OVERDUE",
+		5: "DEFERRED_INVOICE_STATUS_CANCELLED",
+	}
+	DeferredInvoiceStatus_value = map[string]int32{
+		"DEFERRED_INVOICE_STATUS_UNSPECIFIED": 0,
+		"DEFERRED_INVOICE_STATUS_NOT_ISSUED":  1,
+		"DEFERRED_INVOICE_STATUS_ISSUED":      2,
+		"DEFERRED_INVOICE_STATUS_PAID":        3,
+		"DEFERRED_INVOICE_STATUS_OVERDUE":     4,
+		"DEFERRED_INVOICE_STATUS_CANCELLED":   5,
+	}
+)
+
+func (x DeferredInvoiceStatus) Enum() *DeferredInvoiceStatus {
+	p := new(DeferredInvoiceStatus)
+	*p = x
+	return p
+}
+
+func (x DeferredInvoiceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeferredInvoiceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_deferred_payment_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (DeferredInvoiceStatus) Type() protoreflect.EnumType {
+	return &file_payment_deferred_payment_messages_proto_enumTypes[1]
+}
+
+func (x DeferredInvoiceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeferredInvoiceStatus.Descriptor instead.
+func (DeferredInvoiceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_deferred_payment_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type DeferredPayment struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId             string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Provider              string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderTransactionId string                 `protobuf:"bytes,3,opt,name=provider_transaction_id,json=providerTransactionId,proto3" json:"provider_transaction_id,omitempty"`
+	CreditCheck           CreditCheckResult      `protobuf:"varint,4,opt,name=credit_check,json=creditCheck,proto3,enum=shinkansen.payment.CreditCheckResult" json:"credit_check,omitempty"`
+	CreditCheckReason     string                 `protobuf:"bytes,5,opt,name=credit_check_reason,json=creditCheckReason,proto3" json:"credit_check_reason,omitempty"`
+	InvoiceStatus         DeferredInvoiceStatus  `protobuf:"varint,6,opt,name=invoice_status,json=invoiceStatus,proto3,enum=shinkansen.payment.DeferredInvoiceStatus" json:"invoice_status,omitempty"`
+	Carrier               string                 `protobuf:"bytes,7,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber        string                 `protobuf:"bytes,8,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	ShippedAt             *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	PaidAt                *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DeferredPayment) Reset() {
+	*x = DeferredPayment{}
+	mi := &file_payment_deferred_payment_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferredPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferredPayment) ProtoMessage() {}
+
+func (x *DeferredPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_deferred_payment_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferredPayment.ProtoReflect.Descriptor instead.
+func (*DeferredPayment) Descriptor() ([]byte, []int) {
+	return file_payment_deferred_payment_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeferredPayment) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *DeferredPayment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *DeferredPayment) GetProviderTransactionId() string {
+	if x != nil {
+		return x.ProviderTransactionId
+	}
+	return ""
+}
+
+func (x *DeferredPayment) GetCreditCheck() CreditCheckResult {
+	if x != nil {
+		return x.CreditCheck
+	}
+	return CreditCheckResult_CREDIT_CHECK_RESULT_UNSPECIFIED
+}
+
+func (x *DeferredPayment) GetCreditCheckReason() string {
+	if x != nil {
+		return x.CreditCheckReason
+	}
+	return ""
+}
+
+func (x *DeferredPayment) GetInvoiceStatus() DeferredInvoiceStatus {
+	if x != nil {
+		return x.InvoiceStatus
+	}
+	return DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_UNSPECIFIED
+}
+
+func (x *DeferredPayment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *DeferredPayment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *DeferredPayment) GetShippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return nil
+}
+
+func (x *DeferredPayment) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+func (x *DeferredPayment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeferredPayment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetDeferredPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeferredPaymentRequest) Reset() {
+	*x = GetDeferredPaymentRequest{}
+	mi := &file_payment_deferred_payment_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeferredPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeferredPaymentRequest) ProtoMessage() {}
+
+func (x *GetDeferredPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_deferred_payment_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeferredPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetDeferredPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_deferred_payment_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDeferredPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetDeferredPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetDeferredPaymentResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeferredPayment *DeferredPayment       `protobuf:"bytes,1,opt,name=deferred_payment,json=deferredPayment,proto3" json:"deferred_payment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDeferredPaymentResponse) Reset() {
+	*x = GetDeferredPaymentResponse{}
+	mi := &file_payment_deferred_payment_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeferredPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeferredPaymentResponse) ProtoMessage() {}
+
+func (x *GetDeferredPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_deferred_payment_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeferredPaymentResponse.ProtoReflect.Descriptor instead.
+func (*GetDeferredPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_deferred_payment_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetDeferredPaymentResponse) GetDeferredPayment() *DeferredPayment {
+	if x != nil {
+		return x.DeferredPayment
+	}
+	return nil
+}
+
+var File_payment_deferred_payment_messages_proto protoreflect.FileDescriptor
+
+const file_payment_deferred_payment_messages_proto_rawDesc = "" +
+	"\n" +
+	"'payment/deferred_payment_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x04\n" +
+	"\x0fDeferredPayment\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x126\n" +
+	"\x17provider_transaction_id\x18\x03 \x01(\tR\x15providerTransactionId\x12H\n" +
+	"\fcredit_check\x18\x04 \x01(\x0e2%.shinkansen.payment.CreditCheckResultR\vcreditCheck\x12.\n" +
+	"\x13credit_check_reason\x18\x05 \x01(\tR\x11creditCheckReason\x12P\n" +
+	"\x0einvoice_status\x18\x06 \x01(\x0e2).shinkansen.payment.DeferredInvoiceStatusR\rinvoiceStatus\x12\x18\n" +
+	"\acarrier\x18\a \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\b \x01(\tR\x0etrackingNumber\x129\n" +
+	"\n" +
+	"shipped_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tshippedAt\x123\n" +
+	"\apaid_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"U\n" +
+	"\x19GetDeferredPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"l\n" +
+	"\x1aGetDeferredPaymentResponse\x12N\n" +
+	"\x10deferred_payment\x18\x01 \x01(\v2#.shinkansen.payment.DeferredPaymentR\x0fdeferredPayment*\x9c\x01\n" +
+	"\x11CreditCheckResult\x12#\n" +
+	"\x1fCREDIT_CHECK_RESULT_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cCREDIT_CHECK_RESULT_APPROVED\x10\x01\x12 \n" +
+	"\x1cCREDIT_CHECK_RESULT_DECLINED\x10\x02\x12\x1e\n" +
+	"\x1aCREDIT_CHECK_RESULT_REVIEW\x10\x03*\xfa\x01\n" +
+	"\x15DeferredInvoiceStatus\x12'\n" +
+	"#DEFERRED_INVOICE_STATUS_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"DEFERRED_INVOICE_STATUS_NOT_ISSUED\x10\x01\x12\"\n" +
+	"\x1eDEFERRED_INVOICE_STATUS_ISSUED\x10\x02\x12 \n" +
+	"\x1cDEFERRED_INVOICE_STATUS_PAID\x10\x03\x12#\n" +
+	"\x1fDEFERRED_INVOICE_STATUS_OVERDUE\x10\x04\x12%\n" +
+	"!DEFERRED_INVOICE_STATUS_CANCELLED\x10\x05B=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+
+var (
+	file_payment_deferred_payment_messages_proto_rawDescOnce sync.Once
+	file_payment_deferred_payment_messages_proto_rawDescData []byte
+)
+
+func file_payment_deferred_payment_messages_proto_rawDescGZIP() []byte {
+	file_payment_deferred_payment_messages_proto_rawDescOnce.Do(func() {
+		file_payment_deferred_payment_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_deferred_payment_messages_proto_rawDesc), len(file_payment_deferred_payment_messages_proto_rawDesc)))
+	})
+	return file_payment_deferred_payment_messages_proto_rawDescData
+}
+
+var file_payment_deferred_payment_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_payment_deferred_payment_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_payment_deferred_payment_messages_proto_goTypes = []any{
+	(CreditCheckResult)(0),             // 0: shinkansen.payment.CreditCheckResult
+	(DeferredInvoiceStatus)(0),         // 1: shinkansen.payment.DeferredInvoiceStatus
+	(*DeferredPayment)(nil),            // 2: shinkansen.payment.DeferredPayment
+	(*GetDeferredPaymentRequest)(nil),  // 3: shinkansen.payment.GetDeferredPaymentRequest
+	(*GetDeferredPaymentResponse)(nil), // 4: shinkansen.payment.GetDeferredPaymentResponse
+	(*timestamppb.Timestamp)(nil),      // 5: google.protobuf.Timestamp
+}
+var file_payment_deferred_payment_messages_proto_depIdxs = []int32{
+	0, // 0: shinkansen.payment.DeferredPayment.credit_check:type_name -> shinkansen.payment.CreditCheckResult
+	1, // 1: shinkansen.payment.DeferredPayment.invoice_status:type_name -> shinkansen.payment.DeferredInvoiceStatus
+	5, // 2: shinkansen.payment.DeferredPayment.shipped_at:type_name -> google.protobuf.Timestamp
+	5, // 3: shinkansen.payment.DeferredPayment.paid_at:type_name -> google.protobuf.Timestamp
+	5, // 4: shinkansen.payment.DeferredPayment.created_at:type_name -> google.protobuf.Timestamp
+	5, // 5: shinkansen.payment.DeferredPayment.updated_at:type_name -> google.protobuf.Timestamp
+	2, // 6: shinkansen.payment.GetDeferredPaymentResponse.deferred_payment:type_name -> shinkansen.payment.DeferredPayment
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_payment_deferred_payment_messages_proto_init() }
+func file_payment_deferred_payment_messages_proto_init() {
+	if File_payment_deferred_payment_messages_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_deferred_payment_messages_proto_rawDesc), len(file_payment_deferred_payment_messages_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payment_deferred_payment_messages_proto_goTypes,
+		DependencyIndexes: file_payment_deferred_payment_messages_proto_depIdxs,
+		EnumInfos:         file_payment_deferred_payment_messages_proto_enumTypes,
+		MessageInfos:      file_payment_deferred_payment_messages_proto_msgTypes,
+	}.Build()
+	File_payment_deferred_payment_messages_proto = out.File
+	file_payment_deferred_payment_messages_proto_goTypes = nil
+	file_payment_deferred_payment_messages_proto_depIdxs = nil
+}
@@ -0,0 +1,899 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.30.2
+// source: payment/dispute_messages.proto
+
+package payment
+
+import (
+	shared "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DisputeStatus int32
+
+const (
+	DisputeStatus_DISPUTE_STATUS_UNSPECIFIED    DisputeStatus = 0
+	DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE DisputeStatus = 1
+	DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW   DisputeStatus = 2
+	DisputeStatus_DISPUTE_STATUS_WON            DisputeStatus = 3
+	DisputeStatus_DISPUTE_STATUS_LOST           DisputeStatus = 4
+)
+
+// Enum value maps for DisputeStatus.
+var (
+	DisputeStatus_name = map[int32]string{
+		0: "DISPUTE_STATUS_UNSPECIFIED",
+		1: "DISPUTE_STATUS_NEEDS_RESPONSE",
+		2: "DISPUTE_STATUS_UNDER_REVIEW",
+		3: "DISPUTE_STATUS_WON",
+		4: "DISPUTE_STATUS_LOST",
+	}
+	DisputeStatus_value = map[string]int32{
+		"DISPUTE_STATUS_UNSPECIFIED":    0,
+		"DISPUTE_STATUS_NEEDS_RESPONSE": 1,
+		"DISPUTE_STATUS_UNDER_REVIEW":   2,
+		"DISPUTE_STATUS_WON":            3,
+		"DISPUTE_STATUS_LOST":           4,
+	}
+)
+
+func (x DisputeStatus) Enum() *DisputeStatus {
+	p := new(DisputeStatus)
+	*p = x
+	return p
+}
+
+func (x DisputeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisputeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_dispute_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (DisputeStatus) Type() protoreflect.EnumType {
+	return &file_payment_dispute_messages_proto_enumTypes[0]
+}
+
+func (x DisputeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisputeStatus.Descriptor instead.
+func (DisputeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type DisputeEvidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisputeId     string                 `protobuf:"bytes,2,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	FileName      string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeEvidence) Reset() {
+	*x = DisputeEvidence{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidence) ProtoMessage() {}
+
+func (x *DisputeEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeEvidence.ProtoReflect.Descriptor instead.
+func (*DisputeEvidence) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DisputeEvidence) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *DisputeEvidence) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Dispute struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId           string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId             string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProviderDisputeId   string                 `protobuf:"bytes,4,opt,name=provider_dispute_id,json=providerDisputeId,proto3" json:"provider_dispute_id,omitempty"`
+	Status              DisputeStatus          `protobuf:"varint,5,opt,name=status,proto3,enum=shinkansen.payment.DisputeStatus" json:"status,omitempty"`
+	Reason              string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount              *shared.Money          `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	EvidenceDueBy       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=evidence_due_by,json=evidenceDueBy,proto3" json:"evidence_due_by,omitempty"`
+	EvidenceSubmittedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=evidence_submitted_at,json=evidenceSubmittedAt,proto3" json:"evidence_submitted_at,omitempty"`
+	Adjustment          *shared.Money          `protobuf:"bytes,10,opt,name=adjustment,proto3" json:"adjustment,omitempty"`
+	ClosedAt            *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Evidence            []*DisputeEvidence     `protobuf:"bytes,14,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Dispute) Reset() {
+	*x = Dispute{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dispute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dispute) ProtoMessage() {}
+
+func (x *Dispute) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dispute.ProtoReflect.Descriptor instead.
+func (*Dispute) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Dispute) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dispute) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Dispute) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Dispute) GetProviderDisputeId() string {
+	if x != nil {
+		return x.ProviderDisputeId
+	}
+	return ""
+}
+
+func (x *Dispute) GetStatus() DisputeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DisputeStatus_DISPUTE_STATUS_UNSPECIFIED
+}
+
+func (x *Dispute) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Dispute) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Dispute) GetEvidenceDueBy() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EvidenceDueBy
+	}
+	return nil
+}
+
+func (x *Dispute) GetEvidenceSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EvidenceSubmittedAt
+	}
+	return nil
+}
+
+func (x *Dispute) GetAdjustment() *shared.Money {
+	if x != nil {
+		return x.Adjustment
+	}
+	return nil
+}
+
+func (x *Dispute) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Dispute) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Dispute) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Dispute) GetEvidence() []*DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type ListDisputesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        DisputeStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=shinkansen.payment.DisputeStatus" json:"status,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisputesRequest) Reset() {
+	*x = ListDisputesRequest{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisputesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisputesRequest) ProtoMessage() {}
+
+func (x *ListDisputesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisputesRequest.ProtoReflect.Descriptor instead.
+func (*ListDisputesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDisputesRequest) GetStatus() DisputeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DisputeStatus_DISPUTE_STATUS_UNSPECIFIED
+}
+
+func (x *ListDisputesRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListDisputesRequest) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListDisputesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disputes      []*Dispute             `protobuf:"bytes,1,rep,name=disputes,proto3" json:"disputes,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisputesResponse) Reset() {
+	*x = ListDisputesResponse{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisputesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisputesResponse) ProtoMessage() {}
+
+func (x *ListDisputesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisputesResponse.ProtoReflect.Descriptor instead.
+func (*ListDisputesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListDisputesResponse) GetDisputes() []*Dispute {
+	if x != nil {
+		return x.Disputes
+	}
+	return nil
+}
+
+func (x *ListDisputesResponse) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDisputeRequest) Reset() {
+	*x = GetDisputeRequest{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDisputeRequest) ProtoMessage() {}
+
+func (x *GetDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDisputeRequest.ProtoReflect.Descriptor instead.
+func (*GetDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+type GetDisputeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dispute       *Dispute               `protobuf:"bytes,1,opt,name=dispute,proto3" json:"dispute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDisputeResponse) Reset() {
+	*x = GetDisputeResponse{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDisputeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDisputeResponse) ProtoMessage() {}
+
+func (x *GetDisputeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDisputeResponse.ProtoReflect.Descriptor instead.
+func (*GetDisputeResponse) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetDisputeResponse) GetDispute() *Dispute {
+	if x != nil {
+		return x.Dispute
+	}
+	return nil
+}
+
+type AddDisputeEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDisputeEvidenceRequest) Reset() {
+	*x = AddDisputeEvidenceRequest{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDisputeEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDisputeEvidenceRequest) ProtoMessage() {}
+
+func (x *AddDisputeEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDisputeEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*AddDisputeEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddDisputeEvidenceRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AddDisputeEvidenceRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *AddDisputeEvidenceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type AddDisputeEvidenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evidence      *DisputeEvidence       `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDisputeEvidenceResponse) Reset() {
+	*x = AddDisputeEvidenceResponse{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDisputeEvidenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDisputeEvidenceResponse) ProtoMessage() {}
+
+func (x *AddDisputeEvidenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDisputeEvidenceResponse.ProtoReflect.Descriptor instead.
+func (*AddDisputeEvidenceResponse) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddDisputeEvidenceResponse) GetEvidence() *DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type SubmitDisputeEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitDisputeEvidenceRequest) Reset() {
+	*x = SubmitDisputeEvidenceRequest{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitDisputeEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitDisputeEvidenceRequest) ProtoMessage() {}
+
+func (x *SubmitDisputeEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitDisputeEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*SubmitDisputeEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubmitDisputeEvidenceRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+type SubmitDisputeEvidenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dispute       *Dispute               `protobuf:"bytes,1,opt,name=dispute,proto3" json:"dispute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitDisputeEvidenceResponse) Reset() {
+	*x = SubmitDisputeEvidenceResponse{}
+	mi := &file_payment_dispute_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitDisputeEvidenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitDisputeEvidenceResponse) ProtoMessage() {}
+
+func (x *SubmitDisputeEvidenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_dispute_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitDisputeEvidenceResponse.ProtoReflect.Descriptor instead.
+func (*SubmitDisputeEvidenceResponse) Descriptor() ([]byte, []int) {
+	return file_payment_dispute_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubmitDisputeEvidenceResponse) GetDispute() *Dispute {
+	if x != nil {
+		return x.Dispute
+	}
+	return nil
+}
+
+var File_payment_dispute_messages_proto protoreflect.FileDescriptor
+
+const file_payment_dispute_messages_proto_rawDesc = "" +
+	"\n" +
+	"\x1epayment/dispute_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13shared/common.proto\"\x90\x02\n" +
+	"\x0fDisputeEvidence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x02 \x01(\tR\tdisputeId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03R\tsizeBytes\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc6\x05\n" +
+	"\aDispute\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12.\n" +
+	"\x13provider_dispute_id\x18\x04 \x01(\tR\x11providerDisputeId\x129\n" +
+	"\x06status\x18\x05 \x01(\x0e2!.shinkansen.payment.DisputeStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x120\n" +
+	"\x06amount\x18\a \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12B\n" +
+	"\x0fevidence_due_by\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\revidenceDueBy\x12N\n" +
+	"\x15evidence_submitted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x13evidenceSubmittedAt\x128\n" +
+	"\n" +
+	"adjustment\x18\n" +
+	" \x01(\v2\x18.shinkansen.common.MoneyR\n" +
+	"adjustment\x127\n" +
+	"\tclosed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12?\n" +
+	"\bevidence\x18\x0e \x03(\v2#.shinkansen.payment.DisputeEvidenceR\bevidence\"\xaa\x01\n" +
+	"\x13ListDisputesRequest\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.shinkansen.payment.DisputeStatusR\x06status\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12=\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"\x8e\x01\n" +
+	"\x14ListDisputesResponse\x127\n" +
+	"\bdisputes\x18\x01 \x03(\v2\x1b.shinkansen.payment.DisputeR\bdisputes\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"2\n" +
+	"\x11GetDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\"K\n" +
+	"\x12GetDisputeResponse\x125\n" +
+	"\adispute\x18\x01 \x01(\v2\x1b.shinkansen.payment.DisputeR\adispute\"\xca\x01\n" +
+	"\x19AddDisputeEvidenceRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\fR\acontent\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\"]\n" +
+	"\x1aAddDisputeEvidenceResponse\x12?\n" +
+	"\bevidence\x18\x01 \x01(\v2#.shinkansen.payment.DisputeEvidenceR\bevidence\"=\n" +
+	"\x1cSubmitDisputeEvidenceRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\"V\n" +
+	"\x1dSubmitDisputeEvidenceResponse\x125\n" +
+	"\adispute\x18\x01 \x01(\v2\x1b.shinkansen.payment.DisputeR\adispute*\xa4\x01\n" +
+	"\rDisputeStatus\x12\x1e\n" +
+	"\x1aDISPUTE_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dDISPUTE_STATUS_NEEDS_RESPONSE\x10\x01\x12\x1f\n" +
+	"\x1bDISPUTE_STATUS_UNDER_REVIEW\x10\x02\x12\x16\n" +
+	"\x12DISPUTE_STATUS_WON\x10\x03\x12\x17\n" +
+	"\x13DISPUTE_STATUS_LOST\x10\x04B=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+
+var (
+	file_payment_dispute_messages_proto_rawDescOnce sync.Once
+	file_payment_dispute_messages_proto_rawDescData []byte
+)
+
+func file_payment_dispute_messages_proto_rawDescGZIP() []byte {
+	file_payment_dispute_messages_proto_rawDescOnce.Do(func() {
+		file_payment_dispute_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_dispute_messages_proto_rawDesc), len(file_payment_dispute_messages_proto_rawDesc)))
+	})
+	return file_payment_dispute_messages_proto_rawDescData
+}
+
+var file_payment_dispute_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_payment_dispute_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_payment_dispute_messages_proto_goTypes = []any{
+	(DisputeStatus)(0),                    // 0: shinkansen.payment.DisputeStatus
+	(*DisputeEvidence)(nil),               // 1: shinkansen.payment.DisputeEvidence
+	(*Dispute)(nil),                       // 2: shinkansen.payment.Dispute
+	(*ListDisputesRequest)(nil),           // 3: shinkansen.payment.ListDisputesRequest
+	(*ListDisputesResponse)(nil),          // 4: shinkansen.payment.ListDisputesResponse
+	(*GetDisputeRequest)(nil),             // 5: shinkansen.payment.GetDisputeRequest
+	(*GetDisputeResponse)(nil),            // 6: shinkansen.payment.GetDisputeResponse
+	(*AddDisputeEvidenceRequest)(nil),     // 7: shinkansen.payment.AddDisputeEvidenceRequest
+	(*AddDisputeEvidenceResponse)(nil),    // 8: shinkansen.payment.AddDisputeEvidenceResponse
+	(*SubmitDisputeEvidenceRequest)(nil),  // 9: shinkansen.payment.SubmitDisputeEvidenceRequest
+	(*SubmitDisputeEvidenceResponse)(nil), // 10: shinkansen.payment.SubmitDisputeEvidenceResponse
+	(*timestamppb.Timestamp)(nil),         // 11: google.protobuf.Timestamp
+	(*shared.Money)(nil),                  // 12: shinkansen.common.Money
+	(*shared.Pagination)(nil),             // 13: shinkansen.common.Pagination
+}
+var file_payment_dispute_messages_proto_depIdxs = []int32{
+	11, // 0: shinkansen.payment.DisputeEvidence.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: shinkansen.payment.Dispute.status:type_name -> shinkansen.payment.DisputeStatus
+	12, // 2: shinkansen.payment.Dispute.amount:type_name -> shinkansen.common.Money
+	11, // 3: shinkansen.payment.Dispute.evidence_due_by:type_name -> google.protobuf.Timestamp
+	11, // 4: shinkansen.payment.Dispute.evidence_submitted_at:type_name -> google.protobuf.Timestamp
+	12, // 5: shinkansen.payment.Dispute.adjustment:type_name -> shinkansen.common.Money
+	11, // 6: shinkansen.payment.Dispute.closed_at:type_name -> google.protobuf.Timestamp
+	11, // 7: shinkansen.payment.Dispute.created_at:type_name -> google.protobuf.Timestamp
+	11, // 8: shinkansen.payment.Dispute.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: shinkansen.payment.Dispute.evidence:type_name -> shinkansen.payment.DisputeEvidence
+	0,  // 10: shinkansen.payment.ListDisputesRequest.status:type_name -> shinkansen.payment.DisputeStatus
+	13, // 11: shinkansen.payment.ListDisputesRequest.pagination:type_name -> shinkansen.common.Pagination
+	2,  // 12: shinkansen.payment.ListDisputesResponse.disputes:type_name -> shinkansen.payment.Dispute
+	13, // 13: shinkansen.payment.ListDisputesResponse.pagination:type_name -> shinkansen.common.Pagination
+	2,  // 14: shinkansen.payment.GetDisputeResponse.dispute:type_name -> shinkansen.payment.Dispute
+	1,  // 15: shinkansen.payment.AddDisputeEvidenceResponse.evidence:type_name -> shinkansen.payment.DisputeEvidence
+	2,  // 16: shinkansen.payment.SubmitDisputeEvidenceResponse.dispute:type_name -> shinkansen.payment.Dispute
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_payment_dispute_messages_proto_init() }
+func file_payment_dispute_messages_proto_init() {
+	if File_payment_dispute_messages_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_dispute_messages_proto_rawDesc), len(file_payment_dispute_messages_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payment_dispute_messages_proto_goTypes,
+		DependencyIndexes: file_payment_dispute_messages_proto_depIdxs,
+		EnumInfos:         file_payment_dispute_messages_proto_enumTypes,
+		MessageInfos:      file_payment_dispute_messages_proto_msgTypes,
+	}.Build()
+	File_payment_dispute_messages_proto = out.File
+	file_payment_dispute_messages_proto_goTypes = nil
+	file_payment_dispute_messages_proto_depIdxs = nil
+}
@@ -0,0 +1,1111 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.30.2
+// source: payment/gift_card_messages.proto
+
+package payment
+
+import (
+	shared "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GiftCardType int32
+
+const (
+	GiftCardType_GIFT_CARD_TYPE_UNSPECIFIED  GiftCardType = 0
+	GiftCardType_GIFT_CARD_TYPE_GIFT_CARD    GiftCardType = 1
+	GiftCardType_GIFT_CARD_TYPE_STORE_CREDIT GiftCardType = 2
+)
+
+// Enum value maps for GiftCardType.
+var (
+	GiftCardType_name = map[int32]string{
+		0: "GIFT_CARD_TYPE_UNSPECIFIED",
+		1: "GIFT_CARD_TYPE_GIFT_CARD",
+		2: "GIFT_CARD_TYPE_STORE_CREDIT",
+	}
+	GiftCardType_value = map[string]int32{
+		"GIFT_CARD_TYPE_UNSPECIFIED":  0,
+		"GIFT_CARD_TYPE_GIFT_CARD":    1,
+		"GIFT_CARD_TYPE_STORE_CREDIT": 2,
+	}
+)
+
+func (x GiftCardType) Enum() *GiftCardType {
+	p := new(GiftCardType)
+	*p = x
+	return p
+}
+
+func (x GiftCardType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GiftCardType) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_gift_card_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (GiftCardType) Type() protoreflect.EnumType {
+	return &file_payment_gift_card_messages_proto_enumTypes[0]
+}
+
+func (x GiftCardType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GiftCardType.Descriptor instead.
+func (GiftCardType) EnumDescriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type GiftCardStatus int32
+
+const (
+	GiftCardStatus_GIFT_CARD_STATUS_UNSPECIFIED GiftCardStatus = 0
+	GiftCardStatus_GIFT_CARD_STATUS_ACTIVE      GiftCardStatus = 1
+	GiftCardStatus_GIFT_CARD_STATUS_DISABLED    GiftCardStatus = 2
+	GiftCardStatus_GIFT_CARD_STATUS_EXPIRED     GiftCardStatus = 3
+)
+
+// Enum value maps for GiftCardStatus.
+var (
+	GiftCardStatus_name = map[int32]string{
+		0: "GIFT_CARD_STATUS_UNSPECIFIED",
+		1: "GIFT_CARD_STATUS_ACTIVE",
+		2: "GIFT_CARD_STATUS_DISABLED",
+		3: "GIFT_CARD_STATUS_EXPIRED",
+	}
+	GiftCardStatus_value = map[string]int32{
+		"GIFT_CARD_STATUS_UNSPECIFIED": 0,
+		"GIFT_CARD_STATUS_ACTIVE":      1,
+		"GIFT_CARD_STATUS_DISABLED":    2,
+		"GIFT_CARD_STATUS_EXPIRED":     3,
+	}
+)
+
+func (x GiftCardStatus) Enum() *GiftCardStatus {
+	p := new(GiftCardStatus)
+	*p = x
+	return p
+}
+
+func (x GiftCardStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GiftCardStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_gift_card_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (GiftCardStatus) Type() protoreflect.EnumType {
+	return &file_payment_gift_card_messages_proto_enumTypes[1]
+}
+
+func (x GiftCardStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GiftCardStatus.Descriptor instead.
+func (GiftCardStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type GiftCardTransactionKind int32
+
+const (
+	GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_UNSPECIFIED GiftCardTransactionKind = 0
+	GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_ISSUE       GiftCardTransactionKind = 1
+	GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_REDEEM      GiftCardTransactionKind = 2
+	GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_REFUND      GiftCardTransactionKind = 3
+	GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_EXPIRE      GiftCardTransactionKind = 4
+)
+
+// Enum value maps for GiftCardTransactionKind.
+var (
+	GiftCardTransactionKind_name = map[int32]string{
+		0: "GIFT_CARD_TRANSACTION_KIND_UNSPECIFIED",
+		1: "GIFT_CARD_TRANSACTION_KIND_ISSUE",
+		2: "GIFT_CARD_TRANSACTION_KIND_REDEEM",
+		3: "GIFT_CARD_TRANSACTION_KIND_REFUND",
+		4: "GIFT_CARD_TRANSACTION_KIND_EXPIRE",
+	}
+	GiftCardTransactionKind_value = map[string]int32{
+		"GIFT_CARD_TRANSACTION_KIND_UNSPECIFIED": 0,
+		"GIFT_CARD_TRANSACTION_KIND_ISSUE":       1,
+		"GIFT_CARD_TRANSACTION_KIND_REDEEM":      2,
+		"GIFT_CARD_TRANSACTION_KIND_REFUND":      3,
+		"GIFT_CARD_TRANSACTION_KIND_EXPIRE":      4,
+	}
+)
+
+func (x GiftCardTransactionKind) Enum() *GiftCardTransactionKind {
+	p := new(GiftCardTransactionKind)
+	*p = x
+	return p
+}
+
+func (x GiftCardTransactionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GiftCardTransactionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_gift_card_messages_proto_enumTypes[2].Descriptor()
+}
+
+func (GiftCardTransactionKind) Type() protoreflect.EnumType {
+	return &file_payment_gift_card_messages_proto_enumTypes[2]
+}
+
+func (x GiftCardTransactionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GiftCardTransactionKind.Descriptor instead.
+func (GiftCardTransactionKind) EnumDescriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{2}
+}
+
+type GiftCard struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           GiftCardType           `protobuf:"varint,2,opt,name=type,proto3,enum=shinkansen.payment.GiftCardType" json:"type,omitempty"`
+	Status         GiftCardStatus         `protobuf:"varint,3,opt,name=status,proto3,enum=shinkansen.payment.GiftCardStatus" json:"status,omitempty"`
+	CodeLast4      string                 `protobuf:"bytes,4,opt,name=code_last4,json=codeLast4,proto3" json:"code_last4,omitempty"`
+	InitialBalance *shared.Money          `protobuf:"bytes,5,opt,name=initial_balance,json=initialBalance,proto3" json:"initial_balance,omitempty"`
+	Balance        *shared.Money          `protobuf:"bytes,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	UserId         string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GiftCard) Reset() {
+	*x = GiftCard{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GiftCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCard) ProtoMessage() {}
+
+func (x *GiftCard) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCard.ProtoReflect.Descriptor instead.
+func (*GiftCard) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GiftCard) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GiftCard) GetType() GiftCardType {
+	if x != nil {
+		return x.Type
+	}
+	return GiftCardType_GIFT_CARD_TYPE_UNSPECIFIED
+}
+
+func (x *GiftCard) GetStatus() GiftCardStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GiftCardStatus_GIFT_CARD_STATUS_UNSPECIFIED
+}
+
+func (x *GiftCard) GetCodeLast4() string {
+	if x != nil {
+		return x.CodeLast4
+	}
+	return ""
+}
+
+func (x *GiftCard) GetInitialBalance() *shared.Money {
+	if x != nil {
+		return x.InitialBalance
+	}
+	return nil
+}
+
+func (x *GiftCard) GetBalance() *shared.Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *GiftCard) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GiftCard) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *GiftCard) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GiftCard) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GiftCardTransaction struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GiftCardId    string                  `protobuf:"bytes,2,opt,name=gift_card_id,json=giftCardId,proto3" json:"gift_card_id,omitempty"`
+	Kind          GiftCardTransactionKind `protobuf:"varint,3,opt,name=kind,proto3,enum=shinkansen.payment.GiftCardTransactionKind" json:"kind,omitempty"`
+	Amount        *shared.Money           `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	BalanceAfter  *shared.Money           `protobuf:"bytes,5,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	PaymentId     string                  `protobuf:"bytes,6,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	RefundId      string                  `protobuf:"bytes,7,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Note          string                  `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp  `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GiftCardTransaction) Reset() {
+	*x = GiftCardTransaction{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GiftCardTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardTransaction) ProtoMessage() {}
+
+func (x *GiftCardTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardTransaction.ProtoReflect.Descriptor instead.
+func (*GiftCardTransaction) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GiftCardTransaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GiftCardTransaction) GetGiftCardId() string {
+	if x != nil {
+		return x.GiftCardId
+	}
+	return ""
+}
+
+func (x *GiftCardTransaction) GetKind() GiftCardTransactionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_UNSPECIFIED
+}
+
+func (x *GiftCardTransaction) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *GiftCardTransaction) GetBalanceAfter() *shared.Money {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return nil
+}
+
+func (x *GiftCardTransaction) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GiftCardTransaction) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *GiftCardTransaction) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *GiftCardTransaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type IssueGiftCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          GiftCardType           `protobuf:"varint,1,opt,name=type,proto3,enum=shinkansen.payment.GiftCardType" json:"type,omitempty"`
+	Amount        *shared.Money          `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ValidDays     int32                  `protobuf:"varint,4,opt,name=valid_days,json=validDays,proto3" json:"valid_days,omitempty"`
+	Note          string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueGiftCardRequest) Reset() {
+	*x = IssueGiftCardRequest{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueGiftCardRequest) ProtoMessage() {}
+
+func (x *IssueGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*IssueGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IssueGiftCardRequest) GetType() GiftCardType {
+	if x != nil {
+		return x.Type
+	}
+	return GiftCardType_GIFT_CARD_TYPE_UNSPECIFIED
+}
+
+func (x *IssueGiftCardRequest) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *IssueGiftCardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueGiftCardRequest) GetValidDays() int32 {
+	if x != nil {
+		return x.ValidDays
+	}
+	return 0
+}
+
+func (x *IssueGiftCardRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type IssueGiftCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCard      *GiftCard              `protobuf:"bytes,1,opt,name=gift_card,json=giftCard,proto3" json:"gift_card,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueGiftCardResponse) Reset() {
+	*x = IssueGiftCardResponse{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueGiftCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueGiftCardResponse) ProtoMessage() {}
+
+func (x *IssueGiftCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueGiftCardResponse.ProtoReflect.Descriptor instead.
+func (*IssueGiftCardResponse) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IssueGiftCardResponse) GetGiftCard() *GiftCard {
+	if x != nil {
+		return x.GiftCard
+	}
+	return nil
+}
+
+func (x *IssueGiftCardResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type CheckGiftCardBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ClientKey     string                 `protobuf:"bytes,2,opt,name=client_key,json=clientKey,proto3" json:"client_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckGiftCardBalanceRequest) Reset() {
+	*x = CheckGiftCardBalanceRequest{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckGiftCardBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckGiftCardBalanceRequest) ProtoMessage() {}
+
+func (x *CheckGiftCardBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckGiftCardBalanceRequest.ProtoReflect.Descriptor instead.
+func (*CheckGiftCardBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckGiftCardBalanceRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CheckGiftCardBalanceRequest) GetClientKey() string {
+	if x != nil {
+		return x.ClientKey
+	}
+	return ""
+}
+
+type CheckGiftCardBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCard      *GiftCard              `protobuf:"bytes,1,opt,name=gift_card,json=giftCard,proto3" json:"gift_card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckGiftCardBalanceResponse) Reset() {
+	*x = CheckGiftCardBalanceResponse{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckGiftCardBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckGiftCardBalanceResponse) ProtoMessage() {}
+
+func (x *CheckGiftCardBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckGiftCardBalanceResponse.ProtoReflect.Descriptor instead.
+func (*CheckGiftCardBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckGiftCardBalanceResponse) GetGiftCard() *GiftCard {
+	if x != nil {
+		return x.GiftCard
+	}
+	return nil
+}
+
+type GetGiftCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCardId    string                 `protobuf:"bytes,1,opt,name=gift_card_id,json=giftCardId,proto3" json:"gift_card_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGiftCardRequest) Reset() {
+	*x = GetGiftCardRequest{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGiftCardRequest) ProtoMessage() {}
+
+func (x *GetGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*GetGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetGiftCardRequest) GetGiftCardId() string {
+	if x != nil {
+		return x.GiftCardId
+	}
+	return ""
+}
+
+type GetGiftCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCard      *GiftCard              `protobuf:"bytes,1,opt,name=gift_card,json=giftCard,proto3" json:"gift_card,omitempty"`
+	Transactions  []*GiftCardTransaction `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGiftCardResponse) Reset() {
+	*x = GetGiftCardResponse{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGiftCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGiftCardResponse) ProtoMessage() {}
+
+func (x *GetGiftCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGiftCardResponse.ProtoReflect.Descriptor instead.
+func (*GetGiftCardResponse) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetGiftCardResponse) GetGiftCard() *GiftCard {
+	if x != nil {
+		return x.GiftCard
+	}
+	return nil
+}
+
+func (x *GetGiftCardResponse) GetTransactions() []*GiftCardTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type ListGiftCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          GiftCardType           `protobuf:"varint,2,opt,name=type,proto3,enum=shinkansen.payment.GiftCardType" json:"type,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGiftCardsRequest) Reset() {
+	*x = ListGiftCardsRequest{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGiftCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGiftCardsRequest) ProtoMessage() {}
+
+func (x *ListGiftCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGiftCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListGiftCardsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListGiftCardsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListGiftCardsRequest) GetType() GiftCardType {
+	if x != nil {
+		return x.Type
+	}
+	return GiftCardType_GIFT_CARD_TYPE_UNSPECIFIED
+}
+
+func (x *ListGiftCardsRequest) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListGiftCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCards     []*GiftCard            `protobuf:"bytes,1,rep,name=gift_cards,json=giftCards,proto3" json:"gift_cards,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGiftCardsResponse) Reset() {
+	*x = ListGiftCardsResponse{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGiftCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGiftCardsResponse) ProtoMessage() {}
+
+func (x *ListGiftCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGiftCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListGiftCardsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListGiftCardsResponse) GetGiftCards() []*GiftCard {
+	if x != nil {
+		return x.GiftCards
+	}
+	return nil
+}
+
+func (x *ListGiftCardsResponse) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type DisableGiftCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCardId    string                 `protobuf:"bytes,1,opt,name=gift_card_id,json=giftCardId,proto3" json:"gift_card_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableGiftCardRequest) Reset() {
+	*x = DisableGiftCardRequest{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableGiftCardRequest) ProtoMessage() {}
+
+func (x *DisableGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*DisableGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DisableGiftCardRequest) GetGiftCardId() string {
+	if x != nil {
+		return x.GiftCardId
+	}
+	return ""
+}
+
+func (x *DisableGiftCardRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DisableGiftCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiftCard      *GiftCard              `protobuf:"bytes,1,opt,name=gift_card,json=giftCard,proto3" json:"gift_card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableGiftCardResponse) Reset() {
+	*x = DisableGiftCardResponse{}
+	mi := &file_payment_gift_card_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableGiftCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableGiftCardResponse) ProtoMessage() {}
+
+func (x *DisableGiftCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_gift_card_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableGiftCardResponse.ProtoReflect.Descriptor instead.
+func (*DisableGiftCardResponse) Descriptor() ([]byte, []int) {
+	return file_payment_gift_card_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DisableGiftCardResponse) GetGiftCard() *GiftCard {
+	if x != nil {
+		return x.GiftCard
+	}
+	return nil
+}
+
+var File_payment_gift_card_messages_proto protoreflect.FileDescriptor
+
+const file_payment_gift_card_messages_proto_rawDesc = "" +
+	"\n" +
+	" payment/gift_card_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13shared/common.proto\"\xec\x03\n" +
+	"\bGiftCard\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\x04type\x18\x02 \x01(\x0e2 .shinkansen.payment.GiftCardTypeR\x04type\x12:\n" +
+	"\x06status\x18\x03 \x01(\x0e2\".shinkansen.payment.GiftCardStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"code_last4\x18\x04 \x01(\tR\tcodeLast4\x12A\n" +
+	"\x0finitial_balance\x18\x05 \x01(\v2\x18.shinkansen.common.MoneyR\x0einitialBalance\x122\n" +
+	"\abalance\x18\x06 \x01(\v2\x18.shinkansen.common.MoneyR\abalance\x12\x17\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x84\x03\n" +
+	"\x13GiftCardTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\fgift_card_id\x18\x02 \x01(\tR\n" +
+	"giftCardId\x12?\n" +
+	"\x04kind\x18\x03 \x01(\x0e2+.shinkansen.payment.GiftCardTransactionKindR\x04kind\x120\n" +
+	"\x06amount\x18\x04 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12=\n" +
+	"\rbalance_after\x18\x05 \x01(\v2\x18.shinkansen.common.MoneyR\fbalanceAfter\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x06 \x01(\tR\tpaymentId\x12\x1b\n" +
+	"\trefund_id\x18\a \x01(\tR\brefundId\x12\x12\n" +
+	"\x04note\x18\b \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xca\x01\n" +
+	"\x14IssueGiftCardRequest\x124\n" +
+	"\x04type\x18\x01 \x01(\x0e2 .shinkansen.payment.GiftCardTypeR\x04type\x120\n" +
+	"\x06amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"valid_days\x18\x04 \x01(\x05R\tvalidDays\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"f\n" +
+	"\x15IssueGiftCardResponse\x129\n" +
+	"\tgift_card\x18\x01 \x01(\v2\x1c.shinkansen.payment.GiftCardR\bgiftCard\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"P\n" +
+	"\x1bCheckGiftCardBalanceRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"client_key\x18\x02 \x01(\tR\tclientKey\"Y\n" +
+	"\x1cCheckGiftCardBalanceResponse\x129\n" +
+	"\tgift_card\x18\x01 \x01(\v2\x1c.shinkansen.payment.GiftCardR\bgiftCard\"6\n" +
+	"\x12GetGiftCardRequest\x12 \n" +
+	"\fgift_card_id\x18\x01 \x01(\tR\n" +
+	"giftCardId\"\x9d\x01\n" +
+	"\x13GetGiftCardResponse\x129\n" +
+	"\tgift_card\x18\x01 \x01(\v2\x1c.shinkansen.payment.GiftCardR\bgiftCard\x12K\n" +
+	"\ftransactions\x18\x02 \x03(\v2'.shinkansen.payment.GiftCardTransactionR\ftransactions\"\xa4\x01\n" +
+	"\x14ListGiftCardsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x124\n" +
+	"\x04type\x18\x02 \x01(\x0e2 .shinkansen.payment.GiftCardTypeR\x04type\x12=\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"\x93\x01\n" +
+	"\x15ListGiftCardsResponse\x12;\n" +
+	"\n" +
+	"gift_cards\x18\x01 \x03(\v2\x1c.shinkansen.payment.GiftCardR\tgiftCards\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"R\n" +
+	"\x16DisableGiftCardRequest\x12 \n" +
+	"\fgift_card_id\x18\x01 \x01(\tR\n" +
+	"giftCardId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"T\n" +
+	"\x17DisableGiftCardResponse\x129\n" +
+	"\tgift_card\x18\x01 \x01(\v2\x1c.shinkansen.payment.GiftCardR\bgiftCard*m\n" +
+	"\fGiftCardType\x12\x1e\n" +
+	"\x1aGIFT_CARD_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18GIFT_CARD_TYPE_GIFT_CARD\x10\x01\x12\x1f\n" +
+	"\x1bGIFT_CARD_TYPE_STORE_CREDIT\x10\x02*\x8c\x01\n" +
+	"\x0eGiftCardStatus\x12 \n" +
+	"\x1cGIFT_CARD_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17GIFT_CARD_STATUS_ACTIVE\x10\x01\x12\x1d\n" +
+	"\x19GIFT_CARD_STATUS_DISABLED\x10\x02\x12\x1c\n" +
+	"\x18GIFT_CARD_STATUS_EXPIRED\x10\x03*\xe0\x01\n" +
+	"\x17GiftCardTransactionKind\x12*\n" +
+	"&GIFT_CARD_TRANSACTION_KIND_UNSPECIFIED\x10\x00\x12$\n" +
+	" GIFT_CARD_TRANSACTION_KIND_ISSUE\x10\x01\x12%\n" +
+	"!GIFT_CARD_TRANSACTION_KIND_REDEEM\x10\x02\x12%\n" +
+	"!GIFT_CARD_TRANSACTION_KIND_REFUND\x10\x03\x12%\n" +
+	"!GIFT_CARD_TRANSACTION_KIND_EXPIRE\x10\x04B=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+
+var (
+	file_payment_gift_card_messages_proto_rawDescOnce sync.Once
+	file_payment_gift_card_messages_proto_rawDescData []byte
+)
+
+func file_payment_gift_card_messages_proto_rawDescGZIP() []byte {
+	file_payment_gift_card_messages_proto_rawDescOnce.Do(func() {
+		file_payment_gift_card_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_gift_card_messages_proto_rawDesc), len(file_payment_gift_card_messages_proto_rawDesc)))
+	})
+	return file_payment_gift_card_messages_proto_rawDescData
+}
+
+var file_payment_gift_card_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_payment_gift_card_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_payment_gift_card_messages_proto_goTypes = []any{
+	(GiftCardType)(0),                    // 0: shinkansen.payment.GiftCardType
+	(GiftCardStatus)(0),                  // 1: shinkansen.payment.GiftCardStatus
+	(GiftCardTransactionKind)(0),         // 2: shinkansen.payment.GiftCardTransactionKind
+	(*GiftCard)(nil),                     // 3: shinkansen.payment.GiftCard
+	(*GiftCardTransaction)(nil),          // 4: shinkansen.payment.GiftCardTransaction
+	(*IssueGiftCardRequest)(nil),         // 5: shinkansen.payment.IssueGiftCardRequest
+	(*IssueGiftCardResponse)(nil),        // 6: shinkansen.payment.IssueGiftCardResponse
+	(*CheckGiftCardBalanceRequest)(nil),  // 7: shinkansen.payment.CheckGiftCardBalanceRequest
+	(*CheckGiftCardBalanceResponse)(nil), // 8: shinkansen.payment.CheckGiftCardBalanceResponse
+	(*GetGiftCardRequest)(nil),           // 9: shinkansen.payment.GetGiftCardRequest
+	(*GetGiftCardResponse)(nil),          // 10: shinkansen.payment.GetGiftCardResponse
+	(*ListGiftCardsRequest)(nil),         // 11: shinkansen.payment.ListGiftCardsRequest
+	(*ListGiftCardsResponse)(nil),        // 12: shinkansen.payment.ListGiftCardsResponse
+	(*DisableGiftCardRequest)(nil),       // 13: shinkansen.payment.DisableGiftCardRequest
+	(*DisableGiftCardResponse)(nil),      // 14: shinkansen.payment.DisableGiftCardResponse
+	(*shared.Money)(nil),                 // 15: shinkansen.common.Money
+	(*timestamppb.Timestamp)(nil),        // 16: google.protobuf.Timestamp
+	(*shared.Pagination)(nil),            // 17: shinkansen.common.Pagination
+}
+var file_payment_gift_card_messages_proto_depIdxs = []int32{
+	0,  // 0: shinkansen.payment.GiftCard.type:type_name -> shinkansen.payment.GiftCardType
+	1,  // 1: shinkansen.payment.GiftCard.status:type_name -> shinkansen.payment.GiftCardStatus
+	15, // 2: shinkansen.payment.GiftCard.initial_balance:type_name -> shinkansen.common.Money
+	15, // 3: shinkansen.payment.GiftCard.balance:type_name -> shinkansen.common.Money
+	16, // 4: shinkansen.payment.GiftCard.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 5: shinkansen.payment.GiftCard.created_at:type_name -> google.protobuf.Timestamp
+	16, // 6: shinkansen.payment.GiftCard.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 7: shinkansen.payment.GiftCardTransaction.kind:type_name -> shinkansen.payment.GiftCardTransactionKind
+	15, // 8: shinkansen.payment.GiftCardTransaction.amount:type_name -> shinkansen.common.Money
+	15, // 9: shinkansen.payment.GiftCardTransaction.balance_after:type_name -> shinkansen.common.Money
+	16, // 10: shinkansen.payment.GiftCardTransaction.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: shinkansen.payment.IssueGiftCardRequest.type:type_name -> shinkansen.payment.GiftCardType
+	15, // 12: shinkansen.payment.IssueGiftCardRequest.amount:type_name -> shinkansen.common.Money
+	3,  // 13: shinkansen.payment.IssueGiftCardResponse.gift_card:type_name -> shinkansen.payment.GiftCard
+	3,  // 14: shinkansen.payment.CheckGiftCardBalanceResponse.gift_card:type_name -> shinkansen.payment.GiftCard
+	3,  // 15: shinkansen.payment.GetGiftCardResponse.gift_card:type_name -> shinkansen.payment.GiftCard
+	4,  // 16: shinkansen.payment.GetGiftCardResponse.transactions:type_name -> shinkansen.payment.GiftCardTransaction
+	0,  // 17: shinkansen.payment.ListGiftCardsRequest.type:type_name -> shinkansen.payment.GiftCardType
+	17, // 18: shinkansen.payment.ListGiftCardsRequest.pagination:type_name -> shinkansen.common.Pagination
+	3,  // 19: shinkansen.payment.ListGiftCardsResponse.gift_cards:type_name -> shinkansen.payment.GiftCard
+	17, // 20: shinkansen.payment.ListGiftCardsResponse.pagination:type_name -> shinkansen.common.Pagination
+	3,  // 21: shinkansen.payment.DisableGiftCardResponse.gift_card:type_name -> shinkansen.payment.GiftCard
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_payment_gift_card_messages_proto_init() }
+func file_payment_gift_card_messages_proto_init() {
+	if File_payment_gift_card_messages_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_gift_card_messages_proto_rawDesc), len(file_payment_gift_card_messages_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payment_gift_card_messages_proto_goTypes,
+		DependencyIndexes: file_payment_gift_card_messages_proto_depIdxs,
+		EnumInfos:         file_payment_gift_card_messages_proto_enumTypes,
+		MessageInfos:      file_payment_gift_card_messages_proto_msgTypes,
+	}.Build()
+	File_payment_gift_card_messages_proto = out.File
+	file_payment_gift_card_messages_proto_goTypes = nil
+	file_payment_gift_card_messages_proto_depIdxs = nil
+}
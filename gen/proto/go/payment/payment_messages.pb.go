@@ -26,34 +26,49 @@ const (
 type PaymentStatus int32
 
 const (
-	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED PaymentStatus = 0
-	PaymentStatus_PAYMENT_STATUS_PENDING     PaymentStatus = 1
-	PaymentStatus_PAYMENT_STATUS_PROCESSING  PaymentStatus = 2
-	PaymentStatus_PAYMENT_STATUS_COMPLETED   PaymentStatus = 3
-	PaymentStatus_PAYMENT_STATUS_FAILED      PaymentStatus = 4
-	PaymentStatus_PAYMENT_STATUS_CANCELLED   PaymentStatus = 5
-	PaymentStatus_PAYMENT_STATUS_REFUNDED    PaymentStatus = 6
+	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED        PaymentStatus = 0
+	PaymentStatus_PAYMENT_STATUS_PENDING            PaymentStatus = 1
+	PaymentStatus_PAYMENT_STATUS_PROCESSING         PaymentStatus = 2
+	PaymentStatus_PAYMENT_STATUS_COMPLETED          PaymentStatus = 3
+	PaymentStatus_PAYMENT_STATUS_FAILED             PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_CANCELLED          PaymentStatus = 5
+	PaymentStatus_PAYMENT_STATUS_REFUNDED           PaymentStatus = 6
+	PaymentStatus_PAYMENT_STATUS_AUTHORIZED         PaymentStatus = 7
+	PaymentStatus_PAYMENT_STATUS_CAPTURED           PaymentStatus = 8
+	PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED PaymentStatus = 9
+	PaymentStatus_PAYMENT_STATUS_EXPIRED            PaymentStatus = 10
+	PaymentStatus_PAYMENT_STATUS_CHARGED_BACK       PaymentStatus = 11
 )
 
 // Enum value maps for PaymentStatus.
 var (
 	PaymentStatus_name = map[int32]string{
-		0: "PAYMENT_STATUS_UNSPECIFIED",
-		1: "PAYMENT_STATUS_PENDING",
-		2: "PAYMENT_STATUS_PROCESSING",
-		3: "PAYMENT_STATUS_COMPLETED",
-		4: "PAYMENT_STATUS_FAILED",
-		5: "PAYMENT_STATUS_CANCELLED",
-		6: "PAYMENT_STATUS_REFUNDED",
+		0:  "PAYMENT_STATUS_UNSPECIFIED",
+		1:  "PAYMENT_STATUS_PENDING",
+		2:  "PAYMENT_STATUS_PROCESSING",
+		3:  "PAYMENT_STATUS_COMPLETED",
+		4:  "PAYMENT_STATUS_FAILED",
+		5:  "PAYMENT_STATUS_CANCELLED",
+		6:  "PAYMENT_STATUS_REFUNDED",
+		7:  "PAYMENT_STATUS_AUTHORIZED",
+		8:  "PAYMENT_STATUS_CAPTURED",
+		9:  "PAYMENT_STATUS_PARTIALLY_REFUNDED",
+		10: "PAYMENT_STATUS_EXPIRED",
+		11: "PAYMENT_STATUS_CHARGED_BACK",
 	}
 	PaymentStatus_value = map[string]int32{
-		"PAYMENT_STATUS_UNSPECIFIED": 0,
-		"PAYMENT_STATUS_PENDING":     1,
-		"PAYMENT_STATUS_PROCESSING":  2,
-		"PAYMENT_STATUS_COMPLETED":   3,
-		"PAYMENT_STATUS_FAILED":      4,
-		"PAYMENT_STATUS_CANCELLED":   5,
-		"PAYMENT_STATUS_REFUNDED":    6,
+		"PAYMENT_STATUS_UNSPECIFIED":        0,
+		"PAYMENT_STATUS_PENDING":            1,
+		"PAYMENT_STATUS_PROCESSING":         2,
+		"PAYMENT_STATUS_COMPLETED":          3,
+		"PAYMENT_STATUS_FAILED":             4,
+		"PAYMENT_STATUS_CANCELLED":          5,
+		"PAYMENT_STATUS_REFUNDED":           6,
+		"PAYMENT_STATUS_AUTHORIZED":         7,
+		"PAYMENT_STATUS_CAPTURED":           8,
+		"PAYMENT_STATUS_PARTIALLY_REFUNDED": 9,
+		"PAYMENT_STATUS_EXPIRED":            10,
+		"PAYMENT_STATUS_CHARGED_BACK":       11,
 	}
 )
 
@@ -84,6 +99,107 @@ func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
 	return file_payment_payment_messages_proto_rawDescGZIP(), []int{0}
 }
 
+type RefundStatus int32
+
+const (
+	RefundStatus_REFUND_STATUS_UNSPECIFIED RefundStatus = 0
+	RefundStatus_REFUND_STATUS_PENDING     RefundStatus = 1
+	RefundStatus_REFUND_STATUS_SUCCEEDED   RefundStatus = 2
+	RefundStatus_REFUND_STATUS_FAILED      RefundStatus = 3
+)
+
+// Enum value maps for RefundStatus.
+var (
+	RefundStatus_name = map[int32]string{
+		0: "REFUND_STATUS_UNSPECIFIED",
+		1: "REFUND_STATUS_PENDING",
+		2: "REFUND_STATUS_SUCCEEDED",
+		3: "REFUND_STATUS_FAILED",
+	}
+	RefundStatus_value = map[string]int32{
+		"REFUND_STATUS_UNSPECIFIED": 0,
+		"REFUND_STATUS_PENDING":     1,
+		"REFUND_STATUS_SUCCEEDED":   2,
+		"REFUND_STATUS_FAILED":      3,
+	}
+)
+
+func (x RefundStatus) Enum() *RefundStatus {
+	p := new(RefundStatus)
+	*p = x
+	return p
+}
+
+func (x RefundStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RefundStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_payment_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (RefundStatus) Type() protoreflect.EnumType {
+	return &file_payment_payment_messages_proto_enumTypes[1]
+}
+
+func (x RefundStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RefundStatus.Descriptor instead.
+func (RefundStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type RefundDestination int32
+
+const (
+	RefundDestination_REFUND_DESTINATION_UNSPECIFIED  RefundDestination = 0
+	RefundDestination_REFUND_DESTINATION_ORIGINAL     RefundDestination = 1
+	RefundDestination_REFUND_DESTINATION_STORE_CREDIT RefundDestination = 2
+)
+
+// Enum value maps for RefundDestination.
+var (
+	RefundDestination_name = map[int32]string{
+		0: "REFUND_DESTINATION_UNSPECIFIED",
+		1: "REFUND_DESTINATION_ORIGINAL",
+		2: "REFUND_DESTINATION_STORE_CREDIT",
+	}
+	RefundDestination_value = map[string]int32{
+		"REFUND_DESTINATION_UNSPECIFIED":  0,
+		"REFUND_DESTINATION_ORIGINAL":     1,
+		"REFUND_DESTINATION_STORE_CREDIT": 2,
+	}
+)
+
+func (x RefundDestination) Enum() *RefundDestination {
+	p := new(RefundDestination)
+	*p = x
+	return p
+}
+
+func (x RefundDestination) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RefundDestination) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_payment_messages_proto_enumTypes[2].Descriptor()
+}
+
+func (RefundDestination) Type() protoreflect.EnumType {
+	return &file_payment_payment_messages_proto_enumTypes[2]
+}
+
+func (x RefundDestination) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RefundDestination.Descriptor instead.
+func (RefundDestination) EnumDescriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{2}
+}
+
 type PaymentMethod int32
 
 const (
@@ -94,18 +210,28 @@ const (
 	PaymentMethod_PAYMENT_METHOD_KONBINI_FAMILYMART  PaymentMethod = 4
 	PaymentMethod_PAYMENT_METHOD_PAYPAY              PaymentMethod = 5
 	PaymentMethod_PAYMENT_METHOD_RAKUTEN_PAY         PaymentMethod = 6
+	PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER       PaymentMethod = 7
+	PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY    PaymentMethod = 8
+	PaymentMethod_PAYMENT_METHOD_DEFERRED            PaymentMethod = 9
+	PaymentMethod_PAYMENT_METHOD_POINTS              PaymentMethod = 10
+	PaymentMethod_PAYMENT_METHOD_GIFT_CARD           PaymentMethod = 11
 )
 
 // Enum value maps for PaymentMethod.
 var (
 	PaymentMethod_name = map[int32]string{
-		0: "PAYMENT_METHOD_UNSPECIFIED",
-		1: "PAYMENT_METHOD_CREDIT_CARD",
-		2: "PAYMENT_METHOD_KONBINI_SEVENELEVEN",
-		3: "PAYMENT_METHOD_KONBINI_LAWSON",
-		4: "PAYMENT_METHOD_KONBINI_FAMILYMART",
-		5: "PAYMENT_METHOD_PAYPAY",
-		6: "PAYMENT_METHOD_RAKUTEN_PAY",
+		0:  "PAYMENT_METHOD_UNSPECIFIED",
+		1:  "PAYMENT_METHOD_CREDIT_CARD",
+		2:  "PAYMENT_METHOD_KONBINI_SEVENELEVEN",
+		3:  "PAYMENT_METHOD_KONBINI_LAWSON",
+		4:  "PAYMENT_METHOD_KONBINI_FAMILYMART",
+		5:  "PAYMENT_METHOD_PAYPAY",
+		6:  "PAYMENT_METHOD_RAKUTEN_PAY",
+		7:  "PAYMENT_METHOD_BANK_TRANSFER",
+		8:  "PAYMENT_METHOD_CASH_ON_DELIVERY",
+		9:  "PAYMENT_METHOD_DEFERRED",
+		10: "PAYMENT_METHOD_POINTS",
+		11: "PAYMENT_METHOD_GIFT_CARD",
 	}
 	PaymentMethod_value = map[string]int32{
 		"PAYMENT_METHOD_UNSPECIFIED":         0,
@@ -115,6 +241,11 @@ var (
 		"PAYMENT_METHOD_KONBINI_FAMILYMART":  4,
 		"PAYMENT_METHOD_PAYPAY":              5,
 		"PAYMENT_METHOD_RAKUTEN_PAY":         6,
+		"PAYMENT_METHOD_BANK_TRANSFER":       7,
+		"PAYMENT_METHOD_CASH_ON_DELIVERY":    8,
+		"PAYMENT_METHOD_DEFERRED":            9,
+		"PAYMENT_METHOD_POINTS":              10,
+		"PAYMENT_METHOD_GIFT_CARD":           11,
 	}
 )
 
@@ -129,11 +260,11 @@ func (x PaymentMethod) String() string {
 }
 
 func (PaymentMethod) Descriptor() protoreflect.EnumDescriptor {
-	return file_payment_payment_messages_proto_enumTypes[1].Descriptor()
+	return file_payment_payment_messages_proto_enumTypes[3].Descriptor()
 }
 
 func (PaymentMethod) Type() protoreflect.EnumType {
-	return &file_payment_payment_messages_proto_enumTypes[1]
+	return &file_payment_payment_messages_proto_enumTypes[3]
 }
 
 func (x PaymentMethod) Number() protoreflect.EnumNumber {
@@ -142,21 +273,23 @@ func (x PaymentMethod) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PaymentMethod.Descriptor instead.
 func (PaymentMethod) EnumDescriptor() ([]byte, []int) {
-	return file_payment_payment_messages_proto_rawDescGZIP(), []int{1}
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{3}
 }
 
 type Payment struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Method        PaymentMethod          `protobuf:"varint,3,opt,name=method,proto3,enum=shinkansen.payment.PaymentMethod" json:"method,omitempty"`
-	Amount        *shared.Money          `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Status        PaymentStatus          `protobuf:"varint,5,opt,name=status,proto3,enum=shinkansen.payment.PaymentStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	TransactionId string                 `protobuf:"bytes,8,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId                string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Method                 PaymentMethod          `protobuf:"varint,3,opt,name=method,proto3,enum=shinkansen.payment.PaymentMethod" json:"method,omitempty"`
+	Amount                 *shared.Money          `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                 PaymentStatus          `protobuf:"varint,5,opt,name=status,proto3,enum=shinkansen.payment.PaymentStatus" json:"status,omitempty"`
+	CreatedAt              *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt              *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	TransactionId          string                 `protobuf:"bytes,8,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AuthorizationExpiresAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=authorization_expires_at,json=authorizationExpiresAt,proto3" json:"authorization_expires_at,omitempty"`
+	CapturedAt             *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *Payment) Reset() {
@@ -245,6 +378,20 @@ func (x *Payment) GetTransactionId() string {
 	return ""
 }
 
+func (x *Payment) GetAuthorizationExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthorizationExpiresAt
+	}
+	return nil
+}
+
+func (x *Payment) GetCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
 type CreatePaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -498,11 +645,13 @@ func (x *ProcessPaymentRequest) GetPaymentData() map[string]string {
 }
 
 type ProcessPaymentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        PaymentStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=shinkansen.payment.PaymentStatus" json:"status,omitempty"`
-	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState    `protogen:"open.v1"`
+	Status          PaymentStatus             `protobuf:"varint,1,opt,name=status,proto3,enum=shinkansen.payment.PaymentStatus" json:"status,omitempty"`
+	TransactionId   string                    `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BankTransfer    *BankTransferInstructions `protobuf:"bytes,3,opt,name=bank_transfer,json=bankTransfer,proto3" json:"bank_transfer,omitempty"`
+	DeferredPayment *DeferredPayment          `protobuf:"bytes,4,opt,name=deferred_payment,json=deferredPayment,proto3" json:"deferred_payment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ProcessPaymentResponse) Reset() {
@@ -549,10 +698,26 @@ func (x *ProcessPaymentResponse) GetTransactionId() string {
 	return ""
 }
 
+func (x *ProcessPaymentResponse) GetBankTransfer() *BankTransferInstructions {
+	if x != nil {
+		return x.BankTransfer
+	}
+	return nil
+}
+
+func (x *ProcessPaymentResponse) GetDeferredPayment() *DeferredPayment {
+	if x != nil {
+		return x.DeferredPayment
+	}
+	return nil
+}
+
 type RefundPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
 	Amount        *shared.Money          `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Destination   RefundDestination      `protobuf:"varint,4,opt,name=destination,proto3,enum=shinkansen.payment.RefundDestination" json:"destination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -601,49 +766,1055 @@ func (x *RefundPaymentRequest) GetAmount() *shared.Money {
 	return nil
 }
 
-var File_payment_payment_messages_proto protoreflect.FileDescriptor
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
 
-const file_payment_payment_messages_proto_rawDesc = "" +
-	"\n" +
-	"\x1epayment/payment_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13shared/common.proto\"\xf9\x02\n" +
-	"\aPayment\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x129\n" +
-	"\x06method\x18\x03 \x01(\x0e2!.shinkansen.payment.PaymentMethodR\x06method\x120\n" +
-	"\x06amount\x18\x04 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x129\n" +
-	"\x06status\x18\x05 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
-	"\x0etransaction_id\x18\b \x01(\tR\rtransactionId\"\x9e\x01\n" +
-	"\x14CreatePaymentRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x129\n" +
-	"\x06method\x18\x02 \x01(\x0e2!.shinkansen.payment.PaymentMethodR\x06method\x120\n" +
-	"\x06amount\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\"q\n" +
-	"\x15CreatePaymentResponse\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\x129\n" +
-	"\x06status\x18\x02 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\"2\n" +
-	"\x11GetPaymentRequest\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\"K\n" +
-	"\x12GetPaymentResponse\x125\n" +
-	"\apayment\x18\x01 \x01(\v2\x1b.shinkansen.payment.PaymentR\apayment\"\xd5\x01\n" +
-	"\x15ProcessPaymentRequest\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12]\n" +
-	"\fpayment_data\x18\x02 \x03(\v2:.shinkansen.payment.ProcessPaymentRequest.PaymentDataEntryR\vpaymentData\x1a>\n" +
-	"\x10PaymentDataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"z\n" +
-	"\x16ProcessPaymentResponse\x129\n" +
-	"\x06status\x18\x01 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\x12%\n" +
-	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\"g\n" +
-	"\x14RefundPaymentRequest\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\x120\n" +
-	"\x06amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount*\xde\x01\n" +
+func (x *RefundPaymentRequest) GetDestination() RefundDestination {
+	if x != nil {
+		return x.Destination
+	}
+	return RefundDestination_REFUND_DESTINATION_UNSPECIFIED
+}
+
+type Refund struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId         string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount            *shared.Money          `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason            string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status            RefundStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=shinkansen.payment.RefundStatus" json:"status,omitempty"`
+	ProviderReference string                 `protobuf:"bytes,6,opt,name=provider_reference,json=providerReference,proto3" json:"provider_reference,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Destination       RefundDestination      `protobuf:"varint,9,opt,name=destination,proto3,enum=shinkansen.payment.RefundDestination" json:"destination,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_payment_payment_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Refund) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Refund) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Refund) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Refund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetStatus() RefundStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RefundStatus_REFUND_STATUS_UNSPECIFIED
+}
+
+func (x *Refund) GetProviderReference() string {
+	if x != nil {
+		return x.ProviderReference
+	}
+	return ""
+}
+
+func (x *Refund) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Refund) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Refund) GetDestination() RefundDestination {
+	if x != nil {
+		return x.Destination
+	}
+	return RefundDestination_REFUND_DESTINATION_UNSPECIFIED
+}
+
+type ListRefundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefundsRequest) Reset() {
+	*x = ListRefundsRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefundsRequest) ProtoMessage() {}
+
+func (x *ListRefundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefundsRequest.ProtoReflect.Descriptor instead.
+func (*ListRefundsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRefundsRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type ListRefundsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Refunds          []*Refund              `protobuf:"bytes,1,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	RefundedAmount   *shared.Money          `protobuf:"bytes,2,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	RefundableAmount *shared.Money          `protobuf:"bytes,3,opt,name=refundable_amount,json=refundableAmount,proto3" json:"refundable_amount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListRefundsResponse) Reset() {
+	*x = ListRefundsResponse{}
+	mi := &file_payment_payment_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefundsResponse) ProtoMessage() {}
+
+func (x *ListRefundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefundsResponse.ProtoReflect.Descriptor instead.
+func (*ListRefundsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRefundsResponse) GetRefunds() []*Refund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+func (x *ListRefundsResponse) GetRefundedAmount() *shared.Money {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return nil
+}
+
+func (x *ListRefundsResponse) GetRefundableAmount() *shared.Money {
+	if x != nil {
+		return x.RefundableAmount
+	}
+	return nil
+}
+
+type CapturePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount        *shared.Money          `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentRequest) Reset() {
+	*x = CapturePaymentRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentRequest) ProtoMessage() {}
+
+func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CapturePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CapturePaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *CapturePaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CapturePaymentRequest) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type CapturePaymentResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         PaymentStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=shinkansen.payment.PaymentStatus" json:"status,omitempty"`
+	TransactionId  string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	CapturedAmount *shared.Money          `protobuf:"bytes,3,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CapturePaymentResponse) Reset() {
+	*x = CapturePaymentResponse{}
+	mi := &file_payment_payment_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentResponse) ProtoMessage() {}
+
+func (x *CapturePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentResponse.ProtoReflect.Descriptor instead.
+func (*CapturePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CapturePaymentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *CapturePaymentResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CapturePaymentResponse) GetCapturedAmount() *shared.Money {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return nil
+}
+
+type VoidPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidPaymentRequest) Reset() {
+	*x = VoidPaymentRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidPaymentRequest) ProtoMessage() {}
+
+func (x *VoidPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidPaymentRequest.ProtoReflect.Descriptor instead.
+func (*VoidPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VoidPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *VoidPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CompleteCashOnDeliveryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CollectedAmount *shared.Money          `protobuf:"bytes,2,opt,name=collected_amount,json=collectedAmount,proto3" json:"collected_amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CompleteCashOnDeliveryRequest) Reset() {
+	*x = CompleteCashOnDeliveryRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteCashOnDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteCashOnDeliveryRequest) ProtoMessage() {}
+
+func (x *CompleteCashOnDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteCashOnDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*CompleteCashOnDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CompleteCashOnDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CompleteCashOnDeliveryRequest) GetCollectedAmount() *shared.Money {
+	if x != nil {
+		return x.CollectedAmount
+	}
+	return nil
+}
+
+type CompleteCashOnDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteCashOnDeliveryResponse) Reset() {
+	*x = CompleteCashOnDeliveryResponse{}
+	mi := &file_payment_payment_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteCashOnDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteCashOnDeliveryResponse) ProtoMessage() {}
+
+func (x *CompleteCashOnDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteCashOnDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*CompleteCashOnDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CompleteCashOnDeliveryResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type PaymentStatusChange struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId        string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	FromStatus       PaymentStatus          `protobuf:"varint,3,opt,name=from_status,json=fromStatus,proto3,enum=shinkansen.payment.PaymentStatus" json:"from_status,omitempty"`
+	ToStatus         PaymentStatus          `protobuf:"varint,4,opt,name=to_status,json=toStatus,proto3,enum=shinkansen.payment.PaymentStatus" json:"to_status,omitempty"`
+	ChangedBy        string                 `protobuf:"bytes,5,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	Reason           string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	ProviderResponse string                 `protobuf:"bytes,7,opt,name=provider_response,json=providerResponse,proto3" json:"provider_response,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PaymentStatusChange) Reset() {
+	*x = PaymentStatusChange{}
+	mi := &file_payment_payment_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentStatusChange) ProtoMessage() {}
+
+func (x *PaymentStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentStatusChange.ProtoReflect.Descriptor instead.
+func (*PaymentStatusChange) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PaymentStatusChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PaymentStatusChange) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *PaymentStatusChange) GetFromStatus() PaymentStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *PaymentStatusChange) GetToStatus() PaymentStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *PaymentStatusChange) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *PaymentStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PaymentStatusChange) GetProviderResponse() string {
+	if x != nil {
+		return x.ProviderResponse
+	}
+	return ""
+}
+
+func (x *PaymentStatusChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListPaymentStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentStatusHistoryRequest) Reset() {
+	*x = ListPaymentStatusHistoryRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentStatusHistoryRequest) ProtoMessage() {}
+
+func (x *ListPaymentStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPaymentStatusHistoryRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type ListPaymentStatusHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PaymentStatusChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentStatusHistoryResponse) Reset() {
+	*x = ListPaymentStatusHistoryResponse{}
+	mi := &file_payment_payment_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentStatusHistoryResponse) ProtoMessage() {}
+
+func (x *ListPaymentStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPaymentStatusHistoryResponse) GetChanges() []*PaymentStatusChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type Tender struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        PaymentMethod          `protobuf:"varint,1,opt,name=method,proto3,enum=shinkansen.payment.PaymentMethod" json:"method,omitempty"`
+	Amount        *shared.Money          `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaymentData   map[string]string      `protobuf:"bytes,3,rep,name=payment_data,json=paymentData,proto3" json:"payment_data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tender) Reset() {
+	*x = Tender{}
+	mi := &file_payment_payment_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tender) ProtoMessage() {}
+
+func (x *Tender) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tender.ProtoReflect.Descriptor instead.
+func (*Tender) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Tender) GetMethod() PaymentMethod {
+	if x != nil {
+		return x.Method
+	}
+	return PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
+}
+
+func (x *Tender) GetAmount() *shared.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Tender) GetPaymentData() map[string]string {
+	if x != nil {
+		return x.PaymentData
+	}
+	return nil
+}
+
+type PayOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Tenders       []*Tender              `protobuf:"bytes,2,rep,name=tenders,proto3" json:"tenders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderRequest) ProtoMessage() {}
+
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PayOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PayOrderRequest) GetTenders() []*Tender {
+	if x != nil {
+		return x.Tenders
+	}
+	return nil
+}
+
+type PayOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderResponse) Reset() {
+	*x = PayOrderResponse{}
+	mi := &file_payment_payment_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderResponse) ProtoMessage() {}
+
+func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
+func (*PayOrderResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PayOrderResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type ListPaymentsByOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsByOrderRequest) Reset() {
+	*x = ListPaymentsByOrderRequest{}
+	mi := &file_payment_payment_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsByOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsByOrderRequest) ProtoMessage() {}
+
+func (x *ListPaymentsByOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsByOrderRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsByOrderRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPaymentsByOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListPaymentsByOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsByOrderResponse) Reset() {
+	*x = ListPaymentsByOrderResponse{}
+	mi := &file_payment_payment_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsByOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsByOrderResponse) ProtoMessage() {}
+
+func (x *ListPaymentsByOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsByOrderResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsByOrderResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListPaymentsByOrderResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+var File_payment_payment_messages_proto protoreflect.FileDescriptor
+
+const file_payment_payment_messages_proto_rawDesc = "" +
+	"\n" +
+	"\x1epayment/payment_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\x1a$payment/bank_transfer_messages.proto\x1a'payment/deferred_payment_messages.proto\x1a\x13shared/common.proto\"\x8c\x04\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x129\n" +
+	"\x06method\x18\x03 \x01(\x0e2!.shinkansen.payment.PaymentMethodR\x06method\x120\n" +
+	"\x06amount\x18\x04 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x129\n" +
+	"\x06status\x18\x05 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
+	"\x0etransaction_id\x18\b \x01(\tR\rtransactionId\x12T\n" +
+	"\x18authorization_expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x16authorizationExpiresAt\x12;\n" +
+	"\vcaptured_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"capturedAt\"\x9e\x01\n" +
+	"\x14CreatePaymentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x129\n" +
+	"\x06method\x18\x02 \x01(\x0e2!.shinkansen.payment.PaymentMethodR\x06method\x120\n" +
+	"\x06amount\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\"q\n" +
+	"\x15CreatePaymentResponse\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x129\n" +
+	"\x06status\x18\x02 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\"2\n" +
+	"\x11GetPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"K\n" +
+	"\x12GetPaymentResponse\x125\n" +
+	"\apayment\x18\x01 \x01(\v2\x1b.shinkansen.payment.PaymentR\apayment\"\xd5\x01\n" +
+	"\x15ProcessPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12]\n" +
+	"\fpayment_data\x18\x02 \x03(\v2:.shinkansen.payment.ProcessPaymentRequest.PaymentDataEntryR\vpaymentData\x1a>\n" +
+	"\x10PaymentDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9d\x02\n" +
+	"\x16ProcessPaymentResponse\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12Q\n" +
+	"\rbank_transfer\x18\x03 \x01(\v2,.shinkansen.payment.BankTransferInstructionsR\fbankTransfer\x12N\n" +
+	"\x10deferred_payment\x18\x04 \x01(\v2#.shinkansen.payment.DeferredPaymentR\x0fdeferredPayment\"\xc8\x01\n" +
+	"\x14RefundPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x120\n" +
+	"\x06amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12G\n" +
+	"\vdestination\x18\x04 \x01(\x0e2%.shinkansen.payment.RefundDestinationR\vdestination\"\xa9\x03\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x120\n" +
+	"\x06amount\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x128\n" +
+	"\x06status\x18\x05 \x01(\x0e2 .shinkansen.payment.RefundStatusR\x06status\x12-\n" +
+	"\x12provider_reference\x18\x06 \x01(\tR\x11providerReference\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12G\n" +
+	"\vdestination\x18\t \x01(\x0e2%.shinkansen.payment.RefundDestinationR\vdestination\"3\n" +
+	"\x12ListRefundsRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"\xd5\x01\n" +
+	"\x13ListRefundsResponse\x124\n" +
+	"\arefunds\x18\x01 \x03(\v2\x1a.shinkansen.payment.RefundR\arefunds\x12A\n" +
+	"\x0frefunded_amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x0erefundedAmount\x12E\n" +
+	"\x11refundable_amount\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\x10refundableAmount\"\x83\x01\n" +
+	"\x15CapturePaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x120\n" +
+	"\x06amount\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\"\xbd\x01\n" +
+	"\x16CapturePaymentResponse\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\x06status\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12A\n" +
+	"\x0fcaptured_amount\x18\x03 \x01(\v2\x18.shinkansen.common.MoneyR\x0ecapturedAmount\"K\n" +
+	"\x12VoidPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x7f\n" +
+	"\x1dCompleteCashOnDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12C\n" +
+	"\x10collected_amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x0fcollectedAmount\"W\n" +
+	"\x1eCompleteCashOnDeliveryResponse\x125\n" +
+	"\apayment\x18\x01 \x01(\v2\x1b.shinkansen.payment.PaymentR\apayment\"\xe7\x02\n" +
+	"\x13PaymentStatusChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12B\n" +
+	"\vfrom_status\x18\x03 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\n" +
+	"fromStatus\x12>\n" +
+	"\tto_status\x18\x04 \x01(\x0e2!.shinkansen.payment.PaymentStatusR\btoStatus\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x05 \x01(\tR\tchangedBy\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12+\n" +
+	"\x11provider_response\x18\a \x01(\tR\x10providerResponse\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"@\n" +
+	"\x1fListPaymentStatusHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"e\n" +
+	" ListPaymentStatusHistoryResponse\x12A\n" +
+	"\achanges\x18\x01 \x03(\v2'.shinkansen.payment.PaymentStatusChangeR\achanges\"\x85\x02\n" +
+	"\x06Tender\x129\n" +
+	"\x06method\x18\x01 \x01(\x0e2!.shinkansen.payment.PaymentMethodR\x06method\x120\n" +
+	"\x06amount\x18\x02 \x01(\v2\x18.shinkansen.common.MoneyR\x06amount\x12N\n" +
+	"\fpayment_data\x18\x03 \x03(\v2+.shinkansen.payment.Tender.PaymentDataEntryR\vpaymentData\x1a>\n" +
+	"\x10PaymentDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"b\n" +
+	"\x0fPayOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x124\n" +
+	"\atenders\x18\x02 \x03(\v2\x1a.shinkansen.payment.TenderR\atenders\"K\n" +
+	"\x10PayOrderResponse\x127\n" +
+	"\bpayments\x18\x01 \x03(\v2\x1b.shinkansen.payment.PaymentR\bpayments\"7\n" +
+	"\x1aListPaymentsByOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"V\n" +
+	"\x1bListPaymentsByOrderResponse\x127\n" +
+	"\bpayments\x18\x01 \x03(\v2\x1b.shinkansen.payment.PaymentR\bpayments*\xfe\x02\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x1d\n" +
@@ -651,7 +1822,22 @@ const file_payment_payment_messages_proto_rawDesc = "" +
 	"\x18PAYMENT_STATUS_COMPLETED\x10\x03\x12\x19\n" +
 	"\x15PAYMENT_STATUS_FAILED\x10\x04\x12\x1c\n" +
 	"\x18PAYMENT_STATUS_CANCELLED\x10\x05\x12\x1b\n" +
-	"\x17PAYMENT_STATUS_REFUNDED\x10\x06*\xfc\x01\n" +
+	"\x17PAYMENT_STATUS_REFUNDED\x10\x06\x12\x1d\n" +
+	"\x19PAYMENT_STATUS_AUTHORIZED\x10\a\x12\x1b\n" +
+	"\x17PAYMENT_STATUS_CAPTURED\x10\b\x12%\n" +
+	"!PAYMENT_STATUS_PARTIALLY_REFUNDED\x10\t\x12\x1a\n" +
+	"\x16PAYMENT_STATUS_EXPIRED\x10\n" +
+	"\x12\x1f\n" +
+	"\x1bPAYMENT_STATUS_CHARGED_BACK\x10\v*\x7f\n" +
+	"\fRefundStatus\x12\x1d\n" +
+	"\x19REFUND_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15REFUND_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17REFUND_STATUS_SUCCEEDED\x10\x02\x12\x18\n" +
+	"\x14REFUND_STATUS_FAILED\x10\x03*}\n" +
+	"\x11RefundDestination\x12\"\n" +
+	"\x1eREFUND_DESTINATION_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bREFUND_DESTINATION_ORIGINAL\x10\x01\x12#\n" +
+	"\x1fREFUND_DESTINATION_STORE_CREDIT\x10\x02*\x99\x03\n" +
 	"\rPaymentMethod\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_CREDIT_CARD\x10\x01\x12&\n" +
@@ -659,7 +1845,13 @@ const file_payment_payment_messages_proto_rawDesc = "" +
 	"\x1dPAYMENT_METHOD_KONBINI_LAWSON\x10\x03\x12%\n" +
 	"!PAYMENT_METHOD_KONBINI_FAMILYMART\x10\x04\x12\x19\n" +
 	"\x15PAYMENT_METHOD_PAYPAY\x10\x05\x12\x1e\n" +
-	"\x1aPAYMENT_METHOD_RAKUTEN_PAY\x10\x06B=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+	"\x1aPAYMENT_METHOD_RAKUTEN_PAY\x10\x06\x12 \n" +
+	"\x1cPAYMENT_METHOD_BANK_TRANSFER\x10\a\x12#\n" +
+	"\x1fPAYMENT_METHOD_CASH_ON_DELIVERY\x10\b\x12\x1b\n" +
+	"\x17PAYMENT_METHOD_DEFERRED\x10\t\x12\x19\n" +
+	"\x15PAYMENT_METHOD_POINTS\x10\n" +
+	"\x12\x1c\n" +
+	"\x18PAYMENT_METHOD_GIFT_CARD\x10\vB=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
 
 var (
 	file_payment_payment_messages_proto_rawDescOnce sync.Once
@@ -673,41 +1865,90 @@ func file_payment_payment_messages_proto_rawDescGZIP() []byte {
 	return file_payment_payment_messages_proto_rawDescData
 }
 
-var file_payment_payment_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_payment_payment_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_payment_payment_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_payment_payment_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_payment_payment_messages_proto_goTypes = []any{
-	(PaymentStatus)(0),             // 0: shinkansen.payment.PaymentStatus
-	(PaymentMethod)(0),             // 1: shinkansen.payment.PaymentMethod
-	(*Payment)(nil),                // 2: shinkansen.payment.Payment
-	(*CreatePaymentRequest)(nil),   // 3: shinkansen.payment.CreatePaymentRequest
-	(*CreatePaymentResponse)(nil),  // 4: shinkansen.payment.CreatePaymentResponse
-	(*GetPaymentRequest)(nil),      // 5: shinkansen.payment.GetPaymentRequest
-	(*GetPaymentResponse)(nil),     // 6: shinkansen.payment.GetPaymentResponse
-	(*ProcessPaymentRequest)(nil),  // 7: shinkansen.payment.ProcessPaymentRequest
-	(*ProcessPaymentResponse)(nil), // 8: shinkansen.payment.ProcessPaymentResponse
-	(*RefundPaymentRequest)(nil),   // 9: shinkansen.payment.RefundPaymentRequest
-	nil,                            // 10: shinkansen.payment.ProcessPaymentRequest.PaymentDataEntry
-	(*shared.Money)(nil),           // 11: shinkansen.common.Money
-	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
+	(PaymentStatus)(0),                       // 0: shinkansen.payment.PaymentStatus
+	(RefundStatus)(0),                        // 1: shinkansen.payment.RefundStatus
+	(RefundDestination)(0),                   // 2: shinkansen.payment.RefundDestination
+	(PaymentMethod)(0),                       // 3: shinkansen.payment.PaymentMethod
+	(*Payment)(nil),                          // 4: shinkansen.payment.Payment
+	(*CreatePaymentRequest)(nil),             // 5: shinkansen.payment.CreatePaymentRequest
+	(*CreatePaymentResponse)(nil),            // 6: shinkansen.payment.CreatePaymentResponse
+	(*GetPaymentRequest)(nil),                // 7: shinkansen.payment.GetPaymentRequest
+	(*GetPaymentResponse)(nil),               // 8: shinkansen.payment.GetPaymentResponse
+	(*ProcessPaymentRequest)(nil),            // 9: shinkansen.payment.ProcessPaymentRequest
+	(*ProcessPaymentResponse)(nil),           // 10: shinkansen.payment.ProcessPaymentResponse
+	(*RefundPaymentRequest)(nil),             // 11: shinkansen.payment.RefundPaymentRequest
+	(*Refund)(nil),                           // 12: shinkansen.payment.Refund
+	(*ListRefundsRequest)(nil),               // 13: shinkansen.payment.ListRefundsRequest
+	(*ListRefundsResponse)(nil),              // 14: shinkansen.payment.ListRefundsResponse
+	(*CapturePaymentRequest)(nil),            // 15: shinkansen.payment.CapturePaymentRequest
+	(*CapturePaymentResponse)(nil),           // 16: shinkansen.payment.CapturePaymentResponse
+	(*VoidPaymentRequest)(nil),               // 17: shinkansen.payment.VoidPaymentRequest
+	(*CompleteCashOnDeliveryRequest)(nil),    // 18: shinkansen.payment.CompleteCashOnDeliveryRequest
+	(*CompleteCashOnDeliveryResponse)(nil),   // 19: shinkansen.payment.CompleteCashOnDeliveryResponse
+	(*PaymentStatusChange)(nil),              // 20: shinkansen.payment.PaymentStatusChange
+	(*ListPaymentStatusHistoryRequest)(nil),  // 21: shinkansen.payment.ListPaymentStatusHistoryRequest
+	(*ListPaymentStatusHistoryResponse)(nil), // 22: shinkansen.payment.ListPaymentStatusHistoryResponse
+	(*Tender)(nil),                           // 23: shinkansen.payment.Tender
+	(*PayOrderRequest)(nil),                  // 24: shinkansen.payment.PayOrderRequest
+	(*PayOrderResponse)(nil),                 // 25: shinkansen.payment.PayOrderResponse
+	(*ListPaymentsByOrderRequest)(nil),       // 26: shinkansen.payment.ListPaymentsByOrderRequest
+	(*ListPaymentsByOrderResponse)(nil),      // 27: shinkansen.payment.ListPaymentsByOrderResponse
+	nil,                                      // 28: shinkansen.payment.ProcessPaymentRequest.PaymentDataEntry
+	nil,                                      // 29: shinkansen.payment.Tender.PaymentDataEntry
+	(*shared.Money)(nil),                     // 30: shinkansen.common.Money
+	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
+	(*BankTransferInstructions)(nil),         // 32: shinkansen.payment.BankTransferInstructions
+	(*DeferredPayment)(nil),                  // 33: shinkansen.payment.DeferredPayment
 }
 var file_payment_payment_messages_proto_depIdxs = []int32{
-	1,  // 0: shinkansen.payment.Payment.method:type_name -> shinkansen.payment.PaymentMethod
-	11, // 1: shinkansen.payment.Payment.amount:type_name -> shinkansen.common.Money
+	3,  // 0: shinkansen.payment.Payment.method:type_name -> shinkansen.payment.PaymentMethod
+	30, // 1: shinkansen.payment.Payment.amount:type_name -> shinkansen.common.Money
 	0,  // 2: shinkansen.payment.Payment.status:type_name -> shinkansen.payment.PaymentStatus
-	12, // 3: shinkansen.payment.Payment.created_at:type_name -> google.protobuf.Timestamp
-	12, // 4: shinkansen.payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 5: shinkansen.payment.CreatePaymentRequest.method:type_name -> shinkansen.payment.PaymentMethod
-	11, // 6: shinkansen.payment.CreatePaymentRequest.amount:type_name -> shinkansen.common.Money
-	0,  // 7: shinkansen.payment.CreatePaymentResponse.status:type_name -> shinkansen.payment.PaymentStatus
-	2,  // 8: shinkansen.payment.GetPaymentResponse.payment:type_name -> shinkansen.payment.Payment
-	10, // 9: shinkansen.payment.ProcessPaymentRequest.payment_data:type_name -> shinkansen.payment.ProcessPaymentRequest.PaymentDataEntry
-	0,  // 10: shinkansen.payment.ProcessPaymentResponse.status:type_name -> shinkansen.payment.PaymentStatus
-	11, // 11: shinkansen.payment.RefundPaymentRequest.amount:type_name -> shinkansen.common.Money
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	31, // 3: shinkansen.payment.Payment.created_at:type_name -> google.protobuf.Timestamp
+	31, // 4: shinkansen.payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 5: shinkansen.payment.Payment.authorization_expires_at:type_name -> google.protobuf.Timestamp
+	31, // 6: shinkansen.payment.Payment.captured_at:type_name -> google.protobuf.Timestamp
+	3,  // 7: shinkansen.payment.CreatePaymentRequest.method:type_name -> shinkansen.payment.PaymentMethod
+	30, // 8: shinkansen.payment.CreatePaymentRequest.amount:type_name -> shinkansen.common.Money
+	0,  // 9: shinkansen.payment.CreatePaymentResponse.status:type_name -> shinkansen.payment.PaymentStatus
+	4,  // 10: shinkansen.payment.GetPaymentResponse.payment:type_name -> shinkansen.payment.Payment
+	28, // 11: shinkansen.payment.ProcessPaymentRequest.payment_data:type_name -> shinkansen.payment.ProcessPaymentRequest.PaymentDataEntry
+	0,  // 12: shinkansen.payment.ProcessPaymentResponse.status:type_name -> shinkansen.payment.PaymentStatus
+	32, // 13: shinkansen.payment.ProcessPaymentResponse.bank_transfer:type_name -> shinkansen.payment.BankTransferInstructions
+	33, // 14: shinkansen.payment.ProcessPaymentResponse.deferred_payment:type_name -> shinkansen.payment.DeferredPayment
+	30, // 15: shinkansen.payment.RefundPaymentRequest.amount:type_name -> shinkansen.common.Money
+	2,  // 16: shinkansen.payment.RefundPaymentRequest.destination:type_name -> shinkansen.payment.RefundDestination
+	30, // 17: shinkansen.payment.Refund.amount:type_name -> shinkansen.common.Money
+	1,  // 18: shinkansen.payment.Refund.status:type_name -> shinkansen.payment.RefundStatus
+	31, // 19: shinkansen.payment.Refund.created_at:type_name -> google.protobuf.Timestamp
+	31, // 20: shinkansen.payment.Refund.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 21: shinkansen.payment.Refund.destination:type_name -> shinkansen.payment.RefundDestination
+	12, // 22: shinkansen.payment.ListRefundsResponse.refunds:type_name -> shinkansen.payment.Refund
+	30, // 23: shinkansen.payment.ListRefundsResponse.refunded_amount:type_name -> shinkansen.common.Money
+	30, // 24: shinkansen.payment.ListRefundsResponse.refundable_amount:type_name -> shinkansen.common.Money
+	30, // 25: shinkansen.payment.CapturePaymentRequest.amount:type_name -> shinkansen.common.Money
+	0,  // 26: shinkansen.payment.CapturePaymentResponse.status:type_name -> shinkansen.payment.PaymentStatus
+	30, // 27: shinkansen.payment.CapturePaymentResponse.captured_amount:type_name -> shinkansen.common.Money
+	30, // 28: shinkansen.payment.CompleteCashOnDeliveryRequest.collected_amount:type_name -> shinkansen.common.Money
+	4,  // 29: shinkansen.payment.CompleteCashOnDeliveryResponse.payment:type_name -> shinkansen.payment.Payment
+	0,  // 30: shinkansen.payment.PaymentStatusChange.from_status:type_name -> shinkansen.payment.PaymentStatus
+	0,  // 31: shinkansen.payment.PaymentStatusChange.to_status:type_name -> shinkansen.payment.PaymentStatus
+	31, // 32: shinkansen.payment.PaymentStatusChange.created_at:type_name -> google.protobuf.Timestamp
+	20, // 33: shinkansen.payment.ListPaymentStatusHistoryResponse.changes:type_name -> shinkansen.payment.PaymentStatusChange
+	3,  // 34: shinkansen.payment.Tender.method:type_name -> shinkansen.payment.PaymentMethod
+	30, // 35: shinkansen.payment.Tender.amount:type_name -> shinkansen.common.Money
+	29, // 36: shinkansen.payment.Tender.payment_data:type_name -> shinkansen.payment.Tender.PaymentDataEntry
+	23, // 37: shinkansen.payment.PayOrderRequest.tenders:type_name -> shinkansen.payment.Tender
+	4,  // 38: shinkansen.payment.PayOrderResponse.payments:type_name -> shinkansen.payment.Payment
+	4,  // 39: shinkansen.payment.ListPaymentsByOrderResponse.payments:type_name -> shinkansen.payment.Payment
+	40, // [40:40] is the sub-list for method output_type
+	40, // [40:40] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_payment_payment_messages_proto_init() }
@@ -715,13 +1956,15 @@ func file_payment_payment_messages_proto_init() {
 	if File_payment_payment_messages_proto != nil {
 		return
 	}
+	file_payment_bank_transfer_messages_proto_init()
+	file_payment_deferred_payment_messages_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_payment_messages_proto_rawDesc), len(file_payment_payment_messages_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   9,
+			NumEnums:      4,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -26,38 +26,201 @@ var File_payment_payment_service_proto protoreflect.FileDescriptor
 
 const file_payment_payment_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1dpayment/payment_service.proto\x12\x12shinkansen.payment\x1a\x1cgoogle/api/annotations.proto\x1a\x1epayment/payment_messages.proto\x1a\x13shared/common.proto2\xaa\x04\n" +
+	"\x1dpayment/payment_service.proto\x12\x12shinkansen.payment\x1a\x1cgoogle/api/annotations.proto\x1a$payment/bank_transfer_messages.proto\x1a'payment/deferred_payment_messages.proto\x1a\x1epayment/dispute_messages.proto\x1a payment/gift_card_messages.proto\x1a\x1epayment/payment_messages.proto\x1a%payment/payment_method_messages.proto\x1a%payment/reconciliation_messages.proto\x1a\x1epayment/webhook_messages.proto\x1a\x13shared/common.proto2\xc4,\n" +
 	"\x0ePaymentService\x12}\n" +
 	"\rCreatePayment\x12(.shinkansen.payment.CreatePaymentRequest\x1a).shinkansen.payment.CreatePaymentResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/payments\x12~\n" +
 	"\n" +
 	"GetPayment\x12%.shinkansen.payment.GetPaymentRequest\x1a&.shinkansen.payment.GetPaymentResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/payments/{payment_id}\x12\x95\x01\n" +
 	"\x0eProcessPayment\x12).shinkansen.payment.ProcessPaymentRequest\x1a*.shinkansen.payment.ProcessPaymentResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/payments/{payment_id}/process\x12\x80\x01\n" +
-	"\rRefundPayment\x12(.shinkansen.payment.RefundPaymentRequest\x1a\x18.shinkansen.common.Empty\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/payments/{payment_id}/refundB=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+	"\rRefundPayment\x12(.shinkansen.payment.RefundPaymentRequest\x1a\x18.shinkansen.common.Empty\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/payments/{payment_id}/refund\x12\x95\x01\n" +
+	"\x0eCapturePayment\x12).shinkansen.payment.CapturePaymentRequest\x1a*.shinkansen.payment.CapturePaymentResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/payments/{payment_id}/capture\x12z\n" +
+	"\vVoidPayment\x12&.shinkansen.payment.VoidPaymentRequest\x1a\x18.shinkansen.common.Empty\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/payments/{payment_id}/void\x12\x89\x01\n" +
+	"\vListRefunds\x12&.shinkansen.payment.ListRefundsRequest\x1a'.shinkansen.payment.ListRefundsResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/payments/{payment_id}/refunds\x12\x9e\x01\n" +
+	"\x15CreateWebhookEndpoint\x120.shinkansen.payment.CreateWebhookEndpointRequest\x1a1.shinkansen.payment.CreateWebhookEndpointResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/webhook-endpoints\x12\x98\x01\n" +
+	"\x14ListWebhookEndpoints\x12/.shinkansen.payment.ListWebhookEndpointsRequest\x1a0.shinkansen.payment.ListWebhookEndpointsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/webhook-endpoints\x12\xac\x01\n" +
+	"\x15UpdateWebhookEndpoint\x120.shinkansen.payment.UpdateWebhookEndpointRequest\x1a1.shinkansen.payment.UpdateWebhookEndpointResponse\".\x82\xd3\xe4\x93\x02(:\x01*2#/v1/webhook-endpoints/{endpoint_id}\x12\x90\x01\n" +
+	"\x15DeleteWebhookEndpoint\x120.shinkansen.payment.DeleteWebhookEndpointRequest\x1a\x18.shinkansen.common.Empty\"+\x82\xd3\xe4\x93\x02%*#/v1/webhook-endpoints/{endpoint_id}\x12\x9c\x01\n" +
+	"\x15ListWebhookDeliveries\x120.shinkansen.payment.ListWebhookDeliveriesRequest\x1a1.shinkansen.payment.ListWebhookDeliveriesResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/webhook-deliveries\x12\xb4\x01\n" +
+	"\x15ReplayWebhookDelivery\x120.shinkansen.payment.ReplayWebhookDeliveryRequest\x1a1.shinkansen.payment.ReplayWebhookDeliveryResponse\"6\x82\xd3\xe4\x93\x020:\x01*\"+/v1/webhook-deliveries/{delivery_id}/replay\x12\xa0\x01\n" +
+	"\x16ListReconciliationRuns\x121.shinkansen.payment.ListReconciliationRunsRequest\x1a2.shinkansen.payment.ListReconciliationRunsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/reconciliation-runs\x12\xa3\x01\n" +
+	"\x14GetReconciliationRun\x12/.shinkansen.payment.GetReconciliationRunRequest\x1a0.shinkansen.payment.GetReconciliationRunResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/reconciliation-runs/{run_id}\x12\xbf\x01\n" +
+	"\x1bGetBankTransferInstructions\x126.shinkansen.payment.GetBankTransferInstructionsRequest\x1a7.shinkansen.payment.GetBankTransferInstructionsResponse\"/\x82\xd3\xe4\x93\x02)\x12'/v1/payments/{payment_id}/bank-transfer\x12\x98\x01\n" +
+	"\x12ImportBankDeposits\x12-.shinkansen.payment.ImportBankDepositsRequest\x1a..shinkansen.payment.ImportBankDepositsResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/bank-deposits/import\x12\x88\x01\n" +
+	"\x10ListBankDeposits\x12+.shinkansen.payment.ListBankDepositsRequest\x1a,.shinkansen.payment.ListBankDepositsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/bank-deposits\x12\xbb\x01\n" +
+	"\x16CompleteCashOnDelivery\x121.shinkansen.payment.CompleteCashOnDeliveryRequest\x1a2.shinkansen.payment.CompleteCashOnDeliveryResponse\":\x82\xd3\xe4\x93\x024:\x01*\"//v1/orders/{order_id}/cash-on-delivery/complete\x12\x9f\x01\n" +
+	"\x12GetDeferredPayment\x12-.shinkansen.payment.GetDeferredPaymentRequest\x1a..shinkansen.payment.GetDeferredPaymentResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/payments/{payment_id}/deferred\x12\xb0\x01\n" +
+	"\x18ListPaymentStatusHistory\x123.shinkansen.payment.ListPaymentStatusHistoryRequest\x1a4.shinkansen.payment.ListPaymentStatusHistoryResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/payments/{payment_id}/history\x12v\n" +
+	"\bPayOrder\x12#.shinkansen.payment.PayOrderRequest\x1a$.shinkansen.payment.PayOrderResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/payments/tenders\x12\x8c\x01\n" +
+	"\x13ListPaymentsByOrder\x12..shinkansen.payment.ListPaymentsByOrderRequest\x1a/.shinkansen.payment.ListPaymentsByOrderResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/payments\x12\x99\x01\n" +
+	"\x11SavePaymentMethod\x12,.shinkansen.payment.SavePaymentMethodRequest\x1a-.shinkansen.payment.SavePaymentMethodResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/users/me/payment-methods\x12\x99\x01\n" +
+	"\x12ListPaymentMethods\x12-.shinkansen.payment.ListPaymentMethodsRequest\x1a..shinkansen.payment.ListPaymentMethodsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/users/me/payment-methods\x12\xc4\x01\n" +
+	"\x17SetDefaultPaymentMethod\x122.shinkansen.payment.SetDefaultPaymentMethodRequest\x1a3.shinkansen.payment.SetDefaultPaymentMethodResponse\"@\x82\xd3\xe4\x93\x02:\"8/v1/users/me/payment-methods/{payment_method_id}/default\x12\x99\x01\n" +
+	"\x13DeletePaymentMethod\x12..shinkansen.payment.DeletePaymentMethodRequest\x1a\x18.shinkansen.common.Empty\"8\x82\xd3\xe4\x93\x022*0/v1/users/me/payment-methods/{payment_method_id}\x12\xb1\x01\n" +
+	"\x1aListExpiringPaymentMethods\x125.shinkansen.payment.ListExpiringPaymentMethodsRequest\x1a6.shinkansen.payment.ListExpiringPaymentMethodsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/payment-methods/expiring\x12w\n" +
+	"\fListDisputes\x12'.shinkansen.payment.ListDisputesRequest\x1a(.shinkansen.payment.ListDisputesResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/disputes\x12~\n" +
+	"\n" +
+	"GetDispute\x12%.shinkansen.payment.GetDisputeRequest\x1a&.shinkansen.payment.GetDisputeResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/disputes/{dispute_id}\x12\xa2\x01\n" +
+	"\x12AddDisputeEvidence\x12-.shinkansen.payment.AddDisputeEvidenceRequest\x1a..shinkansen.payment.AddDisputeEvidenceResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/disputes/{dispute_id}/evidence\x12\xa6\x01\n" +
+	"\x15SubmitDisputeEvidence\x120.shinkansen.payment.SubmitDisputeEvidenceRequest\x1a1.shinkansen.payment.SubmitDisputeEvidenceResponse\"(\x82\xd3\xe4\x93\x02\"\" /v1/disputes/{dispute_id}/submit\x12\x7f\n" +
+	"\rIssueGiftCard\x12(.shinkansen.payment.IssueGiftCardRequest\x1a).shinkansen.payment.IssueGiftCardResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/gift-cards\x12\x9c\x01\n" +
+	"\x14CheckGiftCardBalance\x12/.shinkansen.payment.CheckGiftCardBalanceRequest\x1a0.shinkansen.payment.CheckGiftCardBalanceResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/gift-cards/balance\x12\x85\x01\n" +
+	"\vGetGiftCard\x12&.shinkansen.payment.GetGiftCardRequest\x1a'.shinkansen.payment.GetGiftCardResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/gift-cards/{gift_card_id}\x12|\n" +
+	"\rListGiftCards\x12(.shinkansen.payment.ListGiftCardsRequest\x1a).shinkansen.payment.ListGiftCardsResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/gift-cards\x12\x9c\x01\n" +
+	"\x0fDisableGiftCard\x12*.shinkansen.payment.DisableGiftCardRequest\x1a+.shinkansen.payment.DisableGiftCardResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/gift-cards/{gift_card_id}/disableB=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
 
 var file_payment_payment_service_proto_goTypes = []any{
-	(*CreatePaymentRequest)(nil),   // 0: shinkansen.payment.CreatePaymentRequest
-	(*GetPaymentRequest)(nil),      // 1: shinkansen.payment.GetPaymentRequest
-	(*ProcessPaymentRequest)(nil),  // 2: shinkansen.payment.ProcessPaymentRequest
-	(*RefundPaymentRequest)(nil),   // 3: shinkansen.payment.RefundPaymentRequest
-	(*CreatePaymentResponse)(nil),  // 4: shinkansen.payment.CreatePaymentResponse
-	(*GetPaymentResponse)(nil),     // 5: shinkansen.payment.GetPaymentResponse
-	(*ProcessPaymentResponse)(nil), // 6: shinkansen.payment.ProcessPaymentResponse
-	(*shared.Empty)(nil),           // 7: shinkansen.common.Empty
+	(*CreatePaymentRequest)(nil),                // 0: shinkansen.payment.CreatePaymentRequest
+	(*GetPaymentRequest)(nil),                   // 1: shinkansen.payment.GetPaymentRequest
+	(*ProcessPaymentRequest)(nil),               // 2: shinkansen.payment.ProcessPaymentRequest
+	(*RefundPaymentRequest)(nil),                // 3: shinkansen.payment.RefundPaymentRequest
+	(*CapturePaymentRequest)(nil),               // 4: shinkansen.payment.CapturePaymentRequest
+	(*VoidPaymentRequest)(nil),                  // 5: shinkansen.payment.VoidPaymentRequest
+	(*ListRefundsRequest)(nil),                  // 6: shinkansen.payment.ListRefundsRequest
+	(*CreateWebhookEndpointRequest)(nil),        // 7: shinkansen.payment.CreateWebhookEndpointRequest
+	(*ListWebhookEndpointsRequest)(nil),         // 8: shinkansen.payment.ListWebhookEndpointsRequest
+	(*UpdateWebhookEndpointRequest)(nil),        // 9: shinkansen.payment.UpdateWebhookEndpointRequest
+	(*DeleteWebhookEndpointRequest)(nil),        // 10: shinkansen.payment.DeleteWebhookEndpointRequest
+	(*ListWebhookDeliveriesRequest)(nil),        // 11: shinkansen.payment.ListWebhookDeliveriesRequest
+	(*ReplayWebhookDeliveryRequest)(nil),        // 12: shinkansen.payment.ReplayWebhookDeliveryRequest
+	(*ListReconciliationRunsRequest)(nil),       // 13: shinkansen.payment.ListReconciliationRunsRequest
+	(*GetReconciliationRunRequest)(nil),         // 14: shinkansen.payment.GetReconciliationRunRequest
+	(*GetBankTransferInstructionsRequest)(nil),  // 15: shinkansen.payment.GetBankTransferInstructionsRequest
+	(*ImportBankDepositsRequest)(nil),           // 16: shinkansen.payment.ImportBankDepositsRequest
+	(*ListBankDepositsRequest)(nil),             // 17: shinkansen.payment.ListBankDepositsRequest
+	(*CompleteCashOnDeliveryRequest)(nil),       // 18: shinkansen.payment.CompleteCashOnDeliveryRequest
+	(*GetDeferredPaymentRequest)(nil),           // 19: shinkansen.payment.GetDeferredPaymentRequest
+	(*ListPaymentStatusHistoryRequest)(nil),     // 20: shinkansen.payment.ListPaymentStatusHistoryRequest
+	(*PayOrderRequest)(nil),                     // 21: shinkansen.payment.PayOrderRequest
+	(*ListPaymentsByOrderRequest)(nil),          // 22: shinkansen.payment.ListPaymentsByOrderRequest
+	(*SavePaymentMethodRequest)(nil),            // 23: shinkansen.payment.SavePaymentMethodRequest
+	(*ListPaymentMethodsRequest)(nil),           // 24: shinkansen.payment.ListPaymentMethodsRequest
+	(*SetDefaultPaymentMethodRequest)(nil),      // 25: shinkansen.payment.SetDefaultPaymentMethodRequest
+	(*DeletePaymentMethodRequest)(nil),          // 26: shinkansen.payment.DeletePaymentMethodRequest
+	(*ListExpiringPaymentMethodsRequest)(nil),   // 27: shinkansen.payment.ListExpiringPaymentMethodsRequest
+	(*ListDisputesRequest)(nil),                 // 28: shinkansen.payment.ListDisputesRequest
+	(*GetDisputeRequest)(nil),                   // 29: shinkansen.payment.GetDisputeRequest
+	(*AddDisputeEvidenceRequest)(nil),           // 30: shinkansen.payment.AddDisputeEvidenceRequest
+	(*SubmitDisputeEvidenceRequest)(nil),        // 31: shinkansen.payment.SubmitDisputeEvidenceRequest
+	(*IssueGiftCardRequest)(nil),                // 32: shinkansen.payment.IssueGiftCardRequest
+	(*CheckGiftCardBalanceRequest)(nil),         // 33: shinkansen.payment.CheckGiftCardBalanceRequest
+	(*GetGiftCardRequest)(nil),                  // 34: shinkansen.payment.GetGiftCardRequest
+	(*ListGiftCardsRequest)(nil),                // 35: shinkansen.payment.ListGiftCardsRequest
+	(*DisableGiftCardRequest)(nil),              // 36: shinkansen.payment.DisableGiftCardRequest
+	(*CreatePaymentResponse)(nil),               // 37: shinkansen.payment.CreatePaymentResponse
+	(*GetPaymentResponse)(nil),                  // 38: shinkansen.payment.GetPaymentResponse
+	(*ProcessPaymentResponse)(nil),              // 39: shinkansen.payment.ProcessPaymentResponse
+	(*shared.Empty)(nil),                        // 40: shinkansen.common.Empty
+	(*CapturePaymentResponse)(nil),              // 41: shinkansen.payment.CapturePaymentResponse
+	(*ListRefundsResponse)(nil),                 // 42: shinkansen.payment.ListRefundsResponse
+	(*CreateWebhookEndpointResponse)(nil),       // 43: shinkansen.payment.CreateWebhookEndpointResponse
+	(*ListWebhookEndpointsResponse)(nil),        // 44: shinkansen.payment.ListWebhookEndpointsResponse
+	(*UpdateWebhookEndpointResponse)(nil),       // 45: shinkansen.payment.UpdateWebhookEndpointResponse
+	(*ListWebhookDeliveriesResponse)(nil),       // 46: shinkansen.payment.ListWebhookDeliveriesResponse
+	(*ReplayWebhookDeliveryResponse)(nil),       // 47: shinkansen.payment.ReplayWebhookDeliveryResponse
+	(*ListReconciliationRunsResponse)(nil),      // 48: shinkansen.payment.ListReconciliationRunsResponse
+	(*GetReconciliationRunResponse)(nil),        // 49: shinkansen.payment.GetReconciliationRunResponse
+	(*GetBankTransferInstructionsResponse)(nil), // 50: shinkansen.payment.GetBankTransferInstructionsResponse
+	(*ImportBankDepositsResponse)(nil),          // 51: shinkansen.payment.ImportBankDepositsResponse
+	(*ListBankDepositsResponse)(nil),            // 52: shinkansen.payment.ListBankDepositsResponse
+	(*CompleteCashOnDeliveryResponse)(nil),      // 53: shinkansen.payment.CompleteCashOnDeliveryResponse
+	(*GetDeferredPaymentResponse)(nil),          // 54: shinkansen.payment.GetDeferredPaymentResponse
+	(*ListPaymentStatusHistoryResponse)(nil),    // 55: shinkansen.payment.ListPaymentStatusHistoryResponse
+	(*PayOrderResponse)(nil),                    // 56: shinkansen.payment.PayOrderResponse
+	(*ListPaymentsByOrderResponse)(nil),         // 57: shinkansen.payment.ListPaymentsByOrderResponse
+	(*SavePaymentMethodResponse)(nil),           // 58: shinkansen.payment.SavePaymentMethodResponse
+	(*ListPaymentMethodsResponse)(nil),          // 59: shinkansen.payment.ListPaymentMethodsResponse
+	(*SetDefaultPaymentMethodResponse)(nil),     // 60: shinkansen.payment.SetDefaultPaymentMethodResponse
+	(*ListExpiringPaymentMethodsResponse)(nil),  // 61: shinkansen.payment.ListExpiringPaymentMethodsResponse
+	(*ListDisputesResponse)(nil),                // 62: shinkansen.payment.ListDisputesResponse
+	(*GetDisputeResponse)(nil),                  // 63: shinkansen.payment.GetDisputeResponse
+	(*AddDisputeEvidenceResponse)(nil),          // 64: shinkansen.payment.AddDisputeEvidenceResponse
+	(*SubmitDisputeEvidenceResponse)(nil),       // 65: shinkansen.payment.SubmitDisputeEvidenceResponse
+	(*IssueGiftCardResponse)(nil),               // 66: shinkansen.payment.IssueGiftCardResponse
+	(*CheckGiftCardBalanceResponse)(nil),        // 67: shinkansen.payment.CheckGiftCardBalanceResponse
+	(*GetGiftCardResponse)(nil),                 // 68: shinkansen.payment.GetGiftCardResponse
+	(*ListGiftCardsResponse)(nil),               // 69: shinkansen.payment.ListGiftCardsResponse
+	(*DisableGiftCardResponse)(nil),             // 70: shinkansen.payment.DisableGiftCardResponse
 }
 var file_payment_payment_service_proto_depIdxs = []int32{
-	0, // 0: shinkansen.payment.PaymentService.CreatePayment:input_type -> shinkansen.payment.CreatePaymentRequest
-	1, // 1: shinkansen.payment.PaymentService.GetPayment:input_type -> shinkansen.payment.GetPaymentRequest
-	2, // 2: shinkansen.payment.PaymentService.ProcessPayment:input_type -> shinkansen.payment.ProcessPaymentRequest
-	3, // 3: shinkansen.payment.PaymentService.RefundPayment:input_type -> shinkansen.payment.RefundPaymentRequest
-	4, // 4: shinkansen.payment.PaymentService.CreatePayment:output_type -> shinkansen.payment.CreatePaymentResponse
-	5, // 5: shinkansen.payment.PaymentService.GetPayment:output_type -> shinkansen.payment.GetPaymentResponse
-	6, // 6: shinkansen.payment.PaymentService.ProcessPayment:output_type -> shinkansen.payment.ProcessPaymentResponse
-	7, // 7: shinkansen.payment.PaymentService.RefundPayment:output_type -> shinkansen.common.Empty
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: shinkansen.payment.PaymentService.CreatePayment:input_type -> shinkansen.payment.CreatePaymentRequest
+	1,  // 1: shinkansen.payment.PaymentService.GetPayment:input_type -> shinkansen.payment.GetPaymentRequest
+	2,  // 2: shinkansen.payment.PaymentService.ProcessPayment:input_type -> shinkansen.payment.ProcessPaymentRequest
+	3,  // 3: shinkansen.payment.PaymentService.RefundPayment:input_type -> shinkansen.payment.RefundPaymentRequest
+	4,  // 4: shinkansen.payment.PaymentService.CapturePayment:input_type -> shinkansen.payment.CapturePaymentRequest
+	5,  // 5: shinkansen.payment.PaymentService.VoidPayment:input_type -> shinkansen.payment.VoidPaymentRequest
+	6,  // 6: shinkansen.payment.PaymentService.ListRefunds:input_type -> shinkansen.payment.ListRefundsRequest
+	7,  // 7: shinkansen.payment.PaymentService.CreateWebhookEndpoint:input_type -> shinkansen.payment.CreateWebhookEndpointRequest
+	8,  // 8: shinkansen.payment.PaymentService.ListWebhookEndpoints:input_type -> shinkansen.payment.ListWebhookEndpointsRequest
+	9,  // 9: shinkansen.payment.PaymentService.UpdateWebhookEndpoint:input_type -> shinkansen.payment.UpdateWebhookEndpointRequest
+	10, // 10: shinkansen.payment.PaymentService.DeleteWebhookEndpoint:input_type -> shinkansen.payment.DeleteWebhookEndpointRequest
+	11, // 11: shinkansen.payment.PaymentService.ListWebhookDeliveries:input_type -> shinkansen.payment.ListWebhookDeliveriesRequest
+	12, // 12: shinkansen.payment.PaymentService.ReplayWebhookDelivery:input_type -> shinkansen.payment.ReplayWebhookDeliveryRequest
+	13, // 13: shinkansen.payment.PaymentService.ListReconciliationRuns:input_type -> shinkansen.payment.ListReconciliationRunsRequest
+	14, // 14: shinkansen.payment.PaymentService.GetReconciliationRun:input_type -> shinkansen.payment.GetReconciliationRunRequest
+	15, // 15: shinkansen.payment.PaymentService.GetBankTransferInstructions:input_type -> shinkansen.payment.GetBankTransferInstructionsRequest
+	16, // 16: shinkansen.payment.PaymentService.ImportBankDeposits:input_type -> shinkansen.payment.ImportBankDepositsRequest
+	17, // 17: shinkansen.payment.PaymentService.ListBankDeposits:input_type -> shinkansen.payment.ListBankDepositsRequest
+	18, // 18: shinkansen.payment.PaymentService.CompleteCashOnDelivery:input_type -> shinkansen.payment.CompleteCashOnDeliveryRequest
+	19, // 19: shinkansen.payment.PaymentService.GetDeferredPayment:input_type -> shinkansen.payment.GetDeferredPaymentRequest
+	20, // 20: shinkansen.payment.PaymentService.ListPaymentStatusHistory:input_type -> shinkansen.payment.ListPaymentStatusHistoryRequest
+	21, // 21: shinkansen.payment.PaymentService.PayOrder:input_type -> shinkansen.payment.PayOrderRequest
+	22, // 22: shinkansen.payment.PaymentService.ListPaymentsByOrder:input_type -> shinkansen.payment.ListPaymentsByOrderRequest
+	23, // 23: shinkansen.payment.PaymentService.SavePaymentMethod:input_type -> shinkansen.payment.SavePaymentMethodRequest
+	24, // 24: shinkansen.payment.PaymentService.ListPaymentMethods:input_type -> shinkansen.payment.ListPaymentMethodsRequest
+	25, // 25: shinkansen.payment.PaymentService.SetDefaultPaymentMethod:input_type -> shinkansen.payment.SetDefaultPaymentMethodRequest
+	26, // 26: shinkansen.payment.PaymentService.DeletePaymentMethod:input_type -> shinkansen.payment.DeletePaymentMethodRequest
+	27, // 27: shinkansen.payment.PaymentService.ListExpiringPaymentMethods:input_type -> shinkansen.payment.ListExpiringPaymentMethodsRequest
+	28, // 28: shinkansen.payment.PaymentService.ListDisputes:input_type -> shinkansen.payment.ListDisputesRequest
+	29, // 29: shinkansen.payment.PaymentService.GetDispute:input_type -> shinkansen.payment.GetDisputeRequest
+	30, // 30: shinkansen.payment.PaymentService.AddDisputeEvidence:input_type -> shinkansen.payment.AddDisputeEvidenceRequest
+	31, // 31: shinkansen.payment.PaymentService.SubmitDisputeEvidence:input_type -> shinkansen.payment.SubmitDisputeEvidenceRequest
+	32, // 32: shinkansen.payment.PaymentService.IssueGiftCard:input_type -> shinkansen.payment.IssueGiftCardRequest
+	33, // 33: shinkansen.payment.PaymentService.CheckGiftCardBalance:input_type -> shinkansen.payment.CheckGiftCardBalanceRequest
+	34, // 34: shinkansen.payment.PaymentService.GetGiftCard:input_type -> shinkansen.payment.GetGiftCardRequest
+	35, // 35: shinkansen.payment.PaymentService.ListGiftCards:input_type -> shinkansen.payment.ListGiftCardsRequest
+	36, // 36: shinkansen.payment.PaymentService.DisableGiftCard:input_type -> shinkansen.payment.DisableGiftCardRequest
+	37, // 37: shinkansen.payment.PaymentService.CreatePayment:output_type -> shinkansen.payment.CreatePaymentResponse
+	38, // 38: shinkansen.payment.PaymentService.GetPayment:output_type -> shinkansen.payment.GetPaymentResponse
+	39, // 39: shinkansen.payment.PaymentService.ProcessPayment:output_type -> shinkansen.payment.ProcessPaymentResponse
+	40, // 40: shinkansen.payment.PaymentService.RefundPayment:output_type -> shinkansen.common.Empty
+	41, // 41: shinkansen.payment.PaymentService.CapturePayment:output_type -> shinkansen.payment.CapturePaymentResponse
+	40, // 42: shinkansen.payment.PaymentService.VoidPayment:output_type -> shinkansen.common.Empty
+	42, // 43: shinkansen.payment.PaymentService.ListRefunds:output_type -> shinkansen.payment.ListRefundsResponse
+	43, // 44: shinkansen.payment.PaymentService.CreateWebhookEndpoint:output_type -> shinkansen.payment.CreateWebhookEndpointResponse
+	44, // 45: shinkansen.payment.PaymentService.ListWebhookEndpoints:output_type -> shinkansen.payment.ListWebhookEndpointsResponse
+	45, // 46: shinkansen.payment.PaymentService.UpdateWebhookEndpoint:output_type -> shinkansen.payment.UpdateWebhookEndpointResponse
+	40, // 47: shinkansen.payment.PaymentService.DeleteWebhookEndpoint:output_type -> shinkansen.common.Empty
+	46, // 48: shinkansen.payment.PaymentService.ListWebhookDeliveries:output_type -> shinkansen.payment.ListWebhookDeliveriesResponse
+	47, // 49: shinkansen.payment.PaymentService.ReplayWebhookDelivery:output_type -> shinkansen.payment.ReplayWebhookDeliveryResponse
+	48, // 50: shinkansen.payment.PaymentService.ListReconciliationRuns:output_type -> shinkansen.payment.ListReconciliationRunsResponse
+	49, // 51: shinkansen.payment.PaymentService.GetReconciliationRun:output_type -> shinkansen.payment.GetReconciliationRunResponse
+	50, // 52: shinkansen.payment.PaymentService.GetBankTransferInstructions:output_type -> shinkansen.payment.GetBankTransferInstructionsResponse
+	51, // 53: shinkansen.payment.PaymentService.ImportBankDeposits:output_type -> shinkansen.payment.ImportBankDepositsResponse
+	52, // 54: shinkansen.payment.PaymentService.ListBankDeposits:output_type -> shinkansen.payment.ListBankDepositsResponse
+	53, // 55: shinkansen.payment.PaymentService.CompleteCashOnDelivery:output_type -> shinkansen.payment.CompleteCashOnDeliveryResponse
+	54, // 56: shinkansen.payment.PaymentService.GetDeferredPayment:output_type -> shinkansen.payment.GetDeferredPaymentResponse
+	55, // 57: shinkansen.payment.PaymentService.ListPaymentStatusHistory:output_type -> shinkansen.payment.ListPaymentStatusHistoryResponse
+	56, // 58: shinkansen.payment.PaymentService.PayOrder:output_type -> shinkansen.payment.PayOrderResponse
+	57, // 59: shinkansen.payment.PaymentService.ListPaymentsByOrder:output_type -> shinkansen.payment.ListPaymentsByOrderResponse
+	58, // 60: shinkansen.payment.PaymentService.SavePaymentMethod:output_type -> shinkansen.payment.SavePaymentMethodResponse
+	59, // 61: shinkansen.payment.PaymentService.ListPaymentMethods:output_type -> shinkansen.payment.ListPaymentMethodsResponse
+	60, // 62: shinkansen.payment.PaymentService.SetDefaultPaymentMethod:output_type -> shinkansen.payment.SetDefaultPaymentMethodResponse
+	40, // 63: shinkansen.payment.PaymentService.DeletePaymentMethod:output_type -> shinkansen.common.Empty
+	61, // 64: shinkansen.payment.PaymentService.ListExpiringPaymentMethods:output_type -> shinkansen.payment.ListExpiringPaymentMethodsResponse
+	62, // 65: shinkansen.payment.PaymentService.ListDisputes:output_type -> shinkansen.payment.ListDisputesResponse
+	63, // 66: shinkansen.payment.PaymentService.GetDispute:output_type -> shinkansen.payment.GetDisputeResponse
+	64, // 67: shinkansen.payment.PaymentService.AddDisputeEvidence:output_type -> shinkansen.payment.AddDisputeEvidenceResponse
+	65, // 68: shinkansen.payment.PaymentService.SubmitDisputeEvidence:output_type -> shinkansen.payment.SubmitDisputeEvidenceResponse
+	66, // 69: shinkansen.payment.PaymentService.IssueGiftCard:output_type -> shinkansen.payment.IssueGiftCardResponse
+	67, // 70: shinkansen.payment.PaymentService.CheckGiftCardBalance:output_type -> shinkansen.payment.CheckGiftCardBalanceResponse
+	68, // 71: shinkansen.payment.PaymentService.GetGiftCard:output_type -> shinkansen.payment.GetGiftCardResponse
+	69, // 72: shinkansen.payment.PaymentService.ListGiftCards:output_type -> shinkansen.payment.ListGiftCardsResponse
+	70, // 73: shinkansen.payment.PaymentService.DisableGiftCard:output_type -> shinkansen.payment.DisableGiftCardResponse
+	37, // [37:74] is the sub-list for method output_type
+	0,  // [0:37] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_payment_payment_service_proto_init() }
@@ -65,7 +228,14 @@ func file_payment_payment_service_proto_init() {
 	if File_payment_payment_service_proto != nil {
 		return
 	}
+	file_payment_bank_transfer_messages_proto_init()
+	file_payment_deferred_payment_messages_proto_init()
+	file_payment_dispute_messages_proto_init()
+	file_payment_gift_card_messages_proto_init()
 	file_payment_payment_messages_proto_init()
+	file_payment_payment_method_messages_proto_init()
+	file_payment_reconciliation_messages_proto_init()
+	file_payment_webhook_messages_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -32,6 +32,7 @@ const (
 	PointTransactionType_POINT_TRANSACTION_TYPE_REDEEMED    PointTransactionType = 2
 	PointTransactionType_POINT_TRANSACTION_TYPE_EXPIRED     PointTransactionType = 3
 	PointTransactionType_POINT_TRANSACTION_TYPE_ADJUSTED    PointTransactionType = 4
+	PointTransactionType_POINT_TRANSACTION_TYPE_REFUNDED    PointTransactionType = 5
 )
 
 // Enum value maps for PointTransactionType.
@@ -42,6 +43,7 @@ var (
 		2: "POINT_TRANSACTION_TYPE_REDEEMED",
 		3: "POINT_TRANSACTION_TYPE_EXPIRED",
 		4: "POINT_TRANSACTION_TYPE_ADJUSTED",
+		5: "POINT_TRANSACTION_TYPE_REFUNDED",
 	}
 	PointTransactionType_value = map[string]int32{
 		"POINT_TRANSACTION_TYPE_UNSPECIFIED": 0,
@@ -49,6 +51,7 @@ var (
 		"POINT_TRANSACTION_TYPE_REDEEMED":    2,
 		"POINT_TRANSACTION_TYPE_EXPIRED":     3,
 		"POINT_TRANSACTION_TYPE_ADJUSTED":    4,
+		"POINT_TRANSACTION_TYPE_REFUNDED":    5,
 	}
 )
 
@@ -609,13 +612,14 @@ const file_payment_points_proto_rawDesc = "" +
 	"\x04type\x18\x04 \x01(\x0e2(.shinkansen.payment.PointTransactionTypeR\x04type\x12\x16\n" +
 	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*\xcf\x01\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*\xf4\x01\n" +
 	"\x14PointTransactionType\x12&\n" +
 	"\"POINT_TRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
 	"\x1dPOINT_TRANSACTION_TYPE_EARNED\x10\x01\x12#\n" +
 	"\x1fPOINT_TRANSACTION_TYPE_REDEEMED\x10\x02\x12\"\n" +
 	"\x1ePOINT_TRANSACTION_TYPE_EXPIRED\x10\x03\x12#\n" +
-	"\x1fPOINT_TRANSACTION_TYPE_ADJUSTED\x10\x042\xfd\x02\n" +
+	"\x1fPOINT_TRANSACTION_TYPE_ADJUSTED\x10\x04\x12#\n" +
+	"\x1fPOINT_TRANSACTION_TYPE_REFUNDED\x10\x052\xfd\x02\n" +
 	"\rPointsService\x12[\n" +
 	"\n" +
 	"GetBalance\x12%.shinkansen.payment.GetBalanceRequest\x1a&.shinkansen.payment.GetBalanceResponse\x12a\n" +
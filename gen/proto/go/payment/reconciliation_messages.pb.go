@@ -0,0 +1,658 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.30.2
+// source: payment/reconciliation_messages.proto
+
+package payment
+
+import (
+	shared "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReconciliationResult int32
+
+const (
+	ReconciliationResult_RECONCILIATION_RESULT_UNSPECIFIED          ReconciliationResult = 0
+	ReconciliationResult_RECONCILIATION_RESULT_MATCHED              ReconciliationResult = 1
+	ReconciliationResult_RECONCILIATION_RESULT_AMOUNT_MISMATCH      ReconciliationResult = 2
+	ReconciliationResult_RECONCILIATION_RESULT_MISSING_CAPTURE      ReconciliationResult = 3
+	ReconciliationResult_RECONCILIATION_RESULT_MISSING_SETTLEMENT   ReconciliationResult = 4
+	ReconciliationResult_RECONCILIATION_RESULT_ORPHAN_SETTLEMENT    ReconciliationResult = 5
+	ReconciliationResult_RECONCILIATION_RESULT_DUPLICATE_SETTLEMENT ReconciliationResult = 6
+)
+
+// Enum value maps for ReconciliationResult.
+var (
+	ReconciliationResult_name = map[int32]string{
+		0: "RECONCILIATION_RESULT_UNSPECIFIED",
+		1: "RECONCILIATION_RESULT_MATCHED",
+		2: "RECONCILIATION_RESULT_AMOUNT_MISMATCH",
+		3: "RECONCILIATION_RESULT_MISSING_CAPTURE",
+		4: "RECONCILIATION_RESULT_MISSING_SETTLEMENT",
+		5: "RECONCILIATION_RESULT_ORPHAN_SETTLEMENT",
+		6: "RECONCILIATION_RESULT_DUPLICATE_SETTLEMENT",
+	}
+	ReconciliationResult_value = map[string]int32{
+		"RECONCILIATION_RESULT_UNSPECIFIED":          0,
+		"RECONCILIATION_RESULT_MATCHED":              1,
+		"RECONCILIATION_RESULT_AMOUNT_MISMATCH":      2,
+		"RECONCILIATION_RESULT_MISSING_CAPTURE":      3,
+		"RECONCILIATION_RESULT_MISSING_SETTLEMENT":   4,
+		"RECONCILIATION_RESULT_ORPHAN_SETTLEMENT":    5,
+		"RECONCILIATION_RESULT_DUPLICATE_SETTLEMENT": 6,
+	}
+)
+
+func (x ReconciliationResult) Enum() *ReconciliationResult {
+	p := new(ReconciliationResult)
+	*p = x
+	return p
+}
+
+func (x ReconciliationResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconciliationResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_reconciliation_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (ReconciliationResult) Type() protoreflect.EnumType {
+	return &file_payment_reconciliation_messages_proto_enumTypes[0]
+}
+
+func (x ReconciliationResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconciliationResult.Descriptor instead.
+func (ReconciliationResult) EnumDescriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type ReconciliationRun struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider       string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	FileName       string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileFormat     string                 `protobuf:"bytes,4,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
+	PeriodStart    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	TotalLines     int32                  `protobuf:"varint,7,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	MatchedCount   int32                  `protobuf:"varint,8,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	ExceptionCount int32                  `protobuf:"varint,9,opt,name=exception_count,json=exceptionCount,proto3" json:"exception_count,omitempty"`
+	SettledAmount  *shared.Money          `protobuf:"bytes,10,opt,name=settled_amount,json=settledAmount,proto3" json:"settled_amount,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReconciliationRun) Reset() {
+	*x = ReconciliationRun{}
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationRun) ProtoMessage() {}
+
+func (x *ReconciliationRun) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationRun.ProtoReflect.Descriptor instead.
+func (*ReconciliationRun) Descriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReconciliationRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReconciliationRun) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ReconciliationRun) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ReconciliationRun) GetFileFormat() string {
+	if x != nil {
+		return x.FileFormat
+	}
+	return ""
+}
+
+func (x *ReconciliationRun) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ReconciliationRun) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *ReconciliationRun) GetTotalLines() int32 {
+	if x != nil {
+		return x.TotalLines
+	}
+	return 0
+}
+
+func (x *ReconciliationRun) GetMatchedCount() int32 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *ReconciliationRun) GetExceptionCount() int32 {
+	if x != nil {
+		return x.ExceptionCount
+	}
+	return 0
+}
+
+func (x *ReconciliationRun) GetSettledAmount() *shared.Money {
+	if x != nil {
+		return x.SettledAmount
+	}
+	return nil
+}
+
+func (x *ReconciliationRun) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ReconciliationItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Result         ReconciliationResult   `protobuf:"varint,2,opt,name=result,proto3,enum=shinkansen.payment.ReconciliationResult" json:"result,omitempty"`
+	TransactionId  string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	PaymentId      string                 `protobuf:"bytes,4,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	LineNumber     int32                  `protobuf:"varint,5,opt,name=line_number,json=lineNumber,proto3" json:"line_number,omitempty"`
+	SettledAmount  *shared.Money          `protobuf:"bytes,6,opt,name=settled_amount,json=settledAmount,proto3" json:"settled_amount,omitempty"`
+	ExpectedAmount *shared.Money          `protobuf:"bytes,7,opt,name=expected_amount,json=expectedAmount,proto3" json:"expected_amount,omitempty"`
+	Fee            *shared.Money          `protobuf:"bytes,8,opt,name=fee,proto3" json:"fee,omitempty"`
+	SettledOn      string                 `protobuf:"bytes,9,opt,name=settled_on,json=settledOn,proto3" json:"settled_on,omitempty"`
+	Detail         string                 `protobuf:"bytes,10,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReconciliationItem) Reset() {
+	*x = ReconciliationItem{}
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationItem) ProtoMessage() {}
+
+func (x *ReconciliationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationItem.ProtoReflect.Descriptor instead.
+func (*ReconciliationItem) Descriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReconciliationItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetResult() ReconciliationResult {
+	if x != nil {
+		return x.Result
+	}
+	return ReconciliationResult_RECONCILIATION_RESULT_UNSPECIFIED
+}
+
+func (x *ReconciliationItem) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetLineNumber() int32 {
+	if x != nil {
+		return x.LineNumber
+	}
+	return 0
+}
+
+func (x *ReconciliationItem) GetSettledAmount() *shared.Money {
+	if x != nil {
+		return x.SettledAmount
+	}
+	return nil
+}
+
+func (x *ReconciliationItem) GetExpectedAmount() *shared.Money {
+	if x != nil {
+		return x.ExpectedAmount
+	}
+	return nil
+}
+
+func (x *ReconciliationItem) GetFee() *shared.Money {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *ReconciliationItem) GetSettledOn() string {
+	if x != nil {
+		return x.SettledOn
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type ListReconciliationRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReconciliationRunsRequest) Reset() {
+	*x = ListReconciliationRunsRequest{}
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReconciliationRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReconciliationRunsRequest) ProtoMessage() {}
+
+func (x *ListReconciliationRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReconciliationRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListReconciliationRunsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListReconciliationRunsRequest) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListReconciliationRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*ReconciliationRun   `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	Pagination    *shared.Pagination     `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReconciliationRunsResponse) Reset() {
+	*x = ListReconciliationRunsResponse{}
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReconciliationRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReconciliationRunsResponse) ProtoMessage() {}
+
+func (x *ListReconciliationRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReconciliationRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListReconciliationRunsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListReconciliationRunsResponse) GetRuns() []*ReconciliationRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *ListReconciliationRunsResponse) GetPagination() *shared.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetReconciliationRunRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RunId          string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	IncludeMatched bool                   `protobuf:"varint,2,opt,name=include_matched,json=includeMatched,proto3" json:"include_matched,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetReconciliationRunRequest) Reset() {
+	*x = GetReconciliationRunRequest{}
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconciliationRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconciliationRunRequest) ProtoMessage() {}
+
+func (x *GetReconciliationRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconciliationRunRequest.ProtoReflect.Descriptor instead.
+func (*GetReconciliationRunRequest) Descriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetReconciliationRunRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *GetReconciliationRunRequest) GetIncludeMatched() bool {
+	if x != nil {
+		return x.IncludeMatched
+	}
+	return false
+}
+
+type GetReconciliationRunResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Run           *ReconciliationRun     `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	Items         []*ReconciliationItem  `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReconciliationRunResponse) Reset() {
+	*x = GetReconciliationRunResponse{}
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconciliationRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconciliationRunResponse) ProtoMessage() {}
+
+func (x *GetReconciliationRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_reconciliation_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconciliationRunResponse.ProtoReflect.Descriptor instead.
+func (*GetReconciliationRunResponse) Descriptor() ([]byte, []int) {
+	return file_payment_reconciliation_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetReconciliationRunResponse) GetRun() *ReconciliationRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *GetReconciliationRunResponse) GetItems() []*ReconciliationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_payment_reconciliation_messages_proto protoreflect.FileDescriptor
+
+const file_payment_reconciliation_messages_proto_rawDesc = "" +
+	"\n" +
+	"%payment/reconciliation_messages.proto\x12\x12shinkansen.payment\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13shared/common.proto\"\xe2\x03\n" +
+	"\x11ReconciliationRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1f\n" +
+	"\vfile_format\x18\x04 \x01(\tR\n" +
+	"fileFormat\x12=\n" +
+	"\fperiod_start\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\x12\x1f\n" +
+	"\vtotal_lines\x18\a \x01(\x05R\n" +
+	"totalLines\x12#\n" +
+	"\rmatched_count\x18\b \x01(\x05R\fmatchedCount\x12'\n" +
+	"\x0fexception_count\x18\t \x01(\x05R\x0eexceptionCount\x12?\n" +
+	"\x0esettled_amount\x18\n" +
+	" \x01(\v2\x18.shinkansen.common.MoneyR\rsettledAmount\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb4\x03\n" +
+	"\x12ReconciliationItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12@\n" +
+	"\x06result\x18\x02 \x01(\x0e2(.shinkansen.payment.ReconciliationResultR\x06result\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x04 \x01(\tR\tpaymentId\x12\x1f\n" +
+	"\vline_number\x18\x05 \x01(\x05R\n" +
+	"lineNumber\x12?\n" +
+	"\x0esettled_amount\x18\x06 \x01(\v2\x18.shinkansen.common.MoneyR\rsettledAmount\x12A\n" +
+	"\x0fexpected_amount\x18\a \x01(\v2\x18.shinkansen.common.MoneyR\x0eexpectedAmount\x12*\n" +
+	"\x03fee\x18\b \x01(\v2\x18.shinkansen.common.MoneyR\x03fee\x12\x1d\n" +
+	"\n" +
+	"settled_on\x18\t \x01(\tR\tsettledOn\x12\x16\n" +
+	"\x06detail\x18\n" +
+	" \x01(\tR\x06detail\"^\n" +
+	"\x1dListReconciliationRunsRequest\x12=\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"\x9a\x01\n" +
+	"\x1eListReconciliationRunsResponse\x129\n" +
+	"\x04runs\x18\x01 \x03(\v2%.shinkansen.payment.ReconciliationRunR\x04runs\x12=\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1d.shinkansen.common.PaginationR\n" +
+	"pagination\"]\n" +
+	"\x1bGetReconciliationRunRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12'\n" +
+	"\x0finclude_matched\x18\x02 \x01(\bR\x0eincludeMatched\"\x95\x01\n" +
+	"\x1cGetReconciliationRunResponse\x127\n" +
+	"\x03run\x18\x01 \x01(\v2%.shinkansen.payment.ReconciliationRunR\x03run\x12<\n" +
+	"\x05items\x18\x02 \x03(\v2&.shinkansen.payment.ReconciliationItemR\x05items*\xc1\x02\n" +
+	"\x14ReconciliationResult\x12%\n" +
+	"!RECONCILIATION_RESULT_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dRECONCILIATION_RESULT_MATCHED\x10\x01\x12)\n" +
+	"%RECONCILIATION_RESULT_AMOUNT_MISMATCH\x10\x02\x12)\n" +
+	"%RECONCILIATION_RESULT_MISSING_CAPTURE\x10\x03\x12,\n" +
+	"(RECONCILIATION_RESULT_MISSING_SETTLEMENT\x10\x04\x12+\n" +
+	"'RECONCILIATION_RESULT_ORPHAN_SETTLEMENT\x10\x05\x12.\n" +
+	"*RECONCILIATION_RESULT_DUPLICATE_SETTLEMENT\x10\x06B=Z;github.com/afasari/shinkansen-commerce/gen/proto/go/paymentb\x06proto3"
+
+var (
+	file_payment_reconciliation_messages_proto_rawDescOnce sync.Once
+	file_payment_reconciliation_messages_proto_rawDescData []byte
+)
+
+func file_payment_reconciliation_messages_proto_rawDescGZIP() []byte {
+	file_payment_reconciliation_messages_proto_rawDescOnce.Do(func() {
+		file_payment_reconciliation_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_reconciliation_messages_proto_rawDesc), len(file_payment_reconciliation_messages_proto_rawDesc)))
+	})
+	return file_payment_reconciliation_messages_proto_rawDescData
+}
+
+var file_payment_reconciliation_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_payment_reconciliation_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_payment_reconciliation_messages_proto_goTypes = []any{
+	(ReconciliationResult)(0),              // 0: shinkansen.payment.ReconciliationResult
+	(*ReconciliationRun)(nil),              // 1: shinkansen.payment.ReconciliationRun
+	(*ReconciliationItem)(nil),             // 2: shinkansen.payment.ReconciliationItem
+	(*ListReconciliationRunsRequest)(nil),  // 3: shinkansen.payment.ListReconciliationRunsRequest
+	(*ListReconciliationRunsResponse)(nil), // 4: shinkansen.payment.ListReconciliationRunsResponse
+	(*GetReconciliationRunRequest)(nil),    // 5: shinkansen.payment.GetReconciliationRunRequest
+	(*GetReconciliationRunResponse)(nil),   // 6: shinkansen.payment.GetReconciliationRunResponse
+	(*timestamppb.Timestamp)(nil),          // 7: google.protobuf.Timestamp
+	(*shared.Money)(nil),                   // 8: shinkansen.common.Money
+	(*shared.Pagination)(nil),              // 9: shinkansen.common.Pagination
+}
+var file_payment_reconciliation_messages_proto_depIdxs = []int32{
+	7,  // 0: shinkansen.payment.ReconciliationRun.period_start:type_name -> google.protobuf.Timestamp
+	7,  // 1: shinkansen.payment.ReconciliationRun.period_end:type_name -> google.protobuf.Timestamp
+	8,  // 2: shinkansen.payment.ReconciliationRun.settled_amount:type_name -> shinkansen.common.Money
+	7,  // 3: shinkansen.payment.ReconciliationRun.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: shinkansen.payment.ReconciliationItem.result:type_name -> shinkansen.payment.ReconciliationResult
+	8,  // 5: shinkansen.payment.ReconciliationItem.settled_amount:type_name -> shinkansen.common.Money
+	8,  // 6: shinkansen.payment.ReconciliationItem.expected_amount:type_name -> shinkansen.common.Money
+	8,  // 7: shinkansen.payment.ReconciliationItem.fee:type_name -> shinkansen.common.Money
+	9,  // 8: shinkansen.payment.ListReconciliationRunsRequest.pagination:type_name -> shinkansen.common.Pagination
+	1,  // 9: shinkansen.payment.ListReconciliationRunsResponse.runs:type_name -> shinkansen.payment.ReconciliationRun
+	9,  // 10: shinkansen.payment.ListReconciliationRunsResponse.pagination:type_name -> shinkansen.common.Pagination
+	1,  // 11: shinkansen.payment.GetReconciliationRunResponse.run:type_name -> shinkansen.payment.ReconciliationRun
+	2,  // 12: shinkansen.payment.GetReconciliationRunResponse.items:type_name -> shinkansen.payment.ReconciliationItem
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_payment_reconciliation_messages_proto_init() }
+func file_payment_reconciliation_messages_proto_init() {
+	if File_payment_reconciliation_messages_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_reconciliation_messages_proto_rawDesc), len(file_payment_reconciliation_messages_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payment_reconciliation_messages_proto_goTypes,
+		DependencyIndexes: file_payment_reconciliation_messages_proto_depIdxs,
+		EnumInfos:         file_payment_reconciliation_messages_proto_enumTypes,
+		MessageInfos:      file_payment_reconciliation_messages_proto_msgTypes,
+	}.Build()
+	File_payment_reconciliation_messages_proto = out.File
+	file_payment_reconciliation_messages_proto_goTypes = nil
+	file_payment_reconciliation_messages_proto_depIdxs = nil
+}
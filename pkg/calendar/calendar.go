@@ -0,0 +1,176 @@
+// Package calendar works out business days in Japan, skipping weekends,
+// national holidays and closures such as the New Year break, and the
+// deadlines and delivery dates that are counted in them.
+package calendar
+
+import (
+	"time"
+)
+
+// Tokyo is Japan Standard Time, which has no daylight saving
+var Tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// maxClosedDays bounds the search for a business day, so a calendar closed
+// all year round cannot loop forever
+const maxClosedDays = 366
+
+// MonthDay is a day of the year
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+// Closure is a break taken every year, from one day to another inclusive.
+// It may run over the new year.
+type Closure struct {
+	Name string
+	From MonthDay
+	To   MonthDay
+}
+
+var (
+	// NewYear is the 年末年始 break of warehouses and carriers
+	NewYear = Closure{Name: "年末年始", From: MonthDay{time.December, 29}, To: MonthDay{time.January, 3}}
+	// GoldenWeek runs from 昭和の日 to こどもの日
+	GoldenWeek = Closure{Name: "ゴールデンウィーク", From: MonthDay{time.April, 29}, To: MonthDay{time.May, 5}}
+	// Obon is the お盆 break in mid-August
+	Obon = Closure{Name: "お盆", From: MonthDay{time.August, 13}, To: MonthDay{time.August, 16}}
+	// BankNewYear is when banks close over the new year (銀行休業日)
+	BankNewYear = Closure{Name: "年末年始", From: MonthDay{time.December, 31}, To: MonthDay{time.January, 3}}
+)
+
+// CarrierClosures are the breaks that hold up shipping
+var CarrierClosures = []Closure{NewYear, GoldenWeek, Obon}
+
+// includes reports whether the closure covers the date
+func (c Closure) includes(date time.Time) bool {
+	day := MonthDay{date.Month(), date.Day()}
+	if c.From.before(c.To) || c.From == c.To {
+		return !day.before(c.From) && !c.To.before(day)
+	}
+	return !day.before(c.From) || !c.To.before(day)
+}
+
+func (d MonthDay) before(other MonthDay) bool {
+	if d.Month != other.Month {
+		return d.Month < other.Month
+	}
+	return d.Day < other.Day
+}
+
+// ClosureDay is a single day closed on top of the yearly closures, such as
+// a stocktaking day
+type ClosureDay struct {
+	Date time.Time
+	Name string
+}
+
+// Calendar tells business days from closed days. Saturdays, Sundays and
+// national holidays are always closed. A Calendar is not changed once
+// made, so it is safe to share.
+type Calendar struct {
+	closures []Closure
+	days     map[time.Time]string
+}
+
+// New returns a calendar that is also closed for the given yearly closures
+func New(closures ...Closure) *Calendar {
+	return &Calendar{
+		closures: append([]Closure(nil), closures...),
+		days:     map[time.Time]string{},
+	}
+}
+
+// WithClosureDays returns a copy of the calendar that is also closed on the
+// given days
+func (c *Calendar) WithClosureDays(days ...ClosureDay) *Calendar {
+	copied := &Calendar{
+		closures: c.closures,
+		days:     make(map[time.Time]string, len(c.days)+len(days)),
+	}
+	for date, name := range c.days {
+		copied.days[date] = name
+	}
+	for _, day := range days {
+		copied.days[Date(day.Date)] = day.Name
+	}
+	return copied
+}
+
+// Closed reports why the day t falls on in Japan is not a business day
+func (c *Calendar) Closed(t time.Time) (string, bool) {
+	date := Date(t)
+	if name, ok := c.days[date]; ok {
+		return name, true
+	}
+	if name, ok := HolidayName(date); ok {
+		return name, true
+	}
+	for _, closure := range c.closures {
+		if closure.includes(date) {
+			return closure.Name, true
+		}
+	}
+	switch date.Weekday() {
+	case time.Saturday:
+		return "土曜日", true
+	case time.Sunday:
+		return "日曜日", true
+	}
+	return "", false
+}
+
+// IsBusinessDay reports whether the day t falls on in Japan is open
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	_, closed := c.Closed(t)
+	return !closed
+}
+
+// NextBusinessDay returns the first business day on or after the day t
+// falls on in Japan
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	date := Date(t)
+	for i := 0; i < maxClosedDays && !c.IsBusinessDay(date); i++ {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// AddBusinessDays returns the business day n business days after the day t
+// falls on in Japan. A closed day counts from the business day after it.
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	date := c.NextBusinessDay(t)
+	for ; n > 0; n-- {
+		date = c.NextBusinessDay(date.AddDate(0, 0, 1))
+	}
+	return date
+}
+
+// ShipDate returns the business day work on something received at t is
+// done: the same day when that is a business day and t is before the
+// cutoff, given as time since midnight JST, or else the next business day.
+func (c *Calendar) ShipDate(t time.Time, cutoff time.Duration) time.Time {
+	date := Date(t)
+	if c.IsBusinessDay(date) && t.Before(date.Add(cutoff)) {
+		return date
+	}
+	return c.NextBusinessDay(date.AddDate(0, 0, 1))
+}
+
+// PromisedDate returns the day an order placed at t arrives when it leaves
+// on its ShipDate and takes leadDays business days on the way
+func (c *Calendar) PromisedDate(t time.Time, cutoff time.Duration, leadDays int) time.Time {
+	return c.AddBusinessDays(c.ShipDate(t, cutoff), leadDays)
+}
+
+// Date returns the day t falls on in Japan, as midnight JST
+func Date(t time.Time) time.Time {
+	y, m, d := t.In(Tokyo).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, Tokyo)
+}
+
+// EndOfDay returns the end of the day t falls on in Japan, which is
+// midnight JST at the start of the next day
+func EndOfDay(t time.Time) time.Time {
+	return Date(t).AddDate(0, 0, 1)
+}
@@ -0,0 +1,184 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, Tokyo)
+}
+
+func TestHolidays(t *testing.T) {
+	names := func(year int) map[string]string {
+		got := make(map[string]string)
+		for _, h := range Holidays(year) {
+			got[h.Date.Format("01-02")] = h.Name
+		}
+		return got
+	}
+
+	assert.Equal(t, map[string]string{
+		"01-01": "元日",
+		"01-12": "成人の日",
+		"02-11": "建国記念の日",
+		"02-23": "天皇誕生日",
+		"03-20": "春分の日",
+		"04-29": "昭和の日",
+		"05-03": "憲法記念日",
+		"05-04": "みどりの日",
+		"05-05": "こどもの日",
+		"05-06": "振替休日",
+		"07-20": "海の日",
+		"08-11": "山の日",
+		"09-21": "敬老の日",
+		"09-22": "国民の休日",
+		"09-23": "秋分の日",
+		"10-12": "スポーツの日",
+		"11-03": "文化の日",
+		"11-23": "勤労感謝の日",
+	}, names(2026))
+
+	t.Run("substitute holidays", func(t *testing.T) {
+		got := names(2025)
+		assert.Equal(t, "振替休日", got["02-24"])
+		assert.Equal(t, "振替休日", got["05-06"])
+		assert.Equal(t, "振替休日", got["11-24"])
+		assert.Len(t, got, 19)
+	})
+
+	t.Run("olympic years", func(t *testing.T) {
+		got := names(2021)
+		assert.Equal(t, "海の日", got["07-22"])
+		assert.Equal(t, "スポーツの日", got["07-23"])
+		assert.Equal(t, "山の日", got["08-08"])
+		assert.Equal(t, "振替休日", got["08-09"])
+		assert.NotContains(t, got, "10-11")
+	})
+
+	t.Run("in date order", func(t *testing.T) {
+		holidays := Holidays(2027)
+		for i := 1; i < len(holidays); i++ {
+			assert.True(t, holidays[i-1].Date.Before(holidays[i].Date))
+		}
+	})
+}
+
+func TestHolidayName(t *testing.T) {
+	// 15:30 UTC on the 2nd is already the 3rd in Japan
+	name, ok := HolidayName(time.Date(2026, time.November, 2, 15, 30, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "文化の日", name)
+
+	_, ok = HolidayName(time.Date(2026, time.November, 2, 14, 30, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestCalendar_Closed(t *testing.T) {
+	cal := New(CarrierClosures...).WithClosureDays(ClosureDay{Date: day(2026, time.October, 30), Name: "棚卸し"})
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		reason string
+	}{
+		{"business day", day(2026, time.October, 29), ""},
+		{"saturday", day(2026, time.October, 31), "土曜日"},
+		{"sunday", day(2026, time.November, 1), "日曜日"},
+		{"holiday", day(2026, time.November, 3), "文化の日"},
+		{"closure day", day(2026, time.October, 30), "棚卸し"},
+		{"new year before", day(2026, time.December, 29), "年末年始"},
+		{"new year after", day(2027, time.January, 1), "元日"},
+		{"new year end", day(2027, time.January, 3), "年末年始"},
+		{"after new year", day(2027, time.January, 4), ""},
+		{"golden week", day(2026, time.April, 30), "ゴールデンウィーク"},
+		{"obon", day(2026, time.August, 13), "お盆"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, closed := cal.Closed(tt.date)
+			assert.Equal(t, tt.reason != "", closed)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	t.Run("closure days do not change the original", func(t *testing.T) {
+		assert.True(t, New(CarrierClosures...).IsBusinessDay(day(2026, time.October, 30)))
+	})
+}
+
+func TestCalendar_AddBusinessDays(t *testing.T) {
+	cal := New(CarrierClosures...)
+
+	assert.Equal(t, day(2026, time.October, 20), cal.AddBusinessDays(day(2026, time.October, 19), 1))
+	// Friday plus one is Monday
+	assert.Equal(t, day(2026, time.October, 26), cal.AddBusinessDays(day(2026, time.October, 23), 1))
+	// A Saturday counts from Monday
+	assert.Equal(t, day(2026, time.October, 26), cal.AddBusinessDays(day(2026, time.October, 24), 0))
+	assert.Equal(t, day(2026, time.October, 27), cal.AddBusinessDays(day(2026, time.October, 24), 1))
+	// Over the new year break
+	assert.Equal(t, day(2027, time.January, 4), cal.AddBusinessDays(day(2026, time.December, 25), 2))
+}
+
+func TestCalendar_PromisedDate(t *testing.T) {
+	cal := New(CarrierClosures...)
+	cutoff := 15 * time.Hour
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		leadDays int
+		shipDate time.Time
+		promised time.Time
+	}{
+		{
+			name:     "before cutoff",
+			at:       time.Date(2026, time.October, 20, 14, 59, 0, 0, Tokyo),
+			leadDays: 1,
+			shipDate: day(2026, time.October, 20),
+			promised: day(2026, time.October, 21),
+		},
+		{
+			name:     "after cutoff",
+			at:       time.Date(2026, time.October, 20, 15, 0, 0, 0, Tokyo),
+			leadDays: 1,
+			shipDate: day(2026, time.October, 21),
+			promised: day(2026, time.October, 22),
+		},
+		{
+			name:     "weekend",
+			at:       time.Date(2026, time.October, 24, 9, 0, 0, 0, Tokyo),
+			leadDays: 2,
+			shipDate: day(2026, time.October, 26),
+			promised: day(2026, time.October, 28),
+		},
+		{
+			name:     "golden week",
+			at:       time.Date(2026, time.April, 28, 16, 0, 0, 0, Tokyo),
+			leadDays: 1,
+			shipDate: day(2026, time.May, 7),
+			promised: day(2026, time.May, 8),
+		},
+		{
+			name:     "order time in UTC",
+			at:       time.Date(2026, time.October, 20, 5, 0, 0, 0, time.UTC),
+			leadDays: 3,
+			shipDate: day(2026, time.October, 20),
+			promised: day(2026, time.October, 23),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.shipDate, cal.ShipDate(tt.at, cutoff))
+			assert.Equal(t, tt.promised, cal.PromisedDate(tt.at, cutoff, tt.leadDays))
+		})
+	}
+}
+
+func TestEndOfDay(t *testing.T) {
+	at := time.Date(2026, time.October, 20, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, day(2026, time.October, 22), EndOfDay(at))
+	assert.True(t, EndOfDay(at).Equal(time.Date(2026, time.October, 21, 15, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,137 @@
+package calendar
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Holiday is a national holiday (国民の祝日)
+type Holiday struct {
+	// Midnight JST
+	Date time.Time
+	Name string
+}
+
+// holidayCache holds each year's holidays by date once worked out
+var holidayCache sync.Map // map[int]map[time.Time]string
+
+// Holidays returns the national holidays of a year in date order, including
+// substitute holidays (振替休日) and citizens' holidays (国民の休日). They
+// follow the Act on National Holidays as it stands since 2020; the
+// equinoxes are worked out for 1980 to 2099.
+func Holidays(year int) []Holiday {
+	byDate := holidaysOf(year)
+	holidays := make([]Holiday, 0, len(byDate))
+	for date, name := range byDate {
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// HolidayName returns the name of the national holiday t falls on in Japan
+func HolidayName(t time.Time) (string, bool) {
+	date := Date(t)
+	name, ok := holidaysOf(date.Year())[date]
+	return name, ok
+}
+
+func holidaysOf(year int) map[time.Time]string {
+	if cached, ok := holidayCache.Load(year); ok {
+		return cached.(map[time.Time]string)
+	}
+	holidays := computeHolidays(year)
+	holidayCache.Store(year, holidays)
+	return holidays
+}
+
+func computeHolidays(year int) map[time.Time]string {
+	holidays := make(map[time.Time]string)
+	add := func(month time.Month, day int, name string) {
+		holidays[time.Date(year, month, day, 0, 0, 0, 0, Tokyo)] = name
+	}
+
+	add(time.January, 1, "元日")
+	add(time.January, nthMonday(year, time.January, 2), "成人の日")
+	add(time.February, 11, "建国記念の日")
+	add(time.February, 23, "天皇誕生日")
+	add(time.March, vernalEquinoxDay(year), "春分の日")
+	add(time.April, 29, "昭和の日")
+	add(time.May, 3, "憲法記念日")
+	add(time.May, 4, "みどりの日")
+	add(time.May, 5, "こどもの日")
+	add(time.September, nthMonday(year, time.September, 3), "敬老の日")
+	add(time.September, autumnalEquinoxDay(year), "秋分の日")
+	add(time.November, 3, "文化の日")
+	add(time.November, 23, "勤労感謝の日")
+
+	// The Tokyo Olympics moved 海の日, スポーツの日 and 山の日 in 2020
+	// and 2021
+	switch year {
+	case 2020:
+		add(time.July, 23, "海の日")
+		add(time.July, 24, "スポーツの日")
+		add(time.August, 10, "山の日")
+	case 2021:
+		add(time.July, 22, "海の日")
+		add(time.July, 23, "スポーツの日")
+		add(time.August, 8, "山の日")
+	default:
+		add(time.July, nthMonday(year, time.July, 3), "海の日")
+		add(time.October, nthMonday(year, time.October, 2), "スポーツの日")
+		add(time.August, 11, "山の日")
+	}
+
+	// A day between two holidays is a holiday too
+	start := time.Date(year, time.January, 2, 0, 0, 0, 0, Tokyo)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, Tokyo)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		_, before := holidays[day.AddDate(0, 0, -1)]
+		_, after := holidays[day.AddDate(0, 0, 1)]
+		_, holiday := holidays[day]
+		if before && after && !holiday && day.Weekday() != time.Sunday {
+			holidays[day] = "国民の休日"
+		}
+	}
+
+	// A holiday on a Sunday moves to the next day that is not a holiday
+	for day, name := range holidays {
+		if day.Weekday() != time.Sunday || name == "振替休日" {
+			continue
+		}
+		substitute := day.AddDate(0, 0, 1)
+		for {
+			if _, ok := holidays[substitute]; !ok {
+				break
+			}
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		if substitute.Year() == year {
+			holidays[substitute] = "振替休日"
+		}
+	}
+	return holidays
+}
+
+// nthMonday returns the day of the month of its nth Monday
+func nthMonday(year int, month time.Month, n int) int {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, Tokyo).Weekday()
+	offset := (int(time.Monday) - int(first) + 7) % 7
+	return 1 + offset + (n-1)*7
+}
+
+// vernalEquinoxDay is the March day of 春分の日
+func vernalEquinoxDay(year int) int {
+	return equinoxDay(year, 20.8431)
+}
+
+// autumnalEquinoxDay is the September day of 秋分の日
+func autumnalEquinoxDay(year int) int {
+	return equinoxDay(year, 23.2488)
+}
+
+func equinoxDay(year int, base float64) int {
+	y := year - 1980
+	return int(base+0.242194*float64(y)) - y/4
+}
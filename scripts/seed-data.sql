@@ -5,47 +5,44 @@
 -- Prerequisites: all migrations must be applied (make db-migrate)
 
 -- ============================================================
--- 1. Delivery Zone (Kanto region)
+-- 1. Delivery Zones (Kanto region)
 -- ============================================================
 INSERT INTO delivery.delivery_zones (id, name, postal_codes, prefectures, delivery_days)
 VALUES (
     'a0000000-0000-0000-0000-000000000001',
     'Kanto Region',
-    ARRAY['100-0000','150-0000','160-0000','170-0000','180-0000','200-0000','210-0000','220-0000','230-0000','240-0000','250-0000','260-0000','270-0000','280-0000','290-0000','300-0000','310-0000','350-0000'],
+    ARRAY[]::TEXT[],
     ARRAY['東京都','神奈川県','埼玉県','千葉県','茨城県','栃木県','群馬県'],
     1
 ) ON CONFLICT (id) DO NOTHING;
 
+-- Tokyo's Izu and Ogasawara islands (100-01xx to 100-17xx) take longer and
+-- have no time slots
+INSERT INTO delivery.delivery_zones (id, name, postal_codes, prefectures, delivery_days, remote_island)
+VALUES (
+    'a0000000-0000-0000-0000-000000000002',
+    'Izu and Ogasawara Islands',
+    ARRAY['100-0100~100-1799'],
+    ARRAY['東京都'],
+    4,
+    true
+) ON CONFLICT (id) DO NOTHING;
+
 -- ============================================================
--- 2. Delivery Slots (today + 2 days, 4 slots per day)
+-- 2. Delivery Slot Templates (every day, all five time windows)
+--    delivery-service generates the slots from these
 -- ============================================================
-INSERT INTO delivery.delivery_slots (id, delivery_zone_id, start_time, end_time, capacity, reserved, date)
-VALUES
-    ('b0000000-0000-0000-0000-000000000001', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '8 hours',  CURRENT_DATE + INTERVAL '10 hours', 10, 0, CURRENT_DATE),
-    ('b0000000-0000-0000-0000-000000000002', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '10 hours', CURRENT_DATE + INTERVAL '12 hours', 10, 0, CURRENT_DATE),
-    ('b0000000-0000-0000-0000-000000000003', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '14 hours', CURRENT_DATE + INTERVAL '16 hours', 10, 0, CURRENT_DATE),
-    ('b0000000-0000-0000-0000-000000000004', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '16 hours', CURRENT_DATE + INTERVAL '18 hours', 10, 0, CURRENT_DATE),
-    ('b0000000-0000-0000-0000-000000000005', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '1 day 8 hours',  CURRENT_DATE + INTERVAL '1 day 10 hours',  10, 0, CURRENT_DATE + INTERVAL '1 day'),
-    ('b0000000-0000-0000-0000-000000000006', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '1 day 10 hours', CURRENT_DATE + INTERVAL '1 day 12 hours', 10, 0, CURRENT_DATE + INTERVAL '1 day'),
-    ('b0000000-0000-0000-0000-000000000007', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '1 day 14 hours', CURRENT_DATE + INTERVAL '1 day 16 hours', 10, 0, CURRENT_DATE + INTERVAL '1 day'),
-    ('b0000000-0000-0000-0000-000000000008', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '1 day 16 hours', CURRENT_DATE + INTERVAL '1 day 18 hours', 10, 0, CURRENT_DATE + INTERVAL '1 day'),
-    ('b0000000-0000-0000-0000-000000000009', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '2 days 8 hours',  CURRENT_DATE + INTERVAL '2 days 10 hours',  10, 0, CURRENT_DATE + INTERVAL '2 days'),
-    ('b0000000-0000-0000-0000-000000000010', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '2 days 10 hours', CURRENT_DATE + INTERVAL '2 days 12 hours', 10, 0, CURRENT_DATE + INTERVAL '2 days'),
-    ('b0000000-0000-0000-0000-000000000011', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '2 days 14 hours', CURRENT_DATE + INTERVAL '2 days 16 hours', 10, 0, CURRENT_DATE + INTERVAL '2 days'),
-    ('b0000000-0000-0000-0000-000000000012', 'a0000000-0000-0000-0000-000000000001',
-     CURRENT_DATE + INTERVAL '2 days 16 hours', CURRENT_DATE + INTERVAL '2 days 18 hours', 10, 0, CURRENT_DATE + INTERVAL '2 days')
-ON CONFLICT (id) DO NOTHING;
+INSERT INTO delivery.slot_templates (delivery_zone_id, weekday, time_window, capacity, cutoff_days_before, cutoff_time)
+SELECT 'a0000000-0000-0000-0000-000000000001'::uuid, weekday, time_window, 10, 1, '17:00'::time
+FROM generate_series(0, 6) AS weekday
+CROSS JOIN unnest(ARRAY[
+    'DELIVERY_TIME_WINDOW_MORNING',
+    'DELIVERY_TIME_WINDOW_14_16',
+    'DELIVERY_TIME_WINDOW_16_18',
+    'DELIVERY_TIME_WINDOW_18_20',
+    'DELIVERY_TIME_WINDOW_19_21'
+]) AS time_window
+ON CONFLICT (delivery_zone_id, weekday, time_window) DO NOTHING;
 
 -- ============================================================
 -- 3. Product Categories
@@ -107,8 +104,8 @@ ON CONFLICT (id) DO NOTHING;
 -- ============================================================
 -- Summary
 -- ============================================================
--- Delivery Zone:  Kanto Region (a0000000-...)
--- Delivery Slots: 12 slots across 3 days (b0000000-...)
+-- Delivery Zones: Kanto Region and the Izu and Ogasawara Islands (a0000000-...)
+-- Delivery Slot Templates: 35 for Kanto (7 days x 5 time windows)
 -- Categories:     Electronics, Clothing, Food & Drink (c0000000-...)
 -- Products:       3 products (d0000000-...) at ¥2500–¥5800
 -- Stock Items:    3 items in warehouse f0000000-... with 30–100 units each
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	deliveryv1 "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/config"
 	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
 	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/service"
@@ -17,6 +20,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -50,6 +54,64 @@ func main() {
 
 	queries := db.NewQueries(dbpool)
 	deliveryService := service.NewDeliveryService(queries, logger)
+	deliveryService.SetSlotHorizonDays(cfg.SlotHorizonDays)
+	deliveryService.SetReservationHoldTTL(time.Duration(cfg.SlotHoldTTL) * time.Second)
+	deliveryService.SetWarehouseCutoff(cfg.WarehouseCutoff)
+
+	paymentConn, err := grpc.NewClient(cfg.PaymentServiceGRPCAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Fatal("Failed to dial payment service", zap.Error(err))
+	}
+	defer func() { _ = paymentConn.Close() }()
+	deliveryService.SetPaymentClient(paymentpb.NewPaymentServiceClient(paymentConn))
+
+	carriers, err := newCarrierRegistry(cfg, logger)
+	if err != nil {
+		logger.Fatal("Failed to configure carriers", zap.Error(err))
+	}
+	deliveryService.SetCarriers(carriers, service.CarrierAddress{
+		Name:         cfg.Shipper.Name,
+		Phone:        cfg.Shipper.Phone,
+		PostalCode:   cfg.Shipper.PostalCode,
+		Prefecture:   cfg.Shipper.Prefecture,
+		City:         cfg.Shipper.City,
+		AddressLine1: cfg.Shipper.AddressLine1,
+		AddressLine2: cfg.Shipper.AddressLine2,
+	})
+	deliveryService.SetShippingFees(service.ShippingFees{
+		FreeShippingThreshold: cfg.Shipping.FreeShippingThreshold,
+		TimeWindowFee:         cfg.Shipping.TimeWindowFee,
+		ExpressFee:            cfg.Shipping.ExpressFee,
+		RemoteIslandFee:       cfg.Shipping.RemoteIslandFee,
+	})
+
+	if cfg.KafkaBrokers != "" {
+		publisher, err := service.NewShipmentEventProducer(strings.Split(cfg.KafkaBrokers, ","), cfg.ShipmentEventsTopic, logger)
+		if err != nil {
+			logger.Warn("Failed to create shipment event publisher, continuing without", zap.Error(err))
+		} else {
+			defer func() { _ = publisher.Close() }()
+			deliveryService.SetEventPublisher(publisher)
+		}
+	}
+
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	defer stopScheduler()
+	slotScheduler := service.NewSlotScheduler(deliveryService, logger)
+	slotScheduler.StartPeriodicRollForward(schedulerCtx, time.Duration(cfg.SlotGenerationInterval)*time.Second)
+
+	holdSweeper := service.NewReservationHoldSweeper(deliveryService, logger)
+	holdSweeper.StartPeriodicSweep(schedulerCtx, time.Duration(cfg.SlotHoldSweepInterval)*time.Second)
+
+	trackingInterval := time.Duration(cfg.CarrierTrackingInterval) * time.Second
+	carrierTracker := service.NewCarrierTracker(deliveryService, trackingInterval, logger)
+	carrierTracker.StartPeriodicPoll(schedulerCtx, trackingInterval)
+
+	pickupSweeper := service.NewPickupExpirySweeper(deliveryService, logger)
+	pickupSweeper.StartPeriodicSweep(schedulerCtx, time.Duration(cfg.PickupExpirySweepInterval)*time.Second)
 
 	server := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	deliveryv1.RegisterDeliveryServiceServer(server, deliveryService)
@@ -88,3 +150,50 @@ func main() {
 	server.GracefulStop()
 	logger.Info("gRPC server stopped")
 }
+
+// newCarrierRegistry configures the Yamato, Sagawa and Japan Post adapters,
+// pointing them at a local fake carrier server when one is configured
+func newCarrierRegistry(cfg *config.Config, logger *zap.Logger) (*service.CarrierRegistry, error) {
+	yamato := carrierAPIConfig(cfg.Yamato)
+	sagawa := carrierAPIConfig(cfg.Sagawa)
+	japanPost := carrierAPIConfig(cfg.JapanPost)
+
+	if cfg.FakeCarrierServerAddress != "" {
+		fake := service.NewFakeCarrierServer(time.Hour)
+		go func() {
+			logger.Warn("Starting fake carrier server", zap.String("address", cfg.FakeCarrierServerAddress))
+			if err := http.ListenAndServe(cfg.FakeCarrierServerAddress, fake); err != nil {
+				logger.Error("Fake carrier server failed", zap.Error(err))
+			}
+		}()
+
+		host := cfg.FakeCarrierServerAddress
+		if strings.HasPrefix(host, ":") {
+			host = "localhost" + host
+		}
+		yamato.BaseURL = "http://" + host + "/yamato"
+		sagawa.BaseURL = "http://" + host + "/sagawa"
+		japanPost.BaseURL = "http://" + host + "/japan-post"
+	}
+
+	registry, err := service.NewCarrierRegistry(cfg.DefaultCarrier,
+		service.NewYamatoCarrier(yamato),
+		service.NewSagawaCarrier(sagawa),
+		service.NewJapanPostCarrier(japanPost),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.SetServiceLevelCarriers(cfg.ServiceLevelCarriers); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+func carrierAPIConfig(c config.CarrierConfig) service.CarrierAPIConfig {
+	return service.CarrierAPIConfig{
+		BaseURL:      c.BaseURL,
+		APIKey:       c.APIKey,
+		CustomerCode: c.CustomerCode,
+	}
+}
@@ -2,9 +2,11 @@ package db
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -30,6 +32,20 @@ func (db *DB) Close() error {
 	return nil
 }
 
+type DeliveryZone struct {
+	ID            uuid.UUID
+	Name          string
+	PostalCodes   []string
+	Prefectures   []string
+	DeliveryDays  int
+	Undeliverable bool
+	RemoteIsland  bool
+	// Carrier code for shipments to the zone; nil uses the default
+	Carrier   *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type DeliverySlot struct {
 	ID             uuid.UUID
 	DeliveryZoneID uuid.UUID
@@ -39,8 +55,42 @@ type DeliverySlot struct {
 	Reserved       int
 	Available      int
 	Date           time.Time
+	// Set for slots generated from a template
+	TemplateID *uuid.UUID
+	TimeWindow *string
+	CutoffAt   *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type SlotTemplate struct {
+	ID               uuid.UUID
+	DeliveryZoneID   uuid.UUID
+	Weekday          int
+	TimeWindow       string
+	Capacity         int
+	CutoffDaysBefore int
+	// HH:MM, JST
+	CutoffTime string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type SlotBlackout struct {
+	ID uuid.UUID
+	// nil blacks out the date in every zone
+	DeliveryZoneID *uuid.UUID
+	Date           time.Time
+	Reason         string
 	CreatedAt      time.Time
-	UpdatedAt      time.Time
+}
+
+// ClosureDay is a day the warehouse does not ship
+type ClosureDay struct {
+	ID        uuid.UUID
+	Date      time.Time
+	Reason    string
+	CreatedAt time.Time
 }
 
 type Shipment struct {
@@ -50,27 +100,214 @@ type Shipment struct {
 	Status              string
 	EstimatedDeliveryAt *time.Time
 	ActualDeliveryAt    *time.Time
-	Carrier             string
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+	// Carrier code; empty until dispatched
+	Carrier string
+	// Cash to collect on delivery; nil unless the order is paid cash on delivery
+	CODAmountMinor       *int64
+	CODCurrency          *string
+	CollectedAmountMinor *int64
+	ServiceLevel         string
+	// Set once dispatched to a carrier
+	Recipient    *Address
+	DispatchedAt *time.Time
+	// Set for shipments collected at a pickup point
+	PickupPointID *uuid.UUID
+	PickupCode    *string
+	// Set once the shipment is ready for pickup
+	PickupDeadlineAt *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Address is a shipment's recipient, stored as JSONB
+type Address struct {
+	Name         string `json:"name"`
+	Phone        string `json:"phone"`
+	PostalCode   string `json:"postal_code"`
+	Prefecture   string `json:"prefecture"`
+	City         string `json:"city"`
+	AddressLine1 string `json:"address_line1"`
+	AddressLine2 string `json:"address_line2,omitempty"`
+}
+
+// PickupPoint is a konbini counter or parcel locker shipments can be sent to
+// for collection
+type PickupPoint struct {
+	ID           uuid.UUID
+	Code         string
+	Name         string
+	Type         string
+	Operator     string
+	PostalCode   string
+	Prefecture   string
+	City         string
+	AddressLine1 string
+	AddressLine2 string
+	OpeningHours string
+	HoldDays     int
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type TrackingEvent struct {
+	ID          uuid.UUID
+	ShipmentID  uuid.UUID
+	Status      string
+	Location    string
+	Description string
+	OccurredAt  time.Time
+	CreatedAt   time.Time
 }
 
 type DeliveryReservation struct {
-	ID        uuid.UUID
-	SlotID    uuid.UUID
-	OrderID   uuid.UUID
-	CreatedAt time.Time
+	ID      uuid.UUID
+	SlotID  uuid.UUID
+	OrderID uuid.UUID
+	Status  string
+	// Set while the reservation is held
+	ExpiresAt   *time.Time
+	ConfirmedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// ErrSlotUnavailable is returned when a slot is full or does not exist
+var ErrSlotUnavailable = errors.New("delivery slot unavailable")
+
+// ErrReservationConfirmed is returned when an order whose reservation is
+// already confirmed asks for a different slot
+var ErrReservationConfirmed = errors.New("delivery slot reservation already confirmed")
+
+type CreateSlotTemplateParams struct {
+	DeliveryZoneID   uuid.UUID
+	Weekday          int
+	TimeWindow       string
+	Capacity         int
+	CutoffDaysBefore int
+	CutoffTime       string
+}
+
+type UpdateSlotTemplateParams struct {
+	ID               uuid.UUID
+	Weekday          int
+	TimeWindow       string
+	Capacity         int
+	CutoffDaysBefore int
+	CutoffTime       string
+}
+
+type CreateSlotBlackoutParams struct {
+	DeliveryZoneID *uuid.UUID
+	Date           time.Time
+	Reason         string
+}
+
+type CreateTrackingEventParams struct {
+	ShipmentID  uuid.UUID
+	Status      string
+	Location    string
+	Description string
+	// Nil records the event as happening now
+	OccurredAt *time.Time
+}
+
+type DispatchShipmentParams struct {
+	ID                  uuid.UUID
+	Carrier             string
+	TrackingNumber      string
+	ServiceLevel        string
+	Recipient           Address
+	EstimatedDeliveryAt *time.Time
+	// Set when the shipment is sent to a pickup point
+	PickupPointID *uuid.UUID
+	PickupCode    *string
+	// Recorded in the tracking timeline
+	Description string
+}
+
+type CreatePickupPointParams struct {
+	Code         string
+	Name         string
+	Type         string
+	Operator     string
+	PostalCode   string
+	Prefecture   string
+	City         string
+	AddressLine1 string
+	AddressLine2 string
+	OpeningHours string
+	HoldDays     int
+	Active       bool
+}
+
+type UpdatePickupPointParams struct {
+	ID           uuid.UUID
+	Code         string
+	Name         string
+	Type         string
+	Operator     string
+	PostalCode   string
+	Prefecture   string
+	City         string
+	AddressLine1 string
+	AddressLine2 string
+	OpeningHours string
+	HoldDays     int
+	Active       bool
+}
+
+type CreateDeliverySlotParams struct {
+	DeliveryZoneID uuid.UUID
+	TemplateID     uuid.UUID
+	TimeWindow     string
+	StartTime      time.Time
+	EndTime        time.Time
+	Capacity       int
+	Date           time.Time
+	CutoffAt       time.Time
 }
 
 type Querier interface {
+	ListDeliveryZonesForPrefecture(ctx context.Context, prefecture string) ([]DeliveryZone, error)
 	GetDeliverySlots(ctx context.Context, deliveryZoneID uuid.UUID, date time.Time) ([]DeliverySlot, error)
 	GetDeliverySlot(ctx context.Context, id uuid.UUID) (DeliverySlot, error)
-	ReserveDeliverySlot(ctx context.Context, slotID, orderID uuid.UUID) (uuid.UUID, error)
+	CreateDeliverySlots(ctx context.Context, slots []CreateDeliverySlotParams) (int, error)
+	DeleteUnreservedTemplateSlots(ctx context.Context, templateID uuid.UUID, after time.Time) (int, error)
+	DeleteUnreservedSlotsOnDate(ctx context.Context, deliveryZoneID *uuid.UUID, date time.Time) (removed int, reserved int, err error)
+	ListSlotTemplates(ctx context.Context, deliveryZoneID uuid.UUID) ([]SlotTemplate, error)
+	ListAllSlotTemplates(ctx context.Context) ([]SlotTemplate, error)
+	GetSlotTemplate(ctx context.Context, id uuid.UUID) (SlotTemplate, error)
+	CreateSlotTemplate(ctx context.Context, arg CreateSlotTemplateParams) (SlotTemplate, error)
+	UpdateSlotTemplate(ctx context.Context, arg UpdateSlotTemplateParams) (SlotTemplate, error)
+	DeleteSlotTemplate(ctx context.Context, id uuid.UUID) (SlotTemplate, error)
+	ListSlotBlackouts(ctx context.Context, from time.Time) ([]SlotBlackout, error)
+	CreateSlotBlackout(ctx context.Context, arg CreateSlotBlackoutParams) (SlotBlackout, error)
+	DeleteSlotBlackout(ctx context.Context, id uuid.UUID) (SlotBlackout, error)
+	ListClosureDays(ctx context.Context, from time.Time) ([]ClosureDay, error)
+	CreateClosureDay(ctx context.Context, date time.Time, reason string) (ClosureDay, error)
+	DeleteClosureDay(ctx context.Context, id uuid.UUID) (ClosureDay, error)
+	ReserveDeliverySlot(ctx context.Context, slotID, orderID uuid.UUID, holdTTL time.Duration) (DeliveryReservation, error)
+	ConfirmReservation(ctx context.Context, orderID uuid.UUID) (DeliveryReservation, error)
+	ReleaseExpiredHolds(ctx context.Context, limit int) ([]DeliveryReservation, error)
 	GetShipmentByOrderID(ctx context.Context, orderID uuid.UUID) (Shipment, error)
 	GetShipment(ctx context.Context, id uuid.UUID) (Shipment, error)
 	CreateShipment(ctx context.Context, orderID uuid.UUID) (uuid.UUID, error)
-	UpdateShipmentStatus(ctx context.Context, id uuid.UUID, status string) error
-	ReleaseDeliverySlot(ctx context.Context, orderID uuid.UUID) error
+	TransitionShipmentStatus(ctx context.Context, id uuid.UUID, from, to, description string) (Shipment, error)
+	ReleaseDeliverySlot(ctx context.Context, orderID uuid.UUID) (bool, error)
+	RequestCashOnDelivery(ctx context.Context, orderID uuid.UUID, amountMinor int64, currency string) (Shipment, error)
+	MarkCODShipmentDelivered(ctx context.Context, id uuid.UUID, collectedAmountMinor int64, from []string, description string) error
+	CreateTrackingEvent(ctx context.Context, arg CreateTrackingEventParams) (TrackingEvent, error)
+	ListTrackingEvents(ctx context.Context, shipmentID uuid.UUID) ([]TrackingEvent, error)
+	GetReservedSlot(ctx context.Context, orderID uuid.UUID) (DeliverySlot, error)
+	DispatchShipment(ctx context.Context, arg DispatchShipmentParams) (Shipment, error)
+	ClaimShipmentsToTrack(ctx context.Context, trackedBefore time.Duration, limit int) ([]Shipment, error)
+	RecordCarrierTracking(ctx context.Context, shipmentID uuid.UUID, status string, from []string, checkpoints []CreateTrackingEventParams) (Shipment, bool, error)
+	ListDispatchedShipments(ctx context.Context, from, to time.Time, carrier string) ([]Shipment, error)
+	ListPickupPoints(ctx context.Context, postalCode, pointType string, limit int) ([]PickupPoint, error)
+	GetPickupPoint(ctx context.Context, id uuid.UUID) (PickupPoint, error)
+	CreatePickupPoint(ctx context.Context, arg CreatePickupPointParams) (PickupPoint, error)
+	UpdatePickupPoint(ctx context.Context, arg UpdatePickupPointParams) (PickupPoint, error)
+	ExpireUncollectedPickups(ctx context.Context, description string, limit int) ([]Shipment, error)
 }
 
 type Queries struct {
@@ -81,117 +318,479 @@ func NewQueries(db *DB) *Queries {
 	return &Queries{db: db}
 }
 
-func (q *Queries) GetDeliverySlots(ctx context.Context, deliveryZoneID uuid.UUID, date time.Time) ([]DeliverySlot, error) {
+// ListDeliveryZonesForPrefecture returns the zones that list the prefecture
+// or list none at all
+func (q *Queries) ListDeliveryZonesForPrefecture(ctx context.Context, prefecture string) ([]DeliveryZone, error) {
 	const sql = `
-		SELECT id, delivery_zone_id, start_time, end_time, capacity, reserved, available, created_at, updated_at, date
-		FROM delivery.delivery_slots
-		WHERE delivery_zone_id = $1 AND date = $2 AND available > 0
-		ORDER BY start_time ASC
+		SELECT id, name, postal_codes, prefectures, delivery_days, undeliverable, remote_island, carrier, created_at, updated_at
+		FROM delivery.delivery_zones
+		WHERE $1 = ANY(prefectures) OR cardinality(prefectures) = 0
+		ORDER BY id
 	`
-	rows, err := q.db.pool.Query(ctx, sql, deliveryZoneID, date)
+	rows, err := q.db.pool.Query(ctx, sql, prefecture)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var slots []DeliverySlot
+	var zones []DeliveryZone
 	for rows.Next() {
-		var s DeliverySlot
+		var z DeliveryZone
 		err := rows.Scan(
-			&s.ID, &s.DeliveryZoneID, &s.StartTime, &s.EndTime,
-			&s.Capacity, &s.Reserved, &s.Available,
-			&s.CreatedAt, &s.UpdatedAt, &s.Date,
+			&z.ID, &z.Name, &z.PostalCodes, &z.Prefectures, &z.DeliveryDays,
+			&z.Undeliverable, &z.RemoteIsland, &z.Carrier, &z.CreatedAt, &z.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
-		slots = append(slots, s)
+		zones = append(zones, z)
 	}
-	return slots, rows.Err()
+	return zones, rows.Err()
 }
 
-func (q *Queries) GetDeliverySlot(ctx context.Context, id uuid.UUID) (DeliverySlot, error) {
-	const sql = `
-		SELECT id, delivery_zone_id, start_time, end_time, capacity, reserved, available, created_at, updated_at, date
-		FROM delivery.delivery_slots
-		WHERE id = $1
-	`
-	row := q.db.pool.QueryRow(ctx, sql, id)
+const deliverySlotColumns = `
+	id, delivery_zone_id, start_time, end_time, capacity, reserved, available,
+	created_at, updated_at, date, template_id, time_window, cutoff_at`
+
+func scanDeliverySlot(row pgx.Row) (DeliverySlot, error) {
 	var s DeliverySlot
 	err := row.Scan(
 		&s.ID, &s.DeliveryZoneID, &s.StartTime, &s.EndTime,
 		&s.Capacity, &s.Reserved, &s.Available,
 		&s.CreatedAt, &s.UpdatedAt, &s.Date,
+		&s.TemplateID, &s.TimeWindow, &s.CutoffAt,
 	)
 	return s, err
 }
 
-func (q *Queries) ReserveDeliverySlot(ctx context.Context, slotID, orderID uuid.UUID) (uuid.UUID, error) {
+// GetDeliverySlots returns the zone's slots on the date that can still be
+// booked. Slot times are JST wall clock, so the cutoff is compared with the
+// current time in Tokyo.
+func (q *Queries) GetDeliverySlots(ctx context.Context, deliveryZoneID uuid.UUID, date time.Time) ([]DeliverySlot, error) {
+	sql := `
+		SELECT ` + deliverySlotColumns + `
+		FROM delivery.delivery_slots
+		WHERE delivery_zone_id = $1 AND date = $2 AND available > 0
+			AND (cutoff_at IS NULL OR cutoff_at > (NOW() AT TIME ZONE 'Asia/Tokyo'))
+		ORDER BY start_time ASC
+	`
+	rows, err := q.db.pool.Query(ctx, sql, deliveryZoneID, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []DeliverySlot
+	for rows.Next() {
+		s, err := scanDeliverySlot(rows)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, s)
+	}
+	return slots, rows.Err()
+}
+
+func (q *Queries) GetDeliverySlot(ctx context.Context, id uuid.UUID) (DeliverySlot, error) {
+	sql := `SELECT ` + deliverySlotColumns + ` FROM delivery.delivery_slots WHERE id = $1`
+	return scanDeliverySlot(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+// CreateDeliverySlots inserts generated slots, skipping any whose zone
+// already has a slot starting at the same time. It returns the number
+// inserted.
+func (q *Queries) CreateDeliverySlots(ctx context.Context, slots []CreateDeliverySlotParams) (int, error) {
 	tx, err := q.db.pool.Begin(ctx)
 	if err != nil {
-		return uuid.Nil, err
+		return 0, err
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	const updateSQL = `
-		UPDATE delivery.delivery_slots
-		SET reserved = reserved + 1, updated_at = NOW()
-		WHERE id = $1 AND available > 0
-		RETURNING id
+	const sql = `
+		INSERT INTO delivery.delivery_slots
+			(delivery_zone_id, template_id, time_window, start_time, end_time, capacity, date, cutoff_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		ON CONFLICT (delivery_zone_id, start_time) DO NOTHING
 	`
-	var updatedSlotID uuid.UUID
-	err = tx.QueryRow(ctx, updateSQL, slotID).Scan(&updatedSlotID)
-	if err != nil {
-		return uuid.Nil, err
+	created := 0
+	for _, slot := range slots {
+		tag, err := tx.Exec(ctx, sql,
+			slot.DeliveryZoneID, slot.TemplateID, slot.TimeWindow,
+			slot.StartTime, slot.EndTime, slot.Capacity, slot.Date, slot.CutoffAt,
+		)
+		if err != nil {
+			return 0, err
+		}
+		created += int(tag.RowsAffected())
 	}
 
-	const insertSQL = `
-		INSERT INTO delivery.delivery_reservations (slot_id, order_id, created_at)
-		VALUES ($1, $2, NOW())
-		ON CONFLICT (order_id) DO NOTHING
-		RETURNING id
+	return created, tx.Commit(ctx)
+}
+
+// DeleteUnreservedTemplateSlots deletes the template's slots starting after
+// the given time that have no reservations
+func (q *Queries) DeleteUnreservedTemplateSlots(ctx context.Context, templateID uuid.UUID, after time.Time) (int, error) {
+	const sql = `
+		DELETE FROM delivery.delivery_slots
+		WHERE template_id = $1 AND start_time > $2 AND reserved = 0
 	`
-	var reservationID uuid.UUID
-	err = tx.QueryRow(ctx, insertSQL, slotID, orderID).Scan(&reservationID)
+	tag, err := q.db.pool.Exec(ctx, sql, templateID, after)
 	if err != nil {
-		return uuid.Nil, err
+		return 0, err
 	}
-
-	return reservationID, tx.Commit(ctx)
+	return int(tag.RowsAffected()), nil
 }
 
-func (q *Queries) GetShipmentByOrderID(ctx context.Context, orderID uuid.UUID) (Shipment, error) {
+// DeleteUnreservedSlotsOnDate deletes the slots on the date that have no
+// reservations, in one zone or, when deliveryZoneID is nil, every zone. It
+// also returns how many slots on the date were kept for their reservations.
+func (q *Queries) DeleteUnreservedSlotsOnDate(ctx context.Context, deliveryZoneID *uuid.UUID, date time.Time) (int, int, error) {
 	const sql = `
-		SELECT id, order_id, tracking_number, status, estimated_delivery_at, actual_delivery_at, carrier, created_at, updated_at
-		FROM delivery.shipments
-		WHERE order_id = $1
+		WITH removed AS (
+			DELETE FROM delivery.delivery_slots
+			WHERE date = $2 AND reserved = 0 AND ($1::uuid IS NULL OR delivery_zone_id = $1)
+			RETURNING id
+		)
+		SELECT
+			(SELECT COUNT(*) FROM removed),
+			(SELECT COUNT(*) FROM delivery.delivery_slots
+			 WHERE date = $2 AND reserved > 0 AND ($1::uuid IS NULL OR delivery_zone_id = $1))
 	`
-	row := q.db.pool.QueryRow(ctx, sql, orderID)
-	var s Shipment
+	var removed, reserved int
+	err := q.db.pool.QueryRow(ctx, sql, deliveryZoneID, date).Scan(&removed, &reserved)
+	return removed, reserved, err
+}
+
+const slotTemplateColumns = `
+	id, delivery_zone_id, weekday, time_window, capacity, cutoff_days_before,
+	to_char(cutoff_time, 'HH24:MI'), created_at, updated_at`
+
+func scanSlotTemplate(row pgx.Row) (SlotTemplate, error) {
+	var t SlotTemplate
 	err := row.Scan(
-		&s.ID, &s.OrderID, &s.TrackingNumber, &s.Status,
-		&s.EstimatedDeliveryAt, &s.ActualDeliveryAt, &s.Carrier,
-		&s.CreatedAt, &s.UpdatedAt,
+		&t.ID, &t.DeliveryZoneID, &t.Weekday, &t.TimeWindow, &t.Capacity,
+		&t.CutoffDaysBefore, &t.CutoffTime, &t.CreatedAt, &t.UpdatedAt,
 	)
-	return s, err
+	return t, err
 }
 
-func (q *Queries) GetShipment(ctx context.Context, id uuid.UUID) (Shipment, error) {
-	const sql = `
-		SELECT id, order_id, tracking_number, status, estimated_delivery_at, actual_delivery_at, carrier, created_at, updated_at
-		FROM delivery.shipments
+func (q *Queries) listSlotTemplates(ctx context.Context, sql string, args ...any) ([]SlotTemplate, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []SlotTemplate
+	for rows.Next() {
+		t, err := scanSlotTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func (q *Queries) ListSlotTemplates(ctx context.Context, deliveryZoneID uuid.UUID) ([]SlotTemplate, error) {
+	sql := `SELECT ` + slotTemplateColumns + ` FROM delivery.slot_templates
+		WHERE delivery_zone_id = $1
+		ORDER BY weekday, time_window`
+	return q.listSlotTemplates(ctx, sql, deliveryZoneID)
+}
+
+func (q *Queries) ListAllSlotTemplates(ctx context.Context) ([]SlotTemplate, error) {
+	sql := `SELECT ` + slotTemplateColumns + ` FROM delivery.slot_templates
+		ORDER BY delivery_zone_id, weekday, time_window`
+	return q.listSlotTemplates(ctx, sql)
+}
+
+func (q *Queries) GetSlotTemplate(ctx context.Context, id uuid.UUID) (SlotTemplate, error) {
+	sql := `SELECT ` + slotTemplateColumns + ` FROM delivery.slot_templates WHERE id = $1`
+	return scanSlotTemplate(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+func (q *Queries) CreateSlotTemplate(ctx context.Context, arg CreateSlotTemplateParams) (SlotTemplate, error) {
+	sql := `
+		INSERT INTO delivery.slot_templates
+			(delivery_zone_id, weekday, time_window, capacity, cutoff_days_before, cutoff_time, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6::time, NOW(), NOW())
+		RETURNING ` + slotTemplateColumns
+	return scanSlotTemplate(q.db.pool.QueryRow(ctx, sql,
+		arg.DeliveryZoneID, arg.Weekday, arg.TimeWindow, arg.Capacity, arg.CutoffDaysBefore, arg.CutoffTime,
+	))
+}
+
+func (q *Queries) UpdateSlotTemplate(ctx context.Context, arg UpdateSlotTemplateParams) (SlotTemplate, error) {
+	sql := `
+		UPDATE delivery.slot_templates
+		SET weekday = $2, time_window = $3, capacity = $4,
+			cutoff_days_before = $5, cutoff_time = $6::time, updated_at = NOW()
 		WHERE id = $1
+		RETURNING ` + slotTemplateColumns
+	return scanSlotTemplate(q.db.pool.QueryRow(ctx, sql,
+		arg.ID, arg.Weekday, arg.TimeWindow, arg.Capacity, arg.CutoffDaysBefore, arg.CutoffTime,
+	))
+}
+
+// DeleteSlotTemplate deletes a template. Slots generated from it keep
+// existing with no template.
+func (q *Queries) DeleteSlotTemplate(ctx context.Context, id uuid.UUID) (SlotTemplate, error) {
+	sql := `DELETE FROM delivery.slot_templates WHERE id = $1 RETURNING ` + slotTemplateColumns
+	return scanSlotTemplate(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+const slotBlackoutColumns = `id, delivery_zone_id, date, reason, created_at`
+
+func scanSlotBlackout(row pgx.Row) (SlotBlackout, error) {
+	var b SlotBlackout
+	err := row.Scan(&b.ID, &b.DeliveryZoneID, &b.Date, &b.Reason, &b.CreatedAt)
+	return b, err
+}
+
+// ListSlotBlackouts returns the blackouts on or after the given date
+func (q *Queries) ListSlotBlackouts(ctx context.Context, from time.Time) ([]SlotBlackout, error) {
+	sql := `SELECT ` + slotBlackoutColumns + ` FROM delivery.slot_blackouts
+		WHERE date >= $1
+		ORDER BY date, delivery_zone_id NULLS FIRST`
+	rows, err := q.db.pool.Query(ctx, sql, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blackouts []SlotBlackout
+	for rows.Next() {
+		b, err := scanSlotBlackout(rows)
+		if err != nil {
+			return nil, err
+		}
+		blackouts = append(blackouts, b)
+	}
+	return blackouts, rows.Err()
+}
+
+func (q *Queries) CreateSlotBlackout(ctx context.Context, arg CreateSlotBlackoutParams) (SlotBlackout, error) {
+	sql := `
+		INSERT INTO delivery.slot_blackouts (delivery_zone_id, date, reason, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING ` + slotBlackoutColumns
+	return scanSlotBlackout(q.db.pool.QueryRow(ctx, sql, arg.DeliveryZoneID, arg.Date, arg.Reason))
+}
+
+func (q *Queries) DeleteSlotBlackout(ctx context.Context, id uuid.UUID) (SlotBlackout, error) {
+	sql := `DELETE FROM delivery.slot_blackouts WHERE id = $1 RETURNING ` + slotBlackoutColumns
+	return scanSlotBlackout(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+const closureDayColumns = `id, date, reason, created_at`
+
+func scanClosureDay(row pgx.Row) (ClosureDay, error) {
+	var d ClosureDay
+	err := row.Scan(&d.ID, &d.Date, &d.Reason, &d.CreatedAt)
+	return d, err
+}
+
+// ListClosureDays returns the closure days on or after the given date
+func (q *Queries) ListClosureDays(ctx context.Context, from time.Time) ([]ClosureDay, error) {
+	sql := `SELECT ` + closureDayColumns + ` FROM delivery.closure_days
+		WHERE date >= $1
+		ORDER BY date`
+	rows, err := q.db.pool.Query(ctx, sql, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []ClosureDay
+	for rows.Next() {
+		d, err := scanClosureDay(rows)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+func (q *Queries) CreateClosureDay(ctx context.Context, date time.Time, reason string) (ClosureDay, error) {
+	sql := `
+		INSERT INTO delivery.closure_days (date, reason, created_at)
+		VALUES ($1, $2, NOW())
+		RETURNING ` + closureDayColumns
+	return scanClosureDay(q.db.pool.QueryRow(ctx, sql, date, reason))
+}
+
+func (q *Queries) DeleteClosureDay(ctx context.Context, id uuid.UUID) (ClosureDay, error) {
+	sql := `DELETE FROM delivery.closure_days WHERE id = $1 RETURNING ` + closureDayColumns
+	return scanClosureDay(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+const reservationColumns = `id, slot_id, order_id, status, expires_at, confirmed_at, created_at`
+
+func scanReservation(row pgx.Row) (DeliveryReservation, error) {
+	var r DeliveryReservation
+	err := row.Scan(&r.ID, &r.SlotID, &r.OrderID, &r.Status, &r.ExpiresAt, &r.ConfirmedAt, &r.CreatedAt)
+	return r, err
+}
+
+// ReserveDeliverySlot holds a place in the slot for the order until holdTTL
+// from now. Asking again for the same slot extends the hold; asking for
+// another slot moves it, unless the reservation is already confirmed.
+func (q *Queries) ReserveDeliverySlot(ctx context.Context, slotID, orderID uuid.UUID, holdTTL time.Duration) (DeliveryReservation, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return DeliveryReservation{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	selectSQL := `SELECT ` + reservationColumns + ` FROM delivery.delivery_reservations WHERE order_id = $1 FOR UPDATE`
+	existing, err := scanReservation(tx.QueryRow(ctx, selectSQL, orderID))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return DeliveryReservation{}, err
+	case existing.SlotID == slotID && existing.Status == "RESERVATION_STATUS_CONFIRMED":
+		return existing, tx.Commit(ctx)
+	case existing.Status == "RESERVATION_STATUS_CONFIRMED":
+		return DeliveryReservation{}, ErrReservationConfirmed
+	case existing.SlotID != slotID:
+		const releaseSQL = `
+			UPDATE delivery.delivery_slots
+			SET reserved = GREATEST(0, reserved - 1), updated_at = NOW()
+			WHERE id = $1
+		`
+		if _, err := tx.Exec(ctx, releaseSQL, existing.SlotID); err != nil {
+			return DeliveryReservation{}, err
+		}
+	}
+
+	if existing.SlotID != slotID {
+		const updateSQL = `
+			UPDATE delivery.delivery_slots
+			SET reserved = reserved + 1, updated_at = NOW()
+			WHERE id = $1 AND available > 0
+			RETURNING id
+		`
+		var updatedSlotID uuid.UUID
+		err = tx.QueryRow(ctx, updateSQL, slotID).Scan(&updatedSlotID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryReservation{}, ErrSlotUnavailable
+		}
+		if err != nil {
+			return DeliveryReservation{}, err
+		}
+	}
+
+	upsertSQL := `
+		INSERT INTO delivery.delivery_reservations (slot_id, order_id, status, expires_at, created_at)
+		VALUES ($1, $2, 'RESERVATION_STATUS_HELD', NOW() + make_interval(secs => $3), NOW())
+		ON CONFLICT (order_id) DO UPDATE
+		SET slot_id = EXCLUDED.slot_id,
+			expires_at = EXCLUDED.expires_at,
+			created_at = CASE WHEN delivery.delivery_reservations.slot_id = EXCLUDED.slot_id
+				THEN delivery.delivery_reservations.created_at ELSE NOW() END
+		RETURNING ` + reservationColumns
+	reservation, err := scanReservation(tx.QueryRow(ctx, upsertSQL, slotID, orderID, holdTTL.Seconds()))
+	if err != nil {
+		return DeliveryReservation{}, err
+	}
+
+	return reservation, tx.Commit(ctx)
+}
+
+// ConfirmReservation makes the order's reservation permanent. A hold that
+// has lapsed but not been released yet still has its place counted, so it
+// can be confirmed too. Returns pgx.ErrNoRows when the order has no
+// reservation.
+func (q *Queries) ConfirmReservation(ctx context.Context, orderID uuid.UUID) (DeliveryReservation, error) {
+	sql := `
+		UPDATE delivery.delivery_reservations
+		SET status = 'RESERVATION_STATUS_CONFIRMED',
+			confirmed_at = COALESCE(confirmed_at, NOW()),
+			expires_at = NULL
+		WHERE order_id = $1
+		RETURNING ` + reservationColumns
+	return scanReservation(q.db.pool.QueryRow(ctx, sql, orderID))
+}
+
+// ReleaseExpiredHolds deletes up to limit lapsed holds and frees their
+// places, returning the released reservations
+func (q *Queries) ReleaseExpiredHolds(ctx context.Context, limit int) ([]DeliveryReservation, error) {
+	sql := `
+		WITH expired AS (
+			DELETE FROM delivery.delivery_reservations
+			WHERE id IN (
+				SELECT id FROM delivery.delivery_reservations
+				WHERE status = 'RESERVATION_STATUS_HELD' AND expires_at <= NOW()
+				ORDER BY expires_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + reservationColumns + `
+		), freed AS (
+			SELECT slot_id, COUNT(*) AS places FROM expired GROUP BY slot_id
+		), released AS (
+			UPDATE delivery.delivery_slots ds
+			SET reserved = GREATEST(0, ds.reserved - freed.places), updated_at = NOW()
+			FROM freed
+			WHERE ds.id = freed.slot_id
+		)
+		SELECT ` + reservationColumns + ` FROM expired
 	`
-	row := q.db.pool.QueryRow(ctx, sql, id)
+	rows, err := q.db.pool.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []DeliveryReservation
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, rows.Err()
+}
+
+const shipmentColumns = `
+	id, order_id, tracking_number, status, estimated_delivery_at, actual_delivery_at, carrier,
+	cod_amount_minor, cod_currency, collected_amount_minor, service_level, recipient, dispatched_at,
+	pickup_point_id, pickup_code, pickup_deadline_at, created_at, updated_at`
+
+// pickupDeadlineSQL is the end of the last day, in Japan, that a shipment
+// arriving at its pickup point now is kept there, as a UTC timestamp
+const pickupDeadlineSQL = `(
+	SELECT ((NOW() AT TIME ZONE 'Asia/Tokyo')::date + p.hold_days + 1)::timestamp AT TIME ZONE 'Asia/Tokyo' AT TIME ZONE 'UTC'
+	FROM delivery.pickup_points p
+	WHERE p.id = delivery.shipments.pickup_point_id
+)`
+
+func scanShipment(row pgx.Row) (Shipment, error) {
 	var s Shipment
 	err := row.Scan(
 		&s.ID, &s.OrderID, &s.TrackingNumber, &s.Status,
 		&s.EstimatedDeliveryAt, &s.ActualDeliveryAt, &s.Carrier,
+		&s.CODAmountMinor, &s.CODCurrency, &s.CollectedAmountMinor,
+		&s.ServiceLevel, &s.Recipient, &s.DispatchedAt,
+		&s.PickupPointID, &s.PickupCode, &s.PickupDeadlineAt,
 		&s.CreatedAt, &s.UpdatedAt,
 	)
 	return s, err
 }
 
+func (q *Queries) GetShipmentByOrderID(ctx context.Context, orderID uuid.UUID) (Shipment, error) {
+	sql := `SELECT ` + shipmentColumns + ` FROM delivery.shipments WHERE order_id = $1`
+	return scanShipment(q.db.pool.QueryRow(ctx, sql, orderID))
+}
+
+func (q *Queries) GetShipment(ctx context.Context, id uuid.UUID) (Shipment, error) {
+	sql := `SELECT ` + shipmentColumns + ` FROM delivery.shipments WHERE id = $1`
+	return scanShipment(q.db.pool.QueryRow(ctx, sql, id))
+}
+
 func (q *Queries) CreateShipment(ctx context.Context, orderID uuid.UUID) (uuid.UUID, error) {
 	const sql = `
 		INSERT INTO delivery.shipments (order_id, status, created_at, updated_at)
@@ -204,34 +803,415 @@ func (q *Queries) CreateShipment(ctx context.Context, orderID uuid.UUID) (uuid.U
 	return id, err
 }
 
-func (q *Queries) UpdateShipmentStatus(ctx context.Context, id uuid.UUID, status string) error {
+// TransitionShipmentStatus moves a shipment from one status to another and
+// records the change in its tracking timeline. A shipment becoming ready for
+// pickup gets its pickup deadline. A shipment no longer in the from status is
+// not changed and pgx.ErrNoRows is returned.
+func (q *Queries) TransitionShipmentStatus(ctx context.Context, id uuid.UUID, from, to, description string) (Shipment, error) {
+	sql := `
+		WITH updated AS (
+			UPDATE delivery.shipments
+			SET status = $3,
+				actual_delivery_at = CASE WHEN $3 IN ('SHIPMENT_STATUS_DELIVERED', 'SHIPMENT_STATUS_PICKED_UP') THEN NOW() ELSE actual_delivery_at END,
+				pickup_deadline_at = CASE WHEN $3 = 'SHIPMENT_STATUS_READY_FOR_PICKUP' THEN ` + pickupDeadlineSQL + ` ELSE pickup_deadline_at END,
+				updated_at = NOW()
+			WHERE id = $1 AND status = $2
+			RETURNING ` + shipmentColumns + `
+		), event AS (
+			INSERT INTO delivery.tracking_events (shipment_id, status, description)
+			SELECT id, status, $4 FROM updated
+		)
+		SELECT ` + shipmentColumns + ` FROM updated`
+	return scanShipment(q.db.pool.QueryRow(ctx, sql, id, from, to, description))
+}
+
+// RequestCashOnDelivery sets the cash to collect for an order's shipment,
+// creating the shipment if needed. Shipments that have left the warehouse
+// are not changed and pgx.ErrNoRows is returned.
+func (q *Queries) RequestCashOnDelivery(ctx context.Context, orderID uuid.UUID, amountMinor int64, currency string) (Shipment, error) {
+	sql := `
+		INSERT INTO delivery.shipments (order_id, status, cod_amount_minor, cod_currency, created_at, updated_at)
+		VALUES ($1, 'SHIPMENT_STATUS_PREPARING', $2, $3, NOW(), NOW())
+		ON CONFLICT (order_id) DO UPDATE
+		SET cod_amount_minor = EXCLUDED.cod_amount_minor,
+			cod_currency = EXCLUDED.cod_currency,
+			updated_at = NOW()
+		WHERE delivery.shipments.status = 'SHIPMENT_STATUS_PREPARING'
+		RETURNING ` + shipmentColumns
+	return scanShipment(q.db.pool.QueryRow(ctx, sql, orderID, amountMinor, currency))
+}
+
+// MarkCODShipmentDelivered marks a cash on delivery shipment delivered and
+// records the cash the courier collected. The delivery is added to the
+// tracking timeline unless the shipment was already delivered, so retries
+// don't repeat it. Shipments not in one of the from statuses are not
+// changed and pgx.ErrNoRows is returned.
+func (q *Queries) MarkCODShipmentDelivered(ctx context.Context, id uuid.UUID, collectedAmountMinor int64, from []string, description string) error {
 	const sql = `
+		WITH updated AS (
+			UPDATE delivery.shipments s
+			SET status = 'SHIPMENT_STATUS_DELIVERED',
+				collected_amount_minor = $2,
+				actual_delivery_at = COALESCE(s.actual_delivery_at, NOW()),
+				updated_at = NOW()
+			FROM delivery.shipments previous
+			WHERE s.id = $1 AND previous.id = s.id AND s.cod_amount_minor IS NOT NULL
+				AND previous.status = ANY($3)
+			RETURNING s.id, previous.status AS previous_status
+		), event AS (
+			INSERT INTO delivery.tracking_events (shipment_id, status, description)
+			SELECT id, 'SHIPMENT_STATUS_DELIVERED', $4 FROM updated
+			WHERE previous_status <> 'SHIPMENT_STATUS_DELIVERED'
+		)
+		SELECT COUNT(*) FROM updated
+	`
+	var updated int
+	if err := q.db.pool.QueryRow(ctx, sql, id, collectedAmountMinor, from, description).Scan(&updated); err != nil {
+		return err
+	}
+	if updated == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetReservedSlot returns the slot reserved for an order, held or confirmed
+func (q *Queries) GetReservedSlot(ctx context.Context, orderID uuid.UUID) (DeliverySlot, error) {
+	sql := `
+		SELECT ` + deliverySlotColumns + `
+		FROM delivery.delivery_slots
+		WHERE id = (SELECT slot_id FROM delivery.delivery_reservations WHERE order_id = $1)
+	`
+	return scanDeliverySlot(q.db.pool.QueryRow(ctx, sql, orderID))
+}
+
+// DispatchShipment records the carrier and tracking number of a shipment
+// handed to a carrier and adds it to the tracking timeline. Shipments that
+// were already dispatched or have left the warehouse are not changed and
+// pgx.ErrNoRows is returned.
+func (q *Queries) DispatchShipment(ctx context.Context, arg DispatchShipmentParams) (Shipment, error) {
+	sql := `
+		WITH dispatched AS (
+			UPDATE delivery.shipments
+			SET carrier = $2,
+				tracking_number = $3,
+				service_level = $4,
+				recipient = $5,
+				estimated_delivery_at = COALESCE($6, estimated_delivery_at),
+				pickup_point_id = $8,
+				pickup_code = $9,
+				dispatched_at = NOW(),
+				updated_at = NOW()
+			WHERE id = $1 AND tracking_number IS NULL AND status = 'SHIPMENT_STATUS_PREPARING'
+			RETURNING ` + shipmentColumns + `
+		), event AS (
+			INSERT INTO delivery.tracking_events (shipment_id, status, description)
+			SELECT id, status, $7 FROM dispatched
+		)
+		SELECT ` + shipmentColumns + ` FROM dispatched`
+	return scanShipment(q.db.pool.QueryRow(ctx, sql,
+		arg.ID, arg.Carrier, arg.TrackingNumber, arg.ServiceLevel, arg.Recipient,
+		arg.EstimatedDeliveryAt, arg.Description, arg.PickupPointID, arg.PickupCode))
+}
+
+// ClaimShipmentsToTrack returns dispatched shipments still on their way that
+// were last polled longer ago than trackedBefore, marking them polled so that
+// other replicas skip them
+func (q *Queries) ClaimShipmentsToTrack(ctx context.Context, trackedBefore time.Duration, limit int) ([]Shipment, error) {
+	sql := `
 		UPDATE delivery.shipments
-		SET status = $2, updated_at = NOW()
-		WHERE id = $1
+		SET tracked_at = NOW()
+		WHERE id IN (
+			SELECT id FROM delivery.shipments
+			WHERE tracking_number IS NOT NULL
+				AND status IN ('SHIPMENT_STATUS_PREPARING', 'SHIPMENT_STATUS_SHIPPED', 'SHIPMENT_STATUS_IN_TRANSIT', 'SHIPMENT_STATUS_FAILED_DELIVERY')
+				AND (tracked_at IS NULL OR tracked_at < NOW() - make_interval(secs => $1))
+			ORDER BY tracked_at NULLS FIRST
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + shipmentColumns
+	rows, err := q.db.pool.Query(ctx, sql, trackedBefore.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shipments []Shipment
+	for rows.Next() {
+		s, err := scanShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, s)
+	}
+	return shipments, rows.Err()
+}
+
+// ListDispatchedShipments returns shipments dispatched in [from, to) that
+// have not been cancelled, by carrier and dispatch time. An empty carrier
+// matches every carrier.
+func (q *Queries) ListDispatchedShipments(ctx context.Context, from, to time.Time, carrier string) ([]Shipment, error) {
+	sql := `
+		SELECT ` + shipmentColumns + `
+		FROM delivery.shipments
+		WHERE dispatched_at >= $1 AND dispatched_at < $2
+			AND ($3 = '' OR carrier = $3)
+			AND status <> 'SHIPMENT_STATUS_CANCELLED'
+		ORDER BY carrier, dispatched_at, id
+	`
+	rows, err := q.db.pool.Query(ctx, sql, from.UTC(), to.UTC(), carrier)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shipments []Shipment
+	for rows.Next() {
+		s, err := scanShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, s)
+	}
+	return shipments, rows.Err()
+}
+
+// RecordCarrierTracking adds the carrier's checkpoints that are not yet in a
+// shipment's timeline and moves the shipment to the carrier's status if it
+// is in one of the from statuses. An empty status leaves it unchanged. Cash
+// on delivery shipments the carrier delivered are recorded as having
+// collected their full amount, and shipments that reached their pickup point
+// get their pickup deadline. It reports whether the status changed.
+func (q *Queries) RecordCarrierTracking(ctx context.Context, shipmentID uuid.UUID, status string, from []string, checkpoints []CreateTrackingEventParams) (Shipment, bool, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return Shipment{}, false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const insertSQL = `
+		INSERT INTO delivery.tracking_events (shipment_id, status, location, description, occurred_at)
+		SELECT $1, $2, $3, $4, $5::timestamp
+		WHERE NOT EXISTS (
+			SELECT 1 FROM delivery.tracking_events
+			WHERE shipment_id = $1 AND status = $2 AND location = $3 AND description = $4 AND occurred_at = $5::timestamp
+		)
 	`
-	_, err := q.db.pool.Exec(ctx, sql, id, status)
-	return err
+	for _, c := range checkpoints {
+		if _, err := tx.Exec(ctx, insertSQL, shipmentID, c.Status, c.Location, c.Description, c.OccurredAt); err != nil {
+			return Shipment{}, false, err
+		}
+	}
+
+	changed := false
+	if status != "" {
+		const updateSQL = `
+			UPDATE delivery.shipments
+			SET status = $2,
+				actual_delivery_at = CASE WHEN $2 = 'SHIPMENT_STATUS_DELIVERED' THEN COALESCE(actual_delivery_at, NOW()) ELSE actual_delivery_at END,
+				collected_amount_minor = CASE WHEN $2 = 'SHIPMENT_STATUS_DELIVERED' THEN cod_amount_minor ELSE collected_amount_minor END,
+				pickup_deadline_at = CASE WHEN $2 = 'SHIPMENT_STATUS_READY_FOR_PICKUP' THEN ` + pickupDeadlineSQL + ` ELSE pickup_deadline_at END,
+				updated_at = NOW()
+			WHERE id = $1 AND status = ANY($3)
+		`
+		tag, err := tx.Exec(ctx, updateSQL, shipmentID, status, from)
+		if err != nil {
+			return Shipment{}, false, err
+		}
+		changed = tag.RowsAffected() > 0
+	}
+
+	sql := `SELECT ` + shipmentColumns + ` FROM delivery.shipments WHERE id = $1`
+	shipment, err := scanShipment(tx.QueryRow(ctx, sql, shipmentID))
+	if err != nil {
+		return Shipment{}, false, err
+	}
+	return shipment, changed, tx.Commit(ctx)
 }
 
-func (q *Queries) ReleaseDeliverySlot(ctx context.Context, orderID uuid.UUID) error {
-	const sql = `
-		WITH released AS (
-			SELECT dr.slot_id
-			FROM delivery.delivery_reservations dr
-			WHERE dr.order_id = $1
+// ExpireUncollectedPickups sends back up to limit shipments whose pickup
+// deadline has passed, recording description in their timelines, and
+// returns them
+func (q *Queries) ExpireUncollectedPickups(ctx context.Context, description string, limit int) ([]Shipment, error) {
+	sql := `
+		WITH expired AS (
+			UPDATE delivery.shipments
+			SET status = 'SHIPMENT_STATUS_RETURNED', updated_at = NOW()
+			WHERE id IN (
+				SELECT id FROM delivery.shipments
+				WHERE status = 'SHIPMENT_STATUS_READY_FOR_PICKUP' AND pickup_deadline_at <= NOW()
+				ORDER BY pickup_deadline_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + shipmentColumns + `
+		), event AS (
+			INSERT INTO delivery.tracking_events (shipment_id, status, description)
+			SELECT id, status, $1 FROM expired
 		)
-		UPDATE delivery.delivery_slots ds
-		SET reserved = GREATEST(0, reserved - 1), updated_at = NOW()
-		FROM released
-		WHERE ds.id = released.slot_id
+		SELECT ` + shipmentColumns + ` FROM expired`
+	rows, err := q.db.pool.Query(ctx, sql, description, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shipments []Shipment
+	for rows.Next() {
+		s, err := scanShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, s)
+	}
+	return shipments, rows.Err()
+}
+
+const pickupPointColumns = `
+	id, code, name, type, operator, postal_code, prefecture, city, address_line1, address_line2,
+	opening_hours, hold_days, active, created_at, updated_at`
+
+func scanPickupPoint(row pgx.Row) (PickupPoint, error) {
+	var p PickupPoint
+	err := row.Scan(
+		&p.ID, &p.Code, &p.Name, &p.Type, &p.Operator,
+		&p.PostalCode, &p.Prefecture, &p.City, &p.AddressLine1, &p.AddressLine2,
+		&p.OpeningHours, &p.HoldDays, &p.Active, &p.CreatedAt, &p.UpdatedAt,
+	)
+	return p, err
+}
+
+// ListPickupPoints returns up to limit active pickup points in the postal
+// code's area, its first three digits, nearest postal code first. An empty
+// type matches every type.
+func (q *Queries) ListPickupPoints(ctx context.Context, postalCode, pointType string, limit int) ([]PickupPoint, error) {
+	sql := `
+		SELECT ` + pickupPointColumns + `
+		FROM delivery.pickup_points
+		WHERE active AND left(postal_code, 3) = left($1::text, 3)
+			AND ($2 = '' OR type = $2)
+		ORDER BY abs(postal_code::int - $1::text::int), name, id
+		LIMIT $3
 	`
-	_, err := q.db.pool.Exec(ctx, sql, orderID)
+	rows, err := q.db.pool.Query(ctx, sql, postalCode, pointType, limit)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []PickupPoint
+	for rows.Next() {
+		p, err := scanPickupPoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+func (q *Queries) GetPickupPoint(ctx context.Context, id uuid.UUID) (PickupPoint, error) {
+	sql := `SELECT ` + pickupPointColumns + ` FROM delivery.pickup_points WHERE id = $1`
+	return scanPickupPoint(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+func (q *Queries) CreatePickupPoint(ctx context.Context, arg CreatePickupPointParams) (PickupPoint, error) {
+	sql := `
+		INSERT INTO delivery.pickup_points (
+			code, name, type, operator, postal_code, prefecture, city, address_line1, address_line2,
+			opening_hours, hold_days, active, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+		RETURNING ` + pickupPointColumns
+	return scanPickupPoint(q.db.pool.QueryRow(ctx, sql,
+		arg.Code, arg.Name, arg.Type, arg.Operator, arg.PostalCode, arg.Prefecture, arg.City,
+		arg.AddressLine1, arg.AddressLine2, arg.OpeningHours, arg.HoldDays, arg.Active))
+}
+
+func (q *Queries) UpdatePickupPoint(ctx context.Context, arg UpdatePickupPointParams) (PickupPoint, error) {
+	sql := `
+		UPDATE delivery.pickup_points
+		SET code = $2, name = $3, type = $4, operator = $5, postal_code = $6, prefecture = $7,
+			city = $8, address_line1 = $9, address_line2 = $10, opening_hours = $11,
+			hold_days = $12, active = $13, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + pickupPointColumns
+	return scanPickupPoint(q.db.pool.QueryRow(ctx, sql,
+		arg.ID, arg.Code, arg.Name, arg.Type, arg.Operator, arg.PostalCode, arg.Prefecture, arg.City,
+		arg.AddressLine1, arg.AddressLine2, arg.OpeningHours, arg.HoldDays, arg.Active))
+}
+
+const trackingEventColumns = `id, shipment_id, status, location, description, occurred_at, created_at`
+
+func scanTrackingEvent(row pgx.Row) (TrackingEvent, error) {
+	var e TrackingEvent
+	err := row.Scan(&e.ID, &e.ShipmentID, &e.Status, &e.Location, &e.Description, &e.OccurredAt, &e.CreatedAt)
+	return e, err
+}
+
+// CreateTrackingEvent adds a checkpoint to a shipment's tracking timeline
+func (q *Queries) CreateTrackingEvent(ctx context.Context, arg CreateTrackingEventParams) (TrackingEvent, error) {
+	sql := `
+		INSERT INTO delivery.tracking_events (shipment_id, status, location, description, occurred_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5::timestamp, NOW()))
+		RETURNING ` + trackingEventColumns
+	return scanTrackingEvent(q.db.pool.QueryRow(ctx, sql,
+		arg.ShipmentID, arg.Status, arg.Location, arg.Description, arg.OccurredAt))
+}
+
+// ListTrackingEvents returns a shipment's tracking timeline, oldest first
+func (q *Queries) ListTrackingEvents(ctx context.Context, shipmentID uuid.UUID) ([]TrackingEvent, error) {
+	sql := `
+		SELECT ` + trackingEventColumns + `
+		FROM delivery.tracking_events
+		WHERE shipment_id = $1
+		ORDER BY occurred_at, created_at
+	`
+	rows, err := q.db.pool.Query(ctx, sql, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []TrackingEvent{}
+	for rows.Next() {
+		e, err := scanTrackingEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ReleaseDeliverySlot deletes the order's reservation, held or confirmed,
+// and frees its place. It reports whether the order had a reservation.
+func (q *Queries) ReleaseDeliverySlot(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const deleteSQL = `DELETE FROM delivery.delivery_reservations WHERE order_id = $1 RETURNING slot_id`
+	var slotID uuid.UUID
+	err = tx.QueryRow(ctx, deleteSQL, orderID).Scan(&slotID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	const updateSQL = `
+		UPDATE delivery.delivery_slots
+		SET reserved = GREATEST(0, reserved - 1), updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, updateSQL, slotID); err != nil {
+		return false, err
 	}
 
-	deleteSQL := `DELETE FROM delivery.delivery_reservations WHERE order_id = $1`
-	_, err = q.db.pool.Exec(ctx, deleteSQL, orderID)
-	return err
+	return true, tx.Commit(ctx)
 }
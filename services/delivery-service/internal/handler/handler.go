@@ -28,11 +28,91 @@ func (h *Handler) GetDeliverySlots(ctx context.Context, req *deliverypb.GetDeliv
 	return h.service.GetDeliverySlots(ctx, req)
 }
 
+func (h *Handler) ResolveDeliveryZone(ctx context.Context, req *deliverypb.ResolveDeliveryZoneRequest) (*deliverypb.ResolveDeliveryZoneResponse, error) {
+	h.logger.Debug("ResolveDeliveryZone called", zap.String("postal_code", req.PostalCode))
+	return h.service.ResolveDeliveryZone(ctx, req)
+}
+
+func (h *Handler) GetDeliverySlotsForAddress(ctx context.Context, req *deliverypb.GetDeliverySlotsForAddressRequest) (*deliverypb.GetDeliverySlotsForAddressResponse, error) {
+	h.logger.Debug("GetDeliverySlotsForAddress called", zap.String("postal_code", req.PostalCode))
+	return h.service.GetDeliverySlotsForAddress(ctx, req)
+}
+
+func (h *Handler) ListSlotTemplates(ctx context.Context, req *deliverypb.ListSlotTemplatesRequest) (*deliverypb.ListSlotTemplatesResponse, error) {
+	h.logger.Debug("ListSlotTemplates called", zap.String("delivery_zone_id", req.DeliveryZoneId))
+	return h.service.ListSlotTemplates(ctx, req)
+}
+
+func (h *Handler) CreateSlotTemplate(ctx context.Context, req *deliverypb.CreateSlotTemplateRequest) (*deliverypb.CreateSlotTemplateResponse, error) {
+	h.logger.Debug("CreateSlotTemplate called", zap.String("delivery_zone_id", req.GetTemplate().GetDeliveryZoneId()))
+	return h.service.CreateSlotTemplate(ctx, req)
+}
+
+func (h *Handler) UpdateSlotTemplate(ctx context.Context, req *deliverypb.UpdateSlotTemplateRequest) (*deliverypb.UpdateSlotTemplateResponse, error) {
+	h.logger.Debug("UpdateSlotTemplate called", zap.String("template_id", req.GetTemplate().GetId()))
+	return h.service.UpdateSlotTemplate(ctx, req)
+}
+
+func (h *Handler) DeleteSlotTemplate(ctx context.Context, req *deliverypb.DeleteSlotTemplateRequest) (*deliverypb.DeleteSlotTemplateResponse, error) {
+	h.logger.Debug("DeleteSlotTemplate called", zap.String("template_id", req.Id))
+	return h.service.DeleteSlotTemplate(ctx, req)
+}
+
+func (h *Handler) ListSlotBlackouts(ctx context.Context, req *deliverypb.ListSlotBlackoutsRequest) (*deliverypb.ListSlotBlackoutsResponse, error) {
+	h.logger.Debug("ListSlotBlackouts called", zap.String("delivery_zone_id", req.DeliveryZoneId))
+	return h.service.ListSlotBlackouts(ctx, req)
+}
+
+func (h *Handler) CreateSlotBlackout(ctx context.Context, req *deliverypb.CreateSlotBlackoutRequest) (*deliverypb.CreateSlotBlackoutResponse, error) {
+	h.logger.Debug("CreateSlotBlackout called", zap.String("delivery_zone_id", req.GetBlackout().GetDeliveryZoneId()))
+	return h.service.CreateSlotBlackout(ctx, req)
+}
+
+func (h *Handler) DeleteSlotBlackout(ctx context.Context, req *deliverypb.DeleteSlotBlackoutRequest) (*deliverypb.DeleteSlotBlackoutResponse, error) {
+	h.logger.Debug("DeleteSlotBlackout called", zap.String("blackout_id", req.Id))
+	return h.service.DeleteSlotBlackout(ctx, req)
+}
+
+func (h *Handler) ListPickupPoints(ctx context.Context, req *deliverypb.ListPickupPointsRequest) (*deliverypb.ListPickupPointsResponse, error) {
+	h.logger.Debug("ListPickupPoints called", zap.String("postal_code", req.PostalCode))
+	return h.service.ListPickupPoints(ctx, req)
+}
+
+func (h *Handler) GetPickupPoint(ctx context.Context, req *deliverypb.GetPickupPointRequest) (*deliverypb.GetPickupPointResponse, error) {
+	h.logger.Debug("GetPickupPoint called", zap.String("pickup_point_id", req.Id))
+	return h.service.GetPickupPoint(ctx, req)
+}
+
+func (h *Handler) CreatePickupPoint(ctx context.Context, req *deliverypb.CreatePickupPointRequest) (*deliverypb.CreatePickupPointResponse, error) {
+	h.logger.Debug("CreatePickupPoint called", zap.String("code", req.GetPickupPoint().GetCode()))
+	return h.service.CreatePickupPoint(ctx, req)
+}
+
+func (h *Handler) UpdatePickupPoint(ctx context.Context, req *deliverypb.UpdatePickupPointRequest) (*deliverypb.UpdatePickupPointResponse, error) {
+	h.logger.Debug("UpdatePickupPoint called", zap.String("pickup_point_id", req.GetPickupPoint().GetId()))
+	return h.service.UpdatePickupPoint(ctx, req)
+}
+
+func (h *Handler) GenerateDeliverySlots(ctx context.Context, req *deliverypb.GenerateDeliverySlotsRequest) (*deliverypb.GenerateDeliverySlotsResponse, error) {
+	h.logger.Debug("GenerateDeliverySlots called", zap.String("delivery_zone_id", req.DeliveryZoneId))
+	return h.service.GenerateDeliverySlots(ctx, req)
+}
+
 func (h *Handler) ReserveDeliverySlot(ctx context.Context, req *deliverypb.ReserveDeliverySlotRequest) (*deliverypb.ReserveDeliverySlotResponse, error) {
 	h.logger.Debug("ReserveDeliverySlot called", zap.String("slot_id", req.SlotId))
 	return h.service.ReserveDeliverySlot(ctx, req)
 }
 
+func (h *Handler) ConfirmReservation(ctx context.Context, req *deliverypb.ConfirmReservationRequest) (*deliverypb.ConfirmReservationResponse, error) {
+	h.logger.Debug("ConfirmReservation called", zap.String("order_id", req.OrderId))
+	return h.service.ConfirmReservation(ctx, req)
+}
+
+func (h *Handler) ReleaseDeliverySlot(ctx context.Context, req *deliverypb.ReleaseDeliverySlotRequest) (*deliverypb.ReleaseDeliverySlotResponse, error) {
+	h.logger.Debug("ReleaseDeliverySlot called", zap.String("order_id", req.OrderId))
+	return h.service.ReleaseDeliverySlot(ctx, req)
+}
+
 func (h *Handler) GetShipment(ctx context.Context, req *deliverypb.GetShipmentRequest) (*deliverypb.GetShipmentResponse, error) {
 	h.logger.Debug("GetShipment called", zap.String("shipment_id", req.ShipmentId))
 	return h.service.GetShipment(ctx, req)
@@ -42,3 +122,70 @@ func (h *Handler) UpdateShipmentStatus(ctx context.Context, req *deliverypb.Upda
 	h.logger.Debug("UpdateShipmentStatus called", zap.String("shipment_id", req.ShipmentId))
 	return h.service.UpdateShipmentStatus(ctx, req)
 }
+
+func (h *Handler) AddTrackingEvent(ctx context.Context, req *deliverypb.AddTrackingEventRequest) (*deliverypb.AddTrackingEventResponse, error) {
+	h.logger.Debug("AddTrackingEvent called", zap.String("shipment_id", req.ShipmentId))
+	return h.service.AddTrackingEvent(ctx, req)
+}
+
+func (h *Handler) ListTrackingEvents(ctx context.Context, req *deliverypb.ListTrackingEventsRequest) (*deliverypb.ListTrackingEventsResponse, error) {
+	h.logger.Debug("ListTrackingEvents called",
+		zap.String("shipment_id", req.ShipmentId),
+		zap.String("order_id", req.OrderId))
+	return h.service.ListTrackingEvents(ctx, req)
+}
+
+func (h *Handler) DispatchShipment(ctx context.Context, req *deliverypb.DispatchShipmentRequest) (*deliverypb.DispatchShipmentResponse, error) {
+	h.logger.Debug("DispatchShipment called", zap.String("order_id", req.OrderId))
+	return h.service.DispatchShipment(ctx, req)
+}
+
+func (h *Handler) CancelShipment(ctx context.Context, req *deliverypb.CancelShipmentRequest) (*deliverypb.CancelShipmentResponse, error) {
+	h.logger.Debug("CancelShipment called", zap.String("shipment_id", req.ShipmentId))
+	return h.service.CancelShipment(ctx, req)
+}
+
+func (h *Handler) ConfirmPickup(ctx context.Context, req *deliverypb.ConfirmPickupRequest) (*deliverypb.ConfirmPickupResponse, error) {
+	h.logger.Debug("ConfirmPickup called", zap.String("shipment_id", req.ShipmentId))
+	return h.service.ConfirmPickup(ctx, req)
+}
+
+func (h *Handler) ValidateTrackingNumber(ctx context.Context, req *deliverypb.ValidateTrackingNumberRequest) (*deliverypb.ValidateTrackingNumberResponse, error) {
+	h.logger.Debug("ValidateTrackingNumber called", zap.String("carrier", req.Carrier))
+	return h.service.ValidateTrackingNumber(ctx, req)
+}
+
+func (h *Handler) GetShippingLabel(ctx context.Context, req *deliverypb.GetShippingLabelRequest) (*deliverypb.GetShippingLabelResponse, error) {
+	h.logger.Debug("GetShippingLabel called", zap.String("shipment_id", req.ShipmentId))
+	return h.service.GetShippingLabel(ctx, req)
+}
+
+func (h *Handler) GetShippingLabels(ctx context.Context, req *deliverypb.GetShippingLabelsRequest) (*deliverypb.GetShippingLabelsResponse, error) {
+	h.logger.Debug("GetShippingLabels called", zap.String("carrier", req.Carrier))
+	return h.service.GetShippingLabels(ctx, req)
+}
+
+func (h *Handler) ListClosureDays(ctx context.Context, req *deliverypb.ListClosureDaysRequest) (*deliverypb.ListClosureDaysResponse, error) {
+	h.logger.Debug("ListClosureDays called")
+	return h.service.ListClosureDays(ctx, req)
+}
+
+func (h *Handler) CreateClosureDay(ctx context.Context, req *deliverypb.CreateClosureDayRequest) (*deliverypb.CreateClosureDayResponse, error) {
+	h.logger.Debug("CreateClosureDay called", zap.Time("date", req.GetClosureDay().GetDate().AsTime()))
+	return h.service.CreateClosureDay(ctx, req)
+}
+
+func (h *Handler) DeleteClosureDay(ctx context.Context, req *deliverypb.DeleteClosureDayRequest) (*deliverypb.DeleteClosureDayResponse, error) {
+	h.logger.Debug("DeleteClosureDay called", zap.String("closure_day_id", req.Id))
+	return h.service.DeleteClosureDay(ctx, req)
+}
+
+func (h *Handler) QuoteShipping(ctx context.Context, req *deliverypb.QuoteShippingRequest) (*deliverypb.QuoteShippingResponse, error) {
+	h.logger.Debug("QuoteShipping called", zap.String("prefecture", req.Prefecture))
+	return h.service.QuoteShipping(ctx, req)
+}
+
+func (h *Handler) RequestCashOnDelivery(ctx context.Context, req *deliverypb.RequestCashOnDeliveryRequest) (*deliverypb.RequestCashOnDeliveryResponse, error) {
+	h.logger.Debug("RequestCashOnDelivery called", zap.String("order_id", req.OrderId))
+	return h.service.RequestCashOnDelivery(ctx, req)
+}
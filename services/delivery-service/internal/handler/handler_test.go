@@ -50,6 +50,238 @@ func (m *MockDeliveryService) UpdateShipmentStatus(ctx context.Context, req *del
 	return args.Get(0).(*sharedpb.Empty), args.Error(1)
 }
 
+func (m *MockDeliveryService) RequestCashOnDelivery(ctx context.Context, req *deliverypb.RequestCashOnDeliveryRequest) (*deliverypb.RequestCashOnDeliveryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.RequestCashOnDeliveryResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ResolveDeliveryZone(ctx context.Context, req *deliverypb.ResolveDeliveryZoneRequest) (*deliverypb.ResolveDeliveryZoneResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ResolveDeliveryZoneResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) GetDeliverySlotsForAddress(ctx context.Context, req *deliverypb.GetDeliverySlotsForAddressRequest) (*deliverypb.GetDeliverySlotsForAddressResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.GetDeliverySlotsForAddressResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ListSlotTemplates(ctx context.Context, req *deliverypb.ListSlotTemplatesRequest) (*deliverypb.ListSlotTemplatesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ListSlotTemplatesResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) CreateSlotTemplate(ctx context.Context, req *deliverypb.CreateSlotTemplateRequest) (*deliverypb.CreateSlotTemplateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.CreateSlotTemplateResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) UpdateSlotTemplate(ctx context.Context, req *deliverypb.UpdateSlotTemplateRequest) (*deliverypb.UpdateSlotTemplateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.UpdateSlotTemplateResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) DeleteSlotTemplate(ctx context.Context, req *deliverypb.DeleteSlotTemplateRequest) (*deliverypb.DeleteSlotTemplateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.DeleteSlotTemplateResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ListSlotBlackouts(ctx context.Context, req *deliverypb.ListSlotBlackoutsRequest) (*deliverypb.ListSlotBlackoutsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ListSlotBlackoutsResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) CreateSlotBlackout(ctx context.Context, req *deliverypb.CreateSlotBlackoutRequest) (*deliverypb.CreateSlotBlackoutResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.CreateSlotBlackoutResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) DeleteSlotBlackout(ctx context.Context, req *deliverypb.DeleteSlotBlackoutRequest) (*deliverypb.DeleteSlotBlackoutResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.DeleteSlotBlackoutResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) GenerateDeliverySlots(ctx context.Context, req *deliverypb.GenerateDeliverySlotsRequest) (*deliverypb.GenerateDeliverySlotsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.GenerateDeliverySlotsResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ConfirmReservation(ctx context.Context, req *deliverypb.ConfirmReservationRequest) (*deliverypb.ConfirmReservationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ConfirmReservationResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ReleaseDeliverySlot(ctx context.Context, req *deliverypb.ReleaseDeliverySlotRequest) (*deliverypb.ReleaseDeliverySlotResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ReleaseDeliverySlotResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) AddTrackingEvent(ctx context.Context, req *deliverypb.AddTrackingEventRequest) (*deliverypb.AddTrackingEventResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.AddTrackingEventResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ListTrackingEvents(ctx context.Context, req *deliverypb.ListTrackingEventsRequest) (*deliverypb.ListTrackingEventsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ListTrackingEventsResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) DispatchShipment(ctx context.Context, req *deliverypb.DispatchShipmentRequest) (*deliverypb.DispatchShipmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.DispatchShipmentResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) CancelShipment(ctx context.Context, req *deliverypb.CancelShipmentRequest) (*deliverypb.CancelShipmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.CancelShipmentResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ValidateTrackingNumber(ctx context.Context, req *deliverypb.ValidateTrackingNumberRequest) (*deliverypb.ValidateTrackingNumberResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ValidateTrackingNumberResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) GetShippingLabel(ctx context.Context, req *deliverypb.GetShippingLabelRequest) (*deliverypb.GetShippingLabelResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.GetShippingLabelResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) GetShippingLabels(ctx context.Context, req *deliverypb.GetShippingLabelsRequest) (*deliverypb.GetShippingLabelsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.GetShippingLabelsResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ListClosureDays(ctx context.Context, req *deliverypb.ListClosureDaysRequest) (*deliverypb.ListClosureDaysResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ListClosureDaysResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) CreateClosureDay(ctx context.Context, req *deliverypb.CreateClosureDayRequest) (*deliverypb.CreateClosureDayResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.CreateClosureDayResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) DeleteClosureDay(ctx context.Context, req *deliverypb.DeleteClosureDayRequest) (*deliverypb.DeleteClosureDayResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.DeleteClosureDayResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ListPickupPoints(ctx context.Context, req *deliverypb.ListPickupPointsRequest) (*deliverypb.ListPickupPointsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ListPickupPointsResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) GetPickupPoint(ctx context.Context, req *deliverypb.GetPickupPointRequest) (*deliverypb.GetPickupPointResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.GetPickupPointResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) CreatePickupPoint(ctx context.Context, req *deliverypb.CreatePickupPointRequest) (*deliverypb.CreatePickupPointResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.CreatePickupPointResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) UpdatePickupPoint(ctx context.Context, req *deliverypb.UpdatePickupPointRequest) (*deliverypb.UpdatePickupPointResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.UpdatePickupPointResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) ConfirmPickup(ctx context.Context, req *deliverypb.ConfirmPickupRequest) (*deliverypb.ConfirmPickupResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ConfirmPickupResponse), args.Error(1)
+}
+
+func (m *MockDeliveryService) QuoteShipping(ctx context.Context, req *deliverypb.QuoteShippingRequest) (*deliverypb.QuoteShippingResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.QuoteShippingResponse), args.Error(1)
+}
+
 func TestHandler_GetDeliverySlots(t *testing.T) {
 	logger := zap.NewNop()
 	mockService := new(MockDeliveryService)
@@ -0,0 +1,7 @@
+-- Name: add_cash_on_delivery
+-- Description: Drop cash on delivery columns from shipments
+
+ALTER TABLE delivery.shipments
+    DROP COLUMN IF EXISTS collected_amount_minor,
+    DROP COLUMN IF EXISTS cod_currency,
+    DROP COLUMN IF EXISTS cod_amount_minor;
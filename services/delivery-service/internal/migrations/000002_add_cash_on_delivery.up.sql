@@ -0,0 +1,11 @@
+-- Name: add_cash_on_delivery
+-- Description: Track cash to collect on delivery (代金引換) for shipments
+
+ALTER TABLE delivery.shipments
+    ADD COLUMN IF NOT EXISTS cod_amount_minor BIGINT,
+    ADD COLUMN IF NOT EXISTS cod_currency TEXT,
+    ADD COLUMN IF NOT EXISTS collected_amount_minor BIGINT;
+
+-- Comments
+COMMENT ON COLUMN delivery.shipments.cod_amount_minor IS 'Cash the courier collects from the recipient, COD fee included; NULL unless paid cash on delivery';
+COMMENT ON COLUMN delivery.shipments.collected_amount_minor IS 'Cash collected when the shipment was delivered';
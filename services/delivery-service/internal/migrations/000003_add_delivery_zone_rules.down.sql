@@ -0,0 +1,8 @@
+-- Name: add_delivery_zone_rules
+-- Description: Drop delivery zone flags
+
+DROP INDEX IF EXISTS delivery.idx_delivery_zones_prefectures;
+
+ALTER TABLE delivery.delivery_zones
+    DROP COLUMN IF EXISTS remote_island,
+    DROP COLUMN IF EXISTS undeliverable;
@@ -0,0 +1,15 @@
+-- Name: add_delivery_zone_rules
+-- Description: Flag undeliverable and remote island delivery zones
+
+ALTER TABLE delivery.delivery_zones
+    ADD COLUMN IF NOT EXISTS undeliverable BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS remote_island BOOLEAN NOT NULL DEFAULT false;
+
+-- Create indexes
+CREATE INDEX idx_delivery_zones_prefectures ON delivery.delivery_zones USING GIN (prefectures);
+
+-- Comments
+COMMENT ON COLUMN delivery.delivery_zones.postal_codes IS 'Postal code rules: 7 digits for one code, fewer digits for a prefix, or from~to for an inclusive range; empty matches any code';
+COMMENT ON COLUMN delivery.delivery_zones.prefectures IS 'Prefecture names such as 東京都; empty matches any prefecture';
+COMMENT ON COLUMN delivery.delivery_zones.undeliverable IS 'Addresses in the zone cannot be delivered to';
+COMMENT ON COLUMN delivery.delivery_zones.remote_island IS 'Remote island (離島) area';
@@ -0,0 +1,13 @@
+-- Name: create_slot_templates
+-- Description: Drop slot templates and blackouts
+
+DROP INDEX IF EXISTS delivery.idx_delivery_slots_template_id;
+DROP INDEX IF EXISTS delivery.idx_delivery_slots_zone_start;
+
+ALTER TABLE delivery.delivery_slots
+    DROP COLUMN IF EXISTS cutoff_at,
+    DROP COLUMN IF EXISTS time_window,
+    DROP COLUMN IF EXISTS template_id;
+
+DROP TABLE IF EXISTS delivery.slot_blackouts;
+DROP TABLE IF EXISTS delivery.slot_templates;
@@ -0,0 +1,49 @@
+-- Name: create_slot_templates
+-- Description: Weekly slot templates and blackout dates that delivery slots are generated from
+
+-- Create slot_templates table
+CREATE TABLE IF NOT EXISTS delivery.slot_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_zone_id UUID NOT NULL REFERENCES delivery.delivery_zones(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    time_window TEXT NOT NULL,
+    capacity INT NOT NULL CHECK (capacity > 0),
+    cutoff_days_before INT NOT NULL DEFAULT 1 CHECK (cutoff_days_before >= 0),
+    cutoff_time TIME NOT NULL DEFAULT '17:00',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (delivery_zone_id, weekday, time_window)
+);
+
+-- Create slot_blackouts table
+CREATE TABLE IF NOT EXISTS delivery.slot_blackouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_zone_id UUID REFERENCES delivery.delivery_zones(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE delivery.delivery_slots
+    ADD COLUMN IF NOT EXISTS template_id UUID REFERENCES delivery.slot_templates(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS time_window TEXT,
+    ADD COLUMN IF NOT EXISTS cutoff_at TIMESTAMP;
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_slot_blackouts_zone_date
+    ON delivery.slot_blackouts(COALESCE(delivery_zone_id, '00000000-0000-0000-0000-000000000000'::uuid), date);
+CREATE INDEX idx_slot_blackouts_date ON delivery.slot_blackouts(date);
+CREATE UNIQUE INDEX idx_delivery_slots_zone_start ON delivery.delivery_slots(delivery_zone_id, start_time);
+CREATE INDEX idx_delivery_slots_template_id ON delivery.delivery_slots(template_id) WHERE template_id IS NOT NULL;
+
+-- Comments
+COMMENT ON TABLE delivery.slot_templates IS 'Weekly delivery slot schedule per zone';
+COMMENT ON TABLE delivery.slot_blackouts IS 'Dates without delivery slots, per zone or for every zone';
+
+COMMENT ON COLUMN delivery.slot_templates.weekday IS '0 = Sunday through 6 = Saturday';
+COMMENT ON COLUMN delivery.slot_templates.time_window IS 'DeliveryTimeWindow enum name, e.g. DELIVERY_TIME_WINDOW_MORNING (午前中)';
+COMMENT ON COLUMN delivery.slot_templates.cutoff_time IS 'Orders for the slot close at this time (JST), cutoff_days_before days before delivery';
+COMMENT ON COLUMN delivery.slot_blackouts.delivery_zone_id IS 'NULL blacks out the date in every zone';
+COMMENT ON COLUMN delivery.delivery_slots.template_id IS 'Template the slot was generated from; NULL for slots added by hand';
+COMMENT ON COLUMN delivery.delivery_slots.start_time IS 'Start of the slot, JST wall clock';
+COMMENT ON COLUMN delivery.delivery_slots.cutoff_at IS 'Slot can no longer be booked from this time (JST wall clock)';
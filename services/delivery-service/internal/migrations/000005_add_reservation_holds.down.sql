@@ -0,0 +1,9 @@
+-- Name: add_reservation_holds
+-- Description: Drop delivery slot reservation holds
+
+DROP INDEX IF EXISTS delivery.idx_delivery_reservations_expires_at;
+
+ALTER TABLE delivery.delivery_reservations
+    DROP COLUMN IF EXISTS confirmed_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,20 @@
+-- Name: add_reservation_holds
+-- Description: Hold delivery slot reservations until the order is paid
+
+-- Existing reservations were permanent, so they start out confirmed
+ALTER TABLE delivery.delivery_reservations
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'RESERVATION_STATUS_CONFIRMED',
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP;
+
+ALTER TABLE delivery.delivery_reservations
+    ALTER COLUMN status SET DEFAULT 'RESERVATION_STATUS_HELD';
+
+-- Create indexes
+CREATE INDEX idx_delivery_reservations_expires_at
+    ON delivery.delivery_reservations(expires_at)
+    WHERE status = 'RESERVATION_STATUS_HELD';
+
+-- Comments
+COMMENT ON COLUMN delivery.delivery_reservations.status IS 'RESERVATION_STATUS_HELD until the order is paid, then RESERVATION_STATUS_CONFIRMED';
+COMMENT ON COLUMN delivery.delivery_reservations.expires_at IS 'When an unconfirmed hold lapses and its slot is freed; NULL once confirmed';
@@ -0,0 +1,4 @@
+-- Name: create_tracking_events
+-- Description: Drop tracking events
+
+DROP TABLE IF EXISTS delivery.tracking_events;
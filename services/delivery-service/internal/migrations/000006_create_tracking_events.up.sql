@@ -0,0 +1,22 @@
+-- Name: create_tracking_events
+-- Description: Tracking checkpoints recorded for each shipment
+
+-- Create tracking_events table
+CREATE TABLE IF NOT EXISTS delivery.tracking_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shipment_id UUID NOT NULL REFERENCES delivery.shipments(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    location TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_tracking_events_shipment_occurred ON delivery.tracking_events(shipment_id, occurred_at);
+
+-- Comments
+COMMENT ON TABLE delivery.tracking_events IS 'Shipment tracking timeline';
+
+COMMENT ON COLUMN delivery.tracking_events.status IS 'ShipmentStatus enum name for status changes, or the carrier''s checkpoint status';
+COMMENT ON COLUMN delivery.tracking_events.occurred_at IS 'When the checkpoint happened, which may be before it was recorded';
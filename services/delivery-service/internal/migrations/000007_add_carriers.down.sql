@@ -0,0 +1,18 @@
+-- Name: add_carriers
+-- Description: Drop carrier dispatch
+
+DROP INDEX IF EXISTS delivery.idx_shipments_tracked_at;
+DROP INDEX IF EXISTS delivery.idx_shipments_carrier_tracking_number;
+
+ALTER TABLE delivery.shipments
+    DROP COLUMN IF EXISTS tracked_at,
+    DROP COLUMN IF EXISTS dispatched_at,
+    DROP COLUMN IF EXISTS recipient,
+    DROP COLUMN IF EXISTS service_level,
+    ALTER COLUMN carrier DROP NOT NULL,
+    ALTER COLUMN carrier SET DEFAULT 'Shinkansen Logistics';
+
+UPDATE delivery.shipments SET carrier = 'Shinkansen Logistics' WHERE carrier = '';
+
+ALTER TABLE delivery.delivery_zones
+    DROP COLUMN IF EXISTS carrier;
@@ -0,0 +1,30 @@
+-- Name: add_carriers
+-- Description: Carrier dispatch of shipments and the carrier chosen per zone
+
+ALTER TABLE delivery.delivery_zones
+    ADD COLUMN IF NOT EXISTS carrier TEXT;
+
+-- Shipments get their carrier when dispatched; until then it is empty
+UPDATE delivery.shipments SET carrier = '' WHERE carrier IS NULL OR tracking_number IS NULL;
+
+ALTER TABLE delivery.shipments
+    ALTER COLUMN carrier SET DEFAULT '',
+    ALTER COLUMN carrier SET NOT NULL,
+    ADD COLUMN IF NOT EXISTS service_level TEXT NOT NULL DEFAULT 'SERVICE_LEVEL_STANDARD',
+    ADD COLUMN IF NOT EXISTS recipient JSONB,
+    ADD COLUMN IF NOT EXISTS dispatched_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS tracked_at TIMESTAMP;
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_shipments_carrier_tracking_number
+    ON delivery.shipments(carrier, tracking_number) WHERE tracking_number IS NOT NULL;
+CREATE INDEX idx_shipments_tracked_at ON delivery.shipments(tracked_at NULLS FIRST)
+    WHERE tracking_number IS NOT NULL
+      AND status IN ('SHIPMENT_STATUS_PREPARING', 'SHIPMENT_STATUS_SHIPPED', 'SHIPMENT_STATUS_IN_TRANSIT', 'SHIPMENT_STATUS_FAILED_DELIVERY');
+
+-- Comments
+COMMENT ON COLUMN delivery.delivery_zones.carrier IS 'Carrier code for shipments to the zone, e.g. japan_post; NULL uses the service level''s or the default carrier';
+COMMENT ON COLUMN delivery.shipments.carrier IS 'Carrier code, e.g. yamato; empty until dispatched';
+COMMENT ON COLUMN delivery.shipments.service_level IS 'ServiceLevel enum name, e.g. SERVICE_LEVEL_COOL_CHILLED';
+COMMENT ON COLUMN delivery.shipments.recipient IS 'Address the carrier delivers to, as JSONB';
+COMMENT ON COLUMN delivery.shipments.tracked_at IS 'Last time the carrier was polled for tracking updates';
@@ -0,0 +1,4 @@
+-- Name: add_shipments_dispatched_at_index
+-- Description: Drop the index for printing a day's shipping labels
+
+DROP INDEX IF EXISTS delivery.idx_shipments_dispatched_at;
@@ -0,0 +1,5 @@
+-- Name: add_shipments_dispatched_at_index
+-- Description: Index for printing a day's shipping labels
+
+CREATE INDEX idx_shipments_dispatched_at ON delivery.shipments(dispatched_at)
+    WHERE dispatched_at IS NOT NULL;
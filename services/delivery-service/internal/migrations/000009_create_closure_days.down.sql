@@ -0,0 +1,4 @@
+-- Name: create_closure_days
+-- Description: Drop closure days
+
+DROP TABLE IF EXISTS delivery.closure_days;
@@ -0,0 +1,12 @@
+-- Name: create_closure_days
+-- Description: Days the warehouse does not ship, counted out of delivery estimates
+
+CREATE TABLE IF NOT EXISTS delivery.closure_days (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    date DATE NOT NULL UNIQUE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Comments
+COMMENT ON TABLE delivery.closure_days IS 'Days closed on top of weekends, national holidays and the New Year, Golden Week and Obon breaks';
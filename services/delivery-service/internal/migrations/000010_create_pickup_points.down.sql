@@ -0,0 +1,11 @@
+-- Name: create_pickup_points
+-- Description: Drop pickup points
+
+DROP INDEX IF EXISTS delivery.idx_shipments_pickup_deadline_at;
+
+ALTER TABLE delivery.shipments
+    DROP COLUMN IF EXISTS pickup_deadline_at,
+    DROP COLUMN IF EXISTS pickup_code,
+    DROP COLUMN IF EXISTS pickup_point_id;
+
+DROP TABLE IF EXISTS delivery.pickup_points;
@@ -0,0 +1,40 @@
+-- Name: create_pickup_points
+-- Description: Konbini counters and parcel lockers shipments can be sent to for collection
+
+CREATE TABLE IF NOT EXISTS delivery.pickup_points (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    operator TEXT NOT NULL DEFAULT '',
+    postal_code TEXT NOT NULL,
+    prefecture TEXT NOT NULL,
+    city TEXT NOT NULL,
+    address_line1 TEXT NOT NULL,
+    address_line2 TEXT NOT NULL DEFAULT '',
+    opening_hours TEXT NOT NULL DEFAULT '',
+    hold_days INTEGER NOT NULL CHECK (hold_days > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE delivery.shipments
+    ADD COLUMN IF NOT EXISTS pickup_point_id UUID REFERENCES delivery.pickup_points(id),
+    ADD COLUMN IF NOT EXISTS pickup_code TEXT,
+    ADD COLUMN IF NOT EXISTS pickup_deadline_at TIMESTAMP;
+
+-- Create indexes
+CREATE INDEX idx_pickup_points_postal_area ON delivery.pickup_points(left(postal_code, 3), postal_code) WHERE active;
+CREATE INDEX idx_shipments_pickup_deadline_at ON delivery.shipments(pickup_deadline_at)
+    WHERE status = 'SHIPMENT_STATUS_READY_FOR_PICKUP';
+
+-- Comments
+COMMENT ON TABLE delivery.pickup_points IS 'Konbini counters and parcel lockers customers collect orders from';
+COMMENT ON COLUMN delivery.pickup_points.code IS 'The point''s code in its operator''s network, passed to the carrier';
+COMMENT ON COLUMN delivery.pickup_points.type IS 'PickupPointType enum name, e.g. PICKUP_POINT_TYPE_LOCKER';
+COMMENT ON COLUMN delivery.pickup_points.postal_code IS '7-digit postal code without a hyphen';
+COMMENT ON COLUMN delivery.pickup_points.hold_days IS 'Days a parcel is kept for collection before it is sent back';
+COMMENT ON COLUMN delivery.shipments.pickup_point_id IS 'Pickup point the shipment is sent to; NULL when it is delivered';
+COMMENT ON COLUMN delivery.shipments.pickup_code IS 'Code the recipient gives to collect the parcel';
+COMMENT ON COLUMN delivery.shipments.pickup_deadline_at IS 'End of the last day the parcel is kept, set once it is ready for pickup';
@@ -0,0 +1,51 @@
+package barcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodabar(t *testing.T) {
+	widths, err := Codabar('A', "1", 'B')
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{
+		1, 1, 3, 3, 1, 3, 1, // A
+		1,
+		1, 1, 1, 1, 3, 3, 1, // 1
+		1,
+		1, 3, 1, 3, 1, 1, 3, // B
+	}, widths)
+}
+
+func TestCodabar_Length(t *testing.T) {
+	widths, err := Codabar('D', "400000000013", 'D')
+	require.NoError(t, err)
+
+	// 14 characters of 7 elements with 13 gaps, ending on a bar
+	assert.Len(t, widths, 14*7+13)
+	assert.Equal(t, 1, len(widths)%2)
+}
+
+func TestCodabar_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		start byte
+		data  string
+		stop  byte
+	}{
+		{"bad start", 'E', "123", 'A'},
+		{"bad stop", 'A', "123", '1'},
+		{"empty", 'A', "", 'A'},
+		{"letter", 'A', "12X", 'A'},
+		{"guard inside data", 'A', "1B2", 'A'},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Codabar(tt.start, tt.data, tt.stop)
+			assert.ErrorIs(t, err, ErrInvalidCodabar)
+		})
+	}
+}
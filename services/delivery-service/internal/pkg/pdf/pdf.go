@@ -0,0 +1,249 @@
+// Package pdf writes simple PDF documents of text, lines and boxes.
+//
+// Japanese text is set in the PDF standard Japanese fonts (Adobe-Japan1
+// HeiseiKakuGo-W5 and HeiseiMin-W3), which PDF viewers and printers supply
+// themselves, so no font file has to be embedded.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Font is one of the fonts a document can use
+type Font int
+
+const (
+	// Gothic is a Japanese sans-serif font (角ゴシック)
+	Gothic Font = iota
+	// Mincho is a Japanese serif font (明朝)
+	Mincho
+	// Helvetica is a Latin font for digits and ASCII text
+	Helvetica
+	// HelveticaBold is the bold Helvetica
+	HelveticaBold
+)
+
+// fontResource is how a font is declared in the document
+type fontResource struct {
+	name string
+	// baseFont of a CID font; empty for Latin fonts
+	cidFont string
+	latin   string
+}
+
+var fonts = map[Font]fontResource{
+	Gothic:        {name: "F1", cidFont: "HeiseiKakuGo-W5"},
+	Mincho:        {name: "F2", cidFont: "HeiseiMin-W3"},
+	Helvetica:     {name: "F3", latin: "Helvetica"},
+	HelveticaBold: {name: "F4", latin: "Helvetica-Bold"},
+}
+
+// fontOrder fixes the order fonts are written in
+var fontOrder = []Font{Gothic, Mincho, Helvetica, HelveticaBold}
+
+// mmToPt converts millimetres to PDF points
+const mmToPt = 72 / 25.4
+
+// Document is a PDF document being built
+type Document struct {
+	pages []*Page
+}
+
+// New creates an empty document
+func New() *Document {
+	return &Document{}
+}
+
+// Page is a page of a document. Positions are in millimetres from the
+// page's top left corner.
+type Page struct {
+	width, height float64
+	content       bytes.Buffer
+}
+
+// AddPage adds a page of the given size in millimetres
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{width: width, height: height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// PageCount returns the number of pages
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text writes text with its baseline at x, y in the font and size in points
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	res := fonts[font]
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td %s Tj ET\n",
+		res.name, num(size), num(x*mmToPt), num((p.height-y)*mmToPt), encodeText(res, text))
+}
+
+// Line draws a line of the given width in millimetres
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width*mmToPt), num(x1*mmToPt), num((p.height-y1)*mmToPt), num(x2*mmToPt), num((p.height-y2)*mmToPt))
+}
+
+// Rect outlines a box whose top left corner is at x, y
+func (p *Page) Rect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(lineWidth*mmToPt), num(x*mmToPt), num((p.height-y-h)*mmToPt), num(w*mmToPt), num(h*mmToPt))
+}
+
+// FillRect fills a box whose top left corner is at x, y in black
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n",
+		num(x*mmToPt), num((p.height-y-h)*mmToPt), num(w*mmToPt), num(h*mmToPt))
+}
+
+// TextWidth estimates the width in millimetres of text in a font and size.
+// Japanese characters are full width and ASCII half width; Helvetica is
+// taken as 0.56 em per character, which suits digits.
+func TextWidth(font Font, size float64, text string) float64 {
+	em := 0.0
+	for _, r := range text {
+		switch {
+		case fonts[font].cidFont == "":
+			em += 0.56
+		case r < 0x80 || (r >= 0xFF61 && r <= 0xFF9F):
+			em += 0.5
+		default:
+			em++
+		}
+	}
+	return em * size / mmToPt
+}
+
+// WriteTo writes the document as PDF
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+	// Objects are numbered from 1 in the order they are written
+	object := func(body string) int {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+		return len(offsets)
+	}
+	stream := func(dict string, data []byte) int {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dict, len(data))
+		buf.Write(data)
+		buf.WriteString("\nendstream\nendobj\n")
+		return len(offsets)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	const catalogID, pagesID = 1, 2
+	object(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+	// The page tree is written once the page objects are numbered
+	pagesOffset := len(offsets)
+	offsets = append(offsets, 0)
+
+	var fontRefs strings.Builder
+	for _, f := range fontOrder {
+		res := fonts[f]
+		var id int
+		if res.cidFont == "" {
+			id = object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", res.latin))
+		} else {
+			descriptor := object(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [-92 -250 1010 922] "+
+				"/ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>", res.cidFont))
+			descendant := object(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s "+
+				"/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> "+
+				"/FontDescriptor %d 0 R /DW 1000 /W [1 95 500 231 632 500] >>", res.cidFont, descriptor))
+			id = object(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniJIS-UCS2-H "+
+				"/DescendantFonts [%d 0 R] >>", res.cidFont, descendant))
+		}
+		fmt.Fprintf(&fontRefs, "/%s %d 0 R ", res.name, id)
+	}
+	resources := object(fmt.Sprintf("<< /Font << %s>> >>", fontRefs.String()))
+
+	var kids []string
+	for _, p := range d.pages {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		content := stream("/Filter /FlateDecode", compressed.Bytes())
+		page := object(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %d 0 R /Contents %d 0 R >>",
+			pagesID, num(p.width*mmToPt), num(p.height*mmToPt), resources, content))
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+
+	// Write the page tree last and point its xref entry at it
+	offsets[pagesOffset] = buf.Len()
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n",
+		pagesID, strings.Join(kids, " "), len(d.pages))
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalogID, xref)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// Bytes returns the document as PDF
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeText writes text as a PDF string for the font: UTF-16 hex for the
+// Japanese fonts, and an escaped literal for Helvetica, which drops
+// characters outside Latin-1
+func encodeText(res fontResource, text string) string {
+	if res.cidFont != "" {
+		var b strings.Builder
+		b.WriteByte('<')
+		for _, u := range utf16.Encode([]rune(text)) {
+			fmt.Fprintf(&b, "%04X", u)
+		}
+		b.WriteByte('>')
+		return b.String()
+	}
+
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7F:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// num formats a number with at most two decimals
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New()
+	page := doc.AddPage(105, 148)
+	page.Text(5, 10, Gothic, 12, "お届け先")
+	page.Text(5, 20, Helvetica, 10, "4000-0000-0013")
+	page.FillRect(5, 30, 0.5, 10)
+	doc.AddPage(105, 148)
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), "/BaseFont /HeiseiKakuGo-W5")
+	assert.Contains(t, string(out), "/Encoding /UniJIS-UCS2-H")
+
+	// Every xref entry points at its object
+	xref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	require.NotNil(t, xref)
+	start, _ := strconv.Atoi(string(xref[1]))
+	require.True(t, bytes.HasPrefix(out[start:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[start:], -1)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		assert.True(t, bytes.HasPrefix(out[off:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	assert.Equal(t, "<304A5C4A305151480030>", encodeText(fonts[Gothic], "お届け先0"))
+	assert.Equal(t, `(a\(b\)c)`, encodeText(fonts[Helvetica], "a(b)c"))
+	assert.Equal(t, `(\245100)`, encodeText(fonts[Helvetica], "¥100"))
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.InDelta(t, TextWidth(Gothic, 10, "あ"), 2*TextWidth(Gothic, 10, "a"), 0.001)
+	assert.Greater(t, TextWidth(Helvetica, 10, "123"), 0.0)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// carrierRequestTimeout bounds each call to a carrier's API
+const carrierRequestTimeout = 15 * time.Second
+
+// CarrierAPIConfig is how an adapter reaches a carrier's business API
+type CarrierAPIConfig struct {
+	BaseURL string
+	APIKey  string
+	// Account the shipments are billed to (お客様コード)
+	CustomerCode string
+}
+
+// carrierAPI sends JSON requests to a carrier's API
+type carrierAPI struct {
+	config CarrierAPIConfig
+	client *http.Client
+	// authorize adds the carrier's authentication to a request
+	authorize func(req *http.Request, config CarrierAPIConfig)
+}
+
+func newCarrierAPI(config CarrierAPIConfig, authorize func(*http.Request, CarrierAPIConfig)) carrierAPI {
+	return carrierAPI{
+		config:    config,
+		client:    &http.Client{Timeout: carrierRequestTimeout},
+		authorize: authorize,
+	}
+}
+
+// do sends body, if not nil, as JSON and decodes the response into out, if
+// not nil. 404 is returned as ErrCarrierShipmentNotFound and 409 as
+// ErrCarrierNotCancellable.
+func (a carrierAPI) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(a.config.BaseURL, "/")+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	a.authorize(req, a.config)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrCarrierShipmentNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return ErrCarrierNotCancellable
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("carrier returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode carrier response: %w", err)
+	}
+	return nil
+}
+
+// parseCarrierTime reads a JST timestamp in the carrier's layout
+func parseCarrierTime(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, tokyo)
+}
+
+// carrierDate formats a time as a date in Tokyo in the carrier's layout
+func carrierDate(layout string, t time.Time) string {
+	return t.In(tokyo).Format(layout)
+}
+
+// carrierStatusText maps the start of a carrier's status text onto a
+// shipment status
+type carrierStatusText struct {
+	prefix string
+	status deliverypb.ShipmentStatus
+}
+
+// matchCarrierStatus returns the status of the first entry the text starts
+// with, or UNSPECIFIED
+func matchCarrierStatus(statuses []carrierStatusText, text string) deliverypb.ShipmentStatus {
+	text = strings.TrimSpace(text)
+	for _, s := range statuses {
+		if strings.HasPrefix(text, s.prefix) {
+			return s.status
+		}
+	}
+	return deliverypb.ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED
+}
+
+// joinAddress writes an address as the single line some carriers take
+func joinAddress(parts ...string) string {
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(strings.TrimSpace(p))
+	}
+	return b.String()
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeCarrierStage is a checkpoint the fake carrier server reports
+type fakeCarrierStage struct {
+	status string
+	office string
+}
+
+// fakeCarrierRoute is how a carrier's parcels move in the fake server; the
+// last stage is replaced by absent when the recipient is not at home
+type fakeCarrierRoute struct {
+	// First 11 digits of the tracking numbers handed out
+	base   int64
+	stages []fakeCarrierStage
+	absent fakeCarrierStage
+	cancel fakeCarrierStage
+}
+
+var fakeCarrierRoutes = map[string]fakeCarrierRoute{
+	CarrierYamato: {
+		base: 40000000000,
+		stages: []fakeCarrierStage{
+			{"荷物受付", "羽田クロノゲートベース"},
+			{"作業店通過", "東京ベース店"},
+			{"配達中", "世田谷センター"},
+			{"配達完了", "世田谷センター"},
+		},
+		absent: fakeCarrierStage{"持戻（ご不在）", "世田谷センター"},
+		cancel: fakeCarrierStage{"取消", "羽田クロノゲートベース"},
+	},
+	CarrierSagawa: {
+		base: 36000000000,
+		stages: []fakeCarrierStage{
+			{"集荷", "東京営業所"},
+			{"輸送中", "東京営業所"},
+			{"配達中", "世田谷営業所"},
+			{"配達完了", "世田谷営業所"},
+		},
+		absent: fakeCarrierStage{"ご不在のため持戻", "世田谷営業所"},
+		cancel: fakeCarrierStage{"取消", "東京営業所"},
+	},
+	CarrierJapanPost: {
+		base: 11000000000,
+		stages: []fakeCarrierStage{
+			{"引受", "新東京郵便局"},
+			{"到着", "世田谷郵便局"},
+			{"持ち出し中", "世田谷郵便局"},
+			{"お届け先にお届け済み", "世田谷郵便局"},
+		},
+		absent: fakeCarrierStage{"ご不在のため持ち戻り", "世田谷郵便局"},
+		cancel: fakeCarrierStage{"引受取消", "新東京郵便局"},
+	},
+}
+
+// fakeCarrierShipment is a parcel registered with the fake server
+type fakeCarrierShipment struct {
+	carrier   string
+	request   json.RawMessage
+	absent    bool
+	createdAt time.Time
+	// Times the parcel reached each stage so far
+	reached   []time.Time
+	cancelled *time.Time
+}
+
+// FakeCarrierServer emulates the Yamato, Sagawa and Japan Post APIs in
+// memory, under /yamato, /sagawa and /japan-post. Parcels move one stage
+// every progressEvery, or on Advance when it is zero. Parcels for a
+// recipient whose name contains 不在 end up back at the depot instead of
+// delivered.
+type FakeCarrierServer struct {
+	mu            sync.Mutex
+	mux           *http.ServeMux
+	progressEvery time.Duration
+	now           func() time.Time
+	shipments     map[string]*fakeCarrierShipment
+	issued        map[string]int64
+}
+
+// NewFakeCarrierServer creates a fake carrier server
+func NewFakeCarrierServer(progressEvery time.Duration) *FakeCarrierServer {
+	s := &FakeCarrierServer{
+		mux:           http.NewServeMux(),
+		progressEvery: progressEvery,
+		now:           time.Now,
+		shipments:     make(map[string]*fakeCarrierShipment),
+		issued:        make(map[string]int64),
+	}
+
+	s.mux.HandleFunc("POST /yamato/b2/v1/shipments", s.createYamato)
+	s.mux.HandleFunc("GET /yamato/b2/v1/shipments/{number}/tracking", s.trackYamato)
+	s.mux.HandleFunc("DELETE /yamato/b2/v1/shipments/{number}", s.cancelHandler(CarrierYamato))
+
+	s.mux.HandleFunc("POST /sagawa/api/v1/orders", s.createSagawa)
+	s.mux.HandleFunc("GET /sagawa/api/v1/tracking", s.trackSagawa)
+	s.mux.HandleFunc("POST /sagawa/api/v1/orders/{number}/cancel", s.cancelHandler(CarrierSagawa))
+
+	s.mux.HandleFunc("POST /japan-post/yupack/v1/labels", s.createJapanPost)
+	s.mux.HandleFunc("GET /japan-post/yupack/v1/tracking/{number}", s.trackJapanPost)
+	s.mux.HandleFunc("DELETE /japan-post/yupack/v1/labels/{number}", s.cancelHandler(CarrierJapanPost))
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *FakeCarrierServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Advance moves a parcel to its next stage
+func (s *FakeCarrierServer) Advance(trackingNumber string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shipment, ok := s.shipments[trackingNumber]
+	if !ok || shipment.cancelled != nil {
+		return false
+	}
+	s.catchUp(shipment)
+	if len(shipment.reached) == len(fakeCarrierRoutes[shipment.carrier].stages) {
+		return false
+	}
+	shipment.reached = append(shipment.reached, s.now())
+	return true
+}
+
+// Request returns the JSON body a parcel was registered with
+func (s *FakeCarrierServer) Request(trackingNumber string) (json.RawMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shipment, ok := s.shipments[trackingNumber]
+	if !ok {
+		return nil, false
+	}
+	return shipment.request, true
+}
+
+// register stores a parcel and returns its tracking number
+func (s *FakeCarrierServer) register(carrier string, request json.RawMessage, recipientName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.issued[carrier]++
+	serial := fmt.Sprintf("%011d", fakeCarrierRoutes[carrier].base+s.issued[carrier])
+	trackingNumber := fmt.Sprintf("%s%d", serial, mod7CheckDigit(serial))
+
+	s.shipments[trackingNumber] = &fakeCarrierShipment{
+		carrier:   carrier,
+		request:   request,
+		absent:    strings.Contains(recipientName, "不在"),
+		createdAt: s.now(),
+	}
+	return trackingNumber
+}
+
+// catchUp moves a parcel through the stages it reached by now
+func (s *FakeCarrierServer) catchUp(shipment *fakeCarrierShipment) {
+	if s.progressEvery <= 0 || shipment.cancelled != nil {
+		return
+	}
+	stages := len(fakeCarrierRoutes[shipment.carrier].stages)
+	for len(shipment.reached) < stages {
+		last := shipment.createdAt
+		if n := len(shipment.reached); n > 0 {
+			last = shipment.reached[n-1]
+		}
+		next := last.Add(s.progressEvery)
+		if next.After(s.now()) {
+			return
+		}
+		shipment.reached = append(shipment.reached, next)
+	}
+}
+
+// fakeCarrierCheckpoint is a stage a parcel reached and when
+type fakeCarrierCheckpoint struct {
+	fakeCarrierStage
+	at time.Time
+}
+
+// checkpoints returns the parcel's checkpoints so far, or false if the
+// carrier does not know it
+func (s *FakeCarrierServer) checkpoints(carrier, trackingNumber string) ([]fakeCarrierCheckpoint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shipment, ok := s.shipments[trackingNumber]
+	if !ok || shipment.carrier != carrier {
+		return nil, false
+	}
+	s.catchUp(shipment)
+
+	route := fakeCarrierRoutes[carrier]
+	checkpoints := make([]fakeCarrierCheckpoint, 0, len(shipment.reached)+1)
+	for i, at := range shipment.reached {
+		stage := route.stages[i]
+		if shipment.absent && i == len(route.stages)-1 {
+			stage = route.absent
+		}
+		checkpoints = append(checkpoints, fakeCarrierCheckpoint{stage, at})
+	}
+	if shipment.cancelled != nil {
+		checkpoints = append(checkpoints, fakeCarrierCheckpoint{route.cancel, *shipment.cancelled})
+	}
+	return checkpoints, true
+}
+
+// cancelHandler cancels a parcel that has not been picked up
+func (s *FakeCarrierServer) cancelHandler(carrier string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		shipment, ok := s.shipments[r.PathValue("number")]
+		if !ok || shipment.carrier != carrier {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		s.catchUp(shipment)
+		if shipment.cancelled == nil {
+			if len(shipment.reached) > 0 {
+				http.Error(w, "already picked up", http.StatusConflict)
+				return
+			}
+			now := s.now()
+			shipment.cancelled = &now
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *FakeCarrierServer) createYamato(w http.ResponseWriter, r *http.Request) {
+	var req yamatoShipmentRequest
+	raw, ok := decodeFakeCarrierRequest(w, r, &req)
+	if !ok {
+		return
+	}
+	if req.Consignee.Name == "" || req.Consignee.ZipCode == "" {
+		http.Error(w, "consignee is required", http.StatusBadRequest)
+		return
+	}
+
+	writeFakeCarrierJSON(w, yamatoShipmentResponse{
+		SlipNumber:   s.register(CarrierYamato, raw, req.Consignee.Name),
+		DeliveryDate: req.DeliveryDate,
+	})
+}
+
+func (s *FakeCarrierServer) trackYamato(w http.ResponseWriter, r *http.Request) {
+	number := r.PathValue("number")
+	checkpoints, ok := s.checkpoints(CarrierYamato, number)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	resp := yamatoTrackingResponse{SlipNumber: number, Events: []yamatoTrackingEvent{}}
+	for _, c := range checkpoints {
+		at := c.at.In(tokyo)
+		resp.Events = append(resp.Events, yamatoTrackingEvent{
+			Status: c.status,
+			Date:   at.Format("2006/01/02"),
+			Time:   at.Format("15:04"),
+			Office: c.office,
+		})
+	}
+	writeFakeCarrierJSON(w, resp)
+}
+
+func (s *FakeCarrierServer) createSagawa(w http.ResponseWriter, r *http.Request) {
+	var req sagawaOrderRequest
+	raw, ok := decodeFakeCarrierRequest(w, r, &req)
+	if !ok {
+		return
+	}
+	if req.Todokesaki.Name == "" || req.Todokesaki.YubinBango == "" {
+		http.Error(w, "todokesaki is required", http.StatusBadRequest)
+		return
+	}
+
+	writeFakeCarrierJSON(w, sagawaOrderResponse{
+		ToiawaseNo:        s.register(CarrierSagawa, raw, req.Todokesaki.Name),
+		HaitatsuYoteiDate: req.HaitatsuShiteiDate,
+	})
+}
+
+func (s *FakeCarrierServer) trackSagawa(w http.ResponseWriter, r *http.Request) {
+	number := r.URL.Query().Get("toiawase_no")
+	checkpoints, ok := s.checkpoints(CarrierSagawa, number)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	resp := sagawaTrackingResponse{ToiawaseNo: number, Details: []sagawaTrackingDetail{}}
+	for _, c := range checkpoints {
+		resp.Details = append(resp.Details, sagawaTrackingDetail{
+			Jokyo:   c.status,
+			Nichiji: c.at.In(tokyo).Format(time.RFC3339),
+			Tenmei:  c.office,
+		})
+	}
+	writeFakeCarrierJSON(w, resp)
+}
+
+func (s *FakeCarrierServer) createJapanPost(w http.ResponseWriter, r *http.Request) {
+	var req japanPostLabelRequest
+	raw, ok := decodeFakeCarrierRequest(w, r, &req)
+	if !ok {
+		return
+	}
+	if req.Recipient.Name == "" || req.Recipient.PostalCode == "" {
+		http.Error(w, "recipient is required", http.StatusBadRequest)
+		return
+	}
+	if req.Product != japanPostProductYupack && req.Product != japanPostProductChilledYupack {
+		http.Error(w, "unknown product", http.StatusBadRequest)
+		return
+	}
+
+	writeFakeCarrierJSON(w, japanPostLabelResponse{
+		InquiryNumber:         s.register(CarrierJapanPost, raw, req.Recipient.Name),
+		EstimatedDeliveryDate: req.DeliveryDate,
+	})
+}
+
+func (s *FakeCarrierServer) trackJapanPost(w http.ResponseWriter, r *http.Request) {
+	number := r.PathValue("number")
+	checkpoints, ok := s.checkpoints(CarrierJapanPost, number)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	resp := japanPostTrackingResponse{InquiryNumber: number, History: []japanPostHistory{}}
+	for _, c := range checkpoints {
+		resp.History = append(resp.History, japanPostHistory{
+			State:      c.status,
+			Datetime:   c.at.In(tokyo).Format("2006-01-02 15:04"),
+			Office:     c.office,
+			Prefecture: "東京都",
+		})
+	}
+	writeFakeCarrierJSON(w, resp)
+}
+
+func decodeFakeCarrierRequest(w http.ResponseWriter, r *http.Request, v interface{}) (json.RawMessage, bool) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		http.Error(w, "malformed JSON", http.StatusBadRequest)
+		return nil, false
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		http.Error(w, "malformed JSON", http.StatusBadRequest)
+		return nil, false
+	}
+	return raw, true
+}
+
+func writeFakeCarrierJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// japanPostTrackingNumberLength is the length of a ゆうパック お問い合わせ番号
+const japanPostTrackingNumberLength = 12
+
+// japanPostS10Length is the length of an S10 item number such as
+// EJ123456785JP, used by EMS and international mail
+const japanPostS10Length = 13
+
+// Japan Post products
+const (
+	japanPostProductYupack        = "yupack"
+	japanPostProductChilledYupack = "chilled_yupack"
+)
+
+// japanPostTimeCodes are the ゆうパック 配達時間帯 codes
+var japanPostTimeCodes = map[deliverypb.DeliveryTimeWindow]string{
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_MORNING: "51",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_14_16:   "53",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_16_18:   "54",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20:   "55",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_19_21:   "57",
+}
+
+// japanPostStatuses lists 引受取消 before 引受, which it starts with
+var japanPostStatuses = []carrierStatusText{
+	{"引受取消", deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED},
+	{"引受", deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED},
+	{"通過", deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT},
+	{"到着", deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT},
+	{"持ち出し中", deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT},
+	{"お届け先にお届け済み", deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED},
+	{"窓口でお渡し", deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED},
+	{"ご不在のため持ち戻り", deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY},
+	{"保管", deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY},
+	{"返送", deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY},
+}
+
+type japanPostAddress struct {
+	Name       string `json:"name"`
+	Phone      string `json:"phone"`
+	PostalCode string `json:"postal_code"`
+	Prefecture string `json:"prefecture"`
+	City       string `json:"city"`
+	Address1   string `json:"address1"`
+	Address2   string `json:"address2,omitempty"`
+}
+
+type japanPostLabelRequest struct {
+	CustomerCode         string           `json:"customer_code"`
+	Product              string           `json:"product"`
+	ShippingDate         string           `json:"shipping_date"`
+	DeliveryDate         string           `json:"delivery_date,omitempty"`
+	DeliveryTimeCode     string           `json:"delivery_time_code,omitempty"`
+	CashOnDeliveryAmount int64            `json:"cash_on_delivery_amount,omitempty"`
+	ReferenceNumber      string           `json:"reference_number"`
+	Recipient            japanPostAddress `json:"recipient"`
+	Sender               japanPostAddress `json:"sender"`
+	// Post office, konbini or locker the parcel is left at (窓口・店頭受取)
+	PickupPointCode string `json:"pickup_point_code,omitempty"`
+}
+
+type japanPostLabelResponse struct {
+	InquiryNumber         string `json:"inquiry_number"`
+	EstimatedDeliveryDate string `json:"estimated_delivery_date"`
+}
+
+type japanPostHistory struct {
+	State      string `json:"state"`
+	Datetime   string `json:"datetime"`
+	Office     string `json:"office"`
+	Prefecture string `json:"prefecture"`
+}
+
+type japanPostTrackingResponse struct {
+	InquiryNumber string             `json:"inquiry_number"`
+	History       []japanPostHistory `json:"history"`
+}
+
+// JapanPostCarrier ships with 日本郵便 ゆうパック
+type JapanPostCarrier struct {
+	api carrierAPI
+}
+
+// NewJapanPostCarrier creates the Japan Post adapter
+func NewJapanPostCarrier(config CarrierAPIConfig) *JapanPostCarrier {
+	return &JapanPostCarrier{api: newCarrierAPI(config, func(req *http.Request, config CarrierAPIConfig) {
+		req.Header.Set("Authorization", "Bearer "+config.APIKey)
+	})}
+}
+
+// Code implements Carrier
+func (c *JapanPostCarrier) Code() string {
+	return CarrierJapanPost
+}
+
+// Name implements Carrier
+func (c *JapanPostCarrier) Name() string {
+	return "日本郵便"
+}
+
+// SupportsServiceLevel implements Carrier. チルドゆうパック is chilled
+// only, and ゆうパック has no faster service.
+func (c *JapanPostCarrier) SupportsServiceLevel(level deliverypb.ServiceLevel) bool {
+	return level == deliverypb.ServiceLevel_SERVICE_LEVEL_STANDARD ||
+		level == deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED
+}
+
+// CreateShipment implements Carrier
+func (c *JapanPostCarrier) CreateShipment(ctx context.Context, req CarrierShipmentRequest) (CarrierShipment, error) {
+	if !c.SupportsServiceLevel(req.ServiceLevel) {
+		return CarrierShipment{}, fmt.Errorf("japan post: %s is not offered", req.ServiceLevel)
+	}
+
+	body := japanPostLabelRequest{
+		CustomerCode:         c.api.config.CustomerCode,
+		Product:              japanPostProductYupack,
+		ShippingDate:         carrierDate("2006-01-02", req.ShipDate),
+		DeliveryTimeCode:     japanPostTimeCodes[req.TimeWindow],
+		CashOnDeliveryAmount: req.CODAmountMinor,
+		ReferenceNumber:      req.OrderID,
+		Recipient:            japanPostAddressFrom(req.Recipient),
+		Sender:               japanPostAddressFrom(req.Sender),
+		PickupPointCode:      req.PickupPointCode,
+	}
+	if req.ServiceLevel == deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED {
+		body.Product = japanPostProductChilledYupack
+	}
+	if req.DeliveryDate != nil {
+		body.DeliveryDate = carrierDate("2006-01-02", *req.DeliveryDate)
+	}
+
+	var resp japanPostLabelResponse
+	if err := c.api.do(ctx, http.MethodPost, "/yupack/v1/labels", body, &resp); err != nil {
+		return CarrierShipment{}, fmt.Errorf("japan post: failed to create shipment: %w", err)
+	}
+
+	shipment := CarrierShipment{TrackingNumber: resp.InquiryNumber}
+	if resp.EstimatedDeliveryDate != "" {
+		if date, err := parseCarrierTime("2006-01-02", resp.EstimatedDeliveryDate); err == nil {
+			shipment.EstimatedDeliveryAt = &date
+		}
+	}
+	return shipment, nil
+}
+
+// ValidateTrackingNumber implements Carrier. ゆうパック numbers are 12 digits
+// ending in a 7DR check digit; S10 numbers (two letters, 8 digits, a check
+// digit and JP) are accepted for EMS and registered mail.
+func (c *JapanPostCarrier) ValidateTrackingNumber(trackingNumber string) (string, error) {
+	n := normalizeTrackingNumber(trackingNumber)
+	if len(n) != japanPostS10Length {
+		return validateMod7TrackingNumber(n, japanPostTrackingNumberLength)
+	}
+
+	serial := n[2:10]
+	if !isLetters(n[:2]) || !isDigits(n[2:11]) || n[11:] != "JP" {
+		return "", fmt.Errorf("%w: must be 12 digits or an S10 number ending in JP", ErrInvalidTrackingNumber)
+	}
+	if s10CheckDigit(serial) != int(n[10]-'0') {
+		return "", fmt.Errorf("%w: check digit does not match", ErrInvalidTrackingNumber)
+	}
+	return n, nil
+}
+
+// Track implements Carrier
+func (c *JapanPostCarrier) Track(ctx context.Context, trackingNumber string) (CarrierTracking, error) {
+	var resp japanPostTrackingResponse
+	if err := c.api.do(ctx, http.MethodGet, "/yupack/v1/tracking/"+url.PathEscape(trackingNumber), nil, &resp); err != nil {
+		return CarrierTracking{}, fmt.Errorf("japan post: failed to track %s: %w", trackingNumber, err)
+	}
+
+	tracking := CarrierTracking{}
+	for _, h := range resp.History {
+		occurredAt, err := parseCarrierTime("2006-01-02 15:04", h.Datetime)
+		if err != nil {
+			return CarrierTracking{}, fmt.Errorf("japan post: bad checkpoint time %q", h.Datetime)
+		}
+		tracking.Checkpoints = append(tracking.Checkpoints, CarrierCheckpoint{
+			Status:      matchCarrierStatus(japanPostStatuses, h.State),
+			Description: h.State,
+			Location:    strings.TrimSpace(h.Prefecture + " " + h.Office),
+			OccurredAt:  occurredAt,
+		})
+	}
+	return tracking, nil
+}
+
+// CancelShipment implements Carrier
+func (c *JapanPostCarrier) CancelShipment(ctx context.Context, trackingNumber string) error {
+	if err := c.api.do(ctx, http.MethodDelete, "/yupack/v1/labels/"+url.PathEscape(trackingNumber), nil, nil); err != nil {
+		return fmt.Errorf("japan post: failed to cancel %s: %w", trackingNumber, err)
+	}
+	return nil
+}
+
+func japanPostAddressFrom(a CarrierAddress) japanPostAddress {
+	return japanPostAddress{
+		Name:       a.Name,
+		Phone:      a.Phone,
+		PostalCode: a.PostalCode,
+		Prefecture: a.Prefecture,
+		City:       a.City,
+		Address1:   a.AddressLine1,
+		Address2:   a.AddressLine2,
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// trackingPollBatchSize caps how many shipments one poll asks carriers about
+const trackingPollBatchSize = 50
+
+// CarrierTracker polls carriers for the progress of dispatched shipments and
+// records their checkpoints in the shipments' timelines
+type CarrierTracker struct {
+	deliveries *DeliveryService
+	// A shipment is polled again once this long has passed
+	pollAfter time.Duration
+	logger    *zap.Logger
+}
+
+// NewCarrierTracker creates a new carrier tracker that polls each shipment
+// at most once per pollAfter
+func NewCarrierTracker(deliveries *DeliveryService, pollAfter time.Duration, logger *zap.Logger) *CarrierTracker {
+	return &CarrierTracker{
+		deliveries: deliveries,
+		pollAfter:  pollAfter,
+		logger:     logger,
+	}
+}
+
+// Poll asks the carriers about shipments not polled recently and returns how
+// many changed status. A carrier failing for one shipment is logged and the
+// shipment is retried on a later poll.
+func (w *CarrierTracker) Poll(ctx context.Context) (int, error) {
+	shipments, err := w.deliveries.queries.ClaimShipmentsToTrack(ctx, w.pollAfter, trackingPollBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, shipment := range shipments {
+		ok, err := w.track(ctx, shipment)
+		if err != nil {
+			w.logger.Error("Failed to track shipment",
+				zap.String("shipment_id", shipment.ID.String()),
+				zap.String("carrier", shipment.Carrier),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// track records a shipment's new checkpoints and reports whether its status
+// changed
+func (w *CarrierTracker) track(ctx context.Context, shipment db.Shipment) (bool, error) {
+	carrier, ok := w.deliveries.carrier(shipment.Carrier)
+	if !ok {
+		w.logger.Warn("Shipment carrier is not configured, skipping",
+			zap.String("shipment_id", shipment.ID.String()),
+			zap.String("carrier", shipment.Carrier))
+		return false, nil
+	}
+
+	tracking, err := carrier.Track(ctx, toStringPtr(shipment.TrackingNumber))
+	if err != nil {
+		return false, err
+	}
+
+	// Checkpoints carry the shipment status they stand for, like the events
+	// UpdateShipmentStatus records; the carrier's own text goes in the
+	// description
+	checkpoints := make([]db.CreateTrackingEventParams, 0, len(tracking.Checkpoints))
+	for _, c := range tracking.Checkpoints {
+		occurredAt := c.OccurredAt.UTC()
+		checkpoints = append(checkpoints, db.CreateTrackingEventParams{
+			ShipmentID:  shipment.ID,
+			Status:      carrierShipmentStatus(shipment, c.Status).String(),
+			Location:    c.Location,
+			Description: carrier.Name() + ": " + c.Description,
+			OccurredAt:  &occurredAt,
+		})
+	}
+
+	// The carrier's status is taken only where the state machine allows it,
+	// so a stale or out of order report never moves a shipment back
+	newStatus := ""
+	var from []string
+	if s := carrierShipmentStatus(shipment, tracking.Status()); s != deliverypb.ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED {
+		newStatus = s.String()
+		from = shipmentStatusesBefore(s)
+	}
+	updated, changed, err := w.deliveries.queries.RecordCarrierTracking(ctx, shipment.ID, newStatus, from, checkpoints)
+	if err != nil {
+		return false, err
+	}
+
+	if changed {
+		w.logger.Info("Shipment status changed by carrier",
+			zap.String("shipment_id", shipment.ID.String()),
+			zap.String("carrier", carrier.Code()),
+			zap.String("from", shipment.Status),
+			zap.String("to", updated.Status))
+		w.deliveries.publishShipmentStatusChanged(ctx, updated, shipment.Status)
+		if updated.Status == deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED.String() && updated.CODAmountMinor != nil {
+			w.completeCashOnDelivery(ctx, updated)
+		}
+	}
+	return changed, nil
+}
+
+// completeCashOnDelivery completes the payment of a cash on delivery
+// shipment the carrier delivered. A failure is logged; marking the shipment
+// delivered through UpdateShipmentStatus retries it.
+func (w *CarrierTracker) completeCashOnDelivery(ctx context.Context, shipment db.Shipment) {
+	if w.deliveries.paymentClient == nil {
+		w.logger.Warn("No payment client, cash on delivery payment left open",
+			zap.String("order_id", shipment.OrderID.String()))
+		return
+	}
+
+	collected := &sharedpb.Money{Units: *shipment.CODAmountMinor, Currency: toStringPtr(shipment.CODCurrency)}
+	if _, err := w.deliveries.paymentClient.CompleteCashOnDelivery(ctx, &paymentpb.CompleteCashOnDeliveryRequest{
+		OrderId:         shipment.OrderID.String(),
+		CollectedAmount: collected,
+	}); err != nil {
+		w.logger.Error("Failed to complete cash on delivery payment",
+			zap.String("order_id", shipment.OrderID.String()),
+			zap.Error(err))
+	}
+}
+
+// StartPeriodicPoll starts polling carriers in the background
+func (w *CarrierTracker) StartPeriodicPoll(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic carrier tracking", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.Poll(ctx); err != nil {
+					w.logger.Error("Periodic carrier tracking failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// carrierShipmentStatus is the shipment status a carrier's status stands
+// for. Carriers report a parcel left at a pickup point as delivered.
+func carrierShipmentStatus(shipment db.Shipment, s deliverypb.ShipmentStatus) deliverypb.ShipmentStatus {
+	if s == deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED && shipment.PickupPointID != nil {
+		return deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP
+	}
+	return s
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// Carrier codes stored on shipments and zones
+const (
+	CarrierYamato    = "yamato"
+	CarrierSagawa    = "sagawa"
+	CarrierJapanPost = "japan_post"
+)
+
+var (
+	// ErrInvalidTrackingNumber is returned for tracking numbers that do not
+	// match the carrier's format or check digit
+	ErrInvalidTrackingNumber = errors.New("invalid tracking number")
+	// ErrCarrierShipmentNotFound is returned when the carrier does not know
+	// the tracking number
+	ErrCarrierShipmentNotFound = errors.New("carrier does not know the shipment")
+	// ErrCarrierNotCancellable is returned when the carrier has already
+	// picked up the shipment
+	ErrCarrierNotCancellable = errors.New("carrier has already picked up the shipment")
+)
+
+// Carrier is a parcel delivery company (宅配便) that shipments are handed to.
+// Adapters talk to the carrier's business API; status texts they report are
+// mapped onto ShipmentStatus.
+type Carrier interface {
+	// Code identifies the carrier in stored shipments, e.g. "yamato"
+	Code() string
+	// Name is the carrier's display name
+	Name() string
+	// SupportsServiceLevel reports whether the carrier offers the service,
+	// e.g. frozen クール便
+	SupportsServiceLevel(level deliverypb.ServiceLevel) bool
+	// CreateShipment registers a shipment (送り状発行) and returns its
+	// tracking number
+	CreateShipment(ctx context.Context, req CarrierShipmentRequest) (CarrierShipment, error)
+	// ValidateTrackingNumber checks the format and check digit of a tracking
+	// number and returns it without hyphens or spaces
+	ValidateTrackingNumber(trackingNumber string) (string, error)
+	// Track returns the shipment's checkpoints so far, oldest first
+	Track(ctx context.Context, trackingNumber string) (CarrierTracking, error)
+	// CancelShipment cancels a shipment the carrier has not picked up yet
+	CancelShipment(ctx context.Context, trackingNumber string) error
+}
+
+// CarrierAddress is a sender or recipient address
+type CarrierAddress struct {
+	Name         string
+	Phone        string
+	PostalCode   string
+	Prefecture   string
+	City         string
+	AddressLine1 string
+	AddressLine2 string
+}
+
+// CarrierShipmentRequest describes a parcel handed to a carrier
+type CarrierShipmentRequest struct {
+	OrderID      string
+	ServiceLevel deliverypb.ServiceLevel
+	Sender       CarrierAddress
+	Recipient    CarrierAddress
+	ShipDate     time.Time
+	// Requested delivery date (配達指定日) and time window (時間帯指定);
+	// unset leaves them to the carrier
+	DeliveryDate *time.Time
+	TimeWindow   deliverypb.DeliveryTimeWindow
+	// Cash the courier collects (代金引換); 0 for prepaid orders
+	CODAmountMinor int64
+	// Carrier's code of the konbini or locker the parcel is left at for
+	// collection (店頭受取); empty for delivery to the door
+	PickupPointCode string
+}
+
+// CarrierShipment is a shipment registered with a carrier
+type CarrierShipment struct {
+	TrackingNumber string
+	// Date the carrier expects to deliver, if it says
+	EstimatedDeliveryAt *time.Time
+}
+
+// CarrierCheckpoint is a scan of the parcel reported by the carrier
+type CarrierCheckpoint struct {
+	// Status the checkpoint moves the shipment to; UNSPECIFIED when the
+	// carrier's status text is not recognised
+	Status deliverypb.ShipmentStatus
+	// Carrier's own status text, e.g. 配達完了
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+// CarrierTracking is the carrier's view of a shipment
+type CarrierTracking struct {
+	Checkpoints []CarrierCheckpoint
+}
+
+// Status is the status of the latest recognised checkpoint, or UNSPECIFIED
+// when there is none
+func (t CarrierTracking) Status() deliverypb.ShipmentStatus {
+	for i := len(t.Checkpoints) - 1; i >= 0; i-- {
+		if t.Checkpoints[i].Status != deliverypb.ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED {
+			return t.Checkpoints[i].Status
+		}
+	}
+	return deliverypb.ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED
+}
+
+// CarrierRegistry holds the configured carriers and picks one for each
+// shipment: a zone's carrier wins, then the service level's, then the default
+type CarrierRegistry struct {
+	carriers       map[string]Carrier
+	defaultCarrier string
+	byServiceLevel map[deliverypb.ServiceLevel]string
+}
+
+// NewCarrierRegistry creates a registry of the carriers; defaultCarrier must
+// be the code of one of them
+func NewCarrierRegistry(defaultCarrier string, carriers ...Carrier) (*CarrierRegistry, error) {
+	r := &CarrierRegistry{
+		carriers:       make(map[string]Carrier, len(carriers)),
+		defaultCarrier: defaultCarrier,
+		byServiceLevel: make(map[deliverypb.ServiceLevel]string),
+	}
+	for _, c := range carriers {
+		r.carriers[c.Code()] = c
+	}
+	if _, ok := r.carriers[defaultCarrier]; !ok {
+		return nil, fmt.Errorf("unknown default carrier %q", defaultCarrier)
+	}
+	return r, nil
+}
+
+// SetServiceLevelCarriers parses rules such as "COOL_FROZEN=yamato,
+// EXPRESS=sagawa" giving the carrier for a service level
+func (r *CarrierRegistry) SetServiceLevelCarriers(rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, code, ok := strings.Cut(rule, "=")
+		if !ok {
+			return fmt.Errorf("malformed service level carrier rule %q", rule)
+		}
+		level, ok := deliverypb.ServiceLevel_value["SERVICE_LEVEL_"+strings.ToUpper(strings.TrimSpace(name))]
+		if !ok || level == int32(deliverypb.ServiceLevel_SERVICE_LEVEL_UNSPECIFIED) {
+			return fmt.Errorf("unknown service level %q", name)
+		}
+		code = strings.TrimSpace(code)
+		if _, ok := r.carriers[code]; !ok {
+			return fmt.Errorf("unknown carrier %q", code)
+		}
+		r.byServiceLevel[deliverypb.ServiceLevel(level)] = code
+	}
+	return nil
+}
+
+// Get returns the carrier with the code
+func (r *CarrierRegistry) Get(code string) (Carrier, bool) {
+	c, ok := r.carriers[code]
+	return c, ok
+}
+
+// Select picks the carrier for a shipment: the zone's if set, then the
+// service level's, then the default. Carriers that don't offer the service
+// level are passed over.
+func (r *CarrierRegistry) Select(zoneCarrier string, level deliverypb.ServiceLevel) (Carrier, error) {
+	for _, code := range []string{zoneCarrier, r.byServiceLevel[level], r.defaultCarrier} {
+		c, ok := r.carriers[code]
+		if ok && c.SupportsServiceLevel(level) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no carrier offers %s", level)
+}
+
+// normalizeTrackingNumber drops the hyphens and spaces people copy from
+// labels and folds full-width characters
+func normalizeTrackingNumber(s string) string {
+	var b strings.Builder
+	for _, r := range foldWidth(strings.TrimSpace(s)) {
+		if r == ' ' || isHyphen(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// validateMod7TrackingNumber checks a tracking number of the given length
+// whose last digit is the rest of the number modulo 7 (7DR), the check digit
+// used by Yamato, Sagawa and ゆうパック
+func validateMod7TrackingNumber(trackingNumber string, length int) (string, error) {
+	n := normalizeTrackingNumber(trackingNumber)
+	if len(n) != length || !isDigits(n) {
+		return "", fmt.Errorf("%w: must be %d digits", ErrInvalidTrackingNumber, length)
+	}
+	if mod7CheckDigit(n[:length-1]) != int(n[length-1]-'0') {
+		return "", fmt.Errorf("%w: check digit does not match", ErrInvalidTrackingNumber)
+	}
+	return n, nil
+}
+
+// mod7CheckDigit returns the decimal number modulo 7
+func mod7CheckDigit(digits string) int {
+	rem := 0
+	for _, d := range digits {
+		rem = (rem*10 + int(d-'0')) % 7
+	}
+	return rem
+}
+
+// s10Weights are the weights of the UPU S10 check digit
+var s10Weights = [8]int{8, 6, 4, 2, 3, 5, 9, 7}
+
+// s10CheckDigit returns the UPU S10 check digit of an 8 digit serial number,
+// used on international and registered mail (EMS, 書留)
+func s10CheckDigit(serial string) int {
+	sum := 0
+	for i, d := range serial {
+		sum += int(d-'0') * s10Weights[i]
+	}
+	switch check := 11 - sum%11; check {
+	case 10:
+		return 0
+	case 11:
+		return 5
+	default:
+		return check
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// newTestCarriers starts a fake carrier server and returns adapters for all
+// three carriers pointed at it
+func newTestCarriers(t *testing.T) (*FakeCarrierServer, []Carrier) {
+	t.Helper()
+	fake := NewFakeCarrierServer(0)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, []Carrier{
+		NewYamatoCarrier(CarrierAPIConfig{BaseURL: server.URL + "/yamato", APIKey: "key", CustomerCode: "0312345678"}),
+		NewSagawaCarrier(CarrierAPIConfig{BaseURL: server.URL + "/sagawa", APIKey: "key", CustomerCode: "123456"}),
+		NewJapanPostCarrier(CarrierAPIConfig{BaseURL: server.URL + "/japan-post", APIKey: "key", CustomerCode: "JP0001"}),
+	}
+}
+
+func testCarrierShipmentRequest(recipientName string) CarrierShipmentRequest {
+	delivery := time.Date(2026, 10, 21, 0, 0, 0, 0, tokyo)
+	return CarrierShipmentRequest{
+		OrderID:      "8f0c3c1e-2f1a-4f3b-9d6e-1b2c3d4e5f60",
+		ServiceLevel: deliverypb.ServiceLevel_SERVICE_LEVEL_STANDARD,
+		Sender:       CarrierAddress{Name: "Shinkansen Commerce", Phone: "03-0000-0000", PostalCode: "1000005", Prefecture: "東京都", City: "千代田区", AddressLine1: "丸の内1-1-1"},
+		Recipient:    CarrierAddress{Name: recipientName, Phone: "090-0000-0000", PostalCode: "1540001", Prefecture: "東京都", City: "世田谷区", AddressLine1: "池尻1-1-1"},
+		ShipDate:     time.Date(2026, 10, 19, 10, 0, 0, 0, tokyo),
+		DeliveryDate: &delivery,
+		TimeWindow:   deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20,
+	}
+}
+
+func TestValidateTrackingNumber(t *testing.T) {
+	_, carriers := newTestCarriers(t)
+	yamato, sagawa, japanPost := carriers[0], carriers[1], carriers[2]
+
+	tests := []struct {
+		name    string
+		carrier Carrier
+		input   string
+		want    string
+		ok      bool
+	}{
+		{"yamato", yamato, "400000000013", "400000000013", true},
+		{"yamato with hyphens", yamato, "4000-0000-0013", "400000000013", true},
+		{"yamato full-width", yamato, "４０００－００００－００１３", "400000000013", true},
+		{"yamato wrong check digit", yamato, "400000000014", "", false},
+		{"yamato too short", yamato, "40000000013", "", false},
+		{"sagawa", sagawa, "3600 0000 0010", "360000000010", true},
+		{"sagawa letters", sagawa, "36000000001A", "", false},
+		{"japan post yu-pack", japanPost, "1100-0000-0014", "110000000014", true},
+		{"japan post S10", japanPost, "ej123456785jp", "EJ123456785JP", true},
+		{"japan post S10 wrong check digit", japanPost, "EJ123456784JP", "", false},
+		{"japan post S10 foreign", japanPost, "EJ123456785US", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.carrier.ValidateTrackingNumber(tt.input)
+			if !tt.ok {
+				assert.ErrorIs(t, err, ErrInvalidTrackingNumber)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCarrierRegistry_Select(t *testing.T) {
+	_, carriers := newTestCarriers(t)
+	registry, err := NewCarrierRegistry(CarrierYamato, carriers...)
+	require.NoError(t, err)
+	require.NoError(t, registry.SetServiceLevelCarriers("express=sagawa, COOL_CHILLED = japan_post"))
+
+	tests := []struct {
+		name        string
+		zoneCarrier string
+		level       deliverypb.ServiceLevel
+		want        string
+	}{
+		{"default", "", deliverypb.ServiceLevel_SERVICE_LEVEL_STANDARD, CarrierYamato},
+		{"service level", "", deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS, CarrierSagawa},
+		{"zone wins", CarrierJapanPost, deliverypb.ServiceLevel_SERVICE_LEVEL_STANDARD, CarrierJapanPost},
+		{"zone carrier without the service", CarrierJapanPost, deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS, CarrierSagawa},
+		{"unknown zone carrier", "seino", deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED, CarrierJapanPost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier, err := registry.Select(tt.zoneCarrier, tt.level)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, carrier.Code())
+		})
+	}
+
+	t.Run("rejects bad rules", func(t *testing.T) {
+		assert.Error(t, registry.SetServiceLevelCarriers("EXPRESS"))
+		assert.Error(t, registry.SetServiceLevelCarriers("OVERNIGHT=yamato"))
+		assert.Error(t, registry.SetServiceLevelCarriers("EXPRESS=seino"))
+	})
+
+	t.Run("rejects an unknown default", func(t *testing.T) {
+		_, err := NewCarrierRegistry("seino", carriers...)
+		assert.Error(t, err)
+	})
+}
+
+func TestCarriers_FakeServer(t *testing.T) {
+	fake, carriers := newTestCarriers(t)
+
+	for _, carrier := range carriers {
+		t.Run(carrier.Code(), func(t *testing.T) {
+			ctx := context.Background()
+
+			shipment, err := carrier.CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+			require.NoError(t, err)
+			_, err = carrier.ValidateTrackingNumber(shipment.TrackingNumber)
+			require.NoError(t, err)
+			require.NotNil(t, shipment.EstimatedDeliveryAt)
+			assert.Equal(t, "2026-10-21", shipment.EstimatedDeliveryAt.In(tokyo).Format("2006-01-02"))
+
+			tracking, err := carrier.Track(ctx, shipment.TrackingNumber)
+			require.NoError(t, err)
+			assert.Empty(t, tracking.Checkpoints)
+			assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED, tracking.Status())
+
+			want := []deliverypb.ShipmentStatus{
+				deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED,
+				deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+				deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+				deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+			}
+			for i, status := range want {
+				require.True(t, fake.Advance(shipment.TrackingNumber))
+				tracking, err := carrier.Track(ctx, shipment.TrackingNumber)
+				require.NoError(t, err)
+				require.Len(t, tracking.Checkpoints, i+1)
+				assert.Equal(t, status, tracking.Status())
+				assert.NotEmpty(t, tracking.Checkpoints[i].Location)
+			}
+			assert.False(t, fake.Advance(shipment.TrackingNumber))
+
+			assert.ErrorIs(t, carrier.CancelShipment(ctx, shipment.TrackingNumber), ErrCarrierNotCancellable)
+		})
+	}
+
+	t.Run("absent recipient", func(t *testing.T) {
+		ctx := context.Background()
+		carrier := carriers[0]
+		shipment, err := carrier.CreateShipment(ctx, testCarrierShipmentRequest("不在 花子"))
+		require.NoError(t, err)
+		for fake.Advance(shipment.TrackingNumber) {
+		}
+
+		tracking, err := carrier.Track(ctx, shipment.TrackingNumber)
+
+		require.NoError(t, err)
+		assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY, tracking.Status())
+	})
+
+	t.Run("cancel before pickup", func(t *testing.T) {
+		ctx := context.Background()
+		for _, carrier := range carriers {
+			shipment, err := carrier.CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+			require.NoError(t, err)
+
+			require.NoError(t, carrier.CancelShipment(ctx, shipment.TrackingNumber), carrier.Code())
+
+			tracking, err := carrier.Track(ctx, shipment.TrackingNumber)
+			require.NoError(t, err)
+			assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED, tracking.Status(), carrier.Code())
+			assert.False(t, fake.Advance(shipment.TrackingNumber))
+		}
+	})
+
+	t.Run("unknown tracking number", func(t *testing.T) {
+		for _, carrier := range carriers {
+			_, err := carrier.Track(context.Background(), "999999999995")
+			assert.ErrorIs(t, err, ErrCarrierShipmentNotFound, carrier.Code())
+		}
+	})
+}
+
+func TestCarriers_CreateShipmentRequest(t *testing.T) {
+	fake, carriers := newTestCarriers(t)
+	ctx := context.Background()
+
+	t.Run("yamato frozen cash on delivery", func(t *testing.T) {
+		req := testCarrierShipmentRequest("山田 太郎")
+		req.ServiceLevel = deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN
+		req.CODAmountMinor = 5330
+		shipment, err := carriers[0].CreateShipment(ctx, req)
+		require.NoError(t, err)
+
+		raw, ok := fake.Request(shipment.TrackingNumber)
+		require.True(t, ok)
+		var body yamatoShipmentRequest
+		require.NoError(t, json.Unmarshal(raw, &body))
+		assert.Equal(t, yamatoServiceCollect, body.ServiceType)
+		assert.Equal(t, yamatoCoolFrozen, body.CoolType)
+		assert.Equal(t, int64(5330), body.CollectAmount)
+		assert.Equal(t, "1820", body.DeliveryTime)
+		assert.Equal(t, "2026/10/21", body.DeliveryDate)
+		assert.Equal(t, "東京都世田谷区池尻1-1-1", body.Consignee.Address1)
+	})
+
+	t.Run("sagawa express", func(t *testing.T) {
+		req := testCarrierShipmentRequest("山田 太郎")
+		req.ServiceLevel = deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS
+		shipment, err := carriers[1].CreateShipment(ctx, req)
+		require.NoError(t, err)
+
+		raw, ok := fake.Request(shipment.TrackingNumber)
+		require.True(t, ok)
+		var body sagawaOrderRequest
+		require.NoError(t, json.Unmarshal(raw, &body))
+		assert.Equal(t, sagawaServiceExpress, body.Binshu)
+		assert.Equal(t, "20261019", body.ShukkaDate)
+		assert.Equal(t, "18", body.JikantaiCode)
+	})
+
+	t.Run("japan post chilled", func(t *testing.T) {
+		req := testCarrierShipmentRequest("山田 太郎")
+		req.ServiceLevel = deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED
+		shipment, err := carriers[2].CreateShipment(ctx, req)
+		require.NoError(t, err)
+
+		raw, ok := fake.Request(shipment.TrackingNumber)
+		require.True(t, ok)
+		var body japanPostLabelRequest
+		require.NoError(t, json.Unmarshal(raw, &body))
+		assert.Equal(t, japanPostProductChilledYupack, body.Product)
+		assert.Equal(t, "55", body.DeliveryTimeCode)
+	})
+
+	t.Run("japan post has no frozen service", func(t *testing.T) {
+		req := testCarrierShipmentRequest("山田 太郎")
+		req.ServiceLevel = deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN
+
+		_, err := carriers[2].CreateShipment(ctx, req)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFakeCarrierServer_Progress(t *testing.T) {
+	fake := NewFakeCarrierServer(time.Hour)
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, tokyo)
+	fake.now = func() time.Time { return now }
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	carrier := NewYamatoCarrier(CarrierAPIConfig{BaseURL: server.URL + "/yamato"})
+	ctx := context.Background()
+
+	shipment, err := carrier.CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+	require.NoError(t, err)
+
+	now = now.Add(150 * time.Minute)
+	tracking, err := carrier.Track(ctx, shipment.TrackingNumber)
+
+	require.NoError(t, err)
+	require.Len(t, tracking.Checkpoints, 2)
+	assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT, tracking.Status())
+	assert.Equal(t, "2026-10-19 11:00", tracking.Checkpoints[1].OccurredAt.In(tokyo).Format("2006-01-02 15:04"))
+}
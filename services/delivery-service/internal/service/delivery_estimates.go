@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/pkg/calendar"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// defaultWarehouseCutoff is when orders stop shipping the same day, JST,
+// when no cutoff is configured
+const defaultWarehouseCutoff = 15 * time.Hour
+
+// shippingCalendar closes for the New Year, Golden Week and Obon breaks on
+// top of weekends and national holidays
+var shippingCalendar = calendar.New(calendar.CarrierClosures...)
+
+// SetWarehouseCutoff sets the time of day, JST, after which orders ship the
+// next business day
+func (s *DeliveryService) SetWarehouseCutoff(cutoff time.Duration) {
+	s.warehouseCutoff = cutoff
+}
+
+func (s *DeliveryService) cutoff() time.Duration {
+	if s.warehouseCutoff <= 0 || s.warehouseCutoff > 24*time.Hour {
+		return defaultWarehouseCutoff
+	}
+	return s.warehouseCutoff
+}
+
+// businessCalendar returns the shipping calendar with the closure days
+// operators added from the given time on
+func (s *DeliveryService) businessCalendar(ctx context.Context, from time.Time) (*calendar.Calendar, error) {
+	days, err := s.queries.ListClosureDays(ctx, tokyoDate(from))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list closure days: %w", err)
+	}
+	closures := make([]calendar.ClosureDay, 0, len(days))
+	for _, day := range days {
+		closures = append(closures, calendar.ClosureDay{Date: day.Date, Name: day.Reason})
+	}
+	return shippingCalendar.WithClosureDays(closures...), nil
+}
+
+// estimateDelivery returns the day, midnight JST, an order placed at
+// orderedAt is promised to arrive when it takes deliveryDays business days
+// on the way after leaving the warehouse
+func (s *DeliveryService) estimateDelivery(ctx context.Context, orderedAt time.Time, deliveryDays int) (time.Time, error) {
+	cal, err := s.businessCalendar(ctx, orderedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cal.PromisedDate(orderedAt, s.cutoff(), max(deliveryDays, 0)), nil
+}
+
+func (s *DeliveryService) ListClosureDays(ctx context.Context, req *deliverypb.ListClosureDaysRequest) (*deliverypb.ListClosureDaysResponse, error) {
+	s.logger.Info("Listing closure days")
+
+	days, err := s.queries.ListClosureDays(ctx, tokyoDate(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list closure days: %w", err)
+	}
+
+	protoDays := make([]*deliverypb.ClosureDay, 0, len(days))
+	for _, day := range days {
+		protoDays = append(protoDays, closureDayToProto(day))
+	}
+
+	return &deliverypb.ListClosureDaysResponse{
+		ClosureDays: protoDays,
+	}, nil
+}
+
+// CreateClosureDay closes a day for shipping, such as a stocktaking day.
+// Delivery estimates made from then on skip it; shipments already
+// dispatched keep theirs.
+func (s *DeliveryService) CreateClosureDay(ctx context.Context, req *deliverypb.CreateClosureDayRequest) (*deliverypb.CreateClosureDayResponse, error) {
+	if req.ClosureDay == nil {
+		return nil, status.Error(codes.InvalidArgument, "closure_day is required")
+	}
+	if req.ClosureDay.Date == nil {
+		return nil, status.Error(codes.InvalidArgument, "date is required")
+	}
+	s.logger.Info("Creating closure day", zap.Time("date", req.ClosureDay.Date.AsTime()))
+
+	date := calendarDate(req.ClosureDay.Date.AsTime())
+	if date.Before(tokyoDate(time.Now())) {
+		return nil, status.Error(codes.InvalidArgument, "date must not be in the past")
+	}
+
+	day, err := s.queries.CreateClosureDay(ctx, date, strings.TrimSpace(req.ClosureDay.Reason))
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "date is already a closure day")
+		}
+		return nil, fmt.Errorf("failed to create closure day: %w", err)
+	}
+
+	return &deliverypb.CreateClosureDayResponse{
+		ClosureDay: closureDayToProto(day),
+	}, nil
+}
+
+// DeleteClosureDay reopens a closure day
+func (s *DeliveryService) DeleteClosureDay(ctx context.Context, req *deliverypb.DeleteClosureDayRequest) (*deliverypb.DeleteClosureDayResponse, error) {
+	s.logger.Info("Deleting closure day", zap.String("closure_day_id", req.Id))
+
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id: must be a valid UUID")
+	}
+
+	if _, err := s.queries.DeleteClosureDay(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "closure day not found")
+		}
+		return nil, fmt.Errorf("failed to delete closure day: %w", err)
+	}
+
+	return &deliverypb.DeleteClosureDayResponse{}, nil
+}
+
+func closureDayToProto(day db.ClosureDay) *deliverypb.ClosureDay {
+	return &deliverypb.ClosureDay{
+		Id:     day.ID.String(),
+		Date:   timestamppb.New(day.Date),
+		Reason: day.Reason,
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/pkg/calendar"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func TestDeliveryService_EstimateDelivery(t *testing.T) {
+	jst := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, tokyo)
+	}
+
+	t.Run("counts the zone's delivery days in business days", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		// Stocktaking on Thursday the 22nd
+		mockQueries.On("ListClosureDays", mock.Anything, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)).
+			Return([]db.ClosureDay{{Date: time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), Reason: "棚卸し"}}, nil)
+
+		estimate, err := service.estimateDelivery(context.Background(), jst(time.October, 20, 10), 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, calendar.Date(jst(time.October, 23, 0)), estimate)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("orders after the cutoff ship the next business day", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		service.SetWarehouseCutoff(12 * time.Hour)
+		mockQueries.On("ListClosureDays", mock.Anything, mock.Anything).Return([]db.ClosureDay{}, nil)
+
+		// Friday afternoon ships on Monday
+		estimate, err := service.estimateDelivery(context.Background(), jst(time.October, 23, 13), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, calendar.Date(jst(time.October, 27, 0)), estimate)
+	})
+}
+
+func TestDeliveryService_QuoteShipping_EstimatedDelivery(t *testing.T) {
+	zones := []db.DeliveryZone{
+		{ID: uuid.New(), Name: "Tokyo", Prefectures: []string{"東京都"}, DeliveryDays: 2},
+	}
+
+	t.Run("estimates from the zone's delivery days", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := newQuoteTestService(mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("ListClosureDays", mock.Anything, mock.Anything).Return([]db.ClosureDay{}, nil)
+
+		resp, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			PostalCode: "154-0001",
+			Prefecture: "東京都",
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.Quote.EstimatedDeliveryAt)
+		estimate := resp.Quote.EstimatedDeliveryAt.AsTime()
+		assert.True(t, shippingCalendar.IsBusinessDay(estimate))
+		assert.True(t, estimate.After(calendar.Date(time.Now()).AddDate(0, 0, 1)))
+	})
+
+	t.Run("takes the slot's date", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := newQuoteTestService(mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		slotID := uuid.New()
+		mockQueries.On("GetDeliverySlot", mock.Anything, slotID).
+			Return(db.DeliverySlot{ID: slotID, Date: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)}, nil)
+
+		resp, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			PostalCode:     "154-0001",
+			Prefecture:     "東京都",
+			DeliverySlotId: slotID.String(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 31, 0, 0, 0, 0, tokyo).Unix(), resp.Quote.EstimatedDeliveryAt.AsTime().Unix())
+		mockQueries.AssertNotCalled(t, "ListClosureDays", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no estimate without a postal code", func(t *testing.T) {
+		service := newQuoteTestService(new(MockQuerier))
+
+		resp, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{Prefecture: "東京都"})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Quote.EstimatedDeliveryAt)
+	})
+}
+
+func TestDeliveryService_ClosureDays(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	t.Run("creates a closure day", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		date := calendarDate(tomorrow)
+		mockQueries.On("CreateClosureDay", mock.Anything, date, "棚卸し").
+			Return(db.ClosureDay{ID: uuid.New(), Date: date, Reason: "棚卸し"}, nil)
+
+		resp, err := service.CreateClosureDay(context.Background(), &deliverypb.CreateClosureDayRequest{
+			ClosureDay: &deliverypb.ClosureDay{Date: timestamppb.New(date), Reason: " 棚卸し "},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "棚卸し", resp.ClosureDay.Reason)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects a day already closed", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		mockQueries.On("CreateClosureDay", mock.Anything, mock.Anything, mock.Anything).
+			Return(db.ClosureDay{}, &pgconn.PgError{Code: "23505"})
+
+		_, err := service.CreateClosureDay(context.Background(), &deliverypb.CreateClosureDayRequest{
+			ClosureDay: &deliverypb.ClosureDay{Date: timestamppb.New(calendarDate(tomorrow))},
+		})
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("rejects past days", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.CreateClosureDay(context.Background(), &deliverypb.CreateClosureDayRequest{
+			ClosureDay: &deliverypb.ClosureDay{Date: timestamppb.New(time.Now().AddDate(0, 0, -2))},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("delete returns not found", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		id := uuid.New()
+		mockQueries.On("DeleteClosureDay", mock.Anything, id).Return(db.ClosureDay{}, pgx.ErrNoRows)
+
+		_, err := service.DeleteClosureDay(context.Background(), &deliverypb.DeleteClosureDayRequest{Id: id.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
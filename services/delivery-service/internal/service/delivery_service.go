@@ -2,15 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
 	"go.uber.org/zap"
@@ -18,8 +21,24 @@ import (
 
 type DeliveryService struct {
 	deliverypb.UnimplementedDeliveryServiceServer
-	queries db.Querier
-	logger  *zap.Logger
+	queries       db.Querier
+	paymentClient paymentpb.PaymentServiceClient
+	// Days ahead that slots are generated from templates
+	slotHorizonDays int
+	// How long a reservation holds its slot until the order is paid
+	holdTTL time.Duration
+	// Carriers shipments are dispatched to, and the address they ship from
+	carriers *CarrierRegistry
+	shipper  CarrierAddress
+	// Surcharges and free shipping threshold of shipping quotes; nil uses
+	// defaultShippingFees
+	shippingFees *ShippingFees
+	// Time of day, JST, after which orders ship the next business day; zero
+	// uses defaultWarehouseCutoff
+	warehouseCutoff time.Duration
+	// Announces shipment status changes; nil publishes nothing
+	eventPublisher *ShipmentEventPublisher
+	logger         *zap.Logger
 }
 
 func NewDeliveryService(queries db.Querier, logger *zap.Logger) *DeliveryService {
@@ -29,6 +48,12 @@ func NewDeliveryService(queries db.Querier, logger *zap.Logger) *DeliveryService
 	}
 }
 
+// SetPaymentClient sets the payment service client used to complete cash on
+// delivery payments (optional)
+func (s *DeliveryService) SetPaymentClient(paymentClient paymentpb.PaymentServiceClient) {
+	s.paymentClient = paymentClient
+}
+
 func (s *DeliveryService) GetDeliverySlots(ctx context.Context, req *deliverypb.GetDeliverySlotsRequest) (*deliverypb.GetDeliverySlotsResponse, error) {
 	s.logger.Info("Getting delivery slots",
 		zap.String("delivery_zone_id", req.DeliveryZoneId))
@@ -71,35 +96,89 @@ func (s *DeliveryService) ReserveDeliverySlot(ctx context.Context, req *delivery
 		return nil, status.Error(codes.InvalidArgument, "invalid order_id: must be a valid UUID")
 	}
 
-	reservationID, err := s.queries.ReserveDeliverySlot(ctx, slotID, orderID)
+	reservation, err := s.queries.ReserveDeliverySlot(ctx, slotID, orderID, s.reservationHoldTTL())
 	if err != nil {
+		if errors.Is(err, db.ErrSlotUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, "delivery slot is full or does not exist")
+		}
+		if errors.Is(err, db.ErrReservationConfirmed) {
+			return nil, status.Error(codes.FailedPrecondition, "order already has a confirmed delivery slot; release it first")
+		}
 		return nil, fmt.Errorf("failed to reserve delivery slot: %w", err)
 	}
 
-	return &deliverypb.ReserveDeliverySlotResponse{
-		ReservationId: reservationID.String(),
-		ReservedAt:    timestamppb.Now(),
-	}, nil
+	resp := &deliverypb.ReserveDeliverySlotResponse{
+		ReservationId: reservation.ID.String(),
+		ReservedAt:    timestamppb.New(reservation.CreatedAt),
+		Status:        deliverypb.ReservationStatus(deliverypb.ReservationStatus_value[reservation.Status]),
+	}
+	if reservation.ExpiresAt != nil {
+		resp.ExpiresAt = timestamppb.New(*reservation.ExpiresAt)
+	}
+	return resp, nil
 }
 
 func (s *DeliveryService) GetShipment(ctx context.Context, req *deliverypb.GetShipmentRequest) (*deliverypb.GetShipmentResponse, error) {
-	s.logger.Info("Getting shipment", zap.String("shipment_id", req.ShipmentId))
+	s.logger.Info("Getting shipment",
+		zap.String("shipment_id", req.ShipmentId),
+		zap.String("order_id", req.OrderId))
 
-	shipmentID, err := uuid.Parse(req.ShipmentId)
+	shipment, err := s.findShipment(ctx, req.ShipmentId, req.OrderId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid shipment_id: must be a valid UUID")
+		return nil, err
 	}
-	var shipment db.Shipment
-	shipment, err = s.queries.GetShipment(ctx, shipmentID)
+
+	events, err := s.queries.ListTrackingEvents(ctx, shipment.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get shipment: %w", err)
+		return nil, fmt.Errorf("failed to list tracking events: %w", err)
 	}
 
+	pb := s.shipmentToProto(shipment)
+	pb.TrackingEvents = trackingEventsToProto(events)
+
 	return &deliverypb.GetShipmentResponse{
-		Shipment: s.shipmentToProto(shipment),
+		Shipment: pb,
 	}, nil
 }
 
+// findShipment looks up a shipment by ID, or by order when no ID is given
+func (s *DeliveryService) findShipment(ctx context.Context, shipmentID, orderID string) (db.Shipment, error) {
+	var shipment db.Shipment
+	switch {
+	case shipmentID != "":
+		id, err := uuid.Parse(shipmentID)
+		if err != nil {
+			return db.Shipment{}, status.Error(codes.InvalidArgument, "invalid shipment_id: must be a valid UUID")
+		}
+		shipment, err = s.queries.GetShipment(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return db.Shipment{}, status.Error(codes.NotFound, "shipment not found")
+			}
+			return db.Shipment{}, fmt.Errorf("failed to get shipment: %w", err)
+		}
+	case orderID != "":
+		id, err := uuid.Parse(orderID)
+		if err != nil {
+			return db.Shipment{}, status.Error(codes.InvalidArgument, "invalid order_id: must be a valid UUID")
+		}
+		shipment, err = s.queries.GetShipmentByOrderID(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return db.Shipment{}, status.Error(codes.NotFound, "shipment not found")
+			}
+			return db.Shipment{}, fmt.Errorf("failed to get shipment: %w", err)
+		}
+	default:
+		return db.Shipment{}, status.Error(codes.InvalidArgument, "shipment_id or order_id is required")
+	}
+	return shipment, nil
+}
+
+// UpdateShipmentStatus moves a shipment to a new status along the shipment
+// state machine and announces it. Setting the status a shipment already has
+// changes nothing, except that delivering a cash on delivery shipment again
+// retries completing its payment.
 func (s *DeliveryService) UpdateShipmentStatus(ctx context.Context, req *deliverypb.UpdateShipmentStatusRequest) (*sharedpb.Empty, error) {
 	s.logger.Info("Updating shipment status",
 		zap.String("shipment_id", req.ShipmentId),
@@ -109,16 +188,126 @@ func (s *DeliveryService) UpdateShipmentStatus(ctx context.Context, req *deliver
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid shipment_id: must be a valid UUID")
 	}
-	err = s.queries.UpdateShipmentStatus(ctx, shipmentID, req.Status.String())
+
+	shipment, err := s.queries.GetShipment(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "shipment not found")
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	current := parseShipmentStatus(shipment.Status)
+	if err := checkPickupStatus(shipment, req.Status); err != nil {
+		return nil, err
+	}
+
+	if req.Status == deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED && shipment.CODAmountMinor != nil {
+		if current != req.Status && !canTransitionShipment(current, req.Status) {
+			return nil, status.Errorf(codes.FailedPrecondition, "shipment cannot go from %s to %s", current, req.Status)
+		}
+		if err := s.deliverCashOnDelivery(ctx, shipment, req.CollectedAmount, req.Description); err != nil {
+			return nil, err
+		}
+		return &sharedpb.Empty{}, nil
+	}
+
+	if current == req.Status {
+		return &sharedpb.Empty{}, nil
+	}
+	if !canTransitionShipment(current, req.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "shipment cannot go from %s to %s", current, req.Status)
+	}
+
+	updated, err := s.queries.TransitionShipmentStatus(ctx, shipmentID, shipment.Status, req.Status.String(), req.Description)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.Aborted, "shipment status changed meanwhile")
+		}
 		return nil, fmt.Errorf("failed to update shipment status: %w", err)
 	}
+	s.publishShipmentStatusChanged(ctx, updated, shipment.Status)
 
 	return &sharedpb.Empty{}, nil
 }
 
+// RequestCashOnDelivery has the courier collect the given amount when the
+// order's shipment is handed over. The amount can be changed until the
+// shipment leaves the warehouse.
+func (s *DeliveryService) RequestCashOnDelivery(ctx context.Context, req *deliverypb.RequestCashOnDeliveryRequest) (*deliverypb.RequestCashOnDeliveryResponse, error) {
+	s.logger.Info("Requesting cash on delivery", zap.String("order_id", req.OrderId))
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id: must be a valid UUID")
+	}
+	if req.Amount == nil || req.Amount.Units <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	shipment, err := s.queries.RequestCashOnDelivery(ctx, orderID, req.Amount.Units, req.Amount.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "shipment has already left the warehouse")
+		}
+		return nil, fmt.Errorf("failed to request cash on delivery: %w", err)
+	}
+
+	return &deliverypb.RequestCashOnDeliveryResponse{
+		Shipment: s.shipmentToProto(shipment),
+	}, nil
+}
+
+// deliverCashOnDelivery marks a cash on delivery shipment delivered once the
+// courier reports collecting the full amount, then completes the payment.
+// Payment completion is idempotent, so a failed call is retried by marking
+// the shipment delivered again.
+func (s *DeliveryService) deliverCashOnDelivery(ctx context.Context, shipment db.Shipment, collected *sharedpb.Money, description string) error {
+	if collected == nil {
+		return status.Error(codes.InvalidArgument, "collected_amount is required for cash on delivery shipments")
+	}
+	if collected.Units != *shipment.CODAmountMinor || collected.Currency != toStringPtr(shipment.CODCurrency) {
+		return status.Errorf(codes.InvalidArgument, "collected amount must be %d %s", *shipment.CODAmountMinor, toStringPtr(shipment.CODCurrency))
+	}
+
+	delivered := deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED
+	from := append(shipmentStatusesBefore(delivered), delivered.String())
+	if err := s.queries.MarkCODShipmentDelivered(ctx, shipment.ID, collected.Units, from, description); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Error(codes.Aborted, "shipment status changed meanwhile")
+		}
+		return fmt.Errorf("failed to update shipment status: %w", err)
+	}
+	if shipment.Status != delivered.String() {
+		previous := shipment.Status
+		shipment.Status = delivered.String()
+		s.publishShipmentStatusChanged(ctx, shipment, previous)
+	}
+
+	if s.paymentClient == nil {
+		s.logger.Warn("No payment client, cash on delivery payment left open",
+			zap.String("order_id", shipment.OrderID.String()))
+		return nil
+	}
+
+	if _, err := s.paymentClient.CompleteCashOnDelivery(ctx, &paymentpb.CompleteCashOnDeliveryRequest{
+		OrderId:         shipment.OrderID.String(),
+		CollectedAmount: collected,
+	}); err != nil {
+		s.logger.Error("Failed to complete cash on delivery payment",
+			zap.String("order_id", shipment.OrderID.String()),
+			zap.Error(err))
+		return status.Error(codes.Unavailable, "shipment delivered but payment could not be completed; retry the update")
+	}
+
+	s.logger.Info("Cash on delivery payment collected",
+		zap.String("order_id", shipment.OrderID.String()),
+		zap.Int64("amount", collected.Units))
+
+	return nil
+}
+
 func (s *DeliveryService) deliverySlotToProto(slot db.DeliverySlot) *deliverypb.DeliverySlot {
-	return &deliverypb.DeliverySlot{
+	pb := &deliverypb.DeliverySlot{
 		Id:             slot.ID.String(),
 		DeliveryZoneId: slot.DeliveryZoneID.String(),
 		StartTime:      timestamppb.New(slot.StartTime),
@@ -128,6 +317,13 @@ func (s *DeliveryService) deliverySlotToProto(slot db.DeliverySlot) *deliverypb.
 		Available:      int32(slot.Available),
 		Date:           timestamppb.New(slot.Date),
 	}
+	if slot.TimeWindow != nil {
+		pb.TimeWindow = deliverypb.DeliveryTimeWindow(deliverypb.DeliveryTimeWindow_value[*slot.TimeWindow])
+	}
+	if slot.CutoffAt != nil {
+		pb.CutoffAt = timestamppb.New(*slot.CutoffAt)
+	}
+	return pb
 }
 
 func (s *DeliveryService) shipmentToProto(shipment db.Shipment) *deliverypb.Shipment {
@@ -143,7 +339,7 @@ func (s *DeliveryService) shipmentToProto(shipment db.Shipment) *deliverypb.Ship
 		actualDeliveryAt = timestamppb.New(*shipment.ActualDeliveryAt)
 	}
 
-	return &deliverypb.Shipment{
+	pb := &deliverypb.Shipment{
 		Id:                  shipment.ID.String(),
 		OrderId:             shipment.OrderID.String(),
 		TrackingNumber:      toStringPtr(shipment.TrackingNumber),
@@ -151,7 +347,29 @@ func (s *DeliveryService) shipmentToProto(shipment db.Shipment) *deliverypb.Ship
 		EstimatedDeliveryAt: estimatedDeliveryAt,
 		ActualDeliveryAt:    actualDeliveryAt,
 		Carrier:             shipment.Carrier,
+		ServiceLevel:        deliverypb.ServiceLevel(deliverypb.ServiceLevel_value[shipment.ServiceLevel]),
+	}
+	if shipment.Recipient != nil {
+		pb.Recipient = shipmentAddressToProto(*shipment.Recipient)
+	}
+	if shipment.DispatchedAt != nil {
+		pb.DispatchedAt = timestamppb.New(*shipment.DispatchedAt)
+	}
+	if shipment.PickupPointID != nil {
+		pb.PickupPointId = shipment.PickupPointID.String()
+		pb.PickupCode = toStringPtr(shipment.PickupCode)
+	}
+	if shipment.PickupDeadlineAt != nil {
+		pb.PickupDeadlineAt = timestamppb.New(*shipment.PickupDeadlineAt)
+	}
+	if shipment.CODAmountMinor != nil {
+		currency := toStringPtr(shipment.CODCurrency)
+		pb.CodAmount = &sharedpb.Money{Units: *shipment.CODAmountMinor, Currency: currency}
+		if shipment.CollectedAmountMinor != nil {
+			pb.CollectedAmount = &sharedpb.Money{Units: *shipment.CollectedAmountMinor, Currency: currency}
+		}
 	}
+	return pb
 }
 
 func toStringPtr(s *string) string {
@@ -6,15 +6,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
 )
 
@@ -23,14 +27,122 @@ type MockQuerier struct {
 	mock.Mock
 }
 
+func (m *MockQuerier) ListDeliveryZonesForPrefecture(ctx context.Context, prefecture string) ([]db.DeliveryZone, error) {
+	args := m.Called(ctx, prefecture)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.DeliveryZone), args.Error(1)
+}
+
 func (m *MockQuerier) GetDeliverySlots(ctx context.Context, deliveryZoneID uuid.UUID, date time.Time) ([]db.DeliverySlot, error) {
 	args := m.Called(ctx, deliveryZoneID, date)
 	return args.Get(0).([]db.DeliverySlot), args.Error(1)
 }
 
-func (m *MockQuerier) ReserveDeliverySlot(ctx context.Context, slotID uuid.UUID, orderID uuid.UUID) (uuid.UUID, error) {
-	args := m.Called(ctx, slotID, orderID)
-	return args.Get(0).(uuid.UUID), args.Error(1)
+func (m *MockQuerier) CreateDeliverySlots(ctx context.Context, slots []db.CreateDeliverySlotParams) (int, error) {
+	args := m.Called(ctx, slots)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteUnreservedTemplateSlots(ctx context.Context, templateID uuid.UUID, after time.Time) (int, error) {
+	args := m.Called(ctx, templateID, after)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteUnreservedSlotsOnDate(ctx context.Context, deliveryZoneID *uuid.UUID, date time.Time) (int, int, error) {
+	args := m.Called(ctx, deliveryZoneID, date)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) ListSlotTemplates(ctx context.Context, deliveryZoneID uuid.UUID) ([]db.SlotTemplate, error) {
+	args := m.Called(ctx, deliveryZoneID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.SlotTemplate), args.Error(1)
+}
+
+func (m *MockQuerier) ListAllSlotTemplates(ctx context.Context) ([]db.SlotTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.SlotTemplate), args.Error(1)
+}
+
+func (m *MockQuerier) GetSlotTemplate(ctx context.Context, id uuid.UUID) (db.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.SlotTemplate), args.Error(1)
+}
+
+func (m *MockQuerier) CreateSlotTemplate(ctx context.Context, arg db.CreateSlotTemplateParams) (db.SlotTemplate, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.SlotTemplate), args.Error(1)
+}
+
+func (m *MockQuerier) UpdateSlotTemplate(ctx context.Context, arg db.UpdateSlotTemplateParams) (db.SlotTemplate, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.SlotTemplate), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteSlotTemplate(ctx context.Context, id uuid.UUID) (db.SlotTemplate, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.SlotTemplate), args.Error(1)
+}
+
+func (m *MockQuerier) ListSlotBlackouts(ctx context.Context, from time.Time) ([]db.SlotBlackout, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.SlotBlackout), args.Error(1)
+}
+
+func (m *MockQuerier) CreateSlotBlackout(ctx context.Context, arg db.CreateSlotBlackoutParams) (db.SlotBlackout, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.SlotBlackout), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteSlotBlackout(ctx context.Context, id uuid.UUID) (db.SlotBlackout, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.SlotBlackout), args.Error(1)
+}
+
+func (m *MockQuerier) ListClosureDays(ctx context.Context, from time.Time) ([]db.ClosureDay, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.ClosureDay), args.Error(1)
+}
+
+func (m *MockQuerier) CreateClosureDay(ctx context.Context, date time.Time, reason string) (db.ClosureDay, error) {
+	args := m.Called(ctx, date, reason)
+	return args.Get(0).(db.ClosureDay), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteClosureDay(ctx context.Context, id uuid.UUID) (db.ClosureDay, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.ClosureDay), args.Error(1)
+}
+
+func (m *MockQuerier) ReserveDeliverySlot(ctx context.Context, slotID uuid.UUID, orderID uuid.UUID, holdTTL time.Duration) (db.DeliveryReservation, error) {
+	args := m.Called(ctx, slotID, orderID, holdTTL)
+	return args.Get(0).(db.DeliveryReservation), args.Error(1)
+}
+
+func (m *MockQuerier) ConfirmReservation(ctx context.Context, orderID uuid.UUID) (db.DeliveryReservation, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).(db.DeliveryReservation), args.Error(1)
+}
+
+func (m *MockQuerier) ReleaseExpiredHolds(ctx context.Context, limit int) ([]db.DeliveryReservation, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.DeliveryReservation), args.Error(1)
 }
 
 func (m *MockQuerier) GetShipment(ctx context.Context, id uuid.UUID) (db.Shipment, error) {
@@ -38,9 +150,9 @@ func (m *MockQuerier) GetShipment(ctx context.Context, id uuid.UUID) (db.Shipmen
 	return args.Get(0).(db.Shipment), args.Error(1)
 }
 
-func (m *MockQuerier) UpdateShipmentStatus(ctx context.Context, id uuid.UUID, status string) error {
-	args := m.Called(ctx, id, status)
-	return args.Error(0)
+func (m *MockQuerier) TransitionShipmentStatus(ctx context.Context, id uuid.UUID, from, to, description string) (db.Shipment, error) {
+	args := m.Called(ctx, id, from, to, description)
+	return args.Get(0).(db.Shipment), args.Error(1)
 }
 
 func (m *MockQuerier) CreateShipment(ctx context.Context, orderID uuid.UUID) (uuid.UUID, error) {
@@ -61,11 +173,105 @@ func (m *MockQuerier) GetShipmentByOrderID(ctx context.Context, orderID uuid.UUI
 	return args.Get(0).(db.Shipment), args.Error(1)
 }
 
-func (m *MockQuerier) ReleaseDeliverySlot(ctx context.Context, orderID uuid.UUID) error {
+func (m *MockQuerier) ReleaseDeliverySlot(ctx context.Context, orderID uuid.UUID) (bool, error) {
 	args := m.Called(ctx, orderID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) RequestCashOnDelivery(ctx context.Context, orderID uuid.UUID, amountMinor int64, currency string) (db.Shipment, error) {
+	args := m.Called(ctx, orderID, amountMinor, currency)
+	return args.Get(0).(db.Shipment), args.Error(1)
+}
+
+func (m *MockQuerier) MarkCODShipmentDelivered(ctx context.Context, id uuid.UUID, collectedAmountMinor int64, from []string, description string) error {
+	args := m.Called(ctx, id, collectedAmountMinor, from, description)
 	return args.Error(0)
 }
 
+func (m *MockQuerier) CreateTrackingEvent(ctx context.Context, arg db.CreateTrackingEventParams) (db.TrackingEvent, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.TrackingEvent), args.Error(1)
+}
+
+func (m *MockQuerier) ListTrackingEvents(ctx context.Context, shipmentID uuid.UUID) ([]db.TrackingEvent, error) {
+	args := m.Called(ctx, shipmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.TrackingEvent), args.Error(1)
+}
+
+func (m *MockQuerier) GetReservedSlot(ctx context.Context, orderID uuid.UUID) (db.DeliverySlot, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).(db.DeliverySlot), args.Error(1)
+}
+
+func (m *MockQuerier) DispatchShipment(ctx context.Context, arg db.DispatchShipmentParams) (db.Shipment, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.Shipment), args.Error(1)
+}
+
+func (m *MockQuerier) ClaimShipmentsToTrack(ctx context.Context, trackedBefore time.Duration, limit int) ([]db.Shipment, error) {
+	args := m.Called(ctx, trackedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.Shipment), args.Error(1)
+}
+
+func (m *MockQuerier) RecordCarrierTracking(ctx context.Context, shipmentID uuid.UUID, status string, from []string, checkpoints []db.CreateTrackingEventParams) (db.Shipment, bool, error) {
+	args := m.Called(ctx, shipmentID, status, from, checkpoints)
+	return args.Get(0).(db.Shipment), args.Bool(1), args.Error(2)
+}
+
+func (m *MockQuerier) ListDispatchedShipments(ctx context.Context, from, to time.Time, carrier string) ([]db.Shipment, error) {
+	args := m.Called(ctx, from, to, carrier)
+	return args.Get(0).([]db.Shipment), args.Error(1)
+}
+
+func (m *MockQuerier) ListPickupPoints(ctx context.Context, postalCode, pointType string, limit int) ([]db.PickupPoint, error) {
+	args := m.Called(ctx, postalCode, pointType, limit)
+	return args.Get(0).([]db.PickupPoint), args.Error(1)
+}
+
+func (m *MockQuerier) GetPickupPoint(ctx context.Context, id uuid.UUID) (db.PickupPoint, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.PickupPoint), args.Error(1)
+}
+
+func (m *MockQuerier) CreatePickupPoint(ctx context.Context, arg db.CreatePickupPointParams) (db.PickupPoint, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.PickupPoint), args.Error(1)
+}
+
+func (m *MockQuerier) UpdatePickupPoint(ctx context.Context, arg db.UpdatePickupPointParams) (db.PickupPoint, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.PickupPoint), args.Error(1)
+}
+
+func (m *MockQuerier) ExpireUncollectedPickups(ctx context.Context, description string, limit int) ([]db.Shipment, error) {
+	args := m.Called(ctx, description, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.Shipment), args.Error(1)
+}
+
+// fakePaymentClient records cash on delivery completions
+type fakePaymentClient struct {
+	paymentpb.PaymentServiceClient
+	completed []*paymentpb.CompleteCashOnDeliveryRequest
+	err       error
+}
+
+func (c *fakePaymentClient) CompleteCashOnDelivery(ctx context.Context, req *paymentpb.CompleteCashOnDeliveryRequest, opts ...grpc.CallOption) (*paymentpb.CompleteCashOnDeliveryResponse, error) {
+	c.completed = append(c.completed, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &paymentpb.CompleteCashOnDeliveryResponse{}, nil
+}
+
 func TestDeliveryService_GetDeliverySlots(t *testing.T) {
 	t.Run("successful delivery slots retrieval", func(t *testing.T) {
 		logger := zap.NewNop()
@@ -172,8 +378,16 @@ func TestDeliveryService_ReserveDeliverySlot(t *testing.T) {
 		slotID := uuid.New()
 		orderID := uuid.New()
 		reservationID := uuid.New()
+		expiresAt := time.Now().Add(30 * time.Minute)
 
-		mockQueries.On("ReserveDeliverySlot", mock.Anything, slotID, orderID).Return(reservationID, nil)
+		mockQueries.On("ReserveDeliverySlot", mock.Anything, slotID, orderID, 30*time.Minute).Return(db.DeliveryReservation{
+			ID:        reservationID,
+			SlotID:    slotID,
+			OrderID:   orderID,
+			Status:    "RESERVATION_STATUS_HELD",
+			ExpiresAt: &expiresAt,
+			CreatedAt: time.Now(),
+		}, nil)
 
 		req := &deliverypb.ReserveDeliverySlotRequest{
 			SlotId:  slotID.String(),
@@ -186,6 +400,8 @@ func TestDeliveryService_ReserveDeliverySlot(t *testing.T) {
 		assert.NotNil(t, resp)
 		assert.Equal(t, reservationID.String(), resp.ReservationId)
 		assert.NotNil(t, resp.ReservedAt)
+		assert.Equal(t, deliverypb.ReservationStatus_RESERVATION_STATUS_HELD, resp.Status)
+		assert.Equal(t, expiresAt.Unix(), resp.ExpiresAt.AsTime().Unix())
 		mockQueries.AssertExpectations(t)
 	})
 
@@ -240,7 +456,7 @@ func TestDeliveryService_ReserveDeliverySlot(t *testing.T) {
 		slotID := uuid.New()
 		orderID := uuid.New()
 
-		mockQueries.On("ReserveDeliverySlot", mock.Anything, slotID, orderID).Return(uuid.Nil, assert.AnError)
+		mockQueries.On("ReserveDeliverySlot", mock.Anything, slotID, orderID, mock.Anything).Return(db.DeliveryReservation{}, db.ErrSlotUnavailable)
 
 		req := &deliverypb.ReserveDeliverySlotRequest{
 			SlotId:  slotID.String(),
@@ -251,6 +467,7 @@ func TestDeliveryService_ReserveDeliverySlot(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 		mockQueries.AssertExpectations(t)
 	})
 }
@@ -278,6 +495,7 @@ func TestDeliveryService_GetShipment(t *testing.T) {
 		}
 
 		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(shipment, nil)
+		mockQueries.On("ListTrackingEvents", mock.Anything, shipmentID).Return([]db.TrackingEvent{}, nil)
 
 		req := &deliverypb.GetShipmentRequest{
 			ShipmentId: shipmentID.String(),
@@ -336,6 +554,32 @@ func TestDeliveryService_GetShipment(t *testing.T) {
 		assert.Contains(t, status.Convert(err).Message(), "invalid shipment_id")
 	})
 
+	t.Run("by order", func(t *testing.T) {
+		logger := zap.NewNop()
+		mockQueries := new(MockQuerier)
+
+		service := NewDeliveryService(mockQueries, logger)
+
+		orderID := uuid.New()
+		shipment := db.Shipment{
+			ID:      uuid.New(),
+			OrderID: orderID,
+			Status:  "SHIPMENT_STATUS_SHIPPED",
+			Carrier: "Yamato Transport",
+		}
+
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).Return(shipment, nil)
+		mockQueries.On("ListTrackingEvents", mock.Anything, shipment.ID).Return([]db.TrackingEvent{}, nil)
+
+		resp, err := service.GetShipment(context.Background(), &deliverypb.GetShipmentRequest{
+			OrderId: orderID.String(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, shipment.ID.String(), resp.Shipment.Id)
+		mockQueries.AssertExpectations(t)
+	})
+
 	t.Run("delivered shipment", func(t *testing.T) {
 		logger := zap.NewNop()
 		mockQueries := new(MockQuerier)
@@ -359,6 +603,7 @@ func TestDeliveryService_GetShipment(t *testing.T) {
 		}
 
 		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(shipment, nil)
+		mockQueries.On("ListTrackingEvents", mock.Anything, shipmentID).Return([]db.TrackingEvent{}, nil)
 
 		req := &deliverypb.GetShipmentRequest{
 			ShipmentId: shipmentID.String(),
@@ -383,7 +628,9 @@ func TestDeliveryService_UpdateShipmentStatus(t *testing.T) {
 
 		shipmentID := uuid.New()
 
-		mockQueries.On("UpdateShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_SHIPPED").Return(nil)
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_PREPARING", "SHIPMENT_STATUS_SHIPPED", "").
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_SHIPPED"}, nil)
 
 		req := &deliverypb.UpdateShipmentStatusRequest{
 			ShipmentId: shipmentID.String(),
@@ -424,7 +671,9 @@ func TestDeliveryService_UpdateShipmentStatus(t *testing.T) {
 
 		shipmentID := uuid.New()
 
-		mockQueries.On("UpdateShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_DELIVERED").Return(nil)
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_IN_TRANSIT"}, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_IN_TRANSIT", "SHIPMENT_STATUS_DELIVERED", "").
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_DELIVERED"}, nil)
 
 		req := &deliverypb.UpdateShipmentStatusRequest{
 			ShipmentId: shipmentID.String(),
@@ -446,11 +695,14 @@ func TestDeliveryService_UpdateShipmentStatus(t *testing.T) {
 
 		shipmentID := uuid.New()
 
-		mockQueries.On("UpdateShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_FAILED_DELIVERY").Return(nil)
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_IN_TRANSIT"}, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_IN_TRANSIT", "SHIPMENT_STATUS_FAILED_DELIVERY", "ご不在のため持ち戻り").
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_FAILED_DELIVERY"}, nil)
 
 		req := &deliverypb.UpdateShipmentStatusRequest{
-			ShipmentId: shipmentID.String(),
-			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY,
+			ShipmentId:  shipmentID.String(),
+			Status:      deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY,
+			Description: "ご不在のため持ち戻り",
 		}
 
 		resp, err := service.UpdateShipmentStatus(context.Background(), req)
@@ -459,4 +711,180 @@ func TestDeliveryService_UpdateShipmentStatus(t *testing.T) {
 		assert.NotNil(t, resp)
 		mockQueries.AssertExpectations(t)
 	})
+
+	t.Run("rejects a transition the state machine does not allow", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipmentID := uuid.New()
+
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_DELIVERED"}, nil)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId: shipmentID.String(),
+			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "TransitionShipmentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves a shipment already in the status alone", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipmentID := uuid.New()
+
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_IN_TRANSIT"}, nil)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId: shipmentID.String(),
+			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "TransitionShipmentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("shipment changed meanwhile", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipmentID := uuid.New()
+
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_SHIPPED"}, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_SHIPPED", "SHIPMENT_STATUS_IN_TRANSIT", "").
+			Return(db.Shipment{}, pgx.ErrNoRows)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId: shipmentID.String(),
+			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+		})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+}
+
+func TestDeliveryService_RequestCashOnDelivery(t *testing.T) {
+	t.Run("sets the amount to collect", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		orderID := uuid.New()
+		amount := int64(11440)
+		currency := "JPY"
+
+		mockQueries.On("RequestCashOnDelivery", mock.Anything, orderID, amount, currency).Return(db.Shipment{
+			ID:             uuid.New(),
+			OrderID:        orderID,
+			Status:         "SHIPMENT_STATUS_PREPARING",
+			CODAmountMinor: &amount,
+			CODCurrency:    &currency,
+		}, nil)
+
+		resp, err := service.RequestCashOnDelivery(context.Background(), &deliverypb.RequestCashOnDeliveryRequest{
+			OrderId: orderID.String(),
+			Amount:  &sharedpb.Money{Units: amount, Currency: currency},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, amount, resp.Shipment.CodAmount.Units)
+		assert.Nil(t, resp.Shipment.CollectedAmount)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment already left the warehouse", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		orderID := uuid.New()
+		mockQueries.On("RequestCashOnDelivery", mock.Anything, orderID, int64(11440), "JPY").Return(db.Shipment{}, pgx.ErrNoRows)
+
+		_, err := service.RequestCashOnDelivery(context.Background(), &deliverypb.RequestCashOnDeliveryRequest{
+			OrderId: orderID.String(),
+			Amount:  &sharedpb.Money{Units: 11440, Currency: "JPY"},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_UpdateShipmentStatus_CashOnDelivery(t *testing.T) {
+	amount := int64(11440)
+	currency := "JPY"
+	codShipment := func() db.Shipment {
+		return db.Shipment{
+			ID:             uuid.New(),
+			OrderID:        uuid.New(),
+			Status:         "SHIPMENT_STATUS_IN_TRANSIT",
+			CODAmountMinor: &amount,
+			CODCurrency:    &currency,
+		}
+	}
+
+	t.Run("completes the payment with the collected amount", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		payments := &fakePaymentClient{}
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		service.SetPaymentClient(payments)
+		shipment := codShipment()
+
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		mockQueries.On("MarkCODShipmentDelivered", mock.Anything, shipment.ID, amount, mock.Anything, "").Return(nil)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId:      shipment.ID.String(),
+			Status:          deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+			CollectedAmount: &sharedpb.Money{Units: amount, Currency: currency},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, payments.completed, 1)
+		assert.Equal(t, shipment.OrderID.String(), payments.completed[0].OrderId)
+		assert.Equal(t, amount, payments.completed[0].CollectedAmount.Units)
+		mockQueries.AssertNotCalled(t, "TransitionShipmentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("requires the collected amount", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		payments := &fakePaymentClient{}
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		service.SetPaymentClient(payments)
+		shipment := codShipment()
+
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId: shipment.ID.String(),
+			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId:      shipment.ID.String(),
+			Status:          deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+			CollectedAmount: &sharedpb.Money{Units: 11000, Currency: currency},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		assert.Empty(t, payments.completed)
+		mockQueries.AssertNotCalled(t, "MarkCODShipmentDelivered", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports a payment that could not be completed", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		payments := &fakePaymentClient{err: status.Error(codes.Unavailable, "connection refused")}
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		service.SetPaymentClient(payments)
+		shipment := codShipment()
+
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		mockQueries.On("MarkCODShipmentDelivered", mock.Anything, shipment.ID, amount, mock.Anything, "").Return(nil)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId:      shipment.ID.String(),
+			Status:          deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+			CollectedAmount: &sharedpb.Money{Units: amount, Currency: currency},
+		})
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
 }
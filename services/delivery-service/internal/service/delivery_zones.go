@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// postalCodeLength is the number of digits in a Japanese postal code
+const postalCodeLength = 7
+
+// prefectureWideSpan ranks zones without postal code rules below any zone
+// whose rules matched, as it is wider than every possible rule
+const prefectureWideSpan = 10_000_001
+
+// ResolveDeliveryZone finds the delivery zone covering an address
+func (s *DeliveryService) ResolveDeliveryZone(ctx context.Context, req *deliverypb.ResolveDeliveryZoneRequest) (*deliverypb.ResolveDeliveryZoneResponse, error) {
+	s.logger.Info("Resolving delivery zone",
+		zap.String("postal_code", req.PostalCode),
+		zap.String("prefecture", req.Prefecture))
+
+	return s.resolveDeliveryZone(ctx, req.PostalCode, req.Prefecture)
+}
+
+// GetDeliverySlotsForAddress returns the delivery slots of the zone covering
+// an address. Addresses that cannot be delivered to get no slots.
+func (s *DeliveryService) GetDeliverySlotsForAddress(ctx context.Context, req *deliverypb.GetDeliverySlotsForAddressRequest) (*deliverypb.GetDeliverySlotsForAddressResponse, error) {
+	s.logger.Info("Getting delivery slots for address",
+		zap.String("postal_code", req.PostalCode),
+		zap.String("prefecture", req.Prefecture))
+
+	zone, err := s.resolveDeliveryZone(ctx, req.PostalCode, req.Prefecture)
+	if err != nil {
+		return nil, err
+	}
+	if !zone.Deliverable {
+		return &deliverypb.GetDeliverySlotsForAddressResponse{Zone: zone, Slots: []*deliverypb.DeliverySlot{}}, nil
+	}
+
+	slots, err := s.GetDeliverySlots(ctx, &deliverypb.GetDeliverySlotsRequest{
+		DeliveryZoneId: zone.Zone.Id,
+		Date:           req.Date,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deliverypb.GetDeliverySlotsForAddressResponse{Zone: zone, Slots: slots.Slots}, nil
+}
+
+func (s *DeliveryService) resolveDeliveryZone(ctx context.Context, postalCode, prefecture string) (*deliverypb.ResolveDeliveryZoneResponse, error) {
+	code, ok := normalizePostalCode(postalCode)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid postal_code: must be 7 digits")
+	}
+	pref, ok := normalizePrefecture(prefecture)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid prefecture")
+	}
+
+	zones, err := s.queries.ListDeliveryZonesForPrefecture(ctx, pref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delivery zones: %w", err)
+	}
+
+	resp := &deliverypb.ResolveDeliveryZoneResponse{
+		PostalCode: code,
+		Prefecture: pref,
+	}
+
+	zone := s.matchDeliveryZone(zones, code, pref)
+	if zone == nil {
+		s.logger.Info("No delivery zone covers address",
+			zap.String("postal_code", code),
+			zap.String("prefecture", pref))
+		return resp, nil
+	}
+
+	resp.Zone = deliveryZoneToProto(*zone)
+	resp.Deliverable = !zone.Undeliverable
+	resp.RemoteIsland = zone.RemoteIsland
+	return resp, nil
+}
+
+// matchDeliveryZone picks the most specific zone covering the address: the
+// one whose matching postal code rule spans the fewest codes, with zones
+// without rules coming last. On a tie an undeliverable zone wins, so that an
+// exclusion is never overridden by an equally specific zone.
+func (s *DeliveryService) matchDeliveryZone(zones []db.DeliveryZone, postalCode, prefecture string) *db.DeliveryZone {
+	var best *db.DeliveryZone
+	bestSpan := 0
+	for i := range zones {
+		zone := &zones[i]
+		if len(zone.Prefectures) > 0 && !containsString(zone.Prefectures, prefecture) {
+			continue
+		}
+
+		span, ok := prefectureWideSpan, true
+		if len(zone.PostalCodes) > 0 {
+			span, ok = s.matchPostalCodeRules(*zone, postalCode)
+		}
+		if !ok {
+			continue
+		}
+
+		if best == nil || span < bestSpan || (span == bestSpan && zone.Undeliverable && !best.Undeliverable) {
+			best, bestSpan = zone, span
+		}
+	}
+	return best
+}
+
+// matchPostalCodeRules returns the span of the narrowest of the zone's rules
+// covering the postal code. Malformed rules are logged and skipped.
+func (s *DeliveryService) matchPostalCodeRules(zone db.DeliveryZone, postalCode string) (int, bool) {
+	span, matched := 0, false
+	for _, r := range zone.PostalCodes {
+		rule, err := parsePostalCodeRule(r)
+		if err != nil {
+			s.logger.Warn("Skipping malformed postal code rule",
+				zap.String("delivery_zone_id", zone.ID.String()),
+				zap.String("rule", r),
+				zap.Error(err))
+			continue
+		}
+		if rule.contains(postalCode) && (!matched || rule.span() < span) {
+			span, matched = rule.span(), true
+		}
+	}
+	return span, matched
+}
+
+// postalCodeRule is an inclusive range of 7-digit postal codes
+type postalCodeRule struct {
+	from, to string
+}
+
+// parsePostalCodeRule parses a zone's postal code rule: a 7-digit code, a
+// shorter prefix that covers every code starting with it, or two of those
+// joined by ~ for a range. Hyphens and full-width digits are accepted.
+func parsePostalCodeRule(rule string) (postalCodeRule, error) {
+	rule = strings.ReplaceAll(foldWidth(rule), "〜", "~")
+	from, to, isRange := strings.Cut(rule, "~")
+	if !isRange {
+		to = from
+	}
+
+	lo, ok := postalCodeDigits(from)
+	if !ok {
+		return postalCodeRule{}, fmt.Errorf("invalid postal code %q", from)
+	}
+	hi, ok := postalCodeDigits(to)
+	if !ok {
+		return postalCodeRule{}, fmt.Errorf("invalid postal code %q", to)
+	}
+
+	r := postalCodeRule{
+		from: lo + strings.Repeat("0", postalCodeLength-len(lo)),
+		to:   hi + strings.Repeat("9", postalCodeLength-len(hi)),
+	}
+	if r.from > r.to {
+		return postalCodeRule{}, fmt.Errorf("range %q is reversed", rule)
+	}
+	return r, nil
+}
+
+func (r postalCodeRule) contains(postalCode string) bool {
+	return r.from <= postalCode && postalCode <= r.to
+}
+
+// span is the number of postal codes the rule covers
+func (r postalCodeRule) span() int {
+	from, _ := strconv.Atoi(r.from)
+	to, _ := strconv.Atoi(r.to)
+	return to - from + 1
+}
+
+// normalizePostalCode returns the 7 digits of a postal code written with or
+// without a hyphen, a 〒 mark or full-width digits
+func normalizePostalCode(s string) (string, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(foldWidth(s)), "〒")
+	digits, ok := postalCodeDigits(s)
+	if !ok || len(digits) != postalCodeLength {
+		return "", false
+	}
+	return digits, true
+}
+
+// postalCodeDigits strips spaces and hyphens from up to 7 half-width digits
+func postalCodeDigits(s string) (string, bool) {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || isHyphen(r):
+		default:
+			return "", false
+		}
+	}
+	digits := b.String()
+	return digits, len(digits) > 0 && len(digits) <= postalCodeLength
+}
+
+// isHyphen reports whether r is one of the dashes people type between the
+// two parts of a postal code
+func isHyphen(r rune) bool {
+	switch r {
+	case '-', '‐', '‑', '–', '—', '―', '−', 'ー', 'ｰ':
+		return true
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func deliveryZoneToProto(zone db.DeliveryZone) *deliverypb.DeliveryZone {
+	return &deliverypb.DeliveryZone{
+		Id:            zone.ID.String(),
+		Name:          zone.Name,
+		PostalCodes:   zone.PostalCodes,
+		Prefectures:   zone.Prefectures,
+		DeliveryDays:  int32(zone.DeliveryDays),
+		Undeliverable: zone.Undeliverable,
+		RemoteIsland:  zone.RemoteIsland,
+		Carrier:       toStringPtr(zone.Carrier),
+	}
+}
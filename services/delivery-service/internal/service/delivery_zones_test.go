@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"1000001", "1000001", true},
+		{"100-0001", "1000001", true},
+		{"１００－０００１", "1000001", true},
+		{"〒100ー0001", "1000001", true},
+		{" 100 0001 ", "1000001", true},
+		{"100-001", "", false},
+		{"10000011", "", false},
+		{"100-000A", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizePostalCode(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestNormalizePrefecture(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"東京都", "東京都", true},
+		{"東京", "東京都", true},
+		{"京都", "京都府", true},
+		{"北海道", "北海道", true},
+		{"Tokyo", "東京都", true},
+		{"osaka-fu", "大阪府", true},
+		{"Okinawa Prefecture", "沖縄県", true},
+		{"13", "東京都", true},
+		{"０１", "北海道", true},
+		{"48", "", false},
+		{"東京府", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizePrefecture(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestParsePostalCodeRule(t *testing.T) {
+	tests := []struct {
+		rule string
+		want postalCodeRule
+		span int
+	}{
+		{"100-0001", postalCodeRule{"1000001", "1000001"}, 1},
+		{"100", postalCodeRule{"1000000", "1009999"}, 10000},
+		{"1000100~1001799", postalCodeRule{"1000100", "1001799"}, 1700},
+		{"１００－０１〜１００－１７", postalCodeRule{"1000100", "1001799"}, 1700},
+	}
+	for _, tt := range tests {
+		got, err := parsePostalCodeRule(tt.rule)
+		require.NoError(t, err, tt.rule)
+		assert.Equal(t, tt.want, got, tt.rule)
+		assert.Equal(t, tt.span, got.span(), tt.rule)
+	}
+
+	for _, rule := range []string{"", "abc", "2000000~1000000", "10000001"} {
+		_, err := parsePostalCodeRule(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestDeliveryService_ResolveDeliveryZone(t *testing.T) {
+	tokyo := db.DeliveryZone{ID: uuid.New(), Name: "東京", Prefectures: []string{"東京都"}, DeliveryDays: 1}
+	chiyoda := db.DeliveryZone{ID: uuid.New(), Name: "千代田", PostalCodes: []string{"100", "bad rule"}, Prefectures: []string{"東京都"}, DeliveryDays: 1}
+	islands := db.DeliveryZone{ID: uuid.New(), Name: "伊豆諸島・小笠原", PostalCodes: []string{"1000100~1001799"}, Prefectures: []string{"東京都"}, DeliveryDays: 4, RemoteIsland: true}
+	closed := db.DeliveryZone{ID: uuid.New(), Name: "配達不可", PostalCodes: []string{"1000400~1000499"}, Undeliverable: true}
+	zones := []db.DeliveryZone{tokyo, chiyoda, islands, closed}
+
+	tests := []struct {
+		name         string
+		postalCode   string
+		wantZone     uuid.UUID
+		deliverable  bool
+		remoteIsland bool
+	}{
+		{"prefecture-wide zone", "160-0022", tokyo.ID, true, false},
+		{"prefix beats prefecture", "100-0001", chiyoda.ID, true, false},
+		{"range beats wider prefix", "100-1101", islands.ID, true, true},
+		{"undeliverable range", "100-0401", closed.ID, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueries := new(MockQuerier)
+			mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+			service := NewDeliveryService(mockQueries, zap.NewNop())
+
+			resp, err := service.ResolveDeliveryZone(context.Background(), &deliverypb.ResolveDeliveryZoneRequest{
+				PostalCode: tt.postalCode,
+				Prefecture: "東京",
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, resp.Zone)
+			assert.Equal(t, tt.wantZone.String(), resp.Zone.Id)
+			assert.Equal(t, "東京都", resp.Prefecture)
+			assert.Equal(t, tt.deliverable, resp.Deliverable)
+			assert.Equal(t, tt.remoteIsland, resp.RemoteIsland)
+		})
+	}
+
+	t.Run("no zone covers the address", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "北海道").Return([]db.DeliveryZone{chiyoda}, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.ResolveDeliveryZone(context.Background(), &deliverypb.ResolveDeliveryZoneRequest{
+			PostalCode: "060-0001",
+			Prefecture: "北海道",
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Zone)
+		assert.Equal(t, "0600001", resp.PostalCode)
+		assert.False(t, resp.Deliverable)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.ResolveDeliveryZone(context.Background(), &deliverypb.ResolveDeliveryZoneRequest{
+			PostalCode: "100-001",
+			Prefecture: "東京都",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = service.ResolveDeliveryZone(context.Background(), &deliverypb.ResolveDeliveryZoneRequest{
+			PostalCode: "100-0001",
+			Prefecture: "Atlantis",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDeliveryService_GetDeliverySlotsForAddress(t *testing.T) {
+	date := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("returns the zone's slots", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		zone := db.DeliveryZone{ID: uuid.New(), Name: "東京", Prefectures: []string{"東京都"}, DeliveryDays: 1}
+		slot := db.DeliverySlot{ID: uuid.New(), DeliveryZoneID: zone.ID, Capacity: 10, Available: 10, Date: date}
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return([]db.DeliveryZone{zone}, nil)
+		mockQueries.On("GetDeliverySlots", mock.Anything, zone.ID, date).Return([]db.DeliverySlot{slot}, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.GetDeliverySlotsForAddress(context.Background(), &deliverypb.GetDeliverySlotsForAddressRequest{
+			PostalCode: "1600022",
+			Prefecture: "13",
+			Date:       timestamppb.New(date),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Zone.Deliverable)
+		require.Len(t, resp.Slots, 1)
+		assert.Equal(t, slot.ID.String(), resp.Slots[0].Id)
+	})
+
+	t.Run("undeliverable address gets no slots", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		zone := db.DeliveryZone{ID: uuid.New(), Name: "配達不可", Undeliverable: true}
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return([]db.DeliveryZone{zone}, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.GetDeliverySlotsForAddress(context.Background(), &deliverypb.GetDeliverySlotsForAddressRequest{
+			PostalCode: "1000401",
+			Prefecture: "東京都",
+			Date:       timestamppb.New(date),
+		})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Zone.Deliverable)
+		assert.Empty(t, resp.Slots)
+		mockQueries.AssertNotCalled(t, "GetDeliverySlots", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
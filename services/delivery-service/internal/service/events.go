@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Shipment event types consumed by other services
+const (
+	ShipmentEventShipped   = "shipment.shipped"
+	ShipmentEventInTransit = "shipment.in_transit"
+	ShipmentEventDelivered = "shipment.delivered"
+	ShipmentEventFailed    = "shipment.failed"
+	// Pickup point shipments
+	ShipmentEventReadyForPickup = "shipment.ready_for_pickup"
+	ShipmentEventPickedUp       = "shipment.picked_up"
+	ShipmentEventReturned       = "shipment.returned"
+)
+
+// ShipmentEventPublisher publishes shipment-related events to Kafka
+type ShipmentEventPublisher struct {
+	producer sarama.SyncProducer
+	topic    string
+	logger   *zap.Logger
+}
+
+// NewShipmentEventProducer creates a new shipment event publisher
+func NewShipmentEventProducer(
+	brokers []string,
+	topic string,
+	logger *zap.Logger,
+) (*ShipmentEventPublisher, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+
+	return &ShipmentEventPublisher{
+		producer: producer,
+		topic:    topic,
+		logger:   logger,
+	}, nil
+}
+
+// Close closes the Kafka producer
+func (p *ShipmentEventPublisher) Close() error {
+	return p.producer.Close()
+}
+
+// ShipmentEvent represents a shipment event
+type ShipmentEvent struct {
+	EventID    string                 `json:"event_id"`
+	EventType  string                 `json:"event_type"`
+	ShipmentID string                 `json:"shipment_id"`
+	OrderID    string                 `json:"order_id"`
+	Status     string                 `json:"status"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// Publish sends an event to Kafka keyed by order so per-order ordering holds
+func (p *ShipmentEventPublisher) Publish(ctx context.Context, event ShipmentEvent) error {
+	if event.EventID == "" {
+		event.EventID = uuid.New().String()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	message := &sarama.ProducerMessage{
+		Topic:     p.topic,
+		Key:       sarama.StringEncoder(event.OrderID),
+		Value:     sarama.ByteEncoder(data),
+		Timestamp: time.Now(),
+	}
+
+	partition, offset, err := p.producer.SendMessage(message)
+	if err != nil {
+		p.logger.Error("Failed to publish shipment event",
+			zap.String("event_type", event.EventType),
+			zap.String("shipment_id", event.ShipmentID),
+			zap.Error(err))
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	p.logger.Info("Published shipment event",
+		zap.String("event_type", event.EventType),
+		zap.String("shipment_id", event.ShipmentID),
+		zap.Int32("partition", partition),
+		zap.Int64("offset", offset))
+
+	return nil
+}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+const (
+	// Days a parcel waits at a pickup point when the point does not say:
+	// konbini counters keep parcels for a week, lockers free up sooner
+	defaultKonbiniHoldDays = 7
+	defaultLockerHoldDays  = 3
+
+	defaultPickupPointsLimit = 20
+	maxPickupPointsLimit     = 50
+
+	// pickupCodeDigits is the length of the code customers show to collect
+	// a parcel, as konbini terminals and lockers take numbers only
+	pickupCodeDigits = 8
+
+	// pickupSweepBatchSize caps how many uncollected parcels one sweep returns
+	pickupSweepBatchSize = 100
+)
+
+// ListPickupPoints finds the active pickup points near a postal code,
+// nearest first
+func (s *DeliveryService) ListPickupPoints(ctx context.Context, req *deliverypb.ListPickupPointsRequest) (*deliverypb.ListPickupPointsResponse, error) {
+	s.logger.Info("Listing pickup points",
+		zap.String("postal_code", req.PostalCode),
+		zap.String("type", req.Type.String()))
+
+	postalCode, ok := normalizePostalCode(req.PostalCode)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid postal_code: must be 7 digits")
+	}
+	pointType := ""
+	if req.Type != deliverypb.PickupPointType_PICKUP_POINT_TYPE_UNSPECIFIED {
+		pointType = req.Type.String()
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultPickupPointsLimit
+	}
+	limit = min(limit, maxPickupPointsLimit)
+
+	points, err := s.queries.ListPickupPoints(ctx, postalCode, pointType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pickup points: %w", err)
+	}
+
+	protoPoints := make([]*deliverypb.PickupPoint, 0, len(points))
+	for _, point := range points {
+		protoPoints = append(protoPoints, pickupPointToProto(point))
+	}
+
+	return &deliverypb.ListPickupPointsResponse{
+		PickupPoints: protoPoints,
+	}, nil
+}
+
+func (s *DeliveryService) GetPickupPoint(ctx context.Context, req *deliverypb.GetPickupPointRequest) (*deliverypb.GetPickupPointResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id: must be a valid UUID")
+	}
+
+	point, err := s.queries.GetPickupPoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "pickup point not found")
+		}
+		return nil, fmt.Errorf("failed to get pickup point: %w", err)
+	}
+
+	return &deliverypb.GetPickupPointResponse{
+		PickupPoint: pickupPointToProto(point),
+	}, nil
+}
+
+func (s *DeliveryService) CreatePickupPoint(ctx context.Context, req *deliverypb.CreatePickupPointRequest) (*deliverypb.CreatePickupPointResponse, error) {
+	point, err := pickupPointFromProto(req.PickupPoint)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Creating pickup point", zap.String("code", point.Code))
+
+	created, err := s.queries.CreatePickupPoint(ctx, db.CreatePickupPointParams{
+		Code:         point.Code,
+		Name:         point.Name,
+		Type:         point.Type,
+		Operator:     point.Operator,
+		PostalCode:   point.PostalCode,
+		Prefecture:   point.Prefecture,
+		City:         point.City,
+		AddressLine1: point.AddressLine1,
+		AddressLine2: point.AddressLine2,
+		OpeningHours: point.OpeningHours,
+		HoldDays:     point.HoldDays,
+		Active:       point.Active,
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "pickup point %q already exists", point.Code)
+		}
+		return nil, fmt.Errorf("failed to create pickup point: %w", err)
+	}
+
+	return &deliverypb.CreatePickupPointResponse{
+		PickupPoint: pickupPointToProto(created),
+	}, nil
+}
+
+// UpdatePickupPoint replaces a pickup point's details. Shipments already
+// sent there keep the address they were sent to; deactivating a point only
+// stops new shipments going there.
+func (s *DeliveryService) UpdatePickupPoint(ctx context.Context, req *deliverypb.UpdatePickupPointRequest) (*deliverypb.UpdatePickupPointResponse, error) {
+	point, err := pickupPointFromProto(req.PickupPoint)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(req.PickupPoint.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id: must be a valid UUID")
+	}
+	s.logger.Info("Updating pickup point", zap.String("pickup_point_id", id.String()))
+
+	updated, err := s.queries.UpdatePickupPoint(ctx, db.UpdatePickupPointParams{
+		ID:           id,
+		Code:         point.Code,
+		Name:         point.Name,
+		Type:         point.Type,
+		Operator:     point.Operator,
+		PostalCode:   point.PostalCode,
+		Prefecture:   point.Prefecture,
+		City:         point.City,
+		AddressLine1: point.AddressLine1,
+		AddressLine2: point.AddressLine2,
+		OpeningHours: point.OpeningHours,
+		HoldDays:     point.HoldDays,
+		Active:       point.Active,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "pickup point not found")
+		}
+		if isDuplicateKeyError(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "pickup point %q already exists", point.Code)
+		}
+		return nil, fmt.Errorf("failed to update pickup point: %w", err)
+	}
+
+	return &deliverypb.UpdatePickupPointResponse{
+		PickupPoint: pickupPointToProto(updated),
+	}, nil
+}
+
+// ConfirmPickup records that the customer collected their parcel at the
+// pickup point, on showing the shipment's pickup code. Confirming a parcel
+// already collected is a no-op.
+func (s *DeliveryService) ConfirmPickup(ctx context.Context, req *deliverypb.ConfirmPickupRequest) (*deliverypb.ConfirmPickupResponse, error) {
+	s.logger.Info("Confirming pickup", zap.String("shipment_id", req.ShipmentId))
+
+	shipment, err := s.findShipment(ctx, req.ShipmentId, "")
+	if err != nil {
+		return nil, err
+	}
+	if shipment.PickupPointID == nil {
+		return nil, status.Error(codes.FailedPrecondition, "shipment is not sent to a pickup point")
+	}
+	code := strings.TrimSpace(foldWidth(req.PickupCode))
+	if subtle.ConstantTimeCompare([]byte(code), []byte(toStringPtr(shipment.PickupCode))) != 1 {
+		return nil, status.Error(codes.PermissionDenied, "pickup code does not match")
+	}
+
+	readyForPickup := deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP
+	pickedUp := deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP
+	switch current := parseShipmentStatus(shipment.Status); current {
+	case pickedUp:
+		return &deliverypb.ConfirmPickupResponse{Shipment: s.shipmentToProto(shipment)}, nil
+	case readyForPickup:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "shipment is %s, not ready for pickup", current)
+	}
+
+	updated, err := s.queries.TransitionShipmentStatus(ctx, shipment.ID, readyForPickup.String(), pickedUp.String(), "Collected at pickup point")
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.Aborted, "shipment status changed meanwhile")
+		}
+		return nil, fmt.Errorf("failed to update shipment status: %w", err)
+	}
+	s.publishShipmentStatusChanged(ctx, updated, shipment.Status)
+
+	return &deliverypb.ConfirmPickupResponse{
+		Shipment: s.shipmentToProto(updated),
+	}, nil
+}
+
+// pickupPointForDispatch returns the pickup point a shipment is to be sent
+// to, which must still take parcels
+func (s *DeliveryService) pickupPointForDispatch(ctx context.Context, pickupPointID string) (db.PickupPoint, error) {
+	id, err := uuid.Parse(pickupPointID)
+	if err != nil {
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "invalid pickup_point_id: must be a valid UUID")
+	}
+	point, err := s.queries.GetPickupPoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.PickupPoint{}, status.Error(codes.FailedPrecondition, "pickup point not found")
+		}
+		return db.PickupPoint{}, fmt.Errorf("failed to get pickup point: %w", err)
+	}
+	if !point.Active {
+		return db.PickupPoint{}, status.Errorf(codes.FailedPrecondition, "pickup point %s no longer takes parcels", point.Name)
+	}
+	return point, nil
+}
+
+// pickupRecipient addresses a parcel to the customer care of the pickup
+// point. Only the customer's name and phone are kept, for the point to
+// contact them.
+func pickupRecipient(a *deliverypb.ShipmentAddress, point db.PickupPoint) (db.Address, error) {
+	if a == nil {
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient is required")
+	}
+	recipient := db.Address{
+		Name:         strings.TrimSpace(a.Name),
+		Phone:        strings.TrimSpace(a.Phone),
+		PostalCode:   point.PostalCode,
+		Prefecture:   point.Prefecture,
+		City:         point.City,
+		AddressLine1: strings.TrimSpace(point.AddressLine1 + " " + point.AddressLine2),
+		AddressLine2: point.Name,
+	}
+	switch {
+	case recipient.Name == "":
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient name is required")
+	case recipient.Phone == "":
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient phone is required")
+	}
+	return recipient, nil
+}
+
+// checkPickupStatus rejects status changes that do not fit how the shipment
+// is collected: only pickup point shipments wait for pickup, and they are
+// never delivered to the door
+func checkPickupStatus(shipment db.Shipment, to deliverypb.ShipmentStatus) error {
+	switch to {
+	case deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_RETURNED:
+		if shipment.PickupPointID == nil {
+			return status.Errorf(codes.FailedPrecondition, "shipment is not sent to a pickup point and cannot be %s", to)
+		}
+	case deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED:
+		if shipment.PickupPointID != nil {
+			return status.Error(codes.FailedPrecondition, "shipment is sent to a pickup point; mark it ready for pickup instead")
+		}
+	}
+	return nil
+}
+
+// newPickupCode returns a random numeric code for collecting a parcel
+func newPickupCode() (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(pickupCodeDigits), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", pickupCodeDigits, n), nil
+}
+
+// pickupPointFromProto validates a pickup point's details. The type's
+// default hold days are used when none are given.
+func pickupPointFromProto(p *deliverypb.PickupPoint) (db.PickupPoint, error) {
+	if p == nil {
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "pickup_point is required")
+	}
+	point := db.PickupPoint{
+		Code:         strings.TrimSpace(p.Code),
+		Name:         strings.TrimSpace(p.Name),
+		Type:         p.Type.String(),
+		Operator:     strings.TrimSpace(p.Operator),
+		City:         strings.TrimSpace(p.City),
+		AddressLine1: strings.TrimSpace(p.AddressLine1),
+		AddressLine2: strings.TrimSpace(p.AddressLine2),
+		OpeningHours: strings.TrimSpace(p.OpeningHours),
+		HoldDays:     int(p.HoldDays),
+		Active:       p.Active,
+	}
+	switch {
+	case point.Code == "":
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "code is required")
+	case point.Name == "":
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "name is required")
+	case point.City == "" || point.AddressLine1 == "":
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "city and address_line1 are required")
+	case point.HoldDays < 0:
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "hold_days must not be negative")
+	}
+
+	switch p.Type {
+	case deliverypb.PickupPointType_PICKUP_POINT_TYPE_KONBINI:
+		if point.HoldDays == 0 {
+			point.HoldDays = defaultKonbiniHoldDays
+		}
+	case deliverypb.PickupPointType_PICKUP_POINT_TYPE_LOCKER:
+		if point.HoldDays == 0 {
+			point.HoldDays = defaultLockerHoldDays
+		}
+	default:
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "type is required")
+	}
+
+	var ok bool
+	if point.PostalCode, ok = normalizePostalCode(p.PostalCode); !ok {
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "invalid postal_code: must be 7 digits")
+	}
+	if point.Prefecture, ok = normalizePrefecture(p.Prefecture); !ok {
+		return db.PickupPoint{}, status.Error(codes.InvalidArgument, "invalid prefecture")
+	}
+	return point, nil
+}
+
+func pickupPointToProto(p db.PickupPoint) *deliverypb.PickupPoint {
+	return &deliverypb.PickupPoint{
+		Id:           p.ID.String(),
+		Code:         p.Code,
+		Name:         p.Name,
+		Type:         deliverypb.PickupPointType(deliverypb.PickupPointType_value[p.Type]),
+		Operator:     p.Operator,
+		PostalCode:   p.PostalCode,
+		Prefecture:   p.Prefecture,
+		City:         p.City,
+		AddressLine1: p.AddressLine1,
+		AddressLine2: p.AddressLine2,
+		OpeningHours: p.OpeningHours,
+		HoldDays:     int32(p.HoldDays),
+		Active:       p.Active,
+	}
+}
+
+// PickupExpirySweeper returns parcels left at pickup points past their
+// pickup deadline to the sender
+type PickupExpirySweeper struct {
+	deliveries *DeliveryService
+	logger     *zap.Logger
+}
+
+// NewPickupExpirySweeper creates a new pickup expiry sweeper
+func NewPickupExpirySweeper(deliveries *DeliveryService, logger *zap.Logger) *PickupExpirySweeper {
+	return &PickupExpirySweeper{
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+// ExpireUncollected returns parcels whose pickup deadline has passed and
+// announces each. Each batch is returned in one transaction; a full batch
+// is followed by another.
+func (w *PickupExpirySweeper) ExpireUncollected(ctx context.Context) (int, error) {
+	returned := 0
+	for {
+		shipments, err := w.deliveries.queries.ExpireUncollectedPickups(ctx, "Not collected by the pickup deadline, returned to sender", pickupSweepBatchSize)
+		if err != nil {
+			return returned, err
+		}
+		for _, shipment := range shipments {
+			w.logger.Info("Returned uncollected pickup point shipment",
+				zap.String("shipment_id", shipment.ID.String()),
+				zap.String("order_id", shipment.OrderID.String()))
+			w.deliveries.publishShipmentStatusChanged(ctx, shipment, deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP.String())
+		}
+		returned += len(shipments)
+		if len(shipments) < pickupSweepBatchSize {
+			return returned, nil
+		}
+	}
+}
+
+// StartPeriodicSweep starts returning uncollected parcels in the background
+func (w *PickupExpirySweeper) StartPeriodicSweep(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic pickup expiry sweep", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.ExpireUncollected(ctx); err != nil {
+					w.logger.Error("Periodic pickup expiry sweep failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
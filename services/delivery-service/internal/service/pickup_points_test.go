@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func testPickupPoint() db.PickupPoint {
+	return db.PickupPoint{
+		ID:           uuid.New(),
+		Code:         "FM-154002",
+		Name:         "ファミリーマート 池尻二丁目店",
+		Type:         "PICKUP_POINT_TYPE_KONBINI",
+		Operator:     "FamilyMart",
+		PostalCode:   "1540002",
+		Prefecture:   "東京都",
+		City:         "世田谷区",
+		AddressLine1: "池尻2-2-2",
+		HoldDays:     7,
+		Active:       true,
+	}
+}
+
+func TestDeliveryService_ListPickupPoints(t *testing.T) {
+	t.Run("searches near the normalized postal code", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		point := testPickupPoint()
+		mockQueries.On("ListPickupPoints", mock.Anything, "1540001", "PICKUP_POINT_TYPE_KONBINI", maxPickupPointsLimit).
+			Return([]db.PickupPoint{point}, nil)
+
+		resp, err := service.ListPickupPoints(context.Background(), &deliverypb.ListPickupPointsRequest{
+			PostalCode: "〒１５４－０００１",
+			Type:       deliverypb.PickupPointType_PICKUP_POINT_TYPE_KONBINI,
+			Limit:      500,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.PickupPoints, 1)
+		assert.Equal(t, point.ID.String(), resp.PickupPoints[0].Id)
+		assert.Equal(t, deliverypb.PickupPointType_PICKUP_POINT_TYPE_KONBINI, resp.PickupPoints[0].Type)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("any type by default", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		mockQueries.On("ListPickupPoints", mock.Anything, "1540001", "", defaultPickupPointsLimit).Return([]db.PickupPoint{}, nil)
+
+		resp, err := service.ListPickupPoints(context.Background(), &deliverypb.ListPickupPointsRequest{PostalCode: "154-0001"})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.PickupPoints)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("requires a postal code", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.ListPickupPoints(context.Background(), &deliverypb.ListPickupPointsRequest{PostalCode: "154"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDeliveryService_CreatePickupPoint(t *testing.T) {
+	newPoint := func() *deliverypb.PickupPoint {
+		return &deliverypb.PickupPoint{
+			Code:         "PUDO-1540001",
+			Name:         "PUDOステーション 池尻大橋駅",
+			Type:         deliverypb.PickupPointType_PICKUP_POINT_TYPE_LOCKER,
+			PostalCode:   "154-0001",
+			Prefecture:   "東京",
+			City:         "世田谷区",
+			AddressLine1: "池尻3-1-1",
+			Active:       true,
+		}
+	}
+
+	t.Run("lockers hold parcels three days by default", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		mockQueries.On("CreatePickupPoint", mock.Anything, mock.MatchedBy(func(p db.CreatePickupPointParams) bool {
+			return p.HoldDays == defaultLockerHoldDays && p.PostalCode == "1540001" && p.Prefecture == "東京都" &&
+				p.Type == "PICKUP_POINT_TYPE_LOCKER"
+		})).Return(db.PickupPoint{ID: uuid.New(), Type: "PICKUP_POINT_TYPE_LOCKER", HoldDays: defaultLockerHoldDays}, nil)
+
+		resp, err := service.CreatePickupPoint(context.Background(), &deliverypb.CreatePickupPointRequest{PickupPoint: newPoint()})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(defaultLockerHoldDays), resp.PickupPoint.HoldDays)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects a code already in use", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		mockQueries.On("CreatePickupPoint", mock.Anything, mock.Anything).Return(db.PickupPoint{}, &pgconn.PgError{Code: "23505"})
+
+		_, err := service.CreatePickupPoint(context.Background(), &deliverypb.CreatePickupPointRequest{PickupPoint: newPoint()})
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	invalid := []struct {
+		name   string
+		modify func(p *deliverypb.PickupPoint)
+	}{
+		{"missing type", func(p *deliverypb.PickupPoint) { p.Type = deliverypb.PickupPointType_PICKUP_POINT_TYPE_UNSPECIFIED }},
+		{"missing code", func(p *deliverypb.PickupPoint) { p.Code = " " }},
+		{"bad postal code", func(p *deliverypb.PickupPoint) { p.PostalCode = "15400" }},
+		{"bad prefecture", func(p *deliverypb.PickupPoint) { p.Prefecture = "Atlantis" }},
+		{"negative hold days", func(p *deliverypb.PickupPoint) { p.HoldDays = -1 }},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+			point := newPoint()
+			tt.modify(point)
+
+			_, err := service.CreatePickupPoint(context.Background(), &deliverypb.CreatePickupPointRequest{PickupPoint: point})
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	t.Run("update of an unknown point", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		mockQueries.On("UpdatePickupPoint", mock.Anything, mock.Anything).Return(db.PickupPoint{}, pgx.ErrNoRows)
+		point := newPoint()
+		point.Id = uuid.New().String()
+
+		_, err := service.UpdatePickupPoint(context.Background(), &deliverypb.UpdatePickupPointRequest{PickupPoint: point})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestDeliveryService_ConfirmPickup(t *testing.T) {
+	pointID := uuid.New()
+	code := "01234567"
+	readyShipment := func() db.Shipment {
+		return db.Shipment{
+			ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_READY_FOR_PICKUP",
+			PickupPointID: &pointID, PickupCode: &code,
+		}
+	}
+
+	t.Run("collects with the pickup code", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, producer := newEventTestService(t, mockQueries)
+		shipment := readyShipment()
+		collected := shipment
+		collected.Status = "SHIPMENT_STATUS_PICKED_UP"
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipment.ID,
+			"SHIPMENT_STATUS_READY_FOR_PICKUP", "SHIPMENT_STATUS_PICKED_UP", mock.Anything).Return(collected, nil)
+		var event ShipmentEvent
+		expectShipmentEvent(producer, &event)
+
+		resp, err := service.ConfirmPickup(context.Background(), &deliverypb.ConfirmPickupRequest{
+			ShipmentId: shipment.ID.String(),
+			PickupCode: "０１２３４５６７",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP, resp.Shipment.Status)
+		assert.Equal(t, ShipmentEventPickedUp, event.EventType)
+		assert.Equal(t, pointID.String(), event.Data["pickup_point_id"])
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipment := readyShipment()
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+
+		_, err := service.ConfirmPickup(context.Background(), &deliverypb.ConfirmPickupRequest{
+			ShipmentId: shipment.ID.String(),
+			PickupCode: "76543210",
+		})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		mockQueries.AssertNotCalled(t, "TransitionShipmentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already collected", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipment := readyShipment()
+		shipment.Status = "SHIPMENT_STATUS_PICKED_UP"
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+
+		resp, err := service.ConfirmPickup(context.Background(), &deliverypb.ConfirmPickupRequest{
+			ShipmentId: shipment.ID.String(),
+			PickupCode: code,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP, resp.Shipment.Status)
+	})
+
+	t.Run("not yet at the pickup point", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipment := readyShipment()
+		shipment.Status = "SHIPMENT_STATUS_IN_TRANSIT"
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+
+		_, err := service.ConfirmPickup(context.Background(), &deliverypb.ConfirmPickupRequest{
+			ShipmentId: shipment.ID.String(),
+			PickupCode: code,
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("door delivery shipment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipmentID := uuid.New()
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_DELIVERED"}, nil)
+
+		_, err := service.ConfirmPickup(context.Background(), &deliverypb.ConfirmPickupRequest{ShipmentId: shipmentID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_DispatchShipment_PickupPoint(t *testing.T) {
+	orderID := uuid.New()
+	shipmentID := uuid.New()
+	point := testPickupPoint()
+	zoneCarrier := CarrierJapanPost
+	zones := []db.DeliveryZone{
+		{ID: uuid.New(), Name: "Setagaya", PostalCodes: []string{"154"}, Prefectures: []string{"東京都"}, Carrier: &zoneCarrier},
+	}
+
+	t.Run("addresses the parcel to the pickup point", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, fake := newDispatchTestService(t, mockQueries)
+		mockQueries.On("GetPickupPoint", mock.Anything, point.ID).Return(point, nil)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("ListClosureDays", mock.Anything, mock.Anything).Return([]db.ClosureDay{}, nil)
+
+		var recorded db.DispatchShipmentParams
+		mockQueries.On("DispatchShipment", mock.Anything, mock.MatchedBy(func(p db.DispatchShipmentParams) bool {
+			recorded = p
+			return p.ID == shipmentID
+		})).Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_SHIPPED", Carrier: CarrierJapanPost}, nil)
+
+		// Only the customer's name and phone are needed
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:       orderID.String(),
+			Recipient:     &deliverypb.ShipmentAddress{Name: "山田 太郎", Phone: "090-0000-0000"},
+			PickupPointId: point.ID.String(),
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, recorded.PickupPointID)
+		assert.Equal(t, point.ID, *recorded.PickupPointID)
+		require.NotNil(t, recorded.PickupCode)
+		assert.Regexp(t, `^\d{8}$`, *recorded.PickupCode)
+		assert.Equal(t, "1540002", recorded.Recipient.PostalCode)
+		assert.Equal(t, point.Name, recorded.Recipient.AddressLine2)
+		assert.Contains(t, recorded.Description, point.Name)
+
+		raw, ok := fake.Request(recorded.TrackingNumber)
+		require.True(t, ok)
+		assert.Contains(t, string(raw), `"pickup_point_code":"FM-154002"`)
+		mockQueries.AssertNotCalled(t, "GetReservedSlot", mock.Anything, mock.Anything)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("inactive pickup point", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		closed := point
+		closed.Active = false
+		mockQueries.On("GetPickupPoint", mock.Anything, point.ID).Return(closed, nil)
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:       orderID.String(),
+			Recipient:     &deliverypb.ShipmentAddress{Name: "山田 太郎", Phone: "090-0000-0000"},
+			PickupPointId: point.ID.String(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("cash on delivery", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		codAmount := int64(5330)
+		mockQueries.On("GetPickupPoint", mock.Anything, point.ID).Return(point, nil)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING", CODAmountMinor: &codAmount}, nil)
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:       orderID.String(),
+			Recipient:     &deliverypb.ShipmentAddress{Name: "山田 太郎", Phone: "090-0000-0000"},
+			PickupPointId: point.ID.String(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_UpdateShipmentStatus_PickupPoint(t *testing.T) {
+	pointID := uuid.New()
+	tests := []struct {
+		name     string
+		shipment db.Shipment
+		to       deliverypb.ShipmentStatus
+	}{
+		{
+			name:     "door delivery cannot wait for pickup",
+			shipment: db.Shipment{Status: "SHIPMENT_STATUS_IN_TRANSIT"},
+			to:       deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP,
+		},
+		{
+			name:     "pickup shipment is not delivered to the door",
+			shipment: db.Shipment{Status: "SHIPMENT_STATUS_IN_TRANSIT", PickupPointID: &pointID},
+			to:       deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueries := new(MockQuerier)
+			service := NewDeliveryService(mockQueries, zap.NewNop())
+			tt.shipment.ID = uuid.New()
+			mockQueries.On("GetShipment", mock.Anything, tt.shipment.ID).Return(tt.shipment, nil)
+
+			_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+				ShipmentId: tt.shipment.ID.String(),
+				Status:     tt.to,
+			})
+
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+	}
+}
+
+func TestCarrierTracker_Poll_PickupPoint(t *testing.T) {
+	ctx := context.Background()
+	mockQueries := new(MockQuerier)
+	service, fake := newDispatchTestService(t, mockQueries)
+
+	created, err := service.carriers.carriers[CarrierYamato].CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+	require.NoError(t, err)
+	for fake.Advance(created.TrackingNumber) {
+	}
+
+	pointID := uuid.New()
+	shipment := db.Shipment{
+		ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_IN_TRANSIT",
+		Carrier: CarrierYamato, TrackingNumber: &created.TrackingNumber, PickupPointID: &pointID,
+	}
+	mockQueries.On("ClaimShipmentsToTrack", mock.Anything, time.Hour, trackingPollBatchSize).Return([]db.Shipment{shipment}, nil)
+
+	// The carrier's 配達完了 means the parcel reached the pickup point
+	ready := shipment
+	ready.Status = "SHIPMENT_STATUS_READY_FOR_PICKUP"
+	mockQueries.On("RecordCarrierTracking", mock.Anything, shipment.ID, "SHIPMENT_STATUS_READY_FOR_PICKUP",
+		mock.MatchedBy(func(from []string) bool {
+			return assert.ElementsMatch(t, []string{"SHIPMENT_STATUS_SHIPPED", "SHIPMENT_STATUS_IN_TRANSIT", "SHIPMENT_STATUS_FAILED_DELIVERY"}, from)
+		}), mock.Anything).Return(ready, true, nil)
+
+	changed, err := NewCarrierTracker(service, time.Hour, zap.NewNop()).Poll(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestPickupExpirySweeper_ExpireUncollected(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	service, producer := newEventTestService(t, mockQueries)
+	pointID := uuid.New()
+	returned := db.Shipment{ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_RETURNED", PickupPointID: &pointID}
+	mockQueries.On("ExpireUncollectedPickups", mock.Anything, mock.Anything, pickupSweepBatchSize).Return([]db.Shipment{returned}, nil)
+	var event ShipmentEvent
+	expectShipmentEvent(producer, &event)
+
+	n, err := NewPickupExpirySweeper(service, zap.NewNop()).ExpireUncollected(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, ShipmentEventReturned, event.EventType)
+	assert.Equal(t, returned.OrderID.String(), event.OrderID)
+	assert.Equal(t, "SHIPMENT_STATUS_READY_FOR_PICKUP", event.Data["previous_status"])
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// prefectures lists Japan's prefectures in JIS X 0401 order, so that a
+// prefecture's code is its index plus one
+var prefectures = []struct {
+	name   string
+	romaji string
+}{
+	{"北海道", "hokkaido"}, {"青森県", "aomori"}, {"岩手県", "iwate"}, {"宮城県", "miyagi"},
+	{"秋田県", "akita"}, {"山形県", "yamagata"}, {"福島県", "fukushima"}, {"茨城県", "ibaraki"},
+	{"栃木県", "tochigi"}, {"群馬県", "gunma"}, {"埼玉県", "saitama"}, {"千葉県", "chiba"},
+	{"東京都", "tokyo"}, {"神奈川県", "kanagawa"}, {"新潟県", "niigata"}, {"富山県", "toyama"},
+	{"石川県", "ishikawa"}, {"福井県", "fukui"}, {"山梨県", "yamanashi"}, {"長野県", "nagano"},
+	{"岐阜県", "gifu"}, {"静岡県", "shizuoka"}, {"愛知県", "aichi"}, {"三重県", "mie"},
+	{"滋賀県", "shiga"}, {"京都府", "kyoto"}, {"大阪府", "osaka"}, {"兵庫県", "hyogo"},
+	{"奈良県", "nara"}, {"和歌山県", "wakayama"}, {"鳥取県", "tottori"}, {"島根県", "shimane"},
+	{"岡山県", "okayama"}, {"広島県", "hiroshima"}, {"山口県", "yamaguchi"}, {"徳島県", "tokushima"},
+	{"香川県", "kagawa"}, {"愛媛県", "ehime"}, {"高知県", "kochi"}, {"福岡県", "fukuoka"},
+	{"佐賀県", "saga"}, {"長崎県", "nagasaki"}, {"熊本県", "kumamoto"}, {"大分県", "oita"},
+	{"宮崎県", "miyazaki"}, {"鹿児島県", "kagoshima"}, {"沖縄県", "okinawa"},
+}
+
+// prefectureAliases maps every accepted spelling of a prefecture to its
+// full name
+var prefectureAliases = func() map[string]string {
+	aliases := make(map[string]string, len(prefectures)*3)
+	for _, p := range prefectures {
+		aliases[p.name] = p.name
+		aliases[p.romaji] = p.name
+		if p.name != "北海道" {
+			_, size := utf8.DecodeLastRuneInString(p.name)
+			aliases[p.name[:len(p.name)-size]] = p.name
+		}
+	}
+	return aliases
+}()
+
+// normalizePrefecture returns the full name of a prefecture given as its
+// name with or without the 都/道/府/県 suffix, in romaji (tokyo, tokyo-to)
+// or as its JIS code (13). It reports false for anything else.
+func normalizePrefecture(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(foldWidth(s)))
+	if code, err := strconv.Atoi(s); err == nil {
+		if code < 1 || code > len(prefectures) {
+			return "", false
+		}
+		return prefectures[code-1].name, true
+	}
+
+	for _, suffix := range []string{" prefecture", "-ken", "-to", "-fu"} {
+		s = strings.TrimSuffix(s, suffix)
+	}
+	name, ok := prefectureAliases[s]
+	return name, ok
+}
+
+// foldWidth maps full-width ASCII (０-９, Ａ-Ｚ, －) and the ideographic
+// space to their half-width forms
+func foldWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '！' && r <= '～':
+			return r - '！' + '!'
+		case r == '　':
+			return ' '
+		}
+		return r
+	}, s)
+}
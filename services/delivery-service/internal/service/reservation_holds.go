@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// defaultReservationHoldTTL is how long a slot is held for an unpaid order
+// when SetReservationHoldTTL is not called
+const defaultReservationHoldTTL = 30 * time.Minute
+
+// holdSweepBatchSize caps how many lapsed holds one sweep releases
+const holdSweepBatchSize = 100
+
+// SetReservationHoldTTL sets how long a reservation holds its slot before
+// the order is paid
+func (s *DeliveryService) SetReservationHoldTTL(ttl time.Duration) {
+	s.holdTTL = ttl
+}
+
+func (s *DeliveryService) reservationHoldTTL() time.Duration {
+	if s.holdTTL <= 0 {
+		return defaultReservationHoldTTL
+	}
+	return s.holdTTL
+}
+
+// ConfirmReservation keeps the order's slot once the order is paid.
+// Confirming twice is a no-op.
+func (s *DeliveryService) ConfirmReservation(ctx context.Context, req *deliverypb.ConfirmReservationRequest) (*deliverypb.ConfirmReservationResponse, error) {
+	s.logger.Info("Confirming delivery slot reservation", zap.String("order_id", req.OrderId))
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id: must be a valid UUID")
+	}
+
+	reservation, err := s.queries.ConfirmReservation(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "reservation not found; the hold may have expired")
+		}
+		return nil, fmt.Errorf("failed to confirm reservation: %w", err)
+	}
+
+	resp := &deliverypb.ConfirmReservationResponse{
+		ReservationId: reservation.ID.String(),
+		SlotId:        reservation.SlotID.String(),
+	}
+	if reservation.ConfirmedAt != nil {
+		resp.ConfirmedAt = timestamppb.New(*reservation.ConfirmedAt)
+	}
+	return resp, nil
+}
+
+// ReleaseDeliverySlot gives up the order's slot, held or confirmed.
+// Releasing an order without a reservation is not an error.
+func (s *DeliveryService) ReleaseDeliverySlot(ctx context.Context, req *deliverypb.ReleaseDeliverySlotRequest) (*deliverypb.ReleaseDeliverySlotResponse, error) {
+	s.logger.Info("Releasing delivery slot", zap.String("order_id", req.OrderId))
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id: must be a valid UUID")
+	}
+
+	released, err := s.queries.ReleaseDeliverySlot(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release delivery slot: %w", err)
+	}
+
+	return &deliverypb.ReleaseDeliverySlotResponse{
+		Released: released,
+	}, nil
+}
+
+// ReservationHoldSweeper frees the slots of holds that lapsed before their
+// order was paid
+type ReservationHoldSweeper struct {
+	deliveries *DeliveryService
+	logger     *zap.Logger
+}
+
+// NewReservationHoldSweeper creates a new reservation hold sweeper
+func NewReservationHoldSweeper(deliveries *DeliveryService, logger *zap.Logger) *ReservationHoldSweeper {
+	return &ReservationHoldSweeper{
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+// ReleaseExpired releases lapsed holds. Each batch is released in one
+// transaction; a full batch is followed by another.
+func (w *ReservationHoldSweeper) ReleaseExpired(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		reservations, err := w.deliveries.queries.ReleaseExpiredHolds(ctx, holdSweepBatchSize)
+		if err != nil {
+			return released, err
+		}
+		for _, reservation := range reservations {
+			w.logger.Info("Released expired delivery slot hold",
+				zap.String("order_id", reservation.OrderID.String()),
+				zap.String("slot_id", reservation.SlotID.String()))
+		}
+		released += len(reservations)
+		if len(reservations) < holdSweepBatchSize {
+			return released, nil
+		}
+	}
+}
+
+// StartPeriodicSweep starts releasing lapsed holds in the background
+func (w *ReservationHoldSweeper) StartPeriodicSweep(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic reservation hold sweep", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.ReleaseExpired(ctx); err != nil {
+					w.logger.Error("Periodic reservation hold sweep failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
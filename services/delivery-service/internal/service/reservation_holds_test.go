@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func TestDeliveryService_ReserveDeliverySlot_Hold(t *testing.T) {
+	t.Run("uses the configured hold", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		slotID := uuid.New()
+		orderID := uuid.New()
+		mockQueries.On("ReserveDeliverySlot", mock.Anything, slotID, orderID, 2*time.Hour).Return(db.DeliveryReservation{
+			ID: uuid.New(), SlotID: slotID, OrderID: orderID, Status: "RESERVATION_STATUS_HELD",
+		}, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		service.SetReservationHoldTTL(2 * time.Hour)
+
+		_, err := service.ReserveDeliverySlot(context.Background(), &deliverypb.ReserveDeliverySlotRequest{
+			SlotId: slotID.String(), OrderId: orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("confirmed reservation cannot move", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("ReserveDeliverySlot", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(db.DeliveryReservation{}, db.ErrReservationConfirmed)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		_, err := service.ReserveDeliverySlot(context.Background(), &deliverypb.ReserveDeliverySlotRequest{
+			SlotId: uuid.New().String(), OrderId: uuid.New().String(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_ConfirmReservation(t *testing.T) {
+	t.Run("confirms the hold", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		orderID := uuid.New()
+		confirmedAt := time.Now()
+		reservation := db.DeliveryReservation{
+			ID: uuid.New(), SlotID: uuid.New(), OrderID: orderID,
+			Status: "RESERVATION_STATUS_CONFIRMED", ConfirmedAt: &confirmedAt,
+		}
+		mockQueries.On("ConfirmReservation", mock.Anything, orderID).Return(reservation, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.ConfirmReservation(context.Background(), &deliverypb.ConfirmReservationRequest{OrderId: orderID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, reservation.ID.String(), resp.ReservationId)
+		assert.Equal(t, reservation.SlotID.String(), resp.SlotId)
+		assert.NotNil(t, resp.ConfirmedAt)
+	})
+
+	t.Run("released hold", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("ConfirmReservation", mock.Anything, mock.Anything).Return(db.DeliveryReservation{}, pgx.ErrNoRows)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		_, err := service.ConfirmReservation(context.Background(), &deliverypb.ConfirmReservationRequest{OrderId: uuid.New().String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid order ID", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.ConfirmReservation(context.Background(), &deliverypb.ConfirmReservationRequest{OrderId: "nope"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDeliveryService_ReleaseDeliverySlot(t *testing.T) {
+	orderID := uuid.New()
+
+	for _, released := range []bool{true, false} {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("ReleaseDeliverySlot", mock.Anything, orderID).Return(released, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.ReleaseDeliverySlot(context.Background(), &deliverypb.ReleaseDeliverySlotRequest{OrderId: orderID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, released, resp.Released)
+	}
+}
+
+func TestReservationHoldSweeper_ReleaseExpired(t *testing.T) {
+	t.Run("releases batches until one is short", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		full := make([]db.DeliveryReservation, holdSweepBatchSize)
+		for i := range full {
+			full[i] = db.DeliveryReservation{ID: uuid.New(), SlotID: uuid.New(), OrderID: uuid.New()}
+		}
+		mockQueries.On("ReleaseExpiredHolds", mock.Anything, holdSweepBatchSize).Return(full, nil).Once()
+		mockQueries.On("ReleaseExpiredHolds", mock.Anything, holdSweepBatchSize).
+			Return([]db.DeliveryReservation{{ID: uuid.New(), SlotID: uuid.New(), OrderID: uuid.New()}}, nil).Once()
+		sweeper := NewReservationHoldSweeper(NewDeliveryService(mockQueries, zap.NewNop()), zap.NewNop())
+
+		released, err := sweeper.ReleaseExpired(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, holdSweepBatchSize+1, released)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("reports errors", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("ReleaseExpiredHolds", mock.Anything, holdSweepBatchSize).Return(nil, assert.AnError)
+		sweeper := NewReservationHoldSweeper(NewDeliveryService(mockQueries, zap.NewNop()), zap.NewNop())
+
+		released, err := sweeper.ReleaseExpired(context.Background())
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, released)
+	})
+}
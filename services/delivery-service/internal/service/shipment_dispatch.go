@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// SetCarriers sets the carriers shipments are dispatched to and the address
+// parcels are sent from
+func (s *DeliveryService) SetCarriers(carriers *CarrierRegistry, shipper CarrierAddress) {
+	s.carriers = carriers
+	s.shipper = shipper
+}
+
+// DispatchShipment hands the order's shipment to a carrier and records the
+// tracking number it gives. The carrier is the one asked for, or else the one
+// chosen for the recipient's zone and the service level. The order's
+// reserved slot, if any, becomes the requested delivery date and time. A
+// shipment for a pickup point is addressed to the point and given the
+// pickup code the customer collects it with.
+func (s *DeliveryService) DispatchShipment(ctx context.Context, req *deliverypb.DispatchShipmentRequest) (*deliverypb.DispatchShipmentResponse, error) {
+	s.logger.Info("Dispatching shipment",
+		zap.String("order_id", req.OrderId),
+		zap.String("service_level", req.ServiceLevel.String()),
+		zap.String("carrier", req.Carrier))
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id: must be a valid UUID")
+	}
+	level := req.ServiceLevel
+	if level == deliverypb.ServiceLevel_SERVICE_LEVEL_UNSPECIFIED {
+		level = deliverypb.ServiceLevel_SERVICE_LEVEL_STANDARD
+	}
+	var pickupPoint *db.PickupPoint
+	var recipient db.Address
+	if req.PickupPointId != "" {
+		point, err := s.pickupPointForDispatch(ctx, req.PickupPointId)
+		if err != nil {
+			return nil, err
+		}
+		if recipient, err = pickupRecipient(req.Recipient, point); err != nil {
+			return nil, err
+		}
+		pickupPoint = &point
+	} else if recipient, err = recipientFromProto(req.Recipient); err != nil {
+		return nil, err
+	}
+	if s.carriers == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no carriers are configured")
+	}
+
+	zone, err := s.resolveDeliveryZone(ctx, recipient.PostalCode, recipient.Prefecture)
+	if err != nil {
+		return nil, err
+	}
+	if zone.Zone == nil || !zone.Deliverable {
+		return nil, status.Error(codes.FailedPrecondition, "recipient address cannot be delivered to")
+	}
+	recipient.PostalCode, recipient.Prefecture = zone.PostalCode, zone.Prefecture
+
+	carrier, err := s.chooseCarrier(req.Carrier, zone.Zone.Carrier, level)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment, err := s.shipmentForOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.TrackingNumber != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "shipment was already dispatched to %s", shipment.Carrier)
+	}
+	if shipment.Status != deliverypb.ShipmentStatus_SHIPMENT_STATUS_PREPARING.String() {
+		return nil, status.Error(codes.FailedPrecondition, "shipment has already left the warehouse")
+	}
+	if pickupPoint != nil && shipment.CODAmountMinor != nil {
+		return nil, status.Error(codes.FailedPrecondition, "cash on delivery shipments cannot be sent to a pickup point")
+	}
+
+	carrierReq := CarrierShipmentRequest{
+		OrderID:      orderID.String(),
+		ServiceLevel: level,
+		Sender:       s.shipper,
+		Recipient:    carrierAddressFromDB(recipient),
+		ShipDate:     time.Now(),
+	}
+	if shipment.CODAmountMinor != nil {
+		carrierReq.CODAmountMinor = *shipment.CODAmountMinor
+	}
+	var pickupPointID *uuid.UUID
+	var pickupCode *string
+	if pickupPoint != nil {
+		code, err := newPickupCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate pickup code: %w", err)
+		}
+		carrierReq.PickupPointCode = pickupPoint.Code
+		pickupPointID, pickupCode = &pickupPoint.ID, &code
+	} else {
+		// Pickup points take parcels whenever they arrive, so only door
+		// deliveries ask for the reserved slot
+		slot, err := s.queries.GetReservedSlot(ctx, orderID)
+		switch {
+		case err == nil:
+			carrierReq.DeliveryDate = &slot.Date
+			if slot.TimeWindow != nil {
+				carrierReq.TimeWindow = deliverypb.DeliveryTimeWindow(deliverypb.DeliveryTimeWindow_value[*slot.TimeWindow])
+			}
+		case !errors.Is(err, pgx.ErrNoRows):
+			return nil, fmt.Errorf("failed to get reserved slot: %w", err)
+		}
+	}
+
+	created, err := carrier.CreateShipment(ctx, carrierReq)
+	if err != nil {
+		s.logger.Error("Carrier rejected shipment",
+			zap.String("order_id", orderID.String()),
+			zap.String("carrier", carrier.Code()),
+			zap.Error(err))
+		return nil, status.Errorf(codes.Unavailable, "%s could not register the shipment", carrier.Name())
+	}
+	trackingNumber, err := carrier.ValidateTrackingNumber(created.TrackingNumber)
+	if err != nil {
+		s.logger.Error("Carrier returned a malformed tracking number",
+			zap.String("carrier", carrier.Code()),
+			zap.String("tracking_number", created.TrackingNumber),
+			zap.Error(err))
+		trackingNumber = created.TrackingNumber
+	}
+
+	// Without the carrier's estimate or a reserved slot the zone's delivery
+	// days are counted from today
+	estimatedDeliveryAt := created.EstimatedDeliveryAt
+	if estimatedDeliveryAt == nil {
+		estimatedDeliveryAt = carrierReq.DeliveryDate
+	}
+	if estimatedDeliveryAt == nil {
+		estimate, err := s.estimateDelivery(ctx, carrierReq.ShipDate, int(zone.Zone.DeliveryDays))
+		if err != nil {
+			s.logger.Warn("Failed to estimate delivery", zap.String("order_id", orderID.String()), zap.Error(err))
+		} else {
+			estimatedDeliveryAt = &estimate
+		}
+	}
+	description := fmt.Sprintf("Handed to %s, tracking number %s", carrier.Name(), trackingNumber)
+	if pickupPoint != nil {
+		description += fmt.Sprintf(", for pickup at %s", pickupPoint.Name)
+	}
+	dispatched, err := s.queries.DispatchShipment(ctx, db.DispatchShipmentParams{
+		ID:                  shipment.ID,
+		Carrier:             carrier.Code(),
+		TrackingNumber:      trackingNumber,
+		ServiceLevel:        level.String(),
+		Recipient:           recipient,
+		EstimatedDeliveryAt: estimatedDeliveryAt,
+		PickupPointID:       pickupPointID,
+		PickupCode:          pickupCode,
+		Description:         description,
+	})
+	if err != nil {
+		// Don't leave a label nobody will use at the carrier
+		if cancelErr := carrier.CancelShipment(ctx, trackingNumber); cancelErr != nil {
+			s.logger.Error("Failed to cancel unrecorded carrier shipment",
+				zap.String("carrier", carrier.Code()),
+				zap.String("tracking_number", trackingNumber),
+				zap.Error(cancelErr))
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "shipment was dispatched or left the warehouse meanwhile")
+		}
+		return nil, fmt.Errorf("failed to record dispatched shipment: %w", err)
+	}
+
+	s.logger.Info("Shipment dispatched",
+		zap.String("order_id", orderID.String()),
+		zap.String("carrier", carrier.Code()),
+		zap.String("tracking_number", trackingNumber))
+
+	return &deliverypb.DispatchShipmentResponse{
+		Shipment: s.shipmentToProto(dispatched),
+	}, nil
+}
+
+// CancelShipment cancels a shipment that has not been delivered. A
+// dispatched shipment is cancelled with its carrier first, which refuses
+// once the parcel has been picked up.
+func (s *DeliveryService) CancelShipment(ctx context.Context, req *deliverypb.CancelShipmentRequest) (*deliverypb.CancelShipmentResponse, error) {
+	s.logger.Info("Cancelling shipment", zap.String("shipment_id", req.ShipmentId))
+
+	shipment, err := s.findShipment(ctx, req.ShipmentId, "")
+	if err != nil {
+		return nil, err
+	}
+	cancelled := deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED
+	switch current := parseShipmentStatus(shipment.Status); {
+	case current == cancelled:
+		return &deliverypb.CancelShipmentResponse{Shipment: s.shipmentToProto(shipment)}, nil
+	case current == deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED:
+		return nil, status.Error(codes.FailedPrecondition, "shipment has been delivered")
+	case !canTransitionShipment(current, cancelled):
+		return nil, status.Errorf(codes.FailedPrecondition, "shipment cannot be cancelled from %s", current)
+	}
+
+	if shipment.TrackingNumber != nil && shipment.Carrier != "" {
+		carrier, ok := s.carrier(shipment.Carrier)
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "carrier %q is not configured", shipment.Carrier)
+		}
+		if err := carrier.CancelShipment(ctx, *shipment.TrackingNumber); err != nil {
+			if errors.Is(err, ErrCarrierNotCancellable) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%s has already picked up the shipment", carrier.Name())
+			}
+			if !errors.Is(err, ErrCarrierShipmentNotFound) {
+				s.logger.Error("Failed to cancel shipment with carrier",
+					zap.String("shipment_id", shipment.ID.String()),
+					zap.String("carrier", carrier.Code()),
+					zap.Error(err))
+				return nil, status.Errorf(codes.Unavailable, "%s could not cancel the shipment", carrier.Name())
+			}
+		}
+	}
+
+	description := strings.TrimSpace(req.Reason)
+	if description == "" {
+		description = "Shipment cancelled"
+	}
+	updated, err := s.queries.TransitionShipmentStatus(ctx, shipment.ID, shipment.Status, cancelled.String(), description)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.Aborted, "shipment status changed meanwhile")
+		}
+		return nil, fmt.Errorf("failed to update shipment status: %w", err)
+	}
+	return &deliverypb.CancelShipmentResponse{
+		Shipment: s.shipmentToProto(updated),
+	}, nil
+}
+
+// ValidateTrackingNumber checks a tracking number against the carrier's
+// format and check digit
+func (s *DeliveryService) ValidateTrackingNumber(ctx context.Context, req *deliverypb.ValidateTrackingNumberRequest) (*deliverypb.ValidateTrackingNumberResponse, error) {
+	carrier, ok := s.carrier(req.Carrier)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown carrier %q", req.Carrier)
+	}
+
+	trackingNumber, err := carrier.ValidateTrackingNumber(req.TrackingNumber)
+	if err != nil {
+		return &deliverypb.ValidateTrackingNumberResponse{Reason: err.Error()}, nil
+	}
+	return &deliverypb.ValidateTrackingNumberResponse{
+		Valid:          true,
+		TrackingNumber: trackingNumber,
+	}, nil
+}
+
+// carrier returns the configured carrier with the code
+func (s *DeliveryService) carrier(code string) (Carrier, bool) {
+	if s.carriers == nil {
+		return nil, false
+	}
+	return s.carriers.Get(code)
+}
+
+// chooseCarrier returns the requested carrier, or the one the registry
+// selects for the zone and service level
+func (s *DeliveryService) chooseCarrier(requested, zoneCarrier string, level deliverypb.ServiceLevel) (Carrier, error) {
+	if requested == "" {
+		carrier, err := s.carriers.Select(zoneCarrier, level)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return carrier, nil
+	}
+
+	carrier, ok := s.carriers.Get(requested)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown carrier %q", requested)
+	}
+	if !carrier.SupportsServiceLevel(level) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s does not offer %s", carrier.Name(), level)
+	}
+	return carrier, nil
+}
+
+// shipmentForOrder returns the order's shipment, creating it if the order
+// has none yet
+func (s *DeliveryService) shipmentForOrder(ctx context.Context, orderID uuid.UUID) (db.Shipment, error) {
+	shipment, err := s.queries.GetShipmentByOrderID(ctx, orderID)
+	if err == nil {
+		return shipment, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return db.Shipment{}, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	id, err := s.queries.CreateShipment(ctx, orderID)
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to create shipment: %w", err)
+	}
+	shipment, err = s.queries.GetShipment(ctx, id)
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	return shipment, nil
+}
+
+func recipientFromProto(a *deliverypb.ShipmentAddress) (db.Address, error) {
+	if a == nil {
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient is required")
+	}
+	recipient := db.Address{
+		Name:         strings.TrimSpace(a.Name),
+		Phone:        strings.TrimSpace(a.Phone),
+		PostalCode:   strings.TrimSpace(a.PostalCode),
+		Prefecture:   strings.TrimSpace(a.Prefecture),
+		City:         strings.TrimSpace(a.City),
+		AddressLine1: strings.TrimSpace(a.AddressLine1),
+		AddressLine2: strings.TrimSpace(a.AddressLine2),
+	}
+	switch {
+	case recipient.Name == "":
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient name is required")
+	case recipient.Phone == "":
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient phone is required")
+	case recipient.City == "" || recipient.AddressLine1 == "":
+		return db.Address{}, status.Error(codes.InvalidArgument, "recipient city and address_line1 are required")
+	}
+	return recipient, nil
+}
+
+func carrierAddressFromDB(a db.Address) CarrierAddress {
+	return CarrierAddress{
+		Name:         a.Name,
+		Phone:        a.Phone,
+		PostalCode:   a.PostalCode,
+		Prefecture:   a.Prefecture,
+		City:         a.City,
+		AddressLine1: a.AddressLine1,
+		AddressLine2: a.AddressLine2,
+	}
+}
+
+func shipmentAddressToProto(a db.Address) *deliverypb.ShipmentAddress {
+	return &deliverypb.ShipmentAddress{
+		Name:         a.Name,
+		Phone:        a.Phone,
+		PostalCode:   a.PostalCode,
+		Prefecture:   a.Prefecture,
+		City:         a.City,
+		AddressLine1: a.AddressLine1,
+		AddressLine2: a.AddressLine2,
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// newDispatchTestService returns a delivery service whose carriers talk to a
+// fake carrier server
+func newDispatchTestService(t *testing.T, mockQueries *MockQuerier) (*DeliveryService, *FakeCarrierServer) {
+	t.Helper()
+	fake, carriers := newTestCarriers(t)
+	registry, err := NewCarrierRegistry(CarrierYamato, carriers...)
+	require.NoError(t, err)
+
+	service := NewDeliveryService(mockQueries, zap.NewNop())
+	service.SetCarriers(registry, CarrierAddress{Name: "Shinkansen Commerce", PostalCode: "1000005", Prefecture: "東京都", City: "千代田区", AddressLine1: "丸の内1-1-1"})
+	return service, fake
+}
+
+func testShipmentAddress() *deliverypb.ShipmentAddress {
+	return &deliverypb.ShipmentAddress{
+		Name:         "山田 太郎",
+		Phone:        "090-0000-0000",
+		PostalCode:   "154-0001",
+		Prefecture:   "東京",
+		City:         "世田谷区",
+		AddressLine1: "池尻1-1-1",
+	}
+}
+
+func TestDeliveryService_DispatchShipment(t *testing.T) {
+	orderID := uuid.New()
+	shipmentID := uuid.New()
+	zoneCarrier := CarrierJapanPost
+	zones := []db.DeliveryZone{
+		{ID: uuid.New(), Name: "Tokyo", Prefectures: []string{"東京都"}},
+		{ID: uuid.New(), Name: "Setagaya", PostalCodes: []string{"154"}, Prefectures: []string{"東京都"}, Carrier: &zoneCarrier},
+	}
+
+	t.Run("dispatches to the zone's carrier with the reserved slot", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, fake := newDispatchTestService(t, mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		slotDate := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+		window := "DELIVERY_TIME_WINDOW_MORNING"
+		mockQueries.On("GetReservedSlot", mock.Anything, orderID).Return(db.DeliverySlot{Date: slotDate, TimeWindow: &window}, nil)
+
+		var recorded db.DispatchShipmentParams
+		mockQueries.On("DispatchShipment", mock.Anything, mock.MatchedBy(func(p db.DispatchShipmentParams) bool {
+			recorded = p
+			return p.ID == shipmentID
+		})).Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING", Carrier: CarrierJapanPost}, nil)
+
+		resp, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:   orderID.String(),
+			Recipient: testShipmentAddress(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, CarrierJapanPost, resp.Shipment.Carrier)
+		assert.Equal(t, CarrierJapanPost, recorded.Carrier)
+		assert.Equal(t, "SERVICE_LEVEL_STANDARD", recorded.ServiceLevel)
+		assert.Equal(t, "1540001", recorded.Recipient.PostalCode)
+		assert.Equal(t, "東京都", recorded.Recipient.Prefecture)
+		require.NotNil(t, recorded.EstimatedDeliveryAt)
+
+		_, err = service.carriers.carriers[CarrierJapanPost].ValidateTrackingNumber(recorded.TrackingNumber)
+		require.NoError(t, err)
+		raw, ok := fake.Request(recorded.TrackingNumber)
+		require.True(t, ok)
+		assert.Contains(t, string(raw), `"delivery_time_code":"51"`)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("requested carrier overrides the zone", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).Return(db.Shipment{}, pgx.ErrNoRows)
+		mockQueries.On("CreateShipment", mock.Anything, orderID).Return(shipmentID, nil)
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("GetReservedSlot", mock.Anything, orderID).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+		// Sagawa gives no estimate, so the zone's delivery days are counted
+		mockQueries.On("ListClosureDays", mock.Anything, mock.Anything).Return([]db.ClosureDay{}, nil)
+		mockQueries.On("DispatchShipment", mock.Anything, mock.MatchedBy(func(p db.DispatchShipmentParams) bool {
+			return p.Carrier == CarrierSagawa && p.ServiceLevel == "SERVICE_LEVEL_COOL_FROZEN" &&
+				p.EstimatedDeliveryAt != nil && shippingCalendar.IsBusinessDay(*p.EstimatedDeliveryAt)
+		})).Return(db.Shipment{ID: shipmentID, OrderID: orderID, Carrier: CarrierSagawa}, nil)
+
+		resp, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:      orderID.String(),
+			ServiceLevel: deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN,
+			Recipient:    testShipmentAddress(),
+			Carrier:      CarrierSagawa,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, CarrierSagawa, resp.Shipment.Carrier)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("zone carrier without the service level falls back", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("GetReservedSlot", mock.Anything, orderID).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+		mockQueries.On("ListClosureDays", mock.Anything, mock.Anything).Return([]db.ClosureDay{}, nil)
+		mockQueries.On("DispatchShipment", mock.Anything, mock.MatchedBy(func(p db.DispatchShipmentParams) bool {
+			return p.Carrier == CarrierYamato
+		})).Return(db.Shipment{ID: shipmentID, Carrier: CarrierYamato}, nil)
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:      orderID.String(),
+			ServiceLevel: deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN,
+			Recipient:    testShipmentAddress(),
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("cancels the carrier shipment when recording fails", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, fake := newDispatchTestService(t, mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("GetReservedSlot", mock.Anything, orderID).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+		mockQueries.On("ListClosureDays", mock.Anything, mock.Anything).Return([]db.ClosureDay{}, nil)
+		var trackingNumber string
+		mockQueries.On("DispatchShipment", mock.Anything, mock.MatchedBy(func(p db.DispatchShipmentParams) bool {
+			trackingNumber = p.TrackingNumber
+			return true
+		})).Return(db.Shipment{}, pgx.ErrNoRows)
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:   orderID.String(),
+			Recipient: testShipmentAddress(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.False(t, fake.Advance(trackingNumber))
+	})
+
+	t.Run("already dispatched", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		trackingNumber := "400000000013"
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).
+			Return(db.Shipment{ID: shipmentID, OrderID: orderID, Status: "SHIPMENT_STATUS_PREPARING", Carrier: CarrierYamato, TrackingNumber: &trackingNumber}, nil)
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:   orderID.String(),
+			Recipient: testShipmentAddress(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("undeliverable address", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").
+			Return([]db.DeliveryZone{{ID: uuid.New(), Prefectures: []string{"東京都"}, Undeliverable: true}}, nil)
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:   orderID.String(),
+			Recipient: testShipmentAddress(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("validates the request", func(t *testing.T) {
+		service, _ := newDispatchTestService(t, new(MockQuerier))
+		noName := testShipmentAddress()
+		noName.Name = " "
+
+		tests := []*deliverypb.DispatchShipmentRequest{
+			{OrderId: "not-a-uuid", Recipient: testShipmentAddress()},
+			{OrderId: orderID.String()},
+			{OrderId: orderID.String(), Recipient: noName},
+		}
+		for _, req := range tests {
+			_, err := service.DispatchShipment(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("without carriers", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.DispatchShipment(context.Background(), &deliverypb.DispatchShipmentRequest{
+			OrderId:   orderID.String(),
+			Recipient: testShipmentAddress(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_CancelShipment(t *testing.T) {
+	shipmentID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("cancels with the carrier before pickup", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, fake := newDispatchTestService(t, mockQueries)
+		created, err := service.carriers.carriers[CarrierYamato].CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+		require.NoError(t, err)
+		shipment := db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_PREPARING", Carrier: CarrierYamato, TrackingNumber: &created.TrackingNumber}
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(shipment, nil)
+		cancelled := shipment
+		cancelled.Status = "SHIPMENT_STATUS_CANCELLED"
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_PREPARING", "SHIPMENT_STATUS_CANCELLED", "customer cancelled").Return(cancelled, nil)
+
+		resp, err := service.CancelShipment(ctx, &deliverypb.CancelShipmentRequest{ShipmentId: shipmentID.String(), Reason: "customer cancelled"})
+
+		require.NoError(t, err)
+		assert.Equal(t, deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED, resp.Shipment.Status)
+		assert.False(t, fake.Advance(created.TrackingNumber))
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("refuses once the carrier has picked up", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, fake := newDispatchTestService(t, mockQueries)
+		created, err := service.carriers.carriers[CarrierSagawa].CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+		require.NoError(t, err)
+		require.True(t, fake.Advance(created.TrackingNumber))
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_SHIPPED", Carrier: CarrierSagawa, TrackingNumber: &created.TrackingNumber}, nil)
+
+		_, err = service.CancelShipment(ctx, &deliverypb.CancelShipmentRequest{ShipmentId: shipmentID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "TransitionShipmentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("undispatched shipment is cancelled locally", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_PREPARING", "SHIPMENT_STATUS_CANCELLED", "Shipment cancelled").
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_CANCELLED"}, nil)
+
+		_, err := service.CancelShipment(ctx, &deliverypb.CancelShipmentRequest{ShipmentId: shipmentID.String()})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("delivered shipment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_DELIVERED"}, nil)
+
+		_, err := service.CancelShipment(ctx, &deliverypb.CancelShipmentRequest{ShipmentId: shipmentID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_ValidateTrackingNumber(t *testing.T) {
+	service, _ := newDispatchTestService(t, new(MockQuerier))
+
+	resp, err := service.ValidateTrackingNumber(context.Background(), &deliverypb.ValidateTrackingNumberRequest{
+		Carrier:        CarrierYamato,
+		TrackingNumber: "4000-0000-0013",
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, "400000000013", resp.TrackingNumber)
+
+	resp, err = service.ValidateTrackingNumber(context.Background(), &deliverypb.ValidateTrackingNumberRequest{
+		Carrier:        CarrierYamato,
+		TrackingNumber: "400000000012",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	assert.Contains(t, resp.Reason, "check digit")
+
+	_, err = service.ValidateTrackingNumber(context.Background(), &deliverypb.ValidateTrackingNumberRequest{
+		Carrier:        "seino",
+		TrackingNumber: "400000000013",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCarrierTracker_Poll(t *testing.T) {
+	ctx := context.Background()
+	mockQueries := new(MockQuerier)
+	service, fake := newDispatchTestService(t, mockQueries)
+	payments := &fakePaymentClient{}
+	service.SetPaymentClient(payments)
+
+	created, err := service.carriers.carriers[CarrierYamato].CreateShipment(ctx, testCarrierShipmentRequest("山田 太郎"))
+	require.NoError(t, err)
+	for fake.Advance(created.TrackingNumber) {
+	}
+
+	codAmount, currency := int64(5330), "JPY"
+	shipment := db.Shipment{
+		ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_SHIPPED",
+		Carrier: CarrierYamato, TrackingNumber: &created.TrackingNumber,
+		CODAmountMinor: &codAmount, CODCurrency: &currency,
+	}
+	unknown := "999999999995"
+	lost := db.Shipment{ID: uuid.New(), Status: "SHIPMENT_STATUS_SHIPPED", Carrier: CarrierYamato, TrackingNumber: &unknown}
+	mockQueries.On("ClaimShipmentsToTrack", mock.Anything, time.Hour, trackingPollBatchSize).Return([]db.Shipment{lost, shipment}, nil)
+
+	delivered := shipment
+	delivered.Status = "SHIPMENT_STATUS_DELIVERED"
+	mockQueries.On("RecordCarrierTracking", mock.Anything, shipment.ID, "SHIPMENT_STATUS_DELIVERED",
+		mock.MatchedBy(func(from []string) bool {
+			return assert.ElementsMatch(t, []string{"SHIPMENT_STATUS_SHIPPED", "SHIPMENT_STATUS_IN_TRANSIT", "SHIPMENT_STATUS_FAILED_DELIVERY"}, from)
+		}),
+		mock.MatchedBy(func(checkpoints []db.CreateTrackingEventParams) bool {
+			return len(checkpoints) == 4 &&
+				checkpoints[0].Status == "SHIPMENT_STATUS_SHIPPED" &&
+				checkpoints[3].Status == "SHIPMENT_STATUS_DELIVERED" &&
+				checkpoints[3].Description == "ヤマト運輸: 配達完了" &&
+				checkpoints[3].Location == "世田谷センター"
+		})).Return(delivered, true, nil)
+
+	changed, err := NewCarrierTracker(service, time.Hour, zap.NewNop()).Poll(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	require.Len(t, payments.completed, 1)
+	assert.Equal(t, shipment.OrderID.String(), payments.completed[0].OrderId)
+	assert.Equal(t, codAmount, payments.completed[0].CollectedAmount.Units)
+	mockQueries.AssertExpectations(t)
+}
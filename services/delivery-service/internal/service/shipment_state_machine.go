@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// shipmentTransitions holds the statuses a shipment can move to from each
+// status. Carriers may skip IN_TRANSIT, and a failed delivery goes back out
+// for redelivery. A shipment sent to a pickup point waits there until the
+// customer collects it or its pickup deadline passes and it is returned.
+// Any shipment not yet delivered or at its pickup point can be cancelled;
+// once a carrier has picked the parcel up, the carrier refuses.
+var shipmentTransitions = map[deliverypb.ShipmentStatus][]deliverypb.ShipmentStatus{
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_PREPARING: {
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED,
+	},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED: {
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED,
+	},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT: {
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED,
+	},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY: {
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED,
+	},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP: {
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP,
+		deliverypb.ShipmentStatus_SHIPMENT_STATUS_RETURNED,
+	},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED: {},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP: {},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_RETURNED:  {},
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED: {},
+}
+
+// shipmentEventTypes are the events published when a shipment enters a status
+var shipmentEventTypes = map[deliverypb.ShipmentStatus]string{
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED:          ShipmentEventShipped,
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT:       ShipmentEventInTransit,
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED:        ShipmentEventDelivered,
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY:  ShipmentEventFailed,
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP: ShipmentEventReadyForPickup,
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP:        ShipmentEventPickedUp,
+	deliverypb.ShipmentStatus_SHIPMENT_STATUS_RETURNED:         ShipmentEventReturned,
+}
+
+// parseShipmentStatus returns the status a shipment's status column holds
+func parseShipmentStatus(s string) deliverypb.ShipmentStatus {
+	return deliverypb.ShipmentStatus(deliverypb.ShipmentStatus_value[s])
+}
+
+// canTransitionShipment reports whether a shipment can move from one status
+// to another
+func canTransitionShipment(from, to deliverypb.ShipmentStatus) bool {
+	for _, allowed := range shipmentTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// shipmentStatusesBefore lists the statuses a shipment can move to status
+// from, as stored in the status column
+func shipmentStatusesBefore(to deliverypb.ShipmentStatus) []string {
+	var from []string
+	for _, s := range deliverypb.ShipmentStatus_value {
+		status := deliverypb.ShipmentStatus(s)
+		if canTransitionShipment(status, to) {
+			from = append(from, status.String())
+		}
+	}
+	return from
+}
+
+// SetEventPublisher sets the publisher shipment status changes are announced
+// on (optional)
+func (s *DeliveryService) SetEventPublisher(publisher *ShipmentEventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// publishShipmentStatusChanged announces a shipment that moved from one
+// status to its current one. Statuses other services don't follow are not
+// published, and a failure to publish is logged.
+func (s *DeliveryService) publishShipmentStatusChanged(ctx context.Context, shipment db.Shipment, from string) {
+	if s.eventPublisher == nil {
+		return
+	}
+	eventType, ok := shipmentEventTypes[parseShipmentStatus(shipment.Status)]
+	if !ok {
+		return
+	}
+
+	data := map[string]interface{}{
+		"previous_status": from,
+		"carrier":         shipment.Carrier,
+		"tracking_number": toStringPtr(shipment.TrackingNumber),
+	}
+	if shipment.PickupPointID != nil {
+		data["pickup_point_id"] = shipment.PickupPointID.String()
+	}
+	if shipment.PickupDeadlineAt != nil {
+		data["pickup_deadline_at"] = shipment.PickupDeadlineAt.UTC().Format(time.RFC3339)
+	}
+
+	if err := s.eventPublisher.Publish(ctx, ShipmentEvent{
+		EventType:  eventType,
+		ShipmentID: shipment.ID.String(),
+		OrderID:    shipment.OrderID.String(),
+		Status:     shipment.Status,
+		Timestamp:  time.Now(),
+		Data:       data,
+	}); err != nil {
+		s.logger.Warn("Failed to publish shipment event",
+			zap.String("shipment_id", shipment.ID.String()),
+			zap.String("event_type", eventType),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/sarama/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// expectShipmentEvent has the producer expect one event and decodes it into
+// event when it is sent
+func expectShipmentEvent(producer *mocks.SyncProducer, event *ShipmentEvent) {
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+		return json.Unmarshal(value, event)
+	})
+}
+
+func newEventTestService(t *testing.T, mockQueries *MockQuerier) (*DeliveryService, *mocks.SyncProducer) {
+	producer := mocks.NewSyncProducer(t, nil)
+	t.Cleanup(func() { _ = producer.Close() })
+	service := NewDeliveryService(mockQueries, zap.NewNop())
+	service.SetEventPublisher(&ShipmentEventPublisher{producer: producer, topic: "shipment-events", logger: zap.NewNop()})
+	return service, producer
+}
+
+func TestCanTransitionShipment(t *testing.T) {
+	tests := []struct {
+		from, to deliverypb.ShipmentStatus
+		want     bool
+	}{
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_PREPARING, deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED, true},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_PREPARING, deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED, false},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED, deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED, true},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT, deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED, false},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_FAILED_DELIVERY, deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT, true},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED, deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED, false},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED, deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED, false},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_UNSPECIFIED, deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED, false},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT, deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP, true},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP, deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP, true},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP, deliverypb.ShipmentStatus_SHIPMENT_STATUS_RETURNED, true},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_READY_FOR_PICKUP, deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED, false},
+		{deliverypb.ShipmentStatus_SHIPMENT_STATUS_PICKED_UP, deliverypb.ShipmentStatus_SHIPMENT_STATUS_RETURNED, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, canTransitionShipment(tt.from, tt.to), "%s to %s", tt.from, tt.to)
+	}
+
+	assert.ElementsMatch(t, []string{"SHIPMENT_STATUS_SHIPPED", "SHIPMENT_STATUS_FAILED_DELIVERY"},
+		shipmentStatusesBefore(deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT))
+}
+
+func TestDeliveryService_ShipmentEvents(t *testing.T) {
+	t.Run("publishes the new status", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, producer := newEventTestService(t, mockQueries)
+		trackingNumber := "400000000013"
+		shipment := db.Shipment{ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_SHIPPED", Carrier: CarrierYamato, TrackingNumber: &trackingNumber}
+		updated := shipment
+		updated.Status = "SHIPMENT_STATUS_IN_TRANSIT"
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipment.ID, "SHIPMENT_STATUS_SHIPPED", "SHIPMENT_STATUS_IN_TRANSIT", "").Return(updated, nil)
+		var event ShipmentEvent
+		expectShipmentEvent(producer, &event)
+
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId: shipment.ID.String(),
+			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_IN_TRANSIT,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ShipmentEventInTransit, event.EventType)
+		assert.Equal(t, shipment.OrderID.String(), event.OrderID)
+		assert.Equal(t, shipment.ID.String(), event.ShipmentID)
+		assert.Equal(t, "SHIPMENT_STATUS_SHIPPED", event.Data["previous_status"])
+		assert.Equal(t, trackingNumber, event.Data["tracking_number"])
+	})
+
+	t.Run("does not publish cancellations", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newEventTestService(t, mockQueries)
+		shipmentID := uuid.New()
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+		mockQueries.On("TransitionShipmentStatus", mock.Anything, shipmentID, "SHIPMENT_STATUS_PREPARING", "SHIPMENT_STATUS_CANCELLED", "").
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_CANCELLED"}, nil)
+
+		// The mock producer fails the test on an unexpected message
+		_, err := service.UpdateShipmentStatus(context.Background(), &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId: shipmentID.String(),
+			Status:     deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED,
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("publishes a cash on delivery shipment's delivery once", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, producer := newEventTestService(t, mockQueries)
+		service.SetPaymentClient(&fakePaymentClient{})
+		amount, currency := int64(5330), "JPY"
+		shipment := db.Shipment{ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_IN_TRANSIT", CODAmountMinor: &amount, CODCurrency: &currency}
+		delivered := shipment
+		delivered.Status = "SHIPMENT_STATUS_DELIVERED"
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil).Once()
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(delivered, nil).Once()
+		mockQueries.On("MarkCODShipmentDelivered", mock.Anything, shipment.ID, amount, mock.MatchedBy(func(from []string) bool {
+			return assert.Contains(t, from, "SHIPMENT_STATUS_DELIVERED") && assert.Contains(t, from, "SHIPMENT_STATUS_IN_TRANSIT")
+		}), "").Return(nil)
+		var event ShipmentEvent
+		expectShipmentEvent(producer, &event)
+
+		req := &deliverypb.UpdateShipmentStatusRequest{
+			ShipmentId:      shipment.ID.String(),
+			Status:          deliverypb.ShipmentStatus_SHIPMENT_STATUS_DELIVERED,
+			CollectedAmount: &sharedpb.Money{Units: amount, Currency: currency},
+		}
+		_, err := service.UpdateShipmentStatus(context.Background(), req)
+		require.NoError(t, err)
+		// Delivering again retries the payment without another event
+		_, err = service.UpdateShipmentStatus(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, ShipmentEventDelivered, event.EventType)
+		assert.Equal(t, "SHIPMENT_STATUS_DELIVERED", event.Status)
+	})
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/pkg/barcode"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/pkg/pdf"
+)
+
+// Labels are printed on A6 sheets, the size carriers' thermal label printers
+// take
+const (
+	labelWidth  = 105.0
+	labelHeight = 148.0
+	// labelMargin is the space left on each side of the label
+	labelMargin = 4.0
+)
+
+// labelLayout is how a carrier's 送り状 names its service and encodes its
+// tracking barcode
+type labelLayout struct {
+	carrierName string
+	// Product printed in the header, e.g. 宅急便
+	product string
+	// Marks printed for the service levels beyond standard delivery
+	marks map[deliverypb.ServiceLevel]string
+	// Codabar start and stop characters the carrier's scanners expect
+	barcodeStart, barcodeStop byte
+}
+
+var labelLayouts = map[string]labelLayout{
+	CarrierYamato: {
+		carrierName: "ヤマト運輸",
+		product:     "宅急便",
+		marks: map[deliverypb.ServiceLevel]string{
+			deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS:      "タイムサービス",
+			deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED: "クール冷蔵",
+			deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN:  "クール冷凍",
+		},
+		barcodeStart: 'A',
+		barcodeStop:  'A',
+	},
+	CarrierSagawa: {
+		carrierName: "佐川急便",
+		product:     "飛脚宅配便",
+		marks: map[deliverypb.ServiceLevel]string{
+			deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS:      "飛脚即日配達便",
+			deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED: "飛脚クール便 冷蔵",
+			deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN:  "飛脚クール便 冷凍",
+		},
+		barcodeStart: 'D',
+		barcodeStop:  'D',
+	},
+	CarrierJapanPost: {
+		carrierName: "日本郵便",
+		product:     "ゆうパック",
+		marks: map[deliverypb.ServiceLevel]string{
+			deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS:      "速達",
+			deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED: "チルド",
+		},
+		barcodeStart: 'C',
+		barcodeStop:  'C',
+	},
+}
+
+// timeWindowLabels is how each time window is printed on a label
+var timeWindowLabels = map[deliverypb.DeliveryTimeWindow]string{
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_MORNING: "午前中",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_14_16:   "14時～16時",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_16_18:   "16時～18時",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20:   "18時～20時",
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_19_21:   "19時～21時",
+}
+
+var japaneseWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// shippingLabel is what is printed on one shipment's label
+type shippingLabel struct {
+	layout         labelLayout
+	trackingNumber string
+	serviceLevel   deliverypb.ServiceLevel
+	sender         CarrierAddress
+	recipient      db.Address
+	shipDate       time.Time
+	deliveryDate   *time.Time
+	timeWindow     deliverypb.DeliveryTimeWindow
+	codAmountMinor *int64
+	orderID        string
+}
+
+// GetShippingLabel renders a dispatched shipment's label as a PDF
+func (s *DeliveryService) GetShippingLabel(ctx context.Context, req *deliverypb.GetShippingLabelRequest) (*deliverypb.GetShippingLabelResponse, error) {
+	s.logger.Debug("Getting shipping label", zap.String("shipment_id", req.ShipmentId))
+
+	shipment, err := s.findShipment(ctx, req.ShipmentId, "")
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Status == deliverypb.ShipmentStatus_SHIPMENT_STATUS_CANCELLED.String() {
+		return nil, status.Error(codes.FailedPrecondition, "shipment has been cancelled")
+	}
+	if shipment.TrackingNumber == nil || shipment.Recipient == nil {
+		return nil, status.Error(codes.FailedPrecondition, "shipment has not been dispatched to a carrier")
+	}
+
+	label, err := s.shippingLabel(ctx, shipment)
+	if err != nil {
+		return nil, err
+	}
+	doc := pdf.New()
+	if err := renderShippingLabel(doc, label); err != nil {
+		if errors.Is(err, barcode.ErrInvalidCodabar) {
+			return nil, status.Error(codes.FailedPrecondition, "tracking number cannot be printed as a barcode")
+		}
+		return nil, fmt.Errorf("failed to render shipping label: %w", err)
+	}
+	out, err := doc.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write shipping label: %w", err)
+	}
+
+	return &deliverypb.GetShippingLabelResponse{
+		Pdf:      out,
+		Filename: fmt.Sprintf("label-%s-%s.pdf", shipment.Carrier, *shipment.TrackingNumber),
+	}, nil
+}
+
+// GetShippingLabels renders the labels of every shipment dispatched on a day
+// in Japan time, one per page, grouped by carrier. Shipments whose labels
+// cannot be printed are logged and left out.
+func (s *DeliveryService) GetShippingLabels(ctx context.Context, req *deliverypb.GetShippingLabelsRequest) (*deliverypb.GetShippingLabelsResponse, error) {
+	s.logger.Info("Getting shipping labels", zap.String("carrier", req.Carrier))
+
+	carrier := strings.TrimSpace(req.Carrier)
+	if _, ok := labelLayouts[carrier]; carrier != "" && !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown carrier %q", req.Carrier)
+	}
+	date := time.Now()
+	if req.Date != nil {
+		date = req.Date.AsTime()
+	}
+	date = date.In(tokyo)
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, tokyo)
+
+	shipments, err := s.queries.ListDispatchedShipments(ctx, from, from.AddDate(0, 0, 1), carrier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispatched shipments: %w", err)
+	}
+
+	doc := pdf.New()
+	for _, shipment := range shipments {
+		if shipment.TrackingNumber == nil || shipment.Recipient == nil {
+			continue
+		}
+		label, err := s.shippingLabel(ctx, shipment)
+		if err != nil {
+			return nil, err
+		}
+		if err := renderShippingLabel(doc, label); err != nil {
+			s.logger.Warn("Skipping shipment whose label cannot be rendered",
+				zap.String("shipment_id", shipment.ID.String()),
+				zap.Error(err))
+		}
+	}
+	if doc.PageCount() == 0 {
+		return nil, status.Errorf(codes.NotFound, "no shipments were dispatched on %s", from.Format(time.DateOnly))
+	}
+
+	out, err := doc.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write shipping labels: %w", err)
+	}
+	filename := "labels-" + from.Format(time.DateOnly)
+	if carrier != "" {
+		filename += "-" + carrier
+	}
+
+	s.logger.Info("Shipping labels rendered",
+		zap.String("date", from.Format(time.DateOnly)),
+		zap.Int("labels", doc.PageCount()))
+
+	return &deliverypb.GetShippingLabelsResponse{
+		Pdf:        out,
+		Filename:   filename + ".pdf",
+		LabelCount: int32(doc.PageCount()),
+	}, nil
+}
+
+// shippingLabel gathers what a dispatched shipment's label shows. The
+// delivery date and time window come from the order's reserved slot, or else
+// the carrier's estimate.
+func (s *DeliveryService) shippingLabel(ctx context.Context, shipment db.Shipment) (shippingLabel, error) {
+	layout, ok := labelLayouts[shipment.Carrier]
+	if !ok {
+		layout = labelLayout{carrierName: shipment.Carrier, product: "宅配便", barcodeStart: 'A', barcodeStop: 'A'}
+	}
+
+	label := shippingLabel{
+		layout:         layout,
+		trackingNumber: toStringPtr(shipment.TrackingNumber),
+		serviceLevel:   deliverypb.ServiceLevel(deliverypb.ServiceLevel_value[shipment.ServiceLevel]),
+		sender:         s.shipper,
+		recipient:      *shipment.Recipient,
+		shipDate:       shipment.CreatedAt,
+		deliveryDate:   shipment.EstimatedDeliveryAt,
+		codAmountMinor: shipment.CODAmountMinor,
+		orderID:        shipment.OrderID.String(),
+	}
+	if shipment.DispatchedAt != nil {
+		label.shipDate = *shipment.DispatchedAt
+	}
+
+	slot, err := s.queries.GetReservedSlot(ctx, shipment.OrderID)
+	switch {
+	case err == nil:
+		label.deliveryDate = &slot.Date
+		if slot.TimeWindow != nil {
+			label.timeWindow = deliverypb.DeliveryTimeWindow(deliverypb.DeliveryTimeWindow_value[*slot.TimeWindow])
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return shippingLabel{}, fmt.Errorf("failed to get reserved slot: %w", err)
+	}
+	return label, nil
+}
+
+// renderShippingLabel adds a page with the label to the document
+func renderShippingLabel(doc *pdf.Document, label shippingLabel) error {
+	bars, err := barcode.Codabar(label.layout.barcodeStart, label.trackingNumber, label.layout.barcodeStop)
+	if err != nil {
+		return fmt.Errorf("tracking number %q: %w", label.trackingNumber, err)
+	}
+
+	page := doc.AddPage(labelWidth, labelHeight)
+	left, width := labelMargin, labelWidth-2*labelMargin
+	textLeft := left + 2
+
+	// Header: product, carrier and service mark
+	page.Text(textLeft, 13, pdf.Gothic, 16, label.layout.product)
+	page.Text(textLeft, 17.5, pdf.Gothic, 7, label.layout.carrierName)
+	if mark, ok := label.layout.marks[label.serviceLevel]; ok {
+		page.Rect(58, 5, left+width-58, 12, 0.8)
+		fitText(page, 60, 13, pdf.Gothic, 12, left+width-62, mark)
+	}
+
+	// Delivery date and time window
+	page.Rect(left, 20, width, 14, 0.3)
+	page.Line(53, 20, 53, 34, 0.3)
+	page.Text(textLeft, 24, pdf.Gothic, 7, "お届け予定日")
+	page.Text(textLeft, 31, pdf.Gothic, 11, labelDate(label.deliveryDate))
+	page.Text(55, 24, pdf.Gothic, 7, "時間帯指定")
+	window, ok := timeWindowLabels[label.timeWindow]
+	if !ok {
+		window = "指定なし"
+	}
+	page.Text(55, 31, pdf.Gothic, 11, window)
+
+	// Recipient
+	r := label.recipient
+	page.Rect(left, 36, width, 40, 0.6)
+	page.Text(textLeft, 40, pdf.Gothic, 7, "お届け先")
+	page.Text(textLeft, 46, pdf.Gothic, 10, "〒"+formatPostalCode(r.PostalCode))
+	fitText(page, textLeft, 52, pdf.Gothic, 10, width-4, r.Prefecture+r.City+r.AddressLine1)
+	fitText(page, textLeft, 57, pdf.Gothic, 10, width-4, r.AddressLine2)
+	fitText(page, textLeft, 66, pdf.Gothic, 14, width-4, r.Name+" 様")
+	page.Text(textLeft, 72, pdf.Gothic, 9, "TEL "+r.Phone)
+
+	// Sender
+	sender := label.sender
+	page.Rect(left, 78, width, 22, 0.3)
+	page.Text(textLeft, 82, pdf.Gothic, 7, "ご依頼主")
+	page.Text(textLeft, 86.5, pdf.Gothic, 8, "〒"+formatPostalCode(sender.PostalCode))
+	fitText(page, textLeft, 91, pdf.Gothic, 8, width-4,
+		strings.TrimSpace(sender.Prefecture+sender.City+sender.AddressLine1+" "+sender.AddressLine2))
+	fitText(page, textLeft, 97, pdf.Gothic, 9, width-4, strings.TrimSpace(sender.Name+"　TEL "+sender.Phone))
+
+	// Cash on delivery amount, or the ship date and order
+	if label.codAmountMinor != nil {
+		page.Rect(left, 102, width, 10, 1)
+		page.Text(textLeft, 108.5, pdf.Gothic, 10, "代金引換")
+		amount := formatYen(*label.codAmountMinor)
+		page.Text(left+width-2-pdf.TextWidth(pdf.Gothic, 13, amount), 109, pdf.Gothic, 13, amount)
+	} else {
+		page.Text(textLeft, 108, pdf.Gothic, 7, "発送日 "+labelDate(&label.shipDate))
+	}
+
+	// Tracking barcode, centred, with its human readable form
+	units := 0
+	for _, w := range bars {
+		units += w
+	}
+	narrow := min(0.33, (width-8)/float64(units))
+	x := left + (width-narrow*float64(units))/2
+	for i, w := range bars {
+		if i%2 == 0 {
+			page.FillRect(x, 115, narrow*float64(w), 16)
+		}
+		x += narrow * float64(w)
+	}
+	symbol := string(label.layout.barcodeStart) + label.trackingNumber + string(label.layout.barcodeStop)
+	page.Text(left+(width-pdf.TextWidth(pdf.Helvetica, 9, symbol))/2, 135, pdf.Helvetica, 9, symbol)
+
+	inquiry := "お問い合せ番号 " + formatTrackingNumber(label.trackingNumber)
+	page.Text(left+(width-pdf.TextWidth(pdf.Gothic, 10, inquiry))/2, 141, pdf.Gothic, 10, inquiry)
+	page.Text(textLeft, 145.5, pdf.Gothic, 6, "注文番号 "+label.orderID)
+	return nil
+}
+
+// fitText writes text, shrinking it to fit within maxWidth
+func fitText(page *pdf.Page, x, y float64, font pdf.Font, size, maxWidth float64, text string) {
+	if text == "" {
+		return
+	}
+	if w := pdf.TextWidth(font, size, text); w > maxWidth {
+		size *= maxWidth / w
+	}
+	page.Text(x, y, font, size, text)
+}
+
+// labelDate prints a date as 2026年10月21日(水)
+func labelDate(t *time.Time) string {
+	if t == nil {
+		return "指定なし"
+	}
+	d := t.In(tokyo)
+	return fmt.Sprintf("%d年%d月%d日(%s)", d.Year(), d.Month(), d.Day(), japaneseWeekdays[d.Weekday()])
+}
+
+// formatPostalCode prints a 7-digit postal code as 154-0001
+func formatPostalCode(code string) string {
+	if len(code) == 7 && isDigits(code) {
+		return code[:3] + "-" + code[3:]
+	}
+	return code
+}
+
+// formatTrackingNumber groups a 12-digit tracking number as 4000-0000-0013
+func formatTrackingNumber(n string) string {
+	if len(n) == 12 && isDigits(n) {
+		return n[:4] + "-" + n[4:8] + "-" + n[8:]
+	}
+	return n
+}
+
+// formatYen prints an amount as ¥5,330
+func formatYen(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return sign + "¥" + b.String()
+}
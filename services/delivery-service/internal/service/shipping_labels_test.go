@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// labelContent returns the inflated page content of a PDF
+func labelContent(t *testing.T, out []byte) string {
+	t.Helper()
+	var content strings.Builder
+	for {
+		start := bytes.Index(out, []byte("stream\n"))
+		if start < 0 {
+			return content.String()
+		}
+		out = out[start+len("stream\n"):]
+		end := bytes.Index(out, []byte("\nendstream"))
+		require.GreaterOrEqual(t, end, 0)
+		zr, err := zlib.NewReader(bytes.NewReader(out[:end]))
+		require.NoError(t, err)
+		page, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		content.Write(page)
+		out = out[end+len("\nendstream"):]
+	}
+}
+
+// cidText is how text in a Japanese font appears in page content
+func cidText(text string) string {
+	var b strings.Builder
+	for _, u := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	return b.String()
+}
+
+func testLabelShipment(carrier, trackingNumber, level string) db.Shipment {
+	dispatchedAt := time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)
+	return db.Shipment{
+		ID:             uuid.New(),
+		OrderID:        uuid.New(),
+		TrackingNumber: &trackingNumber,
+		Status:         "SHIPMENT_STATUS_PREPARING",
+		Carrier:        carrier,
+		ServiceLevel:   level,
+		Recipient: &db.Address{
+			Name:         "山田 太郎",
+			Phone:        "090-0000-0000",
+			PostalCode:   "1540001",
+			Prefecture:   "東京都",
+			City:         "世田谷区",
+			AddressLine1: "池尻1-1-1",
+		},
+		DispatchedAt: &dispatchedAt,
+	}
+}
+
+func TestDeliveryService_GetShippingLabel(t *testing.T) {
+	t.Run("renders the carrier's layout", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		shipment := testLabelShipment(CarrierYamato, "400000000013", "SERVICE_LEVEL_COOL_FROZEN")
+		cod := int64(5330)
+		shipment.CODAmountMinor = &cod
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		window := "DELIVERY_TIME_WINDOW_14_16"
+		mockQueries.On("GetReservedSlot", mock.Anything, shipment.OrderID).
+			Return(db.DeliverySlot{Date: time.Date(2026, 10, 21, 0, 0, 0, 0, tokyo), TimeWindow: &window}, nil)
+
+		resp, err := service.GetShippingLabel(context.Background(), &deliverypb.GetShippingLabelRequest{ShipmentId: shipment.ID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, "label-yamato-400000000013.pdf", resp.Filename)
+		assert.True(t, bytes.HasPrefix(resp.Pdf, []byte("%PDF-")))
+		assert.Contains(t, string(resp.Pdf), "/Count 1")
+
+		content := labelContent(t, resp.Pdf)
+		for _, text := range []string{"宅急便", "クール冷凍", "2026年10月21日(水)", "14時～16時", "〒154-0001", "山田 太郎 様", "代金引換", "¥5,330", "お問い合せ番号 4000-0000-0013"} {
+			assert.Contains(t, content, cidText(text), text)
+		}
+		assert.Contains(t, content, "(A400000000013A)")
+	})
+
+	t.Run("uses the estimate without a reserved slot", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		shipment := testLabelShipment(CarrierSagawa, "360000000016", "SERVICE_LEVEL_STANDARD")
+		estimate := time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC)
+		shipment.EstimatedDeliveryAt = &estimate
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		mockQueries.On("GetReservedSlot", mock.Anything, shipment.OrderID).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+
+		resp, err := service.GetShippingLabel(context.Background(), &deliverypb.GetShippingLabelRequest{ShipmentId: shipment.ID.String()})
+
+		require.NoError(t, err)
+		content := labelContent(t, resp.Pdf)
+		assert.Contains(t, content, cidText("飛脚宅配便"))
+		assert.Contains(t, content, cidText("2026年10月20日(火)"))
+		assert.Contains(t, content, cidText("指定なし"))
+		assert.Contains(t, content, "(D360000000016D)")
+	})
+
+	t.Run("rejects an undispatched shipment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		shipmentID := uuid.New()
+		mockQueries.On("GetShipment", mock.Anything, shipmentID).
+			Return(db.Shipment{ID: shipmentID, Status: "SHIPMENT_STATUS_PREPARING"}, nil)
+
+		_, err := service.GetShippingLabel(context.Background(), &deliverypb.GetShippingLabelRequest{ShipmentId: shipmentID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("rejects a tracking number codabar cannot encode", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service, _ := newDispatchTestService(t, mockQueries)
+		shipment := testLabelShipment(CarrierJapanPost, "EJ123456785JP", "SERVICE_LEVEL_STANDARD")
+		mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+		mockQueries.On("GetReservedSlot", mock.Anything, shipment.OrderID).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+
+		_, err := service.GetShippingLabel(context.Background(), &deliverypb.GetShippingLabelRequest{ShipmentId: shipment.ID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeliveryService_GetShippingLabels(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, tokyo)
+
+	t.Run("renders a page per shipment dispatched that day", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		shipments := []db.Shipment{
+			testLabelShipment(CarrierJapanPost, "110000000014", "SERVICE_LEVEL_COOL_CHILLED"),
+			testLabelShipment(CarrierYamato, "400000000013", "SERVICE_LEVEL_STANDARD"),
+			testLabelShipment(CarrierJapanPost, "EJ123456785JP", "SERVICE_LEVEL_STANDARD"),
+		}
+		mockQueries.On("ListDispatchedShipments", mock.Anything, mock.MatchedBy(func(from time.Time) bool {
+			return from.Equal(day)
+		}), mock.MatchedBy(func(to time.Time) bool {
+			return to.Equal(day.AddDate(0, 0, 1))
+		}), "").Return(shipments, nil)
+		mockQueries.On("GetReservedSlot", mock.Anything, mock.Anything).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+
+		resp, err := service.GetShippingLabels(context.Background(), &deliverypb.GetShippingLabelsRequest{
+			Date: timestamppb.New(day.Add(15 * time.Hour)),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), resp.LabelCount)
+		assert.Equal(t, "labels-2026-10-18.pdf", resp.Filename)
+		assert.Contains(t, string(resp.Pdf), "/Count 2")
+		content := labelContent(t, resp.Pdf)
+		assert.Contains(t, content, cidText("チルド"))
+		assert.Contains(t, content, cidText("宅急便"))
+	})
+
+	t.Run("returns not found when nothing was dispatched", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+		mockQueries.On("ListDispatchedShipments", mock.Anything, mock.Anything, mock.Anything, CarrierSagawa).Return([]db.Shipment{}, nil)
+
+		_, err := service.GetShippingLabels(context.Background(), &deliverypb.GetShippingLabelsRequest{
+			Date:    timestamppb.New(day),
+			Carrier: CarrierSagawa,
+		})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("rejects an unknown carrier", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.GetShippingLabels(context.Background(), &deliverypb.GetShippingLabelsRequest{Carrier: "fedex"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestFormatYen(t *testing.T) {
+	assert.Equal(t, "¥0", formatYen(0))
+	assert.Equal(t, "¥980", formatYen(980))
+	assert.Equal(t, "¥5,330", formatYen(5330))
+	assert.Equal(t, "¥1,234,567", formatYen(1234567))
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/pkg/calendar"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// ShippingFees are the charges added to parcels' base rates, in yen with
+// tax included
+type ShippingFees struct {
+	// Orders of at least this much ship without base rates; 0 disables
+	// free shipping
+	FreeShippingThreshold int64
+	// Charged once per order delivered in a time window
+	TimeWindowFee int64
+	// Charged per parcel sent express
+	ExpressFee int64
+	// Charged per parcel to a 離島 zone
+	RemoteIslandFee int64
+}
+
+// defaultShippingFees apply until SetShippingFees is called
+var defaultShippingFees = ShippingFees{
+	FreeShippingThreshold: 5000,
+	TimeWindowFee:         110,
+	ExpressFee:            550,
+	RemoteIslandFee:       1100,
+}
+
+// sizeClass is a carrier size class: parcels whose three sides add up to at
+// most maxSidesCm and that weigh at most maxWeightGrams
+type sizeClass struct {
+	size           int32
+	maxSidesCm     int32
+	maxWeightGrams int32
+	// Rate within the origin's region, and how much each distance band
+	// adds
+	baseRate int64
+	bandRate int64
+	// クール便 surcharge; 0 where cool delivery is not offered
+	coolSurcharge int64
+}
+
+// sizeClasses lists the size classes from smallest, with the shop's rates
+// in yen, tax included
+var sizeClasses = []sizeClass{
+	{size: 60, maxSidesCm: 60, maxWeightGrams: 2000, baseRate: 940, bandRate: 120, coolSurcharge: 275},
+	{size: 80, maxSidesCm: 80, maxWeightGrams: 5000, baseRate: 1220, bandRate: 130, coolSurcharge: 330},
+	{size: 100, maxSidesCm: 100, maxWeightGrams: 10000, baseRate: 1530, bandRate: 140, coolSurcharge: 440},
+	{size: 120, maxSidesCm: 120, maxWeightGrams: 15000, baseRate: 1850, bandRate: 150, coolSurcharge: 715},
+	{size: 140, maxSidesCm: 140, maxWeightGrams: 20000, baseRate: 2170, bandRate: 160},
+	{size: 160, maxSidesCm: 160, maxWeightGrams: 25000, baseRate: 2500, bandRate: 170},
+}
+
+// shippingRegion is one of the carriers' 地域区分. Regions at the same
+// position are neighbours; the distance band between two regions is how far
+// apart their positions are.
+type shippingRegion struct {
+	name     string
+	position int
+}
+
+var (
+	regionHokkaido    = shippingRegion{"北海道", 0}
+	regionNorthTohoku = shippingRegion{"北東北", 1}
+	regionSouthTohoku = shippingRegion{"南東北", 2}
+	regionKanto       = shippingRegion{"関東", 3}
+	regionShinetsu    = shippingRegion{"信越", 3}
+	regionHokuriku    = shippingRegion{"北陸", 4}
+	regionChubu       = shippingRegion{"中部", 4}
+	regionKansai      = shippingRegion{"関西", 5}
+	regionChugoku     = shippingRegion{"中国", 6}
+	regionShikoku     = shippingRegion{"四国", 6}
+	regionKyushu      = shippingRegion{"九州", 7}
+	regionOkinawa     = shippingRegion{"沖縄", -1}
+)
+
+const (
+	// maxDistanceBand caps the band between regions on the main islands
+	maxDistanceBand = 5
+	// okinawaDistanceBand is the band between Okinawa and anywhere else
+	okinawaDistanceBand = 6
+)
+
+// prefectureRegions holds each prefecture's region, in JIS code order
+var prefectureRegions = []shippingRegion{
+	regionHokkaido,
+	regionNorthTohoku, regionNorthTohoku, regionSouthTohoku, regionNorthTohoku, regionSouthTohoku, regionSouthTohoku,
+	regionKanto, regionKanto, regionKanto, regionKanto, regionKanto, regionKanto, regionKanto,
+	regionShinetsu, regionHokuriku, regionHokuriku, regionHokuriku, regionKanto, regionShinetsu,
+	regionChubu, regionChubu, regionChubu, regionChubu,
+	regionKansai, regionKansai, regionKansai, regionKansai, regionKansai, regionKansai,
+	regionChugoku, regionChugoku, regionChugoku, regionChugoku, regionChugoku,
+	regionShikoku, regionShikoku, regionShikoku, regionShikoku,
+	regionKyushu, regionKyushu, regionKyushu, regionKyushu, regionKyushu, regionKyushu, regionKyushu,
+	regionOkinawa,
+}
+
+// SetShippingFees sets the surcharges and free shipping threshold of
+// shipping quotes
+func (s *DeliveryService) SetShippingFees(fees ShippingFees) {
+	s.shippingFees = &fees
+}
+
+func (s *DeliveryService) fees() ShippingFees {
+	if s.shippingFees == nil {
+		return defaultShippingFees
+	}
+	return *s.shippingFees
+}
+
+// QuoteShipping prices delivering parcels to an address. Each parcel is
+// charged its size class's rate for the distance from the origin's region to
+// the destination's, plus the クール便 surcharge for cool delivery and the
+// express and 離島 fees. Orders in a time window pay the time window fee once.
+// Orders reaching the free shipping threshold have the base rates waived;
+// surcharges and fees are still charged.
+func (s *DeliveryService) QuoteShipping(ctx context.Context, req *deliverypb.QuoteShippingRequest) (*deliverypb.QuoteShippingResponse, error) {
+	s.logger.Debug("Quoting shipping",
+		zap.String("prefecture", req.Prefecture),
+		zap.Int("parcels", len(req.Parcels)),
+		zap.String("service_level", req.ServiceLevel.String()))
+
+	level := req.ServiceLevel
+	if level == deliverypb.ServiceLevel_SERVICE_LEVEL_UNSPECIFIED {
+		level = deliverypb.ServiceLevel_SERVICE_LEVEL_STANDARD
+	}
+	if _, ok := deliverypb.ServiceLevel_name[int32(level)]; !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid service_level")
+	}
+	cool := level == deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED || level == deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN
+
+	origin := req.OriginPrefecture
+	if origin == "" {
+		origin = s.shipper.Prefecture
+	}
+	if origin == "" {
+		return nil, status.Error(codes.InvalidArgument, "origin_prefecture is required")
+	}
+	origin, ok := normalizePrefecture(origin)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid origin_prefecture")
+	}
+
+	// Without a postal code the prefecture alone is priced and no zone is
+	// checked, so there is no delivery estimate either
+	destination, remoteIsland, deliveryDays := "", false, -1
+	if strings.TrimSpace(req.PostalCode) == "" {
+		if destination, ok = normalizePrefecture(req.Prefecture); !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid prefecture")
+		}
+	} else {
+		zone, err := s.resolveDeliveryZone(ctx, req.PostalCode, req.Prefecture)
+		if err != nil {
+			return nil, err
+		}
+		if zone.Zone != nil && !zone.Deliverable {
+			return nil, status.Error(codes.FailedPrecondition, "address cannot be delivered to")
+		}
+		destination, remoteIsland = zone.Prefecture, zone.RemoteIsland
+		if zone.Zone != nil {
+			deliveryDays = int(zone.Zone.DeliveryDays)
+		}
+	}
+
+	var estimatedDeliveryAt *time.Time
+	timeWindow := req.TimeWindow
+	if req.DeliverySlotId != "" {
+		slot, err := s.quotedSlot(ctx, req.DeliverySlotId)
+		if err != nil {
+			return nil, err
+		}
+		if slot.TimeWindow == nil {
+			timeWindow = deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED
+		} else {
+			timeWindow = deliverypb.DeliveryTimeWindow(deliverypb.DeliveryTimeWindow_value[*slot.TimeWindow])
+		}
+		date := calendar.Date(slot.Date)
+		estimatedDeliveryAt = &date
+	} else if deliveryDays >= 0 {
+		date, err := s.estimateDelivery(ctx, time.Now(), deliveryDays)
+		if err != nil {
+			return nil, err
+		}
+		estimatedDeliveryAt = &date
+	}
+
+	parcels := req.Parcels
+	if len(parcels) == 0 {
+		parcels = []*deliverypb.Parcel{{SizeClass: sizeClasses[0].size}}
+	}
+
+	fees := s.fees()
+	band := distanceBand(origin, destination)
+	quote := &deliverypb.ShippingQuote{
+		OriginPrefecture:      origin,
+		DestinationPrefecture: destination,
+	}
+	var baseTotal, coolTotal, expressTotal, remoteTotal, timeWindowFee int64
+	for i, p := range parcels {
+		class, err := parcelSizeClass(p)
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "parcel %d: %s", i+1, status.Convert(err).Message())
+		}
+		base := class.baseRate + class.bandRate*int64(band)
+		var coolSurcharge int64
+		if cool {
+			if class.coolSurcharge == 0 {
+				return nil, status.Errorf(codes.FailedPrecondition, "parcel %d: cool delivery is limited to size %d", i+1, largestCoolSize())
+			}
+			coolSurcharge = class.coolSurcharge
+		}
+		quote.Parcels = append(quote.Parcels, &deliverypb.ParcelRate{
+			SizeClass:     class.size,
+			BaseAmount:    yen(base),
+			CoolSurcharge: yen(coolSurcharge),
+		})
+		baseTotal += base
+		coolTotal += coolSurcharge
+		if level == deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS {
+			expressTotal += fees.ExpressFee
+		}
+		if remoteIsland {
+			remoteTotal += fees.RemoteIslandFee
+		}
+	}
+	if timeWindow != deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED {
+		timeWindowFee = fees.TimeWindowFee
+	}
+
+	var discount, remaining int64
+	if fees.FreeShippingThreshold > 0 {
+		subtotal := req.OrderSubtotal.GetUnits()
+		if subtotal >= fees.FreeShippingThreshold {
+			quote.FreeShipping = true
+			discount = baseTotal
+		} else {
+			remaining = fees.FreeShippingThreshold - subtotal
+		}
+	}
+
+	quote.BaseAmount = yen(baseTotal)
+	quote.CoolSurcharge = yen(coolTotal)
+	quote.ExpressSurcharge = yen(expressTotal)
+	quote.TimeWindowFee = yen(timeWindowFee)
+	quote.RemoteIslandFee = yen(remoteTotal)
+	quote.Discount = yen(discount)
+	quote.Total = yen(baseTotal + coolTotal + expressTotal + timeWindowFee + remoteTotal - discount)
+	quote.FreeShippingRemaining = yen(remaining)
+	if estimatedDeliveryAt != nil {
+		quote.EstimatedDeliveryAt = timestamppb.New(*estimatedDeliveryAt)
+	}
+
+	return &deliverypb.QuoteShippingResponse{Quote: quote}, nil
+}
+
+// quotedSlot returns the delivery slot an order is quoted for
+func (s *DeliveryService) quotedSlot(ctx context.Context, slotID string) (db.DeliverySlot, error) {
+	id, err := uuid.Parse(slotID)
+	if err != nil {
+		return db.DeliverySlot{}, status.Error(codes.InvalidArgument, "invalid delivery_slot_id: must be a valid UUID")
+	}
+	slot, err := s.queries.GetDeliverySlot(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.DeliverySlot{}, status.Error(codes.NotFound, "delivery slot not found")
+		}
+		return db.DeliverySlot{}, fmt.Errorf("failed to get delivery slot: %w", err)
+	}
+	return slot, nil
+}
+
+// parcelSizeClass returns the smallest size class that fits the parcel's
+// dimensions and weight and is at least its given size class
+func parcelSizeClass(p *deliverypb.Parcel) (sizeClass, error) {
+	if p.LengthCm < 0 || p.WidthCm < 0 || p.HeightCm < 0 || p.WeightGrams < 0 {
+		return sizeClass{}, status.Error(codes.InvalidArgument, "dimensions and weight must not be negative")
+	}
+	if p.SizeClass != 0 && !containsSizeClass(p.SizeClass) {
+		return sizeClass{}, status.Errorf(codes.InvalidArgument, "invalid size_class %d", p.SizeClass)
+	}
+
+	sides := p.LengthCm + p.WidthCm + p.HeightCm
+	for _, class := range sizeClasses {
+		if class.size >= p.SizeClass && sides <= class.maxSidesCm && p.WeightGrams <= class.maxWeightGrams {
+			return class, nil
+		}
+	}
+	largest := sizeClasses[len(sizeClasses)-1]
+	return sizeClass{}, status.Errorf(codes.FailedPrecondition, "exceeds size %d (%dcm, %dkg)",
+		largest.size, largest.maxSidesCm, largest.maxWeightGrams/1000)
+}
+
+func containsSizeClass(size int32) bool {
+	for _, class := range sizeClasses {
+		if class.size == size {
+			return true
+		}
+	}
+	return false
+}
+
+// largestCoolSize returns the largest size class offered cool delivery
+func largestCoolSize() int32 {
+	size := int32(0)
+	for _, class := range sizeClasses {
+		if class.coolSurcharge > 0 {
+			size = class.size
+		}
+	}
+	return size
+}
+
+// distanceBand returns the distance band between two prefectures: 0 within
+// a region, 1 between neighbouring regions and up to maxDistanceBand further
+// apart. Okinawa is its own band to and from anywhere else.
+func distanceBand(origin, destination string) int {
+	from, to := prefectureRegion(origin), prefectureRegion(destination)
+	switch {
+	case from == to:
+		return 0
+	case from == regionOkinawa || to == regionOkinawa:
+		return okinawaDistanceBand
+	}
+	band := from.position - to.position
+	if band < 0 {
+		band = -band
+	}
+	return min(max(band, 1), maxDistanceBand)
+}
+
+// prefectureRegion returns the region of a prefecture given by its full
+// name
+func prefectureRegion(prefecture string) shippingRegion {
+	for i, p := range prefectures {
+		if p.name == prefecture {
+			return prefectureRegions[i]
+		}
+	}
+	return shippingRegion{}
+}
+
+func yen(amount int64) *sharedpb.Money {
+	return &sharedpb.Money{Units: amount, Currency: "JPY"}
+}
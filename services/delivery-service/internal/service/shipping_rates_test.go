@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func newQuoteTestService(mockQueries *MockQuerier) *DeliveryService {
+	service := NewDeliveryService(mockQueries, zap.NewNop())
+	service.SetCarriers(nil, CarrierAddress{Prefecture: "東京都"})
+	return service
+}
+
+func TestDeliveryService_QuoteShipping(t *testing.T) {
+	t.Run("prices parcels by size class and distance", func(t *testing.T) {
+		service := newQuoteTestService(new(MockQuerier))
+
+		resp, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			Prefecture: "大阪",
+			Parcels: []*deliverypb.Parcel{
+				{SizeClass: 60},
+				{LengthCm: 40, WidthCm: 30, HeightCm: 25, WeightGrams: 3000},
+			},
+			OrderSubtotal: &sharedpb.Money{Units: 3000, Currency: "JPY"},
+		})
+
+		require.NoError(t, err)
+		quote := resp.Quote
+		assert.Equal(t, "東京都", quote.OriginPrefecture)
+		assert.Equal(t, "大阪府", quote.DestinationPrefecture)
+		require.Len(t, quote.Parcels, 2)
+		// 関東 to 関西 is two bands
+		assert.Equal(t, int32(60), quote.Parcels[0].SizeClass)
+		assert.Equal(t, int64(940+2*120), quote.Parcels[0].BaseAmount.Units)
+		assert.Equal(t, int32(100), quote.Parcels[1].SizeClass)
+		assert.Equal(t, int64(1530+2*140), quote.Parcels[1].BaseAmount.Units)
+		assert.Equal(t, int64(1180+1810), quote.Total.Units)
+		assert.False(t, quote.FreeShipping)
+		assert.Equal(t, int64(2000), quote.FreeShippingRemaining.Units)
+	})
+
+	t.Run("waives base rates over the threshold but keeps surcharges", func(t *testing.T) {
+		service := newQuoteTestService(new(MockQuerier))
+
+		resp, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			Prefecture:    "東京都",
+			Parcels:       []*deliverypb.Parcel{{SizeClass: 80}},
+			ServiceLevel:  deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_FROZEN,
+			TimeWindow:    deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_MORNING,
+			OrderSubtotal: &sharedpb.Money{Units: 5000, Currency: "JPY"},
+		})
+
+		require.NoError(t, err)
+		quote := resp.Quote
+		assert.True(t, quote.FreeShipping)
+		assert.Equal(t, int64(1220), quote.BaseAmount.Units)
+		assert.Equal(t, int64(1220), quote.Discount.Units)
+		assert.Equal(t, int64(330), quote.CoolSurcharge.Units)
+		assert.Equal(t, int64(110), quote.TimeWindowFee.Units)
+		assert.Equal(t, int64(440), quote.Total.Units)
+		assert.Zero(t, quote.FreeShippingRemaining.Units)
+	})
+
+	t.Run("charges remote islands and takes the slot's time window", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := newQuoteTestService(mockQueries)
+		service.SetShippingFees(ShippingFees{TimeWindowFee: 220, ExpressFee: 500, RemoteIslandFee: 1000})
+		zones := []db.DeliveryZone{
+			{ID: uuid.New(), Name: "Izu Islands", PostalCodes: []string{"100-0100~100-1799"}, Prefectures: []string{"東京都"}, RemoteIsland: true},
+		}
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+		slotID := uuid.New()
+		window := "DELIVERY_TIME_WINDOW_18_20"
+		mockQueries.On("GetDeliverySlot", mock.Anything, slotID).Return(db.DeliverySlot{ID: slotID, TimeWindow: &window}, nil)
+
+		resp, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			PostalCode:     "100-0211",
+			Prefecture:     "東京",
+			ServiceLevel:   deliverypb.ServiceLevel_SERVICE_LEVEL_EXPRESS,
+			DeliverySlotId: slotID.String(),
+		})
+
+		require.NoError(t, err)
+		quote := resp.Quote
+		assert.Equal(t, int64(940), quote.BaseAmount.Units)
+		assert.Equal(t, int64(500), quote.ExpressSurcharge.Units)
+		assert.Equal(t, int64(1000), quote.RemoteIslandFee.Units)
+		assert.Equal(t, int64(220), quote.TimeWindowFee.Units)
+		assert.Equal(t, int64(2660), quote.Total.Units)
+		// Without a threshold nothing is ever free
+		assert.False(t, quote.FreeShipping)
+		assert.Zero(t, quote.FreeShippingRemaining.Units)
+	})
+
+	t.Run("rejects cool delivery of large parcels", func(t *testing.T) {
+		service := newQuoteTestService(new(MockQuerier))
+
+		_, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			Prefecture:   "北海道",
+			Parcels:      []*deliverypb.Parcel{{SizeClass: 140}},
+			ServiceLevel: deliverypb.ServiceLevel_SERVICE_LEVEL_COOL_CHILLED,
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("rejects parcels over size 160", func(t *testing.T) {
+		service := newQuoteTestService(new(MockQuerier))
+
+		_, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			Prefecture: "沖縄",
+			Parcels:    []*deliverypb.Parcel{{LengthCm: 60, WidthCm: 60, HeightCm: 50}},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("rejects undeliverable addresses", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := newQuoteTestService(mockQueries)
+		zones := []db.DeliveryZone{
+			{ID: uuid.New(), Name: "Ogasawara", PostalCodes: []string{"100-2100~100-2199"}, Prefectures: []string{"東京都"}, Undeliverable: true},
+		}
+		mockQueries.On("ListDeliveryZonesForPrefecture", mock.Anything, "東京都").Return(zones, nil)
+
+		_, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			PostalCode: "1002101",
+			Prefecture: "東京都",
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("returns not found for an unknown slot", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := newQuoteTestService(mockQueries)
+		slotID := uuid.New()
+		mockQueries.On("GetDeliverySlot", mock.Anything, slotID).Return(db.DeliverySlot{}, pgx.ErrNoRows)
+
+		_, err := service.QuoteShipping(context.Background(), &deliverypb.QuoteShippingRequest{
+			Prefecture:     "東京都",
+			DeliverySlotId: slotID.String(),
+		})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		service := newQuoteTestService(new(MockQuerier))
+
+		tests := []*deliverypb.QuoteShippingRequest{
+			{Prefecture: "Atlantis"},
+			{Prefecture: "東京都", OriginPrefecture: "Atlantis"},
+			{Prefecture: "東京都", Parcels: []*deliverypb.Parcel{{SizeClass: 70}}},
+			{Prefecture: "東京都", Parcels: []*deliverypb.Parcel{{WeightGrams: -1}}},
+		}
+		for _, req := range tests {
+			_, err := service.QuoteShipping(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+		}
+	})
+}
+
+func TestParcelSizeClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		parcel *deliverypb.Parcel
+		want   int32
+	}{
+		{"empty", &deliverypb.Parcel{}, 60},
+		{"by sides", &deliverypb.Parcel{LengthCm: 30, WidthCm: 30, HeightCm: 20}, 80},
+		{"by weight", &deliverypb.Parcel{LengthCm: 20, WidthCm: 20, HeightCm: 10, WeightGrams: 12000}, 120},
+		{"given class", &deliverypb.Parcel{SizeClass: 140}, 140},
+		{"given class too small", &deliverypb.Parcel{SizeClass: 60, LengthCm: 50, WidthCm: 40, HeightCm: 30}, 120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, err := parcelSizeClass(tt.parcel)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, class.size)
+		})
+	}
+}
+
+func TestDistanceBand(t *testing.T) {
+	assert.Equal(t, 0, distanceBand("東京都", "神奈川県"))
+	assert.Equal(t, 1, distanceBand("東京都", "長野県"))
+	assert.Equal(t, 2, distanceBand("大阪府", "東京都"))
+	assert.Equal(t, 5, distanceBand("北海道", "福岡県"))
+	assert.Equal(t, 6, distanceBand("沖縄県", "鹿児島県"))
+	assert.Equal(t, 0, distanceBand("沖縄県", "沖縄県"))
+	assert.Len(t, prefectureRegions, len(prefectures))
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SlotScheduler keeps delivery slots generated from the zones' weekly
+// templates up to the scheduling horizon
+type SlotScheduler struct {
+	deliveries *DeliveryService
+	logger     *zap.Logger
+}
+
+// NewSlotScheduler creates a new slot scheduler
+func NewSlotScheduler(deliveries *DeliveryService, logger *zap.Logger) *SlotScheduler {
+	return &SlotScheduler{
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+// RollForward generates the slots that are missing between today and the
+// horizon. Existing slots, reserved or not, are left alone.
+func (w *SlotScheduler) RollForward(ctx context.Context) (int, error) {
+	created, err := w.deliveries.generateSlots(ctx, nil, time.Now(), w.deliveries.horizonDays())
+	if err != nil {
+		return 0, err
+	}
+
+	if created > 0 {
+		w.logger.Info("Generated delivery slots",
+			zap.Int("created", created),
+			zap.Int("horizon_days", w.deliveries.horizonDays()))
+	}
+
+	return created, nil
+}
+
+// StartPeriodicRollForward generates slots now and then in the background
+func (w *SlotScheduler) StartPeriodicRollForward(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic slot generation", zap.Duration("interval", interval))
+
+	if _, err := w.RollForward(ctx); err != nil {
+		w.logger.Error("Slot generation failed", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.RollForward(ctx); err != nil {
+					w.logger.Error("Periodic slot generation failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
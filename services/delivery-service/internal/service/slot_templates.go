@@ -0,0 +1,517 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// defaultSlotHorizonDays is how many days ahead slots are generated when
+// SetSlotHorizonDays is not called
+const defaultSlotHorizonDays = 14
+
+// maxSlotHorizonDays caps a GenerateDeliverySlots request
+const maxSlotHorizonDays = 90
+
+// tokyo is Japan Standard Time. Slot times are stored as JST wall clock and
+// Japan has no daylight saving, so a fixed zone is enough.
+var tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// timeWindowHours holds the start and end hour of each carrier time window
+var timeWindowHours = map[deliverypb.DeliveryTimeWindow][2]int{
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_MORNING: {8, 12},
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_14_16:   {14, 16},
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_16_18:   {16, 18},
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20:   {18, 20},
+	deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_19_21:   {19, 21},
+}
+
+// SetSlotHorizonDays sets how many days ahead delivery slots are generated
+func (s *DeliveryService) SetSlotHorizonDays(days int) {
+	s.slotHorizonDays = days
+}
+
+func (s *DeliveryService) ListSlotTemplates(ctx context.Context, req *deliverypb.ListSlotTemplatesRequest) (*deliverypb.ListSlotTemplatesResponse, error) {
+	s.logger.Info("Listing slot templates", zap.String("delivery_zone_id", req.DeliveryZoneId))
+
+	zoneID, err := uuid.Parse(req.DeliveryZoneId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delivery_zone_id: must be a valid UUID")
+	}
+
+	templates, err := s.queries.ListSlotTemplates(ctx, zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list slot templates: %w", err)
+	}
+
+	protoTemplates := make([]*deliverypb.SlotTemplate, 0, len(templates))
+	for _, template := range templates {
+		protoTemplates = append(protoTemplates, slotTemplateToProto(template))
+	}
+
+	return &deliverypb.ListSlotTemplatesResponse{
+		Templates: protoTemplates,
+	}, nil
+}
+
+// CreateSlotTemplate adds a weekly slot and generates its slots up to the
+// scheduling horizon
+func (s *DeliveryService) CreateSlotTemplate(ctx context.Context, req *deliverypb.CreateSlotTemplateRequest) (*deliverypb.CreateSlotTemplateResponse, error) {
+	if req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+	s.logger.Info("Creating slot template",
+		zap.String("delivery_zone_id", req.Template.DeliveryZoneId),
+		zap.Int32("weekday", req.Template.Weekday),
+		zap.String("time_window", req.Template.TimeWindow.String()))
+
+	zoneID, err := uuid.Parse(req.Template.DeliveryZoneId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delivery_zone_id: must be a valid UUID")
+	}
+	cutoffTime, err := validateSlotTemplate(req.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.queries.CreateSlotTemplate(ctx, db.CreateSlotTemplateParams{
+		DeliveryZoneID:   zoneID,
+		Weekday:          int(req.Template.Weekday),
+		TimeWindow:       req.Template.TimeWindow.String(),
+		Capacity:         int(req.Template.Capacity),
+		CutoffDaysBefore: int(req.Template.CutoffDaysBefore),
+		CutoffTime:       cutoffTime,
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "zone already has a template for this weekday and time window")
+		}
+		if isForeignKeyError(err) {
+			return nil, status.Error(codes.NotFound, "delivery zone not found")
+		}
+		return nil, fmt.Errorf("failed to create slot template: %w", err)
+	}
+
+	created, err := s.generateSlots(ctx, &zoneID, time.Now(), s.horizonDays())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery slots: %w", err)
+	}
+
+	return &deliverypb.CreateSlotTemplateResponse{
+		Template:     slotTemplateToProto(template),
+		SlotsCreated: int32(created),
+	}, nil
+}
+
+// UpdateSlotTemplate changes a weekly slot. Future slots generated from it
+// that have no reservations are replaced; reserved slots are left as they
+// are so existing bookings keep their window.
+func (s *DeliveryService) UpdateSlotTemplate(ctx context.Context, req *deliverypb.UpdateSlotTemplateRequest) (*deliverypb.UpdateSlotTemplateResponse, error) {
+	if req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+	s.logger.Info("Updating slot template", zap.String("template_id", req.Template.Id))
+
+	templateID, err := uuid.Parse(req.Template.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id: must be a valid UUID")
+	}
+	cutoffTime, err := validateSlotTemplate(req.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.queries.UpdateSlotTemplate(ctx, db.UpdateSlotTemplateParams{
+		ID:               templateID,
+		Weekday:          int(req.Template.Weekday),
+		TimeWindow:       req.Template.TimeWindow.String(),
+		Capacity:         int(req.Template.Capacity),
+		CutoffDaysBefore: int(req.Template.CutoffDaysBefore),
+		CutoffTime:       cutoffTime,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "slot template not found")
+		}
+		if isDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "zone already has a template for this weekday and time window")
+		}
+		return nil, fmt.Errorf("failed to update slot template: %w", err)
+	}
+
+	now := time.Now()
+	removed, err := s.queries.DeleteUnreservedTemplateSlots(ctx, templateID, now.In(tokyo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove template slots: %w", err)
+	}
+	created, err := s.generateSlots(ctx, &template.DeliveryZoneID, now, s.horizonDays())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery slots: %w", err)
+	}
+
+	return &deliverypb.UpdateSlotTemplateResponse{
+		Template:     slotTemplateToProto(template),
+		SlotsRemoved: int32(removed),
+		SlotsCreated: int32(created),
+	}, nil
+}
+
+// DeleteSlotTemplate removes a weekly slot along with its future slots that
+// have no reservations
+func (s *DeliveryService) DeleteSlotTemplate(ctx context.Context, req *deliverypb.DeleteSlotTemplateRequest) (*deliverypb.DeleteSlotTemplateResponse, error) {
+	s.logger.Info("Deleting slot template", zap.String("template_id", req.Id))
+
+	templateID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id: must be a valid UUID")
+	}
+
+	if _, err := s.queries.GetSlotTemplate(ctx, templateID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "slot template not found")
+		}
+		return nil, fmt.Errorf("failed to get slot template: %w", err)
+	}
+
+	removed, err := s.queries.DeleteUnreservedTemplateSlots(ctx, templateID, time.Now().In(tokyo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove template slots: %w", err)
+	}
+	if _, err := s.queries.DeleteSlotTemplate(ctx, templateID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to delete slot template: %w", err)
+	}
+
+	return &deliverypb.DeleteSlotTemplateResponse{
+		SlotsRemoved: int32(removed),
+	}, nil
+}
+
+func (s *DeliveryService) ListSlotBlackouts(ctx context.Context, req *deliverypb.ListSlotBlackoutsRequest) (*deliverypb.ListSlotBlackoutsResponse, error) {
+	s.logger.Info("Listing slot blackouts", zap.String("delivery_zone_id", req.DeliveryZoneId))
+
+	var zoneID *uuid.UUID
+	if req.DeliveryZoneId != "" {
+		id, err := uuid.Parse(req.DeliveryZoneId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid delivery_zone_id: must be a valid UUID")
+		}
+		zoneID = &id
+	}
+
+	blackouts, err := s.queries.ListSlotBlackouts(ctx, tokyoDate(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list slot blackouts: %w", err)
+	}
+
+	protoBlackouts := make([]*deliverypb.SlotBlackout, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		if zoneID != nil && blackout.DeliveryZoneID != nil && *blackout.DeliveryZoneID != *zoneID {
+			continue
+		}
+		protoBlackouts = append(protoBlackouts, slotBlackoutToProto(blackout))
+	}
+
+	return &deliverypb.ListSlotBlackoutsResponse{
+		Blackouts: protoBlackouts,
+	}, nil
+}
+
+// CreateSlotBlackout closes a date for delivery. Slots already generated for
+// the date are removed unless they have reservations, which the caller has
+// to move by hand.
+func (s *DeliveryService) CreateSlotBlackout(ctx context.Context, req *deliverypb.CreateSlotBlackoutRequest) (*deliverypb.CreateSlotBlackoutResponse, error) {
+	if req.Blackout == nil {
+		return nil, status.Error(codes.InvalidArgument, "blackout is required")
+	}
+	s.logger.Info("Creating slot blackout",
+		zap.String("delivery_zone_id", req.Blackout.DeliveryZoneId),
+		zap.Time("date", req.Blackout.Date.AsTime()))
+
+	var zoneID *uuid.UUID
+	if req.Blackout.DeliveryZoneId != "" {
+		id, err := uuid.Parse(req.Blackout.DeliveryZoneId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid delivery_zone_id: must be a valid UUID")
+		}
+		zoneID = &id
+	}
+	if req.Blackout.Date == nil {
+		return nil, status.Error(codes.InvalidArgument, "date is required")
+	}
+	date := calendarDate(req.Blackout.Date.AsTime())
+
+	blackout, err := s.queries.CreateSlotBlackout(ctx, db.CreateSlotBlackoutParams{
+		DeliveryZoneID: zoneID,
+		Date:           date,
+		Reason:         req.Blackout.Reason,
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "date is already blacked out")
+		}
+		if isForeignKeyError(err) {
+			return nil, status.Error(codes.NotFound, "delivery zone not found")
+		}
+		return nil, fmt.Errorf("failed to create slot blackout: %w", err)
+	}
+
+	removed, reserved, err := s.queries.DeleteUnreservedSlotsOnDate(ctx, zoneID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove blacked out slots: %w", err)
+	}
+	if reserved > 0 {
+		s.logger.Warn("Blacked out date has reserved delivery slots",
+			zap.String("blackout_id", blackout.ID.String()),
+			zap.Int("reserved_slots", reserved))
+	}
+
+	return &deliverypb.CreateSlotBlackoutResponse{
+		Blackout:      slotBlackoutToProto(blackout),
+		SlotsRemoved:  int32(removed),
+		ReservedSlots: int32(reserved),
+	}, nil
+}
+
+// DeleteSlotBlackout reopens a date and generates its slots again
+func (s *DeliveryService) DeleteSlotBlackout(ctx context.Context, req *deliverypb.DeleteSlotBlackoutRequest) (*deliverypb.DeleteSlotBlackoutResponse, error) {
+	s.logger.Info("Deleting slot blackout", zap.String("blackout_id", req.Id))
+
+	blackoutID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id: must be a valid UUID")
+	}
+
+	blackout, err := s.queries.DeleteSlotBlackout(ctx, blackoutID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "slot blackout not found")
+		}
+		return nil, fmt.Errorf("failed to delete slot blackout: %w", err)
+	}
+
+	created, err := s.generateSlots(ctx, blackout.DeliveryZoneID, time.Now(), s.horizonDays())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery slots: %w", err)
+	}
+
+	return &deliverypb.DeleteSlotBlackoutResponse{
+		SlotsCreated: int32(created),
+	}, nil
+}
+
+// GenerateDeliverySlots rolls slots forward without waiting for the
+// scheduler. Slots that already exist are left alone.
+func (s *DeliveryService) GenerateDeliverySlots(ctx context.Context, req *deliverypb.GenerateDeliverySlotsRequest) (*deliverypb.GenerateDeliverySlotsResponse, error) {
+	s.logger.Info("Generating delivery slots",
+		zap.String("delivery_zone_id", req.DeliveryZoneId),
+		zap.Int32("days", req.Days))
+
+	var zoneID *uuid.UUID
+	if req.DeliveryZoneId != "" {
+		id, err := uuid.Parse(req.DeliveryZoneId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid delivery_zone_id: must be a valid UUID")
+		}
+		zoneID = &id
+	}
+	days := int(req.Days)
+	if days < 0 || days > maxSlotHorizonDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be between 0 and %d", maxSlotHorizonDays)
+	}
+	if days == 0 {
+		days = s.horizonDays()
+	}
+
+	created, err := s.generateSlots(ctx, zoneID, time.Now(), days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery slots: %w", err)
+	}
+
+	return &deliverypb.GenerateDeliverySlotsResponse{
+		SlotsCreated: int32(created),
+	}, nil
+}
+
+func (s *DeliveryService) horizonDays() int {
+	if s.slotHorizonDays <= 0 {
+		return defaultSlotHorizonDays
+	}
+	return s.slotHorizonDays
+}
+
+// generateSlots creates the slots the templates call for over the next days,
+// in one zone or, when zoneID is nil, every zone
+func (s *DeliveryService) generateSlots(ctx context.Context, zoneID *uuid.UUID, now time.Time, days int) (int, error) {
+	var templates []db.SlotTemplate
+	var err error
+	if zoneID != nil {
+		templates, err = s.queries.ListSlotTemplates(ctx, *zoneID)
+	} else {
+		templates, err = s.queries.ListAllSlotTemplates(ctx)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(templates) == 0 {
+		return 0, nil
+	}
+
+	blackouts, err := s.queries.ListSlotBlackouts(ctx, tokyoDate(now))
+	if err != nil {
+		return 0, err
+	}
+
+	slots := planSlots(templates, blackouts, now, days)
+	if len(slots) == 0 {
+		return 0, nil
+	}
+	return s.queries.CreateDeliverySlots(ctx, slots)
+}
+
+// planSlots lists the slots the templates call for on each of the days
+// starting today (JST). Blacked out dates and slots whose cutoff has passed
+// are skipped.
+func planSlots(templates []db.SlotTemplate, blackouts []db.SlotBlackout, now time.Time, days int) []db.CreateDeliverySlotParams {
+	now = now.In(tokyo)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tokyo)
+
+	var slots []db.CreateDeliverySlotParams
+	for i := 0; i < days; i++ {
+		day := today.AddDate(0, 0, i)
+		date := calendarDate(day)
+
+		for _, template := range templates {
+			if template.Weekday != int(day.Weekday()) || blackedOut(blackouts, template.DeliveryZoneID, date) {
+				continue
+			}
+			hours, ok := timeWindowHours[deliverypb.DeliveryTimeWindow(deliverypb.DeliveryTimeWindow_value[template.TimeWindow])]
+			if !ok {
+				continue
+			}
+			cutoff, ok := slotCutoff(day, template)
+			if !ok || !cutoff.After(now) {
+				continue
+			}
+
+			slots = append(slots, db.CreateDeliverySlotParams{
+				DeliveryZoneID: template.DeliveryZoneID,
+				TemplateID:     template.ID,
+				TimeWindow:     template.TimeWindow,
+				StartTime:      day.Add(time.Duration(hours[0]) * time.Hour),
+				EndTime:        day.Add(time.Duration(hours[1]) * time.Hour),
+				Capacity:       template.Capacity,
+				Date:           date,
+				CutoffAt:       cutoff,
+			})
+		}
+	}
+	return slots
+}
+
+// slotCutoff returns when orders close for the template's slot on day
+func slotCutoff(day time.Time, template db.SlotTemplate) (time.Time, bool) {
+	clock, err := time.Parse("15:04", template.CutoffTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	cutoffDay := day.AddDate(0, 0, -template.CutoffDaysBefore)
+	return time.Date(cutoffDay.Year(), cutoffDay.Month(), cutoffDay.Day(), clock.Hour(), clock.Minute(), 0, 0, tokyo), true
+}
+
+func blackedOut(blackouts []db.SlotBlackout, zoneID uuid.UUID, date time.Time) bool {
+	for _, blackout := range blackouts {
+		if !blackout.Date.Equal(date) {
+			continue
+		}
+		if blackout.DeliveryZoneID == nil || *blackout.DeliveryZoneID == zoneID {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSlotTemplate checks a template and returns its cutoff time as
+// HH:MM, defaulting to 17:00
+func validateSlotTemplate(template *deliverypb.SlotTemplate) (string, error) {
+	if template.Weekday < 0 || template.Weekday > 6 {
+		return "", status.Error(codes.InvalidArgument, "weekday must be between 0 (Sunday) and 6 (Saturday)")
+	}
+	if _, ok := timeWindowHours[template.TimeWindow]; !ok {
+		return "", status.Error(codes.InvalidArgument, "time_window is required")
+	}
+	if template.Capacity <= 0 {
+		return "", status.Error(codes.InvalidArgument, "capacity must be positive")
+	}
+	if template.CutoffDaysBefore < 0 {
+		return "", status.Error(codes.InvalidArgument, "cutoff_days_before must not be negative")
+	}
+
+	if template.CutoffTime == "" {
+		return "17:00", nil
+	}
+	clock, err := time.Parse("15:04", template.CutoffTime)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "cutoff_time must be HH:MM")
+	}
+	if template.CutoffDaysBefore == 0 && clock.Hour() >= timeWindowHours[template.TimeWindow][0] {
+		return "", status.Error(codes.InvalidArgument, "same-day cutoff_time must be before the time window starts")
+	}
+	return clock.Format("15:04"), nil
+}
+
+// tokyoDate returns the JST calendar date of t
+func tokyoDate(t time.Time) time.Time {
+	return calendarDate(t.In(tokyo))
+}
+
+// calendarDate returns t's date as midnight UTC, the form DATE columns are
+// compared in
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isDuplicateKeyError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "23505"
+}
+
+func isForeignKeyError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "23503"
+}
+
+func slotTemplateToProto(template db.SlotTemplate) *deliverypb.SlotTemplate {
+	return &deliverypb.SlotTemplate{
+		Id:               template.ID.String(),
+		DeliveryZoneId:   template.DeliveryZoneID.String(),
+		Weekday:          int32(template.Weekday),
+		TimeWindow:       deliverypb.DeliveryTimeWindow(deliverypb.DeliveryTimeWindow_value[template.TimeWindow]),
+		Capacity:         int32(template.Capacity),
+		CutoffDaysBefore: int32(template.CutoffDaysBefore),
+		CutoffTime:       template.CutoffTime,
+	}
+}
+
+func slotBlackoutToProto(blackout db.SlotBlackout) *deliverypb.SlotBlackout {
+	pb := &deliverypb.SlotBlackout{
+		Id:     blackout.ID.String(),
+		Date:   timestamppb.New(blackout.Date),
+		Reason: blackout.Reason,
+	}
+	if blackout.DeliveryZoneID != nil {
+		pb.DeliveryZoneId = blackout.DeliveryZoneID.String()
+	}
+	return pb
+}
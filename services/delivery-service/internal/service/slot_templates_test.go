@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func TestPlanSlots(t *testing.T) {
+	zoneID := uuid.New()
+	otherZoneID := uuid.New()
+	// Monday 2026-05-04 10:00 JST
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, tokyo)
+
+	morning := db.SlotTemplate{
+		ID: uuid.New(), DeliveryZoneID: zoneID, Weekday: int(time.Tuesday),
+		TimeWindow: "DELIVERY_TIME_WINDOW_MORNING", Capacity: 20,
+		CutoffDaysBefore: 1, CutoffTime: "17:00",
+	}
+	evening := db.SlotTemplate{
+		ID: uuid.New(), DeliveryZoneID: zoneID, Weekday: int(time.Monday),
+		TimeWindow: "DELIVERY_TIME_WINDOW_19_21", Capacity: 5,
+		CutoffDaysBefore: 0, CutoffTime: "12:00",
+	}
+
+	t.Run("generates slots on the template's weekday", func(t *testing.T) {
+		slots := planSlots([]db.SlotTemplate{morning}, nil, now, 14)
+
+		require.Len(t, slots, 2)
+		assert.Equal(t, time.Date(2026, 5, 5, 8, 0, 0, 0, tokyo), slots[0].StartTime)
+		assert.Equal(t, time.Date(2026, 5, 5, 12, 0, 0, 0, tokyo), slots[0].EndTime)
+		assert.Equal(t, time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC), slots[0].Date)
+		assert.Equal(t, time.Date(2026, 5, 4, 17, 0, 0, 0, tokyo), slots[0].CutoffAt)
+		assert.Equal(t, morning.ID, slots[0].TemplateID)
+		assert.Equal(t, 20, slots[0].Capacity)
+		assert.Equal(t, time.Date(2026, 5, 12, 8, 0, 0, 0, tokyo), slots[1].StartTime)
+	})
+
+	t.Run("uses the Tokyo date", func(t *testing.T) {
+		// Sunday 2026-05-03 16:00 UTC is Monday 01:00 in Tokyo
+		slots := planSlots([]db.SlotTemplate{evening}, nil, time.Date(2026, 5, 3, 16, 0, 0, 0, time.UTC), 1)
+
+		require.Len(t, slots, 1)
+		assert.Equal(t, time.Date(2026, 5, 4, 19, 0, 0, 0, tokyo), slots[0].StartTime)
+	})
+
+	t.Run("skips slots past their cutoff", func(t *testing.T) {
+		slots := planSlots([]db.SlotTemplate{evening}, nil, time.Date(2026, 5, 4, 12, 0, 0, 0, tokyo), 8)
+
+		require.Len(t, slots, 1)
+		assert.Equal(t, time.Date(2026, 5, 11, 19, 0, 0, 0, tokyo), slots[0].StartTime)
+	})
+
+	t.Run("skips blacked out dates", func(t *testing.T) {
+		blackouts := []db.SlotBlackout{
+			{ID: uuid.New(), DeliveryZoneID: &zoneID, Date: time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), Date: time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), DeliveryZoneID: &otherZoneID, Date: time.Date(2026, 5, 19, 0, 0, 0, 0, time.UTC)},
+		}
+
+		slots := planSlots([]db.SlotTemplate{morning}, blackouts, now, 21)
+
+		require.Len(t, slots, 1)
+		assert.Equal(t, time.Date(2026, 5, 19, 0, 0, 0, 0, time.UTC), slots[0].Date)
+	})
+
+	t.Run("skips unknown time windows", func(t *testing.T) {
+		unknown := morning
+		unknown.TimeWindow = "DELIVERY_TIME_WINDOW_UNSPECIFIED"
+
+		assert.Empty(t, planSlots([]db.SlotTemplate{unknown}, nil, now, 14))
+	})
+}
+
+func TestValidateSlotTemplate(t *testing.T) {
+	valid := func() *deliverypb.SlotTemplate {
+		return &deliverypb.SlotTemplate{
+			Weekday:    1,
+			TimeWindow: deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_14_16,
+			Capacity:   10,
+		}
+	}
+
+	cutoff, err := validateSlotTemplate(valid())
+	require.NoError(t, err)
+	assert.Equal(t, "17:00", cutoff)
+
+	sameDay := valid()
+	sameDay.CutoffTime = "9:30"
+	cutoff, err = validateSlotTemplate(sameDay)
+	require.NoError(t, err)
+	assert.Equal(t, "09:30", cutoff)
+
+	tests := []struct {
+		name   string
+		modify func(*deliverypb.SlotTemplate)
+	}{
+		{"weekday out of range", func(tpl *deliverypb.SlotTemplate) { tpl.Weekday = 7 }},
+		{"missing time window", func(tpl *deliverypb.SlotTemplate) {
+			tpl.TimeWindow = deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_UNSPECIFIED
+		}},
+		{"zero capacity", func(tpl *deliverypb.SlotTemplate) { tpl.Capacity = 0 }},
+		{"negative cutoff days", func(tpl *deliverypb.SlotTemplate) { tpl.CutoffDaysBefore = -1 }},
+		{"malformed cutoff time", func(tpl *deliverypb.SlotTemplate) { tpl.CutoffTime = "5pm" }},
+		{"same-day cutoff after the window starts", func(tpl *deliverypb.SlotTemplate) { tpl.CutoffTime = "14:00" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := valid()
+			tt.modify(tpl)
+			_, err := validateSlotTemplate(tpl)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestDeliveryService_UpdateSlotTemplate(t *testing.T) {
+	zoneID := uuid.New()
+	templateID := uuid.New()
+
+	t.Run("replaces unreserved slots", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		template := db.SlotTemplate{
+			ID: templateID, DeliveryZoneID: zoneID, Weekday: 3,
+			TimeWindow: "DELIVERY_TIME_WINDOW_18_20", Capacity: 8,
+			CutoffDaysBefore: 1, CutoffTime: "17:00",
+		}
+		mockQueries.On("UpdateSlotTemplate", mock.Anything, db.UpdateSlotTemplateParams{
+			ID: templateID, Weekday: 3, TimeWindow: "DELIVERY_TIME_WINDOW_18_20",
+			Capacity: 8, CutoffDaysBefore: 1, CutoffTime: "17:00",
+		}).Return(template, nil)
+		mockQueries.On("DeleteUnreservedTemplateSlots", mock.Anything, templateID, mock.AnythingOfType("time.Time")).Return(2, nil)
+		mockQueries.On("ListSlotTemplates", mock.Anything, zoneID).Return([]db.SlotTemplate{template}, nil)
+		mockQueries.On("ListSlotBlackouts", mock.Anything, mock.AnythingOfType("time.Time")).Return([]db.SlotBlackout{}, nil)
+		mockQueries.On("CreateDeliverySlots", mock.Anything, mock.MatchedBy(func(slots []db.CreateDeliverySlotParams) bool {
+			for _, slot := range slots {
+				if slot.TemplateID != templateID || slot.Capacity != 8 || slot.StartTime.Hour() != 18 {
+					return false
+				}
+			}
+			return len(slots) > 0
+		})).Return(2, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.UpdateSlotTemplate(context.Background(), &deliverypb.UpdateSlotTemplateRequest{
+			Template: &deliverypb.SlotTemplate{
+				Id: templateID.String(), Weekday: 3,
+				TimeWindow: deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20,
+				Capacity:   8, CutoffDaysBefore: 1,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), resp.SlotsRemoved)
+		assert.Equal(t, int32(2), resp.SlotsCreated)
+		assert.Equal(t, "17:00", resp.Template.CutoffTime)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("UpdateSlotTemplate", mock.Anything, mock.Anything).Return(db.SlotTemplate{}, pgx.ErrNoRows)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		_, err := service.UpdateSlotTemplate(context.Background(), &deliverypb.UpdateSlotTemplateRequest{
+			Template: &deliverypb.SlotTemplate{
+				Id: templateID.String(), Weekday: 3,
+				TimeWindow: deliverypb.DeliveryTimeWindow_DELIVERY_TIME_WINDOW_18_20,
+				Capacity:   8,
+			},
+		})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockQueries.AssertNotCalled(t, "DeleteUnreservedTemplateSlots", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeliveryService_CreateSlotBlackout(t *testing.T) {
+	zoneID := uuid.New()
+	date := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	mockQueries := new(MockQuerier)
+	blackout := db.SlotBlackout{ID: uuid.New(), DeliveryZoneID: &zoneID, Date: date, Reason: "大晦日"}
+	mockQueries.On("CreateSlotBlackout", mock.Anything, db.CreateSlotBlackoutParams{
+		DeliveryZoneID: &zoneID, Date: date, Reason: "大晦日",
+	}).Return(blackout, nil)
+	mockQueries.On("DeleteUnreservedSlotsOnDate", mock.Anything, &zoneID, date).Return(4, 1, nil)
+	service := NewDeliveryService(mockQueries, zap.NewNop())
+
+	resp, err := service.CreateSlotBlackout(context.Background(), &deliverypb.CreateSlotBlackoutRequest{
+		Blackout: &deliverypb.SlotBlackout{
+			DeliveryZoneId: zoneID.String(),
+			Date:           timestamppb.New(date.Add(15 * time.Hour)),
+			Reason:         "大晦日",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), resp.SlotsRemoved)
+	assert.Equal(t, int32(1), resp.ReservedSlots)
+	assert.Equal(t, zoneID.String(), resp.Blackout.DeliveryZoneId)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestDeliveryService_GenerateDeliverySlots(t *testing.T) {
+	t.Run("rejects a horizon that is too long", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.GenerateDeliverySlots(context.Background(), &deliverypb.GenerateDeliverySlotsRequest{Days: 365})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("does nothing without templates", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("ListAllSlotTemplates", mock.Anything).Return([]db.SlotTemplate{}, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.GenerateDeliverySlots(context.Background(), &deliverypb.GenerateDeliverySlotsRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), resp.SlotsCreated)
+		mockQueries.AssertNotCalled(t, "CreateDeliverySlots", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+// AddTrackingEvent records a checkpoint in a shipment's tracking timeline
+// without changing the shipment's status. Status changes are recorded by
+// UpdateShipmentStatus.
+func (s *DeliveryService) AddTrackingEvent(ctx context.Context, req *deliverypb.AddTrackingEventRequest) (*deliverypb.AddTrackingEventResponse, error) {
+	s.logger.Info("Adding tracking event",
+		zap.String("shipment_id", req.ShipmentId),
+		zap.String("status", req.Status))
+
+	shipmentID, err := uuid.Parse(req.ShipmentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid shipment_id: must be a valid UUID")
+	}
+	eventStatus := strings.TrimSpace(req.Status)
+	if eventStatus == "" {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+
+	params := db.CreateTrackingEventParams{
+		ShipmentID:  shipmentID,
+		Status:      eventStatus,
+		Location:    strings.TrimSpace(req.Location),
+		Description: strings.TrimSpace(req.Description),
+	}
+	if req.Timestamp != nil {
+		occurredAt := req.Timestamp.AsTime()
+		if occurredAt.After(time.Now().Add(time.Minute)) {
+			return nil, status.Error(codes.InvalidArgument, "timestamp must not be in the future")
+		}
+		params.OccurredAt = &occurredAt
+	}
+
+	event, err := s.queries.CreateTrackingEvent(ctx, params)
+	if err != nil {
+		if isForeignKeyError(err) {
+			return nil, status.Error(codes.NotFound, "shipment not found")
+		}
+		return nil, fmt.Errorf("failed to create tracking event: %w", err)
+	}
+
+	return &deliverypb.AddTrackingEventResponse{
+		Event: trackingEventToProto(event),
+	}, nil
+}
+
+// ListTrackingEvents returns a shipment's tracking timeline, oldest first
+func (s *DeliveryService) ListTrackingEvents(ctx context.Context, req *deliverypb.ListTrackingEventsRequest) (*deliverypb.ListTrackingEventsResponse, error) {
+	s.logger.Info("Listing tracking events",
+		zap.String("shipment_id", req.ShipmentId),
+		zap.String("order_id", req.OrderId))
+
+	shipment, err := s.findShipment(ctx, req.ShipmentId, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.queries.ListTrackingEvents(ctx, shipment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracking events: %w", err)
+	}
+
+	return &deliverypb.ListTrackingEventsResponse{
+		Events: trackingEventsToProto(events),
+	}, nil
+}
+
+func trackingEventsToProto(events []db.TrackingEvent) []*deliverypb.TrackingEvent {
+	pbEvents := make([]*deliverypb.TrackingEvent, 0, len(events))
+	for _, event := range events {
+		pbEvents = append(pbEvents, trackingEventToProto(event))
+	}
+	return pbEvents
+}
+
+func trackingEventToProto(event db.TrackingEvent) *deliverypb.TrackingEvent {
+	return &deliverypb.TrackingEvent{
+		Id:          event.ID.String(),
+		Status:      event.Status,
+		Location:    event.Location,
+		Timestamp:   timestamppb.New(event.OccurredAt),
+		Description: event.Description,
+	}
+}
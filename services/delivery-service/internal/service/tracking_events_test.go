@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"github.com/afasari/shinkansen-commerce/services/delivery-service/internal/db"
+)
+
+func TestDeliveryService_AddTrackingEvent(t *testing.T) {
+	shipmentID := uuid.New()
+
+	t.Run("records the checkpoint", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		occurredAt := time.Date(2026, 5, 5, 1, 30, 0, 0, time.UTC)
+		event := db.TrackingEvent{
+			ID: uuid.New(), ShipmentID: shipmentID, Status: "到着",
+			Location: "羽田クロノゲート", OccurredAt: occurredAt,
+		}
+		mockQueries.On("CreateTrackingEvent", mock.Anything, db.CreateTrackingEventParams{
+			ShipmentID: shipmentID, Status: "到着", Location: "羽田クロノゲート", OccurredAt: &occurredAt,
+		}).Return(event, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.AddTrackingEvent(context.Background(), &deliverypb.AddTrackingEventRequest{
+			ShipmentId: shipmentID.String(),
+			Status:     " 到着 ",
+			Location:   "羽田クロノゲート",
+			Timestamp:  timestamppb.New(occurredAt),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, event.ID.String(), resp.Event.Id)
+		assert.Equal(t, "羽田クロノゲート", resp.Event.Location)
+		assert.True(t, occurredAt.Equal(resp.Event.Timestamp.AsTime()))
+	})
+
+	t.Run("unknown shipment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockQueries.On("CreateTrackingEvent", mock.Anything, mock.Anything).
+			Return(db.TrackingEvent{}, &pgconn.PgError{Code: "23503"})
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		_, err := service.AddTrackingEvent(context.Background(), &deliverypb.AddTrackingEventRequest{
+			ShipmentId: shipmentID.String(), Status: "発送",
+		})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	tests := []struct {
+		name string
+		req  *deliverypb.AddTrackingEventRequest
+	}{
+		{"invalid shipment ID", &deliverypb.AddTrackingEventRequest{ShipmentId: "nope", Status: "発送"}},
+		{"missing status", &deliverypb.AddTrackingEventRequest{ShipmentId: shipmentID.String(), Status: " "}},
+		{"future timestamp", &deliverypb.AddTrackingEventRequest{
+			ShipmentId: shipmentID.String(), Status: "発送",
+			Timestamp: timestamppb.New(time.Now().Add(time.Hour)),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+			_, err := service.AddTrackingEvent(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestDeliveryService_ListTrackingEvents(t *testing.T) {
+	t.Run("by order", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		orderID := uuid.New()
+		shipment := db.Shipment{ID: uuid.New(), OrderID: orderID, Status: "SHIPMENT_STATUS_IN_TRANSIT"}
+		mockQueries.On("GetShipmentByOrderID", mock.Anything, orderID).Return(shipment, nil)
+		mockQueries.On("ListTrackingEvents", mock.Anything, shipment.ID).Return([]db.TrackingEvent{
+			{ID: uuid.New(), ShipmentID: shipment.ID, Status: "SHIPMENT_STATUS_SHIPPED"},
+			{ID: uuid.New(), ShipmentID: shipment.ID, Status: "SHIPMENT_STATUS_IN_TRANSIT", Location: "大阪ベース"},
+		}, nil)
+		service := NewDeliveryService(mockQueries, zap.NewNop())
+
+		resp, err := service.ListTrackingEvents(context.Background(), &deliverypb.ListTrackingEventsRequest{
+			OrderId: orderID.String(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Events, 2)
+		assert.Equal(t, "SHIPMENT_STATUS_SHIPPED", resp.Events[0].Status)
+		assert.Equal(t, "大阪ベース", resp.Events[1].Location)
+	})
+
+	t.Run("requires a shipment or order", func(t *testing.T) {
+		service := NewDeliveryService(new(MockQuerier), zap.NewNop())
+
+		_, err := service.ListTrackingEvents(context.Background(), &deliverypb.ListTrackingEventsRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDeliveryService_GetShipment_Timeline(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	shipment := db.Shipment{ID: uuid.New(), OrderID: uuid.New(), Status: "SHIPMENT_STATUS_DELIVERED"}
+	mockQueries.On("GetShipment", mock.Anything, shipment.ID).Return(shipment, nil)
+	mockQueries.On("ListTrackingEvents", mock.Anything, shipment.ID).Return([]db.TrackingEvent{
+		{ID: uuid.New(), ShipmentID: shipment.ID, Status: "SHIPMENT_STATUS_SHIPPED"},
+		{ID: uuid.New(), ShipmentID: shipment.ID, Status: "SHIPMENT_STATUS_DELIVERED", Description: "宅配ボックスに配達"},
+	}, nil)
+	service := NewDeliveryService(mockQueries, zap.NewNop())
+
+	resp, err := service.GetShipment(context.Background(), &deliverypb.GetShipmentRequest{ShipmentId: shipment.ID.String()})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Shipment.TrackingEvents, 2)
+	assert.Equal(t, "宅配ボックスに配達", resp.Shipment.TrackingEvents[1].Description)
+}
@@ -2,10 +2,22 @@ import { client } from './client'
 import type {
   DeliverySlot,
   Shipment,
+  TrackingEvent,
   GetDeliverySlotsParams,
+  ResolveDeliveryZoneParams,
+  ResolveDeliveryZoneResponse,
+  GetDeliverySlotsForAddressParams,
+  GetDeliverySlotsForAddressResponse,
   ReserveDeliverySlotRequest,
   ReserveDeliverySlotResponse,
   UpdateShipmentStatusRequest,
+  DispatchShipmentRequest,
+  ValidateTrackingNumberResponse,
+  GetShippingLabelsParams,
+  QuoteShippingRequest,
+  ShippingQuote,
+  PickupPoint,
+  ListPickupPointsParams,
 } from '@/types'
 
 export async function getDeliverySlots(params: GetDeliverySlotsParams): Promise<DeliverySlot[]> {
@@ -13,6 +25,16 @@ export async function getDeliverySlots(params: GetDeliverySlotsParams): Promise<
   return res.data.slots
 }
 
+export async function resolveDeliveryZone(params: ResolveDeliveryZoneParams): Promise<ResolveDeliveryZoneResponse> {
+  const res = await client.get<ResolveDeliveryZoneResponse>('/v1/delivery/zones/resolve', { params })
+  return res.data
+}
+
+export async function getDeliverySlotsForAddress(params: GetDeliverySlotsForAddressParams): Promise<GetDeliverySlotsForAddressResponse> {
+  const res = await client.get<GetDeliverySlotsForAddressResponse>('/v1/delivery/address-slots', { params })
+  return res.data
+}
+
 export async function reserveDeliverySlot(data: ReserveDeliverySlotRequest): Promise<ReserveDeliverySlotResponse> {
   const res = await client.post<ReserveDeliverySlotResponse>('/v1/delivery/slots', data)
   return res.data
@@ -28,6 +50,63 @@ export async function getShipment(shipmentId: string): Promise<Shipment> {
   return res.data.shipment
 }
 
+export async function getOrderShipment(orderId: string): Promise<Shipment> {
+  const res = await client.get<{ shipment: Shipment }>('/v1/shipments', { params: { order_id: orderId } })
+  return res.data.shipment
+}
+
+export async function listTrackingEvents(shipmentId: string): Promise<TrackingEvent[]> {
+  const res = await client.get<{ events: TrackingEvent[] }>(`/v1/shipments/${shipmentId}/tracking-events`)
+  return res.data.events ?? []
+}
+
 export async function updateShipmentStatus(shipmentId: string, data: UpdateShipmentStatusRequest): Promise<void> {
   await client.put(`/v1/shipments/${shipmentId}/status`, data)
 }
+
+export async function dispatchShipment(data: DispatchShipmentRequest): Promise<Shipment> {
+  const res = await client.post<{ shipment: Shipment }>('/v1/shipments/dispatch', data)
+  return res.data.shipment
+}
+
+export async function cancelShipment(shipmentId: string, reason?: string): Promise<Shipment> {
+  const res = await client.post<{ shipment: Shipment }>(`/v1/shipments/${shipmentId}/cancel`, { reason })
+  return res.data.shipment
+}
+
+export async function validateTrackingNumber(carrier: string, trackingNumber: string): Promise<ValidateTrackingNumberResponse> {
+  const res = await client.get<ValidateTrackingNumberResponse>(
+    `/v1/carriers/${carrier}/tracking-numbers/${encodeURIComponent(trackingNumber)}`,
+  )
+  return res.data
+}
+
+export async function getShippingLabel(shipmentId: string): Promise<Blob> {
+  const res = await client.get<Blob>(`/v1/shipments/${shipmentId}/label`, { responseType: 'blob' })
+  return res.data
+}
+
+export async function getShippingLabels(params: GetShippingLabelsParams = {}): Promise<Blob> {
+  const res = await client.get<Blob>('/v1/shipments/labels', { params, responseType: 'blob' })
+  return res.data
+}
+
+export async function quoteShipping(data: QuoteShippingRequest): Promise<ShippingQuote> {
+  const res = await client.post<{ quote: ShippingQuote }>('/v1/shipping/quote', data)
+  return res.data.quote
+}
+
+export async function listPickupPoints(params: ListPickupPointsParams): Promise<PickupPoint[]> {
+  const res = await client.get<{ pickup_points: PickupPoint[] }>('/v1/delivery/pickup-points', { params })
+  return res.data.pickup_points ?? []
+}
+
+export async function getPickupPoint(id: string): Promise<PickupPoint> {
+  const res = await client.get<{ pickup_point: PickupPoint }>(`/v1/delivery/pickup-points/${id}`)
+  return res.data.pickup_point
+}
+
+export async function confirmPickup(shipmentId: string, pickupCode: string): Promise<Shipment> {
+  const res = await client.post<{ shipment: Shipment }>(`/v1/shipments/${shipmentId}/pickup`, { pickup_code: pickupCode })
+  return res.data.shipment
+}
@@ -6,6 +6,10 @@ import type {
   ProcessPaymentRequest,
   ProcessPaymentResponse,
   RefundPaymentRequest,
+  SavedPaymentMethod,
+  SavePaymentMethodRequest,
+  GiftCard,
+  CheckGiftCardBalanceRequest,
 } from '@/types'
 
 export async function createPayment(data: CreatePaymentRequest): Promise<CreatePaymentResponse> {
@@ -26,3 +30,32 @@ export async function processPayment(paymentId: string, data: ProcessPaymentRequ
 export async function refundPayment(paymentId: string, data: RefundPaymentRequest): Promise<void> {
   await client.post(`/v1/payments/${paymentId}/refund`, data)
 }
+
+export async function listPaymentMethods(): Promise<SavedPaymentMethod[]> {
+  const res = await client.get<{ payment_methods?: SavedPaymentMethod[] }>('/v1/users/me/payment-methods')
+  return res.data.payment_methods ?? []
+}
+
+export async function savePaymentMethod(data: SavePaymentMethodRequest): Promise<SavedPaymentMethod> {
+  const res = await client.post<{ payment_method: SavedPaymentMethod }>('/v1/users/me/payment-methods', data)
+  return res.data.payment_method
+}
+
+export async function setDefaultPaymentMethod(paymentMethodId: string): Promise<SavedPaymentMethod> {
+  const res = await client.post<{ payment_method: SavedPaymentMethod }>(`/v1/users/me/payment-methods/${paymentMethodId}/default`)
+  return res.data.payment_method
+}
+
+export async function deletePaymentMethod(paymentMethodId: string): Promise<void> {
+  await client.delete(`/v1/users/me/payment-methods/${paymentMethodId}`)
+}
+
+export async function listMyGiftCards(): Promise<GiftCard[]> {
+  const res = await client.get<{ gift_cards?: GiftCard[] }>('/v1/users/me/gift-cards')
+  return res.data.gift_cards ?? []
+}
+
+export async function checkGiftCardBalance(data: CheckGiftCardBalanceRequest): Promise<GiftCard> {
+  const res = await client.post<{ gift_card: GiftCard }>('/v1/gift-cards/balance', data)
+  return res.data.gift_card
+}
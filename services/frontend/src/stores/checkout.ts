@@ -1,11 +1,12 @@
 import { defineStore } from 'pinia'
 import { ref, computed } from 'vue'
-import type { ShippingAddress, DeliverySlot, Money } from '@/types'
+import type { ShippingAddress, DeliverySlot, PickupPoint, Money } from '@/types'
 import { OrderStatus, PaymentMethod, PaymentStatus } from '@/types'
 import { useCartStore } from './cart'
 import { useAuthStore } from './auth'
 import { DEFAULT_WAREHOUSE_ID } from '@/utils/constants'
 import { createMoney } from '@/utils/format'
+import { tokenizeCard, type CardDetails } from '@/utils/cardToken'
 import * as ordersApi from '@/api/orders'
 import * as inventoryApi from '@/api/inventory'
 import * as deliveryApi from '@/api/delivery'
@@ -16,8 +17,11 @@ export const useCheckoutStore = defineStore('checkout', () => {
   const selectedAddress = ref<ShippingAddress | null>(null)
   const selectedAddressId = ref<string | null>(null)
   const selectedDeliverySlot = ref<DeliverySlot | null>(null)
+  const selectedPickupPoint = ref<PickupPoint | null>(null)
   const selectedPaymentMethod = ref<PaymentMethod | null>(null)
   const pointsToApply = ref(0)
+  const shippingFee = ref(0)
+  const estimatedDelivery = ref<string | null>(null)
   const lastOrderId = ref<string | null>(null)
   const lastOrderNumber = ref<string | null>(null)
   const loading = ref(false)
@@ -40,19 +44,73 @@ export const useCheckoutStore = defineStore('checkout', () => {
     return Math.max(0, Math.round(taxable * TAX_RATE))
   })
 
+  // 代引手数料 tiers, matching order-service
+  const COD_FEE_TIERS = [
+    { upTo: 10000, fee: 330 },
+    { upTo: 30000, fee: 440 },
+    { upTo: 100000, fee: 660 },
+    { upTo: 300000, fee: 1100 },
+  ]
+
+  const codFee = computed<number>(() => {
+    if (selectedPaymentMethod.value !== PaymentMethod.CASH_ON_DELIVERY) return 0
+    const beforeFee = Math.max(0, (subtotal.value?.units ?? 0) - pointsDiscount.value + tax.value) + shippingFee.value
+    return COD_FEE_TIERS.find((tier) => beforeFee < tier.upTo)?.fee ?? 0
+  })
+
   const total = computed<number>(() => {
-    return Math.max(0, (subtotal.value?.units ?? 0) - pointsDiscount.value + tax.value)
+    return Math.max(0, (subtotal.value?.units ?? 0) - pointsDiscount.value + tax.value) + shippingFee.value + codFee.value
   })
 
+  // 送料 as order-service will charge it: quoted for the address and slot,
+  // or the pickup point, on the items with tax, before points
+  async function refreshShippingFee() {
+    if (!selectedAddress.value) {
+      shippingFee.value = 0
+      estimatedDelivery.value = null
+      return
+    }
+    const destination = selectedPickupPoint.value ?? selectedAddress.value
+    const itemsTotal = subtotal.value?.units ?? 0
+    try {
+      const quote = await deliveryApi.quoteShipping({
+        postal_code: destination.postal_code,
+        prefecture: destination.prefecture,
+        delivery_slot_id: selectedDeliverySlot.value?.id || undefined,
+        order_subtotal: itemsTotal + Math.round(itemsTotal * TAX_RATE),
+      })
+      shippingFee.value = quote.total?.units ?? 0
+      estimatedDelivery.value = quote.estimated_delivery_at ?? null
+    } catch (e: unknown) {
+      shippingFee.value = 0
+      estimatedDelivery.value = null
+      error.value = `Shipping could not be calculated: ${(e as Error).message}`
+    }
+  }
+
   function setAddress(addr: ShippingAddress, addressId: string) {
     selectedAddress.value = addr
     selectedAddressId.value = addressId
     step.value = 2
+    refreshShippingFee()
   }
 
   function setDeliverySlot(slot: DeliverySlot) {
     selectedDeliverySlot.value = slot
+    selectedPickupPoint.value = null
+    step.value = 3
+    refreshShippingFee()
+  }
+
+  // Pickup orders have no delivery slot and cannot be paid on delivery
+  function setPickupPoint(point: PickupPoint) {
+    selectedPickupPoint.value = point
+    selectedDeliverySlot.value = null
+    if (selectedPaymentMethod.value === PaymentMethod.CASH_ON_DELIVERY) {
+      selectedPaymentMethod.value = null
+    }
     step.value = 3
+    refreshShippingFee()
   }
 
   function setPaymentMethod(method: PaymentMethod) {
@@ -64,7 +122,7 @@ export const useCheckoutStore = defineStore('checkout', () => {
     pointsToApply.value = points
   }
 
-  async function placeOrder(cardData?: { cardNumber: string; cardExpiry: string; cardCvv: string }) {
+  async function placeOrder(cardData?: CardDetails) {
     const cartStore = useCartStore()
     const authStore = useAuthStore()
 
@@ -102,6 +160,7 @@ export const useCheckoutStore = defineStore('checkout', () => {
         payment_method: selectedPaymentMethod.value,
         points_to_apply: pointsToApply.value > 0 ? String(pointsToApply.value) : undefined,
         delivery_slot_id: selectedDeliverySlot.value?.id || undefined,
+        pickup_point_id: selectedPickupPoint.value?.id || undefined,
       })
       orderId = orderRes.order_id
       orderNumber = orderRes.order_number
@@ -163,9 +222,17 @@ export const useCheckoutStore = defineStore('checkout', () => {
       try {
         const paymentData: Record<string, string> = {}
         if (cardData && selectedPaymentMethod.value === PaymentMethod.CREDIT_CARD) {
-          paymentData.card_number = cardData.cardNumber
-          paymentData.expiry = cardData.cardExpiry
-          paymentData.cvv = cardData.cardCvv
+          const card = await tokenizeCard(cardData)
+          paymentData.card_token = card.token
+        }
+        if (selectedPaymentMethod.value === PaymentMethod.DEFERRED) {
+          // The deferred payment provider's credit check needs the buyer's details
+          const addr = selectedAddress.value
+          paymentData.buyer_name = addr.name
+          paymentData.buyer_phone = addr.phone
+          paymentData.buyer_email = authStore.user?.email ?? ''
+          paymentData.postal_code = addr.postal_code
+          paymentData.address = [addr.prefecture, addr.city, addr.address_line1, addr.address_line2].filter(Boolean).join(' ')
         }
 
         const processRes = await paymentsApi.processPayment(paymentId, { payment_data: paymentData })
@@ -173,7 +240,7 @@ export const useCheckoutStore = defineStore('checkout', () => {
           ? processRes.status
           : paymentStatusFromString(processRes.status)
 
-        if (paymentStatus === PaymentStatus.COMPLETED) {
+        if (paymentStatus === PaymentStatus.COMPLETED || paymentStatus === PaymentStatus.AUTHORIZED) {
           loadingStep.value = 'Confirming order...'
           finalStatus = OrderStatus.CONFIRMED
           try {
@@ -184,7 +251,9 @@ export const useCheckoutStore = defineStore('checkout', () => {
           finalStatus = OrderStatus.PENDING
         } else if (paymentStatus === PaymentStatus.FAILED) {
           await rollbackOrder(orderId, reservationId, paymentId)
-          error.value = 'Payment was declined. Please try a different payment method.'
+          error.value = selectedPaymentMethod.value === PaymentMethod.DEFERRED
+            ? 'Pay later is not available for this order. Please choose a different payment method.'
+            : 'Payment was declined. Please try a different payment method.'
           return null
         }
       } catch (procErr) {
@@ -229,8 +298,11 @@ export const useCheckoutStore = defineStore('checkout', () => {
     selectedAddress.value = null
     selectedAddressId.value = null
     selectedDeliverySlot.value = null
+    selectedPickupPoint.value = null
     selectedPaymentMethod.value = null
     pointsToApply.value = 0
+    shippingFee.value = 0
+    estimatedDelivery.value = null
     lastOrderId.value = null
     lastOrderNumber.value = null
     loading.value = false
@@ -239,10 +311,10 @@ export const useCheckoutStore = defineStore('checkout', () => {
   }
 
   return {
-    step, selectedAddress, selectedAddressId, selectedDeliverySlot,
+    step, selectedAddress, selectedAddressId, selectedDeliverySlot, selectedPickupPoint,
     selectedPaymentMethod, pointsToApply, lastOrderId, lastOrderNumber,
-    loading, loadingStep, error, subtotal, pointsDiscount, tax, total,
-    setAddress, setDeliverySlot, setPaymentMethod, setPoints, placeOrder, reset,
+    loading, loadingStep, error, subtotal, pointsDiscount, tax, shippingFee, estimatedDelivery, codFee, total,
+    setAddress, setDeliverySlot, setPickupPoint, setPaymentMethod, setPoints, placeOrder, reset,
   }
 })
 
@@ -255,6 +327,10 @@ function paymentStatusFromString(s: string): PaymentStatus {
     PAYMENT_STATUS_FAILED: PaymentStatus.FAILED,
     PAYMENT_STATUS_CANCELLED: PaymentStatus.CANCELLED,
     PAYMENT_STATUS_REFUNDED: PaymentStatus.REFUNDED,
+    PAYMENT_STATUS_AUTHORIZED: PaymentStatus.AUTHORIZED,
+    PAYMENT_STATUS_CAPTURED: PaymentStatus.CAPTURED,
+    PAYMENT_STATUS_PARTIALLY_REFUNDED: PaymentStatus.PARTIALLY_REFUNDED,
+    PAYMENT_STATUS_EXPIRED: PaymentStatus.EXPIRED,
   }
   return map[s] ?? PaymentStatus.UNSPECIFIED
 }
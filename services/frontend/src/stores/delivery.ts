@@ -1,10 +1,11 @@
 import { defineStore } from 'pinia'
 import { ref } from 'vue'
-import type { DeliverySlot, Shipment, ShipmentStatus } from '@/types'
+import type { DeliverySlot, PickupPoint, Shipment, ShipmentStatus } from '@/types'
 import * as deliveryApi from '@/api/delivery'
 
 export const useDeliveryStore = defineStore('delivery', () => {
   const slots = ref<DeliverySlot[]>([])
+  const pickupPoints = ref<PickupPoint[]>([])
   const currentShipment = ref<Shipment | null>(null)
   const loading = ref(false)
   const error = ref<string | null>(null)
@@ -22,6 +23,19 @@ export const useDeliveryStore = defineStore('delivery', () => {
     }
   }
 
+  async function fetchPickupPoints(postalCode: string, type?: string) {
+    loading.value = true
+    error.value = null
+    try {
+      pickupPoints.value = await deliveryApi.listPickupPoints({ postal_code: postalCode, type })
+    } catch (e: unknown) {
+      error.value = (e as Error).message
+      pickupPoints.value = []
+    } finally {
+      loading.value = false
+    }
+  }
+
   async function reserveSlot(slotId: string, orderId: string) {
     return await deliveryApi.reserveDeliverySlot({ slot_id: slotId, order_id: orderId })
   }
@@ -38,6 +52,19 @@ export const useDeliveryStore = defineStore('delivery', () => {
     }
   }
 
+  async function fetchOrderShipment(orderId: string) {
+    loading.value = true
+    error.value = null
+    try {
+      currentShipment.value = await deliveryApi.getOrderShipment(orderId)
+    } catch (e: unknown) {
+      error.value = (e as Error).message
+      currentShipment.value = null
+    } finally {
+      loading.value = false
+    }
+  }
+
   async function updateShipmentStatus(shipmentId: string, status: ShipmentStatus, description: string) {
     await deliveryApi.updateShipmentStatus(shipmentId, { status, description })
     if (currentShipment.value && currentShipment.value.id === shipmentId) {
@@ -46,7 +73,7 @@ export const useDeliveryStore = defineStore('delivery', () => {
   }
 
   return {
-    slots, currentShipment, loading, error,
-    fetchSlots, reserveSlot, fetchShipment, updateShipmentStatus,
+    slots, pickupPoints, currentShipment, loading, error,
+    fetchSlots, fetchPickupPoints, reserveSlot, fetchShipment, fetchOrderShipment, updateShipmentStatus,
   }
 })
@@ -1,4 +1,4 @@
-import type { Pagination } from './common'
+import type { Money, Pagination } from './common'
 
 export enum ShipmentStatus {
   UNSPECIFIED = 0,
@@ -8,6 +8,37 @@ export enum ShipmentStatus {
   DELIVERED = 4,
   CANCELLED = 5,
   FAILED_DELIVERY = 6,
+  // Waiting at a konbini or locker for the customer to collect
+  READY_FOR_PICKUP = 7,
+  PICKED_UP = 8,
+  // Not collected before the pickup deadline and sent back
+  RETURNED = 9,
+}
+
+export enum PickupPointType {
+  UNSPECIFIED = 0,
+  // コンビニ受取
+  KONBINI = 1,
+  LOCKER = 2,
+}
+
+// Carrier time windows (時間帯指定); MORNING is 午前中
+export enum DeliveryTimeWindow {
+  UNSPECIFIED = 0,
+  MORNING = 1,
+  WINDOW_14_16 = 2,
+  WINDOW_16_18 = 3,
+  WINDOW_18_20 = 4,
+  WINDOW_19_21 = 5,
+}
+
+// クール便 is COOL_CHILLED (refrigerated) or COOL_FROZEN
+export enum ServiceLevel {
+  UNSPECIFIED = 0,
+  STANDARD = 1,
+  EXPRESS = 2,
+  COOL_CHILLED = 3,
+  COOL_FROZEN = 4,
 }
 
 export interface DeliverySlot {
@@ -19,6 +50,8 @@ export interface DeliverySlot {
   reserved: number
   available: number
   date: string
+  time_window?: DeliveryTimeWindow
+  cutoff_at?: string
 }
 
 export interface DeliveryZone {
@@ -27,6 +60,9 @@ export interface DeliveryZone {
   postal_codes: string[]
   prefectures: string[]
   delivery_days: number
+  undeliverable?: boolean
+  remote_island?: boolean
+  carrier?: string
 }
 
 export interface TrackingEvent {
@@ -46,6 +82,110 @@ export interface Shipment {
   actual_delivery_at: string
   carrier: string
   tracking_events: TrackingEvent[]
+  service_level?: ServiceLevel
+  recipient?: ShipmentAddress
+  dispatched_at?: string
+  pickup_point_id?: string
+  // Shown to the store or locker to collect the parcel
+  pickup_code?: string
+  pickup_deadline_at?: string
+}
+
+export interface PickupPoint {
+  id: string
+  code: string
+  name: string
+  type: PickupPointType
+  operator: string
+  postal_code: string
+  prefecture: string
+  city: string
+  address_line1: string
+  address_line2?: string
+  opening_hours?: string
+  hold_days?: number
+  active?: boolean
+}
+
+export interface ListPickupPointsParams {
+  postal_code?: string
+  // By name, e.g. 'KONBINI' or 'LOCKER'
+  type?: string
+  limit?: number
+}
+
+export interface ShipmentAddress {
+  name: string
+  phone: string
+  postal_code: string
+  prefecture: string
+  city: string
+  address_line1: string
+  address_line2?: string
+}
+
+export interface DispatchShipmentRequest {
+  order_id: string
+  // By name, e.g. 'COOL_FROZEN'; defaults to 'STANDARD'
+  service_level?: string
+  recipient: ShipmentAddress
+  carrier?: string
+  // Ships to the pickup point, keeping only the recipient's name and phone
+  pickup_point_id?: string
+}
+
+export interface ValidateTrackingNumberResponse {
+  valid: boolean
+  tracking_number?: string
+  reason?: string
+}
+
+export interface Parcel {
+  // 60, 80, 100, 120, 140 or 160; or give the dimensions instead
+  size_class?: number
+  length_cm?: number
+  width_cm?: number
+  height_cm?: number
+  weight_grams?: number
+}
+
+export interface ParcelRate {
+  size_class: number
+  base_amount: Money
+  cool_surcharge: Money
+}
+
+export interface ShippingQuote {
+  parcels: ParcelRate[]
+  base_amount: Money
+  cool_surcharge: Money
+  express_surcharge: Money
+  time_window_fee: Money
+  remote_island_fee: Money
+  discount: Money
+  total: Money
+  free_shipping?: boolean
+  free_shipping_remaining: Money
+  origin_prefecture: string
+  destination_prefecture: string
+  estimated_delivery_at?: string
+}
+
+export interface QuoteShippingRequest {
+  postal_code?: string
+  prefecture: string
+  parcels?: Parcel[]
+  // By name, e.g. 'COOL_FROZEN' and '18_20'
+  service_level?: string
+  time_window?: string
+  delivery_slot_id?: string
+  // In yen
+  order_subtotal?: number
+}
+
+export interface GetShippingLabelsParams {
+  date?: string
+  carrier?: string
 }
 
 export interface GetDeliverySlotsParams {
@@ -53,6 +193,28 @@ export interface GetDeliverySlotsParams {
   date?: string
 }
 
+export interface ResolveDeliveryZoneParams {
+  postal_code: string
+  prefecture: string
+}
+
+export interface ResolveDeliveryZoneResponse {
+  zone?: DeliveryZone
+  postal_code: string
+  prefecture: string
+  deliverable?: boolean
+  remote_island?: boolean
+}
+
+export interface GetDeliverySlotsForAddressParams extends ResolveDeliveryZoneParams {
+  date?: string
+}
+
+export interface GetDeliverySlotsForAddressResponse {
+  zone: ResolveDeliveryZoneResponse
+  slots: DeliverySlot[]
+}
+
 export interface ReserveDeliverySlotRequest {
   slot_id: string
   order_id: string
@@ -2,6 +2,6 @@ export { type Money, type Pagination, type ApiError, type PaginatedResponse } fr
 export { type Product, type ProductVariant, type Category, type ListProductsParams, type SearchProductsParams, type ListProductsResponse, type CreateProductRequest, type UpdateProductRequest } from './product'
 export { type User, type Address, type RegisterRequest, type LoginRequest, type AuthResponse, type UpdateUserRequest, type AddAddressRequest, type UpdateAddressRequest } from './user'
 export { OrderStatus, PaymentMethod, type ShippingAddress, type OrderItem, type Order, type CreateOrderRequest, type CreateOrderItem, type CreateOrderResponse, type ListOrdersParams, type ListOrdersResponse, type CartItem, type CartSummary } from './order'
-export { PaymentStatus, type Payment, type CreatePaymentRequest, type CreatePaymentResponse, type ProcessPaymentRequest, type ProcessPaymentResponse, type RefundPaymentRequest, type PointBalance, type PointTransaction } from './payment'
+export { PaymentStatus, type Payment, type CreatePaymentRequest, type CreatePaymentResponse, type ProcessPaymentRequest, type ProcessPaymentResponse, type RefundPaymentRequest, type SavedPaymentMethod, type SavePaymentMethodRequest, RefundDestination, GiftCardType, GiftCardStatus, type GiftCard, type CheckGiftCardBalanceRequest, type PointBalance, type PointTransaction } from './payment'
 export { MovementType, type StockItem, type StockMovement, type GetStockParams, type UpdateStockRequest, type ReserveStockRequest, type StockReservationItem, type ReserveStockResponse, type ReleaseStockRequest, type StockMovementsResponse } from './inventory'
-export { ShipmentStatus, type DeliverySlot, type DeliveryZone, type TrackingEvent, type Shipment, type GetDeliverySlotsParams, type ReserveDeliverySlotRequest, type ReserveDeliverySlotResponse, type UpdateShipmentStatusRequest } from './delivery'
+export { ShipmentStatus, DeliveryTimeWindow, ServiceLevel, type DeliverySlot, type DeliveryZone, type TrackingEvent, type Shipment, type ShipmentAddress, type DispatchShipmentRequest, type ValidateTrackingNumberResponse, type Parcel, type ParcelRate, type ShippingQuote, type QuoteShippingRequest, type GetShippingLabelsParams, type GetDeliverySlotsParams, type ResolveDeliveryZoneParams, type ResolveDeliveryZoneResponse, type GetDeliverySlotsForAddressParams, type GetDeliverySlotsForAddressResponse, type ReserveDeliverySlotRequest, type ReserveDeliverySlotResponse, type UpdateShipmentStatusRequest } from './delivery'
@@ -24,6 +24,11 @@ export enum PaymentMethod {
   KONBINI_FAMILYMART = 4,
   PAYPAY = 5,
   RAKUTEN_PAY = 6,
+  BANK_TRANSFER = 7,
+  CASH_ON_DELIVERY = 8,
+  DEFERRED = 9,
+  POINTS = 10,
+  GIFT_CARD = 11,
 }
 
 export interface ShippingAddress {
@@ -55,6 +60,9 @@ export interface Order {
   tax_amount: Money
   discount_amount: Money
   total_amount: Money
+  cod_fee?: Money
+  shipping_fee?: Money
+  disputed?: boolean
   points_applied: number
   shipping_address: ShippingAddress
   payment_method: PaymentMethod
@@ -62,6 +70,7 @@ export interface Order {
   updated_at: string
   delivery_slot_id?: string
   estimated_delivery_at?: string
+  pickup_point_id?: string
   items: OrderItem[]
 }
 
@@ -72,6 +81,7 @@ export interface CreateOrderRequest {
   payment_method: PaymentMethod
   points_to_apply?: string
   delivery_slot_id?: string
+  pickup_point_id?: string
 }
 
 export interface CreateOrderItem {
@@ -9,6 +9,11 @@ export enum PaymentStatus {
   FAILED = 4,
   CANCELLED = 5,
   REFUNDED = 6,
+  AUTHORIZED = 7,
+  CAPTURED = 8,
+  PARTIALLY_REFUNDED = 9,
+  EXPIRED = 10,
+  CHARGED_BACK = 11,
 }
 
 export interface Payment {
@@ -20,6 +25,8 @@ export interface Payment {
   created_at: string
   updated_at: string
   transaction_id: string
+  authorization_expires_at?: string
+  captured_at?: string
 }
 
 export interface CreatePaymentRequest {
@@ -42,8 +49,71 @@ export interface ProcessPaymentResponse {
   transaction_id: string
 }
 
+export interface SavedPaymentMethod {
+  id: string
+  user_id: string
+  provider: string
+  brand: string
+  last4: string
+  exp_month: number
+  exp_year: number
+  is_default: boolean
+  expiring_soon: boolean
+  expired: boolean
+  last_used_at?: string
+  created_at: string
+}
+
+export interface SavePaymentMethodRequest {
+  provider?: string
+  provider_token: string
+  brand: string
+  last4: string
+  exp_month: number
+  exp_year: number
+  make_default?: boolean
+}
+
+export enum RefundDestination {
+  UNSPECIFIED = 0,
+  ORIGINAL = 1,
+  STORE_CREDIT = 2,
+}
+
 export interface RefundPaymentRequest {
   amount: Money
+  reason?: string
+  destination?: RefundDestination
+}
+
+export enum GiftCardType {
+  UNSPECIFIED = 0,
+  GIFT_CARD = 1,
+  STORE_CREDIT = 2,
+}
+
+export enum GiftCardStatus {
+  UNSPECIFIED = 0,
+  ACTIVE = 1,
+  DISABLED = 2,
+  EXPIRED = 3,
+}
+
+export interface GiftCard {
+  id: string
+  type: GiftCardType | string
+  status: GiftCardStatus | string
+  code_last4: string
+  initial_balance: Money
+  balance: Money
+  user_id?: string
+  expires_at: string
+  created_at: string
+  updated_at: string
+}
+
+export interface CheckGiftCardBalanceRequest {
+  code: string
 }
 
 export interface PointBalance {
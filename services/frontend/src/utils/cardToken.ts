@@ -0,0 +1,52 @@
+export interface CardDetails {
+  cardNumber: string
+  cardExpiry: string
+  cardCvv: string
+}
+
+export interface CardToken {
+  token: string
+  brand: string
+  last4: string
+  expMonth: number
+  expYear: number
+}
+
+function cardBrand(digits: string): string {
+  if (digits.startsWith('4')) return 'visa'
+  if (/^(5[1-5]|2[2-7])/.test(digits)) return 'mastercard'
+  if (/^35(2[89]|[3-8])/.test(digits)) return 'jcb'
+  if (/^3[47]/.test(digits)) return 'amex'
+  if (/^3(0[0-5]|[689])/.test(digits)) return 'diners'
+  return 'unknown'
+}
+
+// tokenizeCard stands in for the card provider's browser SDK: the card
+// details are exchanged for a token here, so only the token, brand, last
+// four digits and expiry are ever sent to our API.
+export async function tokenizeCard(card: CardDetails): Promise<CardToken> {
+  const digits = card.cardNumber.replace(/[\s-]/g, '')
+  if (!/^\d{13,19}$/.test(digits)) {
+    throw new Error('Invalid card number')
+  }
+  if (!/^\d{3,4}$/.test(card.cardCvv)) {
+    throw new Error('Invalid security code')
+  }
+  const expiry = card.cardExpiry.match(/^(\d{1,2})\s*\/\s*(\d{2}|\d{4})$/)
+  if (!expiry) {
+    throw new Error('Invalid expiry date')
+  }
+  const expMonth = Number(expiry[1])
+  const expYear = expiry[2].length === 2 ? 2000 + Number(expiry[2]) : Number(expiry[2])
+  if (expMonth < 1 || expMonth > 12) {
+    throw new Error('Invalid expiry date')
+  }
+
+  return {
+    token: `tok_${crypto.randomUUID().replace(/-/g, '')}`,
+    brand: cardBrand(digits),
+    last4: digits.slice(-4),
+    expMonth,
+    expYear,
+  }
+}
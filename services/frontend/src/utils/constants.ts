@@ -33,6 +33,11 @@ export const PAYMENT_STATUS_COLORS: Record<string, string> = {
   [PaymentStatus.FAILED]: 'bg-red-100 text-red-800',
   [PaymentStatus.CANCELLED]: 'bg-gray-100 text-gray-800',
   [PaymentStatus.REFUNDED]: 'bg-purple-100 text-purple-800',
+  [PaymentStatus.AUTHORIZED]: 'bg-indigo-100 text-indigo-800',
+  [PaymentStatus.CAPTURED]: 'bg-green-100 text-green-800',
+  [PaymentStatus.PARTIALLY_REFUNDED]: 'bg-purple-100 text-purple-800',
+  [PaymentStatus.EXPIRED]: 'bg-gray-100 text-gray-800',
+  [PaymentStatus.CHARGED_BACK]: 'bg-red-100 text-red-800',
 }
 
 export const SHIPMENT_STATUS_COLORS: Record<string, string> = {
@@ -42,6 +47,9 @@ export const SHIPMENT_STATUS_COLORS: Record<string, string> = {
   [ShipmentStatus.DELIVERED]: 'bg-green-100 text-green-800',
   [ShipmentStatus.CANCELLED]: 'bg-red-100 text-red-800',
   [ShipmentStatus.FAILED_DELIVERY]: 'bg-orange-100 text-orange-800',
+  [ShipmentStatus.READY_FOR_PICKUP]: 'bg-teal-100 text-teal-800',
+  [ShipmentStatus.PICKED_UP]: 'bg-emerald-100 text-emerald-800',
+  [ShipmentStatus.RETURNED]: 'bg-pink-100 text-pink-800',
 }
 
 export const ORDER_STATUS_TRANSITIONS: Record<string, OrderStatus[]> = {
@@ -49,7 +57,7 @@ export const ORDER_STATUS_TRANSITIONS: Record<string, OrderStatus[]> = {
   [OrderStatus.CONFIRMED]: [OrderStatus.PROCESSING, OrderStatus.CANCELLED],
   [OrderStatus.PROCESSING]: [OrderStatus.SHIPPED, OrderStatus.READY_FOR_PICKUP],
   [OrderStatus.SHIPPED]: [OrderStatus.IN_TRANSIT],
-  [OrderStatus.IN_TRANSIT]: [OrderStatus.DELIVERED, OrderStatus.FAILED_DELIVERY],
+  [OrderStatus.IN_TRANSIT]: [OrderStatus.DELIVERED, OrderStatus.FAILED_DELIVERY, OrderStatus.READY_FOR_PICKUP],
   [OrderStatus.READY_FOR_PICKUP]: [OrderStatus.PICKED_UP, OrderStatus.CANCELLED],
   [OrderStatus.PICKED_UP]: [OrderStatus.DELIVERED],
   [OrderStatus.FAILED_DELIVERY]: [OrderStatus.RETURNED, OrderStatus.DELIVERED],
@@ -94,6 +102,11 @@ export const PAYMENT_METHOD_LABELS: Record<number, string> = {
   [PaymentMethod.KONBINI_FAMILYMART]: 'FamilyMart',
   [PaymentMethod.PAYPAY]: 'PayPay',
   [PaymentMethod.RAKUTEN_PAY]: 'Rakuten Pay',
+  [PaymentMethod.BANK_TRANSFER]: 'Bank Transfer',
+  [PaymentMethod.CASH_ON_DELIVERY]: 'Cash on Delivery',
+  [PaymentMethod.DEFERRED]: 'Pay Later',
+  [PaymentMethod.POINTS]: 'Points',
+  [PaymentMethod.GIFT_CARD]: 'Gift Card',
 }
 
 export const PAYMENT_STATUS_LABELS: Record<number, string> = {
@@ -103,6 +116,11 @@ export const PAYMENT_STATUS_LABELS: Record<number, string> = {
   [PaymentStatus.FAILED]: 'Failed',
   [PaymentStatus.CANCELLED]: 'Cancelled',
   [PaymentStatus.REFUNDED]: 'Refunded',
+  [PaymentStatus.AUTHORIZED]: 'Authorized',
+  [PaymentStatus.CAPTURED]: 'Captured',
+  [PaymentStatus.PARTIALLY_REFUNDED]: 'Partially refunded',
+  [PaymentStatus.EXPIRED]: 'Expired',
+  [PaymentStatus.CHARGED_BACK]: 'Charged back',
 }
 
 export const SHIPMENT_STATUS_LABELS: Record<number, string> = {
@@ -112,6 +130,9 @@ export const SHIPMENT_STATUS_LABELS: Record<number, string> = {
   [ShipmentStatus.DELIVERED]: 'Delivered',
   [ShipmentStatus.CANCELLED]: 'Cancelled',
   [ShipmentStatus.FAILED_DELIVERY]: 'Failed Delivery',
+  [ShipmentStatus.READY_FOR_PICKUP]: 'Ready for Pickup',
+  [ShipmentStatus.PICKED_UP]: 'Picked Up',
+  [ShipmentStatus.RETURNED]: 'Returned',
 }
 
 export const MOVEMENT_TYPE_LABELS: Record<number, string> = {
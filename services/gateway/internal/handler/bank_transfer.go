@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+)
+
+// maxBankDepositFileSize bounds uploaded deposit files. Zengin records are
+// 200 bytes, so this allows for tens of thousands of deposits.
+const maxBankDepositFileSize = 10 << 20
+
+func (h *PaymentHandler) registerBankTransferHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/bank-deposits", h.handleBankDeposits)
+	mux.HandleFunc("/v1/bank-deposits/import", h.handleImportBankDeposits)
+}
+
+func (h *PaymentHandler) getBankTransferInstructions(w http.ResponseWriter, r *http.Request, ctx context.Context, paymentID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.GetBankTransferInstructions(ctx, &paymentpb.GetBankTransferInstructionsRequest{PaymentId: paymentID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) handleBankDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page := int32(1)
+	limit := int32(50)
+	if p := r.URL.Query().Get("page"); p != "" {
+		if val, err := strconv.ParseInt(p, 10, 32); err == nil {
+			page = int32(val)
+		}
+	}
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	req := &paymentpb.ListBankDepositsRequest{
+		Pagination: &sharedpb.Pagination{
+			Page:  page,
+			Limit: limit,
+		},
+	}
+	if m := r.URL.Query().Get("match"); m != "" {
+		v, ok := paymentpb.BankDepositMatch_value["BANK_DEPOSIT_MATCH_"+strings.ToUpper(m)]
+		if !ok {
+			http.Error(w, "Invalid match", http.StatusBadRequest)
+			return
+		}
+		req.Match = paymentpb.BankDepositMatch(v)
+	}
+
+	resp, err := h.client.ListBankDeposits(ctx, req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleImportBankDeposits accepts either a JSON body with a base64 encoded
+// content field, or the raw file with its name in the file_name query
+// parameter (curl --data-binary @file).
+func (h *PaymentHandler) handleImportBankDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxBankDepositFileSize)
+
+	var req paymentpb.ImportBankDepositsRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	} else {
+		content, err := io.ReadAll(body)
+		if err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		req.FileName = r.URL.Query().Get("file_name")
+		req.Content = content
+	}
+
+	resp, err := h.client.ImportBankDeposits(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -12,6 +12,15 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// trackingEventBody is the JSON body for adding a tracking event; timestamp
+// is RFC 3339 and defaults to now
+type trackingEventBody struct {
+	Status      string `json:"status"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+	Timestamp   string `json:"timestamp"`
+}
+
 type DeliveryHandler struct {
 	client deliverypb.DeliveryServiceClient
 }
@@ -30,7 +39,15 @@ func validateUUID(id, fieldName string) bool {
 func (h *DeliveryHandler) RegisterHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/v1/delivery/slots", h.handleDeliverySlots)
 	mux.HandleFunc("/v1/delivery/slots/", h.handleDeliverySlotReserve)
+	mux.HandleFunc("/v1/delivery/zones/resolve", h.handleResolveDeliveryZone)
+	mux.HandleFunc("/v1/delivery/address-slots", h.handleDeliverySlotsForAddress)
+	mux.HandleFunc("/v1/delivery/reservations/", h.handleDeliveryReservation)
+	mux.HandleFunc("/v1/shipments", h.handleShipments)
 	mux.HandleFunc("/v1/shipments/", h.handleShipment)
+	h.registerScheduleHandlers(mux)
+	h.registerCarrierHandlers(mux)
+	h.registerShippingHandlers(mux)
+	h.registerPickupHandlers(mux)
 }
 
 func (h *DeliveryHandler) handleDeliverySlots(w http.ResponseWriter, r *http.Request) {
@@ -51,6 +68,12 @@ func (h *DeliveryHandler) handleDeliverySlotReserve(w http.ResponseWriter, r *ht
 
 	slotID := r.URL.Path[len("/v1/delivery/slots/"):]
 	parts := splitPath(slotID)
+	if len(parts) == 1 && parts[0] == "generate" {
+		if r.Method == http.MethodPost {
+			h.generateDeliverySlots(w, r, ctx)
+			return
+		}
+	}
 	if len(parts) > 1 && parts[1] == "reserve" {
 		if r.Method == http.MethodPost {
 			h.reserveDeliverySlotByID(w, r, ctx, parts[0])
@@ -61,17 +84,76 @@ func (h *DeliveryHandler) handleDeliverySlotReserve(w http.ResponseWriter, r *ht
 	http.Error(w, "Not found", http.StatusNotFound)
 }
 
+func (h *DeliveryHandler) handleDeliveryReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	parts := splitPath(r.URL.Path[len("/v1/delivery/reservations/"):])
+	if len(parts) == 0 || !validateUUID(parts[0], "order_id") {
+		http.Error(w, "Invalid UUID format for order_id", http.StatusBadRequest)
+		return
+	}
+	orderID := parts[0]
+
+	switch {
+	case len(parts) == 2 && parts[1] == "confirm" && r.Method == http.MethodPost:
+		resp, err := h.client.ConfirmReservation(ctx, &deliverypb.ConfirmReservationRequest{OrderId: orderID})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		resp, err := h.client.ReleaseDeliverySlot(ctx, &deliverypb.ReleaseDeliverySlotRequest{OrderId: orderID})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
 func (h *DeliveryHandler) handleShipment(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	shipmentID := r.URL.Path[len("/v1/shipments/"):]
 	parts := splitPath(shipmentID)
+	if len(parts) == 1 && parts[0] == "dispatch" && r.Method == http.MethodPost {
+		h.dispatchShipment(w, r, ctx)
+		return
+	}
+	if len(parts) == 1 && parts[0] == "labels" && r.Method == http.MethodGet {
+		h.getShippingLabels(w, r, ctx)
+		return
+	}
+	if len(parts) == 2 && parts[1] == "label" && r.Method == http.MethodGet {
+		h.getShippingLabel(w, r, ctx, parts[0])
+		return
+	}
+	if len(parts) == 2 && parts[1] == "cancel" && r.Method == http.MethodPost {
+		h.cancelShipment(w, r, ctx, parts[0])
+		return
+	}
+	if len(parts) == 2 && parts[1] == "pickup" && r.Method == http.MethodPost {
+		h.confirmPickup(w, r, ctx, parts[0])
+		return
+	}
 	if len(parts) > 1 && parts[1] == "status" {
 		if r.Method == http.MethodPut {
 			h.updateShipmentStatus(w, r, ctx, parts[0])
 			return
 		}
 	}
+	if len(parts) > 1 && parts[1] == "tracking-events" {
+		h.handleTrackingEvents(w, r, ctx, parts[0])
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
@@ -85,6 +167,87 @@ func (h *DeliveryHandler) handleShipment(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// handleShipments looks up an order's shipment, with its tracking timeline,
+// for order tracking pages
+func (h *DeliveryHandler) handleShipments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !isAuthenticated(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	orderID := r.URL.Query().Get("order_id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+	if !validateUUID(orderID, "order_id") {
+		http.Error(w, "Invalid UUID format for order_id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.GetShipment(r.Context(), &deliverypb.GetShipmentRequest{OrderId: orderID})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) handleTrackingEvents(w http.ResponseWriter, r *http.Request, ctx context.Context, shipmentID string) {
+	if !validateUUID(shipmentID, "shipment_id") {
+		http.Error(w, "Invalid UUID format for shipment_id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := h.client.ListTrackingEvents(ctx, &deliverypb.ListTrackingEventsRequest{ShipmentId: shipmentID})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		if !isAdmin(r) {
+			http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+			return
+		}
+		var body trackingEventBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		req := &deliverypb.AddTrackingEventRequest{
+			ShipmentId:  shipmentID,
+			Status:      body.Status,
+			Location:    body.Location,
+			Description: body.Description,
+		}
+		if body.Timestamp != "" {
+			timestamp, err := time.Parse(time.RFC3339, body.Timestamp)
+			if err != nil {
+				http.Error(w, "Invalid timestamp format. Use RFC 3339", http.StatusBadRequest)
+				return
+			}
+			req.Timestamp = timestamppb.New(timestamp)
+		}
+
+		resp, err := h.client.AddTrackingEvent(ctx, req)
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusCreated, resp)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 func (h *DeliveryHandler) getDeliverySlots(w http.ResponseWriter, r *http.Request, ctx context.Context) {
 	deliveryZoneID := r.URL.Query().Get("delivery_zone_id")
 	if deliveryZoneID == "" {
@@ -122,6 +285,65 @@ func (h *DeliveryHandler) getDeliverySlots(w http.ResponseWriter, r *http.Reques
 	respondJSON(w, http.StatusOK, resp)
 }
 
+func (h *DeliveryHandler) handleResolveDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("postal_code") == "" || query.Get("prefecture") == "" {
+		http.Error(w, "postal_code and prefecture are required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.ResolveDeliveryZone(r.Context(), &deliverypb.ResolveDeliveryZoneRequest{
+		PostalCode: query.Get("postal_code"),
+		Prefecture: query.Get("prefecture"),
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) handleDeliverySlotsForAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("postal_code") == "" || query.Get("prefecture") == "" {
+		http.Error(w, "postal_code and prefecture are required", http.StatusBadRequest)
+		return
+	}
+
+	date := time.Now()
+	if dateStr := query.Get("date"); dateStr != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			http.Error(w, "Invalid date format. Use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.client.GetDeliverySlotsForAddress(r.Context(), &deliverypb.GetDeliverySlotsForAddressRequest{
+		PostalCode: query.Get("postal_code"),
+		Prefecture: query.Get("prefecture"),
+		Date:       timestamppb.New(date),
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
 func (h *DeliveryHandler) reserveDeliverySlot(w http.ResponseWriter, r *http.Request, ctx context.Context) {
 	var req deliverypb.ReserveDeliverySlotRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// dispatchShipmentBody is the JSON body for dispatching a shipment.
+// service_level is given by name, e.g. "STANDARD" or "COOL_FROZEN".
+type dispatchShipmentBody struct {
+	OrderID       string                      `json:"order_id"`
+	ServiceLevel  string                      `json:"service_level"`
+	Recipient     *deliverypb.ShipmentAddress `json:"recipient"`
+	Carrier       string                      `json:"carrier"`
+	PickupPointID string                      `json:"pickup_point_id"`
+}
+
+// cancelShipmentBody is the optional JSON body for cancelling a shipment
+type cancelShipmentBody struct {
+	Reason string `json:"reason"`
+}
+
+func (h *DeliveryHandler) registerCarrierHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/carriers/", h.handleCarrier)
+}
+
+// handleCarrier serves GET /v1/carriers/{carrier}/tracking-numbers/{number}
+func (h *DeliveryHandler) handleCarrier(w http.ResponseWriter, r *http.Request) {
+	parts := splitPath(r.URL.Path[len("/v1/carriers/"):])
+	if len(parts) != 3 || parts[1] != "tracking-numbers" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.ValidateTrackingNumber(r.Context(), &deliverypb.ValidateTrackingNumberRequest{
+		Carrier:        parts[0],
+		TrackingNumber: parts[2],
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) dispatchShipment(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	var body dispatchShipmentBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if !validateUUID(body.OrderID, "order_id") {
+		http.Error(w, "Invalid UUID format for order_id", http.StatusBadRequest)
+		return
+	}
+	req := &deliverypb.DispatchShipmentRequest{
+		OrderId:       body.OrderID,
+		Recipient:     body.Recipient,
+		Carrier:       body.Carrier,
+		PickupPointId: body.PickupPointID,
+	}
+	if body.ServiceLevel != "" {
+		level, ok := deliverypb.ServiceLevel_value["SERVICE_LEVEL_"+strings.ToUpper(body.ServiceLevel)]
+		if !ok {
+			http.Error(w, "Invalid service_level", http.StatusBadRequest)
+			return
+		}
+		req.ServiceLevel = deliverypb.ServiceLevel(level)
+	}
+
+	resp, err := h.client.DispatchShipment(ctx, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *DeliveryHandler) cancelShipment(w http.ResponseWriter, r *http.Request, ctx context.Context, shipmentID string) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if !validateUUID(shipmentID, "shipment_id") {
+		http.Error(w, "Invalid UUID format for shipment_id", http.StatusBadRequest)
+		return
+	}
+
+	var body cancelShipmentBody
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.client.CancelShipment(ctx, &deliverypb.CancelShipmentRequest{
+		ShipmentId: shipmentID,
+		Reason:     body.Reason,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
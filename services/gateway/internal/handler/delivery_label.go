@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// getShippingLabel serves GET /v1/shipments/{id}/label
+func (h *DeliveryHandler) getShippingLabel(w http.ResponseWriter, r *http.Request, ctx context.Context, shipmentID string) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if !validateUUID(shipmentID, "shipment_id") {
+		http.Error(w, "Invalid UUID format for shipment_id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.GetShippingLabel(ctx, &deliverypb.GetShippingLabelRequest{ShipmentId: shipmentID})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondPDF(w, resp.Filename, resp.Pdf)
+}
+
+// getShippingLabels serves GET /v1/shipments/labels?date=YYYY-MM-DD&carrier=
+// with the labels of a day's shipments in one PDF
+func (h *DeliveryHandler) getShippingLabels(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	req := &deliverypb.GetShippingLabelsRequest{Carrier: query.Get("carrier")}
+	if dateStr := query.Get("date"); dateStr != "" {
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			http.Error(w, "Invalid date format. Use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		req.Date = timestamppb.New(date)
+	}
+
+	resp, err := h.client.GetShippingLabels(ctx, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.Header().Set("X-Label-Count", strconv.Itoa(int(resp.LabelCount)))
+	respondPDF(w, resp.Filename, resp.Pdf)
+}
+
+// respondPDF writes a PDF as a download
+func respondPDF(w http.ResponseWriter, filename string, pdf []byte) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdf)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+)
+
+// pickupPointBody is the JSON body for creating or updating a pickup point.
+// type is given by name, e.g. "KONBINI" or "LOCKER".
+type pickupPointBody struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Operator     string `json:"operator"`
+	PostalCode   string `json:"postal_code"`
+	Prefecture   string `json:"prefecture"`
+	City         string `json:"city"`
+	AddressLine1 string `json:"address_line1"`
+	AddressLine2 string `json:"address_line2"`
+	OpeningHours string `json:"opening_hours"`
+	HoldDays     int32  `json:"hold_days"`
+	Active       bool   `json:"active"`
+}
+
+// confirmPickupBody is the JSON body for handing a parcel over at its
+// pickup point
+type confirmPickupBody struct {
+	PickupCode string `json:"pickup_code"`
+}
+
+func (h *DeliveryHandler) registerPickupHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/delivery/pickup-points", h.handlePickupPoints)
+	mux.HandleFunc("/v1/delivery/pickup-points/", h.handlePickupPoint)
+}
+
+// handlePickupPoints serves GET /v1/delivery/pickup-points, the public
+// search customers choose a pickup point from at checkout, and the admin
+// POST that adds a point
+func (h *DeliveryHandler) handlePickupPoints(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listPickupPoints(w, r)
+	case http.MethodPost:
+		if !isAdmin(r) {
+			http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+			return
+		}
+		point, ok := decodePickupPoint(w, r)
+		if !ok {
+			return
+		}
+
+		resp, err := h.client.CreatePickupPoint(r.Context(), &deliverypb.CreatePickupPointRequest{PickupPoint: point})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusCreated, resp)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *DeliveryHandler) listPickupPoints(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &deliverypb.ListPickupPointsRequest{PostalCode: query.Get("postal_code")}
+	if t := query.Get("type"); t != "" {
+		pointType, ok := deliverypb.PickupPointType_value["PICKUP_POINT_TYPE_"+strings.ToUpper(t)]
+		if !ok {
+			http.Error(w, "Invalid type", http.StatusBadRequest)
+			return
+		}
+		req.Type = deliverypb.PickupPointType(pointType)
+	}
+	if l := query.Get("limit"); l != "" {
+		limit, err := strconv.ParseInt(l, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		req.Limit = int32(limit)
+	}
+
+	resp, err := h.client.ListPickupPoints(r.Context(), req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) handlePickupPoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pointID := r.URL.Path[len("/v1/delivery/pickup-points/"):]
+	if !validateUUID(pointID, "id") {
+		http.Error(w, "Invalid UUID format for id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := h.client.GetPickupPoint(ctx, &deliverypb.GetPickupPointRequest{Id: pointID})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	case http.MethodPut:
+		if !isAdmin(r) {
+			http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+			return
+		}
+		point, ok := decodePickupPoint(w, r)
+		if !ok {
+			return
+		}
+		point.Id = pointID
+
+		resp, err := h.client.UpdatePickupPoint(ctx, &deliverypb.UpdatePickupPointRequest{PickupPoint: point})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// confirmPickup serves POST /v1/shipments/{id}/pickup, recorded by store or
+// locker staff when the customer collects the parcel with its pickup code
+func (h *DeliveryHandler) confirmPickup(w http.ResponseWriter, r *http.Request, ctx context.Context, shipmentID string) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if !validateUUID(shipmentID, "shipment_id") {
+		http.Error(w, "Invalid UUID format for shipment_id", http.StatusBadRequest)
+		return
+	}
+
+	var body confirmPickupBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.ConfirmPickup(ctx, &deliverypb.ConfirmPickupRequest{
+		ShipmentId: shipmentID,
+		PickupCode: body.PickupCode,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func decodePickupPoint(w http.ResponseWriter, r *http.Request) (*deliverypb.PickupPoint, bool) {
+	var body pickupPointBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	pointType, ok := deliverypb.PickupPointType_value["PICKUP_POINT_TYPE_"+strings.ToUpper(body.Type)]
+	if !ok {
+		http.Error(w, "Invalid type", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &deliverypb.PickupPoint{
+		Code:         body.Code,
+		Name:         body.Name,
+		Type:         deliverypb.PickupPointType(pointType),
+		Operator:     body.Operator,
+		PostalCode:   body.PostalCode,
+		Prefecture:   body.Prefecture,
+		City:         body.City,
+		AddressLine1: body.AddressLine1,
+		AddressLine2: body.AddressLine2,
+		OpeningHours: body.OpeningHours,
+		HoldDays:     body.HoldDays,
+		Active:       body.Active,
+	}, true
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// slotTemplateBody is the JSON body for creating or updating a slot
+// template. time_window is given by name, e.g. "MORNING" or "14_16".
+type slotTemplateBody struct {
+	DeliveryZoneID   string `json:"delivery_zone_id"`
+	Weekday          int32  `json:"weekday"`
+	TimeWindow       string `json:"time_window"`
+	Capacity         int32  `json:"capacity"`
+	CutoffDaysBefore int32  `json:"cutoff_days_before"`
+	CutoffTime       string `json:"cutoff_time"`
+}
+
+// slotBlackoutBody is the JSON body for creating a slot blackout; date is
+// YYYY-MM-DD
+type slotBlackoutBody struct {
+	DeliveryZoneID string `json:"delivery_zone_id"`
+	Date           string `json:"date"`
+	Reason         string `json:"reason"`
+}
+
+// closureDayBody is the JSON body for creating a closure day; date is
+// YYYY-MM-DD
+type closureDayBody struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+func (h *DeliveryHandler) registerScheduleHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/delivery/zones/", h.handleDeliveryZone)
+	mux.HandleFunc("/v1/delivery/slot-templates", h.handleSlotTemplates)
+	mux.HandleFunc("/v1/delivery/slot-templates/", h.handleSlotTemplate)
+	mux.HandleFunc("/v1/delivery/slot-blackouts", h.handleSlotBlackouts)
+	mux.HandleFunc("/v1/delivery/slot-blackouts/", h.handleSlotBlackout)
+	mux.HandleFunc("/v1/delivery/closure-days", h.handleClosureDays)
+	mux.HandleFunc("/v1/delivery/closure-days/", h.handleClosureDay)
+}
+
+func (h *DeliveryHandler) handleDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	parts := splitPath(r.URL.Path[len("/v1/delivery/zones/"):])
+	if len(parts) != 2 || parts[1] != "slot-templates" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !validateUUID(parts[0], "delivery_zone_id") {
+		http.Error(w, "Invalid UUID format for delivery_zone_id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.ListSlotTemplates(r.Context(), &deliverypb.ListSlotTemplatesRequest{
+		DeliveryZoneId: parts[0],
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) handleSlotTemplates(w http.ResponseWriter, r *http.Request) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	template, ok := decodeSlotTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.CreateSlotTemplate(r.Context(), &deliverypb.CreateSlotTemplateRequest{Template: template})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *DeliveryHandler) handleSlotTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	templateID := r.URL.Path[len("/v1/delivery/slot-templates/"):]
+	if !validateUUID(templateID, "id") {
+		http.Error(w, "Invalid UUID format for id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		h.updateSlotTemplate(w, r, ctx, templateID)
+	case http.MethodDelete:
+		resp, err := h.client.DeleteSlotTemplate(ctx, &deliverypb.DeleteSlotTemplateRequest{Id: templateID})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *DeliveryHandler) updateSlotTemplate(w http.ResponseWriter, r *http.Request, ctx context.Context, templateID string) {
+	template, ok := decodeSlotTemplate(w, r)
+	if !ok {
+		return
+	}
+	template.Id = templateID
+
+	resp, err := h.client.UpdateSlotTemplate(ctx, &deliverypb.UpdateSlotTemplateRequest{Template: template})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) handleSlotBlackouts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := h.client.ListSlotBlackouts(ctx, &deliverypb.ListSlotBlackoutsRequest{
+			DeliveryZoneId: r.URL.Query().Get("delivery_zone_id"),
+		})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		h.createSlotBlackout(w, r, ctx)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *DeliveryHandler) createSlotBlackout(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	var body slotBlackoutBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	date, err := time.Parse("2006-01-02", body.Date)
+	if err != nil {
+		http.Error(w, "Invalid date format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.CreateSlotBlackout(ctx, &deliverypb.CreateSlotBlackoutRequest{
+		Blackout: &deliverypb.SlotBlackout{
+			DeliveryZoneId: body.DeliveryZoneID,
+			Date:           timestamppb.New(date),
+			Reason:         body.Reason,
+		},
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *DeliveryHandler) handleSlotBlackout(w http.ResponseWriter, r *http.Request) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	blackoutID := r.URL.Path[len("/v1/delivery/slot-blackouts/"):]
+	if !validateUUID(blackoutID, "id") {
+		http.Error(w, "Invalid UUID format for id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.DeleteSlotBlackout(r.Context(), &deliverypb.DeleteSlotBlackoutRequest{Id: blackoutID})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) handleClosureDays(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := h.client.ListClosureDays(ctx, &deliverypb.ListClosureDaysRequest{})
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		h.createClosureDay(w, r, ctx)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *DeliveryHandler) createClosureDay(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	var body closureDayBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	date, err := time.Parse("2006-01-02", body.Date)
+	if err != nil {
+		http.Error(w, "Invalid date format. Use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.CreateClosureDay(ctx, &deliverypb.CreateClosureDayRequest{
+		ClosureDay: &deliverypb.ClosureDay{
+			Date:   timestamppb.New(date),
+			Reason: body.Reason,
+		},
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *DeliveryHandler) handleClosureDay(w http.ResponseWriter, r *http.Request) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	closureDayID := r.URL.Path[len("/v1/delivery/closure-days/"):]
+	if !validateUUID(closureDayID, "id") {
+		http.Error(w, "Invalid UUID format for id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.DeleteClosureDay(r.Context(), &deliverypb.DeleteClosureDayRequest{Id: closureDayID})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *DeliveryHandler) generateDeliverySlots(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	req := &deliverypb.GenerateDeliverySlotsRequest{
+		DeliveryZoneId: r.URL.Query().Get("delivery_zone_id"),
+	}
+	if d := r.URL.Query().Get("days"); d != "" {
+		days, err := strconv.ParseInt(d, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+		req.Days = int32(days)
+	}
+
+	resp, err := h.client.GenerateDeliverySlots(ctx, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func decodeSlotTemplate(w http.ResponseWriter, r *http.Request) (*deliverypb.SlotTemplate, bool) {
+	var body slotTemplateBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	window, ok := deliverypb.DeliveryTimeWindow_value["DELIVERY_TIME_WINDOW_"+strings.ToUpper(body.TimeWindow)]
+	if !ok {
+		http.Error(w, "Invalid time_window", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &deliverypb.SlotTemplate{
+		DeliveryZoneId:   body.DeliveryZoneID,
+		Weekday:          body.Weekday,
+		TimeWindow:       deliverypb.DeliveryTimeWindow(window),
+		Capacity:         body.Capacity,
+		CutoffDaysBefore: body.CutoffDaysBefore,
+		CutoffTime:       body.CutoffTime,
+	}, true
+}
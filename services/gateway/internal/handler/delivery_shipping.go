@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+)
+
+// quoteShippingBody is the JSON body for a shipping quote. service_level and
+// time_window are given by name, e.g. "COOL_FROZEN" and "18_20";
+// order_subtotal is in yen.
+type quoteShippingBody struct {
+	PostalCode       string               `json:"postal_code"`
+	Prefecture       string               `json:"prefecture"`
+	OriginPrefecture string               `json:"origin_prefecture"`
+	Parcels          []*deliverypb.Parcel `json:"parcels"`
+	ServiceLevel     string               `json:"service_level"`
+	TimeWindow       string               `json:"time_window"`
+	DeliverySlotID   string               `json:"delivery_slot_id"`
+	OrderSubtotal    int64                `json:"order_subtotal"`
+}
+
+func (h *DeliveryHandler) registerShippingHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/shipping/quote", h.quoteShipping)
+}
+
+// quoteShipping serves POST /v1/shipping/quote
+func (h *DeliveryHandler) quoteShipping(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body quoteShippingBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.DeliverySlotID != "" && !validateUUID(body.DeliverySlotID, "delivery_slot_id") {
+		http.Error(w, "Invalid UUID format for delivery_slot_id", http.StatusBadRequest)
+		return
+	}
+	req := &deliverypb.QuoteShippingRequest{
+		PostalCode:       body.PostalCode,
+		Prefecture:       body.Prefecture,
+		OriginPrefecture: body.OriginPrefecture,
+		Parcels:          body.Parcels,
+		DeliverySlotId:   body.DeliverySlotID,
+		OrderSubtotal:    &sharedpb.Money{Units: body.OrderSubtotal, Currency: "JPY"},
+	}
+	if body.ServiceLevel != "" {
+		level, ok := deliverypb.ServiceLevel_value["SERVICE_LEVEL_"+strings.ToUpper(body.ServiceLevel)]
+		if !ok {
+			http.Error(w, "Invalid service_level", http.StatusBadRequest)
+			return
+		}
+		req.ServiceLevel = deliverypb.ServiceLevel(level)
+	}
+	if body.TimeWindow != "" {
+		window, ok := deliverypb.DeliveryTimeWindow_value["DELIVERY_TIME_WINDOW_"+strings.ToUpper(body.TimeWindow)]
+		if !ok {
+			http.Error(w, "Invalid time_window", http.StatusBadRequest)
+			return
+		}
+		req.TimeWindow = deliverypb.DeliveryTimeWindow(window)
+	}
+
+	resp, err := h.client.QuoteShipping(r.Context(), req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+)
+
+// maxDisputeEvidenceUploadSize leaves room for base64 encoding of the 3 MB
+// documents payment-service accepts
+const maxDisputeEvidenceUploadSize = 5 << 20
+
+func (h *PaymentHandler) registerDisputeHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/disputes", h.handleDisputes)
+	mux.HandleFunc("/v1/disputes/", h.handleDispute)
+}
+
+func (h *PaymentHandler) handleDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page := int32(1)
+	limit := int32(20)
+	if p := r.URL.Query().Get("page"); p != "" {
+		if val, err := strconv.ParseInt(p, 10, 32); err == nil {
+			page = int32(val)
+		}
+	}
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	req := &paymentpb.ListDisputesRequest{
+		OrderId: r.URL.Query().Get("order_id"),
+		Pagination: &sharedpb.Pagination{
+			Page:  page,
+			Limit: limit,
+		},
+	}
+	if s := r.URL.Query().Get("status"); s != "" {
+		v, ok := paymentpb.DisputeStatus_value["DISPUTE_STATUS_"+strings.ToUpper(s)]
+		if !ok {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			return
+		}
+		req.Status = paymentpb.DisputeStatus(v)
+	}
+
+	resp, err := h.client.ListDisputes(ctx, req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleDispute serves /v1/disputes/{id}, /v1/disputes/{id}/evidence and
+// /v1/disputes/{id}/submit
+func (h *PaymentHandler) handleDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	parts := splitPath(r.URL.Path[len("/v1/disputes/"):])
+	if len(parts) == 0 {
+		http.Error(w, "Dispute ID required", http.StatusBadRequest)
+		return
+	}
+	disputeID := parts[0]
+
+	switch {
+	case len(parts) == 1:
+		h.getDispute(w, r, ctx, disputeID)
+	case len(parts) == 2 && parts[1] == "evidence":
+		h.addDisputeEvidence(w, r, ctx, disputeID)
+	case len(parts) == 2 && parts[1] == "submit":
+		h.submitDisputeEvidence(w, r, ctx, disputeID)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+func (h *PaymentHandler) getDispute(w http.ResponseWriter, r *http.Request, ctx context.Context, disputeID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.GetDispute(ctx, &paymentpb.GetDisputeRequest{DisputeId: disputeID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// addDisputeEvidence accepts either a JSON body with a base64 encoded
+// content field, or the raw document with kind, file_name and description in
+// query parameters and its type in the Content-Type header.
+func (h *PaymentHandler) addDisputeEvidence(w http.ResponseWriter, r *http.Request, ctx context.Context, disputeID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxDisputeEvidenceUploadSize)
+
+	var req paymentpb.AddDisputeEvidenceRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	} else {
+		content, err := io.ReadAll(body)
+		if err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		query := r.URL.Query()
+		req.Kind = query.Get("kind")
+		req.FileName = query.Get("file_name")
+		req.Description = query.Get("description")
+		req.ContentType = r.Header.Get("Content-Type")
+		req.Content = content
+	}
+	req.DisputeId = disputeID
+
+	resp, err := h.client.AddDisputeEvidence(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PaymentHandler) submitDisputeEvidence(w http.ResponseWriter, r *http.Request, ctx context.Context, disputeID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.SubmitDisputeEvidence(ctx, &paymentpb.SubmitDisputeEvidenceRequest{DisputeId: disputeID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/gateway/internal/middleware"
+)
+
+func (h *PaymentHandler) registerGiftCardHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/gift-cards", h.handleGiftCards)
+	mux.HandleFunc("/v1/gift-cards/balance", h.handleGiftCardBalance)
+	mux.HandleFunc("/v1/gift-cards/", h.handleGiftCard)
+	mux.HandleFunc("/v1/users/me/gift-cards", h.handleMyGiftCards)
+}
+
+func (h *PaymentHandler) handleGiftCards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.listGiftCards(w, r, ctx, r.URL.Query().Get("user_id"))
+	case http.MethodPost:
+		h.issueGiftCard(w, r, ctx)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PaymentHandler) issueGiftCard(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	var req paymentpb.IssueGiftCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.IssueGiftCard(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PaymentHandler) listGiftCards(w http.ResponseWriter, r *http.Request, ctx context.Context, userID string) {
+	page := int32(1)
+	limit := int32(20)
+	if p := r.URL.Query().Get("page"); p != "" {
+		if val, err := strconv.ParseInt(p, 10, 32); err == nil {
+			page = int32(val)
+		}
+	}
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	req := &paymentpb.ListGiftCardsRequest{
+		UserId: userID,
+		Pagination: &sharedpb.Pagination{
+			Page:  page,
+			Limit: limit,
+		},
+	}
+	if t := r.URL.Query().Get("type"); t != "" {
+		v, ok := paymentpb.GiftCardType_value["GIFT_CARD_TYPE_"+strings.ToUpper(t)]
+		if !ok {
+			http.Error(w, "Invalid type", http.StatusBadRequest)
+			return
+		}
+		req.Type = paymentpb.GiftCardType(v)
+	}
+
+	resp, err := h.client.ListGiftCards(ctx, req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleGiftCardBalance lets a signed-in customer check a code. Failed
+// attempts are limited per customer.
+func (h *PaymentHandler) handleGiftCardBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID := r.Context().Value(middleware.UserIDKey)
+	if userID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req paymentpb.CheckGiftCardBalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.ClientKey = "user:" + userID.(string)
+
+	resp, err := h.client.CheckGiftCardBalance(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleGiftCard serves /v1/gift-cards/{id} and /v1/gift-cards/{id}/disable
+func (h *PaymentHandler) handleGiftCard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	parts := splitPath(r.URL.Path[len("/v1/gift-cards/"):])
+	if len(parts) == 0 {
+		http.Error(w, "Gift card ID required", http.StatusBadRequest)
+		return
+	}
+	giftCardID := parts[0]
+
+	switch {
+	case len(parts) == 1:
+		h.getGiftCard(w, r, ctx, giftCardID)
+	case len(parts) == 2 && parts[1] == "disable":
+		h.disableGiftCard(w, r, ctx, giftCardID)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+func (h *PaymentHandler) getGiftCard(w http.ResponseWriter, r *http.Request, ctx context.Context, giftCardID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.GetGiftCard(ctx, &paymentpb.GetGiftCardRequest{GiftCardId: giftCardID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) disableGiftCard(w http.ResponseWriter, r *http.Request, ctx context.Context, giftCardID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req paymentpb.DisableGiftCardRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	req.GiftCardId = giftCardID
+
+	resp, err := h.client.DisableGiftCard(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleMyGiftCards lists the gift cards and store credit registered to the
+// signed-in customer
+func (h *PaymentHandler) handleMyGiftCards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID := r.Context().Value(middleware.UserIDKey)
+	if userID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.listGiftCards(w, r, ctx, userID.(string))
+}
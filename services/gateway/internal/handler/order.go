@@ -143,6 +143,7 @@ func (h *OrderHandler) createOrder(w http.ResponseWriter, r *http.Request, ctx c
 	if slotID := getString(raw, "delivery_slot_id"); slotID != "" {
 		req.DeliverySlotId = &wrapperspb.StringValue{Value: slotID}
 	}
+	req.PickupPointId = getString(raw, "pickup_point_id")
 
 	// Parse payment_method (enum — may be string name or numeric)
 	if pm := getString(raw, "payment_method"); pm != "" {
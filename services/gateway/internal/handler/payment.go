@@ -7,6 +7,8 @@ import (
 
 	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type PaymentHandler struct {
@@ -22,12 +24,21 @@ func NewPaymentHandler(conn *grpc.ClientConn) *PaymentHandler {
 func (h *PaymentHandler) RegisterHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/v1/payments", h.handlePayments)
 	mux.HandleFunc("/v1/payments/", h.handlePayment)
+	mux.HandleFunc("/v1/payments/tenders", h.handleTenders)
+	h.registerWebhookHandlers(mux)
+	h.registerReconciliationHandlers(mux)
+	h.registerBankTransferHandlers(mux)
+	h.registerPaymentMethodHandlers(mux)
+	h.registerDisputeHandlers(mux)
+	h.registerGiftCardHandlers(mux)
 }
 
 func (h *PaymentHandler) handlePayments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	switch r.Method {
+	case http.MethodGet:
+		h.listPaymentsByOrder(w, r, ctx)
 	case http.MethodPost:
 		h.createPayment(w, r, ctx)
 	default:
@@ -44,7 +55,46 @@ func (h *PaymentHandler) createPayment(w http.ResponseWriter, r *http.Request, c
 
 	resp, err := h.client.CreatePayment(ctx, &req)
 	if err != nil {
-		handleError(w, err)
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PaymentHandler) listPaymentsByOrder(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	orderID := r.URL.Query().Get("order_id")
+	if orderID == "" {
+		http.Error(w, "order_id required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.ListPaymentsByOrder(ctx, &paymentpb.ListPaymentsByOrderRequest{OrderId: orderID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) handleTenders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req paymentpb.PayOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.PayOrder(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
 		return
 	}
 
@@ -65,6 +115,30 @@ func (h *PaymentHandler) handlePayment(w http.ResponseWriter, r *http.Request) {
 			h.refundPayment(w, r, ctx, parts[0])
 			return
 		}
+		if parts[1] == "capture" {
+			h.capturePayment(w, r, ctx, parts[0])
+			return
+		}
+		if parts[1] == "void" {
+			h.voidPayment(w, r, ctx, parts[0])
+			return
+		}
+		if parts[1] == "refunds" {
+			h.listRefunds(w, r, ctx, parts[0])
+			return
+		}
+		if parts[1] == "bank-transfer" {
+			h.getBankTransferInstructions(w, r, ctx, parts[0])
+			return
+		}
+		if parts[1] == "deferred" {
+			h.getDeferredPayment(w, r, ctx, parts[0])
+			return
+		}
+		if parts[1] == "history" {
+			h.listPaymentStatusHistory(w, r, ctx, parts[0])
+			return
+		}
 	}
 
 	switch r.Method {
@@ -83,7 +157,7 @@ func (h *PaymentHandler) getPayment(w http.ResponseWriter, r *http.Request, ctx
 	req := &paymentpb.GetPaymentRequest{PaymentId: paymentID}
 	resp, err := h.client.GetPayment(ctx, req)
 	if err != nil {
-		handleError(w, err)
+		handlePaymentError(w, err)
 		return
 	}
 
@@ -105,7 +179,7 @@ func (h *PaymentHandler) processPayment(w http.ResponseWriter, r *http.Request,
 
 	resp, err := h.client.ProcessPayment(ctx, &req)
 	if err != nil {
-		handleError(w, err)
+		handlePaymentError(w, err)
 		return
 	}
 
@@ -138,9 +212,132 @@ func (h *PaymentHandler) refundPayment(w http.ResponseWriter, r *http.Request, c
 
 	_, err := h.client.RefundPayment(ctx, &req)
 	if err != nil {
-		handleError(w, err)
+		handlePaymentError(w, err)
 		return
 	}
 
 	respondJSON(w, http.StatusNoContent, nil)
 }
+
+func (h *PaymentHandler) capturePayment(w http.ResponseWriter, r *http.Request, ctx context.Context, paymentID string) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req paymentpb.CapturePaymentRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	req.PaymentId = paymentID
+
+	resp, err := h.client.CapturePayment(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status":          resp.Status.String(),
+		"transaction_id":  resp.TransactionId,
+		"captured_amount": resp.CapturedAmount,
+	})
+}
+
+func (h *PaymentHandler) voidPayment(w http.ResponseWriter, r *http.Request, ctx context.Context, paymentID string) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req paymentpb.VoidPaymentRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	req.PaymentId = paymentID
+
+	_, err := h.client.VoidPayment(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+func (h *PaymentHandler) listRefunds(w http.ResponseWriter, r *http.Request, ctx context.Context, paymentID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.ListRefunds(ctx, &paymentpb.ListRefundsRequest{PaymentId: paymentID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) getDeferredPayment(w http.ResponseWriter, r *http.Request, ctx context.Context, paymentID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.GetDeferredPayment(ctx, &paymentpb.GetDeferredPaymentRequest{PaymentId: paymentID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) listPaymentStatusHistory(w http.ResponseWriter, r *http.Request, ctx context.Context, paymentID string) {
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.ListPaymentStatusHistory(ctx, &paymentpb.ListPaymentStatusHistoryRequest{PaymentId: paymentID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handlePaymentError is handleError for the payment routes, where a failed
+// precondition means the payment, gift card or balance is not in a state
+// that allows the request, and is reported as a conflict
+func handlePaymentError(w http.ResponseWriter, err error) {
+	if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+		http.Error(w, st.Message(), http.StatusConflict)
+		return
+	}
+	handleError(w, err)
+}
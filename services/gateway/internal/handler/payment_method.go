@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/gateway/internal/middleware"
+)
+
+func (h *PaymentHandler) registerPaymentMethodHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/users/me/payment-methods", h.handleMyPaymentMethods)
+	mux.HandleFunc("/v1/users/me/payment-methods/", h.handleMyPaymentMethod)
+	mux.HandleFunc("/v1/payment-methods/expiring", h.handleExpiringPaymentMethods)
+}
+
+func (h *PaymentHandler) handleMyPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID := r.Context().Value(middleware.UserIDKey)
+	if userID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.listPaymentMethods(w, r, ctx, userID.(string))
+	case http.MethodPost:
+		h.savePaymentMethod(w, r, ctx, userID.(string))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PaymentHandler) listPaymentMethods(w http.ResponseWriter, r *http.Request, ctx context.Context, userID string) {
+	resp, err := h.client.ListPaymentMethods(ctx, &paymentpb.ListPaymentMethodsRequest{UserId: userID})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) savePaymentMethod(w http.ResponseWriter, r *http.Request, ctx context.Context, userID string) {
+	var req paymentpb.SavePaymentMethodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.UserId = userID
+
+	resp, err := h.client.SavePaymentMethod(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PaymentHandler) handleMyPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID := r.Context().Value(middleware.UserIDKey)
+	if userID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := splitPath(r.URL.Path[len("/v1/users/me/payment-methods/"):])
+	if len(parts) == 0 {
+		http.Error(w, "Payment method ID required", http.StatusBadRequest)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "default" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		resp, err := h.client.SetDefaultPaymentMethod(ctx, &paymentpb.SetDefaultPaymentMethodRequest{
+			UserId:          userID.(string),
+			PaymentMethodId: parts[0],
+		})
+		if err != nil {
+			handlePaymentError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	if len(parts) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		_, err := h.client.DeletePaymentMethod(ctx, &paymentpb.DeletePaymentMethodRequest{
+			UserId:          userID.(string),
+			PaymentMethodId: parts[0],
+		})
+		if err != nil {
+			handlePaymentError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusNoContent, nil)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PaymentHandler) handleExpiringPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := &paymentpb.ListExpiringPaymentMethodsRequest{}
+	if d := r.URL.Query().Get("within_days"); d != "" {
+		val, err := strconv.ParseInt(d, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid within_days", http.StatusBadRequest)
+			return
+		}
+		req.WithinDays = int32(val)
+	}
+
+	resp, err := h.client.ListExpiringPaymentMethods(ctx, req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -263,6 +263,8 @@ func handleError(w http.ResponseWriter, err error) {
 		http.Error(w, st.Message(), http.StatusBadRequest)
 	case codes.AlreadyExists:
 		http.Error(w, st.Message(), http.StatusConflict)
+	case codes.ResourceExhausted:
+		http.Error(w, st.Message(), http.StatusTooManyRequests)
 	default:
 		http.Error(w, st.Message(), http.StatusInternalServerError)
 	}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+)
+
+func (h *PaymentHandler) registerReconciliationHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/reconciliation-runs", h.handleReconciliationRuns)
+	mux.HandleFunc("/v1/reconciliation-runs/", h.handleReconciliationRun)
+}
+
+func (h *PaymentHandler) handleReconciliationRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page := int32(1)
+	limit := int32(20)
+	if p := r.URL.Query().Get("page"); p != "" {
+		if val, err := strconv.ParseInt(p, 10, 32); err == nil {
+			page = int32(val)
+		}
+	}
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	resp, err := h.client.ListReconciliationRuns(ctx, &paymentpb.ListReconciliationRunsRequest{
+		Pagination: &sharedpb.Pagination{
+			Page:  page,
+			Limit: limit,
+		},
+	})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) handleReconciliationRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	runID := r.URL.Path[len("/v1/reconciliation-runs/"):]
+	if runID == "" {
+		http.Error(w, "Run ID required", http.StatusBadRequest)
+		return
+	}
+
+	includeMatched, _ := strconv.ParseBool(r.URL.Query().Get("include_matched"))
+	resp, err := h.client.GetReconciliationRun(ctx, &paymentpb.GetReconciliationRunRequest{
+		RunId:          runID,
+		IncludeMatched: includeMatched,
+	})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
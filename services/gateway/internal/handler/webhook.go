@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+)
+
+func (h *PaymentHandler) registerWebhookHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/webhook-endpoints", h.handleWebhookEndpoints)
+	mux.HandleFunc("/v1/webhook-endpoints/", h.handleWebhookEndpoint)
+	mux.HandleFunc("/v1/webhook-deliveries", h.handleWebhookDeliveries)
+	mux.HandleFunc("/v1/webhook-deliveries/", h.handleWebhookDelivery)
+}
+
+func (h *PaymentHandler) handleWebhookEndpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := h.client.ListWebhookEndpoints(ctx, &paymentpb.ListWebhookEndpointsRequest{})
+		if err != nil {
+			handlePaymentError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		h.createWebhookEndpoint(w, r, ctx)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PaymentHandler) createWebhookEndpoint(w http.ResponseWriter, r *http.Request, ctx context.Context) {
+	var req paymentpb.CreateWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.CreateWebhookEndpoint(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PaymentHandler) handleWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	endpointID := r.URL.Path[len("/v1/webhook-endpoints/"):]
+	if endpointID == "" {
+		http.Error(w, "Endpoint ID required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		h.updateWebhookEndpoint(w, r, ctx, endpointID)
+	case http.MethodDelete:
+		_, err := h.client.DeleteWebhookEndpoint(ctx, &paymentpb.DeleteWebhookEndpointRequest{EndpointId: endpointID})
+		if err != nil {
+			handlePaymentError(w, err)
+			return
+		}
+		respondJSON(w, http.StatusNoContent, nil)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PaymentHandler) updateWebhookEndpoint(w http.ResponseWriter, r *http.Request, ctx context.Context, endpointID string) {
+	var req paymentpb.UpdateWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.EndpointId = endpointID
+
+	resp, err := h.client.UpdateWebhookEndpoint(ctx, &req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) handleWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page := int32(1)
+	limit := int32(50)
+	if p := r.URL.Query().Get("page"); p != "" {
+		if val, err := strconv.ParseInt(p, 10, 32); err == nil {
+			page = int32(val)
+		}
+	}
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if val, err := strconv.ParseInt(l, 10, 32); err == nil {
+			limit = int32(val)
+		}
+	}
+
+	req := &paymentpb.ListWebhookDeliveriesRequest{
+		EndpointId: r.URL.Query().Get("endpoint_id"),
+		Pagination: &sharedpb.Pagination{
+			Page:  page,
+			Limit: limit,
+		},
+	}
+	if s := r.URL.Query().Get("status"); s != "" {
+		v, ok := paymentpb.WebhookDeliveryStatus_value["WEBHOOK_DELIVERY_STATUS_"+strings.ToUpper(s)]
+		if !ok {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			return
+		}
+		req.Status = paymentpb.WebhookDeliveryStatus(v)
+	}
+
+	resp, err := h.client.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *PaymentHandler) handleWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isAdmin(r) {
+		http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
+		return
+	}
+
+	parts := splitPath(r.URL.Path[len("/v1/webhook-deliveries/"):])
+	if len(parts) != 2 || parts[1] != "replay" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.ReplayWebhookDelivery(ctx, &paymentpb.ReplayWebhookDeliveryRequest{DeliveryId: parts[0]})
+	if err != nil {
+		handlePaymentError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, resp)
+}
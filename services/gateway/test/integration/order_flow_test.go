@@ -331,9 +331,7 @@ func TestCompleteOrderFlow(t *testing.T) {
 	t.Run("Process Payment", func(t *testing.T) {
 		paymentReq := map[string]interface{}{
 			"payment_data": map[string]string{
-				"card_number": "4111111111111111",
-				"expiry":      "12/25",
-				"cvv":         "123",
+				"card_token": "tok_visa_4242",
 			},
 		}
 
@@ -6,9 +6,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	inventorypb "github.com/afasari/shinkansen-commerce/gen/proto/go/inventory"
 	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	productpb "github.com/afasari/shinkansen-commerce/gen/proto/go/product"
 	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
 	"github.com/afasari/shinkansen-commerce/services/order-service/internal/config"
@@ -64,7 +68,77 @@ func main() {
 
 	productClient := productpb.NewProductServiceClient(conn)
 
+	paymentConn, err := grpc.NewClient(cfg.PaymentServiceGRPCAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Fatal("Failed to dial payment service", zap.Error(err))
+	}
+	defer func() { _ = paymentConn.Close() }()
+
+	inventoryConn, err := grpc.NewClient(cfg.InventoryServiceGRPCAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Fatal("Failed to dial inventory service", zap.Error(err))
+	}
+	defer func() { _ = inventoryConn.Close() }()
+
+	deliveryConn, err := grpc.NewClient(cfg.DeliveryServiceGRPCAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Fatal("Failed to dial delivery service", zap.Error(err))
+	}
+	defer func() { _ = deliveryConn.Close() }()
+
 	orderService := service.NewOrderService(queries, productClient, cacheClient, logger)
+	orderService.SetPaymentClient(paymentpb.NewPaymentServiceClient(paymentConn))
+	orderService.SetInventoryClient(inventorypb.NewInventoryServiceClient(inventoryConn))
+	orderService.SetDeliveryClient(deliverypb.NewDeliveryServiceClient(deliveryConn))
+
+	consumerCtx, stopConsumers := context.WithCancel(context.Background())
+	defer stopConsumers()
+	if cfg.KafkaBrokers != "" {
+		paymentConsumer, err := service.NewPaymentEventConsumer(
+			strings.Split(cfg.KafkaBrokers, ","),
+			"order-service",
+			cfg.PaymentEventsTopic,
+			orderService,
+			logger,
+		)
+		if err != nil {
+			logger.Warn("Failed to create payment event consumer, continuing without", zap.Error(err))
+		} else {
+			defer func() { _ = paymentConsumer.Close() }()
+			go func() {
+				if err := paymentConsumer.Consume(consumerCtx); err != nil {
+					logger.Error("Payment event consumer stopped", zap.Error(err))
+				}
+			}()
+		}
+
+		shipmentConsumer, err := service.NewShipmentEventConsumer(
+			strings.Split(cfg.KafkaBrokers, ","),
+			"order-service-shipments",
+			cfg.ShipmentEventsTopic,
+			orderService,
+			logger,
+		)
+		if err != nil {
+			logger.Warn("Failed to create shipment event consumer, continuing without", zap.Error(err))
+		} else {
+			defer func() { _ = shipmentConsumer.Close() }()
+			go func() {
+				if err := shipmentConsumer.Consume(consumerCtx); err != nil {
+					logger.Error("Shipment event consumer stopped", zap.Error(err))
+				}
+			}()
+		}
+	}
 
 	server := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	orderpb.RegisterOrderServiceServer(server, orderService)
@@ -18,7 +18,11 @@ SELECT id, order_number, user_id, status,
        discount_units, discount_currency,
        total_units, total_currency,
        points_applied, shipping_address, payment_method,
-       created_at, updated_at
+       created_at, updated_at,
+       cod_fee_units, cod_fee_currency,
+       disputed,
+       shipping_fee_units, shipping_fee_currency,
+       estimated_delivery_at, pickup_point_id
 FROM orders.orders
 WHERE user_id = $1
 AND ($2::int4 IS NULL OR status = $2)
@@ -65,6 +69,13 @@ func (q *Queries) ListUserOrders(ctx context.Context, arg ListUserOrdersParams)
 			&i.PaymentMethod,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CodFeeUnits,
+			&i.CodFeeCurrency,
+			&i.Disputed,
+			&i.ShippingFeeUnits,
+			&i.ShippingFeeCurrency,
+			&i.EstimatedDeliveryAt,
+			&i.PickupPointID,
 		); err != nil {
 			return nil, err
 		}
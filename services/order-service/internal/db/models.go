@@ -70,4 +70,19 @@ type OrdersOrders struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	// Last update timestamp
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	// Cash on delivery fee in minor units, included in total_units
+	CodFeeUnits int64 `json:"cod_fee_units"`
+	// Cash on delivery fee currency code (JPY)
+	CodFeeCurrency string `json:"cod_fee_currency"`
+	// A payment of the order has an open chargeback dispute
+	Disputed bool `json:"disputed"`
+	// Shipping fee in minor units, included in total_units
+	ShippingFeeUnits int64 `json:"shipping_fee_units"`
+	// Shipping fee currency code (JPY)
+	ShippingFeeCurrency string `json:"shipping_fee_currency"`
+	// Day the order is promised to arrive (midnight JST); NULL when unknown
+	EstimatedDeliveryAt pgtype.Timestamptz `json:"estimated_delivery_at"`
+	// Konbini or locker the order is collected from; NULL for delivery to
+	// the shipping address
+	PickupPointID pgtype.UUID `json:"pickup_point_id"`
 }
@@ -18,26 +18,35 @@ INSERT INTO orders.orders (
     tax_units, tax_currency,
     discount_units, discount_currency,
     total_units, total_currency,
-    points_applied, shipping_address, payment_method
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+    points_applied, shipping_address, payment_method,
+    cod_fee_units, cod_fee_currency,
+    shipping_fee_units, shipping_fee_currency,
+    estimated_delivery_at, pickup_point_id
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 RETURNING id
 `
 
 type CreateOrderParams struct {
-	OrderNumber      string      `json:"order_number"`
-	UserID           pgtype.UUID `json:"user_id"`
-	Status           int32       `json:"status"`
-	SubtotalUnits    int64       `json:"subtotal_units"`
-	SubtotalCurrency string      `json:"subtotal_currency"`
-	TaxUnits         int64       `json:"tax_units"`
-	TaxCurrency      string      `json:"tax_currency"`
-	DiscountUnits    int64       `json:"discount_units"`
-	DiscountCurrency string      `json:"discount_currency"`
-	TotalUnits       int64       `json:"total_units"`
-	TotalCurrency    string      `json:"total_currency"`
-	PointsApplied    int32       `json:"points_applied"`
-	ShippingAddress  []byte      `json:"shipping_address"`
-	PaymentMethod    int32       `json:"payment_method"`
+	OrderNumber         string             `json:"order_number"`
+	UserID              pgtype.UUID        `json:"user_id"`
+	Status              int32              `json:"status"`
+	SubtotalUnits       int64              `json:"subtotal_units"`
+	SubtotalCurrency    string             `json:"subtotal_currency"`
+	TaxUnits            int64              `json:"tax_units"`
+	TaxCurrency         string             `json:"tax_currency"`
+	DiscountUnits       int64              `json:"discount_units"`
+	DiscountCurrency    string             `json:"discount_currency"`
+	TotalUnits          int64              `json:"total_units"`
+	TotalCurrency       string             `json:"total_currency"`
+	PointsApplied       int32              `json:"points_applied"`
+	ShippingAddress     []byte             `json:"shipping_address"`
+	PaymentMethod       int32              `json:"payment_method"`
+	CodFeeUnits         int64              `json:"cod_fee_units"`
+	CodFeeCurrency      string             `json:"cod_fee_currency"`
+	ShippingFeeUnits    int64              `json:"shipping_fee_units"`
+	ShippingFeeCurrency string             `json:"shipping_fee_currency"`
+	EstimatedDeliveryAt pgtype.Timestamptz `json:"estimated_delivery_at"`
+	PickupPointID       pgtype.UUID        `json:"pickup_point_id"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (pgtype.UUID, error) {
@@ -56,6 +65,12 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (pgtyp
 		arg.PointsApplied,
 		arg.ShippingAddress,
 		arg.PaymentMethod,
+		arg.CodFeeUnits,
+		arg.CodFeeCurrency,
+		arg.ShippingFeeUnits,
+		arg.ShippingFeeCurrency,
+		arg.EstimatedDeliveryAt,
+		arg.PickupPointID,
 	)
 	var id pgtype.UUID
 	err := row.Scan(&id)
@@ -69,7 +84,11 @@ SELECT id, order_number, user_id, status,
        discount_units, discount_currency,
        total_units, total_currency,
        points_applied, shipping_address, payment_method,
-       created_at, updated_at
+       created_at, updated_at,
+       cod_fee_units, cod_fee_currency,
+       disputed,
+       shipping_fee_units, shipping_fee_currency,
+       estimated_delivery_at, pickup_point_id
 FROM orders.orders
 WHERE id = $1
 `
@@ -95,6 +114,13 @@ func (q *Queries) GetOrder(ctx context.Context, id pgtype.UUID) (OrdersOrders, e
 		&i.PaymentMethod,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CodFeeUnits,
+		&i.CodFeeCurrency,
+		&i.Disputed,
+		&i.ShippingFeeUnits,
+		&i.ShippingFeeCurrency,
+		&i.EstimatedDeliveryAt,
+		&i.PickupPointID,
 	)
 	return i, err
 }
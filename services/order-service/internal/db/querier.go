@@ -17,6 +17,7 @@ type Querier interface {
 	GetOrderItem(ctx context.Context, id pgtype.UUID) (OrdersOrderItems, error)
 	GetOrderItems(ctx context.Context, orderID pgtype.UUID) ([]OrdersOrderItems, error)
 	ListUserOrders(ctx context.Context, arg ListUserOrdersParams) ([]OrdersOrders, error)
+	SetOrderDisputed(ctx context.Context, arg SetOrderDisputedParams) error
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
 	UpdateOrderWithPoints(ctx context.Context, arg UpdateOrderWithPointsParams) error
 }
@@ -11,6 +11,22 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const setOrderDisputed = `-- name: SetOrderDisputed :exec
+UPDATE orders.orders
+SET disputed = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetOrderDisputedParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Disputed bool        `json:"disputed"`
+}
+
+func (q *Queries) SetOrderDisputed(ctx context.Context, arg SetOrderDisputedParams) error {
+	_, err := q.db.Exec(ctx, setOrderDisputed, arg.ID, arg.Disputed)
+	return err
+}
+
 const updateOrderStatus = `-- name: UpdateOrderStatus :exec
 UPDATE orders.orders
 SET status = $2, updated_at = NOW()
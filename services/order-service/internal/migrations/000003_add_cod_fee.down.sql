@@ -0,0 +1,6 @@
+-- Name: add_cod_fee
+-- Description: Drop cash on delivery fee from orders
+
+ALTER TABLE orders.orders
+    DROP COLUMN IF EXISTS cod_fee_currency,
+    DROP COLUMN IF EXISTS cod_fee_units;
@@ -0,0 +1,11 @@
+-- Name: add_cod_fee
+-- Description: Add cash on delivery fee to orders
+-- Schema: orders
+
+ALTER TABLE orders.orders
+    ADD COLUMN IF NOT EXISTS cod_fee_units BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cod_fee_currency VARCHAR(3) NOT NULL DEFAULT 'JPY';
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.orders.cod_fee_units IS 'Cash on delivery fee in minor units, included in total_units';
+COMMENT ON COLUMN orders.orders.cod_fee_currency IS 'Cash on delivery fee currency code (JPY)';
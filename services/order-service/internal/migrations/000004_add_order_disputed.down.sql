@@ -0,0 +1,7 @@
+-- Name: add_order_disputed
+-- Description: Drop the chargeback dispute flag from orders
+
+DROP INDEX IF EXISTS orders.idx_orders_disputed;
+
+ALTER TABLE orders.orders
+    DROP COLUMN IF EXISTS disputed;
@@ -0,0 +1,11 @@
+-- Name: add_order_disputed
+-- Description: Flag orders with an open chargeback dispute
+-- Schema: orders
+
+ALTER TABLE orders.orders
+    ADD COLUMN IF NOT EXISTS disputed BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_disputed ON orders.orders(created_at) WHERE disputed;
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.orders.disputed IS 'A payment of the order has an open chargeback dispute';
@@ -0,0 +1,6 @@
+-- Name: add_shipping_fee
+-- Description: Drop shipping fee from orders
+
+ALTER TABLE orders.orders
+    DROP COLUMN IF EXISTS shipping_fee_currency,
+    DROP COLUMN IF EXISTS shipping_fee_units;
@@ -0,0 +1,11 @@
+-- Name: add_shipping_fee
+-- Description: Add shipping fee to orders
+-- Schema: orders
+
+ALTER TABLE orders.orders
+    ADD COLUMN IF NOT EXISTS shipping_fee_units BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS shipping_fee_currency VARCHAR(3) NOT NULL DEFAULT 'JPY';
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.orders.shipping_fee_units IS 'Shipping fee in minor units, included in total_units';
+COMMENT ON COLUMN orders.orders.shipping_fee_currency IS 'Shipping fee currency code (JPY)';
@@ -0,0 +1,5 @@
+-- Name: add_estimated_delivery
+-- Description: Drop the promised delivery date from orders
+
+ALTER TABLE orders.orders
+    DROP COLUMN IF EXISTS estimated_delivery_at;
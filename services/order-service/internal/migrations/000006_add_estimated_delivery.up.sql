@@ -0,0 +1,9 @@
+-- Name: add_estimated_delivery
+-- Description: Add the promised delivery date to orders
+-- Schema: orders
+
+ALTER TABLE orders.orders
+    ADD COLUMN IF NOT EXISTS estimated_delivery_at TIMESTAMPTZ;
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.orders.estimated_delivery_at IS 'Day the order is promised to arrive (midnight JST); NULL when unknown';
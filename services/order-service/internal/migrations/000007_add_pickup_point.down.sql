@@ -0,0 +1,5 @@
+-- Name: add_pickup_point
+-- Description: Drop the pickup point from orders
+
+ALTER TABLE orders.orders
+    DROP COLUMN IF EXISTS pickup_point_id;
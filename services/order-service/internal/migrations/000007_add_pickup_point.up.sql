@@ -0,0 +1,9 @@
+-- Name: add_pickup_point
+-- Description: Add the pickup point orders are collected from
+-- Schema: orders
+
+ALTER TABLE orders.orders
+    ADD COLUMN IF NOT EXISTS pickup_point_id UUID;
+
+-- Comments for documentation
+COMMENT ON COLUMN orders.orders.pickup_point_id IS 'Konbini or locker (delivery.pickup_points) the order is collected from; NULL for delivery to the shipping address';
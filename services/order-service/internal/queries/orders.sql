@@ -5,8 +5,11 @@ INSERT INTO orders.orders (
     tax_units, tax_currency,
     discount_units, discount_currency,
     total_units, total_currency,
-    points_applied, shipping_address, payment_method
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+    points_applied, shipping_address, payment_method,
+    cod_fee_units, cod_fee_currency,
+    shipping_fee_units, shipping_fee_currency,
+    estimated_delivery_at, pickup_point_id
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 RETURNING id;
 
 -- name: GetOrder :one
@@ -16,6 +19,10 @@ SELECT id, order_number, user_id, status,
        discount_units, discount_currency,
        total_units, total_currency,
        points_applied, shipping_address, payment_method,
-       created_at, updated_at
+       created_at, updated_at,
+       cod_fee_units, cod_fee_currency,
+       disputed,
+       shipping_fee_units, shipping_fee_currency,
+       estimated_delivery_at, pickup_point_id
 FROM orders.orders
 WHERE id = $1;
@@ -7,3 +7,8 @@ WHERE id = $1;
 UPDATE orders.orders
 SET points_applied = $2, discount_units = $3, total_units = total_units - $3, updated_at = NOW()
 WHERE id = $1;
+
+-- name: SetOrderDisputed :exec
+UPDATE orders.orders
+SET disputed = $2, updated_at = NOW()
+WHERE id = $1;
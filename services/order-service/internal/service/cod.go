@@ -0,0 +1,36 @@
+package service
+
+import "fmt"
+
+// codMaxAmount is the most a courier will collect on a single cash on
+// delivery (代金引換) parcel, fee included
+const codMaxAmount = 300000
+
+// codFeeTier is the fee charged for orders totalling less than upTo yen
+type codFeeTier struct {
+	upTo int64
+	fee  int64
+}
+
+// codFeeTiers follows the carriers' 代引手数料 table, tax included
+var codFeeTiers = []codFeeTier{
+	{upTo: 10000, fee: 330},
+	{upTo: 30000, fee: 440},
+	{upTo: 100000, fee: 660},
+	{upTo: codMaxAmount, fee: 1100},
+}
+
+// codFee returns the cash on delivery fee for an order total. Orders that
+// would exceed the collection limit once the fee is added cannot be paid
+// cash on delivery.
+func codFee(totalUnits int64) (int64, error) {
+	for _, tier := range codFeeTiers {
+		if totalUnits < tier.upTo {
+			if totalUnits+tier.fee > codMaxAmount {
+				break
+			}
+			return tier.fee, nil
+		}
+	}
+	return 0, fmt.Errorf("cash on delivery is limited to orders of %d yen", codMaxAmount)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	productpb "github.com/afasari/shinkansen-commerce/gen/proto/go/product"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/pkg/pgutil"
+)
+
+func TestCodFee(t *testing.T) {
+	tests := []struct {
+		total int64
+		fee   int64
+	}{
+		{total: 1, fee: 330},
+		{total: 9999, fee: 330},
+		{total: 10000, fee: 440},
+		{total: 29999, fee: 440},
+		{total: 30000, fee: 660},
+		{total: 99999, fee: 660},
+		{total: 100000, fee: 1100},
+		{total: 298900, fee: 1100},
+	}
+	for _, tt := range tests {
+		fee, err := codFee(tt.total)
+		require.NoError(t, err)
+		assert.Equal(t, tt.fee, fee, "total %d", tt.total)
+	}
+
+	_, err := codFee(298901)
+	assert.Error(t, err)
+	_, err = codFee(500000)
+	assert.Error(t, err)
+}
+
+func TestOrderService_CreateOrder_CashOnDelivery(t *testing.T) {
+	newRequest := func(productID string, quantity int32) *orderpb.CreateOrderRequest {
+		return &orderpb.CreateOrderRequest{
+			UserId:          uuid.New().String(),
+			Items:           []*orderpb.CreateOrderItem{{ProductId: productID, Quantity: quantity}},
+			ShippingAddress: &orderpb.ShippingAddress{Name: "山田太郎", PostalCode: "100-0001"},
+			PaymentMethod:   orderpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY,
+		}
+	}
+	product := func(id string, price int64) *productpb.GetProductResponse {
+		return &productpb.GetProductResponse{Product: &productpb.Product{
+			Id:            id,
+			Name:          "Test Product",
+			Price:         &sharedpb.Money{Units: price, Currency: "JPY"},
+			StockQuantity: 10,
+		}}
+	}
+
+	t.Run("adds the fee to the order total", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockProductClient := new(MockProductClient)
+		s := NewOrderService(mockQueries, mockProductClient, new(cache.MockCache), zap.NewNop())
+		productID := uuid.New().String()
+
+		mockProductClient.On("GetProduct", mock.Anything, mock.Anything, mock.Anything).Return(product(productID, 5000), nil)
+		mockQueries.On("CreateOrder", mock.Anything, mock.MatchedBy(func(p db.CreateOrderParams) bool {
+			// 2 x 5,000 + 10% tax = 11,000, which falls in the 440 yen tier
+			return p.CodFeeUnits == 440 && p.TotalUnits == 11440 && p.CodFeeCurrency == "JPY"
+		})).Return(pgutil.ToPG(uuid.New()), nil)
+		mockQueries.On("AddOrderItem", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := s.CreateOrder(context.Background(), newRequest(productID, 2))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects orders above the collection limit", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockProductClient := new(MockProductClient)
+		s := NewOrderService(mockQueries, mockProductClient, new(cache.MockCache), zap.NewNop())
+		productID := uuid.New().String()
+
+		mockProductClient.On("GetProduct", mock.Anything, mock.Anything, mock.Anything).Return(product(productID, 150000), nil)
+
+		_, err := s.CreateOrder(context.Background(), newRequest(productID, 2))
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+)
+
+// checkCreditHold holds deferred payment (後払い) orders before CONFIRMED
+// until the provider's credit check has approved them. Orders released from
+// review are confirmed through the payment.authorized event instead.
+func (s *OrderService) checkCreditHold(ctx context.Context, orderID string) error {
+	if s.paymentClient == nil {
+		return nil
+	}
+
+	resp, err := s.paymentClient.GetDeferredPayment(ctx, &paymentpb.GetDeferredPaymentRequest{OrderId: orderID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Error(codes.FailedPrecondition, "order is held until the deferred payment credit check has run")
+		}
+		s.logger.Error("Failed to get deferred payment", zap.String("order_id", orderID), zap.Error(err))
+		return status.Error(codes.Unavailable, "failed to check deferred payment")
+	}
+
+	switch resp.DeferredPayment.CreditCheck {
+	case paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED:
+		return nil
+	case paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW:
+		return status.Error(codes.FailedPrecondition, "order is held while the deferred payment credit check is in review")
+	default:
+		return status.Error(codes.FailedPrecondition, "deferred payment credit check was declined")
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/pkg/pgutil"
+)
+
+func TestOrderService_UpdateOrderStatus_DeferredPaymentHold(t *testing.T) {
+	setup := func() (*OrderService, *MockQuerier, *MockPaymentClient, uuid.UUID) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		mockPayments := new(MockPaymentClient)
+		orderID := uuid.New()
+
+		mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("GetOrder", mock.Anything, pgutil.ToPG(orderID)).Return(db.OrdersOrders{
+			ID:            pgutil.ToPG(orderID),
+			Status:        int32(orderpb.OrderStatus_ORDER_STATUS_PENDING),
+			PaymentMethod: int32(orderpb.PaymentMethod_PAYMENT_METHOD_DEFERRED),
+		}, nil)
+
+		service := NewOrderService(mockQueries, new(MockProductClient), mockCache, zap.NewNop())
+		service.SetPaymentClient(mockPayments)
+		return service, mockQueries, mockPayments, orderID
+	}
+	confirm := func(service *OrderService, orderID uuid.UUID) error {
+		_, err := service.UpdateOrderStatus(context.Background(), &orderpb.UpdateOrderStatusRequest{
+			OrderId: orderID.String(),
+			Status:  orderpb.OrderStatus_ORDER_STATUS_CONFIRMED,
+		})
+		return err
+	}
+	creditCheck := func(result paymentpb.CreditCheckResult) *paymentpb.GetDeferredPaymentResponse {
+		return &paymentpb.GetDeferredPaymentResponse{DeferredPayment: &paymentpb.DeferredPayment{CreditCheck: result}}
+	}
+
+	t.Run("approved orders are confirmed", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("GetDeferredPayment", mock.Anything, &paymentpb.GetDeferredPaymentRequest{OrderId: orderID.String()}).
+			Return(creditCheck(paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED), nil)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, db.UpdateOrderStatusParams{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(orderpb.OrderStatus_ORDER_STATUS_CONFIRMED),
+		}).Return(nil)
+
+		require.NoError(t, confirm(service, orderID))
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("orders in review are held", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("GetDeferredPayment", mock.Anything, mock.Anything).
+			Return(creditCheck(paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW), nil)
+
+		err := confirm(service, orderID)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "in review")
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("orders without a credit check are held", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("GetDeferredPayment", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.NotFound, "deferred payment not found"))
+
+		err := confirm(service, orderID)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_HandlePaymentAuthorized(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	mockCache := new(cache.MockCache)
+	orderID := uuid.New()
+
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mockQueries.On("GetOrder", mock.Anything, pgutil.ToPG(orderID)).Return(db.OrdersOrders{
+		ID:     pgutil.ToPG(orderID),
+		Status: int32(orderpb.OrderStatus_ORDER_STATUS_PENDING),
+	}, nil)
+	mockQueries.On("UpdateOrderStatus", mock.Anything, db.UpdateOrderStatusParams{
+		ID:     pgutil.ToPG(orderID),
+		Status: int32(orderpb.OrderStatus_ORDER_STATUS_CONFIRMED),
+	}).Return(nil)
+
+	service := NewOrderService(mockQueries, new(MockProductClient), mockCache, zap.NewNop())
+	err := service.HandlePaymentAuthorized(context.Background(), PaymentEvent{
+		EventType: "payment.authorized",
+		OrderID:   orderID.String(),
+	})
+
+	require.NoError(t, err)
+	mockQueries.AssertExpectations(t)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backoff between attempts at a consumed event whose handling failed for a
+// reason that may pass, e.g. the database or payment-service being down
+const (
+	eventRetryBackoff    = time.Second
+	eventMaxRetryBackoff = time.Minute
+)
+
+// handleEventWithRetry handles a consumed event, retrying it in place while
+// it fails for a reason that may pass. An event that fails for good is
+// logged and given up. It returns false when ctx, the consumer session, ends
+// before the event is done with; the event must then be left unmarked so
+// that the next session consumes it again.
+func handleEventWithRetry(ctx context.Context, logger *zap.Logger, backoff time.Duration, handle func(ctx context.Context) error, fields ...zap.Field) bool {
+	for {
+		err := handle(ctx)
+		if err == nil {
+			return true
+		}
+		if !retryableEventError(err) {
+			logger.Error("Failed to handle event", append(fields, zap.Error(err))...)
+			return true
+		}
+
+		logger.Warn("Failed to handle event, retrying", append(fields, zap.Duration("backoff", backoff), zap.Error(err))...)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, eventMaxRetryBackoff)
+	}
+}
+
+// retryableEventError reports whether handling an event failed for a reason
+// that may pass. A missing order, or a step the order or its payment
+// rejects, fails the same way every time.
+func retryableEventError(err error) bool {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition, codes.AlreadyExists, codes.PermissionDenied:
+		return false
+	}
+	return true
+}
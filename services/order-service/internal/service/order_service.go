@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	inventorypb "github.com/afasari/shinkansen-commerce/gen/proto/go/inventory"
 	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	productpb "github.com/afasari/shinkansen-commerce/gen/proto/go/product"
 	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
@@ -26,8 +29,13 @@ import (
 
 type OrderService struct {
 	orderpb.UnimplementedOrderServiceServer
-	queries        db.Querier
-	productClient  productpb.ProductServiceClient
+	queries       db.Querier
+	productClient productpb.ProductServiceClient
+	paymentClient paymentpb.PaymentServiceClient
+	// inventoryClient releases the stock held for orders that expire
+	inventoryClient inventorypb.InventoryServiceClient
+	// deliveryClient holds, confirms and releases delivery slots
+	deliveryClient deliverypb.DeliveryServiceClient
 	cache          cache.Cache
 	cartService    *CartService
 	stateMachine   *OrderStateMachine
@@ -67,6 +75,24 @@ func (s *OrderService) SetCartService(cartService *CartService) {
 	s.cartService = cartService
 }
 
+// SetPaymentClient sets the payment service client used to capture payments (optional)
+func (s *OrderService) SetPaymentClient(paymentClient paymentpb.PaymentServiceClient) {
+	s.paymentClient = paymentClient
+}
+
+// SetInventoryClient sets the inventory service client used to release
+// stock held for expired orders (optional)
+func (s *OrderService) SetInventoryClient(inventoryClient inventorypb.InventoryServiceClient) {
+	s.inventoryClient = inventoryClient
+}
+
+// SetDeliveryClient sets the delivery service client used to hold delivery
+// slots for orders and confirm or release them as the order moves on
+// (optional)
+func (s *OrderService) SetDeliveryClient(deliveryClient deliverypb.DeliveryServiceClient) {
+	s.deliveryClient = deliveryClient
+}
+
 // SetStateMachine sets the state machine (optional)
 func (s *OrderService) SetStateMachine(stateMachine *OrderStateMachine) {
 	s.stateMachine = stateMachine
@@ -93,6 +119,20 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *orderpb.CreateOrder
 		return nil, status.Error(codes.InvalidArgument, "order must have at least one item")
 	}
 
+	// The customer collects pickup orders whenever they like and pays for
+	// them up front
+	if req.PickupPointId != "" {
+		if _, err := uuid.Parse(req.PickupPointId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid pickup_point_id")
+		}
+		if req.DeliverySlotId != nil {
+			return nil, status.Error(codes.InvalidArgument, "pickup orders cannot have a delivery slot")
+		}
+		if req.PaymentMethod == orderpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY {
+			return nil, status.Error(codes.InvalidArgument, "pickup orders cannot be paid cash on delivery")
+		}
+	}
+
 	var subtotalUnits int64
 
 	for _, item := range req.Items {
@@ -113,23 +153,46 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *orderpb.CreateOrder
 	taxUnits := int64(float64(subtotalUnits) * 0.10)
 	totalUnits := subtotalUnits + taxUnits
 
+	shippingQuote, err := s.quoteShipping(ctx, req, totalUnits)
+	if err != nil {
+		return nil, err
+	}
+	shippingFeeUnits := shippingQuote.GetTotal().GetUnits()
+	totalUnits += shippingFeeUnits
+
+	// The courier collects the 代引手数料 together with the order total
+	var codFeeUnits int64
+	if req.PaymentMethod == orderpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY {
+		codFeeUnits, err = codFee(totalUnits)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		totalUnits += codFeeUnits
+	}
+
 	orderNumber := fmt.Sprintf("ORD-%s", uuid.New().String())
 
 	orderID, err := s.queries.CreateOrder(ctx, db.CreateOrderParams{
-		OrderNumber:      orderNumber,
-		UserID:           pgutil.ToPG(userID),
-		Status:           int32(orderpb.OrderStatus_ORDER_STATUS_PENDING),
-		SubtotalUnits:    subtotalUnits,
-		SubtotalCurrency: "JPY",
-		TaxUnits:         taxUnits,
-		TaxCurrency:      "JPY",
-		DiscountUnits:    0,
-		DiscountCurrency: "JPY",
-		TotalUnits:       totalUnits,
-		TotalCurrency:    "JPY",
-		PointsApplied:    0,
-		ShippingAddress:  s.addressToBytes(req.ShippingAddress),
-		PaymentMethod:    int32(req.PaymentMethod),
+		OrderNumber:         orderNumber,
+		UserID:              pgutil.ToPG(userID),
+		Status:              int32(orderpb.OrderStatus_ORDER_STATUS_PENDING),
+		SubtotalUnits:       subtotalUnits,
+		SubtotalCurrency:    "JPY",
+		TaxUnits:            taxUnits,
+		TaxCurrency:         "JPY",
+		DiscountUnits:       0,
+		DiscountCurrency:    "JPY",
+		TotalUnits:          totalUnits,
+		TotalCurrency:       "JPY",
+		PointsApplied:       0,
+		ShippingAddress:     s.addressToBytes(req.ShippingAddress),
+		PaymentMethod:       int32(req.PaymentMethod),
+		CodFeeUnits:         codFeeUnits,
+		CodFeeCurrency:      "JPY",
+		ShippingFeeUnits:    shippingFeeUnits,
+		ShippingFeeCurrency: "JPY",
+		EstimatedDeliveryAt: timestamptzFromProto(shippingQuote.EstimatedDeliveryAt),
+		PickupPointID:       pgutil.ToPGFromString(req.PickupPointId),
 	})
 	if err != nil {
 		s.logger.Error("Failed to create order", zap.Error(err))
@@ -142,18 +205,22 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *orderpb.CreateOrder
 	// Publish order created event
 	if s.eventPublisher != nil {
 		orderProto := &orderpb.Order{
-			Id:              pgutil.FromPG(orderID),
-			OrderNumber:     orderNumber,
-			UserId:          req.UserId,
-			Status:          orderpb.OrderStatus_ORDER_STATUS_PENDING,
-			SubtotalAmount:  s.moneyToProto(subtotalUnits, "JPY"),
-			TaxAmount:       s.moneyToProto(taxUnits, "JPY"),
-			DiscountAmount:  s.moneyToProto(0, "JPY"),
-			TotalAmount:     s.moneyToProto(totalUnits, "JPY"),
-			PointsApplied:   0,
-			ShippingAddress: req.ShippingAddress,
-			PaymentMethod:   req.PaymentMethod,
-			CreatedAt:       timestamppb.Now(),
+			Id:                  pgutil.FromPG(orderID),
+			OrderNumber:         orderNumber,
+			UserId:              req.UserId,
+			Status:              orderpb.OrderStatus_ORDER_STATUS_PENDING,
+			SubtotalAmount:      s.moneyToProto(subtotalUnits, "JPY"),
+			TaxAmount:           s.moneyToProto(taxUnits, "JPY"),
+			DiscountAmount:      s.moneyToProto(0, "JPY"),
+			TotalAmount:         s.moneyToProto(totalUnits, "JPY"),
+			CodFee:              s.moneyToProto(codFeeUnits, "JPY"),
+			ShippingFee:         s.moneyToProto(shippingFeeUnits, "JPY"),
+			PointsApplied:       0,
+			ShippingAddress:     req.ShippingAddress,
+			PaymentMethod:       req.PaymentMethod,
+			CreatedAt:           timestamppb.Now(),
+			EstimatedDeliveryAt: shippingQuote.EstimatedDeliveryAt,
+			PickupPointId:       req.PickupPointId,
 		}
 		if err := s.eventPublisher.PublishOrderCreated(ctx, orderProto); err != nil {
 			s.logger.Warn("Failed to publish order created event", zap.Error(err))
@@ -324,6 +391,20 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, req *orderpb.Updat
 		}
 	}
 
+	if req.Status == orderpb.OrderStatus_ORDER_STATUS_CONFIRMED &&
+		orderpb.PaymentMethod(currentOrder.PaymentMethod) == orderpb.PaymentMethod_PAYMENT_METHOD_DEFERRED {
+		if err := s.checkCreditHold(ctx, req.OrderId); err != nil {
+			return nil, err
+		}
+	}
+
+	// Authorized card payments are captured once the goods leave the warehouse
+	if req.Status == orderpb.OrderStatus_ORDER_STATUS_SHIPPED {
+		if err := s.capturePayment(ctx, req.OrderId); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.queries.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
 		ID:     orderIDpg,
 		Status: int32(req.Status),
@@ -351,9 +432,226 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, req *orderpb.Updat
 		s.logger.Warn("Failed to invalidate order cache", zap.Error(err))
 	}
 
+	s.updateDeliverySlot(ctx, req.OrderId, req.Status)
+
 	return &sharedpb.Empty{}, nil
 }
 
+// autoTransition moves an order along the state machine's automatic
+// transition for condition. Orders not in the matching status are left alone.
+func (s *OrderService) autoTransition(ctx context.Context, orderID string, condition string, reason string) error {
+	if s.stateMachine == nil {
+		return nil
+	}
+
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return fmt.Errorf("invalid order_id: %w", err)
+	}
+	orderIDpg := pgutil.ToPG(id)
+
+	order, err := s.queries.GetOrder(ctx, orderIDpg)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	currentStatus := orderpb.OrderStatus(order.Status)
+	newStatus, ok := s.stateMachine.AutoTransition(orderID, currentStatus, condition)
+	if !ok {
+		s.logger.Info("No automatic transition for order",
+			zap.String("order_id", orderID),
+			zap.String("status", currentStatus.String()),
+			zap.String("condition", condition))
+		return nil
+	}
+
+	// As in UpdateOrderStatus, shipping captures the authorized payment
+	if newStatus == orderpb.OrderStatus_ORDER_STATUS_SHIPPED {
+		if err := s.capturePayment(ctx, orderID); err != nil {
+			return err
+		}
+	}
+	// An order cancelled after it was paid, e.g. one not picked up in time,
+	// gives the customer their money back
+	if newStatus == orderpb.OrderStatus_ORDER_STATUS_CANCELLED {
+		if err := s.releasePayment(ctx, orderID, reason); err != nil {
+			return err
+		}
+	}
+
+	if err := s.queries.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+		ID:     orderIDpg,
+		Status: int32(newStatus),
+	}); err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	s.logger.Info("Order status transition",
+		zap.String("order_id", orderID),
+		zap.String("from", currentStatus.String()),
+		zap.String("to", newStatus.String()),
+		zap.String("condition", condition))
+
+	if s.eventPublisher != nil {
+		if err := s.eventPublisher.PublishOrderStatusChanged(
+			ctx,
+			orderID,
+			pgutil.FromPG(order.UserID),
+			currentStatus,
+			newStatus,
+			reason,
+		); err != nil {
+			s.logger.Warn("Failed to publish status change event", zap.Error(err))
+		}
+	}
+
+	if err := s.cache.Delete(ctx, cache.OrderCacheKey(orderID)); err != nil {
+		s.logger.Warn("Failed to invalidate order cache", zap.Error(err))
+	}
+
+	s.updateDeliverySlot(ctx, orderID, newStatus)
+	if newStatus == orderpb.OrderStatus_ORDER_STATUS_EXPIRED {
+		s.releaseStock(ctx, orderID)
+	}
+
+	return nil
+}
+
+// releaseStock releases the stock reserved for an order. Reservations are
+// keyed by order ID; an order without one is a no-op for the inventory
+// service. Failures are logged, as the reservation also lapses on its own.
+func (s *OrderService) releaseStock(ctx context.Context, orderID string) {
+	if s.inventoryClient == nil {
+		return
+	}
+
+	if _, err := s.inventoryClient.ReleaseStock(ctx, &inventorypb.ReleaseStockRequest{ReservationId: orderID}); err != nil {
+		s.logger.Error("Failed to release stock for order", zap.String("order_id", orderID), zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Released stock for order", zap.String("order_id", orderID))
+}
+
+// updateDeliverySlot confirms the order's held delivery slot once the order
+// is confirmed and releases it when the order is cancelled or expires.
+// Failures are logged: an unconfirmed hold lapses on its own, and a slot
+// that was not released only costs capacity.
+func (s *OrderService) updateDeliverySlot(ctx context.Context, orderID string, newStatus orderpb.OrderStatus) {
+	if s.deliveryClient == nil {
+		return
+	}
+
+	switch newStatus {
+	case orderpb.OrderStatus_ORDER_STATUS_CONFIRMED:
+		_, err := s.deliveryClient.ConfirmReservation(ctx, &deliverypb.ConfirmReservationRequest{OrderId: orderID})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			s.logger.Info("No delivery slot held for order", zap.String("order_id", orderID))
+		case err != nil:
+			s.logger.Error("Failed to confirm delivery slot", zap.String("order_id", orderID), zap.Error(err))
+		default:
+			s.logger.Info("Confirmed delivery slot for order", zap.String("order_id", orderID))
+		}
+	case orderpb.OrderStatus_ORDER_STATUS_CANCELLED, orderpb.OrderStatus_ORDER_STATUS_EXPIRED:
+		resp, err := s.deliveryClient.ReleaseDeliverySlot(ctx, &deliverypb.ReleaseDeliverySlotRequest{OrderId: orderID})
+		if err != nil {
+			s.logger.Error("Failed to release delivery slot", zap.String("order_id", orderID), zap.Error(err))
+			return
+		}
+		if resp.Released {
+			s.logger.Info("Released delivery slot for order", zap.String("order_id", orderID))
+		}
+	}
+}
+
+// capturePayment captures the order's authorized payment. Orders without an
+// authorization (konbini, already captured, no payment yet) are left alone,
+// but an authorization that has lapsed keeps the order from shipping: the
+// goods would leave with nothing collected for them.
+func (s *OrderService) capturePayment(ctx context.Context, orderID string) error {
+	if s.paymentClient == nil {
+		return nil
+	}
+
+	resp, err := s.paymentClient.CapturePayment(ctx, &paymentpb.CapturePaymentRequest{OrderId: orderID})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.FailedPrecondition:
+			s.logger.Info("No authorized payment to capture",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+			return nil
+		case codes.Aborted:
+			s.logger.Warn("Payment authorization expired before capture",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+			return status.Error(codes.FailedPrecondition, "payment authorization has expired; the order must be paid again before it ships")
+		default:
+			s.logger.Error("Failed to capture payment", zap.String("order_id", orderID), zap.Error(err))
+			return status.Error(codes.Unavailable, "failed to capture payment")
+		}
+	}
+
+	s.logger.Info("Payment captured",
+		zap.String("order_id", orderID),
+		zap.String("transaction_id", resp.TransactionId))
+
+	return nil
+}
+
+// releasePayment voids the order's authorized payments and refunds the ones
+// already collected in full. Payments that collected nothing, such as cash
+// on delivery that was never handed over or an unpaid konbini slip, are left
+// to lapse in the payment service. A payment that moved on in the meantime
+// is skipped; other failures are returned so the cancellation is retried,
+// and payments already released are skipped on the retry.
+func (s *OrderService) releasePayment(ctx context.Context, orderID string, reason string) error {
+	if s.paymentClient == nil {
+		return nil
+	}
+
+	resp, err := s.paymentClient.ListPaymentsByOrder(ctx, &paymentpb.ListPaymentsByOrderRequest{OrderId: orderID})
+	if err != nil {
+		s.logger.Error("Failed to list payments", zap.String("order_id", orderID), zap.Error(err))
+		return status.Error(codes.Unavailable, "failed to list payments")
+	}
+
+	for _, payment := range resp.Payments {
+		switch payment.Status {
+		case paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED:
+			_, err = s.paymentClient.VoidPayment(ctx, &paymentpb.VoidPaymentRequest{PaymentId: payment.Id, Reason: reason})
+		case paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED,
+			paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED,
+			paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED:
+			_, err = s.paymentClient.RefundPayment(ctx, &paymentpb.RefundPaymentRequest{PaymentId: payment.Id, Reason: reason})
+		default:
+			continue
+		}
+
+		switch status.Code(err) {
+		case codes.OK:
+			s.logger.Info("Released payment of cancelled order",
+				zap.String("order_id", orderID),
+				zap.String("payment_id", payment.Id),
+				zap.String("status", payment.Status.String()))
+		case codes.NotFound, codes.FailedPrecondition:
+			s.logger.Info("Payment no longer needs releasing",
+				zap.String("order_id", orderID),
+				zap.String("payment_id", payment.Id),
+				zap.Error(err))
+		default:
+			s.logger.Error("Failed to release payment",
+				zap.String("order_id", orderID),
+				zap.String("payment_id", payment.Id),
+				zap.Error(err))
+			return status.Error(codes.Unavailable, "failed to release payment")
+		}
+	}
+
+	return nil
+}
+
 func (s *OrderService) CancelOrder(ctx context.Context, req *orderpb.CancelOrderRequest) (*sharedpb.Empty, error) {
 	ctx, span := otel.Tracer("order-service").Start(ctx, "OrderService.CancelOrder",
 		trace.WithAttributes(attribute.String("order.id", req.OrderId)),
@@ -390,6 +688,8 @@ func (s *OrderService) CancelOrder(ctx context.Context, req *orderpb.CancelOrder
 		s.logger.Warn("Failed to invalidate order cache", zap.Error(err))
 	}
 
+	s.updateDeliverySlot(ctx, req.OrderId, orderpb.OrderStatus_ORDER_STATUS_CANCELLED)
+
 	// Publish cancel event
 	if s.eventPublisher != nil {
 		orderProto := s.orderToProto(currentOrder)
@@ -452,39 +752,64 @@ func (s *OrderService) ReserveDeliverySlot(ctx context.Context, req *orderpb.Res
 		return nil, fmt.Errorf("cannot reserve slot for non-pending order")
 	}
 
+	if order.PickupPointID.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "pickup orders cannot reserve a delivery slot")
+	}
+
 	if req.SlotId == "" {
 		return nil, fmt.Errorf("delivery slot ID is required")
 	}
 
-	reservationID := fmt.Sprintf("RES-%s", uuid.New().String())
+	resp := &orderpb.ReserveDeliverySlotResponse{
+		ReservationId: fmt.Sprintf("RES-%s", uuid.New().String()),
+	}
+	// Without delivery-service the slot is only recorded locally
+	if s.deliveryClient != nil {
+		hold, err := s.deliveryClient.ReserveDeliverySlot(ctx, &deliverypb.ReserveDeliverySlotRequest{
+			SlotId:  req.SlotId,
+			OrderId: req.OrderId,
+		})
+		if err != nil {
+			s.logger.Error("Failed to hold delivery slot",
+				zap.String("order_id", req.OrderId),
+				zap.String("slot", req.SlotId),
+				zap.Error(err))
+			return nil, err
+		}
+		resp.ReservationId = hold.ReservationId
+		resp.HoldExpiresAt = hold.ExpiresAt
+	}
 
 	cacheKey := cache.OrderCacheKey(req.OrderId)
 	if err := s.cache.Delete(ctx, cacheKey); err != nil {
 		s.logger.Warn("Failed to invalidate order cache", zap.Error(err))
 	}
 
-	return &orderpb.ReserveDeliverySlotResponse{
-		ReservationId: reservationID,
-	}, nil
+	return resp, nil
 }
 
 func (s *OrderService) orderToProto(o db.OrdersOrders) *orderpb.Order {
 	status := orderpb.OrderStatus(o.Status)
 
 	return &orderpb.Order{
-		Id:              pgutil.FromPG(o.ID),
-		OrderNumber:     o.OrderNumber,
-		UserId:          pgutil.FromPG(o.UserID),
-		Status:          status,
-		SubtotalAmount:  s.moneyToProto(o.SubtotalUnits, o.SubtotalCurrency),
-		TaxAmount:       s.moneyToProto(o.TaxUnits, o.TaxCurrency),
-		DiscountAmount:  s.moneyToProto(o.DiscountUnits, o.DiscountCurrency),
-		TotalAmount:     s.moneyToProto(o.TotalUnits, o.TotalCurrency),
-		PointsApplied:   int64(o.PointsApplied),
-		ShippingAddress: s.bytesToAddress(o.ShippingAddress),
-		PaymentMethod:   orderpb.PaymentMethod(o.PaymentMethod),
-		CreatedAt:       protoTimeFromTimestamptz(o.CreatedAt),
-		UpdatedAt:       protoTimeFromTimestamptz(o.UpdatedAt),
+		Id:                  pgutil.FromPG(o.ID),
+		OrderNumber:         o.OrderNumber,
+		UserId:              pgutil.FromPG(o.UserID),
+		Status:              status,
+		SubtotalAmount:      s.moneyToProto(o.SubtotalUnits, o.SubtotalCurrency),
+		TaxAmount:           s.moneyToProto(o.TaxUnits, o.TaxCurrency),
+		DiscountAmount:      s.moneyToProto(o.DiscountUnits, o.DiscountCurrency),
+		TotalAmount:         s.moneyToProto(o.TotalUnits, o.TotalCurrency),
+		CodFee:              s.moneyToProto(o.CodFeeUnits, o.CodFeeCurrency),
+		ShippingFee:         s.moneyToProto(o.ShippingFeeUnits, o.ShippingFeeCurrency),
+		PointsApplied:       int64(o.PointsApplied),
+		ShippingAddress:     s.bytesToAddress(o.ShippingAddress),
+		PaymentMethod:       orderpb.PaymentMethod(o.PaymentMethod),
+		CreatedAt:           protoTimeFromTimestamptz(o.CreatedAt),
+		UpdatedAt:           protoTimeFromTimestamptz(o.UpdatedAt),
+		EstimatedDeliveryAt: protoTimeFromTimestamptz(o.EstimatedDeliveryAt),
+		PickupPointId:       pgutil.FromPG(o.PickupPointID),
+		Disputed:            o.Disputed,
 	}
 }
 
@@ -554,6 +879,13 @@ func protoTimeFromTimestamptz(ts pgtype.Timestamptz) *timestamppb.Timestamp {
 	return timestamppb.New(ts.Time)
 }
 
+func timestamptzFromProto(ts *timestamppb.Timestamp) pgtype.Timestamptz {
+	if ts == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: ts.AsTime(), Valid: true}
+}
+
 func (s *OrderService) bytesToAddress(data []byte) *orderpb.ShippingAddress {
 	if len(data) == 0 {
 		return &orderpb.ShippingAddress{}
@@ -12,8 +12,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	inventorypb "github.com/afasari/shinkansen-commerce/gen/proto/go/inventory"
 	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	productpb "github.com/afasari/shinkansen-commerce/gen/proto/go/product"
 	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
@@ -66,6 +72,11 @@ func (m *MockQuerier) UpdateOrderWithPoints(ctx context.Context, params db.Updat
 	return args.Error(0)
 }
 
+func (m *MockQuerier) SetOrderDisputed(ctx context.Context, params db.SetOrderDisputedParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
 // MockProductClient is a mock implementation of productpb.ProductServiceClient
 type MockProductClient struct {
 	mock.Mock
@@ -128,6 +139,115 @@ func (m *MockProductClient) UpdateProduct(ctx context.Context, req *productpb.Up
 }
 
 // MockCache is a mock implementation of cache.Cache
+// MockPaymentClient is a mock implementation of paymentpb.PaymentServiceClient.
+// Only the methods the order service calls are overridden.
+type MockPaymentClient struct {
+	mock.Mock
+	paymentpb.PaymentServiceClient
+}
+
+func (m *MockPaymentClient) CapturePayment(ctx context.Context, req *paymentpb.CapturePaymentRequest, opts ...grpc.CallOption) (*paymentpb.CapturePaymentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.CapturePaymentResponse), args.Error(1)
+}
+
+func (m *MockPaymentClient) GetDeferredPayment(ctx context.Context, req *paymentpb.GetDeferredPaymentRequest, opts ...grpc.CallOption) (*paymentpb.GetDeferredPaymentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.GetDeferredPaymentResponse), args.Error(1)
+}
+
+func (m *MockPaymentClient) ListPaymentsByOrder(ctx context.Context, req *paymentpb.ListPaymentsByOrderRequest, opts ...grpc.CallOption) (*paymentpb.ListPaymentsByOrderResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListPaymentsByOrderResponse), args.Error(1)
+}
+
+func (m *MockPaymentClient) VoidPayment(ctx context.Context, req *paymentpb.VoidPaymentRequest, opts ...grpc.CallOption) (*sharedpb.Empty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharedpb.Empty), args.Error(1)
+}
+
+func (m *MockPaymentClient) RefundPayment(ctx context.Context, req *paymentpb.RefundPaymentRequest, opts ...grpc.CallOption) (*sharedpb.Empty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharedpb.Empty), args.Error(1)
+}
+
+// MockInventoryClient is a mock implementation of
+// inventorypb.InventoryServiceClient
+type MockInventoryClient struct {
+	mock.Mock
+	inventorypb.InventoryServiceClient
+}
+
+func (m *MockInventoryClient) ReleaseStock(ctx context.Context, req *inventorypb.ReleaseStockRequest, opts ...grpc.CallOption) (*sharedpb.Empty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharedpb.Empty), args.Error(1)
+}
+
+// MockDeliveryClient is a mock implementation of
+// deliverypb.DeliveryServiceClient
+type MockDeliveryClient struct {
+	mock.Mock
+	deliverypb.DeliveryServiceClient
+}
+
+func (m *MockDeliveryClient) ReserveDeliverySlot(ctx context.Context, req *deliverypb.ReserveDeliverySlotRequest, opts ...grpc.CallOption) (*deliverypb.ReserveDeliverySlotResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ReserveDeliverySlotResponse), args.Error(1)
+}
+
+func (m *MockDeliveryClient) ConfirmReservation(ctx context.Context, req *deliverypb.ConfirmReservationRequest, opts ...grpc.CallOption) (*deliverypb.ConfirmReservationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ConfirmReservationResponse), args.Error(1)
+}
+
+func (m *MockDeliveryClient) ReleaseDeliverySlot(ctx context.Context, req *deliverypb.ReleaseDeliverySlotRequest, opts ...grpc.CallOption) (*deliverypb.ReleaseDeliverySlotResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.ReleaseDeliverySlotResponse), args.Error(1)
+}
+
+func (m *MockDeliveryClient) GetPickupPoint(ctx context.Context, req *deliverypb.GetPickupPointRequest, opts ...grpc.CallOption) (*deliverypb.GetPickupPointResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.GetPickupPointResponse), args.Error(1)
+}
+
+func (m *MockDeliveryClient) QuoteShipping(ctx context.Context, req *deliverypb.QuoteShippingRequest, opts ...grpc.CallOption) (*deliverypb.QuoteShippingResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deliverypb.QuoteShippingResponse), args.Error(1)
+}
+
 type MockCache struct {
 	mock.Mock
 }
@@ -309,6 +429,253 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 	})
 }
 
+func TestOrderService_UpdateOrderStatus_CapturesOnShipment(t *testing.T) {
+	logger := zap.NewNop()
+
+	setup := func() (*OrderService, *MockQuerier, *MockPaymentClient, uuid.UUID) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		mockPayments := new(MockPaymentClient)
+		orderID := uuid.New()
+
+		mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("GetOrder", mock.Anything, mock.Anything).Return(db.OrdersOrders{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(orderpb.OrderStatus_ORDER_STATUS_PROCESSING),
+		}, nil)
+
+		service := NewOrderService(mockQueries, new(MockProductClient), mockCache, logger)
+		service.SetPaymentClient(mockPayments)
+		return service, mockQueries, mockPayments, orderID
+	}
+
+	t.Run("shipping captures the authorized payment", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("CapturePayment", mock.Anything, &paymentpb.CapturePaymentRequest{OrderId: orderID.String()}).
+			Return(&paymentpb.CapturePaymentResponse{Status: paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED}, nil)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, mock.AnythingOfType("db.UpdateOrderStatusParams")).Return(nil)
+
+		_, err := service.UpdateOrderStatus(context.Background(), &orderpb.UpdateOrderStatusRequest{
+			OrderId: orderID.String(),
+			Status:  orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+		})
+
+		require.NoError(t, err)
+		mockPayments.AssertExpectations(t)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("payment without authorization is tolerated", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("CapturePayment", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.FailedPrecondition, "not in authorized status"))
+		mockQueries.On("UpdateOrderStatus", mock.Anything, mock.AnythingOfType("db.UpdateOrderStatusParams")).Return(nil)
+
+		_, err := service.UpdateOrderStatus(context.Background(), &orderpb.UpdateOrderStatusRequest{
+			OrderId: orderID.String(),
+			Status:  orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("expired authorization keeps the order unshipped", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("CapturePayment", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Aborted, "authorization has expired"))
+
+		_, err := service.UpdateOrderStatus(context.Background(), &orderpb.UpdateOrderStatusRequest{
+			OrderId: orderID.String(),
+			Status:  orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("capture failure keeps the order unshipped", func(t *testing.T) {
+		service, mockQueries, mockPayments, orderID := setup()
+
+		mockPayments.On("CapturePayment", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Unavailable, "gateway timeout"))
+
+		_, err := service.UpdateOrderStatus(context.Background(), &orderpb.UpdateOrderStatusRequest{
+			OrderId: orderID.String(),
+			Status:  orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+		})
+
+		assert.Error(t, err)
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_HandlePaymentEvents(t *testing.T) {
+	logger := zap.NewNop()
+
+	setup := func(status orderpb.OrderStatus) (*OrderService, *MockQuerier, uuid.UUID) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		orderID := uuid.New()
+
+		mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("GetOrder", mock.Anything, pgutil.ToPG(orderID)).Return(db.OrdersOrders{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(status),
+		}, nil)
+
+		return NewOrderService(mockQueries, new(MockProductClient), mockCache, logger), mockQueries, orderID
+	}
+
+	t.Run("payment.completed confirms a pending order", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_PENDING)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, db.UpdateOrderStatusParams{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(orderpb.OrderStatus_ORDER_STATUS_CONFIRMED),
+		}).Return(nil)
+
+		err := service.HandlePaymentCompleted(context.Background(), PaymentEvent{
+			EventType: "payment.completed",
+			OrderID:   orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("payment.failed expires a pending order", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_PENDING)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, db.UpdateOrderStatusParams{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(orderpb.OrderStatus_ORDER_STATUS_EXPIRED),
+		}).Return(nil)
+
+		err := service.HandlePaymentFailed(context.Background(), PaymentEvent{
+			EventType: "payment.failed",
+			OrderID:   orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("payment.expired expires the order and releases its stock", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_PENDING)
+		mockInventory := new(MockInventoryClient)
+		service.SetInventoryClient(mockInventory)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, db.UpdateOrderStatusParams{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(orderpb.OrderStatus_ORDER_STATUS_EXPIRED),
+		}).Return(nil)
+		mockInventory.On("ReleaseStock", mock.Anything, &inventorypb.ReleaseStockRequest{ReservationId: orderID.String()}).
+			Return(&sharedpb.Empty{}, nil)
+
+		err := service.HandlePaymentFailed(context.Background(), PaymentEvent{
+			EventType: "payment.expired",
+			OrderID:   orderID.String(),
+			Data:      map[string]interface{}{"reason": "konbini payment deadline passed"},
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockInventory.AssertExpectations(t)
+	})
+
+	t.Run("payment.completed confirms the held delivery slot", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_PENDING)
+		mockDelivery := new(MockDeliveryClient)
+		service.SetDeliveryClient(mockDelivery)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(nil)
+		mockDelivery.On("ConfirmReservation", mock.Anything, &deliverypb.ConfirmReservationRequest{OrderId: orderID.String()}).
+			Return(&deliverypb.ConfirmReservationResponse{ReservationId: uuid.New().String()}, nil)
+
+		err := service.HandlePaymentCompleted(context.Background(), PaymentEvent{
+			EventType: "payment.completed",
+			OrderID:   orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockDelivery.AssertExpectations(t)
+		mockDelivery.AssertNotCalled(t, "ReleaseDeliverySlot", mock.Anything, mock.Anything)
+	})
+
+	t.Run("payment.expired releases the held delivery slot", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_PENDING)
+		mockDelivery := new(MockDeliveryClient)
+		service.SetDeliveryClient(mockDelivery)
+		mockQueries.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(nil)
+		mockDelivery.On("ReleaseDeliverySlot", mock.Anything, &deliverypb.ReleaseDeliverySlotRequest{OrderId: orderID.String()}).
+			Return(&deliverypb.ReleaseDeliverySlotResponse{Released: true}, nil)
+
+		err := service.HandlePaymentFailed(context.Background(), PaymentEvent{
+			EventType: "payment.expired",
+			OrderID:   orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockDelivery.AssertExpectations(t)
+	})
+
+	t.Run("payment.expired for a confirmed order keeps its stock", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_CONFIRMED)
+		mockInventory := new(MockInventoryClient)
+		service.SetInventoryClient(mockInventory)
+
+		err := service.HandlePaymentFailed(context.Background(), PaymentEvent{
+			EventType: "payment.expired",
+			OrderID:   orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+		mockInventory.AssertNotCalled(t, "ReleaseStock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("completed payment for a confirmed order is a no-op", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_CONFIRMED)
+
+		err := service.HandlePaymentCompleted(context.Background(), PaymentEvent{
+			EventType: "payment.completed",
+			OrderID:   orderID.String(),
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dispute events set the disputed flag", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_DELIVERED)
+		mockQueries.On("SetOrderDisputed", mock.Anything, db.SetOrderDisputedParams{
+			ID:       pgutil.ToPG(orderID),
+			Disputed: true,
+		}).Return(nil)
+
+		err := service.HandlePaymentDisputeUpdated(context.Background(), PaymentEvent{
+			EventType: "payment.dispute_opened",
+			OrderID:   orderID.String(),
+			Data:      map[string]interface{}{"order_disputed": true},
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertCalled(t, "SetOrderDisputed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dispute event without the flag is rejected", func(t *testing.T) {
+		service, mockQueries, orderID := setup(orderpb.OrderStatus_ORDER_STATUS_DELIVERED)
+
+		err := service.HandlePaymentDisputeUpdated(context.Background(), PaymentEvent{
+			EventType: "payment.dispute_closed",
+			OrderID:   orderID.String(),
+		})
+
+		assert.Error(t, err)
+		mockQueries.AssertNotCalled(t, "SetOrderDisputed", mock.Anything, mock.Anything)
+	})
+}
+
 func TestOrderService_CancelOrder(t *testing.T) {
 	logger := zap.NewNop()
 	mockQueries := new(MockQuerier)
@@ -452,6 +819,44 @@ func TestOrderService_ReserveDeliverySlot(t *testing.T) {
 		assert.NotEmpty(t, resp.ReservationId)
 	})
 
+	t.Run("holds the slot in delivery-service", func(t *testing.T) {
+		mockDelivery := new(MockDeliveryClient)
+		holdService := NewOrderService(mockQueries, mockProductClient, mockCache, logger)
+		holdService.SetDeliveryClient(mockDelivery)
+		slotID := uuid.New().String()
+		expiresAt := timestamppb.New(time.Now().Add(30 * time.Minute))
+		mockDelivery.On("ReserveDeliverySlot", mock.Anything, &deliverypb.ReserveDeliverySlotRequest{
+			SlotId:  slotID,
+			OrderId: orderID.String(),
+		}).Return(&deliverypb.ReserveDeliverySlotResponse{ReservationId: "res-1", ExpiresAt: expiresAt}, nil)
+
+		resp, err := holdService.ReserveDeliverySlot(context.Background(), &orderpb.ReserveDeliverySlotRequest{
+			OrderId: orderID.String(),
+			SlotId:  slotID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "res-1", resp.ReservationId)
+		assert.Equal(t, expiresAt, resp.HoldExpiresAt)
+		mockDelivery.AssertExpectations(t)
+	})
+
+	t.Run("full slot fails", func(t *testing.T) {
+		mockDelivery := new(MockDeliveryClient)
+		holdService := NewOrderService(mockQueries, mockProductClient, mockCache, logger)
+		holdService.SetDeliveryClient(mockDelivery)
+		mockDelivery.On("ReserveDeliverySlot", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.FailedPrecondition, "delivery slot is full or does not exist"))
+
+		resp, err := holdService.ReserveDeliverySlot(context.Background(), &orderpb.ReserveDeliverySlotRequest{
+			OrderId: orderID.String(),
+			SlotId:  uuid.New().String(),
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
 	t.Run("missing slot id fails", func(t *testing.T) {
 		mockOrder := db.OrdersOrders{
 			ID:     pgutil.ToPG(orderID),
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/pkg/pgutil"
+)
+
+// PaymentEvent is an event published by payment-service
+type PaymentEvent struct {
+	EventID   string                 `json:"event_id"`
+	EventType string                 `json:"event_type"`
+	PaymentID string                 `json:"payment_id"`
+	OrderID   string                 `json:"order_id"`
+	Status    string                 `json:"status"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// PaymentEventHandler handles payment events
+type PaymentEventHandler interface {
+	HandlePaymentAuthorized(ctx context.Context, event PaymentEvent) error
+	HandlePaymentCompleted(ctx context.Context, event PaymentEvent) error
+	HandlePaymentFailed(ctx context.Context, event PaymentEvent) error
+	HandlePaymentRefunded(ctx context.Context, event PaymentEvent) error
+	HandlePaymentDisputeUpdated(ctx context.Context, event PaymentEvent) error
+}
+
+// PaymentEventConsumer consumes payment events
+type PaymentEventConsumer struct {
+	consumer     sarama.ConsumerGroup
+	topic        string
+	handler      PaymentEventHandler
+	logger       *zap.Logger
+	retryBackoff time.Duration
+}
+
+// NewPaymentEventConsumer creates a new payment event consumer
+func NewPaymentEventConsumer(
+	brokers []string,
+	groupID, topic string,
+	handler PaymentEventHandler,
+	logger *zap.Logger,
+) (*PaymentEventConsumer, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_8_0_0
+	balanceStrategy := sarama.NewBalanceStrategyRoundRobin()
+	config.Consumer.Group.Rebalance.Strategy = balanceStrategy
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	consumer, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	return &PaymentEventConsumer{
+		consumer:     consumer,
+		topic:        topic,
+		handler:      handler,
+		logger:       logger,
+		retryBackoff: eventRetryBackoff,
+	}, nil
+}
+
+// Consume starts consuming events
+func (c *PaymentEventConsumer) Consume(ctx context.Context) error {
+	for {
+		if err := c.consumer.Consume(ctx, []string{c.topic}, c); err != nil {
+			return fmt.Errorf("error from consumer: %w", err)
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// Setup is called at the beginning of a new session
+func (c *PaymentEventConsumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is called at the end of a session
+func (c *PaymentEventConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim processes messages. As with shipment events, an event whose
+// handling fails for a reason that may pass is retried in place and only
+// marked once handled, so that a payment outcome is not lost while the
+// database is down. When the session ends first, the event is left unmarked
+// and is consumed again by the next session.
+func (c *PaymentEventConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		var event PaymentEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			c.logger.Error("Failed to unmarshal payment event", zap.Error(err))
+			session.MarkMessage(msg, "")
+			continue
+		}
+		if _, err := uuid.Parse(event.OrderID); err != nil {
+			c.logger.Error("Payment event has an invalid order_id",
+				zap.String("event_type", event.EventType),
+				zap.String("order_id", event.OrderID))
+			session.MarkMessage(msg, "")
+			continue
+		}
+
+		if !handleEventWithRetry(session.Context(), c.logger, c.retryBackoff, func(ctx context.Context) error {
+			return c.handle(ctx, event)
+		}, zap.String("event_type", event.EventType), zap.String("order_id", event.OrderID)) {
+			return nil
+		}
+
+		session.MarkMessage(msg, "")
+	}
+
+	return nil
+}
+
+// handle passes one payment event to its handler
+func (c *PaymentEventConsumer) handle(ctx context.Context, event PaymentEvent) error {
+	switch event.EventType {
+	case "payment.authorized":
+		return c.handler.HandlePaymentAuthorized(ctx, event)
+	case "payment.completed":
+		return c.handler.HandlePaymentCompleted(ctx, event)
+	case "payment.failed", "payment.expired":
+		return c.handler.HandlePaymentFailed(ctx, event)
+	case "payment.refunded":
+		return c.handler.HandlePaymentRefunded(ctx, event)
+	case "payment.dispute_opened", "payment.dispute_closed":
+		return c.handler.HandlePaymentDisputeUpdated(ctx, event)
+	}
+	return nil
+}
+
+// Close closes the consumer
+func (c *PaymentEventConsumer) Close() error {
+	return c.consumer.Close()
+}
+
+// HandlePaymentCompleted confirms a pending order once its payment settles
+func (s *OrderService) HandlePaymentCompleted(ctx context.Context, event PaymentEvent) error {
+	return s.autoTransition(ctx, event.OrderID, "payment_completed", "payment "+event.PaymentID+" completed")
+}
+
+// HandlePaymentAuthorized confirms a pending order held for a deferred
+// payment credit check once the check is approved
+func (s *OrderService) HandlePaymentAuthorized(ctx context.Context, event PaymentEvent) error {
+	return s.autoTransition(ctx, event.OrderID, "payment_authorized", "payment "+event.PaymentID+" authorized")
+}
+
+// HandlePaymentFailed expires a pending order whose payment failed or lapsed
+func (s *OrderService) HandlePaymentFailed(ctx context.Context, event PaymentEvent) error {
+	reason, _ := event.Data["reason"].(string)
+	if reason == "" {
+		reason = event.EventType
+	}
+	return s.autoTransition(ctx, event.OrderID, "payment_timeout", reason)
+}
+
+// HandlePaymentRefunded records a refund against the order. Refunds do not
+// change the order status; returns are handled through UpdateOrderStatus.
+func (s *OrderService) HandlePaymentRefunded(ctx context.Context, event PaymentEvent) error {
+	s.logger.Info("Payment refunded for order",
+		zap.String("order_id", event.OrderID),
+		zap.String("payment_id", event.PaymentID),
+		zap.Any("refund_amount", event.Data["refund_amount"]))
+	return nil
+}
+
+// HandlePaymentDisputeUpdated flags the order while any of its payments has
+// an open chargeback dispute. A lost dispute also charges the payment back,
+// which leaves the order status to the back office.
+func (s *OrderService) HandlePaymentDisputeUpdated(ctx context.Context, event PaymentEvent) error {
+	disputed, ok := event.Data["order_disputed"].(bool)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "missing order_disputed in %s event", event.EventType)
+	}
+
+	id, err := uuid.Parse(event.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order_id: %w", err)
+	}
+
+	if err := s.queries.SetOrderDisputed(ctx, db.SetOrderDisputedParams{ID: pgutil.ToPG(id), Disputed: disputed}); err != nil {
+		return fmt.Errorf("failed to flag order dispute: %w", err)
+	}
+
+	if err := s.cache.Delete(ctx, cache.OrderCacheKey(event.OrderID)); err != nil {
+		s.logger.Warn("Failed to invalidate order cache", zap.Error(err))
+	}
+
+	s.logger.Info("Order dispute flag updated",
+		zap.String("order_id", event.OrderID),
+		zap.String("event_type", event.EventType),
+		zap.Bool("disputed", disputed))
+	return nil
+}
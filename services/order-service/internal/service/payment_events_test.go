@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePaymentHandler fails with errs in turn, then succeeds
+type fakePaymentHandler struct {
+	errs  []error
+	calls int
+}
+
+func (h *fakePaymentHandler) next() error {
+	h.calls++
+	if len(h.errs) == 0 {
+		return nil
+	}
+	err := h.errs[0]
+	h.errs = h.errs[1:]
+	return err
+}
+
+func (h *fakePaymentHandler) HandlePaymentAuthorized(context.Context, PaymentEvent) error {
+	return h.next()
+}
+
+func (h *fakePaymentHandler) HandlePaymentCompleted(context.Context, PaymentEvent) error {
+	return h.next()
+}
+
+func (h *fakePaymentHandler) HandlePaymentFailed(context.Context, PaymentEvent) error {
+	return h.next()
+}
+
+func (h *fakePaymentHandler) HandlePaymentRefunded(context.Context, PaymentEvent) error {
+	return h.next()
+}
+
+func (h *fakePaymentHandler) HandlePaymentDisputeUpdated(context.Context, PaymentEvent) error {
+	return h.next()
+}
+
+func TestPaymentEventConsumer_ConsumeClaim(t *testing.T) {
+	consume := func(handler *fakePaymentHandler) (*fakeConsumerSession, *sarama.ConsumerMessage) {
+		value, err := json.Marshal(PaymentEvent{EventType: "payment.completed", PaymentID: uuid.New().String(), OrderID: uuid.New().String()})
+		require.NoError(t, err)
+		msg := &sarama.ConsumerMessage{Value: value}
+		claim := &fakeConsumerClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+		claim.messages <- msg
+		close(claim.messages)
+
+		consumer := &PaymentEventConsumer{handler: handler, logger: zap.NewNop(), retryBackoff: time.Millisecond}
+		session := &fakeConsumerSession{ctx: context.Background()}
+		require.NoError(t, consumer.ConsumeClaim(session, claim))
+		return session, msg
+	}
+
+	t.Run("retries payment.completed until the order can be confirmed", func(t *testing.T) {
+		handler := &fakePaymentHandler{errs: []error{
+			errors.New("failed to get order: connection refused"),
+			errors.New("failed to update order status: connection refused"),
+		}}
+
+		session, msg := consume(handler)
+
+		assert.Equal(t, 3, handler.calls)
+		assert.Equal(t, []*sarama.ConsumerMessage{msg}, session.marked)
+	})
+
+	t.Run("gives up on an event the order rejects", func(t *testing.T) {
+		handler := &fakePaymentHandler{errs: []error{
+			status.Error(codes.InvalidArgument, "missing order_disputed in payment.dispute_opened event"),
+		}}
+
+		session, msg := consume(handler)
+
+		assert.Equal(t, 1, handler.calls)
+		assert.Equal(t, []*sarama.ConsumerMessage{msg}, session.marked)
+	})
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ShipmentEvent is an event published by delivery-service
+type ShipmentEvent struct {
+	EventID    string                 `json:"event_id"`
+	EventType  string                 `json:"event_type"`
+	ShipmentID string                 `json:"shipment_id"`
+	OrderID    string                 `json:"order_id"`
+	Status     string                 `json:"status"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// ShipmentEventHandler handles shipment events
+type ShipmentEventHandler interface {
+	HandleShipmentShipped(ctx context.Context, event ShipmentEvent) error
+	HandleShipmentInTransit(ctx context.Context, event ShipmentEvent) error
+	HandleShipmentDelivered(ctx context.Context, event ShipmentEvent) error
+	HandleShipmentFailed(ctx context.Context, event ShipmentEvent) error
+	HandleShipmentReadyForPickup(ctx context.Context, event ShipmentEvent) error
+	HandleShipmentPickedUp(ctx context.Context, event ShipmentEvent) error
+	HandleShipmentReturned(ctx context.Context, event ShipmentEvent) error
+}
+
+// ShipmentEventConsumer consumes shipment events
+type ShipmentEventConsumer struct {
+	consumer     sarama.ConsumerGroup
+	topic        string
+	handler      ShipmentEventHandler
+	logger       *zap.Logger
+	retryBackoff time.Duration
+}
+
+// NewShipmentEventConsumer creates a new shipment event consumer
+func NewShipmentEventConsumer(
+	brokers []string,
+	groupID, topic string,
+	handler ShipmentEventHandler,
+	logger *zap.Logger,
+) (*ShipmentEventConsumer, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_8_0_0
+	balanceStrategy := sarama.NewBalanceStrategyRoundRobin()
+	config.Consumer.Group.Rebalance.Strategy = balanceStrategy
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	consumer, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	return &ShipmentEventConsumer{
+		consumer:     consumer,
+		topic:        topic,
+		handler:      handler,
+		logger:       logger,
+		retryBackoff: eventRetryBackoff,
+	}, nil
+}
+
+// Consume starts consuming events
+func (c *ShipmentEventConsumer) Consume(ctx context.Context) error {
+	for {
+		if err := c.consumer.Consume(ctx, []string{c.topic}, c); err != nil {
+			return fmt.Errorf("error from consumer: %w", err)
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// Setup is called at the beginning of a new session
+func (c *ShipmentEventConsumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is called at the end of a session
+func (c *ShipmentEventConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim processes messages. An event whose handling fails for a
+// reason that may pass is retried in place and is only marked once handled:
+// the events of a shipment must be applied in order, and a later offset
+// being marked would commit past it. When the session ends first, the event
+// is left unmarked and is consumed again by the next session.
+func (c *ShipmentEventConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		var event ShipmentEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			c.logger.Error("Failed to unmarshal shipment event", zap.Error(err))
+			session.MarkMessage(msg, "")
+			continue
+		}
+		if _, err := uuid.Parse(event.OrderID); err != nil {
+			c.logger.Error("Shipment event has an invalid order_id",
+				zap.String("event_type", event.EventType),
+				zap.String("order_id", event.OrderID))
+			session.MarkMessage(msg, "")
+			continue
+		}
+
+		if !handleEventWithRetry(session.Context(), c.logger, c.retryBackoff, func(ctx context.Context) error {
+			return c.handle(ctx, event)
+		}, zap.String("event_type", event.EventType), zap.String("order_id", event.OrderID)) {
+			return nil
+		}
+
+		session.MarkMessage(msg, "")
+	}
+
+	return nil
+}
+
+// handle passes one shipment event to its handler
+func (c *ShipmentEventConsumer) handle(ctx context.Context, event ShipmentEvent) error {
+	switch event.EventType {
+	case "shipment.shipped":
+		return c.handler.HandleShipmentShipped(ctx, event)
+	case "shipment.in_transit":
+		return c.handler.HandleShipmentInTransit(ctx, event)
+	case "shipment.delivered":
+		return c.handler.HandleShipmentDelivered(ctx, event)
+	case "shipment.failed":
+		return c.handler.HandleShipmentFailed(ctx, event)
+	case "shipment.ready_for_pickup":
+		return c.handler.HandleShipmentReadyForPickup(ctx, event)
+	case "shipment.picked_up":
+		return c.handler.HandleShipmentPickedUp(ctx, event)
+	case "shipment.returned":
+		return c.handler.HandleShipmentReturned(ctx, event)
+	}
+	return nil
+}
+
+// Close closes the consumer
+func (c *ShipmentEventConsumer) Close() error {
+	return c.consumer.Close()
+}
+
+// followShipment moves an order through the state machine's shipment
+// conditions in turn. Carriers can skip statuses and events can be lost, so
+// an order catches up through the earlier steps; steps that don't apply to
+// its status are no-ops.
+func (s *OrderService) followShipment(ctx context.Context, event ShipmentEvent, conditions ...string) error {
+	reason := "shipment " + event.ShipmentID + " " + event.Status
+	for _, condition := range conditions {
+		if err := s.autoTransition(ctx, event.OrderID, condition, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleShipmentShipped marks an order shipped once the carrier has its
+// parcel, capturing an authorized card payment
+func (s *OrderService) HandleShipmentShipped(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped")
+}
+
+// HandleShipmentInTransit marks a shipped order in transit
+func (s *OrderService) HandleShipmentInTransit(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped", "shipment_in_transit")
+}
+
+// HandleShipmentDelivered marks an order delivered, including one whose
+// earlier delivery attempt failed
+func (s *OrderService) HandleShipmentDelivered(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped", "shipment_in_transit", "delivery_confirmed")
+}
+
+// HandleShipmentFailed marks an order whose delivery attempt failed
+func (s *OrderService) HandleShipmentFailed(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped", "shipment_in_transit", "delivery_failed")
+}
+
+// HandleShipmentReadyForPickup marks an order ready for pickup once its
+// parcel reaches the konbini or locker
+func (s *OrderService) HandleShipmentReadyForPickup(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped", "shipment_in_transit", "shipment_ready_for_pickup")
+}
+
+// HandleShipmentPickedUp marks an order picked up once the customer has
+// collected it
+func (s *OrderService) HandleShipmentPickedUp(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped", "shipment_in_transit", "shipment_ready_for_pickup", "pickup_completed")
+}
+
+// HandleShipmentReturned cancels an order that was not collected by its
+// pickup deadline
+func (s *OrderService) HandleShipmentReturned(ctx context.Context, event ShipmentEvent) error {
+	return s.followShipment(ctx, event, "shipment_prepared", "shipment_shipped", "shipment_in_transit", "shipment_ready_for_pickup", "pickup_timeout")
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/pkg/pgutil"
+)
+
+func TestOrderService_HandleShipmentEvents(t *testing.T) {
+	// setup returns an order that reads as each of statuses in turn, one per
+	// step of the event's catch-up
+	setup := func(statuses ...orderpb.OrderStatus) (*OrderService, *MockQuerier, uuid.UUID) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		orderID := uuid.New()
+
+		mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		for _, status := range statuses {
+			mockQueries.On("GetOrder", mock.Anything, pgutil.ToPG(orderID)).Return(db.OrdersOrders{
+				ID:     pgutil.ToPG(orderID),
+				Status: int32(status),
+			}, nil).Once()
+		}
+
+		return NewOrderService(mockQueries, new(MockProductClient), mockCache, zap.NewNop()), mockQueries, orderID
+	}
+	expectStatus := func(mockQueries *MockQuerier, orderID uuid.UUID, status orderpb.OrderStatus) {
+		mockQueries.On("UpdateOrderStatus", mock.Anything, db.UpdateOrderStatusParams{
+			ID:     pgutil.ToPG(orderID),
+			Status: int32(status),
+		}).Return(nil).Once()
+	}
+	event := func(eventType string, orderID uuid.UUID) ShipmentEvent {
+		return ShipmentEvent{EventType: eventType, ShipmentID: uuid.New().String(), OrderID: orderID.String()}
+	}
+
+	t.Run("shipment.shipped ships a processing order and captures its payment", func(t *testing.T) {
+		service, mockQueries, orderID := setup(
+			orderpb.OrderStatus_ORDER_STATUS_PROCESSING,
+			orderpb.OrderStatus_ORDER_STATUS_PROCESSING,
+		)
+		mockPayments := new(MockPaymentClient)
+		service.SetPaymentClient(mockPayments)
+		mockPayments.On("CapturePayment", mock.Anything, &paymentpb.CapturePaymentRequest{OrderId: orderID.String()}).
+			Return(&paymentpb.CapturePaymentResponse{Status: paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED}, nil)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_SHIPPED)
+
+		err := service.HandleShipmentShipped(context.Background(), event("shipment.shipped", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockPayments.AssertExpectations(t)
+	})
+
+	t.Run("shipment.in_transit moves a confirmed order through every step", func(t *testing.T) {
+		service, mockQueries, orderID := setup(
+			orderpb.OrderStatus_ORDER_STATUS_CONFIRMED,
+			orderpb.OrderStatus_ORDER_STATUS_PROCESSING,
+			orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+		)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_PROCESSING)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_SHIPPED)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT)
+
+		err := service.HandleShipmentInTransit(context.Background(), event("shipment.in_transit", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.delivered catches up a shipped order", func(t *testing.T) {
+		service, mockQueries, orderID := setup(
+			orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+			orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+			orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+			orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT,
+		)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_DELIVERED)
+
+		err := service.HandleShipmentDelivered(context.Background(), event("shipment.delivered", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.delivered after a failed attempt delivers the order", func(t *testing.T) {
+		failed := orderpb.OrderStatus_ORDER_STATUS_FAILED_DELIVERY
+		service, mockQueries, orderID := setup(failed, failed, failed, failed)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_DELIVERED)
+
+		err := service.HandleShipmentDelivered(context.Background(), event("shipment.delivered", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.failed fails an order in transit", func(t *testing.T) {
+		inTransit := orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT
+		service, mockQueries, orderID := setup(inTransit, inTransit, inTransit, inTransit)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_FAILED_DELIVERY)
+
+		err := service.HandleShipmentFailed(context.Background(), event("shipment.failed", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.ready_for_pickup readies an order in transit", func(t *testing.T) {
+		inTransit := orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT
+		service, mockQueries, orderID := setup(inTransit, inTransit, inTransit, inTransit)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP)
+
+		err := service.HandleShipmentReadyForPickup(context.Background(), event("shipment.ready_for_pickup", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.picked_up catches up an order in transit", func(t *testing.T) {
+		inTransit := orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT
+		service, mockQueries, orderID := setup(inTransit, inTransit, inTransit, inTransit,
+			orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_PICKED_UP)
+
+		err := service.HandleShipmentPickedUp(context.Background(), event("shipment.picked_up", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.returned cancels an order not picked up in time", func(t *testing.T) {
+		ready := orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP
+		service, mockQueries, orderID := setup(ready, ready, ready, ready, ready)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_CANCELLED)
+
+		err := service.HandleShipmentReturned(context.Background(), event("shipment.returned", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("shipment.returned gives back the payments of the cancelled order", func(t *testing.T) {
+		ready := orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP
+		service, mockQueries, orderID := setup(ready, ready, ready, ready, ready)
+		mockPayments := new(MockPaymentClient)
+		service.SetPaymentClient(mockPayments)
+		mockPayments.On("ListPaymentsByOrder", mock.Anything, &paymentpb.ListPaymentsByOrderRequest{OrderId: orderID.String()}).
+			Return(&paymentpb.ListPaymentsByOrderResponse{Payments: []*paymentpb.Payment{
+				{Id: "pay_points", Status: paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED},
+				{Id: "pay_card", Status: paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED},
+				{Id: "pay_cod", Status: paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING},
+			}}, nil)
+		mockPayments.On("RefundPayment", mock.Anything, mock.MatchedBy(func(req *paymentpb.RefundPaymentRequest) bool {
+			return req.PaymentId == "pay_points" && req.Amount == nil
+		})).Return(&sharedpb.Empty{}, nil)
+		mockPayments.On("VoidPayment", mock.Anything, mock.MatchedBy(func(req *paymentpb.VoidPaymentRequest) bool {
+			return req.PaymentId == "pay_card"
+		})).Return(&sharedpb.Empty{}, nil)
+		expectStatus(mockQueries, orderID, orderpb.OrderStatus_ORDER_STATUS_CANCELLED)
+
+		err := service.HandleShipmentReturned(context.Background(), event("shipment.returned", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockPayments.AssertExpectations(t)
+		mockPayments.AssertNumberOfCalls(t, "RefundPayment", 1)
+	})
+
+	t.Run("shipment.returned keeps the order until its payment is refunded", func(t *testing.T) {
+		ready := orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP
+		service, mockQueries, orderID := setup(ready, ready, ready, ready, ready)
+		mockPayments := new(MockPaymentClient)
+		service.SetPaymentClient(mockPayments)
+		mockPayments.On("ListPaymentsByOrder", mock.Anything, mock.Anything).
+			Return(&paymentpb.ListPaymentsByOrderResponse{Payments: []*paymentpb.Payment{
+				{Id: "pay_card", Status: paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED},
+			}}, nil)
+		mockPayments.On("RefundPayment", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Unavailable, "provider down"))
+
+		err := service.HandleShipmentReturned(context.Background(), event("shipment.returned", orderID))
+
+		require.Error(t, err)
+		assert.True(t, retryableEventError(err))
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves a cancelled order alone", func(t *testing.T) {
+		cancelled := orderpb.OrderStatus_ORDER_STATUS_CANCELLED
+		service, mockQueries, orderID := setup(cancelled, cancelled, cancelled, cancelled)
+
+		err := service.HandleShipmentDelivered(context.Background(), event("shipment.delivered", orderID))
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not ship an order whose payment capture failed", func(t *testing.T) {
+		service, mockQueries, orderID := setup(
+			orderpb.OrderStatus_ORDER_STATUS_PROCESSING,
+			orderpb.OrderStatus_ORDER_STATUS_PROCESSING,
+		)
+		mockPayments := new(MockPaymentClient)
+		service.SetPaymentClient(mockPayments)
+		mockPayments.On("CapturePayment", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		err := service.HandleShipmentShipped(context.Background(), event("shipment.shipped", orderID))
+
+		assert.Error(t, err)
+		mockQueries.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything)
+	})
+}
+
+// fakeShipmentHandler fails with errs in turn, then succeeds
+type fakeShipmentHandler struct {
+	errs   []error
+	calls  int
+	onCall func()
+}
+
+func (h *fakeShipmentHandler) next() error {
+	h.calls++
+	if h.onCall != nil {
+		h.onCall()
+	}
+	if len(h.errs) == 0 {
+		return nil
+	}
+	err := h.errs[0]
+	if len(h.errs) > 1 {
+		h.errs = h.errs[1:]
+	}
+	return err
+}
+
+func (h *fakeShipmentHandler) HandleShipmentShipped(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+func (h *fakeShipmentHandler) HandleShipmentInTransit(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+func (h *fakeShipmentHandler) HandleShipmentDelivered(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+func (h *fakeShipmentHandler) HandleShipmentFailed(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+func (h *fakeShipmentHandler) HandleShipmentReadyForPickup(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+func (h *fakeShipmentHandler) HandleShipmentPickedUp(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+func (h *fakeShipmentHandler) HandleShipmentReturned(context.Context, ShipmentEvent) error {
+	return h.next()
+}
+
+// fakeConsumerSession records the messages marked in a session
+type fakeConsumerSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *fakeConsumerSession) Context() context.Context { return s.ctx }
+
+func (s *fakeConsumerSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, msg)
+}
+
+// fakeConsumerClaim delivers a fixed set of messages
+type fakeConsumerClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeConsumerClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func TestShipmentEventConsumer_ConsumeClaim(t *testing.T) {
+	consume := func(ctx context.Context, handler *fakeShipmentHandler) (*fakeConsumerSession, *sarama.ConsumerMessage) {
+		value, err := json.Marshal(ShipmentEvent{EventType: "shipment.shipped", ShipmentID: uuid.New().String(), OrderID: uuid.New().String()})
+		require.NoError(t, err)
+		msg := &sarama.ConsumerMessage{Value: value}
+		claim := &fakeConsumerClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+		claim.messages <- msg
+		close(claim.messages)
+
+		consumer := &ShipmentEventConsumer{handler: handler, logger: zap.NewNop(), retryBackoff: time.Millisecond}
+		session := &fakeConsumerSession{ctx: ctx}
+		require.NoError(t, consumer.ConsumeClaim(session, claim))
+		return session, msg
+	}
+
+	t.Run("retries an event until the order service can handle it", func(t *testing.T) {
+		handler := &fakeShipmentHandler{errs: []error{
+			status.Error(codes.Unavailable, "failed to capture payment"),
+			errors.New("failed to get order: connection refused"),
+			nil,
+		}}
+
+		session, msg := consume(context.Background(), handler)
+
+		assert.Equal(t, 3, handler.calls)
+		assert.Equal(t, []*sarama.ConsumerMessage{msg}, session.marked)
+	})
+
+	t.Run("does not retry an event the order rejects", func(t *testing.T) {
+		handler := &fakeShipmentHandler{errs: []error{
+			status.Error(codes.FailedPrecondition, "payment authorization has expired"),
+		}}
+
+		session, msg := consume(context.Background(), handler)
+
+		assert.Equal(t, 1, handler.calls)
+		assert.Equal(t, []*sarama.ConsumerMessage{msg}, session.marked)
+	})
+
+	t.Run("leaves the event unmarked when the session ends while retrying", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		handler := &fakeShipmentHandler{
+			errs:   []error{status.Error(codes.Unavailable, "failed to capture payment")},
+			onCall: cancel,
+		}
+
+		session, _ := consume(ctx, handler)
+
+		assert.Equal(t, 1, handler.calls)
+		assert.Empty(t, session.marked)
+	})
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+)
+
+// quoteShipping asks delivery-service for the 送料 of an order whose items
+// total itemsTotalUnits, tax included, and the day it is promised to
+// arrive. The order ships as one standard parcel, in its delivery slot's
+// time window if it has one, or to its pickup point. Without a delivery
+// client orders ship free with no promised day.
+func (s *OrderService) quoteShipping(ctx context.Context, req *orderpb.CreateOrderRequest, itemsTotalUnits int64) (*deliverypb.ShippingQuote, error) {
+	if s.deliveryClient == nil {
+		return &deliverypb.ShippingQuote{}, nil
+	}
+	if req.ShippingAddress == nil {
+		return nil, status.Error(codes.InvalidArgument, "shipping_address is required")
+	}
+
+	quoteReq := &deliverypb.QuoteShippingRequest{
+		PostalCode:    req.ShippingAddress.PostalCode,
+		Prefecture:    req.ShippingAddress.Prefecture,
+		OrderSubtotal: &sharedpb.Money{Units: itemsTotalUnits, Currency: "JPY"},
+	}
+	if req.DeliverySlotId != nil {
+		quoteReq.DeliverySlotId = req.DeliverySlotId.Value
+	}
+	if req.PickupPointId != "" {
+		point, err := s.pickupPoint(ctx, req.PickupPointId)
+		if err != nil {
+			return nil, err
+		}
+		quoteReq.PostalCode, quoteReq.Prefecture = point.PostalCode, point.Prefecture
+	}
+
+	resp, err := s.deliveryClient.QuoteShipping(ctx, quoteReq)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.FailedPrecondition, codes.NotFound:
+			return nil, status.Errorf(codes.FailedPrecondition, "order cannot be shipped: %s", status.Convert(err).Message())
+		}
+		s.logger.Error("Failed to quote shipping", zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "shipping fee could not be calculated")
+	}
+	if resp.Quote == nil {
+		return &deliverypb.ShippingQuote{}, nil
+	}
+	return resp.Quote, nil
+}
+
+// pickupPoint returns the pickup point an order is to be collected from,
+// which must still take parcels
+func (s *OrderService) pickupPoint(ctx context.Context, id string) (*deliverypb.PickupPoint, error) {
+	resp, err := s.deliveryClient.GetPickupPoint(ctx, &deliverypb.GetPickupPointRequest{Id: id})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.NotFound:
+			return nil, status.Error(codes.FailedPrecondition, "pickup point not found")
+		}
+		s.logger.Error("Failed to get pickup point", zap.String("pickup_point_id", id), zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "pickup point could not be checked")
+	}
+	if !resp.PickupPoint.GetActive() {
+		return nil, status.Errorf(codes.FailedPrecondition, "pickup point %s no longer takes parcels", resp.PickupPoint.GetName())
+	}
+	return resp.PickupPoint, nil
+}
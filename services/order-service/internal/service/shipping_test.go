@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	productpb "github.com/afasari/shinkansen-commerce/gen/proto/go/product"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/order-service/internal/pkg/pgutil"
+)
+
+func TestOrderService_CreateOrder_Shipping(t *testing.T) {
+	newService := func() (*OrderService, *MockQuerier, *MockDeliveryClient, string) {
+		mockQueries := new(MockQuerier)
+		mockProductClient := new(MockProductClient)
+		mockDelivery := new(MockDeliveryClient)
+		s := NewOrderService(mockQueries, mockProductClient, new(cache.MockCache), zap.NewNop())
+		s.SetDeliveryClient(mockDelivery)
+		productID := uuid.New().String()
+		mockProductClient.On("GetProduct", mock.Anything, mock.Anything, mock.Anything).Return(&productpb.GetProductResponse{Product: &productpb.Product{
+			Id:            productID,
+			Name:          "Test Product",
+			Price:         &sharedpb.Money{Units: 2000, Currency: "JPY"},
+			StockQuantity: 10,
+		}}, nil)
+		return s, mockQueries, mockDelivery, productID
+	}
+	newRequest := func(productID string, method orderpb.PaymentMethod) *orderpb.CreateOrderRequest {
+		return &orderpb.CreateOrderRequest{
+			UserId:          uuid.New().String(),
+			Items:           []*orderpb.CreateOrderItem{{ProductId: productID, Quantity: 1}},
+			ShippingAddress: &orderpb.ShippingAddress{Name: "山田太郎", PostalCode: "530-0001", Prefecture: "大阪府"},
+			PaymentMethod:   method,
+		}
+	}
+	quote := func(total int64) *deliverypb.QuoteShippingResponse {
+		return &deliverypb.QuoteShippingResponse{Quote: &deliverypb.ShippingQuote{
+			Total: &sharedpb.Money{Units: total, Currency: "JPY"},
+		}}
+	}
+
+	t.Run("adds the quoted fee to the order total", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		slotID := uuid.New().String()
+		mockDelivery.On("QuoteShipping", mock.Anything, mock.MatchedBy(func(req *deliverypb.QuoteShippingRequest) bool {
+			return req.PostalCode == "530-0001" && req.Prefecture == "大阪府" &&
+				req.OrderSubtotal.Units == 2200 && req.DeliverySlotId == slotID
+		})).Return(quote(1180), nil)
+		mockQueries.On("CreateOrder", mock.Anything, mock.MatchedBy(func(p db.CreateOrderParams) bool {
+			return p.ShippingFeeUnits == 1180 && p.ShippingFeeCurrency == "JPY" && p.TotalUnits == 3380
+		})).Return(pgutil.ToPG(uuid.New()), nil)
+		mockQueries.On("AddOrderItem", mock.Anything, mock.Anything).Return(nil)
+
+		req := newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD)
+		req.DeliverySlotId = wrapperspb.String(slotID)
+		_, err := s.CreateOrder(context.Background(), req)
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockDelivery.AssertExpectations(t)
+	})
+
+	t.Run("records the promised delivery day", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		promised := time.Date(2026, 10, 22, 0, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+		resp := quote(1180)
+		resp.Quote.EstimatedDeliveryAt = timestamppb.New(promised)
+		mockDelivery.On("QuoteShipping", mock.Anything, mock.Anything).Return(resp, nil)
+		mockQueries.On("CreateOrder", mock.Anything, mock.MatchedBy(func(p db.CreateOrderParams) bool {
+			return p.EstimatedDeliveryAt.Valid && p.EstimatedDeliveryAt.Time.Equal(promised)
+		})).Return(pgutil.ToPG(uuid.New()), nil)
+		mockQueries.On("AddOrderItem", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := s.CreateOrder(context.Background(), newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("charges the COD fee on the total with shipping", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		mockDelivery.On("QuoteShipping", mock.Anything, mock.Anything).Return(quote(1180), nil)
+		mockQueries.On("CreateOrder", mock.Anything, mock.MatchedBy(func(p db.CreateOrderParams) bool {
+			return p.ShippingFeeUnits == 1180 && p.CodFeeUnits == 330 && p.TotalUnits == 3710
+		})).Return(pgutil.ToPG(uuid.New()), nil)
+		mockQueries.On("AddOrderItem", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := s.CreateOrder(context.Background(), newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects addresses delivery cannot reach", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		mockDelivery.On("QuoteShipping", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.FailedPrecondition, "postal code 100-2101 is outside the delivery area"))
+
+		_, err := s.CreateOrder(context.Background(), newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD))
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when delivery-service is unreachable", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		mockDelivery.On("QuoteShipping", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		_, err := s.CreateOrder(context.Background(), newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD))
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+	t.Run("quotes to the pickup point", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		pointID := uuid.New().String()
+		mockDelivery.On("GetPickupPoint", mock.Anything, &deliverypb.GetPickupPointRequest{Id: pointID}).
+			Return(&deliverypb.GetPickupPointResponse{PickupPoint: &deliverypb.PickupPoint{
+				Id: pointID, PostalCode: "1540002", Prefecture: "東京都", Active: true,
+			}}, nil)
+		mockDelivery.On("QuoteShipping", mock.Anything, mock.MatchedBy(func(req *deliverypb.QuoteShippingRequest) bool {
+			return req.PostalCode == "1540002" && req.Prefecture == "東京都" && req.DeliverySlotId == ""
+		})).Return(quote(880), nil)
+		mockQueries.On("CreateOrder", mock.Anything, mock.MatchedBy(func(p db.CreateOrderParams) bool {
+			return p.ShippingFeeUnits == 880 && pgutil.FromPG(p.PickupPointID) == pointID
+		})).Return(pgutil.ToPG(uuid.New()), nil)
+		mockQueries.On("AddOrderItem", mock.Anything, mock.Anything).Return(nil)
+
+		req := newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD)
+		req.PickupPointId = pointID
+		_, err := s.CreateOrder(context.Background(), req)
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockDelivery.AssertExpectations(t)
+	})
+
+	t.Run("rejects a pickup point no longer taking parcels", func(t *testing.T) {
+		s, mockQueries, mockDelivery, productID := newService()
+		mockDelivery.On("GetPickupPoint", mock.Anything, mock.Anything).
+			Return(&deliverypb.GetPickupPointResponse{PickupPoint: &deliverypb.PickupPoint{Active: false}}, nil)
+
+		req := newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD)
+		req.PickupPointId = uuid.New().String()
+		_, err := s.CreateOrder(context.Background(), req)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pickup orders are prepaid without a slot", func(t *testing.T) {
+		s, _, _, productID := newService()
+
+		cod := newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY)
+		cod.PickupPointId = uuid.New().String()
+		_, err := s.CreateOrder(context.Background(), cod)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		slotted := newRequest(productID, orderpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD)
+		slotted.PickupPointId = uuid.New().String()
+		slotted.DeliverySlotId = wrapperspb.String(uuid.New().String())
+		_, err = s.CreateOrder(context.Background(), slotted)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -40,6 +40,7 @@ var validTransitions = map[orderpb.OrderStatus][]orderpb.OrderStatus{
 	orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT: {
 		orderpb.OrderStatus_ORDER_STATUS_DELIVERED,
 		orderpb.OrderStatus_ORDER_STATUS_FAILED_DELIVERY,
+		orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP,
 	},
 	orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP: {
 		orderpb.OrderStatus_ORDER_STATUS_PICKED_UP,
@@ -206,11 +207,33 @@ func (sm *OrderStateMachine) AutoTransition(
 		"payment_completed": {
 			orderpb.OrderStatus_ORDER_STATUS_PENDING: orderpb.OrderStatus_ORDER_STATUS_CONFIRMED,
 		},
+		"payment_authorized": {
+			orderpb.OrderStatus_ORDER_STATUS_PENDING: orderpb.OrderStatus_ORDER_STATUS_CONFIRMED,
+		},
 		"pickup_timeout": {
 			orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP: orderpb.OrderStatus_ORDER_STATUS_CANCELLED,
 		},
+		"shipment_prepared": {
+			orderpb.OrderStatus_ORDER_STATUS_CONFIRMED: orderpb.OrderStatus_ORDER_STATUS_PROCESSING,
+		},
+		"shipment_shipped": {
+			orderpb.OrderStatus_ORDER_STATUS_PROCESSING: orderpb.OrderStatus_ORDER_STATUS_SHIPPED,
+		},
+		"shipment_in_transit": {
+			orderpb.OrderStatus_ORDER_STATUS_SHIPPED: orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT,
+		},
 		"delivery_confirmed": {
-			orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT: orderpb.OrderStatus_ORDER_STATUS_DELIVERED,
+			orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT:      orderpb.OrderStatus_ORDER_STATUS_DELIVERED,
+			orderpb.OrderStatus_ORDER_STATUS_FAILED_DELIVERY: orderpb.OrderStatus_ORDER_STATUS_DELIVERED,
+		},
+		"delivery_failed": {
+			orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT: orderpb.OrderStatus_ORDER_STATUS_FAILED_DELIVERY,
+		},
+		"shipment_ready_for_pickup": {
+			orderpb.OrderStatus_ORDER_STATUS_IN_TRANSIT: orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP,
+		},
+		"pickup_completed": {
+			orderpb.OrderStatus_ORDER_STATUS_READY_FOR_PICKUP: orderpb.OrderStatus_ORDER_STATUS_PICKED_UP,
 		},
 	}
 
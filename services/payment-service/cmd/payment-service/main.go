@@ -7,8 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
 	paymentv1 "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
 	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/config"
@@ -18,6 +22,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -53,9 +58,96 @@ func main() {
 	redisClient := cache.NewRedisClient(cfg.RedisURL)
 	cacheClient := cache.NewRedisCache(redisClient)
 	paymentService := service.NewPaymentService(queries, cacheClient, logger)
+	paymentService.SetBankTransferConfig(service.BankTransferConfig{
+		BankCode:      cfg.BankTransferBankCode,
+		BankName:      cfg.BankTransferBankName,
+		BranchCode:    cfg.BankTransferBranchCode,
+		BranchName:    cfg.BankTransferBranchName,
+		AccountType:   "普通",
+		AccountHolder: cfg.BankTransferAccountHolder,
+		DeadlineDays:  cfg.BankTransferDeadlineDays,
+	})
+
+	if cfg.GiftCardCodeSecret != "" {
+		paymentService.SetGiftCardConfig(service.GiftCardConfig{CodeSecret: cfg.GiftCardCodeSecret})
+	} else {
+		logger.Warn("GIFT_CARD_CODE_SECRET is not set, gift cards disabled")
+	}
+
+	deliveryConn, err := grpc.NewClient(cfg.DeliveryServiceGRPCAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Fatal("Failed to dial delivery service", zap.Error(err))
+	}
+	defer func() { _ = deliveryConn.Close() }()
+	paymentService.SetDeliveryClient(deliverypb.NewDeliveryServiceClient(deliveryConn))
+
+	orderConn, err := grpc.NewClient(cfg.OrderServiceGRPCAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Fatal("Failed to dial order service", zap.Error(err))
+	}
+	defer func() { _ = orderConn.Close() }()
+	paymentService.SetOrderClient(orderpb.NewOrderServiceClient(orderConn))
+
+	var deferredProvider service.DeferredPaymentProvider
+	switch cfg.DeferredPaymentProvider {
+	case "":
+	case "fake":
+		logger.Warn("Using the fake deferred payment provider")
+		deferredProvider = service.NewFakeDeferredProvider(time.Duration(cfg.DeferredPaymentSettleSeconds) * time.Second)
+	default:
+		logger.Fatal("Unknown deferred payment provider", zap.String("provider", cfg.DeferredPaymentProvider))
+	}
+	if deferredProvider != nil {
+		paymentService.SetDeferredPaymentProvider(deferredProvider)
+	}
+
+	if cfg.KafkaBrokers != "" {
+		publisher, err := service.NewPaymentEventProducer(strings.Split(cfg.KafkaBrokers, ","), cfg.PaymentEventsTopic, logger)
+		if err != nil {
+			logger.Warn("Failed to create payment event publisher, continuing without", zap.Error(err))
+		} else {
+			defer func() { _ = publisher.Close() }()
+			paymentService.SetEventPublisher(publisher)
+		}
+	}
+
+	sweepCtx, stopSweep := context.WithCancel(ctx)
+	defer stopSweep()
+	sweeper := service.NewAuthorizationSweeper(paymentService, logger)
+	sweeper.StartPeriodicSweep(sweepCtx, time.Duration(cfg.AuthorizationSweepInterval)*time.Second)
+
+	dispatcher := service.NewWebhookDispatcher(queries, logger)
+	dispatcher.StartPeriodicDelivery(sweepCtx, time.Duration(cfg.WebhookDeliveryInterval)*time.Second)
+
+	if cfg.SettlementInboxDir != "" {
+		reconciler := service.NewReconciler(queries, logger)
+		reconciler.StartPeriodicImport(sweepCtx, cfg.SettlementInboxDir, time.Duration(cfg.SettlementImportInterval)*time.Second)
+	}
+
+	bankTransfers := service.NewBankTransferWorker(paymentService, logger)
+	bankTransfers.StartPeriodicSweep(sweepCtx, cfg.BankDepositInboxDir, time.Duration(cfg.BankTransferSweepInterval)*time.Second)
+
+	if deferredProvider != nil {
+		deferredPayments := service.NewDeferredPaymentWorker(paymentService, logger)
+		deferredPayments.StartPeriodicSweep(sweepCtx, time.Duration(cfg.DeferredPaymentSweepInterval)*time.Second)
+	}
+
+	giftCards := service.NewGiftCardExpirySweeper(paymentService, logger)
+	giftCards.StartPeriodicSweep(sweepCtx, time.Duration(cfg.GiftCardExpirySweepInterval)*time.Second)
+
+	konbiniService := service.NewKonbiniService(queries, logger)
+	konbiniPayments := service.NewKonbiniExpirySweeper(paymentService, konbiniService, logger)
+	konbiniPayments.StartPeriodicSweep(sweepCtx, time.Duration(cfg.KonbiniExpirySweepInterval)*time.Second)
 
 	server := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	paymentv1.RegisterPaymentServiceServer(server, paymentService)
+	paymentv1.RegisterPointsServiceServer(server, service.NewPointsService(queries, logger))
 	reflection.Register(server)
 
 	lis, err := net.Listen("tcp", cfg.GRPCServerAddress)
@@ -70,11 +162,23 @@ func main() {
 		}
 	}()
 
+	if cfg.WebhookSecret == "" {
+		logger.Warn("WEBHOOK_SECRET is not set, all provider webhooks will be rejected")
+	}
+	webhookService := service.NewWebhookService(
+		paymentService,
+		konbiniService,
+		cfg.WebhookSecret,
+		time.Duration(cfg.WebhookToleranceSeconds)*time.Second,
+		logger,
+	)
+
 	metricsMux := http.NewServeMux()
 	metricsMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
+	metricsMux.Handle("/webhooks/payments", service.NewWebhookHandler(webhookService))
 
 	go func() {
 		logger.Info("Starting metrics server", zap.String("address", cfg.MetricsServerAddress))
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/service"
+	"go.uber.org/zap"
+)
+
+func main() {
+	provider := flag.String("provider", "", "provider that produced the file (card, paypay, rakuten or konbini)")
+	file := flag.String("file", "", "settlement file to reconcile (.csv, anything else is read as fixed width)")
+	flag.Parse()
+
+	if *provider == "" || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL environment variable is required")
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+	defer func() { _ = logger.Sync() }()
+
+	ctx := context.Background()
+	dbpool, err := db.New(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func() { _ = dbpool.Close() }()
+
+	reconciler := service.NewReconciler(db.NewQueries(dbpool), logger)
+	run, err := reconciler.ReconcileFile(ctx, *provider, *file)
+	if err != nil {
+		log.Fatalf("Reconciliation failed: %v", err)
+	}
+
+	fmt.Printf("Reconciliation run %s\n", run.ID)
+	fmt.Printf("  lines:      %d\n", run.TotalLines)
+	fmt.Printf("  matched:    %d\n", run.MatchedCount)
+	fmt.Printf("  exceptions: %d\n", run.ExceptionCount)
+	fmt.Printf("  settled:    %d\n", run.SettledAmountMinor)
+
+	if run.ExceptionCount > 0 {
+		os.Exit(1)
+	}
+}
@@ -2,9 +2,12 @@ package db
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type Payment struct {
@@ -18,6 +21,272 @@ type Payment struct {
 	PaymentData   []byte
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	AuthorizedAt           *time.Time
+	AuthorizationExpiresAt *time.Time
+	CapturedAt             *time.Time
+	CapturedAmountMinor    *int
+}
+
+type Refund struct {
+	ID                uuid.UUID
+	PaymentID         uuid.UUID
+	AmountMinor       int
+	Currency          string
+	Reason            *string
+	Status            string
+	ProviderReference *string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Destination       string
+}
+
+type WebhookEndpoint struct {
+	ID                  uuid.UUID
+	URL                 string
+	Description         *string
+	EventTypes          []string
+	Secret              string
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type WebhookDelivery struct {
+	ID            uuid.UUID
+	EndpointID    uuid.UUID
+	EventID       string
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	ResponseCode  *int
+	LastError     *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// DueWebhookDelivery is a claimed delivery together with the endpoint
+// details needed to send it.
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+type ReconciliationRun struct {
+	ID                 uuid.UUID
+	Provider           string
+	FileName           string
+	FileFormat         string
+	PeriodStart        *time.Time
+	PeriodEnd          *time.Time
+	TotalLines         int
+	MatchedCount       int
+	ExceptionCount     int
+	SettledAmountMinor int64
+	CreatedAt          time.Time
+}
+
+type ReconciliationItem struct {
+	ID                  uuid.UUID
+	RunID               uuid.UUID
+	Result              string
+	TransactionID       *string
+	PaymentID           *uuid.UUID
+	LineNumber          *int
+	SettledAmountMinor  *int
+	ExpectedAmountMinor *int
+	FeeMinor            *int
+	Currency            *string
+	SettledOn           *time.Time
+	Detail              *string
+}
+
+type BankTransferAccount struct {
+	PaymentID           uuid.UUID
+	AccountNumber       string
+	ExpectedAmountMinor int
+	ReceivedAmountMinor int
+	Currency            string
+	Deadline            time.Time
+	Status              string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type BankDeposit struct {
+	ID              uuid.UUID
+	AccountNumber   string
+	InquiryNumber   string
+	ValueDate       time.Time
+	AmountMinor     int
+	DepositorName   string
+	RemittingBank   string
+	RemittingBranch string
+	PaymentID       *uuid.UUID
+	Match           string
+	FileName        string
+	CreatedAt       time.Time
+}
+
+type KonbiniPayment struct {
+	PaymentID   uuid.UUID
+	Store       string
+	ExpiresAt   time.Time
+	CancelledAt *time.Time
+	CreatedAt   time.Time
+}
+
+type DeferredPayment struct {
+	PaymentID             uuid.UUID
+	Provider              string
+	ProviderTransactionID string
+	CreditCheck           string
+	CreditCheckReason     *string
+	InvoiceStatus         string
+	Carrier               *string
+	TrackingNumber        *string
+	ShippedAt             *time.Time
+	PaidAt                *time.Time
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// ErrRefundExceedsCapture is returned when a refund would take the total
+// refunded past the refundable amount of the payment.
+var ErrRefundExceedsCapture = errors.New("refund exceeds refundable amount")
+
+// ErrPaymentStatusChanged is returned when a status update finds the payment
+// no longer in the status it was read in, i.e. it was changed concurrently.
+var ErrPaymentStatusChanged = errors.New("payment status changed concurrently")
+
+// ErrOrderPaymentInProgress is returned when an order is already being paid
+// by another PayOrder call or already has an open payment.
+var ErrOrderPaymentInProgress = errors.New("order already has a payment in progress")
+
+// ErrDuplicateBankDeposit is returned when a deposit was already imported
+// from an earlier file.
+var ErrDuplicateBankDeposit = errors.New("bank deposit already imported")
+
+// ErrDuplicateGiftCardCode is returned when a newly generated gift card code
+// collides with an existing one.
+var ErrDuplicateGiftCardCode = errors.New("gift card code already exists")
+
+// ErrGiftCardNotUsable is returned when a gift card that is disabled or
+// expired would be charged or credited.
+var ErrGiftCardNotUsable = errors.New("gift card is not usable")
+
+// ErrInsufficientGiftCardBalance is returned when a charge exceeds the
+// balance of a gift card.
+var ErrInsufficientGiftCardBalance = errors.New("insufficient gift card balance")
+
+// ErrInsufficientPoints is returned when more points would be spent than a
+// customer has.
+var ErrInsufficientPoints = errors.New("insufficient points")
+
+type GiftCard struct {
+	ID                 uuid.UUID
+	CodeLast4          string
+	Type               string
+	Status             string
+	InitialAmountMinor int
+	BalanceMinor       int
+	Currency           string
+	UserID             *uuid.UUID
+	ExpiresAt          time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type GiftCardTransaction struct {
+	ID                uuid.UUID
+	GiftCardID        uuid.UUID
+	Kind              string
+	AmountMinor       int
+	BalanceAfterMinor int
+	Currency          string
+	PaymentID         *uuid.UUID
+	RefundID          *uuid.UUID
+	Note              string
+	CreatedAt         time.Time
+}
+
+type PointBalance struct {
+	UserID    uuid.UUID
+	Points    int64
+	UpdatedAt time.Time
+}
+
+type PointTransaction struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Type         string
+	Points       int64
+	BalanceAfter int64
+	OrderID      *uuid.UUID
+	PaymentID    *uuid.UUID
+	RefundID     *uuid.UUID
+	Reason       string
+	CreatedAt    time.Time
+}
+
+type SavedPaymentMethod struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Provider      string
+	ProviderToken string
+	Brand         string
+	Last4         string
+	ExpMonth      int
+	ExpYear       int
+	ExpiresAt     time.Time
+	IsDefault     bool
+	LastUsedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Dispute struct {
+	ID                  uuid.UUID
+	PaymentID           uuid.UUID
+	OrderID             uuid.UUID
+	ProviderDisputeID   string
+	Status              string
+	Reason              string
+	AmountMinor         int
+	Currency            string
+	EvidenceDueBy       time.Time
+	EvidenceSubmittedAt *time.Time
+	AdjustmentMinor     *int
+	ClosedAt            *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type DisputeEvidence struct {
+	ID          uuid.UUID
+	DisputeID   uuid.UUID
+	Kind        string
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	Content     []byte
+	Description string
+	CreatedAt   time.Time
+}
+
+// StartOrderTendersParams claims an order for one PayOrder call. The claim
+// fails while another call holds it or the order already has a payment in
+// one of OpenStatuses. Claims older than StaleBefore are given up first.
+type StartOrderTendersParams struct {
+	OrderID      uuid.UUID
+	OpenStatuses []string
+	StaleBefore  time.Time
 }
 
 type CreatePaymentParams struct {
@@ -27,10 +296,31 @@ type CreatePaymentParams struct {
 	Currency    string
 }
 
+// PaymentStatusChange is the audit record written with every status update.
+// The update only applies while the payment is still in FromStatus.
+type PaymentStatusChange struct {
+	FromStatus       string
+	ChangedBy        string
+	Reason           *string
+	ProviderResponse []byte
+}
+
+type PaymentStatusHistory struct {
+	ID               uuid.UUID
+	PaymentID        uuid.UUID
+	FromStatus       string
+	ToStatus         string
+	ChangedBy        string
+	Reason           *string
+	ProviderResponse []byte
+	CreatedAt        time.Time
+}
+
 type UpdatePaymentStatusParams struct {
 	ID            uuid.UUID
 	Status        string
 	TransactionID *string
+	Change        PaymentStatusChange
 }
 
 type UpdatePaymentDataParams struct {
@@ -38,6 +328,246 @@ type UpdatePaymentDataParams struct {
 	PaymentData []byte
 }
 
+type AuthorizePaymentParams struct {
+	ID            uuid.UUID
+	TransactionID string
+	ExpiresAt     time.Time
+	Change        PaymentStatusChange
+}
+
+type CapturePaymentParams struct {
+	ID            uuid.UUID
+	AmountMinor   int
+	TransactionID *string
+	Change        PaymentStatusChange
+}
+
+type CreateRefundParams struct {
+	PaymentID   uuid.UUID
+	AmountMinor int
+	Currency    string
+	Reason      *string
+	// LimitMinor is the most that may be refunded across all non-failed refunds
+	LimitMinor  int
+	Destination string
+}
+
+// RecordProviderRefundParams records a refund issued at the provider. The
+// payment must still be in Change.FromStatus.
+type RecordProviderRefundParams struct {
+	PaymentID uuid.UUID
+	// AmountMinor of zero refunds what is left of LimitMinor
+	AmountMinor       int
+	Currency          string
+	Reason            *string
+	LimitMinor        int
+	ProviderReference *string
+	Change            PaymentStatusChange
+}
+
+type UpdateRefundStatusParams struct {
+	ID                uuid.UUID
+	Status            string
+	ProviderReference *string
+}
+
+type CreateWebhookEndpointParams struct {
+	URL         string
+	Description *string
+	EventTypes  []string
+	Secret      string
+}
+
+type UpdateWebhookEndpointParams struct {
+	ID          uuid.UUID
+	URL         *string
+	Description *string
+	// EventTypes replaces the subscription when non-nil
+	EventTypes []string
+	Enabled    *bool
+}
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID   string
+	EventType string
+	Payload   []byte
+}
+
+type MarkWebhookDeliveryFailedParams struct {
+	ID           uuid.UUID
+	ResponseCode *int
+	Error        string
+	// NextAttemptAt schedules a retry; nil gives up on the delivery
+	NextAttemptAt *time.Time
+	// DisableAfter is the consecutive failure count that disables the endpoint
+	DisableAfter int
+}
+
+type ListWebhookDeliveriesParams struct {
+	EndpointID *uuid.UUID
+	Status     *string
+	Limit      int
+	Offset     int
+}
+
+type CreateReconciliationRunParams struct {
+	Provider           string
+	FileName           string
+	FileFormat         string
+	PeriodStart        *time.Time
+	PeriodEnd          *time.Time
+	TotalLines         int
+	MatchedCount       int
+	ExceptionCount     int
+	SettledAmountMinor int64
+	// Items are stored with the run; their ID and RunID are ignored
+	Items []ReconciliationItem
+}
+
+type CreateBankTransferAccountParams struct {
+	PaymentID           uuid.UUID
+	ExpectedAmountMinor int
+	Currency            string
+	Deadline            time.Time
+}
+
+type RecordBankDepositParams struct {
+	AccountNumber   string
+	InquiryNumber   string
+	ValueDate       time.Time
+	AmountMinor     int
+	DepositorName   string
+	RemittingBank   string
+	RemittingBranch string
+	FileName        string
+}
+
+type ListBankDepositsParams struct {
+	Match  *string
+	Limit  int
+	Offset int
+}
+
+type CreateKonbiniPaymentParams struct {
+	PaymentID uuid.UUID
+	Store     string
+	ExpiresAt time.Time
+}
+
+type CreateDeferredPaymentParams struct {
+	PaymentID             uuid.UUID
+	Provider              string
+	ProviderTransactionID string
+	CreditCheck           string
+	CreditCheckReason     *string
+}
+
+type SaveSavedPaymentMethodParams struct {
+	UserID        uuid.UUID
+	Provider      string
+	ProviderToken string
+	Brand         string
+	Last4         string
+	ExpMonth      int
+	ExpYear       int
+	ExpiresAt     time.Time
+	MakeDefault   bool
+}
+
+type RecordDeferredShipmentParams struct {
+	PaymentID      uuid.UUID
+	Carrier        string
+	TrackingNumber string
+	ShippedAt      time.Time
+}
+
+type CreateDisputeParams struct {
+	PaymentID         uuid.UUID
+	ProviderDisputeID string
+	Reason            string
+	AmountMinor       int
+	Currency          string
+	EvidenceDueBy     time.Time
+}
+
+type UpdateDisputeParams struct {
+	ID            uuid.UUID
+	Status        string
+	EvidenceDueBy *time.Time
+}
+
+type CloseDisputeParams struct {
+	ID     uuid.UUID
+	Status string
+	// AdjustmentMinor, when set, is recorded against the payment as a
+	// negative amount taken back by the issuer.
+	AdjustmentMinor  *int
+	AdjustmentReason string
+}
+
+type AddDisputeEvidenceParams struct {
+	DisputeID   uuid.UUID
+	Kind        string
+	FileName    string
+	ContentType string
+	Content     []byte
+	Description string
+}
+
+type ListDisputesParams struct {
+	Status  *string
+	OrderID *uuid.UUID
+	Limit   int
+	Offset  int
+}
+
+type CreateGiftCardParams struct {
+	CodeHash    string
+	CodeLast4   string
+	Type        string
+	AmountMinor int
+	Currency    string
+	UserID      *uuid.UUID
+	ExpiresAt   time.Time
+	// RefundID links store credit to the refund it was issued for
+	RefundID *uuid.UUID
+	Note     string
+}
+
+type AdjustGiftCardBalanceParams struct {
+	GiftCardID uuid.UUID
+	Kind       string
+	// AmountMinor is negative for charges and positive for credits
+	AmountMinor int
+	PaymentID   *uuid.UUID
+	RefundID    *uuid.UUID
+	Note        string
+}
+
+type AdjustPointsParams struct {
+	UserID uuid.UUID
+	Type   string
+	// Points is negative when points are spent
+	Points    int64
+	OrderID   *uuid.UUID
+	PaymentID *uuid.UUID
+	RefundID  *uuid.UUID
+	Reason    string
+}
+
+type ListPointTransactionsParams struct {
+	UserID uuid.UUID
+	Limit  int
+	Offset int
+}
+
+type ListGiftCardsParams struct {
+	UserID *uuid.UUID
+	Type   *string
+	Limit  int
+	Offset int
+}
+
 type Querier interface {
 	CreatePayment(ctx context.Context, arg CreatePaymentParams) (uuid.UUID, error)
 	GetPayment(ctx context.Context, id uuid.UUID) (Payment, error)
@@ -45,6 +575,88 @@ type Querier interface {
 	UpdatePaymentStatus(ctx context.Context, arg UpdatePaymentStatusParams) error
 	UpdatePaymentData(ctx context.Context, arg UpdatePaymentDataParams) error
 	ListPaymentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]Payment, error)
+	StartOrderTenders(ctx context.Context, arg StartOrderTendersParams) (uuid.UUID, error)
+	FinishOrderTenders(ctx context.Context, id uuid.UUID, status string) error
+	OrderTendersInProgress(ctx context.Context, orderID uuid.UUID) (bool, error)
+	AuthorizePayment(ctx context.Context, arg AuthorizePaymentParams) error
+	CapturePayment(ctx context.Context, arg CapturePaymentParams) error
+	ListPaymentStatusHistory(ctx context.Context, paymentID uuid.UUID) ([]PaymentStatusHistory, error)
+	ListExpiredAuthorizations(ctx context.Context, before time.Time, limit int) ([]Payment, error)
+	CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, int, error)
+	RecordProviderRefund(ctx context.Context, arg RecordProviderRefundParams) (Refund, string, error)
+	UpdateRefundStatus(ctx context.Context, arg UpdateRefundStatusParams) error
+	ListRefundsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]Refund, error)
+	RecordWebhookEvent(ctx context.Context, eventID string, eventType string) (bool, error)
+	DeleteWebhookEvent(ctx context.Context, eventID string) error
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
+	GetWebhookEndpoint(ctx context.Context, id uuid.UUID) (WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context) ([]WebhookEndpoint, error)
+	UpdateWebhookEndpoint(ctx context.Context, arg UpdateWebhookEndpointParams) (WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]DueWebhookDelivery, error)
+	MarkWebhookDeliverySucceeded(ctx context.Context, id uuid.UUID, responseCode int) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) (bool, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, int, error)
+	ReplayWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	ListPaymentsByTransactionIDs(ctx context.Context, transactionIDs []string) ([]Payment, error)
+	ListSettleablePayments(ctx context.Context, methods []string, from, to time.Time) ([]Payment, error)
+	CreateReconciliationRun(ctx context.Context, arg CreateReconciliationRunParams) (ReconciliationRun, error)
+	GetReconciliationRun(ctx context.Context, id uuid.UUID) (ReconciliationRun, error)
+	ListReconciliationRuns(ctx context.Context, limit, offset int) ([]ReconciliationRun, int, error)
+	ListReconciliationItems(ctx context.Context, runID uuid.UUID, includeMatched bool) ([]ReconciliationItem, error)
+	CreateBankTransferAccount(ctx context.Context, arg CreateBankTransferAccountParams) (BankTransferAccount, error)
+	GetBankTransferAccount(ctx context.Context, paymentID uuid.UUID) (BankTransferAccount, error)
+	RecordBankDeposit(ctx context.Context, arg RecordBankDepositParams) (BankDeposit, *BankTransferAccount, error)
+	ListOverdueBankTransfers(ctx context.Context, before time.Time, limit int) ([]BankTransferAccount, error)
+	ListUncompletedBankTransfers(ctx context.Context, limit int) ([]BankTransferAccount, error)
+	ExpireBankTransferAccount(ctx context.Context, paymentID uuid.UUID) (bool, error)
+	ListBankDeposits(ctx context.Context, arg ListBankDepositsParams) ([]BankDeposit, int, error)
+	CreateKonbiniPayment(ctx context.Context, arg CreateKonbiniPaymentParams) (KonbiniPayment, error)
+	GetKonbiniPayment(ctx context.Context, paymentID uuid.UUID) (KonbiniPayment, error)
+	ListExpiredKonbiniPayments(ctx context.Context, before time.Time, limit int) ([]KonbiniPayment, error)
+	MarkKonbiniPaymentCancelled(ctx context.Context, paymentID uuid.UUID) error
+	CreateDeferredPayment(ctx context.Context, arg CreateDeferredPaymentParams) (DeferredPayment, error)
+	GetDeferredPayment(ctx context.Context, paymentID uuid.UUID) (DeferredPayment, error)
+	UpdateDeferredCreditCheck(ctx context.Context, paymentID uuid.UUID, creditCheck string, reason *string) error
+	RecordDeferredShipment(ctx context.Context, arg RecordDeferredShipmentParams) error
+	UpdateDeferredInvoiceStatus(ctx context.Context, paymentID uuid.UUID, invoiceStatus string) error
+	ListDeferredPaymentsInReview(ctx context.Context, limit int) ([]DeferredPayment, error)
+	ListOpenDeferredInvoices(ctx context.Context, limit int) ([]DeferredPayment, error)
+	SaveSavedPaymentMethod(ctx context.Context, arg SaveSavedPaymentMethodParams) (SavedPaymentMethod, error)
+	GetSavedPaymentMethod(ctx context.Context, id uuid.UUID) (SavedPaymentMethod, error)
+	ListSavedPaymentMethods(ctx context.Context, userID uuid.UUID) ([]SavedPaymentMethod, error)
+	SetDefaultSavedPaymentMethod(ctx context.Context, userID, id uuid.UUID) (SavedPaymentMethod, error)
+	DeleteSavedPaymentMethod(ctx context.Context, userID, id uuid.UUID) error
+	MarkSavedPaymentMethodUsed(ctx context.Context, id uuid.UUID) error
+	ListExpiringSavedPaymentMethods(ctx context.Context, from, to time.Time, limit int) ([]SavedPaymentMethod, error)
+	CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, bool, error)
+	GetDispute(ctx context.Context, id uuid.UUID) (Dispute, error)
+	GetDisputeByProviderID(ctx context.Context, providerDisputeID string) (Dispute, error)
+	ListDisputes(ctx context.Context, arg ListDisputesParams) ([]Dispute, int, error)
+	UpdateDispute(ctx context.Context, arg UpdateDisputeParams) (Dispute, error)
+	MarkDisputeEvidenceSubmitted(ctx context.Context, id uuid.UUID) (Dispute, error)
+	CloseDispute(ctx context.Context, arg CloseDisputeParams) (Dispute, bool, error)
+	AddDisputeEvidence(ctx context.Context, arg AddDisputeEvidenceParams) (DisputeEvidence, error)
+	ListDisputeEvidence(ctx context.Context, disputeID uuid.UUID, withContent bool) ([]DisputeEvidence, error)
+	CountOpenDisputesByOrderID(ctx context.Context, orderID uuid.UUID) (int, error)
+	CreateGiftCard(ctx context.Context, arg CreateGiftCardParams) (GiftCard, error)
+	GetGiftCard(ctx context.Context, id uuid.UUID) (GiftCard, error)
+	GetGiftCardByCodeHash(ctx context.Context, codeHash string) (GiftCard, error)
+	ListGiftCards(ctx context.Context, arg ListGiftCardsParams) ([]GiftCard, int, error)
+	AdjustGiftCardBalance(ctx context.Context, arg AdjustGiftCardBalanceParams) (GiftCard, GiftCardTransaction, error)
+	ListGiftCardTransactions(ctx context.Context, giftCardID uuid.UUID) ([]GiftCardTransaction, error)
+	ListGiftCardTransactionsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]GiftCardTransaction, error)
+	DisableGiftCard(ctx context.Context, id uuid.UUID) (GiftCard, bool, error)
+	ListExpiredGiftCards(ctx context.Context, before time.Time, limit int) ([]GiftCard, error)
+	ExpireGiftCard(ctx context.Context, id uuid.UUID) (bool, error)
+	RecordGiftCardLookupFailure(ctx context.Context, clientKey string) error
+	CountGiftCardLookupFailures(ctx context.Context, clientKey string, since time.Time) (int, error)
+	DeleteGiftCardLookupFailures(ctx context.Context, before time.Time) (int64, error)
+	GetPointBalance(ctx context.Context, userID uuid.UUID) (PointBalance, error)
+	AdjustPoints(ctx context.Context, arg AdjustPointsParams) (PointBalance, PointTransaction, error)
+	ListPointTransactions(ctx context.Context, arg ListPointTransactionsParams) ([]PointTransaction, int, error)
+	ListPointTransactionsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]PointTransaction, error)
 }
 
 type Queries struct {
@@ -69,7 +681,8 @@ func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (u
 
 func (q *Queries) GetPayment(ctx context.Context, id uuid.UUID) (Payment, error) {
 	const sql = `
-		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at
+		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at,
+		       authorized_at, authorization_expires_at, captured_at, captured_amount_minor
 		FROM payments.payments
 		WHERE id = $1
 	`
@@ -78,13 +691,15 @@ func (q *Queries) GetPayment(ctx context.Context, id uuid.UUID) (Payment, error)
 	err := row.Scan(
 		&p.ID, &p.OrderID, &p.Method, &p.AmountMinor, &p.Currency, &p.Status,
 		&p.TransactionID, &p.PaymentData, &p.CreatedAt, &p.UpdatedAt,
+		&p.AuthorizedAt, &p.AuthorizationExpiresAt, &p.CapturedAt, &p.CapturedAmountMinor,
 	)
 	return p, err
 }
 
 func (q *Queries) GetPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (Payment, error) {
 	const sql = `
-		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at
+		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at,
+		       authorized_at, authorization_expires_at, captured_at, captured_amount_minor
 		FROM payments.payments
 		WHERE order_id = $1
 		ORDER BY created_at DESC
@@ -95,6 +710,7 @@ func (q *Queries) GetPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (P
 	err := row.Scan(
 		&p.ID, &p.OrderID, &p.Method, &p.AmountMinor, &p.Currency, &p.Status,
 		&p.TransactionID, &p.PaymentData, &p.CreatedAt, &p.UpdatedAt,
+		&p.AuthorizedAt, &p.AuthorizationExpiresAt, &p.CapturedAt, &p.CapturedAmountMinor,
 	)
 	return p, err
 }
@@ -103,15 +719,78 @@ func (q *Queries) UpdatePaymentStatus(ctx context.Context, arg UpdatePaymentStat
 	const sql = `
 		UPDATE payments.payments
 		SET
-			status = $2,
-			transaction_id = COALESCE($3, transaction_id),
+			status = $3,
+			transaction_id = COALESCE($4, transaction_id),
 			updated_at = NOW()
-		WHERE id = $1
+		WHERE id = $1 AND status = $2
 	`
-	_, err := q.db.pool.Exec(ctx, sql, arg.ID, arg.Status, arg.TransactionID)
+	return q.changePaymentStatus(ctx, arg.ID, arg.Status, arg.Change, sql, arg.Status, arg.TransactionID)
+}
+
+// changePaymentStatus runs a status UPDATE of one payment, which takes the
+// payment ID as $1 and the expected current status as $2, and records the
+// change in the status history in the same transaction.
+func (q *Queries) changePaymentStatus(ctx context.Context, id uuid.UUID, toStatus string, change PaymentStatusChange, sql string, args ...interface{}) error {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, sql, append([]interface{}{id, change.FromStatus}, args...)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentStatusChanged
+	}
+
+	if err := insertPaymentStatusHistory(ctx, tx, id, toStatus, change); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertPaymentStatusHistory records a status change of a payment in the
+// transaction that made it
+func insertPaymentStatusHistory(ctx context.Context, tx pgx.Tx, id uuid.UUID, toStatus string, change PaymentStatusChange) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO payments.payment_status_history (payment_id, from_status, to_status, changed_by, reason, provider_response, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`, id, change.FromStatus, toStatus, change.ChangedBy, change.Reason, change.ProviderResponse)
 	return err
 }
 
+// ListPaymentStatusHistory returns the status changes of a payment, oldest first
+func (q *Queries) ListPaymentStatusHistory(ctx context.Context, paymentID uuid.UUID) ([]PaymentStatusHistory, error) {
+	const sql = `
+		SELECT id, payment_id, from_status, to_status, changed_by, reason, provider_response, created_at
+		FROM payments.payment_status_history
+		WHERE payment_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := q.db.pool.Query(ctx, sql, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []PaymentStatusHistory
+	for rows.Next() {
+		var h PaymentStatusHistory
+		err := rows.Scan(
+			&h.ID, &h.PaymentID, &h.FromStatus, &h.ToStatus, &h.ChangedBy, &h.Reason,
+			&h.ProviderResponse, &h.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
 func (q *Queries) UpdatePaymentData(ctx context.Context, arg UpdatePaymentDataParams) error {
 	const sql = `
 		UPDATE payments.payments
@@ -126,10 +805,11 @@ func (q *Queries) UpdatePaymentData(ctx context.Context, arg UpdatePaymentDataPa
 
 func (q *Queries) ListPaymentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]Payment, error) {
 	const sql = `
-		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at
+		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at,
+		       authorized_at, authorization_expires_at, captured_at, captured_amount_minor
 		FROM payments.payments
 		WHERE order_id = $1
-		ORDER BY created_at DESC
+		ORDER BY created_at ASC
 	`
 	rows, err := q.db.pool.Query(ctx, sql, orderID)
 	if err != nil {
@@ -143,6 +823,130 @@ func (q *Queries) ListPaymentsByOrderID(ctx context.Context, orderID uuid.UUID)
 		err := rows.Scan(
 			&p.ID, &p.OrderID, &p.Method, &p.AmountMinor, &p.Currency, &p.Status,
 			&p.TransactionID, &p.PaymentData, &p.CreatedAt, &p.UpdatedAt,
+			&p.AuthorizedAt, &p.AuthorizationExpiresAt, &p.CapturedAt, &p.CapturedAmountMinor,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+	return payments, rows.Err()
+}
+
+func (q *Queries) StartOrderTenders(ctx context.Context, arg StartOrderTendersParams) (uuid.UUID, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// A PayOrder that died midway would otherwise hold the order forever
+	if _, err := tx.Exec(ctx, `
+		UPDATE payments.order_tenders
+		SET status = 'ABANDONED', updated_at = NOW()
+		WHERE order_id = $1 AND status = 'IN_PROGRESS' AND created_at < $2
+	`, arg.OrderID, arg.StaleBefore); err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO payments.order_tenders (order_id, status, created_at, updated_at)
+		VALUES ($1, 'IN_PROGRESS', NOW(), NOW())
+		RETURNING id
+	`, arg.OrderID).Scan(&id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "idx_order_tenders_order_in_progress" {
+		return uuid.Nil, ErrOrderPaymentInProgress
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var open bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM payments.payments WHERE order_id = $1 AND status = ANY($2))
+	`, arg.OrderID, arg.OpenStatuses).Scan(&open); err != nil {
+		return uuid.Nil, err
+	}
+	if open {
+		return uuid.Nil, ErrOrderPaymentInProgress
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+func (q *Queries) FinishOrderTenders(ctx context.Context, id uuid.UUID, status string) error {
+	const sql = `
+		UPDATE payments.order_tenders
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'IN_PROGRESS'
+	`
+	_, err := q.db.pool.Exec(ctx, sql, id, status)
+	return err
+}
+
+func (q *Queries) OrderTendersInProgress(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	const sql = `
+		SELECT EXISTS (
+			SELECT 1 FROM payments.order_tenders WHERE order_id = $1 AND status = 'IN_PROGRESS'
+		)
+	`
+	var inProgress bool
+	err := q.db.pool.QueryRow(ctx, sql, orderID).Scan(&inProgress)
+	return inProgress, err
+}
+
+func (q *Queries) AuthorizePayment(ctx context.Context, arg AuthorizePaymentParams) error {
+	const sql = `
+		UPDATE payments.payments
+		SET
+			status = 'PAYMENT_STATUS_AUTHORIZED',
+			transaction_id = $3,
+			authorized_at = NOW(),
+			authorization_expires_at = $4,
+			updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`
+	return q.changePaymentStatus(ctx, arg.ID, "PAYMENT_STATUS_AUTHORIZED", arg.Change, sql, arg.TransactionID, arg.ExpiresAt)
+}
+
+func (q *Queries) CapturePayment(ctx context.Context, arg CapturePaymentParams) error {
+	const sql = `
+		UPDATE payments.payments
+		SET
+			status = 'PAYMENT_STATUS_CAPTURED',
+			transaction_id = COALESCE($4, transaction_id),
+			captured_at = NOW(),
+			captured_amount_minor = $3,
+			updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`
+	return q.changePaymentStatus(ctx, arg.ID, "PAYMENT_STATUS_CAPTURED", arg.Change, sql, arg.AmountMinor, arg.TransactionID)
+}
+
+func (q *Queries) ListExpiredAuthorizations(ctx context.Context, before time.Time, limit int) ([]Payment, error) {
+	const sql = `
+		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at,
+		       authorized_at, authorization_expires_at, captured_at, captured_amount_minor
+		FROM payments.payments
+		WHERE status = 'PAYMENT_STATUS_AUTHORIZED' AND authorization_expires_at < $1
+		ORDER BY authorization_expires_at ASC
+		LIMIT $2
+	`
+	rows, err := q.db.pool.Query(ctx, sql, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []Payment
+	for rows.Next() {
+		var p Payment
+		err := rows.Scan(
+			&p.ID, &p.OrderID, &p.Method, &p.AmountMinor, &p.Currency, &p.Status,
+			&p.TransactionID, &p.PaymentData, &p.CreatedAt, &p.UpdatedAt,
+			&p.AuthorizedAt, &p.AuthorizationExpiresAt, &p.CapturedAt, &p.CapturedAmountMinor,
 		)
 		if err != nil {
 			return nil, err
@@ -151,3 +955,1832 @@ func (q *Queries) ListPaymentsByOrderID(ctx context.Context, orderID uuid.UUID)
 	}
 	return payments, rows.Err()
 }
+
+// CreateRefund records a pending refund. The payment row is locked so that
+// concurrent refunds cannot together exceed LimitMinor. It also returns the
+// amount refunded by all non-failed refunds of the payment, this one
+// included, as it stands under the lock.
+func (q *Queries) CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, int, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return Refund{}, 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM payments.payments WHERE id = $1 FOR UPDATE`, arg.PaymentID); err != nil {
+		return Refund{}, 0, err
+	}
+
+	var refunded int
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount_minor), 0)
+		FROM payments.refunds
+		WHERE payment_id = $1 AND status <> 'REFUND_STATUS_FAILED'
+	`, arg.PaymentID).Scan(&refunded); err != nil {
+		return Refund{}, 0, err
+	}
+	if refunded+arg.AmountMinor > arg.LimitMinor {
+		return Refund{}, 0, ErrRefundExceedsCapture
+	}
+
+	const sql = `
+		INSERT INTO payments.refunds (payment_id, amount_minor, currency, reason, status, destination, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'REFUND_STATUS_PENDING', COALESCE(NULLIF($5, ''), 'REFUND_DESTINATION_ORIGINAL'), NOW(), NOW())
+		RETURNING id, payment_id, amount_minor, currency, reason, status, provider_reference, created_at, updated_at, destination
+	`
+	var r Refund
+	err = tx.QueryRow(ctx, sql, arg.PaymentID, arg.AmountMinor, arg.Currency, arg.Reason, arg.Destination).Scan(
+		&r.ID, &r.PaymentID, &r.AmountMinor, &r.Currency, &r.Reason, &r.Status,
+		&r.ProviderReference, &r.CreatedAt, &r.UpdatedAt, &r.Destination,
+	)
+	if err != nil {
+		return Refund{}, 0, err
+	}
+
+	return r, refunded + r.AmountMinor, tx.Commit(ctx)
+}
+
+// RecordProviderRefund records a refund the provider has already issued as
+// SUCCEEDED and moves the payment to PARTIALLY_REFUNDED, or to REFUNDED once
+// its refunds cover LimitMinor, in one transaction. It returns the status
+// the payment moved to. A payment no longer in Change.FromStatus fails with
+// ErrPaymentStatusChanged, and a refund beyond LimitMinor with
+// ErrRefundExceedsCapture.
+func (q *Queries) RecordProviderRefund(ctx context.Context, arg RecordProviderRefundParams) (Refund, string, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return Refund{}, "", err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var current string
+	if err := tx.QueryRow(ctx, `SELECT status FROM payments.payments WHERE id = $1 FOR UPDATE`, arg.PaymentID).Scan(&current); err != nil {
+		return Refund{}, "", err
+	}
+	if current != arg.Change.FromStatus {
+		return Refund{}, "", ErrPaymentStatusChanged
+	}
+
+	var refunded int
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount_minor), 0)
+		FROM payments.refunds
+		WHERE payment_id = $1 AND status <> 'REFUND_STATUS_FAILED'
+	`, arg.PaymentID).Scan(&refunded); err != nil {
+		return Refund{}, "", err
+	}
+	amount := arg.AmountMinor
+	if amount <= 0 {
+		amount = arg.LimitMinor - refunded
+	}
+	if amount <= 0 || refunded+amount > arg.LimitMinor {
+		return Refund{}, "", ErrRefundExceedsCapture
+	}
+
+	const sql = `
+		INSERT INTO payments.refunds (payment_id, amount_minor, currency, reason, status, provider_reference, destination, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'REFUND_STATUS_SUCCEEDED', $5, 'REFUND_DESTINATION_ORIGINAL', NOW(), NOW())
+		RETURNING id, payment_id, amount_minor, currency, reason, status, provider_reference, created_at, updated_at, destination
+	`
+	var r Refund
+	err = tx.QueryRow(ctx, sql, arg.PaymentID, amount, arg.Currency, arg.Reason, arg.ProviderReference).Scan(
+		&r.ID, &r.PaymentID, &r.AmountMinor, &r.Currency, &r.Reason, &r.Status,
+		&r.ProviderReference, &r.CreatedAt, &r.UpdatedAt, &r.Destination,
+	)
+	if err != nil {
+		return Refund{}, "", err
+	}
+
+	toStatus := "PAYMENT_STATUS_PARTIALLY_REFUNDED"
+	if refunded+amount >= arg.LimitMinor {
+		toStatus = "PAYMENT_STATUS_REFUNDED"
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE payments.payments SET status = $2, updated_at = NOW() WHERE id = $1
+	`, arg.PaymentID, toStatus); err != nil {
+		return Refund{}, "", err
+	}
+	if err := insertPaymentStatusHistory(ctx, tx, arg.PaymentID, toStatus, arg.Change); err != nil {
+		return Refund{}, "", err
+	}
+
+	return r, toStatus, tx.Commit(ctx)
+}
+
+func (q *Queries) UpdateRefundStatus(ctx context.Context, arg UpdateRefundStatusParams) error {
+	const sql = `
+		UPDATE payments.refunds
+		SET
+			status = $2,
+			provider_reference = COALESCE($3, provider_reference),
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := q.db.pool.Exec(ctx, sql, arg.ID, arg.Status, arg.ProviderReference)
+	return err
+}
+
+func (q *Queries) ListRefundsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]Refund, error) {
+	const sql = `
+		SELECT id, payment_id, amount_minor, currency, reason, status, provider_reference, created_at, updated_at, destination
+		FROM payments.refunds
+		WHERE payment_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := q.db.pool.Query(ctx, sql, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refunds []Refund
+	for rows.Next() {
+		var r Refund
+		err := rows.Scan(
+			&r.ID, &r.PaymentID, &r.AmountMinor, &r.Currency, &r.Reason, &r.Status,
+			&r.ProviderReference, &r.CreatedAt, &r.UpdatedAt, &r.Destination,
+		)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, r)
+	}
+	return refunds, rows.Err()
+}
+
+// RecordWebhookEvent stores a provider event ID. It reports false when the
+// event was already recorded, i.e. the delivery is a replay.
+func (q *Queries) RecordWebhookEvent(ctx context.Context, eventID string, eventType string) (bool, error) {
+	const sql = `
+		INSERT INTO payments.webhook_events (event_id, event_type, received_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (event_id) DO NOTHING
+	`
+	tag, err := q.db.pool.Exec(ctx, sql, eventID, eventType)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (q *Queries) DeleteWebhookEvent(ctx context.Context, eventID string) error {
+	const sql = `DELETE FROM payments.webhook_events WHERE event_id = $1`
+	_, err := q.db.pool.Exec(ctx, sql, eventID)
+	return err
+}
+
+const webhookEndpointColumns = `id, url, description, event_types, secret, enabled, consecutive_failures, disabled_at, created_at, updated_at`
+
+func scanWebhookEndpoint(row pgx.Row) (WebhookEndpoint, error) {
+	var e WebhookEndpoint
+	err := row.Scan(
+		&e.ID, &e.URL, &e.Description, &e.EventTypes, &e.Secret, &e.Enabled,
+		&e.ConsecutiveFailures, &e.DisabledAt, &e.CreatedAt, &e.UpdatedAt,
+	)
+	return e, err
+}
+
+func (q *Queries) CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error) {
+	sql := `
+		INSERT INTO payments.webhook_endpoints (url, description, event_types, secret, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING ` + webhookEndpointColumns
+	return scanWebhookEndpoint(q.db.pool.QueryRow(ctx, sql, arg.URL, arg.Description, arg.EventTypes, arg.Secret))
+}
+
+func (q *Queries) GetWebhookEndpoint(ctx context.Context, id uuid.UUID) (WebhookEndpoint, error) {
+	sql := `SELECT ` + webhookEndpointColumns + ` FROM payments.webhook_endpoints WHERE id = $1`
+	return scanWebhookEndpoint(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+func (q *Queries) ListWebhookEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	sql := `SELECT ` + webhookEndpointColumns + ` FROM payments.webhook_endpoints ORDER BY created_at ASC`
+	rows, err := q.db.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []WebhookEndpoint
+	for rows.Next() {
+		e, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, rows.Err()
+}
+
+// UpdateWebhookEndpoint changes the given fields. Re-enabling an endpoint
+// clears its failure count so it is not disabled again on the next failure.
+func (q *Queries) UpdateWebhookEndpoint(ctx context.Context, arg UpdateWebhookEndpointParams) (WebhookEndpoint, error) {
+	sql := `
+		UPDATE payments.webhook_endpoints
+		SET
+			url = COALESCE($2, url),
+			description = COALESCE($3, description),
+			event_types = COALESCE($4, event_types),
+			enabled = COALESCE($5, enabled),
+			consecutive_failures = CASE WHEN $5 THEN 0 ELSE consecutive_failures END,
+			disabled_at = CASE WHEN $5 THEN NULL ELSE disabled_at END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + webhookEndpointColumns
+	return scanWebhookEndpoint(q.db.pool.QueryRow(ctx, sql, arg.ID, arg.URL, arg.Description, arg.EventTypes, arg.Enabled))
+}
+
+func (q *Queries) DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error {
+	tag, err := q.db.pool.Exec(ctx, `DELETE FROM payments.webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues one delivery per enabled endpoint subscribed
+// to the event type and returns how many were queued.
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int, error) {
+	const sql = `
+		INSERT INTO payments.webhook_deliveries (endpoint_id, event_id, event_type, payload, next_attempt_at, created_at, updated_at)
+		SELECT id, $1, $2, $3, NOW(), NOW(), NOW()
+		FROM payments.webhook_endpoints
+		WHERE enabled AND ($2 = ANY(event_types) OR '*' = ANY(event_types))
+	`
+	tag, err := q.db.pool.Exec(ctx, sql, arg.EventID, arg.EventType, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimDueWebhookDeliveries picks pending deliveries whose next attempt is due
+// and pushes their next attempt out by lease, so that other workers skip them
+// and a crashed worker's claims are retried once the lease runs out.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]DueWebhookDelivery, error) {
+	const sql = `
+		WITH due AS (
+			SELECT d.id
+			FROM payments.webhook_deliveries d
+			JOIN payments.webhook_endpoints e ON e.id = d.endpoint_id
+			WHERE d.status = 'WEBHOOK_DELIVERY_STATUS_PENDING'
+				AND d.next_attempt_at <= NOW()
+				AND e.enabled
+			ORDER BY d.next_attempt_at ASC
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE payments.webhook_deliveries d
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 second', updated_at = NOW()
+		FROM due, payments.webhook_endpoints e
+		WHERE d.id = due.id AND e.id = d.endpoint_id
+		RETURNING d.id, d.endpoint_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+			d.next_attempt_at, d.last_attempt_at, d.response_code, d.last_error, d.created_at, d.updated_at,
+			e.url, e.secret
+	`
+	rows, err := q.db.pool.Query(ctx, sql, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []DueWebhookDelivery
+	for rows.Next() {
+		var d DueWebhookDelivery
+		err := rows.Scan(
+			&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+			&d.NextAttemptAt, &d.LastAttemptAt, &d.ResponseCode, &d.LastError, &d.CreatedAt, &d.UpdatedAt,
+			&d.URL, &d.Secret,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// MarkWebhookDeliverySucceeded records a successful attempt and resets the
+// endpoint's consecutive failure count.
+func (q *Queries) MarkWebhookDeliverySucceeded(ctx context.Context, id uuid.UUID, responseCode int) error {
+	const sql = `
+		WITH d AS (
+			UPDATE payments.webhook_deliveries
+			SET
+				status = 'WEBHOOK_DELIVERY_STATUS_SUCCEEDED',
+				attempts = attempts + 1,
+				last_attempt_at = NOW(),
+				response_code = $2,
+				last_error = NULL,
+				updated_at = NOW()
+			WHERE id = $1
+			RETURNING endpoint_id
+		)
+		UPDATE payments.webhook_endpoints e
+		SET consecutive_failures = 0, updated_at = NOW()
+		FROM d
+		WHERE e.id = d.endpoint_id
+	`
+	_, err := q.db.pool.Exec(ctx, sql, id, responseCode)
+	return err
+}
+
+// MarkWebhookDeliveryFailed records a failed attempt, either rescheduling the
+// delivery or giving up on it, and counts the failure against the endpoint.
+// It reports true when this failure disabled the endpoint.
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) (bool, error) {
+	const sql = `
+		WITH d AS (
+			UPDATE payments.webhook_deliveries
+			SET
+				status = CASE WHEN $4::timestamp IS NULL THEN 'WEBHOOK_DELIVERY_STATUS_FAILED' ELSE status END,
+				attempts = attempts + 1,
+				next_attempt_at = COALESCE($4, next_attempt_at),
+				last_attempt_at = NOW(),
+				response_code = $2,
+				last_error = $3,
+				updated_at = NOW()
+			WHERE id = $1
+			RETURNING endpoint_id
+		)
+		UPDATE payments.webhook_endpoints e
+		SET
+			consecutive_failures = e.consecutive_failures + 1,
+			enabled = e.enabled AND e.consecutive_failures + 1 < $5,
+			disabled_at = CASE
+				WHEN e.enabled AND e.consecutive_failures + 1 >= $5 THEN NOW()
+				ELSE e.disabled_at
+			END,
+			updated_at = NOW()
+		FROM d
+		WHERE e.id = d.endpoint_id
+		RETURNING NOT e.enabled AND e.consecutive_failures = $5
+	`
+	var disabled bool
+	err := q.db.pool.QueryRow(ctx, sql, arg.ID, arg.ResponseCode, arg.Error, arg.NextAttemptAt, arg.DisableAfter).Scan(&disabled)
+	return disabled, err
+}
+
+const webhookDeliveryColumns = `id, endpoint_id, event_id, event_type, payload, status, attempts,
+	next_attempt_at, last_attempt_at, response_code, last_error, created_at, updated_at`
+
+func scanWebhookDelivery(row pgx.Row) (WebhookDelivery, error) {
+	var d WebhookDelivery
+	err := row.Scan(
+		&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &d.ResponseCode, &d.LastError, &d.CreatedAt, &d.UpdatedAt,
+	)
+	return d, err
+}
+
+// ListWebhookDeliveries returns a page of the delivery log, newest first,
+// together with the total number of matching deliveries.
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, int, error) {
+	const where = `
+		WHERE ($1::uuid IS NULL OR endpoint_id = $1)
+			AND ($2::text IS NULL OR status = $2)
+	`
+	var total int
+	err := q.db.pool.QueryRow(ctx, `SELECT COUNT(*) FROM payments.webhook_deliveries`+where, arg.EndpointID, arg.Status).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sql := `SELECT ` + webhookDeliveryColumns + ` FROM payments.webhook_deliveries` + where + `
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+	rows, err := q.db.pool.Query(ctx, sql, arg.EndpointID, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, total, rows.Err()
+}
+
+// ReplayWebhookDelivery queues a fresh copy of an earlier delivery so that it
+// is sent again with its original event ID and payload.
+func (q *Queries) ReplayWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	sql := `
+		INSERT INTO payments.webhook_deliveries (endpoint_id, event_id, event_type, payload, next_attempt_at, created_at, updated_at)
+		SELECT endpoint_id, event_id, event_type, payload, NOW(), NOW(), NOW()
+		FROM payments.webhook_deliveries
+		WHERE id = $1
+		RETURNING ` + webhookDeliveryColumns
+	return scanWebhookDelivery(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+func (q *Queries) ListPaymentsByTransactionIDs(ctx context.Context, transactionIDs []string) ([]Payment, error) {
+	const sql = `
+		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at,
+		       authorized_at, authorization_expires_at, captured_at, captured_amount_minor
+		FROM payments.payments
+		WHERE transaction_id = ANY($1)
+	`
+	return q.queryPayments(ctx, sql, transactionIDs)
+}
+
+// ListSettleablePayments returns payments captured in [from, to) that a
+// provider is expected to settle. Methods without a separate capture count
+// from when the payment was created.
+func (q *Queries) ListSettleablePayments(ctx context.Context, methods []string, from, to time.Time) ([]Payment, error) {
+	const sql = `
+		SELECT id, order_id, method, amount_minor, currency, status, transaction_id, payment_data, created_at, updated_at,
+		       authorized_at, authorization_expires_at, captured_at, captured_amount_minor
+		FROM payments.payments
+		WHERE status IN ('PAYMENT_STATUS_COMPLETED', 'PAYMENT_STATUS_CAPTURED', 'PAYMENT_STATUS_PARTIALLY_REFUNDED', 'PAYMENT_STATUS_REFUNDED',
+				'PAYMENT_STATUS_CHARGED_BACK')
+			AND method = ANY($1)
+			AND transaction_id IS NOT NULL
+			AND COALESCE(captured_at, created_at) >= $2
+			AND COALESCE(captured_at, created_at) < $3
+		ORDER BY COALESCE(captured_at, created_at) ASC
+	`
+	return q.queryPayments(ctx, sql, methods, from, to)
+}
+
+func (q *Queries) queryPayments(ctx context.Context, sql string, args ...interface{}) ([]Payment, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []Payment
+	for rows.Next() {
+		var p Payment
+		err := rows.Scan(
+			&p.ID, &p.OrderID, &p.Method, &p.AmountMinor, &p.Currency, &p.Status,
+			&p.TransactionID, &p.PaymentData, &p.CreatedAt, &p.UpdatedAt,
+			&p.AuthorizedAt, &p.AuthorizationExpiresAt, &p.CapturedAt, &p.CapturedAmountMinor,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+	return payments, rows.Err()
+}
+
+const reconciliationRunColumns = `id, provider, file_name, file_format, period_start, period_end,
+	total_lines, matched_count, exception_count, settled_amount_minor, created_at`
+
+func scanReconciliationRun(row pgx.Row) (ReconciliationRun, error) {
+	var r ReconciliationRun
+	err := row.Scan(
+		&r.ID, &r.Provider, &r.FileName, &r.FileFormat, &r.PeriodStart, &r.PeriodEnd,
+		&r.TotalLines, &r.MatchedCount, &r.ExceptionCount, &r.SettledAmountMinor, &r.CreatedAt,
+	)
+	return r, err
+}
+
+// CreateReconciliationRun stores a run and all of its items in one
+// transaction, so finance never sees a partially written run.
+func (q *Queries) CreateReconciliationRun(ctx context.Context, arg CreateReconciliationRunParams) (ReconciliationRun, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return ReconciliationRun{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sql := `
+		INSERT INTO payments.reconciliation_runs (
+			provider, file_name, file_format, period_start, period_end,
+			total_lines, matched_count, exception_count, settled_amount_minor, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		RETURNING ` + reconciliationRunColumns
+	run, err := scanReconciliationRun(tx.QueryRow(ctx, sql,
+		arg.Provider, arg.FileName, arg.FileFormat, arg.PeriodStart, arg.PeriodEnd,
+		arg.TotalLines, arg.MatchedCount, arg.ExceptionCount, arg.SettledAmountMinor,
+	))
+	if err != nil {
+		return ReconciliationRun{}, err
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"payments", "reconciliation_items"},
+		[]string{
+			"run_id", "result", "transaction_id", "payment_id", "line_number", "settled_amount_minor",
+			"expected_amount_minor", "fee_minor", "currency", "settled_on", "detail",
+		},
+		pgx.CopyFromSlice(len(arg.Items), func(i int) ([]interface{}, error) {
+			it := arg.Items[i]
+			return []interface{}{
+				run.ID, it.Result, it.TransactionID, it.PaymentID, it.LineNumber, it.SettledAmountMinor,
+				it.ExpectedAmountMinor, it.FeeMinor, it.Currency, it.SettledOn, it.Detail,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return ReconciliationRun{}, err
+	}
+
+	return run, tx.Commit(ctx)
+}
+
+func (q *Queries) GetReconciliationRun(ctx context.Context, id uuid.UUID) (ReconciliationRun, error) {
+	sql := `SELECT ` + reconciliationRunColumns + ` FROM payments.reconciliation_runs WHERE id = $1`
+	return scanReconciliationRun(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+// ListReconciliationRuns returns a page of runs, newest first, together with
+// the total number of runs.
+func (q *Queries) ListReconciliationRuns(ctx context.Context, limit, offset int) ([]ReconciliationRun, int, error) {
+	var total int
+	if err := q.db.pool.QueryRow(ctx, `SELECT COUNT(*) FROM payments.reconciliation_runs`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	sql := `SELECT ` + reconciliationRunColumns + ` FROM payments.reconciliation_runs
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+	rows, err := q.db.pool.Query(ctx, sql, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var runs []ReconciliationRun
+	for rows.Next() {
+		r, err := scanReconciliationRun(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		runs = append(runs, r)
+	}
+	return runs, total, rows.Err()
+}
+
+func (q *Queries) ListReconciliationItems(ctx context.Context, runID uuid.UUID, includeMatched bool) ([]ReconciliationItem, error) {
+	const sql = `
+		SELECT id, run_id, result, transaction_id, payment_id, line_number, settled_amount_minor,
+		       expected_amount_minor, fee_minor, currency, settled_on, detail
+		FROM payments.reconciliation_items
+		WHERE run_id = $1 AND ($2 OR result <> 'RECONCILIATION_RESULT_MATCHED')
+		ORDER BY line_number ASC NULLS LAST, transaction_id ASC
+	`
+	rows, err := q.db.pool.Query(ctx, sql, runID, includeMatched)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []ReconciliationItem
+	for rows.Next() {
+		var it ReconciliationItem
+		err := rows.Scan(
+			&it.ID, &it.RunID, &it.Result, &it.TransactionID, &it.PaymentID, &it.LineNumber, &it.SettledAmountMinor,
+			&it.ExpectedAmountMinor, &it.FeeMinor, &it.Currency, &it.SettledOn, &it.Detail,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+const bankTransferAccountColumns = `payment_id, account_number, expected_amount_minor, received_amount_minor,
+	currency, deadline, status, created_at, updated_at`
+
+func scanBankTransferAccount(row pgx.Row) (BankTransferAccount, error) {
+	var a BankTransferAccount
+	err := row.Scan(
+		&a.PaymentID, &a.AccountNumber, &a.ExpectedAmountMinor, &a.ReceivedAmountMinor,
+		&a.Currency, &a.Deadline, &a.Status, &a.CreatedAt, &a.UpdatedAt,
+	)
+	return a, err
+}
+
+// openBankTransferStatuses are the statuses in which an account still
+// accepts deposits
+const openBankTransferStatuses = `('BANK_TRANSFER_STATUS_AWAITING', 'BANK_TRANSFER_STATUS_UNDERPAID')`
+
+// CreateBankTransferAccount issues the next virtual account number to a
+// payment. Once the sequence wraps a number may still belong to an open
+// account, in which case the next number is tried.
+func (q *Queries) CreateBankTransferAccount(ctx context.Context, arg CreateBankTransferAccountParams) (BankTransferAccount, error) {
+	sql := `
+		INSERT INTO payments.bank_transfer_accounts (
+			payment_id, account_number, expected_amount_minor, currency, deadline, created_at, updated_at
+		)
+		VALUES ($1, LPAD(nextval('payments.virtual_account_number_seq')::text, 7, '0'), $2, $3, $4, NOW(), NOW())
+		RETURNING ` + bankTransferAccountColumns
+
+	const attempts = 5
+	var err error
+	for i := 0; i < attempts; i++ {
+		var account BankTransferAccount
+		account, err = scanBankTransferAccount(q.db.pool.QueryRow(ctx, sql, arg.PaymentID, arg.ExpectedAmountMinor, arg.Currency, arg.Deadline))
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "idx_bank_transfer_accounts_open_number" {
+			continue
+		}
+		return account, err
+	}
+	return BankTransferAccount{}, err
+}
+
+func (q *Queries) GetBankTransferAccount(ctx context.Context, paymentID uuid.UUID) (BankTransferAccount, error) {
+	sql := `SELECT ` + bankTransferAccountColumns + ` FROM payments.bank_transfer_accounts WHERE payment_id = $1`
+	return scanBankTransferAccount(q.db.pool.QueryRow(ctx, sql, paymentID))
+}
+
+const bankDepositColumns = `id, account_number, inquiry_number, value_date, amount_minor, depositor_name,
+	remitting_bank, remitting_branch, payment_id, match, file_name, created_at`
+
+func scanBankDeposit(row pgx.Row) (BankDeposit, error) {
+	var d BankDeposit
+	err := row.Scan(
+		&d.ID, &d.AccountNumber, &d.InquiryNumber, &d.ValueDate, &d.AmountMinor, &d.DepositorName,
+		&d.RemittingBank, &d.RemittingBranch, &d.PaymentID, &d.Match, &d.FileName, &d.CreatedAt,
+	)
+	return d, err
+}
+
+// RecordBankDeposit stores an imported deposit and applies it to the open
+// virtual account with the same number, if any. The returned account is nil
+// when the deposit could not be matched. Deposits imported before return
+// ErrDuplicateBankDeposit so that re-importing a file is harmless.
+func (q *Queries) RecordBankDeposit(ctx context.Context, arg RecordBankDepositParams) (BankDeposit, *BankTransferAccount, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return BankDeposit{}, nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var depositID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO payments.bank_deposits (
+			account_number, inquiry_number, value_date, amount_minor, depositor_name,
+			remitting_bank, remitting_branch, match, file_name, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'BANK_DEPOSIT_MATCH_UNMATCHED', $8, NOW())
+		ON CONFLICT (account_number, inquiry_number, value_date) DO NOTHING
+		RETURNING id
+	`, arg.AccountNumber, arg.InquiryNumber, arg.ValueDate, arg.AmountMinor, arg.DepositorName,
+		arg.RemittingBank, arg.RemittingBranch, arg.FileName,
+	).Scan(&depositID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return BankDeposit{}, nil, ErrDuplicateBankDeposit
+	}
+	if err != nil {
+		return BankDeposit{}, nil, err
+	}
+
+	var account *BankTransferAccount
+	a, err := scanBankTransferAccount(tx.QueryRow(ctx, `
+		SELECT `+bankTransferAccountColumns+`
+		FROM payments.bank_transfer_accounts
+		WHERE account_number = $1 AND status IN `+openBankTransferStatuses+`
+		FOR UPDATE
+	`, arg.AccountNumber))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return BankDeposit{}, nil, err
+	default:
+		received := a.ReceivedAmountMinor + arg.AmountMinor
+		accountStatus, match := bankTransferOutcome(a.ExpectedAmountMinor, received)
+		a, err = scanBankTransferAccount(tx.QueryRow(ctx, `
+			UPDATE payments.bank_transfer_accounts
+			SET received_amount_minor = $2, status = $3, updated_at = NOW()
+			WHERE payment_id = $1
+			RETURNING `+bankTransferAccountColumns,
+			a.PaymentID, received, accountStatus,
+		))
+		if err != nil {
+			return BankDeposit{}, nil, err
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE payments.bank_deposits SET payment_id = $2, match = $3 WHERE id = $1
+		`, depositID, a.PaymentID, match); err != nil {
+			return BankDeposit{}, nil, err
+		}
+		account = &a
+	}
+
+	deposit, err := scanBankDeposit(tx.QueryRow(ctx, `SELECT `+bankDepositColumns+` FROM payments.bank_deposits WHERE id = $1`, depositID))
+	if err != nil {
+		return BankDeposit{}, nil, err
+	}
+	return deposit, account, tx.Commit(ctx)
+}
+
+// bankTransferOutcome classifies the running total received by an account,
+// returning the new account status and the match recorded on the deposit
+func bankTransferOutcome(expected, received int) (string, string) {
+	switch {
+	case received < expected:
+		return "BANK_TRANSFER_STATUS_UNDERPAID", "BANK_DEPOSIT_MATCH_UNDERPAID"
+	case received > expected:
+		return "BANK_TRANSFER_STATUS_OVERPAID", "BANK_DEPOSIT_MATCH_OVERPAID"
+	default:
+		return "BANK_TRANSFER_STATUS_PAID", "BANK_DEPOSIT_MATCH_MATCHED"
+	}
+}
+
+// ListOverdueBankTransfers returns open accounts whose deadline is before the
+// given time, oldest first
+func (q *Queries) ListOverdueBankTransfers(ctx context.Context, before time.Time, limit int) ([]BankTransferAccount, error) {
+	sql := `
+		SELECT ` + bankTransferAccountColumns + `
+		FROM payments.bank_transfer_accounts
+		WHERE status IN ` + openBankTransferStatuses + ` AND deadline < $1
+		ORDER BY deadline ASC
+		LIMIT $2`
+	return q.queryBankTransferAccounts(ctx, sql, before, limit)
+}
+
+// ListUncompletedBankTransfers returns paid accounts whose payment is still
+// processing, which happens when completing the payment failed after the
+// deposit was recorded
+func (q *Queries) ListUncompletedBankTransfers(ctx context.Context, limit int) ([]BankTransferAccount, error) {
+	sql := `
+		SELECT ` + bankTransferAccountColumns + `
+		FROM payments.bank_transfer_accounts
+		WHERE status IN ('BANK_TRANSFER_STATUS_PAID', 'BANK_TRANSFER_STATUS_OVERPAID')
+			AND payment_id IN (SELECT id FROM payments.payments WHERE status = 'PAYMENT_STATUS_PROCESSING')
+		ORDER BY updated_at ASC
+		LIMIT $1`
+	return q.queryBankTransferAccounts(ctx, sql, limit)
+}
+
+func (q *Queries) queryBankTransferAccounts(ctx context.Context, sql string, args ...interface{}) ([]BankTransferAccount, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []BankTransferAccount
+	for rows.Next() {
+		a, err := scanBankTransferAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
+
+// ExpireBankTransferAccount closes an open account. It reports false when a
+// deposit completed the account first.
+func (q *Queries) ExpireBankTransferAccount(ctx context.Context, paymentID uuid.UUID) (bool, error) {
+	sql := `
+		UPDATE payments.bank_transfer_accounts
+		SET status = 'BANK_TRANSFER_STATUS_EXPIRED', updated_at = NOW()
+		WHERE payment_id = $1 AND status IN ` + openBankTransferStatuses
+	tag, err := q.db.pool.Exec(ctx, sql, paymentID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ListBankDeposits returns a page of imported deposits, newest first,
+// together with the total number of matching deposits.
+func (q *Queries) ListBankDeposits(ctx context.Context, arg ListBankDepositsParams) ([]BankDeposit, int, error) {
+	const where = ` WHERE ($1::text IS NULL OR match = $1)`
+	var total int
+	if err := q.db.pool.QueryRow(ctx, `SELECT COUNT(*) FROM payments.bank_deposits`+where, arg.Match).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	sql := `SELECT ` + bankDepositColumns + ` FROM payments.bank_deposits` + where + `
+		ORDER BY created_at DESC, value_date DESC
+		LIMIT $2 OFFSET $3`
+	rows, err := q.db.pool.Query(ctx, sql, arg.Match, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var deposits []BankDeposit
+	for rows.Next() {
+		d, err := scanBankDeposit(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		deposits = append(deposits, d)
+	}
+	return deposits, total, rows.Err()
+}
+
+const deferredPaymentColumns = `payment_id, provider, provider_transaction_id, credit_check, credit_check_reason,
+	invoice_status, carrier, tracking_number, shipped_at, paid_at, created_at, updated_at`
+
+func scanDeferredPayment(row pgx.Row) (DeferredPayment, error) {
+	var d DeferredPayment
+	err := row.Scan(
+		&d.PaymentID, &d.Provider, &d.ProviderTransactionID, &d.CreditCheck, &d.CreditCheckReason,
+		&d.InvoiceStatus, &d.Carrier, &d.TrackingNumber, &d.ShippedAt, &d.PaidAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	return d, err
+}
+
+const konbiniPaymentColumns = `payment_id, store, expires_at, cancelled_at, created_at`
+
+func scanKonbiniPayment(row pgx.Row) (KonbiniPayment, error) {
+	var k KonbiniPayment
+	err := row.Scan(&k.PaymentID, &k.Store, &k.ExpiresAt, &k.CancelledAt, &k.CreatedAt)
+	return k, err
+}
+
+func (q *Queries) CreateKonbiniPayment(ctx context.Context, arg CreateKonbiniPaymentParams) (KonbiniPayment, error) {
+	sql := `
+		INSERT INTO payments.konbini_payments (payment_id, store, expires_at, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING ` + konbiniPaymentColumns
+	return scanKonbiniPayment(q.db.pool.QueryRow(ctx, sql, arg.PaymentID, arg.Store, arg.ExpiresAt))
+}
+
+func (q *Queries) GetKonbiniPayment(ctx context.Context, paymentID uuid.UUID) (KonbiniPayment, error) {
+	sql := `SELECT ` + konbiniPaymentColumns + ` FROM payments.konbini_payments WHERE payment_id = $1`
+	return scanKonbiniPayment(q.db.pool.QueryRow(ctx, sql, paymentID))
+}
+
+// ListExpiredKonbiniPayments returns konbini payments still awaiting payment
+// whose deadline is before the given time, oldest first
+func (q *Queries) ListExpiredKonbiniPayments(ctx context.Context, before time.Time, limit int) ([]KonbiniPayment, error) {
+	sql := `
+		SELECT ` + konbiniPaymentColumns + `
+		FROM payments.konbini_payments
+		WHERE expires_at < $1
+			AND payment_id IN (SELECT id FROM payments.payments WHERE status = 'PAYMENT_STATUS_PROCESSING')
+		ORDER BY expires_at ASC
+		LIMIT $2`
+	rows, err := q.db.pool.Query(ctx, sql, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []KonbiniPayment
+	for rows.Next() {
+		k, err := scanKonbiniPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, k)
+	}
+	return payments, rows.Err()
+}
+
+// MarkKonbiniPaymentCancelled records when the payment code was cancelled at
+// the provider. Later calls keep the first time.
+func (q *Queries) MarkKonbiniPaymentCancelled(ctx context.Context, paymentID uuid.UUID) error {
+	const sql = `
+		UPDATE payments.konbini_payments
+		SET cancelled_at = COALESCE(cancelled_at, NOW())
+		WHERE payment_id = $1`
+	_, err := q.db.pool.Exec(ctx, sql, paymentID)
+	return err
+}
+
+// CreateDeferredPayment records the credit check of a deferred payment. A
+// retried check replaces the earlier one.
+func (q *Queries) CreateDeferredPayment(ctx context.Context, arg CreateDeferredPaymentParams) (DeferredPayment, error) {
+	sql := `
+		INSERT INTO payments.deferred_payments (
+			payment_id, provider, provider_transaction_id, credit_check, credit_check_reason, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (payment_id) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			provider_transaction_id = EXCLUDED.provider_transaction_id,
+			credit_check = EXCLUDED.credit_check,
+			credit_check_reason = EXCLUDED.credit_check_reason,
+			updated_at = NOW()
+		RETURNING ` + deferredPaymentColumns
+	return scanDeferredPayment(q.db.pool.QueryRow(ctx, sql,
+		arg.PaymentID, arg.Provider, arg.ProviderTransactionID, arg.CreditCheck, arg.CreditCheckReason))
+}
+
+func (q *Queries) GetDeferredPayment(ctx context.Context, paymentID uuid.UUID) (DeferredPayment, error) {
+	sql := `SELECT ` + deferredPaymentColumns + ` FROM payments.deferred_payments WHERE payment_id = $1`
+	return scanDeferredPayment(q.db.pool.QueryRow(ctx, sql, paymentID))
+}
+
+func (q *Queries) UpdateDeferredCreditCheck(ctx context.Context, paymentID uuid.UUID, creditCheck string, reason *string) error {
+	const sql = `
+		UPDATE payments.deferred_payments
+		SET credit_check = $2, credit_check_reason = $3, updated_at = NOW()
+		WHERE payment_id = $1
+	`
+	_, err := q.db.pool.Exec(ctx, sql, paymentID, creditCheck, reason)
+	return err
+}
+
+// RecordDeferredShipment stores the shipment registered with the provider,
+// which issues the invoice
+func (q *Queries) RecordDeferredShipment(ctx context.Context, arg RecordDeferredShipmentParams) error {
+	const sql = `
+		UPDATE payments.deferred_payments
+		SET
+			carrier = $2,
+			tracking_number = $3,
+			shipped_at = $4,
+			invoice_status = 'DEFERRED_INVOICE_STATUS_ISSUED',
+			updated_at = NOW()
+		WHERE payment_id = $1
+	`
+	_, err := q.db.pool.Exec(ctx, sql, arg.PaymentID, arg.Carrier, arg.TrackingNumber, arg.ShippedAt)
+	return err
+}
+
+func (q *Queries) UpdateDeferredInvoiceStatus(ctx context.Context, paymentID uuid.UUID, invoiceStatus string) error {
+	const sql = `
+		UPDATE payments.deferred_payments
+		SET
+			invoice_status = $2,
+			paid_at = CASE WHEN $2 = 'DEFERRED_INVOICE_STATUS_PAID' THEN NOW() ELSE paid_at END,
+			updated_at = NOW()
+		WHERE payment_id = $1
+	`
+	_, err := q.db.pool.Exec(ctx, sql, paymentID, invoiceStatus)
+	return err
+}
+
+// ListDeferredPaymentsInReview returns credit checks still in manual review,
+// least recently checked first
+func (q *Queries) ListDeferredPaymentsInReview(ctx context.Context, limit int) ([]DeferredPayment, error) {
+	sql := `
+		SELECT ` + deferredPaymentColumns + `
+		FROM payments.deferred_payments
+		WHERE credit_check = 'CREDIT_CHECK_RESULT_REVIEW'
+		ORDER BY updated_at ASC
+		LIMIT $1`
+	return q.queryDeferredPayments(ctx, sql, limit)
+}
+
+// ListOpenDeferredInvoices returns issued invoices the customer has not paid
+// yet, least recently checked first
+func (q *Queries) ListOpenDeferredInvoices(ctx context.Context, limit int) ([]DeferredPayment, error) {
+	sql := `
+		SELECT ` + deferredPaymentColumns + `
+		FROM payments.deferred_payments
+		WHERE invoice_status IN ('DEFERRED_INVOICE_STATUS_ISSUED', 'DEFERRED_INVOICE_STATUS_OVERDUE')
+		ORDER BY updated_at ASC
+		LIMIT $1`
+	return q.queryDeferredPayments(ctx, sql, limit)
+}
+
+func (q *Queries) queryDeferredPayments(ctx context.Context, sql string, args ...interface{}) ([]DeferredPayment, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []DeferredPayment
+	for rows.Next() {
+		d, err := scanDeferredPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, d)
+	}
+	return payments, rows.Err()
+}
+
+const savedPaymentMethodColumns = `id, user_id, provider, provider_token, brand, last4, exp_month, exp_year,
+	expires_at, is_default, last_used_at, created_at, updated_at`
+
+func scanSavedPaymentMethod(row pgx.Row) (SavedPaymentMethod, error) {
+	var m SavedPaymentMethod
+	err := row.Scan(
+		&m.ID, &m.UserID, &m.Provider, &m.ProviderToken, &m.Brand, &m.Last4, &m.ExpMonth, &m.ExpYear,
+		&m.ExpiresAt, &m.IsDefault, &m.LastUsedAt, &m.CreatedAt, &m.UpdatedAt,
+	)
+	return m, err
+}
+
+// SaveSavedPaymentMethod saves a card for a user. Saving a card the user
+// already has replaces its token. The user's first card becomes the default.
+func (q *Queries) SaveSavedPaymentMethod(ctx context.Context, arg SaveSavedPaymentMethodParams) (SavedPaymentMethod, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return SavedPaymentMethod{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if arg.MakeDefault {
+		if _, err := tx.Exec(ctx, `
+			UPDATE payments.saved_payment_methods
+			SET is_default = FALSE, updated_at = NOW()
+			WHERE user_id = $1 AND is_default
+		`, arg.UserID); err != nil {
+			return SavedPaymentMethod{}, err
+		}
+	}
+
+	sql := `
+		INSERT INTO payments.saved_payment_methods (
+			user_id, provider, provider_token, brand, last4, exp_month, exp_year, expires_at, is_default, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+			$9 OR NOT EXISTS (SELECT 1 FROM payments.saved_payment_methods WHERE user_id = $1 AND is_default),
+			NOW(), NOW())
+		ON CONFLICT (user_id, brand, last4, exp_month, exp_year) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			provider_token = EXCLUDED.provider_token,
+			is_default = saved_payment_methods.is_default OR EXCLUDED.is_default,
+			updated_at = NOW()
+		RETURNING ` + savedPaymentMethodColumns
+	m, err := scanSavedPaymentMethod(tx.QueryRow(ctx, sql,
+		arg.UserID, arg.Provider, arg.ProviderToken, arg.Brand, arg.Last4, arg.ExpMonth, arg.ExpYear, arg.ExpiresAt, arg.MakeDefault))
+	if err != nil {
+		return SavedPaymentMethod{}, err
+	}
+
+	return m, tx.Commit(ctx)
+}
+
+func (q *Queries) GetSavedPaymentMethod(ctx context.Context, id uuid.UUID) (SavedPaymentMethod, error) {
+	sql := `SELECT ` + savedPaymentMethodColumns + ` FROM payments.saved_payment_methods WHERE id = $1`
+	return scanSavedPaymentMethod(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+// ListSavedPaymentMethods returns a user's cards, default first, then most
+// recently used
+func (q *Queries) ListSavedPaymentMethods(ctx context.Context, userID uuid.UUID) ([]SavedPaymentMethod, error) {
+	sql := `
+		SELECT ` + savedPaymentMethodColumns + `
+		FROM payments.saved_payment_methods
+		WHERE user_id = $1
+		ORDER BY is_default DESC, last_used_at DESC NULLS LAST, created_at DESC`
+	return q.querySavedPaymentMethods(ctx, sql, userID)
+}
+
+// SetDefaultSavedPaymentMethod makes one of a user's cards the default. It
+// returns pgx.ErrNoRows when the user has no such card.
+func (q *Queries) SetDefaultSavedPaymentMethod(ctx context.Context, userID, id uuid.UUID) (SavedPaymentMethod, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return SavedPaymentMethod{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM payments.saved_payment_methods WHERE id = $1 AND user_id = $2)
+	`, id, userID).Scan(&exists); err != nil {
+		return SavedPaymentMethod{}, err
+	}
+	if !exists {
+		return SavedPaymentMethod{}, pgx.ErrNoRows
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE payments.saved_payment_methods
+		SET is_default = FALSE, updated_at = NOW()
+		WHERE user_id = $1 AND is_default AND id <> $2
+	`, userID, id); err != nil {
+		return SavedPaymentMethod{}, err
+	}
+
+	sql := `
+		UPDATE payments.saved_payment_methods
+		SET is_default = TRUE, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + savedPaymentMethodColumns
+	m, err := scanSavedPaymentMethod(tx.QueryRow(ctx, sql, id))
+	if err != nil {
+		return SavedPaymentMethod{}, err
+	}
+
+	return m, tx.Commit(ctx)
+}
+
+// DeleteSavedPaymentMethod removes one of a user's cards. When it was the
+// default, the most recently used remaining card takes its place. It returns
+// pgx.ErrNoRows when the user has no such card.
+func (q *Queries) DeleteSavedPaymentMethod(ctx context.Context, userID, id uuid.UUID) error {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var wasDefault bool
+	if err := tx.QueryRow(ctx, `
+		DELETE FROM payments.saved_payment_methods
+		WHERE id = $1 AND user_id = $2
+		RETURNING is_default
+	`, id, userID).Scan(&wasDefault); err != nil {
+		return err
+	}
+
+	if wasDefault {
+		if _, err := tx.Exec(ctx, `
+			UPDATE payments.saved_payment_methods
+			SET is_default = TRUE, updated_at = NOW()
+			WHERE id = (
+				SELECT id FROM payments.saved_payment_methods
+				WHERE user_id = $1 AND expires_at > NOW()
+				ORDER BY last_used_at DESC NULLS LAST, created_at DESC
+				LIMIT 1
+			)
+		`, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (q *Queries) MarkSavedPaymentMethodUsed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.pool.Exec(ctx, `
+		UPDATE payments.saved_payment_methods
+		SET last_used_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// ListExpiringSavedPaymentMethods returns cards that expire after from and no
+// later than to, soonest first
+func (q *Queries) ListExpiringSavedPaymentMethods(ctx context.Context, from, to time.Time, limit int) ([]SavedPaymentMethod, error) {
+	sql := `
+		SELECT ` + savedPaymentMethodColumns + `
+		FROM payments.saved_payment_methods
+		WHERE expires_at > $1 AND expires_at <= $2
+		ORDER BY expires_at ASC, user_id
+		LIMIT $3`
+	return q.querySavedPaymentMethods(ctx, sql, from, to, limit)
+}
+
+func (q *Queries) querySavedPaymentMethods(ctx context.Context, sql string, args ...interface{}) ([]SavedPaymentMethod, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var methods []SavedPaymentMethod
+	for rows.Next() {
+		m, err := scanSavedPaymentMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, m)
+	}
+	return methods, rows.Err()
+}
+
+const disputeSelect = `
+	SELECT d.id, d.payment_id, p.order_id, d.provider_dispute_id, d.status, d.reason, d.amount_minor, d.currency,
+	       d.evidence_due_by, d.evidence_submitted_at, a.amount_minor, d.closed_at, d.created_at, d.updated_at
+	FROM payments.disputes d
+	JOIN payments.payments p ON p.id = d.payment_id
+	LEFT JOIN payments.payment_adjustments a ON a.dispute_id = d.id`
+
+const openDisputeStatuses = `('DISPUTE_STATUS_NEEDS_RESPONSE', 'DISPUTE_STATUS_UNDER_REVIEW')`
+
+func scanDispute(row pgx.Row) (Dispute, error) {
+	var d Dispute
+	err := row.Scan(
+		&d.ID, &d.PaymentID, &d.OrderID, &d.ProviderDisputeID, &d.Status, &d.Reason, &d.AmountMinor, &d.Currency,
+		&d.EvidenceDueBy, &d.EvidenceSubmittedAt, &d.AdjustmentMinor, &d.ClosedAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	return d, err
+}
+
+// CreateDispute records a dispute reported by the provider. Providers resend
+// their notifications, so a dispute already known by its provider ID is
+// returned as is with created set to false.
+func (q *Queries) CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, bool, error) {
+	var id uuid.UUID
+	err := q.db.pool.QueryRow(ctx, `
+		INSERT INTO payments.disputes (payment_id, provider_dispute_id, reason, amount_minor, currency, evidence_due_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (provider_dispute_id) DO NOTHING
+		RETURNING id
+	`, arg.PaymentID, arg.ProviderDisputeID, arg.Reason, arg.AmountMinor, arg.Currency, arg.EvidenceDueBy).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		d, err := q.GetDisputeByProviderID(ctx, arg.ProviderDisputeID)
+		return d, false, err
+	}
+	if err != nil {
+		return Dispute{}, false, err
+	}
+
+	d, err := q.GetDispute(ctx, id)
+	return d, true, err
+}
+
+func (q *Queries) GetDispute(ctx context.Context, id uuid.UUID) (Dispute, error) {
+	return scanDispute(q.db.pool.QueryRow(ctx, disputeSelect+` WHERE d.id = $1`, id))
+}
+
+func (q *Queries) GetDisputeByProviderID(ctx context.Context, providerDisputeID string) (Dispute, error) {
+	return scanDispute(q.db.pool.QueryRow(ctx, disputeSelect+` WHERE d.provider_dispute_id = $1`, providerDisputeID))
+}
+
+// ListDisputes returns disputes, those whose evidence is due soonest first
+func (q *Queries) ListDisputes(ctx context.Context, arg ListDisputesParams) ([]Dispute, int, error) {
+	const where = `
+		WHERE ($1::text IS NULL OR d.status = $1)
+			AND ($2::uuid IS NULL OR p.order_id = $2)
+	`
+	var total int
+	err := q.db.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM payments.disputes d
+		JOIN payments.payments p ON p.id = d.payment_id`+where, arg.Status, arg.OrderID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := q.db.pool.Query(ctx, disputeSelect+where+`
+		ORDER BY d.closed_at IS NOT NULL, d.evidence_due_by ASC, d.created_at DESC
+		LIMIT $3 OFFSET $4`, arg.Status, arg.OrderID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var disputes []Dispute
+	for rows.Next() {
+		d, err := scanDispute(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		disputes = append(disputes, d)
+	}
+	return disputes, total, rows.Err()
+}
+
+// UpdateDispute changes the status and evidence deadline of an open dispute.
+// It returns pgx.ErrNoRows when the dispute does not exist or is closed.
+func (q *Queries) UpdateDispute(ctx context.Context, arg UpdateDisputeParams) (Dispute, error) {
+	var id uuid.UUID
+	err := q.db.pool.QueryRow(ctx, `
+		UPDATE payments.disputes
+		SET status = $2, evidence_due_by = COALESCE($3, evidence_due_by), updated_at = NOW()
+		WHERE id = $1 AND status IN `+openDisputeStatuses+`
+		RETURNING id
+	`, arg.ID, arg.Status, arg.EvidenceDueBy).Scan(&id)
+	if err != nil {
+		return Dispute{}, err
+	}
+	return q.GetDispute(ctx, id)
+}
+
+// MarkDisputeEvidenceSubmitted moves a dispute that needs a response under
+// review. It returns pgx.ErrNoRows when the dispute is in any other status.
+func (q *Queries) MarkDisputeEvidenceSubmitted(ctx context.Context, id uuid.UUID) (Dispute, error) {
+	err := q.db.pool.QueryRow(ctx, `
+		UPDATE payments.disputes
+		SET status = 'DISPUTE_STATUS_UNDER_REVIEW', evidence_submitted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'DISPUTE_STATUS_NEEDS_RESPONSE'
+		RETURNING id
+	`, id).Scan(&id)
+	if err != nil {
+		return Dispute{}, err
+	}
+	return q.GetDispute(ctx, id)
+}
+
+// CloseDispute records the outcome of an open dispute together with the
+// adjustment of a lost one. closed is false when the dispute had already been
+// closed, in which case nothing changes.
+func (q *Queries) CloseDispute(ctx context.Context, arg CloseDisputeParams) (Dispute, bool, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return Dispute{}, false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var paymentID uuid.UUID
+	var currency string
+	err = tx.QueryRow(ctx, `
+		UPDATE payments.disputes
+		SET status = $2, closed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status IN `+openDisputeStatuses+`
+		RETURNING payment_id, currency
+	`, arg.ID, arg.Status).Scan(&paymentID, &currency)
+	if errors.Is(err, pgx.ErrNoRows) {
+		d, err := q.GetDispute(ctx, arg.ID)
+		return d, false, err
+	}
+	if err != nil {
+		return Dispute{}, false, err
+	}
+
+	if arg.AdjustmentMinor != nil {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO payments.payment_adjustments (payment_id, dispute_id, amount_minor, currency, reason, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())
+		`, paymentID, arg.ID, -*arg.AdjustmentMinor, currency, arg.AdjustmentReason); err != nil {
+			return Dispute{}, false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Dispute{}, false, err
+	}
+
+	d, err := q.GetDispute(ctx, arg.ID)
+	return d, true, err
+}
+
+const disputeEvidenceColumns = `id, dispute_id, kind, file_name, content_type, size_bytes, description, created_at`
+
+func (q *Queries) AddDisputeEvidence(ctx context.Context, arg AddDisputeEvidenceParams) (DisputeEvidence, error) {
+	e := DisputeEvidence{Content: arg.Content}
+	err := q.db.pool.QueryRow(ctx, `
+		INSERT INTO payments.dispute_evidence (dispute_id, kind, file_name, content_type, size_bytes, content, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING `+disputeEvidenceColumns,
+		arg.DisputeID, arg.Kind, arg.FileName, arg.ContentType, len(arg.Content), arg.Content, arg.Description,
+	).Scan(&e.ID, &e.DisputeID, &e.Kind, &e.FileName, &e.ContentType, &e.SizeBytes, &e.Description, &e.CreatedAt)
+	return e, err
+}
+
+// ListDisputeEvidence returns the evidence attached to a dispute, oldest
+// first. Documents are only loaded when withContent is set.
+func (q *Queries) ListDisputeEvidence(ctx context.Context, disputeID uuid.UUID, withContent bool) ([]DisputeEvidence, error) {
+	rows, err := q.db.pool.Query(ctx, `
+		SELECT `+disputeEvidenceColumns+`, CASE WHEN $2 THEN content END
+		FROM payments.dispute_evidence
+		WHERE dispute_id = $1
+		ORDER BY created_at ASC
+	`, disputeID, withContent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evidence []DisputeEvidence
+	for rows.Next() {
+		var e DisputeEvidence
+		if err := rows.Scan(&e.ID, &e.DisputeID, &e.Kind, &e.FileName, &e.ContentType, &e.SizeBytes, &e.Description,
+			&e.CreatedAt, &e.Content); err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, e)
+	}
+	return evidence, rows.Err()
+}
+
+// CountOpenDisputesByOrderID counts the disputes not yet decided on any
+// payment of an order
+func (q *Queries) CountOpenDisputesByOrderID(ctx context.Context, orderID uuid.UUID) (int, error) {
+	var count int
+	err := q.db.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM payments.disputes d
+		JOIN payments.payments p ON p.id = d.payment_id
+		WHERE p.order_id = $1 AND d.status IN `+openDisputeStatuses,
+		orderID).Scan(&count)
+	return count, err
+}
+
+const giftCardColumns = `id, code_last4, type, status, initial_amount_minor, balance_minor, currency, user_id,
+	expires_at, created_at, updated_at`
+
+func scanGiftCard(row pgx.Row) (GiftCard, error) {
+	var g GiftCard
+	err := row.Scan(
+		&g.ID, &g.CodeLast4, &g.Type, &g.Status, &g.InitialAmountMinor, &g.BalanceMinor, &g.Currency, &g.UserID,
+		&g.ExpiresAt, &g.CreatedAt, &g.UpdatedAt,
+	)
+	return g, err
+}
+
+const giftCardTransactionColumns = `t.id, t.gift_card_id, t.kind, t.amount_minor, t.balance_after_minor, g.currency,
+	t.payment_id, t.refund_id, t.note, t.created_at`
+
+func scanGiftCardTransaction(row pgx.Row) (GiftCardTransaction, error) {
+	var t GiftCardTransaction
+	err := row.Scan(
+		&t.ID, &t.GiftCardID, &t.Kind, &t.AmountMinor, &t.BalanceAfterMinor, &t.Currency,
+		&t.PaymentID, &t.RefundID, &t.Note, &t.CreatedAt,
+	)
+	return t, err
+}
+
+// insertGiftCardTransaction appends to the ledger of a gift card whose row is
+// locked by tx
+func insertGiftCardTransaction(ctx context.Context, tx pgx.Tx, card GiftCard, arg AdjustGiftCardBalanceParams) (GiftCardTransaction, error) {
+	t := GiftCardTransaction{
+		GiftCardID:        card.ID,
+		Kind:              arg.Kind,
+		AmountMinor:       arg.AmountMinor,
+		BalanceAfterMinor: card.BalanceMinor,
+		Currency:          card.Currency,
+		PaymentID:         arg.PaymentID,
+		RefundID:          arg.RefundID,
+		Note:              arg.Note,
+	}
+	err := tx.QueryRow(ctx, `
+		INSERT INTO payments.gift_card_transactions (
+			gift_card_id, kind, amount_minor, balance_after_minor, payment_id, refund_id, note, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at
+	`, card.ID, arg.Kind, arg.AmountMinor, card.BalanceMinor, arg.PaymentID, arg.RefundID, arg.Note).Scan(&t.ID, &t.CreatedAt)
+	return t, err
+}
+
+// CreateGiftCard stores a new card with its full amount as balance, recording
+// the issue in its ledger
+func (q *Queries) CreateGiftCard(ctx context.Context, arg CreateGiftCardParams) (GiftCard, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return GiftCard{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sql := `
+		INSERT INTO payments.gift_cards (
+			code_hash, code_last4, type, initial_amount_minor, balance_minor, currency, user_id, expires_at, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING ` + giftCardColumns
+	card, err := scanGiftCard(tx.QueryRow(ctx, sql,
+		arg.CodeHash, arg.CodeLast4, arg.Type, arg.AmountMinor, arg.Currency, arg.UserID, arg.ExpiresAt))
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "idx_gift_cards_code_hash" {
+		return GiftCard{}, ErrDuplicateGiftCardCode
+	}
+	if err != nil {
+		return GiftCard{}, err
+	}
+
+	if _, err := insertGiftCardTransaction(ctx, tx, card, AdjustGiftCardBalanceParams{
+		GiftCardID:  card.ID,
+		Kind:        "GIFT_CARD_TRANSACTION_KIND_ISSUE",
+		AmountMinor: arg.AmountMinor,
+		RefundID:    arg.RefundID,
+		Note:        arg.Note,
+	}); err != nil {
+		return GiftCard{}, err
+	}
+
+	return card, tx.Commit(ctx)
+}
+
+func (q *Queries) GetGiftCard(ctx context.Context, id uuid.UUID) (GiftCard, error) {
+	sql := `SELECT ` + giftCardColumns + ` FROM payments.gift_cards WHERE id = $1`
+	return scanGiftCard(q.db.pool.QueryRow(ctx, sql, id))
+}
+
+func (q *Queries) GetGiftCardByCodeHash(ctx context.Context, codeHash string) (GiftCard, error) {
+	sql := `SELECT ` + giftCardColumns + ` FROM payments.gift_cards WHERE code_hash = $1`
+	return scanGiftCard(q.db.pool.QueryRow(ctx, sql, codeHash))
+}
+
+// ListGiftCards returns cards, newest first
+func (q *Queries) ListGiftCards(ctx context.Context, arg ListGiftCardsParams) ([]GiftCard, int, error) {
+	const where = `
+		WHERE ($1::uuid IS NULL OR user_id = $1)
+			AND ($2::text IS NULL OR type = $2)
+	`
+	var total int
+	if err := q.db.pool.QueryRow(ctx, `SELECT COUNT(*) FROM payments.gift_cards`+where, arg.UserID, arg.Type).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	cards, err := q.queryGiftCards(ctx, `SELECT `+giftCardColumns+` FROM payments.gift_cards`+where+`
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`, arg.UserID, arg.Type, arg.Limit, arg.Offset)
+	return cards, total, err
+}
+
+// AdjustGiftCardBalance changes the balance of a card and records the change
+// in its ledger. Only active, unexpired cards can be adjusted; a charge
+// larger than the balance fails with ErrInsufficientGiftCardBalance.
+func (q *Queries) AdjustGiftCardBalance(ctx context.Context, arg AdjustGiftCardBalanceParams) (GiftCard, GiftCardTransaction, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return GiftCard{}, GiftCardTransaction{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	card, err := scanGiftCard(tx.QueryRow(ctx, `SELECT `+giftCardColumns+` FROM payments.gift_cards WHERE id = $1 FOR UPDATE`, arg.GiftCardID))
+	if err != nil {
+		return GiftCard{}, GiftCardTransaction{}, err
+	}
+	if card.Status != "GIFT_CARD_STATUS_ACTIVE" || !card.ExpiresAt.After(time.Now()) {
+		return card, GiftCardTransaction{}, ErrGiftCardNotUsable
+	}
+	if card.BalanceMinor+arg.AmountMinor < 0 {
+		return card, GiftCardTransaction{}, ErrInsufficientGiftCardBalance
+	}
+
+	sql := `
+		UPDATE payments.gift_cards
+		SET balance_minor = balance_minor + $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + giftCardColumns
+	card, err = scanGiftCard(tx.QueryRow(ctx, sql, arg.GiftCardID, arg.AmountMinor))
+	if err != nil {
+		return GiftCard{}, GiftCardTransaction{}, err
+	}
+
+	t, err := insertGiftCardTransaction(ctx, tx, card, arg)
+	if err != nil {
+		return GiftCard{}, GiftCardTransaction{}, err
+	}
+
+	return card, t, tx.Commit(ctx)
+}
+
+// ListGiftCardTransactions returns the ledger of a card, oldest first
+func (q *Queries) ListGiftCardTransactions(ctx context.Context, giftCardID uuid.UUID) ([]GiftCardTransaction, error) {
+	return q.queryGiftCardTransactions(ctx, `
+		SELECT `+giftCardTransactionColumns+`
+		FROM payments.gift_card_transactions t
+		JOIN payments.gift_cards g ON g.id = t.gift_card_id
+		WHERE t.gift_card_id = $1
+		ORDER BY t.created_at ASC`, giftCardID)
+}
+
+// ListGiftCardTransactionsByPaymentID returns the ledger entries made for a
+// payment across all cards, oldest first
+func (q *Queries) ListGiftCardTransactionsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]GiftCardTransaction, error) {
+	return q.queryGiftCardTransactions(ctx, `
+		SELECT `+giftCardTransactionColumns+`
+		FROM payments.gift_card_transactions t
+		JOIN payments.gift_cards g ON g.id = t.gift_card_id
+		WHERE t.payment_id = $1
+		ORDER BY t.created_at ASC`, paymentID)
+}
+
+// DisableGiftCard stops an active card from being used. disabled is false
+// when the card was not active, in which case nothing changes.
+func (q *Queries) DisableGiftCard(ctx context.Context, id uuid.UUID) (GiftCard, bool, error) {
+	sql := `
+		UPDATE payments.gift_cards
+		SET status = 'GIFT_CARD_STATUS_DISABLED', updated_at = NOW()
+		WHERE id = $1 AND status = 'GIFT_CARD_STATUS_ACTIVE'
+		RETURNING ` + giftCardColumns
+	card, err := scanGiftCard(q.db.pool.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		card, err := q.GetGiftCard(ctx, id)
+		return card, false, err
+	}
+	return card, err == nil, err
+}
+
+// ListExpiredGiftCards returns active cards that expired before the given
+// time, oldest first
+func (q *Queries) ListExpiredGiftCards(ctx context.Context, before time.Time, limit int) ([]GiftCard, error) {
+	return q.queryGiftCards(ctx, `
+		SELECT `+giftCardColumns+`
+		FROM payments.gift_cards
+		WHERE status = 'GIFT_CARD_STATUS_ACTIVE' AND expires_at <= $1
+		ORDER BY expires_at ASC
+		LIMIT $2`, before, limit)
+}
+
+// ExpireGiftCard marks an expired card EXPIRED and writes off its remaining
+// balance in the ledger. It reports false when the card was no longer active
+// or not yet expired.
+func (q *Queries) ExpireGiftCard(ctx context.Context, id uuid.UUID) (bool, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var remaining int
+	err = tx.QueryRow(ctx, `
+		SELECT balance_minor FROM payments.gift_cards
+		WHERE id = $1 AND status = 'GIFT_CARD_STATUS_ACTIVE' AND expires_at <= NOW()
+		FOR UPDATE
+	`, id).Scan(&remaining)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	sql := `
+		UPDATE payments.gift_cards
+		SET status = 'GIFT_CARD_STATUS_EXPIRED', balance_minor = 0, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + giftCardColumns
+	card, err := scanGiftCard(tx.QueryRow(ctx, sql, id))
+	if err != nil {
+		return false, err
+	}
+
+	if remaining > 0 {
+		if _, err := insertGiftCardTransaction(ctx, tx, card, AdjustGiftCardBalanceParams{
+			GiftCardID:  id,
+			Kind:        "GIFT_CARD_TRANSACTION_KIND_EXPIRE",
+			AmountMinor: -remaining,
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func (q *Queries) RecordGiftCardLookupFailure(ctx context.Context, clientKey string) error {
+	_, err := q.db.pool.Exec(ctx, `
+		INSERT INTO payments.gift_card_lookup_failures (client_key, created_at)
+		VALUES ($1, NOW())
+	`, clientKey)
+	return err
+}
+
+func (q *Queries) CountGiftCardLookupFailures(ctx context.Context, clientKey string, since time.Time) (int, error) {
+	var count int
+	err := q.db.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM payments.gift_card_lookup_failures
+		WHERE client_key = $1 AND created_at > $2
+	`, clientKey, since).Scan(&count)
+	return count, err
+}
+
+func (q *Queries) DeleteGiftCardLookupFailures(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := q.db.pool.Exec(ctx, `DELETE FROM payments.gift_card_lookup_failures WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (q *Queries) queryGiftCards(ctx context.Context, sql string, args ...interface{}) ([]GiftCard, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []GiftCard
+	for rows.Next() {
+		card, err := scanGiftCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, rows.Err()
+}
+
+func (q *Queries) queryGiftCardTransactions(ctx context.Context, sql string, args ...interface{}) ([]GiftCardTransaction, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []GiftCardTransaction
+	for rows.Next() {
+		t, err := scanGiftCardTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, rows.Err()
+}
+
+// GetPointBalance returns the points of a customer. A customer who never had
+// any has a balance of zero.
+func (q *Queries) GetPointBalance(ctx context.Context, userID uuid.UUID) (PointBalance, error) {
+	b := PointBalance{UserID: userID}
+	err := q.db.pool.QueryRow(ctx, `
+		SELECT points, updated_at FROM payments.point_balances WHERE user_id = $1
+	`, userID).Scan(&b.Points, &b.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return b, nil
+	}
+	return b, err
+}
+
+// AdjustPoints changes the points of a customer and records the change in
+// the ledger. Spending more points than the balance fails with
+// ErrInsufficientPoints.
+func (q *Queries) AdjustPoints(ctx context.Context, arg AdjustPointsParams) (PointBalance, PointTransaction, error) {
+	tx, err := q.db.pool.Begin(ctx)
+	if err != nil {
+		return PointBalance{}, PointTransaction{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO payments.point_balances (user_id, points, updated_at)
+		VALUES ($1, 0, NOW())
+		ON CONFLICT (user_id) DO NOTHING
+	`, arg.UserID); err != nil {
+		return PointBalance{}, PointTransaction{}, err
+	}
+
+	b := PointBalance{UserID: arg.UserID}
+	if err := tx.QueryRow(ctx, `
+		SELECT points, updated_at FROM payments.point_balances WHERE user_id = $1 FOR UPDATE
+	`, arg.UserID).Scan(&b.Points, &b.UpdatedAt); err != nil {
+		return PointBalance{}, PointTransaction{}, err
+	}
+	if b.Points+arg.Points < 0 {
+		return b, PointTransaction{}, ErrInsufficientPoints
+	}
+
+	if err := tx.QueryRow(ctx, `
+		UPDATE payments.point_balances
+		SET points = points + $2, updated_at = NOW()
+		WHERE user_id = $1
+		RETURNING points, updated_at
+	`, arg.UserID, arg.Points).Scan(&b.Points, &b.UpdatedAt); err != nil {
+		return PointBalance{}, PointTransaction{}, err
+	}
+
+	t := PointTransaction{
+		UserID:       arg.UserID,
+		Type:         arg.Type,
+		Points:       arg.Points,
+		BalanceAfter: b.Points,
+		OrderID:      arg.OrderID,
+		PaymentID:    arg.PaymentID,
+		RefundID:     arg.RefundID,
+		Reason:       arg.Reason,
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO payments.point_transactions (
+			user_id, type, points, balance_after, order_id, payment_id, refund_id, reason, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`, arg.UserID, arg.Type, arg.Points, b.Points, arg.OrderID, arg.PaymentID, arg.RefundID, arg.Reason).Scan(&t.ID, &t.CreatedAt); err != nil {
+		return PointBalance{}, PointTransaction{}, err
+	}
+
+	return b, t, tx.Commit(ctx)
+}
+
+const pointTransactionColumns = `id, user_id, type, points, balance_after, order_id, payment_id, refund_id, reason, created_at`
+
+func scanPointTransaction(row pgx.Row) (PointTransaction, error) {
+	var t PointTransaction
+	err := row.Scan(
+		&t.ID, &t.UserID, &t.Type, &t.Points, &t.BalanceAfter, &t.OrderID, &t.PaymentID, &t.RefundID, &t.Reason, &t.CreatedAt,
+	)
+	return t, err
+}
+
+// ListPointTransactions returns the ledger of a customer, newest first
+func (q *Queries) ListPointTransactions(ctx context.Context, arg ListPointTransactionsParams) ([]PointTransaction, int, error) {
+	var total int
+	if err := q.db.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM payments.point_transactions WHERE user_id = $1
+	`, arg.UserID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	transactions, err := q.queryPointTransactions(ctx, `
+		SELECT `+pointTransactionColumns+`
+		FROM payments.point_transactions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`, arg.UserID, arg.Limit, arg.Offset)
+	return transactions, total, err
+}
+
+// ListPointTransactionsByPaymentID returns the ledger entries made for a
+// payment, oldest first
+func (q *Queries) ListPointTransactionsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]PointTransaction, error) {
+	return q.queryPointTransactions(ctx, `
+		SELECT `+pointTransactionColumns+`
+		FROM payments.point_transactions
+		WHERE payment_id = $1
+		ORDER BY created_at ASC`, paymentID)
+}
+
+func (q *Queries) queryPointTransactions(ctx context.Context, sql string, args ...interface{}) ([]PointTransaction, error) {
+	rows, err := q.db.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []PointTransaction
+	for rows.Next() {
+		t, err := scanPointTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, rows.Err()
+}
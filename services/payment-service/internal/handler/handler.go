@@ -42,3 +42,168 @@ func (h *Handler) RefundPayment(ctx context.Context, req *paymentpb.RefundPaymen
 	h.logger.Debug("RefundPayment called", zap.String("payment_id", req.PaymentId))
 	return h.service.RefundPayment(ctx, req)
 }
+
+func (h *Handler) CapturePayment(ctx context.Context, req *paymentpb.CapturePaymentRequest) (*paymentpb.CapturePaymentResponse, error) {
+	h.logger.Debug("CapturePayment called", zap.String("payment_id", req.PaymentId), zap.String("order_id", req.OrderId))
+	return h.service.CapturePayment(ctx, req)
+}
+
+func (h *Handler) VoidPayment(ctx context.Context, req *paymentpb.VoidPaymentRequest) (*sharedpb.Empty, error) {
+	h.logger.Debug("VoidPayment called", zap.String("payment_id", req.PaymentId))
+	return h.service.VoidPayment(ctx, req)
+}
+
+func (h *Handler) ListRefunds(ctx context.Context, req *paymentpb.ListRefundsRequest) (*paymentpb.ListRefundsResponse, error) {
+	h.logger.Debug("ListRefunds called", zap.String("payment_id", req.PaymentId))
+	return h.service.ListRefunds(ctx, req)
+}
+
+func (h *Handler) CreateWebhookEndpoint(ctx context.Context, req *paymentpb.CreateWebhookEndpointRequest) (*paymentpb.CreateWebhookEndpointResponse, error) {
+	h.logger.Debug("CreateWebhookEndpoint called", zap.String("url", req.Url))
+	return h.service.CreateWebhookEndpoint(ctx, req)
+}
+
+func (h *Handler) ListWebhookEndpoints(ctx context.Context, req *paymentpb.ListWebhookEndpointsRequest) (*paymentpb.ListWebhookEndpointsResponse, error) {
+	h.logger.Debug("ListWebhookEndpoints called")
+	return h.service.ListWebhookEndpoints(ctx, req)
+}
+
+func (h *Handler) UpdateWebhookEndpoint(ctx context.Context, req *paymentpb.UpdateWebhookEndpointRequest) (*paymentpb.UpdateWebhookEndpointResponse, error) {
+	h.logger.Debug("UpdateWebhookEndpoint called", zap.String("endpoint_id", req.EndpointId))
+	return h.service.UpdateWebhookEndpoint(ctx, req)
+}
+
+func (h *Handler) DeleteWebhookEndpoint(ctx context.Context, req *paymentpb.DeleteWebhookEndpointRequest) (*sharedpb.Empty, error) {
+	h.logger.Debug("DeleteWebhookEndpoint called", zap.String("endpoint_id", req.EndpointId))
+	return h.service.DeleteWebhookEndpoint(ctx, req)
+}
+
+func (h *Handler) ListWebhookDeliveries(ctx context.Context, req *paymentpb.ListWebhookDeliveriesRequest) (*paymentpb.ListWebhookDeliveriesResponse, error) {
+	h.logger.Debug("ListWebhookDeliveries called", zap.String("endpoint_id", req.EndpointId))
+	return h.service.ListWebhookDeliveries(ctx, req)
+}
+
+func (h *Handler) ReplayWebhookDelivery(ctx context.Context, req *paymentpb.ReplayWebhookDeliveryRequest) (*paymentpb.ReplayWebhookDeliveryResponse, error) {
+	h.logger.Debug("ReplayWebhookDelivery called", zap.String("delivery_id", req.DeliveryId))
+	return h.service.ReplayWebhookDelivery(ctx, req)
+}
+
+func (h *Handler) ListReconciliationRuns(ctx context.Context, req *paymentpb.ListReconciliationRunsRequest) (*paymentpb.ListReconciliationRunsResponse, error) {
+	h.logger.Debug("ListReconciliationRuns called")
+	return h.service.ListReconciliationRuns(ctx, req)
+}
+
+func (h *Handler) GetReconciliationRun(ctx context.Context, req *paymentpb.GetReconciliationRunRequest) (*paymentpb.GetReconciliationRunResponse, error) {
+	h.logger.Debug("GetReconciliationRun called", zap.String("run_id", req.RunId))
+	return h.service.GetReconciliationRun(ctx, req)
+}
+
+func (h *Handler) GetBankTransferInstructions(ctx context.Context, req *paymentpb.GetBankTransferInstructionsRequest) (*paymentpb.GetBankTransferInstructionsResponse, error) {
+	h.logger.Debug("GetBankTransferInstructions called", zap.String("payment_id", req.PaymentId))
+	return h.service.GetBankTransferInstructions(ctx, req)
+}
+
+func (h *Handler) ImportBankDeposits(ctx context.Context, req *paymentpb.ImportBankDepositsRequest) (*paymentpb.ImportBankDepositsResponse, error) {
+	h.logger.Debug("ImportBankDeposits called", zap.String("file_name", req.FileName))
+	return h.service.ImportBankDeposits(ctx, req)
+}
+
+func (h *Handler) ListBankDeposits(ctx context.Context, req *paymentpb.ListBankDepositsRequest) (*paymentpb.ListBankDepositsResponse, error) {
+	h.logger.Debug("ListBankDeposits called")
+	return h.service.ListBankDeposits(ctx, req)
+}
+
+func (h *Handler) CompleteCashOnDelivery(ctx context.Context, req *paymentpb.CompleteCashOnDeliveryRequest) (*paymentpb.CompleteCashOnDeliveryResponse, error) {
+	h.logger.Debug("CompleteCashOnDelivery called", zap.String("order_id", req.OrderId))
+	return h.service.CompleteCashOnDelivery(ctx, req)
+}
+
+func (h *Handler) GetDeferredPayment(ctx context.Context, req *paymentpb.GetDeferredPaymentRequest) (*paymentpb.GetDeferredPaymentResponse, error) {
+	h.logger.Debug("GetDeferredPayment called", zap.String("payment_id", req.PaymentId), zap.String("order_id", req.OrderId))
+	return h.service.GetDeferredPayment(ctx, req)
+}
+
+func (h *Handler) ListPaymentStatusHistory(ctx context.Context, req *paymentpb.ListPaymentStatusHistoryRequest) (*paymentpb.ListPaymentStatusHistoryResponse, error) {
+	h.logger.Debug("ListPaymentStatusHistory called", zap.String("payment_id", req.PaymentId))
+	return h.service.ListPaymentStatusHistory(ctx, req)
+}
+
+func (h *Handler) PayOrder(ctx context.Context, req *paymentpb.PayOrderRequest) (*paymentpb.PayOrderResponse, error) {
+	h.logger.Debug("PayOrder called", zap.String("order_id", req.OrderId), zap.Int("tenders", len(req.Tenders)))
+	return h.service.PayOrder(ctx, req)
+}
+
+func (h *Handler) ListPaymentsByOrder(ctx context.Context, req *paymentpb.ListPaymentsByOrderRequest) (*paymentpb.ListPaymentsByOrderResponse, error) {
+	h.logger.Debug("ListPaymentsByOrder called", zap.String("order_id", req.OrderId))
+	return h.service.ListPaymentsByOrder(ctx, req)
+}
+
+func (h *Handler) SavePaymentMethod(ctx context.Context, req *paymentpb.SavePaymentMethodRequest) (*paymentpb.SavePaymentMethodResponse, error) {
+	h.logger.Debug("SavePaymentMethod called", zap.String("user_id", req.UserId))
+	return h.service.SavePaymentMethod(ctx, req)
+}
+
+func (h *Handler) ListPaymentMethods(ctx context.Context, req *paymentpb.ListPaymentMethodsRequest) (*paymentpb.ListPaymentMethodsResponse, error) {
+	h.logger.Debug("ListPaymentMethods called", zap.String("user_id", req.UserId))
+	return h.service.ListPaymentMethods(ctx, req)
+}
+
+func (h *Handler) SetDefaultPaymentMethod(ctx context.Context, req *paymentpb.SetDefaultPaymentMethodRequest) (*paymentpb.SetDefaultPaymentMethodResponse, error) {
+	h.logger.Debug("SetDefaultPaymentMethod called", zap.String("payment_method_id", req.PaymentMethodId))
+	return h.service.SetDefaultPaymentMethod(ctx, req)
+}
+
+func (h *Handler) DeletePaymentMethod(ctx context.Context, req *paymentpb.DeletePaymentMethodRequest) (*sharedpb.Empty, error) {
+	h.logger.Debug("DeletePaymentMethod called", zap.String("payment_method_id", req.PaymentMethodId))
+	return h.service.DeletePaymentMethod(ctx, req)
+}
+
+func (h *Handler) ListExpiringPaymentMethods(ctx context.Context, req *paymentpb.ListExpiringPaymentMethodsRequest) (*paymentpb.ListExpiringPaymentMethodsResponse, error) {
+	h.logger.Debug("ListExpiringPaymentMethods called", zap.Int32("within_days", req.WithinDays))
+	return h.service.ListExpiringPaymentMethods(ctx, req)
+}
+
+func (h *Handler) ListDisputes(ctx context.Context, req *paymentpb.ListDisputesRequest) (*paymentpb.ListDisputesResponse, error) {
+	h.logger.Debug("ListDisputes called", zap.String("status", req.Status.String()))
+	return h.service.ListDisputes(ctx, req)
+}
+
+func (h *Handler) GetDispute(ctx context.Context, req *paymentpb.GetDisputeRequest) (*paymentpb.GetDisputeResponse, error) {
+	h.logger.Debug("GetDispute called", zap.String("dispute_id", req.DisputeId))
+	return h.service.GetDispute(ctx, req)
+}
+
+func (h *Handler) AddDisputeEvidence(ctx context.Context, req *paymentpb.AddDisputeEvidenceRequest) (*paymentpb.AddDisputeEvidenceResponse, error) {
+	h.logger.Debug("AddDisputeEvidence called", zap.String("dispute_id", req.DisputeId))
+	return h.service.AddDisputeEvidence(ctx, req)
+}
+
+func (h *Handler) SubmitDisputeEvidence(ctx context.Context, req *paymentpb.SubmitDisputeEvidenceRequest) (*paymentpb.SubmitDisputeEvidenceResponse, error) {
+	h.logger.Debug("SubmitDisputeEvidence called", zap.String("dispute_id", req.DisputeId))
+	return h.service.SubmitDisputeEvidence(ctx, req)
+}
+
+func (h *Handler) IssueGiftCard(ctx context.Context, req *paymentpb.IssueGiftCardRequest) (*paymentpb.IssueGiftCardResponse, error) {
+	h.logger.Debug("IssueGiftCard called", zap.String("type", req.Type.String()))
+	return h.service.IssueGiftCard(ctx, req)
+}
+
+func (h *Handler) CheckGiftCardBalance(ctx context.Context, req *paymentpb.CheckGiftCardBalanceRequest) (*paymentpb.CheckGiftCardBalanceResponse, error) {
+	h.logger.Debug("CheckGiftCardBalance called", zap.String("client_key", req.ClientKey))
+	return h.service.CheckGiftCardBalance(ctx, req)
+}
+
+func (h *Handler) GetGiftCard(ctx context.Context, req *paymentpb.GetGiftCardRequest) (*paymentpb.GetGiftCardResponse, error) {
+	h.logger.Debug("GetGiftCard called", zap.String("gift_card_id", req.GiftCardId))
+	return h.service.GetGiftCard(ctx, req)
+}
+
+func (h *Handler) ListGiftCards(ctx context.Context, req *paymentpb.ListGiftCardsRequest) (*paymentpb.ListGiftCardsResponse, error) {
+	h.logger.Debug("ListGiftCards called", zap.String("user_id", req.UserId))
+	return h.service.ListGiftCards(ctx, req)
+}
+
+func (h *Handler) DisableGiftCard(ctx context.Context, req *paymentpb.DisableGiftCardRequest) (*paymentpb.DisableGiftCardResponse, error) {
+	h.logger.Debug("DisableGiftCard called", zap.String("gift_card_id", req.GiftCardId))
+	return h.service.DisableGiftCard(ctx, req)
+}
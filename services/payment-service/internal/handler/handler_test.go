@@ -50,6 +50,270 @@ func (m *MockPaymentService) RefundPayment(ctx context.Context, req *paymentpb.R
 	return args.Get(0).(*sharedpb.Empty), args.Error(1)
 }
 
+func (m *MockPaymentService) CapturePayment(ctx context.Context, req *paymentpb.CapturePaymentRequest) (*paymentpb.CapturePaymentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.CapturePaymentResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) VoidPayment(ctx context.Context, req *paymentpb.VoidPaymentRequest) (*sharedpb.Empty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharedpb.Empty), args.Error(1)
+}
+
+func (m *MockPaymentService) ListRefunds(ctx context.Context, req *paymentpb.ListRefundsRequest) (*paymentpb.ListRefundsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListRefundsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) CreateWebhookEndpoint(ctx context.Context, req *paymentpb.CreateWebhookEndpointRequest) (*paymentpb.CreateWebhookEndpointResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.CreateWebhookEndpointResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListWebhookEndpoints(ctx context.Context, req *paymentpb.ListWebhookEndpointsRequest) (*paymentpb.ListWebhookEndpointsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListWebhookEndpointsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) UpdateWebhookEndpoint(ctx context.Context, req *paymentpb.UpdateWebhookEndpointRequest) (*paymentpb.UpdateWebhookEndpointResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.UpdateWebhookEndpointResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) DeleteWebhookEndpoint(ctx context.Context, req *paymentpb.DeleteWebhookEndpointRequest) (*sharedpb.Empty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharedpb.Empty), args.Error(1)
+}
+
+func (m *MockPaymentService) ListWebhookDeliveries(ctx context.Context, req *paymentpb.ListWebhookDeliveriesRequest) (*paymentpb.ListWebhookDeliveriesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListWebhookDeliveriesResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ReplayWebhookDelivery(ctx context.Context, req *paymentpb.ReplayWebhookDeliveryRequest) (*paymentpb.ReplayWebhookDeliveryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ReplayWebhookDeliveryResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListReconciliationRuns(ctx context.Context, req *paymentpb.ListReconciliationRunsRequest) (*paymentpb.ListReconciliationRunsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListReconciliationRunsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) GetReconciliationRun(ctx context.Context, req *paymentpb.GetReconciliationRunRequest) (*paymentpb.GetReconciliationRunResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.GetReconciliationRunResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) GetBankTransferInstructions(ctx context.Context, req *paymentpb.GetBankTransferInstructionsRequest) (*paymentpb.GetBankTransferInstructionsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.GetBankTransferInstructionsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ImportBankDeposits(ctx context.Context, req *paymentpb.ImportBankDepositsRequest) (*paymentpb.ImportBankDepositsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ImportBankDepositsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListBankDeposits(ctx context.Context, req *paymentpb.ListBankDepositsRequest) (*paymentpb.ListBankDepositsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListBankDepositsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) CompleteCashOnDelivery(ctx context.Context, req *paymentpb.CompleteCashOnDeliveryRequest) (*paymentpb.CompleteCashOnDeliveryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.CompleteCashOnDeliveryResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) GetDeferredPayment(ctx context.Context, req *paymentpb.GetDeferredPaymentRequest) (*paymentpb.GetDeferredPaymentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.GetDeferredPaymentResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListPaymentStatusHistory(ctx context.Context, req *paymentpb.ListPaymentStatusHistoryRequest) (*paymentpb.ListPaymentStatusHistoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListPaymentStatusHistoryResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) PayOrder(ctx context.Context, req *paymentpb.PayOrderRequest) (*paymentpb.PayOrderResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.PayOrderResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListPaymentsByOrder(ctx context.Context, req *paymentpb.ListPaymentsByOrderRequest) (*paymentpb.ListPaymentsByOrderResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListPaymentsByOrderResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) SavePaymentMethod(ctx context.Context, req *paymentpb.SavePaymentMethodRequest) (*paymentpb.SavePaymentMethodResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.SavePaymentMethodResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListPaymentMethods(ctx context.Context, req *paymentpb.ListPaymentMethodsRequest) (*paymentpb.ListPaymentMethodsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListPaymentMethodsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) SetDefaultPaymentMethod(ctx context.Context, req *paymentpb.SetDefaultPaymentMethodRequest) (*paymentpb.SetDefaultPaymentMethodResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.SetDefaultPaymentMethodResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) DeletePaymentMethod(ctx context.Context, req *paymentpb.DeletePaymentMethodRequest) (*sharedpb.Empty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharedpb.Empty), args.Error(1)
+}
+
+func (m *MockPaymentService) ListExpiringPaymentMethods(ctx context.Context, req *paymentpb.ListExpiringPaymentMethodsRequest) (*paymentpb.ListExpiringPaymentMethodsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListExpiringPaymentMethodsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListDisputes(ctx context.Context, req *paymentpb.ListDisputesRequest) (*paymentpb.ListDisputesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListDisputesResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) GetDispute(ctx context.Context, req *paymentpb.GetDisputeRequest) (*paymentpb.GetDisputeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.GetDisputeResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) AddDisputeEvidence(ctx context.Context, req *paymentpb.AddDisputeEvidenceRequest) (*paymentpb.AddDisputeEvidenceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.AddDisputeEvidenceResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) SubmitDisputeEvidence(ctx context.Context, req *paymentpb.SubmitDisputeEvidenceRequest) (*paymentpb.SubmitDisputeEvidenceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.SubmitDisputeEvidenceResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) IssueGiftCard(ctx context.Context, req *paymentpb.IssueGiftCardRequest) (*paymentpb.IssueGiftCardResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.IssueGiftCardResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) CheckGiftCardBalance(ctx context.Context, req *paymentpb.CheckGiftCardBalanceRequest) (*paymentpb.CheckGiftCardBalanceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.CheckGiftCardBalanceResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) GetGiftCard(ctx context.Context, req *paymentpb.GetGiftCardRequest) (*paymentpb.GetGiftCardResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.GetGiftCardResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ListGiftCards(ctx context.Context, req *paymentpb.ListGiftCardsRequest) (*paymentpb.ListGiftCardsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.ListGiftCardsResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) DisableGiftCard(ctx context.Context, req *paymentpb.DisableGiftCardRequest) (*paymentpb.DisableGiftCardResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*paymentpb.DisableGiftCardResponse), args.Error(1)
+}
+
 func TestHandler_CreatePayment(t *testing.T) {
 	logger := zap.NewNop()
 	mockService := new(MockPaymentService)
@@ -0,0 +1,10 @@
+-- Name: add_payment_authorization
+-- Description: Drop authorization and capture columns
+
+DROP INDEX IF EXISTS payments.idx_payments_authorization_expires_at;
+
+ALTER TABLE payments.payments
+    DROP COLUMN IF EXISTS authorized_at,
+    DROP COLUMN IF EXISTS authorization_expires_at,
+    DROP COLUMN IF EXISTS captured_at,
+    DROP COLUMN IF EXISTS captured_amount_minor;
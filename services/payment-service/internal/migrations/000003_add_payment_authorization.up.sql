@@ -0,0 +1,19 @@
+-- Name: add_payment_authorization
+-- Description: Track card authorization and capture separately
+-- Schema: payments
+
+ALTER TABLE payments.payments
+    ADD COLUMN IF NOT EXISTS authorized_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS authorization_expires_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS captured_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS captured_amount_minor INT;
+
+-- Create indexes
+CREATE INDEX idx_payments_authorization_expires_at ON payments.payments(authorization_expires_at)
+    WHERE status = 'PAYMENT_STATUS_AUTHORIZED';
+
+-- Comments
+COMMENT ON COLUMN payments.payments.authorized_at IS 'When the provider authorized the payment (与信)';
+COMMENT ON COLUMN payments.payments.authorization_expires_at IS 'When the authorization lapses and must be voided';
+COMMENT ON COLUMN payments.payments.captured_at IS 'When the authorized amount was captured';
+COMMENT ON COLUMN payments.payments.captured_amount_minor IS 'Captured amount in minor units';
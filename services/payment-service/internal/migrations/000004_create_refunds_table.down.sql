@@ -0,0 +1,4 @@
+-- Name: create_refunds_table
+-- Description: Drop refunds table
+
+DROP TABLE IF EXISTS payments.refunds;
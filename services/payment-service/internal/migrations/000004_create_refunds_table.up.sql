@@ -0,0 +1,31 @@
+-- Name: create_refunds_table
+-- Description: Ledger of partial and full refunds against a payment
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments.payments(id),
+    amount_minor INT NOT NULL CHECK (amount_minor > 0),
+    currency TEXT NOT NULL DEFAULT 'JPY',
+    reason TEXT,
+    status TEXT NOT NULL DEFAULT 'REFUND_STATUS_PENDING',
+    provider_reference TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_refunds_payment_id ON payments.refunds(payment_id);
+CREATE INDEX idx_refunds_status ON payments.refunds(status);
+
+-- Comments
+COMMENT ON TABLE payments.refunds IS 'Refunds issued against payments; several partial refunds may exist per payment';
+COMMENT ON COLUMN payments.refunds.id IS 'Unique refund identifier';
+COMMENT ON COLUMN payments.refunds.payment_id IS 'Refunded payment';
+COMMENT ON COLUMN payments.refunds.amount_minor IS 'Refund amount in minor units';
+COMMENT ON COLUMN payments.refunds.currency IS 'Currency code (JPY)';
+COMMENT ON COLUMN payments.refunds.reason IS 'Why the refund was issued';
+COMMENT ON COLUMN payments.refunds.status IS 'Refund status';
+COMMENT ON COLUMN payments.refunds.provider_reference IS 'Refund reference from payment gateway';
+COMMENT ON COLUMN payments.refunds.created_at IS 'Creation timestamp';
+COMMENT ON COLUMN payments.refunds.updated_at IS 'Last update timestamp';
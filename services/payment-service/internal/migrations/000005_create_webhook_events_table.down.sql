@@ -0,0 +1,4 @@
+-- Name: create_webhook_events_table
+-- Description: Drop webhook events table
+
+DROP TABLE IF EXISTS payments.webhook_events;
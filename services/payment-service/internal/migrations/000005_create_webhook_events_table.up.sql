@@ -0,0 +1,18 @@
+-- Name: create_webhook_events_table
+-- Description: Record processed provider webhook events for replay protection
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.webhook_events (
+    event_id TEXT PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_webhook_events_received_at ON payments.webhook_events(received_at);
+
+-- Comments
+COMMENT ON TABLE payments.webhook_events IS 'Provider webhook events that have been accepted; replays of the same event_id are ignored';
+COMMENT ON COLUMN payments.webhook_events.event_id IS 'Provider event identifier';
+COMMENT ON COLUMN payments.webhook_events.event_type IS 'Provider event type';
+COMMENT ON COLUMN payments.webhook_events.received_at IS 'When the event was first accepted';
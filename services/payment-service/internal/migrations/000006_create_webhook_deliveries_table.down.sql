@@ -0,0 +1,5 @@
+-- Name: create_webhook_deliveries_table
+-- Description: Drop webhook endpoint and delivery tables
+
+DROP TABLE IF EXISTS payments.webhook_deliveries;
+DROP TABLE IF EXISTS payments.webhook_endpoints;
@@ -0,0 +1,55 @@
+-- Name: create_webhook_deliveries_table
+-- Description: Merchant webhook endpoints and the durable outbound delivery queue
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.webhook_endpoints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    description TEXT,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endpoint_id UUID NOT NULL REFERENCES payments.webhook_endpoints(id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'WEBHOOK_DELIVERY_STATUS_PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMP,
+    response_code INT,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_webhook_endpoints_enabled ON payments.webhook_endpoints(enabled);
+CREATE INDEX idx_webhook_deliveries_due ON payments.webhook_deliveries(status, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_endpoint_id ON payments.webhook_deliveries(endpoint_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_event_id ON payments.webhook_deliveries(event_id);
+
+-- Comments
+COMMENT ON TABLE payments.webhook_endpoints IS 'Partner endpoints that receive outbound payment webhooks';
+COMMENT ON COLUMN payments.webhook_endpoints.url IS 'URL deliveries are posted to';
+COMMENT ON COLUMN payments.webhook_endpoints.event_types IS 'Subscribed event types; * subscribes to all';
+COMMENT ON COLUMN payments.webhook_endpoints.secret IS 'Per-endpoint HMAC signing secret';
+COMMENT ON COLUMN payments.webhook_endpoints.enabled IS 'Disabled endpoints receive no deliveries';
+COMMENT ON COLUMN payments.webhook_endpoints.consecutive_failures IS 'Failed attempts since the last successful delivery';
+COMMENT ON COLUMN payments.webhook_endpoints.disabled_at IS 'When the endpoint was disabled after repeated failures';
+COMMENT ON TABLE payments.webhook_deliveries IS 'Outbound webhook delivery queue and delivery log';
+COMMENT ON COLUMN payments.webhook_deliveries.event_id IS 'Event ID, shared by every endpoint receiving the event';
+COMMENT ON COLUMN payments.webhook_deliveries.payload IS 'JSON body posted to the endpoint';
+COMMENT ON COLUMN payments.webhook_deliveries.status IS 'Delivery status';
+COMMENT ON COLUMN payments.webhook_deliveries.attempts IS 'Number of delivery attempts made';
+COMMENT ON COLUMN payments.webhook_deliveries.next_attempt_at IS 'Earliest time of the next attempt';
+COMMENT ON COLUMN payments.webhook_deliveries.response_code IS 'HTTP status of the last attempt';
+COMMENT ON COLUMN payments.webhook_deliveries.last_error IS 'Error from the last failed attempt';
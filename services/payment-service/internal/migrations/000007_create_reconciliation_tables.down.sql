@@ -0,0 +1,5 @@
+-- Name: create_reconciliation_tables
+-- Description: Drop reconciliation tables
+
+DROP TABLE IF EXISTS payments.reconciliation_items;
+DROP TABLE IF EXISTS payments.reconciliation_runs;
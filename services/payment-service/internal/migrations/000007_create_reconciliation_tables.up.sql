@@ -0,0 +1,53 @@
+-- Name: create_reconciliation_tables
+-- Description: Settlement reconciliation runs and their per-line results
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.reconciliation_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    file_format TEXT NOT NULL,
+    period_start TIMESTAMP,
+    period_end TIMESTAMP,
+    total_lines INT NOT NULL DEFAULT 0,
+    matched_count INT NOT NULL DEFAULT 0,
+    exception_count INT NOT NULL DEFAULT 0,
+    settled_amount_minor BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.reconciliation_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID NOT NULL REFERENCES payments.reconciliation_runs(id) ON DELETE CASCADE,
+    result TEXT NOT NULL,
+    transaction_id TEXT,
+    payment_id UUID REFERENCES payments.payments(id),
+    line_number INT,
+    settled_amount_minor INT,
+    expected_amount_minor INT,
+    fee_minor INT,
+    currency TEXT,
+    settled_on DATE,
+    detail TEXT
+);
+
+-- Create indexes
+CREATE INDEX idx_reconciliation_runs_created_at ON payments.reconciliation_runs(created_at DESC);
+CREATE INDEX idx_reconciliation_items_run_id ON payments.reconciliation_items(run_id, result);
+CREATE INDEX idx_reconciliation_items_payment_id ON payments.reconciliation_items(payment_id);
+
+-- Comments
+COMMENT ON TABLE payments.reconciliation_runs IS 'One import of a provider settlement file';
+COMMENT ON COLUMN payments.reconciliation_runs.provider IS 'Payment provider that produced the file';
+COMMENT ON COLUMN payments.reconciliation_runs.file_name IS 'Imported settlement file name';
+COMMENT ON COLUMN payments.reconciliation_runs.file_format IS 'csv or fixed_width';
+COMMENT ON COLUMN payments.reconciliation_runs.period_start IS 'Start of the capture window checked for unsettled payments';
+COMMENT ON COLUMN payments.reconciliation_runs.period_end IS 'End (exclusive) of the capture window checked for unsettled payments';
+COMMENT ON COLUMN payments.reconciliation_runs.exception_count IS 'Items that need review by finance';
+COMMENT ON COLUMN payments.reconciliation_runs.settled_amount_minor IS 'Sum of settled amounts in the file';
+COMMENT ON TABLE payments.reconciliation_items IS 'Result of matching one settlement line or one unsettled payment';
+COMMENT ON COLUMN payments.reconciliation_items.result IS 'Reconciliation result';
+COMMENT ON COLUMN payments.reconciliation_items.line_number IS 'Line in the settlement file; NULL for unsettled payments';
+COMMENT ON COLUMN payments.reconciliation_items.expected_amount_minor IS 'Captured amount recorded in payments.payments';
+COMMENT ON COLUMN payments.reconciliation_items.settled_on IS 'Settlement date reported by the provider';
+COMMENT ON COLUMN payments.reconciliation_items.detail IS 'Human readable explanation of the result';
@@ -0,0 +1,6 @@
+-- Name: create_bank_transfer_tables
+-- Description: Drop bank transfer tables
+
+DROP TABLE IF EXISTS payments.bank_deposits;
+DROP TABLE IF EXISTS payments.bank_transfer_accounts;
+DROP SEQUENCE IF EXISTS payments.virtual_account_number_seq;
@@ -0,0 +1,59 @@
+-- Name: create_bank_transfer_tables
+-- Description: Virtual accounts for bank transfer payments and imported deposits
+-- Schema: payments
+
+-- Virtual account numbers are handed out in sequence. The range wraps once
+-- exhausted; numbers are only reused after the earlier account is closed.
+CREATE SEQUENCE IF NOT EXISTS payments.virtual_account_number_seq
+    MINVALUE 1000000 MAXVALUE 9999999 CYCLE;
+
+CREATE TABLE IF NOT EXISTS payments.bank_transfer_accounts (
+    payment_id UUID PRIMARY KEY REFERENCES payments.payments(id) ON DELETE CASCADE,
+    account_number TEXT NOT NULL,
+    expected_amount_minor INT NOT NULL,
+    received_amount_minor INT NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'JPY',
+    deadline TIMESTAMP NOT NULL,
+    status TEXT NOT NULL DEFAULT 'BANK_TRANSFER_STATUS_AWAITING',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.bank_deposits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_number TEXT NOT NULL,
+    inquiry_number TEXT NOT NULL,
+    value_date DATE NOT NULL,
+    amount_minor INT NOT NULL,
+    depositor_name TEXT NOT NULL DEFAULT '',
+    remitting_bank TEXT NOT NULL DEFAULT '',
+    remitting_branch TEXT NOT NULL DEFAULT '',
+    payment_id UUID REFERENCES payments.payments(id),
+    match TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (account_number, inquiry_number, value_date)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_bank_transfer_accounts_open_number ON payments.bank_transfer_accounts(account_number)
+    WHERE status IN ('BANK_TRANSFER_STATUS_AWAITING', 'BANK_TRANSFER_STATUS_UNDERPAID');
+CREATE INDEX idx_bank_transfer_accounts_deadline ON payments.bank_transfer_accounts(deadline)
+    WHERE status IN ('BANK_TRANSFER_STATUS_AWAITING', 'BANK_TRANSFER_STATUS_UNDERPAID');
+CREATE INDEX idx_bank_deposits_match ON payments.bank_deposits(match, created_at DESC);
+CREATE INDEX idx_bank_deposits_payment_id ON payments.bank_deposits(payment_id);
+
+-- Comments
+COMMENT ON TABLE payments.bank_transfer_accounts IS 'Virtual account issued to one bank transfer payment';
+COMMENT ON COLUMN payments.bank_transfer_accounts.account_number IS '7 digit virtual account number; unique while the account is open';
+COMMENT ON COLUMN payments.bank_transfer_accounts.expected_amount_minor IS 'Amount the customer must transfer';
+COMMENT ON COLUMN payments.bank_transfer_accounts.received_amount_minor IS 'Sum of deposits matched to the account';
+COMMENT ON COLUMN payments.bank_transfer_accounts.deadline IS 'Transfers must arrive before this time';
+COMMENT ON COLUMN payments.bank_transfer_accounts.status IS 'Bank transfer status';
+COMMENT ON TABLE payments.bank_deposits IS 'Deposit imported from a Zengin 振込入金通知 file';
+COMMENT ON COLUMN payments.bank_deposits.inquiry_number IS '照会番号 assigned by the bank; unique per account and value date';
+COMMENT ON COLUMN payments.bank_deposits.value_date IS '勘定日 the deposit was credited';
+COMMENT ON COLUMN payments.bank_deposits.depositor_name IS '振込依頼人名 as reported by the bank, in half-width kana';
+COMMENT ON COLUMN payments.bank_deposits.payment_id IS 'Payment the deposit was matched to; NULL when unmatched';
+COMMENT ON COLUMN payments.bank_deposits.match IS 'Matching result';
+COMMENT ON COLUMN payments.bank_deposits.file_name IS 'Deposit file the record was imported from';
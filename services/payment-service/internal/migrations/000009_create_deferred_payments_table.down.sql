@@ -0,0 +1,4 @@
+-- Name: create_deferred_payments_table
+-- Description: Drop deferred payments table
+
+DROP TABLE IF EXISTS payments.deferred_payments;
@@ -0,0 +1,34 @@
+-- Name: create_deferred_payments_table
+-- Description: Credit checks and invoices of deferred (後払い) payments
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.deferred_payments (
+    payment_id UUID PRIMARY KEY REFERENCES payments.payments(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    provider_transaction_id TEXT NOT NULL,
+    credit_check TEXT NOT NULL,
+    credit_check_reason TEXT,
+    invoice_status TEXT NOT NULL DEFAULT 'DEFERRED_INVOICE_STATUS_NOT_ISSUED',
+    carrier TEXT,
+    tracking_number TEXT,
+    shipped_at TIMESTAMP,
+    paid_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_deferred_payments_provider_transaction ON payments.deferred_payments(provider, provider_transaction_id);
+CREATE INDEX idx_deferred_payments_review ON payments.deferred_payments(updated_at)
+    WHERE credit_check = 'CREDIT_CHECK_RESULT_REVIEW';
+CREATE INDEX idx_deferred_payments_open_invoice ON payments.deferred_payments(updated_at)
+    WHERE invoice_status IN ('DEFERRED_INVOICE_STATUS_ISSUED', 'DEFERRED_INVOICE_STATUS_OVERDUE');
+
+-- Comments
+COMMENT ON TABLE payments.deferred_payments IS 'Provider transaction of one deferred payment';
+COMMENT ON COLUMN payments.deferred_payments.provider IS 'Deferred payment provider, e.g. fake or np';
+COMMENT ON COLUMN payments.deferred_payments.credit_check IS '与信審査 result; REVIEW while the provider checks manually';
+COMMENT ON COLUMN payments.deferred_payments.credit_check_reason IS 'Why the check was declined or sent to review';
+COMMENT ON COLUMN payments.deferred_payments.invoice_status IS 'Status of the invoice sent to the customer';
+COMMENT ON COLUMN payments.deferred_payments.tracking_number IS '送り状番号 registered with the provider when the order shipped';
+COMMENT ON COLUMN payments.deferred_payments.paid_at IS 'When the provider reported the invoice paid';
@@ -0,0 +1,4 @@
+-- Name: create_payment_status_history_table
+-- Description: Drop payment status history table
+
+DROP TABLE IF EXISTS payments.payment_status_history;
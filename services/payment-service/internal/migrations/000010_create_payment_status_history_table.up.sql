@@ -0,0 +1,23 @@
+-- Name: create_payment_status_history_table
+-- Description: Audit trail of payment status changes
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.payment_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments.payments(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_by TEXT NOT NULL,
+    reason TEXT,
+    provider_response JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_payment_status_history_payment ON payments.payment_status_history(payment_id, created_at);
+
+-- Comments
+COMMENT ON TABLE payments.payment_status_history IS 'One row per payment status transition';
+COMMENT ON COLUMN payments.payment_status_history.changed_by IS 'Who made the change: api, webhook, a background worker or a calling service';
+COMMENT ON COLUMN payments.payment_status_history.reason IS 'Why the status changed, e.g. a void or decline reason';
+COMMENT ON COLUMN payments.payment_status_history.provider_response IS 'Provider response payload behind the transition';
@@ -0,0 +1,4 @@
+-- Name: create_saved_payment_methods_table
+-- Description: Drop saved payment methods table
+
+DROP TABLE IF EXISTS payments.saved_payment_methods;
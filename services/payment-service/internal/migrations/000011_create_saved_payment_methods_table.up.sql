@@ -0,0 +1,30 @@
+-- Name: create_saved_payment_methods_table
+-- Description: Provider tokens of cards saved by customers
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.saved_payment_methods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    provider TEXT NOT NULL,
+    provider_token TEXT NOT NULL,
+    brand TEXT NOT NULL,
+    last4 CHAR(4) NOT NULL,
+    exp_month INTEGER NOT NULL CHECK (exp_month BETWEEN 1 AND 12),
+    exp_year INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_saved_payment_methods_card ON payments.saved_payment_methods(user_id, brand, last4, exp_month, exp_year);
+CREATE UNIQUE INDEX idx_saved_payment_methods_default ON payments.saved_payment_methods(user_id) WHERE is_default;
+CREATE INDEX idx_saved_payment_methods_expires_at ON payments.saved_payment_methods(expires_at);
+
+-- Comments
+COMMENT ON TABLE payments.saved_payment_methods IS 'Cards saved by customers; card numbers and security codes are never stored';
+COMMENT ON COLUMN payments.saved_payment_methods.provider_token IS 'Reusable token issued by the provider for the card';
+COMMENT ON COLUMN payments.saved_payment_methods.expires_at IS 'Start of the month after the card expiry, Japan time';
+COMMENT ON COLUMN payments.saved_payment_methods.is_default IS 'Card preselected at checkout; at most one per user';
@@ -0,0 +1,6 @@
+-- Name: create_disputes_tables
+-- Description: Drop disputes, dispute evidence and payment adjustments tables
+
+DROP TABLE IF EXISTS payments.payment_adjustments;
+DROP TABLE IF EXISTS payments.dispute_evidence;
+DROP TABLE IF EXISTS payments.disputes;
@@ -0,0 +1,57 @@
+-- Name: create_disputes_tables
+-- Description: Chargeback disputes, their evidence and the adjustments of lost disputes
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.disputes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments.payments(id) ON DELETE CASCADE,
+    provider_dispute_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'DISPUTE_STATUS_NEEDS_RESPONSE',
+    reason TEXT NOT NULL DEFAULT '',
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    currency VARCHAR(3) NOT NULL,
+    evidence_due_by TIMESTAMP NOT NULL,
+    evidence_submitted_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.dispute_evidence (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dispute_id UUID NOT NULL REFERENCES payments.disputes(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    content BYTEA NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.payment_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments.payments(id) ON DELETE CASCADE,
+    dispute_id UUID REFERENCES payments.disputes(id) ON DELETE SET NULL,
+    amount_minor INTEGER NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_disputes_provider_dispute_id ON payments.disputes(provider_dispute_id);
+CREATE INDEX idx_disputes_payment_id ON payments.disputes(payment_id);
+CREATE INDEX idx_disputes_open ON payments.disputes(evidence_due_by)
+    WHERE status IN ('DISPUTE_STATUS_NEEDS_RESPONSE', 'DISPUTE_STATUS_UNDER_REVIEW');
+CREATE INDEX idx_dispute_evidence_dispute_id ON payments.dispute_evidence(dispute_id);
+CREATE INDEX idx_payment_adjustments_payment_id ON payments.payment_adjustments(payment_id);
+CREATE UNIQUE INDEX idx_payment_adjustments_dispute_id ON payments.payment_adjustments(dispute_id);
+
+-- Comments
+COMMENT ON TABLE payments.disputes IS 'Chargebacks opened by card issuers, reported through provider webhooks';
+COMMENT ON COLUMN payments.disputes.reason IS 'Issuer reason code, e.g. fraudulent or product_not_received';
+COMMENT ON COLUMN payments.disputes.evidence_due_by IS 'Deadline for submitting evidence to the provider';
+COMMENT ON TABLE payments.dispute_evidence IS 'Documents submitted to the provider to contest a dispute';
+COMMENT ON TABLE payments.payment_adjustments IS 'Signed corrections to the money received for a payment, e.g. lost chargebacks';
+COMMENT ON COLUMN payments.payment_adjustments.amount_minor IS 'Negative when money was taken back';
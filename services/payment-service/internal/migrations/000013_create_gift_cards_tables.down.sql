@@ -0,0 +1,7 @@
+-- Name: create_gift_cards_tables
+-- Description: Drop gift card tables and the refund destination
+
+ALTER TABLE payments.refunds DROP COLUMN IF EXISTS destination;
+DROP TABLE IF EXISTS payments.gift_card_lookup_failures;
+DROP TABLE IF EXISTS payments.gift_card_transactions;
+DROP TABLE IF EXISTS payments.gift_cards;
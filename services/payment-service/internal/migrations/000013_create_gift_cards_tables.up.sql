@@ -0,0 +1,59 @@
+-- Name: create_gift_cards_tables
+-- Description: Gift cards and store credit with their balance ledger, and the refund destination
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.gift_cards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code_hash TEXT NOT NULL,
+    code_last4 VARCHAR(4) NOT NULL,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'GIFT_CARD_STATUS_ACTIVE',
+    initial_amount_minor INTEGER NOT NULL CHECK (initial_amount_minor > 0),
+    balance_minor INTEGER NOT NULL CHECK (balance_minor >= 0),
+    currency VARCHAR(3) NOT NULL,
+    user_id UUID,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (type <> 'GIFT_CARD_TYPE_STORE_CREDIT' OR user_id IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS payments.gift_card_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    gift_card_id UUID NOT NULL REFERENCES payments.gift_cards(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    amount_minor INTEGER NOT NULL,
+    balance_after_minor INTEGER NOT NULL CHECK (balance_after_minor >= 0),
+    payment_id UUID REFERENCES payments.payments(id) ON DELETE SET NULL,
+    refund_id UUID REFERENCES payments.refunds(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.gift_card_lookup_failures (
+    id BIGSERIAL PRIMARY KEY,
+    client_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE payments.refunds
+    ADD COLUMN IF NOT EXISTS destination TEXT NOT NULL DEFAULT 'REFUND_DESTINATION_ORIGINAL';
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_gift_cards_code_hash ON payments.gift_cards(code_hash);
+CREATE INDEX idx_gift_cards_user_id ON payments.gift_cards(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_gift_cards_active_expires_at ON payments.gift_cards(expires_at)
+    WHERE status = 'GIFT_CARD_STATUS_ACTIVE';
+CREATE INDEX idx_gift_card_transactions_gift_card_id ON payments.gift_card_transactions(gift_card_id, created_at);
+CREATE INDEX idx_gift_card_transactions_payment_id ON payments.gift_card_transactions(payment_id)
+    WHERE payment_id IS NOT NULL;
+CREATE INDEX idx_gift_card_lookup_failures_client_key ON payments.gift_card_lookup_failures(client_key, created_at);
+
+-- Comments
+COMMENT ON TABLE payments.gift_cards IS 'Prepaid gift cards and store credit spendable as a payment tender';
+COMMENT ON COLUMN payments.gift_cards.code_hash IS 'HMAC-SHA256 of the normalised code; the code itself is never stored';
+COMMENT ON COLUMN payments.gift_cards.user_id IS 'Owner of a store credit; only they can spend it';
+COMMENT ON TABLE payments.gift_card_transactions IS 'Ledger of every change to a gift card balance';
+COMMENT ON COLUMN payments.gift_card_transactions.amount_minor IS 'Negative when money left the card';
+COMMENT ON TABLE payments.gift_card_lookup_failures IS 'Failed code lookups, used to slow down code guessing';
+COMMENT ON COLUMN payments.refunds.destination IS 'Where the money went: the original payment method or store credit';
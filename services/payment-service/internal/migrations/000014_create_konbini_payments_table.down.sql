@@ -0,0 +1,4 @@
+-- Name: create_konbini_payments_table
+-- Description: Drop konbini payments table
+
+DROP TABLE IF EXISTS payments.konbini_payments;
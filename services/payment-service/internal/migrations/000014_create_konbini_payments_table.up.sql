@@ -0,0 +1,20 @@
+-- Name: create_konbini_payments_table
+-- Description: Payment deadlines of konbini payments
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.konbini_payments (
+    payment_id UUID PRIMARY KEY REFERENCES payments.payments(id) ON DELETE CASCADE,
+    store TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    cancelled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_konbini_payments_expires_at ON payments.konbini_payments(expires_at);
+
+-- Comments
+COMMENT ON TABLE payments.konbini_payments IS 'Convenience store payment issued to one payment';
+COMMENT ON COLUMN payments.konbini_payments.store IS 'Convenience store chain the customer pays at';
+COMMENT ON COLUMN payments.konbini_payments.expires_at IS 'End of the last day, in Japan, the customer can pay at the store';
+COMMENT ON COLUMN payments.konbini_payments.cancelled_at IS 'When the payment code was cancelled at the provider after expiry';
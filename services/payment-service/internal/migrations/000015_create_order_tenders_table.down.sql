@@ -0,0 +1,4 @@
+-- Name: create_order_tenders_table
+-- Description: Drop order tenders table
+
+DROP TABLE IF EXISTS payments.order_tenders;
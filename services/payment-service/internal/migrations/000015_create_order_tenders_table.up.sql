@@ -0,0 +1,18 @@
+-- Name: create_order_tenders_table
+-- Description: Multi-tender payments of an order, one at a time per order
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.order_tenders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'IN_PROGRESS',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_order_tenders_order_in_progress ON payments.order_tenders(order_id) WHERE status = 'IN_PROGRESS';
+
+-- Comments
+COMMENT ON TABLE payments.order_tenders IS 'One PayOrder call taking the tenders of an order';
+COMMENT ON COLUMN payments.order_tenders.status IS 'IN_PROGRESS while tenders are taken, then SUCCEEDED, FAILED or ABANDONED';
@@ -0,0 +1,5 @@
+-- Name: create_points_tables
+-- Description: Drop point tables
+
+DROP TABLE IF EXISTS payments.point_transactions;
+DROP TABLE IF EXISTS payments.point_balances;
@@ -0,0 +1,32 @@
+-- Name: create_points_tables
+-- Description: Loyalty point balances with their ledger
+-- Schema: payments
+
+CREATE TABLE IF NOT EXISTS payments.point_balances (
+    user_id UUID PRIMARY KEY,
+    points BIGINT NOT NULL DEFAULT 0 CHECK (points >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payments.point_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES payments.point_balances(user_id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    points BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL CHECK (balance_after >= 0),
+    order_id UUID,
+    payment_id UUID REFERENCES payments.payments(id) ON DELETE SET NULL,
+    refund_id UUID REFERENCES payments.refunds(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_point_transactions_user_id ON payments.point_transactions(user_id, created_at);
+CREATE INDEX idx_point_transactions_payment_id ON payments.point_transactions(payment_id)
+    WHERE payment_id IS NOT NULL;
+
+-- Comments
+COMMENT ON TABLE payments.point_balances IS 'Loyalty points of a customer, redeemable at 1 yen each';
+COMMENT ON TABLE payments.point_transactions IS 'Ledger of every change to a point balance';
+COMMENT ON COLUMN payments.point_transactions.points IS 'Negative when points were spent';
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// sweepBatchSize bounds how many expired authorizations are voided per run
+const sweepBatchSize = 100
+
+// AuthorizationSweeper voids card authorizations that were never captured
+type AuthorizationSweeper struct {
+	payments *PaymentService
+	logger   *zap.Logger
+}
+
+// NewAuthorizationSweeper creates a new authorization sweeper
+func NewAuthorizationSweeper(payments *PaymentService, logger *zap.Logger) *AuthorizationSweeper {
+	return &AuthorizationSweeper{
+		payments: payments,
+		logger:   logger,
+	}
+}
+
+// VoidExpired voids every authorization whose hold has lapsed
+func (w *AuthorizationSweeper) VoidExpired(ctx context.Context) (int, error) {
+	expired, err := w.payments.queries.ListExpiredAuthorizations(ctx, time.Now(), sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	voided := 0
+	for _, payment := range expired {
+		if err := w.payments.voidAuthorization(ctx, payment, "authorization expired", changedByAuthorizationSweeper); err != nil {
+			w.logger.Warn("Failed to void expired authorization",
+				zap.String("payment_id", payment.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		voided++
+	}
+
+	if len(expired) > 0 {
+		w.logger.Info("Voided expired authorizations",
+			zap.Int("found", len(expired)),
+			zap.Int("voided", voided))
+	}
+
+	return voided, nil
+}
+
+// StartPeriodicSweep starts voiding expired authorizations in the background
+func (w *AuthorizationSweeper) StartPeriodicSweep(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic authorization sweep", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.VoidExpired(ctx); err != nil {
+					w.logger.Error("Periodic authorization sweep failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
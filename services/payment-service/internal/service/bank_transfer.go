@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/pkg/calendar"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// defaultBankTransferDeadlineDays is how many days after checkout a customer
+// has to make the transfer when no deadline is configured
+const defaultBankTransferDeadlineDays = 7
+
+// defaultBankDepositsPageSize is used when ListBankDeposits gets no limit
+const defaultBankDepositsPageSize = 50
+
+// BankTransferConfig describes the bank branch that issues virtual accounts
+// (バーチャル口座) and how long customers have to pay into them
+type BankTransferConfig struct {
+	BankCode      string
+	BankName      string
+	BranchCode    string
+	BranchName    string
+	AccountType   string
+	AccountHolder string
+	DeadlineDays  int
+}
+
+// SetBankTransferConfig sets the bank details shown to customers paying by
+// bank transfer
+func (s *PaymentService) SetBankTransferConfig(cfg BankTransferConfig) {
+	if cfg.DeadlineDays <= 0 {
+		cfg.DeadlineDays = defaultBankTransferDeadlineDays
+	}
+	s.bankTransfer = cfg
+}
+
+// bankCalendar is closed on weekends, national holidays and over the new
+// year, when banks do not post deposits
+var bankCalendar = calendar.New(calendar.BankNewYear)
+
+// bankTransferDeadline returns the end of the day, in Japan, the given number
+// of days after now, moved on to the next bank business day when banks are
+// closed that day. Timestamps are stored without a zone, so it is returned
+// in UTC like every other stored time.
+func bankTransferDeadline(now time.Time, days int) time.Time {
+	return calendar.EndOfDay(bankCalendar.NextBusinessDay(now.AddDate(0, 0, days))).UTC()
+}
+
+// processBankTransfer issues a virtual account for the payment. The payment
+// stays processing until deposits covering the amount are imported. Retrying
+// after a failure reuses the account already issued.
+func (s *PaymentService) processBankTransfer(ctx context.Context, payment db.Payment) (paymentpb.PaymentStatus, string, error) {
+	if payment.Currency != "JPY" {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.InvalidArgument, "bank transfers are only available in JPY")
+	}
+
+	account, err := s.queries.GetBankTransferAccount(ctx, payment.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		account, err = s.queries.CreateBankTransferAccount(ctx, db.CreateBankTransferAccountParams{
+			PaymentID:           payment.ID,
+			ExpectedAmountMinor: payment.AmountMinor,
+			Currency:            payment.Currency,
+			Deadline:            bankTransferDeadline(time.Now(), s.bankTransfer.DeadlineDays),
+		})
+	}
+	if err != nil {
+		s.logger.Error("Failed to issue virtual account", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Internal, "failed to issue virtual account")
+	}
+
+	transactionID := fmt.Sprintf("BT-%s-%d", account.AccountNumber, account.CreatedAt.Unix())
+	return paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, transactionID, nil
+}
+
+// GetBankTransferInstructions returns the virtual account a bank transfer
+// payment is paid into and how much has been received so far
+func (s *PaymentService) GetBankTransferInstructions(ctx context.Context, req *paymentpb.GetBankTransferInstructionsRequest) (*paymentpb.GetBankTransferInstructionsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.GetBankTransferInstructions",
+		trace.WithAttributes(attribute.String("payment.id", req.PaymentId)),
+	)
+	defer span.End()
+
+	paymentID, err := uuid.Parse(req.PaymentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
+	}
+
+	account, err := s.queries.GetBankTransferAccount(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "bank transfer not found")
+		}
+		s.logger.Error("Failed to get bank transfer account", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get bank transfer")
+	}
+
+	return &paymentpb.GetBankTransferInstructionsResponse{
+		Instructions: s.bankTransferInstructions(account),
+	}, nil
+}
+
+// ImportBankDeposits imports a Zengin deposit notification file uploaded by
+// finance and matches its deposits to open virtual accounts
+func (s *PaymentService) ImportBankDeposits(ctx context.Context, req *paymentpb.ImportBankDepositsRequest) (*paymentpb.ImportBankDepositsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ImportBankDeposits",
+		trace.WithAttributes(attribute.String("bank_deposits.file_name", req.FileName)),
+	)
+	defer span.End()
+
+	if len(req.Content) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "content is required")
+	}
+	if req.FileName == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_name is required")
+	}
+
+	deposits, err := ParseZenginDeposits(req.Content)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid deposit file: %v", err)
+	}
+
+	result, err := s.applyBankDeposits(ctx, req.FileName, deposits)
+	if err != nil {
+		s.logger.Error("Failed to import bank deposits", zap.String("file", req.FileName), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to import bank deposits")
+	}
+
+	pbDeposits := make([]*paymentpb.BankDeposit, len(result.deposits))
+	for i, d := range result.deposits {
+		pbDeposits[i] = bankDepositToProto(d)
+	}
+
+	return &paymentpb.ImportBankDepositsResponse{
+		Imported:   int32(len(result.deposits)),
+		Duplicates: int32(result.duplicates),
+		Matched:    int32(result.matched),
+		Unmatched:  int32(result.unmatched),
+		Deposits:   pbDeposits,
+	}, nil
+}
+
+// ListBankDeposits lists imported deposits, newest first. Filtering on
+// BANK_DEPOSIT_MATCH_UNMATCHED gives the deposits finance has to review.
+func (s *PaymentService) ListBankDeposits(ctx context.Context, req *paymentpb.ListBankDepositsRequest) (*paymentpb.ListBankDepositsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListBankDeposits")
+	defer span.End()
+
+	page, limit := int32(1), int32(defaultBankDepositsPageSize)
+	if req.Pagination != nil {
+		if req.Pagination.Page > 0 {
+			page = req.Pagination.Page
+		}
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	params := db.ListBankDepositsParams{Limit: int(limit), Offset: int((page - 1) * limit)}
+	if req.Match != paymentpb.BankDepositMatch_BANK_DEPOSIT_MATCH_UNSPECIFIED {
+		match := req.Match.String()
+		params.Match = &match
+	}
+
+	deposits, total, err := s.queries.ListBankDeposits(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list bank deposits", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list bank deposits")
+	}
+
+	pbDeposits := make([]*paymentpb.BankDeposit, len(deposits))
+	for i, d := range deposits {
+		pbDeposits[i] = bankDepositToProto(d)
+	}
+
+	return &paymentpb.ListBankDepositsResponse{
+		Deposits:   pbDeposits,
+		Pagination: &sharedpb.Pagination{Page: page, Limit: limit, Total: int32(total)},
+	}, nil
+}
+
+// bankDepositImport summarises one imported deposit file
+type bankDepositImport struct {
+	deposits   []db.BankDeposit
+	duplicates int
+	matched    int
+	unmatched  int
+}
+
+// applyBankDeposits records each deposit and completes the payments whose
+// virtual accounts are now fully paid. Cancellation records are logged for
+// finance rather than applied, since the deposit they reverse may already
+// have completed an order.
+func (s *PaymentService) applyBankDeposits(ctx context.Context, fileName string, deposits []ZenginDeposit) (bankDepositImport, error) {
+	var result bankDepositImport
+	for _, d := range deposits {
+		if d.Cancelled {
+			s.logger.Warn("Skipping cancelled bank deposit",
+				zap.String("file", fileName),
+				zap.String("account_number", d.AccountNumber),
+				zap.String("inquiry_number", d.InquiryNumber),
+				zap.Int("amount", d.AmountMinor))
+			continue
+		}
+
+		deposit, account, err := s.queries.RecordBankDeposit(ctx, db.RecordBankDepositParams{
+			AccountNumber:   d.AccountNumber,
+			InquiryNumber:   d.InquiryNumber,
+			ValueDate:       d.ValueDate,
+			AmountMinor:     d.AmountMinor,
+			DepositorName:   d.DepositorName,
+			RemittingBank:   d.RemittingBank,
+			RemittingBranch: d.RemittingBranch,
+			FileName:        fileName,
+		})
+		if errors.Is(err, db.ErrDuplicateBankDeposit) {
+			result.duplicates++
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("record %d: %w", d.RecordNumber, err)
+		}
+		result.deposits = append(result.deposits, deposit)
+
+		if account == nil {
+			result.unmatched++
+			s.logger.Warn("Bank deposit matches no open virtual account",
+				zap.String("account_number", d.AccountNumber),
+				zap.String("inquiry_number", d.InquiryNumber),
+				zap.String("depositor", d.DepositorName),
+				zap.Int("amount", d.AmountMinor))
+			continue
+		}
+		result.matched++
+
+		switch account.Status {
+		case "BANK_TRANSFER_STATUS_PAID", "BANK_TRANSFER_STATUS_OVERPAID":
+			if err := s.completeBankTransfer(ctx, *account); err != nil {
+				// The account is paid; the sweep retries the completion
+				s.logger.Error("Failed to complete bank transfer payment",
+					zap.String("payment_id", account.PaymentID.String()),
+					zap.Error(err))
+			}
+		case "BANK_TRANSFER_STATUS_UNDERPAID":
+			s.logger.Info("Bank transfer underpaid",
+				zap.String("payment_id", account.PaymentID.String()),
+				zap.Int("expected", account.ExpectedAmountMinor),
+				zap.Int("received", account.ReceivedAmountMinor))
+		}
+	}
+	return result, nil
+}
+
+// completeBankTransfer completes the payment of a fully paid virtual account.
+// An overpayment still completes the payment; the excess is reported in the
+// event so that it can be refunded.
+func (s *PaymentService) completeBankTransfer(ctx context.Context, account db.BankTransferAccount) error {
+	payment, err := s.queries.GetPayment(ctx, account.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if ParsePaymentStatus(payment.Status) == paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED {
+		return nil
+	}
+
+	if err := s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, statusChange{
+		changedBy: changedByBankTransfer,
+		providerResponse: map[string]interface{}{
+			"account_number":  account.AccountNumber,
+			"account_status":  account.Status,
+			"received_amount": account.ReceivedAmountMinor,
+		},
+	}); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"amount":          payment.AmountMinor,
+		"currency":        payment.Currency,
+		"received_amount": account.ReceivedAmountMinor,
+	}
+	if over := account.ReceivedAmountMinor - account.ExpectedAmountMinor; over > 0 {
+		data["overpaid_amount"] = over
+	}
+	s.publishPaymentEvent(ctx, PaymentEventCompleted, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, data)
+
+	return nil
+}
+
+// expireBankTransfer closes an overdue virtual account and expires its
+// payment, reporting false when a deposit completed the account first. Any
+// partial payment received is reported in the event so that it can be
+// refunded.
+func (s *PaymentService) expireBankTransfer(ctx context.Context, account db.BankTransferAccount) (bool, error) {
+	expired, err := s.queries.ExpireBankTransferAccount(ctx, account.PaymentID)
+	if err != nil || !expired {
+		return false, err
+	}
+
+	payment, err := s.queries.GetPayment(ctx, account.PaymentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if err := s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED, statusChange{
+		changedBy: changedByBankTransferSweeper,
+		reason:    "transfer deadline passed",
+	}); err != nil {
+		return false, err
+	}
+
+	var data map[string]interface{}
+	if account.ReceivedAmountMinor > 0 {
+		data = map[string]interface{}{
+			"received_amount": account.ReceivedAmountMinor,
+			"currency":        account.Currency,
+		}
+	}
+	s.publishPaymentEvent(ctx, PaymentEventExpired, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED, data)
+
+	return true, nil
+}
+
+func (s *PaymentService) bankTransferInstructions(a db.BankTransferAccount) *paymentpb.BankTransferInstructions {
+	return &paymentpb.BankTransferInstructions{
+		PaymentId:      a.PaymentID.String(),
+		BankCode:       s.bankTransfer.BankCode,
+		BankName:       s.bankTransfer.BankName,
+		BranchCode:     s.bankTransfer.BranchCode,
+		BranchName:     s.bankTransfer.BranchName,
+		AccountType:    s.bankTransfer.AccountType,
+		AccountNumber:  a.AccountNumber,
+		AccountHolder:  s.bankTransfer.AccountHolder,
+		Amount:         &sharedpb.Money{Units: int64(a.ExpectedAmountMinor), Currency: a.Currency},
+		ReceivedAmount: &sharedpb.Money{Units: int64(a.ReceivedAmountMinor), Currency: a.Currency},
+		Deadline:       timestamppb.New(a.Deadline),
+		Status:         paymentpb.BankTransferStatus(paymentpb.BankTransferStatus_value[a.Status]),
+	}
+}
+
+func bankDepositToProto(d db.BankDeposit) *paymentpb.BankDeposit {
+	pb := &paymentpb.BankDeposit{
+		Id:            d.ID.String(),
+		AccountNumber: d.AccountNumber,
+		InquiryNumber: d.InquiryNumber,
+		ValueDate:     d.ValueDate.Format("2006-01-02"),
+		Amount:        &sharedpb.Money{Units: int64(d.AmountMinor), Currency: "JPY"},
+		DepositorName: d.DepositorName,
+		Match:         paymentpb.BankDepositMatch(paymentpb.BankDepositMatch_value[d.Match]),
+		FileName:      d.FileName,
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+	}
+	if d.PaymentID != nil {
+		pb.PaymentId = d.PaymentID.String()
+	}
+	return pb
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func TestBankTransferDeadline(t *testing.T) {
+	now := time.Date(2026, 4, 3, 23, 30, 0, 0, tokyo)
+	assert.Equal(t, time.Date(2026, 4, 11, 0, 0, 0, 0, tokyo).UTC(), bankTransferDeadline(now, 7))
+
+	// Falling in Golden Week moves it to the Thursday after
+	now = time.Date(2026, 4, 25, 10, 0, 0, 0, tokyo)
+	assert.Equal(t, time.Date(2026, 5, 8, 0, 0, 0, 0, tokyo).UTC(), bankTransferDeadline(now, 7))
+
+	// Banks close from New Year's Eve to the 3rd
+	now = time.Date(2026, 12, 24, 10, 0, 0, 0, tokyo)
+	assert.Equal(t, time.Date(2027, 1, 5, 0, 0, 0, 0, tokyo).UTC(), bankTransferDeadline(now, 7))
+}
+
+func TestPaymentService_ProcessPayment_BankTransfer(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	s := newTestPaymentService(mockQueries, withBankTransferAccount())
+	payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER, "PAYMENT_STATUS_PENDING", 12000)
+	account := db.BankTransferAccount{
+		PaymentID:           payment.ID,
+		AccountNumber:       "1000042",
+		ExpectedAmountMinor: 12000,
+		Currency:            "JPY",
+		Deadline:            time.Now().Add(7 * 24 * time.Hour),
+		Status:              "BANK_TRANSFER_STATUS_AWAITING",
+		CreatedAt:           time.Now(),
+	}
+
+	mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+	mockQueries.On("GetBankTransferAccount", mock.Anything, payment.ID).Return(db.BankTransferAccount{}, pgx.ErrNoRows).Once()
+	mockQueries.On("CreateBankTransferAccount", mock.Anything, mock.MatchedBy(func(p db.CreateBankTransferAccountParams) bool {
+		return p.PaymentID == payment.ID && p.ExpectedAmountMinor == 12000 && p.Deadline.After(time.Now())
+	})).Return(account, nil)
+	mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+	mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+		return p.Status == "PAYMENT_STATUS_PROCESSING"
+	})).Return(nil)
+	mockQueries.On("GetBankTransferAccount", mock.Anything, payment.ID).Return(account, nil)
+
+	resp, err := s.ProcessPayment(context.Background(), &paymentpb.ProcessPaymentRequest{PaymentId: payment.ID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, resp.Status)
+	assert.Contains(t, resp.TransactionId, "BT-1000042-")
+	require.NotNil(t, resp.BankTransfer)
+	assert.Equal(t, "1000042", resp.BankTransfer.AccountNumber)
+	assert.Equal(t, "テスト銀行", resp.BankTransfer.BankName)
+	assert.Equal(t, int64(12000), resp.BankTransfer.Amount.Units)
+	assert.Equal(t, paymentpb.BankTransferStatus_BANK_TRANSFER_STATUS_AWAITING, resp.BankTransfer.Status)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestPaymentService_ApplyBankDeposits(t *testing.T) {
+	deposit := func(account string, amount int) ZenginDeposit {
+		return ZenginDeposit{
+			RecordNumber:  2,
+			AccountNumber: account,
+			InquiryNumber: "000001",
+			ValueDate:     time.Date(2026, 4, 3, 0, 0, 0, 0, tokyo),
+			AmountMinor:   amount,
+			DepositorName: "ﾔﾏﾀﾞ ﾀﾛｳ",
+		}
+	}
+
+	t.Run("completes a payment paid in full", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withBankTransferAccount())
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER, "PAYMENT_STATUS_PROCESSING", 12000)
+		account := &db.BankTransferAccount{
+			PaymentID:           payment.ID,
+			AccountNumber:       "1000042",
+			ExpectedAmountMinor: 12000,
+			ReceivedAmountMinor: 12000,
+			Currency:            "JPY",
+			Status:              "BANK_TRANSFER_STATUS_PAID",
+		}
+
+		mockQueries.On("RecordBankDeposit", mock.Anything, mock.MatchedBy(func(p db.RecordBankDepositParams) bool {
+			return p.AccountNumber == "1000042" && p.AmountMinor == 12000 && p.FileName == "deposits.txt"
+		})).Return(db.BankDeposit{ID: uuid.New(), Match: "BANK_DEPOSIT_MATCH_MATCHED"}, account, nil)
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == payment.ID && p.Status == "PAYMENT_STATUS_COMPLETED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByBankTransfer
+		})).Return(nil)
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == "payment.completed"
+		})).Return(1, nil)
+
+		result, err := s.applyBankDeposits(context.Background(), "deposits.txt", []ZenginDeposit{deposit("1000042", 12000)})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.matched)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("reports the excess of an overpayment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withBankTransferAccount())
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER, "PAYMENT_STATUS_PROCESSING", 12000)
+		account := &db.BankTransferAccount{
+			PaymentID:           payment.ID,
+			ExpectedAmountMinor: 12000,
+			ReceivedAmountMinor: 12500,
+			Currency:            "JPY",
+			Status:              "BANK_TRANSFER_STATUS_OVERPAID",
+		}
+
+		mockQueries.On("RecordBankDeposit", mock.Anything, mock.Anything).Return(db.BankDeposit{ID: uuid.New()}, account, nil)
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.Anything).Return(nil)
+
+		var event PaymentEvent
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return json.Unmarshal(p.Payload, &event) == nil
+		})).Return(1, nil)
+
+		_, err := s.applyBankDeposits(context.Background(), "deposits.txt", []ZenginDeposit{deposit("1000042", 12500)})
+
+		require.NoError(t, err)
+		assert.Equal(t, float64(500), event.Data["overpaid_amount"])
+		assert.Equal(t, float64(12500), event.Data["received_amount"])
+	})
+
+	t.Run("leaves an underpaid payment processing", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withBankTransferAccount())
+		account := &db.BankTransferAccount{
+			PaymentID:           uuid.New(),
+			ExpectedAmountMinor: 12000,
+			ReceivedAmountMinor: 10000,
+			Status:              "BANK_TRANSFER_STATUS_UNDERPAID",
+		}
+
+		mockQueries.On("RecordBankDeposit", mock.Anything, mock.Anything).Return(db.BankDeposit{ID: uuid.New()}, account, nil)
+
+		result, err := s.applyBankDeposits(context.Background(), "deposits.txt", []ZenginDeposit{deposit("1000042", 10000)})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.matched)
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("counts unmatched, duplicate and cancelled deposits", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withBankTransferAccount())
+
+		cancelled := deposit("1000042", 800)
+		cancelled.Cancelled = true
+
+		mockQueries.On("RecordBankDeposit", mock.Anything, mock.MatchedBy(func(p db.RecordBankDepositParams) bool {
+			return p.AccountNumber == "9999999"
+		})).Return(db.BankDeposit{ID: uuid.New(), Match: "BANK_DEPOSIT_MATCH_UNMATCHED"}, nil, nil)
+		mockQueries.On("RecordBankDeposit", mock.Anything, mock.MatchedBy(func(p db.RecordBankDepositParams) bool {
+			return p.AccountNumber == "1000042"
+		})).Return(db.BankDeposit{}, nil, db.ErrDuplicateBankDeposit)
+
+		result, err := s.applyBankDeposits(context.Background(), "deposits.txt", []ZenginDeposit{
+			deposit("9999999", 1000),
+			deposit("1000042", 12000),
+			cancelled,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, result.deposits, 1)
+		assert.Equal(t, 1, result.unmatched)
+		assert.Equal(t, 1, result.duplicates)
+		assert.Equal(t, 0, result.matched)
+		mockQueries.AssertNumberOfCalls(t, "RecordBankDeposit", 2)
+	})
+}
+
+func TestBankTransferWorker_ExpireOverdue(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	s := newTestPaymentService(mockQueries, withBankTransferAccount())
+	worker := NewBankTransferWorker(s, zap.NewNop())
+
+	payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER, "PAYMENT_STATUS_PROCESSING", 12000)
+	overdue := db.BankTransferAccount{PaymentID: payment.ID, ReceivedAmountMinor: 3000, Currency: "JPY"}
+	paidMeanwhile := db.BankTransferAccount{PaymentID: uuid.New()}
+
+	mockQueries.On("ListOverdueBankTransfers", mock.Anything, mock.Anything, sweepBatchSize).
+		Return([]db.BankTransferAccount{overdue, paidMeanwhile}, nil)
+	mockQueries.On("ExpireBankTransferAccount", mock.Anything, payment.ID).Return(true, nil)
+	mockQueries.On("ExpireBankTransferAccount", mock.Anything, paidMeanwhile.PaymentID).Return(false, nil)
+	mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+	mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+		return p.ID == payment.ID && p.Status == "PAYMENT_STATUS_EXPIRED" && p.TransactionID == nil &&
+			p.Change.ChangedBy == changedByBankTransferSweeper
+	})).Return(nil)
+
+	var event PaymentEvent
+	mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+		return p.EventType == "payment.expired" && json.Unmarshal(p.Payload, &event) == nil
+	})).Return(1, nil)
+
+	expired, err := worker.ExpireOverdue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, float64(3000), event.Data["received_amount"])
+	mockQueries.AssertNotCalled(t, "GetPayment", mock.Anything, paidMeanwhile.PaymentID)
+	mockQueries.AssertExpectations(t)
+}
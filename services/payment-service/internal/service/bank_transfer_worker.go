@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BankTransferWorker imports deposit files dropped by the bank, completes
+// paid transfers and expires overdue ones
+type BankTransferWorker struct {
+	payments *PaymentService
+	logger   *zap.Logger
+}
+
+// NewBankTransferWorker creates a new bank transfer worker
+func NewBankTransferWorker(payments *PaymentService, logger *zap.Logger) *BankTransferWorker {
+	return &BankTransferWorker{
+		payments: payments,
+		logger:   logger,
+	}
+}
+
+// ImportInbox imports every deposit file in dir. Imported files are moved to
+// processed/ and files that fail to parse or import to failed/.
+func (w *BankTransferWorker) ImportInbox(ctx context.Context, dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	imported := 0
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+
+		target := "processed"
+		if err := w.importFile(ctx, path); err != nil {
+			w.logger.Error("Failed to import bank deposit file",
+				zap.String("file", name),
+				zap.Error(err))
+			target = "failed"
+		} else {
+			imported++
+		}
+
+		if err := moveToSubdir(path, target); err != nil {
+			return imported, fmt.Errorf("failed to move %s: %w", name, err)
+		}
+	}
+	return imported, nil
+}
+
+func (w *BankTransferWorker) importFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	deposits, err := ParseZenginDeposits(data)
+	if err != nil {
+		return err
+	}
+
+	result, err := w.payments.applyBankDeposits(ctx, filepath.Base(path), deposits)
+	if err != nil {
+		return err
+	}
+	w.logger.Info("Imported bank deposit file",
+		zap.String("file", filepath.Base(path)),
+		zap.Int("imported", len(result.deposits)),
+		zap.Int("duplicates", result.duplicates),
+		zap.Int("unmatched", result.unmatched))
+	return nil
+}
+
+// CompletePaid completes payments whose accounts were paid but which are
+// still processing
+func (w *BankTransferWorker) CompletePaid(ctx context.Context) (int, error) {
+	accounts, err := w.payments.queries.ListUncompletedBankTransfers(ctx, sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, account := range accounts {
+		if err := w.payments.completeBankTransfer(ctx, account); err != nil {
+			w.logger.Warn("Failed to complete bank transfer payment",
+				zap.String("payment_id", account.PaymentID.String()),
+				zap.Error(err))
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// ExpireOverdue expires every open transfer whose deadline has passed
+func (w *BankTransferWorker) ExpireOverdue(ctx context.Context) (int, error) {
+	overdue, err := w.payments.queries.ListOverdueBankTransfers(ctx, time.Now().UTC(), sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, account := range overdue {
+		ok, err := w.payments.expireBankTransfer(ctx, account)
+		if err != nil {
+			w.logger.Warn("Failed to expire bank transfer",
+				zap.String("payment_id", account.PaymentID.String()),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+
+	if len(overdue) > 0 {
+		w.logger.Info("Expired overdue bank transfers",
+			zap.Int("found", len(overdue)),
+			zap.Int("expired", expired))
+	}
+
+	return expired, nil
+}
+
+// StartPeriodicSweep starts the worker in the background. Deposit files are
+// only imported when inboxDir is set. Each run imports before expiring so
+// that a transfer paid on its last day is not expired first.
+func (w *BankTransferWorker) StartPeriodicSweep(ctx context.Context, inboxDir string, interval time.Duration) {
+	w.logger.Info("Starting periodic bank transfer sweep",
+		zap.String("inbox_dir", inboxDir),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if inboxDir != "" {
+					if _, err := w.ImportInbox(ctx, inboxDir); err != nil {
+						w.logger.Error("Periodic bank deposit import failed", zap.Error(err))
+					}
+				}
+				if _, err := w.CompletePaid(ctx); err != nil {
+					w.logger.Error("Periodic bank transfer completion failed", zap.Error(err))
+				}
+				if _, err := w.ExpireOverdue(ctx); err != nil {
+					w.logger.Error("Periodic bank transfer expiry failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// SetDeliveryClient sets the delivery service client used to have couriers
+// collect cash on delivery payments (optional)
+func (s *PaymentService) SetDeliveryClient(deliveryClient deliverypb.DeliveryServiceClient) {
+	s.deliveryClient = deliveryClient
+}
+
+// processCashOnDelivery asks delivery-service to collect the payment amount,
+// which already includes the order's COD fee, when the parcel is handed over.
+// The payment stays processing until the shipment is delivered.
+func (s *PaymentService) processCashOnDelivery(ctx context.Context, payment db.Payment) (paymentpb.PaymentStatus, string, error) {
+	if payment.Currency != "JPY" {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.InvalidArgument, "cash on delivery is only available in JPY")
+	}
+	if s.deliveryClient == nil {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.FailedPrecondition, "cash on delivery is not available")
+	}
+
+	resp, err := s.deliveryClient.RequestCashOnDelivery(ctx, &deliverypb.RequestCashOnDeliveryRequest{
+		OrderId: payment.OrderID.String(),
+		Amount:  &sharedpb.Money{Units: int64(payment.AmountMinor), Currency: payment.Currency},
+	})
+	if err != nil {
+		s.logger.Error("Failed to request cash on delivery",
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err))
+		if status.Code(err) == codes.FailedPrecondition {
+			return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", err
+		}
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Unavailable, "failed to request cash on delivery")
+	}
+
+	return paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, fmt.Sprintf("COD-%s", resp.Shipment.Id), nil
+}
+
+// CompleteCashOnDelivery completes a cash on delivery payment once the
+// courier has handed over the parcel and collected the amount. Repeated
+// calls for a completed payment succeed without publishing again.
+func (s *PaymentService) CompleteCashOnDelivery(ctx context.Context, req *paymentpb.CompleteCashOnDeliveryRequest) (*paymentpb.CompleteCashOnDeliveryResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.CompleteCashOnDelivery",
+		trace.WithAttributes(attribute.String("payment.order_id", req.OrderId)),
+	)
+	defer span.End()
+
+	s.logger.Info("Completing cash on delivery payment", zap.String("order_id", req.OrderId))
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	if req.CollectedAmount == nil {
+		return nil, status.Error(codes.InvalidArgument, "collected_amount is required")
+	}
+
+	payment, err := s.queries.GetPaymentByOrderID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("Failed to get payment for order", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+
+	if payment.Method != paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY.String() {
+		return nil, status.Error(codes.FailedPrecondition, "payment is not cash on delivery")
+	}
+	if req.CollectedAmount.Units != int64(payment.AmountMinor) || req.CollectedAmount.Currency != payment.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "collected amount must be %d %s", payment.AmountMinor, payment.Currency)
+	}
+
+	if payment.Status != paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED.String() {
+		if err := s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, statusChange{
+			changedBy: changedByDeliveryService,
+			reason:    "collected by the courier",
+		}); err != nil {
+			return nil, err
+		}
+		payment.Status = paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED.String()
+
+		s.publishPaymentEvent(ctx, PaymentEventCompleted, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, map[string]interface{}{
+			"amount":           payment.AmountMinor,
+			"currency":         payment.Currency,
+			"collected_amount": req.CollectedAmount.Units,
+		})
+	}
+
+	return &paymentpb.CompleteCashOnDeliveryResponse{
+		Payment: s.paymentToProto(payment),
+	}, nil
+}
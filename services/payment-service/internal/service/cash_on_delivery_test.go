@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// fakeDeliveryClient records cash on delivery requests
+type fakeDeliveryClient struct {
+	deliverypb.DeliveryServiceClient
+	requests []*deliverypb.RequestCashOnDeliveryRequest
+	err      error
+}
+
+func (c *fakeDeliveryClient) RequestCashOnDelivery(ctx context.Context, req *deliverypb.RequestCashOnDeliveryRequest, opts ...grpc.CallOption) (*deliverypb.RequestCashOnDeliveryResponse, error) {
+	c.requests = append(c.requests, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &deliverypb.RequestCashOnDeliveryResponse{
+		Shipment: &deliverypb.Shipment{Id: "shipment-1", OrderId: req.OrderId, CodAmount: req.Amount},
+	}, nil
+}
+
+func TestPaymentService_ProcessPayment_CashOnDelivery(t *testing.T) {
+	t.Run("asks delivery-service to collect the amount", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		deliveryClient := &fakeDeliveryClient{}
+		s := newTestPaymentService(mockQueries, withDeliveryClient(deliveryClient))
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY, "PAYMENT_STATUS_PENDING", 11440)
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.Status == "PAYMENT_STATUS_PROCESSING" && *p.TransactionID == "COD-shipment-1"
+		})).Return(nil)
+
+		resp, err := s.ProcessPayment(context.Background(), &paymentpb.ProcessPaymentRequest{PaymentId: payment.ID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, resp.Status)
+		require.Len(t, deliveryClient.requests, 1)
+		assert.Equal(t, payment.OrderID.String(), deliveryClient.requests[0].OrderId)
+		assert.Equal(t, int64(11440), deliveryClient.requests[0].Amount.Units)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("leaves the payment pending when the shipment has left", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		deliveryClient := &fakeDeliveryClient{err: status.Error(codes.FailedPrecondition, "shipment has already left the warehouse")}
+		s := newTestPaymentService(mockQueries, withDeliveryClient(deliveryClient))
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY, "PAYMENT_STATUS_PENDING", 11440)
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+
+		_, err := s.ProcessPayment(context.Background(), &paymentpb.ProcessPaymentRequest{PaymentId: payment.ID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_CompleteCashOnDelivery(t *testing.T) {
+	collected := func(units int64) *sharedpb.Money {
+		return &sharedpb.Money{Units: units, Currency: "JPY"}
+	}
+
+	t.Run("completes the payment with the collected amount", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY, "PAYMENT_STATUS_PROCESSING", 11440)
+
+		mockQueries.On("GetPaymentByOrderID", mock.Anything, payment.OrderID).Return(payment, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == payment.ID && p.Status == "PAYMENT_STATUS_COMPLETED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByDeliveryService
+		})).Return(nil)
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == "payment.completed"
+		})).Return(1, nil)
+
+		resp, err := s.CompleteCashOnDelivery(context.Background(), &paymentpb.CompleteCashOnDeliveryRequest{
+			OrderId:         payment.OrderID.String(),
+			CollectedAmount: collected(11440),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Payment.Status)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("is idempotent once completed", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY, "PAYMENT_STATUS_COMPLETED", 11440)
+
+		mockQueries.On("GetPaymentByOrderID", mock.Anything, payment.OrderID).Return(payment, nil)
+
+		resp, err := s.CompleteCashOnDelivery(context.Background(), &paymentpb.CompleteCashOnDeliveryRequest{
+			OrderId:         payment.OrderID.String(),
+			CollectedAmount: collected(11440),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Payment.Status)
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a short collection", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY, "PAYMENT_STATUS_PROCESSING", 11440)
+
+		mockQueries.On("GetPaymentByOrderID", mock.Anything, payment.OrderID).Return(payment, nil)
+
+		_, err := s.CompleteCashOnDelivery(context.Background(), &paymentpb.CompleteCashOnDeliveryRequest{
+			OrderId:         payment.OrderID.String(),
+			CollectedAmount: collected(11000),
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects other payment methods", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_CASH_ON_DELIVERY, "PAYMENT_STATUS_PROCESSING", 11440)
+		payment.Method = "PAYMENT_METHOD_KONBINI_LAWSON"
+
+		mockQueries.On("GetPaymentByOrderID", mock.Anything, payment.OrderID).Return(payment, nil)
+
+		_, err := s.CompleteCashOnDelivery(context.Background(), &paymentpb.CompleteCashOnDeliveryRequest{
+			OrderId:         payment.OrderID.String(),
+			CollectedAmount: collected(11440),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// deferredAuthorizationTTL is how long an approved credit check is held for
+// the order to ship. Unshipped orders are voided by the authorization sweeper
+// like lapsed card authorizations.
+const deferredAuthorizationTTL = 30 * 24 * time.Hour
+
+// DeferredPaymentProvider is a 後払い service such as NP後払い. It vets the
+// customer at checkout, invoices them once the order ships and guarantees
+// the merchant is paid whether or not the invoice is.
+type DeferredPaymentProvider interface {
+	// Name identifies the provider in stored transactions
+	Name() string
+	// CheckCredit runs the credit check (与信審査) for an order
+	CheckCredit(ctx context.Context, req DeferredCreditRequest) (DeferredCreditResult, error)
+	// GetCreditCheck returns the current result of a check, which changes
+	// once a check held for review is decided
+	GetCreditCheck(ctx context.Context, transactionID string) (DeferredCreditResult, error)
+	// RegisterShipment reports the shipment (出荷報告), after which the
+	// provider sends the customer the invoice
+	RegisterShipment(ctx context.Context, transactionID string, shipment DeferredShipment) error
+	// GetInvoiceStatus reports whether the customer has paid the invoice
+	GetInvoiceStatus(ctx context.Context, transactionID string) (paymentpb.DeferredInvoiceStatus, error)
+	// CancelTransaction cancels a transaction whose order will not ship
+	CancelTransaction(ctx context.Context, transactionID string) error
+}
+
+// DeferredCreditRequest describes the order and customer being checked
+type DeferredCreditRequest struct {
+	PaymentID   string
+	OrderID     string
+	AmountMinor int
+	Currency    string
+	BuyerName   string
+	BuyerEmail  string
+	BuyerPhone  string
+	PostalCode  string
+	Address     string
+}
+
+// DeferredCreditResult is the provider's decision on a credit check
+type DeferredCreditResult struct {
+	TransactionID string
+	Result        paymentpb.CreditCheckResult
+	// Reason is set when the check was declined or sent to review
+	Reason string
+}
+
+// payload is the result as recorded in the payment status history
+func (r DeferredCreditResult) payload() map[string]interface{} {
+	return map[string]interface{}{
+		"transaction_id": r.TransactionID,
+		"credit_check":   r.Result.String(),
+		"reason":         r.Reason,
+	}
+}
+
+// DeferredShipment is the shipment registered with the provider
+type DeferredShipment struct {
+	Carrier        string
+	TrackingNumber string
+	ShippedAt      time.Time
+}
+
+// SetDeferredPaymentProvider sets the provider used for deferred payments
+// (optional)
+func (s *PaymentService) SetDeferredPaymentProvider(provider DeferredPaymentProvider) {
+	s.deferredProvider = provider
+}
+
+// processDeferred runs the credit check for a deferred payment. Approved
+// payments are authorized until the order ships, declined ones fail and ones
+// held for review stay processing until the worker picks up the decision.
+func (s *PaymentService) processDeferred(ctx context.Context, payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string, error) {
+	if payment.Currency != "JPY" {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.InvalidArgument, "deferred payment is only available in JPY")
+	}
+	if s.deferredProvider == nil {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.FailedPrecondition, "deferred payment is not available")
+	}
+
+	result, err := s.deferredProvider.CheckCredit(ctx, DeferredCreditRequest{
+		PaymentID:   payment.ID.String(),
+		OrderID:     payment.OrderID.String(),
+		AmountMinor: payment.AmountMinor,
+		Currency:    payment.Currency,
+		BuyerName:   paymentData["buyer_name"],
+		BuyerEmail:  paymentData["buyer_email"],
+		BuyerPhone:  paymentData["buyer_phone"],
+		PostalCode:  paymentData["postal_code"],
+		Address:     paymentData["address"],
+	})
+	if err != nil {
+		s.logger.Error("Deferred payment credit check failed",
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err))
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Unavailable, "failed to run credit check")
+	}
+
+	if _, err := s.queries.CreateDeferredPayment(ctx, db.CreateDeferredPaymentParams{
+		PaymentID:             payment.ID,
+		Provider:              s.deferredProvider.Name(),
+		ProviderTransactionID: result.TransactionID,
+		CreditCheck:           result.Result.String(),
+		CreditCheckReason:     nullableString(result.Reason),
+	}); err != nil {
+		s.logger.Error("Failed to record credit check", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Internal, "failed to record credit check")
+	}
+
+	s.logger.Info("Deferred payment credit check",
+		zap.String("payment_id", payment.ID.String()),
+		zap.String("result", result.Result.String()),
+		zap.String("reason", result.Reason))
+
+	switch result.Result {
+	case paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED:
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, result.TransactionID, nil
+	case paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW:
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, result.TransactionID, nil
+	default:
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, result.TransactionID, nil
+	}
+}
+
+// resolveCreditReview applies the provider's decision on a check held for
+// review, reporting whether it was decided. Approval authorizes the payment
+// and publishes payment.authorized so the held order is confirmed; a decline
+// fails the payment.
+func (s *PaymentService) resolveCreditReview(ctx context.Context, deferred db.DeferredPayment) (bool, error) {
+	result, err := s.deferredProvider.GetCreditCheck(ctx, deferred.ProviderTransactionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get credit check: %w", err)
+	}
+	if result.Result == paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW {
+		return false, nil
+	}
+
+	payment, err := s.queries.GetPayment(ctx, deferred.PaymentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if err := s.queries.UpdateDeferredCreditCheck(ctx, deferred.PaymentID, result.Result.String(), nullableString(result.Reason)); err != nil {
+		return false, fmt.Errorf("failed to update credit check: %w", err)
+	}
+
+	if result.Result == paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED {
+		if err := s.authorizePayment(ctx, payment, deferred.ProviderTransactionID, time.Now().Add(deferredAuthorizationTTL), statusChange{
+			changedBy:        changedByDeferredWorker,
+			reason:           "credit review approved",
+			providerResponse: result.payload(),
+		}); err != nil {
+			return false, err
+		}
+		s.publishPaymentEvent(ctx, PaymentEventAuthorized, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, map[string]interface{}{
+			"amount":       payment.AmountMinor,
+			"currency":     payment.Currency,
+			"credit_check": result.Result.String(),
+		})
+		return true, nil
+	}
+
+	reason := "credit check declined"
+	if result.Reason != "" {
+		reason += ": " + result.Reason
+	}
+	if err := s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, statusChange{
+		changedBy:        changedByDeferredWorker,
+		reason:           reason,
+		providerResponse: result.payload(),
+	}); err != nil {
+		return false, err
+	}
+	s.publishPaymentEvent(ctx, PaymentEventFailed, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, map[string]interface{}{
+		"reason": reason,
+	})
+	return true, nil
+}
+
+// registerDeferredShipment reports the order's shipment to the provider when
+// the payment is captured. Failures are reported as unavailable so that the
+// order is not marked shipped without the invoice being issued.
+func (s *PaymentService) registerDeferredShipment(ctx context.Context, payment db.Payment, amount int) (string, error) {
+	if amount != payment.AmountMinor {
+		return "", status.Error(codes.InvalidArgument, "deferred payments are captured in full")
+	}
+	if s.deferredProvider == nil || s.deliveryClient == nil {
+		return "", status.Error(codes.Unavailable, "deferred payment shipment registration is not available")
+	}
+
+	deferred, err := s.queries.GetDeferredPayment(ctx, payment.ID)
+	if err != nil {
+		s.logger.Error("Failed to get deferred payment", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return "", status.Error(codes.Internal, "failed to get deferred payment")
+	}
+
+	resp, err := s.deliveryClient.GetShipment(ctx, &deliverypb.GetShipmentRequest{OrderId: payment.OrderID.String()})
+	if err != nil {
+		s.logger.Error("Failed to get shipment for deferred payment",
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err))
+		return "", status.Error(codes.Unavailable, "failed to get shipment")
+	}
+
+	shipment := DeferredShipment{
+		Carrier:        resp.Shipment.Carrier,
+		TrackingNumber: resp.Shipment.TrackingNumber,
+		ShippedAt:      time.Now(),
+	}
+	if err := s.deferredProvider.RegisterShipment(ctx, deferred.ProviderTransactionID, shipment); err != nil {
+		s.logger.Error("Failed to register deferred payment shipment",
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err))
+		return "", status.Error(codes.Unavailable, "failed to register shipment with deferred payment provider")
+	}
+
+	if err := s.queries.RecordDeferredShipment(ctx, db.RecordDeferredShipmentParams{
+		PaymentID:      payment.ID,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		ShippedAt:      shipment.ShippedAt,
+	}); err != nil {
+		// The provider already invoiced the customer; only our copy is stale
+		s.logger.Error("Failed to record deferred payment shipment",
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err))
+	}
+
+	return deferred.ProviderTransactionID, nil
+}
+
+// cancelDeferred cancels the provider transaction of a voided deferred payment
+func (s *PaymentService) cancelDeferred(ctx context.Context, payment db.Payment) error {
+	if s.deferredProvider == nil {
+		return status.Error(codes.Unavailable, "deferred payment is not available")
+	}
+
+	deferred, err := s.queries.GetDeferredPayment(ctx, payment.ID)
+	if err != nil {
+		s.logger.Error("Failed to get deferred payment", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return status.Error(codes.Internal, "failed to get deferred payment")
+	}
+
+	if err := s.deferredProvider.CancelTransaction(ctx, deferred.ProviderTransactionID); err != nil {
+		s.logger.Error("Failed to cancel deferred payment", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return status.Error(codes.Unavailable, "failed to cancel deferred payment")
+	}
+
+	if err := s.queries.UpdateDeferredInvoiceStatus(ctx, payment.ID, paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_CANCELLED.String()); err != nil {
+		s.logger.Warn("Failed to mark deferred invoice cancelled", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+	}
+	return nil
+}
+
+// syncDeferredInvoice stores the provider's invoice status when it changed,
+// reporting whether it did
+func (s *PaymentService) syncDeferredInvoice(ctx context.Context, deferred db.DeferredPayment) (bool, error) {
+	invoiceStatus, err := s.deferredProvider.GetInvoiceStatus(ctx, deferred.ProviderTransactionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get invoice status: %w", err)
+	}
+	if invoiceStatus.String() == deferred.InvoiceStatus {
+		return false, nil
+	}
+
+	if err := s.queries.UpdateDeferredInvoiceStatus(ctx, deferred.PaymentID, invoiceStatus.String()); err != nil {
+		return false, fmt.Errorf("failed to update invoice status: %w", err)
+	}
+
+	s.logger.Info("Deferred payment invoice status changed",
+		zap.String("payment_id", deferred.PaymentID.String()),
+		zap.String("from", deferred.InvoiceStatus),
+		zap.String("to", invoiceStatus.String()))
+	return true, nil
+}
+
+// GetDeferredPayment returns the credit check and invoice of a deferred
+// payment, looked up by payment or order
+func (s *PaymentService) GetDeferredPayment(ctx context.Context, req *paymentpb.GetDeferredPaymentRequest) (*paymentpb.GetDeferredPaymentResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.GetDeferredPayment",
+		trace.WithAttributes(
+			attribute.String("payment.id", req.PaymentId),
+			attribute.String("payment.order_id", req.OrderId),
+		),
+	)
+	defer span.End()
+
+	var paymentID uuid.UUID
+	switch {
+	case req.PaymentId != "":
+		id, err := uuid.Parse(req.PaymentId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
+		}
+		paymentID = id
+	case req.OrderId != "":
+		orderID, err := uuid.Parse(req.OrderId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+		}
+		payment, err := s.queries.GetPaymentByOrderID(ctx, orderID)
+		if err != nil {
+			s.logger.Error("Failed to get payment for order", zap.Error(err))
+			return nil, status.Error(codes.NotFound, "payment not found")
+		}
+		paymentID = payment.ID
+	default:
+		return nil, status.Error(codes.InvalidArgument, "payment_id or order_id is required")
+	}
+
+	deferred, err := s.queries.GetDeferredPayment(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "deferred payment not found")
+		}
+		s.logger.Error("Failed to get deferred payment", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get deferred payment")
+	}
+
+	return &paymentpb.GetDeferredPaymentResponse{
+		DeferredPayment: deferredPaymentToProto(deferred),
+	}, nil
+}
+
+func deferredPaymentToProto(d db.DeferredPayment) *paymentpb.DeferredPayment {
+	return &paymentpb.DeferredPayment{
+		PaymentId:             d.PaymentID.String(),
+		Provider:              d.Provider,
+		ProviderTransactionId: d.ProviderTransactionID,
+		CreditCheck:           paymentpb.CreditCheckResult(paymentpb.CreditCheckResult_value[d.CreditCheck]),
+		CreditCheckReason:     toStringPtr(d.CreditCheckReason),
+		InvoiceStatus:         paymentpb.DeferredInvoiceStatus(paymentpb.DeferredInvoiceStatus_value[d.InvoiceStatus]),
+		Carrier:               toStringPtr(d.Carrier),
+		TrackingNumber:        toStringPtr(d.TrackingNumber),
+		ShippedAt:             toTimestampPtr(d.ShippedAt),
+		PaidAt:                toTimestampPtr(d.PaidAt),
+		CreatedAt:             timestamppb.New(d.CreatedAt),
+		UpdatedAt:             timestamppb.New(d.UpdatedAt),
+	}
+}
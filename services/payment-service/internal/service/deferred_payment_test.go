@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// fakeShipmentClient returns a shipped parcel for any order
+type fakeShipmentClient struct {
+	deliverypb.DeliveryServiceClient
+}
+
+func (c *fakeShipmentClient) GetShipment(ctx context.Context, req *deliverypb.GetShipmentRequest, opts ...grpc.CallOption) (*deliverypb.GetShipmentResponse, error) {
+	return &deliverypb.GetShipmentResponse{Shipment: &deliverypb.Shipment{
+		Id:             "shipment-1",
+		OrderId:        req.OrderId,
+		Carrier:        "yamato",
+		TrackingNumber: "123456789012",
+		Status:         deliverypb.ShipmentStatus_SHIPMENT_STATUS_SHIPPED,
+	}}, nil
+}
+
+func TestFakeDeferredProvider_CheckCredit(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int
+		email  string
+		want   paymentpb.CreditCheckResult
+	}{
+		{name: "approves by default", amount: 12000, email: "yamada@example.com", want: paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED},
+		{name: "approves the limit", amount: 55000, email: "", want: paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED},
+		{name: "declines above the limit", amount: 55001, email: "yamada@example.com", want: paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_DECLINED},
+		{name: "declines tagged emails", amount: 12000, email: "Yamada+Decline@example.com", want: paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_DECLINED},
+		{name: "reviews tagged emails", amount: 12000, email: "yamada+review@example.com", want: paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFakeDeferredProvider(0)
+			result, err := p.CheckCredit(context.Background(), DeferredCreditRequest{AmountMinor: tt.amount, BuyerEmail: tt.email})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Result)
+			assert.NotEmpty(t, result.TransactionID)
+		})
+	}
+}
+
+func TestFakeDeferredProvider_Settle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC)
+	p := NewFakeDeferredProvider(time.Minute)
+	p.now = func() time.Time { return now }
+
+	result, err := p.CheckCredit(ctx, DeferredCreditRequest{AmountMinor: 1000, BuyerEmail: "a+review@example.com"})
+	require.NoError(t, err)
+
+	check, err := p.GetCreditCheck(ctx, result.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW, check.Result)
+
+	now = now.Add(time.Minute)
+	check, err = p.GetCreditCheck(ctx, result.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED, check.Result)
+
+	require.NoError(t, p.RegisterShipment(ctx, result.TransactionID, DeferredShipment{TrackingNumber: "1"}))
+	invoice, err := p.GetInvoiceStatus(ctx, result.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_ISSUED, invoice)
+
+	now = now.Add(time.Minute)
+	invoice, err = p.GetInvoiceStatus(ctx, result.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_PAID, invoice)
+	assert.Error(t, p.CancelTransaction(ctx, result.TransactionID))
+}
+
+func TestPaymentService_ProcessPayment_Deferred(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      int
+		email       string
+		wantStatus  paymentpb.PaymentStatus
+		creditCheck string
+	}{
+		{name: "approved checks authorize the payment", amount: 12000, email: "yamada@example.com",
+			wantStatus: paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, creditCheck: "CREDIT_CHECK_RESULT_APPROVED"},
+		{name: "checks in review keep the payment processing", amount: 12000, email: "yamada+review@example.com",
+			wantStatus: paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, creditCheck: "CREDIT_CHECK_RESULT_REVIEW"},
+		{name: "declined checks fail the payment", amount: 60000, email: "yamada@example.com",
+			wantStatus: paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, creditCheck: "CREDIT_CHECK_RESULT_DECLINED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueries := new(MockQuerier)
+			s := newTestPaymentService(mockQueries, withDeferredProvider(NewFakeDeferredProvider(0)), withDeliveryClient(&fakeShipmentClient{}))
+			payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_DEFERRED, "PAYMENT_STATUS_PENDING", tt.amount)
+			record := db.DeferredPayment{PaymentID: payment.ID, Provider: "fake", CreditCheck: tt.creditCheck}
+
+			mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+			mockQueries.On("CreateDeferredPayment", mock.Anything, mock.MatchedBy(func(p db.CreateDeferredPaymentParams) bool {
+				return p.PaymentID == payment.ID && p.Provider == "fake" && p.CreditCheck == tt.creditCheck
+			})).Return(record, nil)
+			mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+			if tt.wantStatus == paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+				mockQueries.On("AuthorizePayment", mock.Anything, mock.MatchedBy(func(p db.AuthorizePaymentParams) bool {
+					return p.ID == payment.ID && p.ExpiresAt.After(time.Now().Add(authorizationTTL))
+				})).Return(nil)
+			} else {
+				mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+					return p.Status == tt.wantStatus.String()
+				})).Return(nil)
+			}
+			mockQueries.On("GetDeferredPayment", mock.Anything, payment.ID).Return(record, nil)
+
+			resp, err := s.ProcessPayment(context.Background(), &paymentpb.ProcessPaymentRequest{
+				PaymentId:   payment.ID.String(),
+				PaymentData: map[string]string{"buyer_email": tt.email},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.creditCheck, resp.DeferredPayment.CreditCheck.String())
+			mockQueries.AssertExpectations(t)
+		})
+	}
+
+	t.Run("is unavailable without a provider", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withDeliveryClient(&fakeShipmentClient{}))
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_DEFERRED, "PAYMENT_STATUS_PENDING", 12000)
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+
+		_, err := s.ProcessPayment(context.Background(), &paymentpb.ProcessPaymentRequest{PaymentId: payment.ID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestDeferredPaymentWorker_ResolveReviews(t *testing.T) {
+	setup := func(t *testing.T) (*MockQuerier, *FakeDeferredProvider, *DeferredPaymentWorker, db.Payment, db.DeferredPayment) {
+		mockQueries := new(MockQuerier)
+		provider := NewFakeDeferredProvider(0)
+		s := newTestPaymentService(mockQueries, withDeferredProvider(provider), withDeliveryClient(&fakeShipmentClient{}))
+		payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_DEFERRED, "PAYMENT_STATUS_PROCESSING", 12000)
+
+		result, err := provider.CheckCredit(context.Background(), DeferredCreditRequest{AmountMinor: 12000, BuyerEmail: "a+review@example.com"})
+		require.NoError(t, err)
+		deferred := db.DeferredPayment{
+			PaymentID:             payment.ID,
+			Provider:              "fake",
+			ProviderTransactionID: result.TransactionID,
+			CreditCheck:           "CREDIT_CHECK_RESULT_REVIEW",
+		}
+		mockQueries.On("ListDeferredPaymentsInReview", mock.Anything, sweepBatchSize).Return([]db.DeferredPayment{deferred}, nil)
+		return mockQueries, provider, NewDeferredPaymentWorker(s, zap.NewNop()), payment, deferred
+	}
+
+	t.Run("leaves undecided reviews alone", func(t *testing.T) {
+		mockQueries, _, worker, _, _ := setup(t)
+
+		resolved, err := worker.ResolveReviews(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, resolved)
+		mockQueries.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("authorizes approved payments and releases the order", func(t *testing.T) {
+		mockQueries, provider, worker, payment, deferred := setup(t)
+		require.NoError(t, provider.ResolveReview(deferred.ProviderTransactionID, paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED, ""))
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("UpdateDeferredCreditCheck", mock.Anything, payment.ID, "CREDIT_CHECK_RESULT_APPROVED", (*string)(nil)).Return(nil)
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.MatchedBy(func(p db.AuthorizePaymentParams) bool {
+			return p.ID == payment.ID && p.TransactionID == deferred.ProviderTransactionID
+		})).Return(nil)
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == PaymentEventAuthorized
+		})).Return(1, nil)
+
+		resolved, err := worker.ResolveReviews(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resolved)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("fails declined payments", func(t *testing.T) {
+		mockQueries, provider, worker, payment, deferred := setup(t)
+		require.NoError(t, provider.ResolveReview(deferred.ProviderTransactionID, paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_DECLINED, "address unverified"))
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("UpdateDeferredCreditCheck", mock.Anything, payment.ID, "CREDIT_CHECK_RESULT_DECLINED", mock.Anything).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == payment.ID && p.Status == "PAYMENT_STATUS_FAILED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByDeferredWorker
+		})).Return(nil)
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == PaymentEventFailed
+		})).Return(1, nil)
+
+		resolved, err := worker.ResolveReviews(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resolved)
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestPaymentService_CapturePayment_Deferred(t *testing.T) {
+	ctx := context.Background()
+	mockQueries := new(MockQuerier)
+	provider := NewFakeDeferredProvider(0)
+	s := newTestPaymentService(mockQueries, withDeferredProvider(provider), withDeliveryClient(&fakeShipmentClient{}))
+	payment := testPayment(paymentpb.PaymentMethod_PAYMENT_METHOD_DEFERRED, "PAYMENT_STATUS_AUTHORIZED", 12000)
+
+	result, err := provider.CheckCredit(ctx, DeferredCreditRequest{AmountMinor: 12000})
+	require.NoError(t, err)
+	deferred := db.DeferredPayment{PaymentID: payment.ID, ProviderTransactionID: result.TransactionID}
+
+	mockQueries.On("ListPaymentsByOrderID", mock.Anything, payment.OrderID).Return([]db.Payment{payment}, nil)
+	mockQueries.On("GetDeferredPayment", mock.Anything, payment.ID).Return(deferred, nil)
+	mockQueries.On("RecordDeferredShipment", mock.Anything, mock.MatchedBy(func(p db.RecordDeferredShipmentParams) bool {
+		return p.PaymentID == payment.ID && p.Carrier == "yamato" && p.TrackingNumber == "123456789012"
+	})).Return(nil)
+	mockQueries.On("CapturePayment", mock.Anything, mock.MatchedBy(func(p db.CapturePaymentParams) bool {
+		return p.AmountMinor == 12000 && *p.TransactionID == result.TransactionID
+	})).Return(nil)
+
+	resp, err := s.CapturePayment(ctx, &paymentpb.CapturePaymentRequest{OrderId: payment.OrderID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED, resp.Status)
+	require.NotNil(t, provider.Shipment(result.TransactionID))
+	assert.Equal(t, "123456789012", provider.Shipment(result.TransactionID).TrackingNumber)
+	mockQueries.AssertExpectations(t)
+
+	t.Run("rejects partial captures", func(t *testing.T) {
+		_, err := s.CapturePayment(ctx, &paymentpb.CapturePaymentRequest{
+			OrderId: payment.OrderID.String(),
+			Amount:  &sharedpb.Money{Units: 5000, Currency: "JPY"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDeferredPaymentWorker_SyncInvoices(t *testing.T) {
+	ctx := context.Background()
+	mockQueries := new(MockQuerier)
+	provider := NewFakeDeferredProvider(0)
+	worker := NewDeferredPaymentWorker(newTestPaymentService(mockQueries, withDeferredProvider(provider), withDeliveryClient(&fakeShipmentClient{})), zap.NewNop())
+
+	paid, err := provider.CheckCredit(ctx, DeferredCreditRequest{AmountMinor: 1000})
+	require.NoError(t, err)
+	require.NoError(t, provider.RegisterShipment(ctx, paid.TransactionID, DeferredShipment{}))
+	require.NoError(t, provider.SetInvoiceStatus(paid.TransactionID, paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_PAID))
+
+	unpaid, err := provider.CheckCredit(ctx, DeferredCreditRequest{AmountMinor: 1000})
+	require.NoError(t, err)
+	require.NoError(t, provider.RegisterShipment(ctx, unpaid.TransactionID, DeferredShipment{}))
+
+	paidPayment := uuid.New()
+	mockQueries.On("ListOpenDeferredInvoices", mock.Anything, sweepBatchSize).Return([]db.DeferredPayment{
+		{PaymentID: paidPayment, ProviderTransactionID: paid.TransactionID, InvoiceStatus: "DEFERRED_INVOICE_STATUS_ISSUED"},
+		{PaymentID: uuid.New(), ProviderTransactionID: unpaid.TransactionID, InvoiceStatus: "DEFERRED_INVOICE_STATUS_ISSUED"},
+	}, nil)
+	mockQueries.On("UpdateDeferredInvoiceStatus", mock.Anything, paidPayment, "DEFERRED_INVOICE_STATUS_PAID").Return(nil)
+
+	updated, err := worker.SyncInvoices(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	mockQueries.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeferredPaymentWorker follows deferred payments at the provider: it applies
+// the decisions on credit checks held for review and tracks whether issued
+// invoices have been paid
+type DeferredPaymentWorker struct {
+	payments *PaymentService
+	logger   *zap.Logger
+}
+
+// NewDeferredPaymentWorker creates a new deferred payment worker
+func NewDeferredPaymentWorker(payments *PaymentService, logger *zap.Logger) *DeferredPaymentWorker {
+	return &DeferredPaymentWorker{
+		payments: payments,
+		logger:   logger,
+	}
+}
+
+// ResolveReviews applies every decided credit check that was held for review
+func (w *DeferredPaymentWorker) ResolveReviews(ctx context.Context) (int, error) {
+	reviews, err := w.payments.queries.ListDeferredPaymentsInReview(ctx, sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resolved := 0
+	for _, deferred := range reviews {
+		ok, err := w.payments.resolveCreditReview(ctx, deferred)
+		if err != nil {
+			w.logger.Warn("Failed to resolve credit review",
+				zap.String("payment_id", deferred.PaymentID.String()),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			resolved++
+		}
+	}
+
+	if resolved > 0 {
+		w.logger.Info("Resolved deferred payment credit reviews",
+			zap.Int("in_review", len(reviews)),
+			zap.Int("resolved", resolved))
+	}
+
+	return resolved, nil
+}
+
+// SyncInvoices refreshes the status of every unpaid invoice
+func (w *DeferredPaymentWorker) SyncInvoices(ctx context.Context) (int, error) {
+	invoices, err := w.payments.queries.ListOpenDeferredInvoices(ctx, sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, deferred := range invoices {
+		ok, err := w.payments.syncDeferredInvoice(ctx, deferred)
+		if err != nil {
+			w.logger.Warn("Failed to sync deferred invoice",
+				zap.String("payment_id", deferred.PaymentID.String()),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// StartPeriodicSweep starts the worker in the background
+func (w *DeferredPaymentWorker) StartPeriodicSweep(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic deferred payment sweep", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.ResolveReviews(ctx); err != nil {
+					w.logger.Error("Periodic credit review sweep failed", zap.Error(err))
+				}
+				if _, err := w.SyncInvoices(ctx); err != nil {
+					w.logger.Error("Periodic deferred invoice sync failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+)
+
+// fakeDeferredLimit mirrors the 55,000 yen per order limit of NP後払い
+const fakeDeferredLimit = 55000
+
+// FakeDeferredProvider is an in-memory deferred payment provider for local
+// development and tests. The credit check outcome is picked from the order:
+//
+//   - orders over 55,000 yen are declined
+//   - a buyer email tagged +decline (yamada+decline@example.com) is declined
+//   - a buyer email tagged +review is held for review
+//   - everything else is approved
+//
+// With settleAfter set, reviews are approved and invoices paid once that long
+// has passed; otherwise they wait for ResolveReview and SetInvoiceStatus.
+type FakeDeferredProvider struct {
+	settleAfter time.Duration
+	now         func() time.Time
+
+	mu           sync.Mutex
+	transactions map[string]*fakeDeferredTransaction
+}
+
+type fakeDeferredTransaction struct {
+	result        DeferredCreditResult
+	checkedAt     time.Time
+	shipment      *DeferredShipment
+	invoiceStatus paymentpb.DeferredInvoiceStatus
+	issuedAt      time.Time
+}
+
+// NewFakeDeferredProvider creates a fake provider
+func NewFakeDeferredProvider(settleAfter time.Duration) *FakeDeferredProvider {
+	return &FakeDeferredProvider{
+		settleAfter:  settleAfter,
+		now:          time.Now,
+		transactions: make(map[string]*fakeDeferredTransaction),
+	}
+}
+
+// Name implements DeferredPaymentProvider
+func (p *FakeDeferredProvider) Name() string {
+	return "fake"
+}
+
+// CheckCredit implements DeferredPaymentProvider
+func (p *FakeDeferredProvider) CheckCredit(ctx context.Context, req DeferredCreditRequest) (DeferredCreditResult, error) {
+	result := DeferredCreditResult{
+		TransactionID: fmt.Sprintf("FAKE-DEFERRED-%s", uuid.New().String()[:8]),
+		Result:        paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED,
+	}
+
+	local, _, _ := strings.Cut(strings.ToLower(req.BuyerEmail), "@")
+	switch {
+	case req.AmountMinor > fakeDeferredLimit:
+		result.Result = paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_DECLINED
+		result.Reason = fmt.Sprintf("amount exceeds the %d yen limit", fakeDeferredLimit)
+	case strings.HasSuffix(local, "+decline"):
+		result.Result = paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_DECLINED
+		result.Reason = "customer did not pass the credit check"
+	case strings.HasSuffix(local, "+review"):
+		result.Result = paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW
+		result.Reason = "held for manual review"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.transactions[result.TransactionID] = &fakeDeferredTransaction{
+		result:        result,
+		checkedAt:     p.now(),
+		invoiceStatus: paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_NOT_ISSUED,
+	}
+	return result, nil
+}
+
+// GetCreditCheck implements DeferredPaymentProvider
+func (p *FakeDeferredProvider) GetCreditCheck(ctx context.Context, transactionID string) (DeferredCreditResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, ok := p.transactions[transactionID]
+	if !ok {
+		return DeferredCreditResult{}, fmt.Errorf("unknown transaction %s", transactionID)
+	}
+	if tx.result.Result == paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_REVIEW && p.settled(tx.checkedAt) {
+		tx.result.Result = paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED
+		tx.result.Reason = ""
+	}
+	return tx.result, nil
+}
+
+// RegisterShipment implements DeferredPaymentProvider
+func (p *FakeDeferredProvider) RegisterShipment(ctx context.Context, transactionID string, shipment DeferredShipment) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, ok := p.transactions[transactionID]
+	if !ok {
+		return fmt.Errorf("unknown transaction %s", transactionID)
+	}
+	if tx.result.Result != paymentpb.CreditCheckResult_CREDIT_CHECK_RESULT_APPROVED {
+		return fmt.Errorf("transaction %s is not approved", transactionID)
+	}
+	if tx.invoiceStatus == paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_CANCELLED {
+		return fmt.Errorf("transaction %s is cancelled", transactionID)
+	}
+
+	tx.shipment = &shipment
+	if tx.invoiceStatus == paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_NOT_ISSUED {
+		tx.invoiceStatus = paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_ISSUED
+		tx.issuedAt = p.now()
+	}
+	return nil
+}
+
+// GetInvoiceStatus implements DeferredPaymentProvider
+func (p *FakeDeferredProvider) GetInvoiceStatus(ctx context.Context, transactionID string) (paymentpb.DeferredInvoiceStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, ok := p.transactions[transactionID]
+	if !ok {
+		return paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_UNSPECIFIED, fmt.Errorf("unknown transaction %s", transactionID)
+	}
+	if tx.invoiceStatus == paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_ISSUED && p.settled(tx.issuedAt) {
+		tx.invoiceStatus = paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_PAID
+	}
+	return tx.invoiceStatus, nil
+}
+
+// CancelTransaction implements DeferredPaymentProvider
+func (p *FakeDeferredProvider) CancelTransaction(ctx context.Context, transactionID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, ok := p.transactions[transactionID]
+	if !ok {
+		return fmt.Errorf("unknown transaction %s", transactionID)
+	}
+	if tx.invoiceStatus == paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_PAID {
+		return fmt.Errorf("transaction %s is already paid", transactionID)
+	}
+	tx.invoiceStatus = paymentpb.DeferredInvoiceStatus_DEFERRED_INVOICE_STATUS_CANCELLED
+	return nil
+}
+
+// ResolveReview decides a check held for review
+func (p *FakeDeferredProvider) ResolveReview(transactionID string, result paymentpb.CreditCheckResult, reason string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, ok := p.transactions[transactionID]
+	if !ok {
+		return fmt.Errorf("unknown transaction %s", transactionID)
+	}
+	tx.result.Result = result
+	tx.result.Reason = reason
+	return nil
+}
+
+// SetInvoiceStatus changes the status of an issued invoice, e.g. to paid or
+// overdue
+func (p *FakeDeferredProvider) SetInvoiceStatus(transactionID string, invoiceStatus paymentpb.DeferredInvoiceStatus) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, ok := p.transactions[transactionID]
+	if !ok {
+		return fmt.Errorf("unknown transaction %s", transactionID)
+	}
+	tx.invoiceStatus = invoiceStatus
+	return nil
+}
+
+// Shipment returns the shipment registered for a transaction, if any
+func (p *FakeDeferredProvider) Shipment(transactionID string) *DeferredShipment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tx, ok := p.transactions[transactionID]; ok {
+		return tx.shipment
+	}
+	return nil
+}
+
+func (p *FakeDeferredProvider) settled(since time.Time) bool {
+	return p.settleAfter > 0 && p.now().Sub(since) >= p.settleAfter
+}
@@ -0,0 +1,559 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+const (
+	// defaultDisputeResponseWindow is used when the provider does not send
+	// an evidence deadline
+	defaultDisputeResponseWindow = 7 * 24 * time.Hour
+
+	// maxDisputeEvidenceBytes keeps a document well below the gRPC message
+	// limit
+	maxDisputeEvidenceBytes = 3 << 20
+	maxDisputeEvidenceItems = 20
+
+	defaultDisputesPageSize = 20
+)
+
+// disputeEvidenceKinds are the evidence categories providers accept
+var disputeEvidenceKinds = map[string]bool{
+	"receipt":                true,
+	"shipping_proof":         true,
+	"customer_communication": true,
+	"refund_policy":          true,
+	"other":                  true,
+}
+
+var disputeEvidenceContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"text/plain":      true,
+}
+
+// providerDisputeStatuses maps the status names providers send in dispute
+// webhooks
+var providerDisputeStatuses = map[string]paymentpb.DisputeStatus{
+	"needs_response": paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE,
+	"under_review":   paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW,
+	"won":            paymentpb.DisputeStatus_DISPUTE_STATUS_WON,
+	"lost":           paymentpb.DisputeStatus_DISPUTE_STATUS_LOST,
+}
+
+// ListDisputes returns disputes for the back office, those whose evidence is
+// due soonest first
+func (s *PaymentService) ListDisputes(ctx context.Context, req *paymentpb.ListDisputesRequest) (*paymentpb.ListDisputesResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListDisputes",
+		trace.WithAttributes(attribute.String("dispute.status", req.Status.String())),
+	)
+	defer span.End()
+
+	page, limit := int32(1), int32(defaultDisputesPageSize)
+	if req.Pagination != nil {
+		if req.Pagination.Page > 0 {
+			page = req.Pagination.Page
+		}
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	params := db.ListDisputesParams{
+		Limit:  int(limit),
+		Offset: int((page - 1) * limit),
+	}
+	if req.OrderId != "" {
+		orderID, err := uuid.Parse(req.OrderId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+		}
+		params.OrderID = &orderID
+	}
+	if req.Status != paymentpb.DisputeStatus_DISPUTE_STATUS_UNSPECIFIED {
+		st := req.Status.String()
+		params.Status = &st
+	}
+
+	disputes, total, err := s.queries.ListDisputes(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list disputes", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list disputes")
+	}
+
+	pbDisputes := make([]*paymentpb.Dispute, len(disputes))
+	for i, d := range disputes {
+		pbDisputes[i] = disputeToProto(d, nil)
+	}
+
+	return &paymentpb.ListDisputesResponse{
+		Disputes:   pbDisputes,
+		Pagination: &sharedpb.Pagination{Page: page, Limit: limit, Total: int32(total)},
+	}, nil
+}
+
+// GetDispute returns a dispute with the evidence attached so far
+func (s *PaymentService) GetDispute(ctx context.Context, req *paymentpb.GetDisputeRequest) (*paymentpb.GetDisputeResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.GetDispute",
+		trace.WithAttributes(attribute.String("dispute.id", req.DisputeId)),
+	)
+	defer span.End()
+
+	dispute, err := s.getDispute(ctx, req.DisputeId)
+	if err != nil {
+		return nil, err
+	}
+
+	evidence, err := s.queries.ListDisputeEvidence(ctx, dispute.ID, false)
+	if err != nil {
+		s.logger.Error("Failed to list dispute evidence", zap.String("dispute_id", req.DisputeId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get dispute")
+	}
+
+	return &paymentpb.GetDisputeResponse{Dispute: disputeToProto(dispute, evidence)}, nil
+}
+
+// AddDisputeEvidence attaches a document to a dispute that still needs a
+// response. Evidence is sent to the provider with SubmitDisputeEvidence.
+func (s *PaymentService) AddDisputeEvidence(ctx context.Context, req *paymentpb.AddDisputeEvidenceRequest) (*paymentpb.AddDisputeEvidenceResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.AddDisputeEvidence",
+		trace.WithAttributes(
+			attribute.String("dispute.id", req.DisputeId),
+			attribute.String("evidence.kind", req.Kind),
+		),
+	)
+	defer span.End()
+
+	if err := validateDisputeEvidence(req); err != nil {
+		return nil, err
+	}
+
+	dispute, err := s.getDispute(ctx, req.DisputeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDisputeOpenForEvidence(dispute); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.queries.ListDisputeEvidence(ctx, dispute.ID, false)
+	if err != nil {
+		s.logger.Error("Failed to list dispute evidence", zap.String("dispute_id", req.DisputeId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to add dispute evidence")
+	}
+	if len(existing) >= maxDisputeEvidenceItems {
+		return nil, status.Errorf(codes.FailedPrecondition, "a dispute can have at most %d evidence documents", maxDisputeEvidenceItems)
+	}
+
+	evidence, err := s.queries.AddDisputeEvidence(ctx, db.AddDisputeEvidenceParams{
+		DisputeID:   dispute.ID,
+		Kind:        req.Kind,
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		Content:     req.Content,
+		Description: req.Description,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add dispute evidence", zap.String("dispute_id", req.DisputeId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to add dispute evidence")
+	}
+
+	return &paymentpb.AddDisputeEvidenceResponse{Evidence: disputeEvidenceToProto(evidence)}, nil
+}
+
+// SubmitDisputeEvidence sends the attached evidence to the provider and puts
+// the dispute under review. Evidence can be submitted once, before the
+// deadline.
+func (s *PaymentService) SubmitDisputeEvidence(ctx context.Context, req *paymentpb.SubmitDisputeEvidenceRequest) (*paymentpb.SubmitDisputeEvidenceResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.SubmitDisputeEvidence",
+		trace.WithAttributes(attribute.String("dispute.id", req.DisputeId)),
+	)
+	defer span.End()
+
+	dispute, err := s.getDispute(ctx, req.DisputeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDisputeOpenForEvidence(dispute); err != nil {
+		return nil, err
+	}
+
+	evidence, err := s.queries.ListDisputeEvidence(ctx, dispute.ID, true)
+	if err != nil {
+		s.logger.Error("Failed to list dispute evidence", zap.String("dispute_id", req.DisputeId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to submit dispute evidence")
+	}
+	if len(evidence) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "attach evidence before submitting")
+	}
+
+	if err := s.submitDisputeEvidenceWithGateway(ctx, dispute, evidence); err != nil {
+		s.logger.Error("Provider rejected dispute evidence", zap.String("dispute_id", req.DisputeId), zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "failed to submit dispute evidence to provider")
+	}
+
+	dispute, err = s.queries.MarkDisputeEvidenceSubmitted(ctx, dispute.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "dispute no longer needs a response")
+		}
+		s.logger.Error("Failed to mark dispute evidence submitted", zap.String("dispute_id", req.DisputeId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to submit dispute evidence")
+	}
+
+	return &paymentpb.SubmitDisputeEvidenceResponse{Dispute: disputeToProto(dispute, evidence)}, nil
+}
+
+func (s *PaymentService) getDispute(ctx context.Context, disputeID string) (db.Dispute, error) {
+	id, err := uuid.Parse(disputeID)
+	if err != nil {
+		return db.Dispute{}, status.Error(codes.InvalidArgument, "invalid dispute_id")
+	}
+
+	dispute, err := s.queries.GetDispute(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Dispute{}, status.Error(codes.NotFound, "dispute not found")
+		}
+		s.logger.Error("Failed to get dispute", zap.String("dispute_id", disputeID), zap.Error(err))
+		return db.Dispute{}, status.Error(codes.Internal, "failed to get dispute")
+	}
+	return dispute, nil
+}
+
+func (s *PaymentService) submitDisputeEvidenceWithGateway(ctx context.Context, dispute db.Dispute, evidence []db.DisputeEvidence) error {
+	s.logger.Info("Submitting dispute evidence to gateway",
+		zap.String("dispute_id", dispute.ID.String()),
+		zap.String("provider_dispute_id", dispute.ProviderDisputeID),
+		zap.Int("documents", len(evidence)))
+	return nil
+}
+
+// publishDisputeEvent notifies other services of a dispute. order_disputed
+// tells the order service whether the order still has an open dispute.
+func (s *PaymentService) publishDisputeEvent(ctx context.Context, eventType string, dispute db.Dispute, payment db.Payment, paymentStatus paymentpb.PaymentStatus) {
+	open, err := s.queries.CountOpenDisputesByOrderID(ctx, payment.OrderID)
+	if err != nil {
+		s.logger.Warn("Failed to count open disputes",
+			zap.String("order_id", payment.OrderID.String()),
+			zap.Error(err))
+		open = 0
+		if disputeIsOpen(dispute) {
+			open = 1
+		}
+	}
+
+	data := map[string]interface{}{
+		"dispute_id":     dispute.ID.String(),
+		"dispute_status": dispute.Status,
+		"reason":         dispute.Reason,
+		"amount":         dispute.AmountMinor,
+		"currency":       dispute.Currency,
+		"order_disputed": open > 0,
+	}
+	if dispute.AdjustmentMinor != nil {
+		data["adjustment"] = *dispute.AdjustmentMinor
+	}
+
+	s.publishPaymentEvent(ctx, eventType, payment, paymentStatus, data)
+}
+
+// handleDisputeCreated records a chargeback the card issuer opened
+func (s *WebhookService) handleDisputeCreated(ctx context.Context, event WebhookEvent) error {
+	paymentID, ok := event.Data["payment_id"].(string)
+	if !ok {
+		return fmt.Errorf("%w: missing payment_id", ErrInvalidWebhookEvent)
+	}
+	providerDisputeID, ok := event.Data["dispute_id"].(string)
+	if !ok || providerDisputeID == "" {
+		return fmt.Errorf("%w: missing dispute_id", ErrInvalidWebhookEvent)
+	}
+	reason, _ := event.Data["reason"].(string)
+
+	s.logger.Info("Dispute created webhook",
+		zap.String("payment_id", paymentID),
+		zap.String("provider_dispute_id", providerDisputeID),
+		zap.String("reason", reason))
+
+	id, err := uuid.Parse(paymentID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid payment_id: %v", ErrInvalidWebhookEvent, err)
+	}
+
+	queries := s.paymentService.queries
+	payment, err := queries.GetPayment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: unknown payment %s", ErrInvalidWebhookEvent, paymentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	amount := payment.AmountMinor
+	if amountFloat, ok := event.Data["amount"].(float64); ok && amountFloat > 0 {
+		amount = int(amountFloat)
+	}
+	currency := payment.Currency
+	if c, ok := event.Data["currency"].(string); ok && c != "" {
+		currency = c
+	}
+	dueBy := eventTime(event, "evidence_due_by", time.Now().Add(defaultDisputeResponseWindow))
+
+	dispute, created, err := queries.CreateDispute(ctx, db.CreateDisputeParams{
+		PaymentID:         payment.ID,
+		ProviderDisputeID: providerDisputeID,
+		Reason:            reason,
+		AmountMinor:       amount,
+		Currency:          currency,
+		EvidenceDueBy:     dueBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record dispute: %w", err)
+	}
+	if !created {
+		s.logger.Info("Dispute already recorded", zap.String("provider_dispute_id", providerDisputeID))
+		return nil
+	}
+
+	s.paymentService.publishDisputeEvent(ctx, PaymentEventDisputeOpened, dispute, payment, ParsePaymentStatus(payment.Status))
+
+	return nil
+}
+
+// handleDisputeUpdated records a new status or evidence deadline of an open
+// dispute. Outcomes arrive as dispute.closed.
+func (s *WebhookService) handleDisputeUpdated(ctx context.Context, event WebhookEvent) error {
+	dispute, err := s.loadDispute(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	newStatus := ParseDisputeStatus(dispute.Status)
+	if name, ok := event.Data["status"].(string); ok {
+		st, known := providerDisputeStatuses[strings.ToLower(name)]
+		if !known {
+			return fmt.Errorf("%w: unknown dispute status %q", ErrInvalidWebhookEvent, name)
+		}
+		newStatus = st
+	}
+	if newStatus == paymentpb.DisputeStatus_DISPUTE_STATUS_WON || newStatus == paymentpb.DisputeStatus_DISPUTE_STATUS_LOST {
+		return s.closeDispute(ctx, event, dispute, newStatus)
+	}
+
+	var dueBy *time.Time
+	if _, ok := event.Data["evidence_due_by"]; ok {
+		t := eventTime(event, "evidence_due_by", dispute.EvidenceDueBy)
+		dueBy = &t
+	}
+
+	s.logger.Info("Dispute updated webhook",
+		zap.String("dispute_id", dispute.ID.String()),
+		zap.String("status", newStatus.String()))
+
+	if _, err := s.paymentService.queries.UpdateDispute(ctx, db.UpdateDisputeParams{
+		ID:            dispute.ID,
+		Status:        newStatus.String(),
+		EvidenceDueBy: dueBy,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("Ignoring update for closed dispute", zap.String("dispute_id", dispute.ID.String()))
+			return nil
+		}
+		return fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	return nil
+}
+
+// handleDisputeClosed records the outcome of a dispute
+func (s *WebhookService) handleDisputeClosed(ctx context.Context, event WebhookEvent) error {
+	dispute, err := s.loadDispute(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	name, _ := event.Data["status"].(string)
+	outcome := providerDisputeStatuses[strings.ToLower(name)]
+	if outcome != paymentpb.DisputeStatus_DISPUTE_STATUS_WON && outcome != paymentpb.DisputeStatus_DISPUTE_STATUS_LOST {
+		return fmt.Errorf("%w: dispute.closed needs status won or lost, got %q", ErrInvalidWebhookEvent, name)
+	}
+
+	return s.closeDispute(ctx, event, dispute, outcome)
+}
+
+// closeDispute records the outcome of a dispute. A lost dispute takes the
+// disputed amount back as a negative adjustment and charges the payment back.
+func (s *WebhookService) closeDispute(ctx context.Context, event WebhookEvent, dispute db.Dispute, outcome paymentpb.DisputeStatus) error {
+	s.logger.Info("Dispute closed webhook",
+		zap.String("dispute_id", dispute.ID.String()),
+		zap.String("outcome", outcome.String()))
+
+	params := db.CloseDisputeParams{ID: dispute.ID, Status: outcome.String()}
+	if outcome == paymentpb.DisputeStatus_DISPUTE_STATUS_LOST {
+		amount := dispute.AmountMinor
+		params.AdjustmentMinor = &amount
+		params.AdjustmentReason = "chargeback lost: " + dispute.Reason
+	}
+
+	queries := s.paymentService.queries
+	dispute, closed, err := queries.CloseDispute(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to close dispute: %w", err)
+	}
+	if !closed {
+		s.logger.Info("Dispute already closed", zap.String("dispute_id", dispute.ID.String()))
+		return nil
+	}
+
+	payment, err := queries.GetPayment(ctx, dispute.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	paymentStatus := ParsePaymentStatus(payment.Status)
+	if outcome == paymentpb.DisputeStatus_DISPUTE_STATUS_LOST {
+		chargedBack := paymentpb.PaymentStatus_PAYMENT_STATUS_CHARGED_BACK
+		if s.paymentService.stateMachine.CanTransition(paymentStatus, chargedBack) {
+			if err := s.paymentService.transitionPayment(ctx, payment, chargedBack, webhookChange(event, dispute.Reason)); err != nil {
+				return err
+			}
+			paymentStatus = chargedBack
+		} else {
+			s.logger.Warn("Lost dispute on payment that cannot be charged back",
+				zap.String("payment_id", payment.ID.String()),
+				zap.String("status", payment.Status))
+		}
+	}
+
+	s.paymentService.publishDisputeEvent(ctx, PaymentEventDisputeClosed, dispute, payment, paymentStatus)
+
+	return nil
+}
+
+// loadDispute fetches the dispute an event refers to by its provider ID
+func (s *WebhookService) loadDispute(ctx context.Context, event WebhookEvent) (db.Dispute, error) {
+	providerDisputeID, ok := event.Data["dispute_id"].(string)
+	if !ok || providerDisputeID == "" {
+		return db.Dispute{}, fmt.Errorf("%w: missing dispute_id", ErrInvalidWebhookEvent)
+	}
+
+	dispute, err := s.paymentService.queries.GetDisputeByProviderID(ctx, providerDisputeID)
+	if err != nil {
+		return db.Dispute{}, fmt.Errorf("failed to get dispute %s: %w", providerDisputeID, err)
+	}
+	return dispute, nil
+}
+
+// eventTime reads an RFC 3339 time from event data, falling back to def
+func eventTime(event WebhookEvent, key string, def time.Time) time.Time {
+	value, _ := event.Data[key].(string)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return def
+	}
+	return t
+}
+
+func validateDisputeEvidence(req *paymentpb.AddDisputeEvidenceRequest) error {
+	if !disputeEvidenceKinds[req.Kind] {
+		return status.Errorf(codes.InvalidArgument, "unsupported evidence kind %q", req.Kind)
+	}
+	if strings.TrimSpace(req.FileName) == "" {
+		return status.Error(codes.InvalidArgument, "file_name is required")
+	}
+	if !disputeEvidenceContentTypes[req.ContentType] {
+		return status.Errorf(codes.InvalidArgument, "unsupported content type %q", req.ContentType)
+	}
+	if len(req.Content) == 0 {
+		return status.Error(codes.InvalidArgument, "content is required")
+	}
+	if len(req.Content) > maxDisputeEvidenceBytes {
+		return status.Errorf(codes.InvalidArgument, "evidence documents are limited to %d bytes", maxDisputeEvidenceBytes)
+	}
+	return nil
+}
+
+// checkDisputeOpenForEvidence fails unless the dispute still awaits evidence
+// and its deadline has not passed
+func checkDisputeOpenForEvidence(dispute db.Dispute) error {
+	if dispute.Status != paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE.String() {
+		return status.Errorf(codes.FailedPrecondition, "dispute is %s", dispute.Status)
+	}
+	if !time.Now().Before(dispute.EvidenceDueBy) {
+		return status.Error(codes.FailedPrecondition, "evidence deadline has passed")
+	}
+	return nil
+}
+
+func disputeIsOpen(dispute db.Dispute) bool {
+	switch ParseDisputeStatus(dispute.Status) {
+	case paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE, paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW:
+		return true
+	}
+	return false
+}
+
+// ParseDisputeStatus converts a stored dispute status name to the enum
+func ParseDisputeStatus(name string) paymentpb.DisputeStatus {
+	return paymentpb.DisputeStatus(paymentpb.DisputeStatus_value[name])
+}
+
+func disputeToProto(d db.Dispute, evidence []db.DisputeEvidence) *paymentpb.Dispute {
+	pb := &paymentpb.Dispute{
+		Id:                d.ID.String(),
+		PaymentId:         d.PaymentID.String(),
+		OrderId:           d.OrderID.String(),
+		ProviderDisputeId: d.ProviderDisputeID,
+		Status:            ParseDisputeStatus(d.Status),
+		Reason:            d.Reason,
+		Amount:            &sharedpb.Money{Units: int64(d.AmountMinor), Currency: d.Currency},
+		EvidenceDueBy:     timestamppb.New(d.EvidenceDueBy),
+		CreatedAt:         timestamppb.New(d.CreatedAt),
+		UpdatedAt:         timestamppb.New(d.UpdatedAt),
+	}
+	if d.EvidenceSubmittedAt != nil {
+		pb.EvidenceSubmittedAt = timestamppb.New(*d.EvidenceSubmittedAt)
+	}
+	if d.AdjustmentMinor != nil {
+		pb.Adjustment = &sharedpb.Money{Units: int64(*d.AdjustmentMinor), Currency: d.Currency}
+	}
+	if d.ClosedAt != nil {
+		pb.ClosedAt = timestamppb.New(*d.ClosedAt)
+	}
+	for _, e := range evidence {
+		pb.Evidence = append(pb.Evidence, disputeEvidenceToProto(e))
+	}
+	return pb
+}
+
+func disputeEvidenceToProto(e db.DisputeEvidence) *paymentpb.DisputeEvidence {
+	return &paymentpb.DisputeEvidence{
+		Id:          e.ID.String(),
+		DisputeId:   e.DisputeID.String(),
+		Kind:        e.Kind,
+		FileName:    e.FileName,
+		ContentType: e.ContentType,
+		SizeBytes:   e.SizeBytes,
+		Description: e.Description,
+		CreatedAt:   timestamppb.New(e.CreatedAt),
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func testDispute(payment db.Payment, st paymentpb.DisputeStatus) db.Dispute {
+	return db.Dispute{
+		ID:                uuid.New(),
+		PaymentID:         payment.ID,
+		OrderID:           payment.OrderID,
+		ProviderDisputeID: "dp_1",
+		Status:            st.String(),
+		Reason:            "fraudulent",
+		AmountMinor:       payment.AmountMinor,
+		Currency:          payment.Currency,
+		EvidenceDueBy:     time.Now().Add(72 * time.Hour),
+	}
+}
+
+// captureDisputeEvent returns the data of the payment event queued for
+// merchant webhooks
+func captureDisputeEvent(mockQueries *MockQuerier) *PaymentEvent {
+	var event PaymentEvent
+	mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(1).(db.EnqueueWebhookDeliveriesParams).Payload, &event)
+		}).
+		Return(0, nil)
+	return &event
+}
+
+func TestWebhookService_DisputeCreated(t *testing.T) {
+	payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+	dueBy := time.Date(2026, 11, 1, 23, 59, 0, 0, time.UTC)
+	event := WebhookEvent{ID: "evt_1", Type: "dispute.created", Data: map[string]interface{}{
+		"payment_id":      payment.ID.String(),
+		"dispute_id":      "dp_1",
+		"reason":          "fraudulent",
+		"amount":          float64(3000),
+		"evidence_due_by": dueBy.Format(time.RFC3339),
+	}}
+
+	t.Run("records the dispute and flags the order", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE)
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("CreateDispute", mock.Anything, db.CreateDisputeParams{
+			PaymentID:         payment.ID,
+			ProviderDisputeID: "dp_1",
+			Reason:            "fraudulent",
+			AmountMinor:       3000,
+			Currency:          "JPY",
+			EvidenceDueBy:     dueBy,
+		}).Return(dispute, true, nil)
+		mockQueries.On("CountOpenDisputesByOrderID", mock.Anything, payment.OrderID).Return(1, nil)
+		published := captureDisputeEvent(mockQueries)
+
+		require.NoError(t, webhooks.dispatch(context.Background(), event))
+
+		assert.Equal(t, PaymentEventDisputeOpened, published.EventType)
+		assert.Equal(t, true, published.Data["order_disputed"])
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("ignores a dispute already recorded", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("CreateDispute", mock.Anything, mock.Anything).
+			Return(testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW), false, nil)
+
+		require.NoError(t, webhooks.dispatch(context.Background(), event))
+
+		mockQueries.AssertNotCalled(t, "EnqueueWebhookDeliveries", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_DisputeClosed(t *testing.T) {
+	payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+	closedEvent := func(outcome string) WebhookEvent {
+		return WebhookEvent{ID: uuid.New().String(), Type: "dispute.closed", Data: map[string]interface{}{
+			"dispute_id": "dp_1",
+			"status":     outcome,
+		}}
+	}
+
+	t.Run("a lost dispute records an adjustment and charges the payment back", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW)
+		closed := dispute
+		closed.Status = paymentpb.DisputeStatus_DISPUTE_STATUS_LOST.String()
+		adjustment := -dispute.AmountMinor
+		closed.AdjustmentMinor = &adjustment
+
+		mockQueries.On("GetDisputeByProviderID", mock.Anything, "dp_1").Return(dispute, nil)
+		var params db.CloseDisputeParams
+		mockQueries.On("CloseDispute", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { params = args.Get(1).(db.CloseDisputeParams) }).
+			Return(closed, true, nil)
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.Status == "PAYMENT_STATUS_CHARGED_BACK"
+		})).Return(nil)
+		mockQueries.On("CountOpenDisputesByOrderID", mock.Anything, payment.OrderID).Return(0, nil)
+		published := captureDisputeEvent(mockQueries)
+
+		require.NoError(t, webhooks.dispatch(context.Background(), closedEvent("lost")))
+
+		require.NotNil(t, params.AdjustmentMinor)
+		assert.Equal(t, 5000, *params.AdjustmentMinor)
+		assert.Equal(t, PaymentEventDisputeClosed, published.EventType)
+		assert.Equal(t, "PAYMENT_STATUS_CHARGED_BACK", published.Status)
+		assert.Equal(t, false, published.Data["order_disputed"])
+		assert.Equal(t, float64(-5000), published.Data["adjustment"])
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("a won dispute leaves the payment alone", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW)
+		closed := dispute
+		closed.Status = paymentpb.DisputeStatus_DISPUTE_STATUS_WON.String()
+
+		mockQueries.On("GetDisputeByProviderID", mock.Anything, "dp_1").Return(dispute, nil)
+		mockQueries.On("CloseDispute", mock.Anything, db.CloseDisputeParams{
+			ID:     dispute.ID,
+			Status: "DISPUTE_STATUS_WON",
+		}).Return(closed, true, nil)
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("CountOpenDisputesByOrderID", mock.Anything, payment.OrderID).Return(0, nil)
+		published := captureDisputeEvent(mockQueries)
+
+		require.NoError(t, webhooks.dispatch(context.Background(), closedEvent("won")))
+
+		assert.Equal(t, "PAYMENT_STATUS_COMPLETED", published.Status)
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a dispute closed earlier is ignored", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_LOST)
+
+		mockQueries.On("GetDisputeByProviderID", mock.Anything, "dp_1").Return(dispute, nil)
+		mockQueries.On("CloseDispute", mock.Anything, mock.Anything).Return(dispute, false, nil)
+
+		require.NoError(t, webhooks.dispatch(context.Background(), closedEvent("lost")))
+
+		mockQueries.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an open status", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		mockQueries.On("GetDisputeByProviderID", mock.Anything, "dp_1").
+			Return(testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW), nil)
+
+		assert.Error(t, webhooks.dispatch(context.Background(), closedEvent("under_review")))
+	})
+}
+
+func TestPaymentService_AddDisputeEvidence(t *testing.T) {
+	payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), AmountMinor: 5000, Currency: "JPY"}
+	validRequest := func(disputeID uuid.UUID) *paymentpb.AddDisputeEvidenceRequest {
+		return &paymentpb.AddDisputeEvidenceRequest{
+			DisputeId:   disputeID.String(),
+			Kind:        "shipping_proof",
+			FileName:    "delivery.pdf",
+			ContentType: "application/pdf",
+			Content:     []byte("%PDF-1.4"),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *paymentpb.AddDisputeEvidenceRequest, d *db.Dispute)
+		code   codes.Code
+	}{
+		{"unknown kind", func(req *paymentpb.AddDisputeEvidenceRequest, _ *db.Dispute) { req.Kind = "selfie" }, codes.InvalidArgument},
+		{"unsupported content type", func(req *paymentpb.AddDisputeEvidenceRequest, _ *db.Dispute) { req.ContentType = "application/zip" }, codes.InvalidArgument},
+		{"empty document", func(req *paymentpb.AddDisputeEvidenceRequest, _ *db.Dispute) { req.Content = nil }, codes.InvalidArgument},
+		{"document too large", func(req *paymentpb.AddDisputeEvidenceRequest, _ *db.Dispute) {
+			req.Content = make([]byte, maxDisputeEvidenceBytes+1)
+		}, codes.InvalidArgument},
+		{"dispute under review", func(_ *paymentpb.AddDisputeEvidenceRequest, d *db.Dispute) {
+			d.Status = paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW.String()
+		}, codes.FailedPrecondition},
+		{"deadline passed", func(_ *paymentpb.AddDisputeEvidenceRequest, d *db.Dispute) {
+			d.EvidenceDueBy = time.Now().Add(-time.Minute)
+		}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueries := new(MockQuerier)
+			s := newTestPaymentService(mockQueries)
+			dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE)
+			req := validRequest(dispute.ID)
+			tt.modify(req, &dispute)
+			mockQueries.On("GetDispute", mock.Anything, dispute.ID).Return(dispute, nil).Maybe()
+
+			_, err := s.AddDisputeEvidence(context.Background(), req)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			mockQueries.AssertNotCalled(t, "AddDisputeEvidence", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("attaches the document", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE)
+
+		mockQueries.On("GetDispute", mock.Anything, dispute.ID).Return(dispute, nil)
+		mockQueries.On("ListDisputeEvidence", mock.Anything, dispute.ID, false).Return(nil, nil)
+		mockQueries.On("AddDisputeEvidence", mock.Anything, mock.Anything).Return(db.DisputeEvidence{
+			ID: uuid.New(), DisputeID: dispute.ID, Kind: "shipping_proof", FileName: "delivery.pdf",
+			ContentType: "application/pdf", SizeBytes: 8,
+		}, nil)
+
+		resp, err := s.AddDisputeEvidence(context.Background(), validRequest(dispute.ID))
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(8), resp.Evidence.SizeBytes)
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestPaymentService_SubmitDisputeEvidence(t *testing.T) {
+	payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), AmountMinor: 5000, Currency: "JPY"}
+
+	t.Run("requires evidence", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE)
+
+		mockQueries.On("GetDispute", mock.Anything, dispute.ID).Return(dispute, nil)
+		mockQueries.On("ListDisputeEvidence", mock.Anything, dispute.ID, true).Return(nil, nil)
+
+		_, err := s.SubmitDisputeEvidence(context.Background(), &paymentpb.SubmitDisputeEvidenceRequest{DisputeId: dispute.ID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "MarkDisputeEvidenceSubmitted", mock.Anything, mock.Anything)
+	})
+
+	t.Run("puts the dispute under review", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		dispute := testDispute(payment, paymentpb.DisputeStatus_DISPUTE_STATUS_NEEDS_RESPONSE)
+		submitted := dispute
+		submitted.Status = paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW.String()
+
+		mockQueries.On("GetDispute", mock.Anything, dispute.ID).Return(dispute, nil)
+		mockQueries.On("ListDisputeEvidence", mock.Anything, dispute.ID, true).Return([]db.DisputeEvidence{
+			{ID: uuid.New(), DisputeID: dispute.ID, Kind: "receipt", Content: []byte("receipt")},
+		}, nil)
+		mockQueries.On("MarkDisputeEvidenceSubmitted", mock.Anything, dispute.ID).Return(submitted, nil)
+
+		resp, err := s.SubmitDisputeEvidence(context.Background(), &paymentpb.SubmitDisputeEvidenceRequest{DisputeId: dispute.ID.String()})
+		require.NoError(t, err)
+
+		assert.Equal(t, paymentpb.DisputeStatus_DISPUTE_STATUS_UNDER_REVIEW, resp.Dispute.Status)
+		assert.Len(t, resp.Dispute.Evidence, 1)
+	})
+}
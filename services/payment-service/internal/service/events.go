@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Payment event types consumed by other services
+const (
+	PaymentEventAuthorized = "payment.authorized"
+	PaymentEventCompleted  = "payment.completed"
+	PaymentEventFailed     = "payment.failed"
+	PaymentEventRefunded   = "payment.refunded"
+	PaymentEventExpired    = "payment.expired"
+
+	PaymentEventDisputeOpened = "payment.dispute_opened"
+	PaymentEventDisputeClosed = "payment.dispute_closed"
+)
+
+// PaymentEventPublisher publishes payment-related events to Kafka
+type PaymentEventPublisher struct {
+	producer sarama.SyncProducer
+	topic    string
+	logger   *zap.Logger
+}
+
+// NewPaymentEventProducer creates a new payment event publisher
+func NewPaymentEventProducer(
+	brokers []string,
+	topic string,
+	logger *zap.Logger,
+) (*PaymentEventPublisher, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+
+	return &PaymentEventPublisher{
+		producer: producer,
+		topic:    topic,
+		logger:   logger,
+	}, nil
+}
+
+// Close closes the Kafka producer
+func (p *PaymentEventPublisher) Close() error {
+	return p.producer.Close()
+}
+
+// PaymentEvent represents a payment event
+type PaymentEvent struct {
+	EventID   string                 `json:"event_id"`
+	EventType string                 `json:"event_type"`
+	PaymentID string                 `json:"payment_id"`
+	OrderID   string                 `json:"order_id"`
+	Status    string                 `json:"status"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// PublishPaymentEvent publishes a payment event of the given type
+func (p *PaymentEventPublisher) PublishPaymentEvent(
+	ctx context.Context,
+	eventType string,
+	paymentID, orderID string,
+	status string,
+	data map[string]interface{},
+) error {
+	event := PaymentEvent{
+		EventID:   uuid.New().String(),
+		EventType: eventType,
+		PaymentID: paymentID,
+		OrderID:   orderID,
+		Status:    status,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+
+	return p.Publish(ctx, event)
+}
+
+// Publish sends an event to Kafka keyed by order so per-order ordering holds
+func (p *PaymentEventPublisher) Publish(ctx context.Context, event PaymentEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	message := &sarama.ProducerMessage{
+		Topic:     p.topic,
+		Key:       sarama.StringEncoder(event.OrderID),
+		Value:     sarama.ByteEncoder(data),
+		Timestamp: time.Now(),
+	}
+
+	partition, offset, err := p.producer.SendMessage(message)
+	if err != nil {
+		p.logger.Error("Failed to publish payment event",
+			zap.String("event_type", event.EventType),
+			zap.String("payment_id", event.PaymentID),
+			zap.Error(err))
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	p.logger.Info("Published payment event",
+		zap.String("event_type", event.EventType),
+		zap.String("payment_id", event.PaymentID),
+		zap.Int32("partition", partition),
+		zap.Int64("offset", offset))
+
+	return nil
+}
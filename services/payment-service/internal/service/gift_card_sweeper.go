@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GiftCardExpirySweeper expires gift cards past their date, writing off
+// their balance, and forgets old failed code lookups
+type GiftCardExpirySweeper struct {
+	payments *PaymentService
+	logger   *zap.Logger
+}
+
+// NewGiftCardExpirySweeper creates a new gift card expiry sweeper
+func NewGiftCardExpirySweeper(payments *PaymentService, logger *zap.Logger) *GiftCardExpirySweeper {
+	return &GiftCardExpirySweeper{
+		payments: payments,
+		logger:   logger,
+	}
+}
+
+// ExpireDue expires every active card whose expiry has passed
+func (w *GiftCardExpirySweeper) ExpireDue(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	if deleted, err := w.payments.queries.DeleteGiftCardLookupFailures(ctx, now.Add(-giftCardLookupFailureRetention)); err != nil {
+		w.logger.Warn("Failed to delete old gift card lookup failures", zap.Error(err))
+	} else if deleted > 0 {
+		w.logger.Debug("Deleted old gift card lookup failures", zap.Int64("deleted", deleted))
+	}
+
+	due, err := w.payments.queries.ListExpiredGiftCards(ctx, now, sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, card := range due {
+		ok, err := w.payments.queries.ExpireGiftCard(ctx, card.ID)
+		if err != nil {
+			w.logger.Warn("Failed to expire gift card",
+				zap.String("gift_card_id", card.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+
+	if len(due) > 0 {
+		w.logger.Info("Expired gift cards",
+			zap.Int("found", len(due)),
+			zap.Int("expired", expired))
+	}
+
+	return expired, nil
+}
+
+// StartPeriodicSweep starts expiring gift cards in the background
+func (w *GiftCardExpirySweeper) StartPeriodicSweep(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic gift card expiry sweep", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.ExpireDue(ctx); err != nil {
+					w.logger.Error("Periodic gift card expiry sweep failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
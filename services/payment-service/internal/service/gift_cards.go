@@ -0,0 +1,634 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+const (
+	// giftCardCodeAlphabet leaves out 0, 1, I and O, which are easily
+	// misread on a printed card. Its 32 characters make the check character
+	// a Luhn mod 32 digit.
+	giftCardCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	giftCardCodeLength   = 16
+
+	defaultGiftCardValidityDays    = 3 * 365
+	defaultStoreCreditValidityDays = 365
+
+	// maxGiftCardLookupFailures failed lookups within giftCardLookupWindow
+	// block a client from looking up codes until the oldest falls out of
+	// the window
+	maxGiftCardLookupFailures = 5
+	giftCardLookupWindow      = 15 * time.Minute
+
+	// giftCardLookupFailureRetention is how long failed lookups are kept
+	// before the sweeper deletes them
+	giftCardLookupFailureRetention = 24 * time.Hour
+
+	maxGiftCardIssueAttempts = 3
+	defaultGiftCardsPageSize = 20
+)
+
+// errGiftCardNotFound is returned for malformed, mistyped and unknown codes
+// alike, so that lookups do not tell a guesser how close they came
+var errGiftCardNotFound = status.Error(codes.NotFound, "gift card not found")
+
+// GiftCardConfig holds the key gift card codes are hashed with. Codes are
+// only stored hashed, so changing the key makes every issued code unusable.
+type GiftCardConfig struct {
+	CodeSecret string
+}
+
+// SetGiftCardConfig enables gift cards (optional)
+func (s *PaymentService) SetGiftCardConfig(cfg GiftCardConfig) {
+	s.giftCards = cfg
+}
+
+// IssueGiftCard creates a gift card, or store credit for a customer. The
+// code is returned only here; afterwards only its last four characters are
+// known.
+func (s *PaymentService) IssueGiftCard(ctx context.Context, req *paymentpb.IssueGiftCardRequest) (*paymentpb.IssueGiftCardResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.IssueGiftCard",
+		trace.WithAttributes(attribute.String("gift_card.type", req.Type.String())),
+	)
+	defer span.End()
+
+	if req.Type == paymentpb.GiftCardType_GIFT_CARD_TYPE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "type is required")
+	}
+	if req.Amount == nil || req.Amount.Units <= 0 || req.Amount.Currency == "" {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	if req.ValidDays < 0 {
+		return nil, status.Error(codes.InvalidArgument, "valid_days must not be negative")
+	}
+
+	var userID *uuid.UUID
+	if req.UserId != "" {
+		id, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+		userID = &id
+	}
+	if req.Type == paymentpb.GiftCardType_GIFT_CARD_TYPE_STORE_CREDIT && userID == nil {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required for store credit")
+	}
+
+	card, code, err := s.issueGiftCard(ctx, db.CreateGiftCardParams{
+		Type:        req.Type.String(),
+		AmountMinor: int(req.Amount.Units),
+		Currency:    req.Amount.Currency,
+		UserID:      userID,
+		ExpiresAt:   giftCardExpiry(time.Now(), req.Type, int(req.ValidDays)),
+		Note:        req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Issued gift card",
+		zap.String("gift_card_id", card.ID.String()),
+		zap.String("type", card.Type),
+		zap.Int("amount", card.InitialAmountMinor))
+
+	return &paymentpb.IssueGiftCardResponse{
+		GiftCard: giftCardToProto(card),
+		Code:     formatGiftCardCode(code),
+	}, nil
+}
+
+// CheckGiftCardBalance looks a card up by its code. Failed lookups count
+// against the caller's client key.
+func (s *PaymentService) CheckGiftCardBalance(ctx context.Context, req *paymentpb.CheckGiftCardBalanceRequest) (*paymentpb.CheckGiftCardBalanceResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.CheckGiftCardBalance")
+	defer span.End()
+
+	if req.ClientKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_key is required")
+	}
+
+	card, err := s.lookupGiftCard(ctx, req.ClientKey, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	pb := giftCardToProto(card)
+	// Anyone holding the code may check it; whose credit it is stays private
+	pb.UserId = ""
+	return &paymentpb.CheckGiftCardBalanceResponse{GiftCard: pb}, nil
+}
+
+// GetGiftCard returns a card with its ledger
+func (s *PaymentService) GetGiftCard(ctx context.Context, req *paymentpb.GetGiftCardRequest) (*paymentpb.GetGiftCardResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.GetGiftCard",
+		trace.WithAttributes(attribute.String("gift_card.id", req.GiftCardId)),
+	)
+	defer span.End()
+
+	card, err := s.getGiftCard(ctx, req.GiftCardId)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.queries.ListGiftCardTransactions(ctx, card.ID)
+	if err != nil {
+		s.logger.Error("Failed to list gift card transactions", zap.String("gift_card_id", req.GiftCardId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get gift card")
+	}
+
+	resp := &paymentpb.GetGiftCardResponse{
+		GiftCard:     giftCardToProto(card),
+		Transactions: make([]*paymentpb.GiftCardTransaction, len(transactions)),
+	}
+	for i, t := range transactions {
+		resp.Transactions[i] = giftCardTransactionToProto(t)
+	}
+	return resp, nil
+}
+
+// ListGiftCards returns cards, newest first, optionally only those of one
+// customer or of one type
+func (s *PaymentService) ListGiftCards(ctx context.Context, req *paymentpb.ListGiftCardsRequest) (*paymentpb.ListGiftCardsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListGiftCards",
+		trace.WithAttributes(attribute.String("gift_card.user_id", req.UserId)),
+	)
+	defer span.End()
+
+	page, limit := int32(1), int32(defaultGiftCardsPageSize)
+	if req.Pagination != nil {
+		if req.Pagination.Page > 0 {
+			page = req.Pagination.Page
+		}
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	params := db.ListGiftCardsParams{
+		Limit:  int(limit),
+		Offset: int((page - 1) * limit),
+	}
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+		params.UserID = &userID
+	}
+	if req.Type != paymentpb.GiftCardType_GIFT_CARD_TYPE_UNSPECIFIED {
+		t := req.Type.String()
+		params.Type = &t
+	}
+
+	cards, total, err := s.queries.ListGiftCards(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list gift cards", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list gift cards")
+	}
+
+	pbCards := make([]*paymentpb.GiftCard, len(cards))
+	for i, c := range cards {
+		pbCards[i] = giftCardToProto(c)
+	}
+
+	return &paymentpb.ListGiftCardsResponse{
+		GiftCards:  pbCards,
+		Pagination: &sharedpb.Pagination{Page: page, Limit: limit, Total: int32(total)},
+	}, nil
+}
+
+// DisableGiftCard stops a card from being spent, e.g. when it was reported
+// stolen. Its balance is kept. Disabling a disabled card does nothing.
+func (s *PaymentService) DisableGiftCard(ctx context.Context, req *paymentpb.DisableGiftCardRequest) (*paymentpb.DisableGiftCardResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.DisableGiftCard",
+		trace.WithAttributes(attribute.String("gift_card.id", req.GiftCardId)),
+	)
+	defer span.End()
+
+	id, err := uuid.Parse(req.GiftCardId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid gift_card_id")
+	}
+
+	card, disabled, err := s.queries.DisableGiftCard(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "gift card not found")
+		}
+		s.logger.Error("Failed to disable gift card", zap.String("gift_card_id", req.GiftCardId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to disable gift card")
+	}
+	if !disabled && card.Status == paymentpb.GiftCardStatus_GIFT_CARD_STATUS_EXPIRED.String() {
+		return nil, status.Error(codes.FailedPrecondition, "gift card has expired")
+	}
+	if disabled {
+		s.logger.Info("Disabled gift card",
+			zap.String("gift_card_id", card.ID.String()),
+			zap.String("reason", req.Reason))
+	}
+
+	return &paymentpb.DisableGiftCardResponse{GiftCard: giftCardToProto(card)}, nil
+}
+
+// processGiftCard pays with a gift card, given by its code (gift_card_code),
+// or with a card registered to the customer, given by its ID
+// (gift_card_id) as for store credit. The card must cover the whole payment;
+// to spend what is left on a card, pay that much with it as one tender of
+// the order.
+func (s *PaymentService) processGiftCard(ctx context.Context, payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string, error) {
+	userID, err := s.orderCustomer(ctx, payment)
+	if err != nil {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", err
+	}
+
+	var card db.GiftCard
+	switch {
+	case paymentData["gift_card_code"] != "":
+		card, err = s.lookupGiftCard(ctx, "user:"+userID.String(), paymentData["gift_card_code"])
+	case paymentData["gift_card_id"] != "":
+		card, err = s.getGiftCard(ctx, paymentData["gift_card_id"])
+		if err == nil && (card.UserID == nil || *card.UserID != userID) {
+			err = errGiftCardNotFound
+		}
+	default:
+		err = status.Error(codes.InvalidArgument, "gift_card_code or gift_card_id is required")
+	}
+	if err != nil {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", err
+	}
+
+	if card.Currency != payment.Currency {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Errorf(codes.InvalidArgument, "gift card is in %s", card.Currency)
+	}
+
+	cardID := card.ID
+	card, t, err := s.queries.AdjustGiftCardBalance(ctx, db.AdjustGiftCardBalanceParams{
+		GiftCardID:  cardID,
+		Kind:        paymentpb.GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_REDEEM.String(),
+		AmountMinor: -payment.AmountMinor,
+		PaymentID:   &payment.ID,
+	})
+	switch {
+	case errors.Is(err, db.ErrGiftCardNotUsable):
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.FailedPrecondition, "gift card is disabled or expired")
+	case errors.Is(err, db.ErrInsufficientGiftCardBalance):
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", &giftCardBalanceError{card: card, amount: payment.AmountMinor}
+	case err != nil:
+		s.logger.Error("Failed to redeem gift card", zap.String("gift_card_id", cardID.String()), zap.Error(err))
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Internal, "failed to redeem gift card")
+	}
+
+	s.logger.Info("Redeemed gift card",
+		zap.String("payment_id", payment.ID.String()),
+		zap.String("gift_card_id", card.ID.String()),
+		zap.Int("balance", card.BalanceMinor))
+
+	transactionID := fmt.Sprintf("GIFTCARD-%s-%d", t.ID.String()[:8], time.Now().Unix())
+	return paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, transactionID, nil
+}
+
+// giftCardBalanceError is a payment larger than the balance of its gift
+// card. It is a FailedPrecondition that reports the balance.
+type giftCardBalanceError struct {
+	card   db.GiftCard
+	amount int
+}
+
+func (e *giftCardBalanceError) Error() string {
+	return fmt.Sprintf("gift card balance is %d %s, less than the %d %s to pay",
+		e.card.BalanceMinor, e.card.Currency, e.amount, e.card.Currency)
+}
+
+func (e *giftCardBalanceError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// giftCardBalanceAfterRollback re-reads the card of a balance error once
+// the other tenders of an order have been given back. The balance the error
+// was raised with still had the card's earlier tenders taken off.
+func (s *PaymentService) giftCardBalanceAfterRollback(ctx context.Context, err error) error {
+	var balanceErr *giftCardBalanceError
+	if !errors.As(err, &balanceErr) {
+		return err
+	}
+
+	card, getErr := s.queries.GetGiftCard(ctx, balanceErr.card.ID)
+	if getErr != nil {
+		s.logger.Warn("Failed to get gift card balance", zap.String("gift_card_id", balanceErr.card.ID.String()), zap.Error(getErr))
+		return err
+	}
+	return &giftCardBalanceError{card: card, amount: balanceErr.amount}
+}
+
+// refundToGiftCard credits a refund back to the card a payment was made
+// with. A card that has since expired or been disabled cannot take it, and
+// the refund has to go to store credit instead.
+func (s *PaymentService) refundToGiftCard(ctx context.Context, payment db.Payment, refund db.Refund) (string, error) {
+	transactions, err := s.queries.ListGiftCardTransactionsByPaymentID(ctx, payment.ID)
+	if err != nil {
+		s.logger.Error("Failed to list gift card transactions", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return "", status.Error(codes.Internal, "failed to find gift card")
+	}
+	var cardID uuid.UUID
+	for _, t := range transactions {
+		if t.Kind == paymentpb.GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_REDEEM.String() {
+			cardID = t.GiftCardID
+		}
+	}
+	if cardID == uuid.Nil {
+		return "", status.Error(codes.FailedPrecondition, "payment has no gift card redemption")
+	}
+
+	_, _, err = s.queries.AdjustGiftCardBalance(ctx, db.AdjustGiftCardBalanceParams{
+		GiftCardID:  cardID,
+		Kind:        paymentpb.GiftCardTransactionKind_GIFT_CARD_TRANSACTION_KIND_REFUND.String(),
+		AmountMinor: refund.AmountMinor,
+		PaymentID:   &payment.ID,
+		RefundID:    &refund.ID,
+	})
+	if errors.Is(err, db.ErrGiftCardNotUsable) {
+		return "", status.Error(codes.FailedPrecondition, "gift card is disabled or expired; refund to store credit instead")
+	}
+	if err != nil {
+		s.logger.Error("Failed to credit gift card", zap.String("gift_card_id", cardID.String()), zap.Error(err))
+		return "", status.Error(codes.Internal, "failed to credit gift card")
+	}
+	return cardID.String(), nil
+}
+
+// refundToStoreCredit issues the refunded amount as store credit for the
+// customer who placed the order
+func (s *PaymentService) refundToStoreCredit(ctx context.Context, payment db.Payment, refund db.Refund, userID uuid.UUID) (string, error) {
+	card, _, err := s.issueGiftCard(ctx, db.CreateGiftCardParams{
+		Type:        paymentpb.GiftCardType_GIFT_CARD_TYPE_STORE_CREDIT.String(),
+		AmountMinor: refund.AmountMinor,
+		Currency:    refund.Currency,
+		UserID:      &userID,
+		ExpiresAt:   giftCardExpiry(time.Now(), paymentpb.GiftCardType_GIFT_CARD_TYPE_STORE_CREDIT, 0),
+		RefundID:    &refund.ID,
+		Note:        "refund of payment " + payment.ID.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return card.ID.String(), nil
+}
+
+// issueGiftCard stores a card under a fresh code and returns the code
+func (s *PaymentService) issueGiftCard(ctx context.Context, arg db.CreateGiftCardParams) (db.GiftCard, string, error) {
+	if s.giftCards.CodeSecret == "" {
+		return db.GiftCard{}, "", status.Error(codes.FailedPrecondition, "gift cards are not available")
+	}
+
+	for i := 0; i < maxGiftCardIssueAttempts; i++ {
+		code, err := generateGiftCardCode()
+		if err != nil {
+			s.logger.Error("Failed to generate gift card code", zap.Error(err))
+			return db.GiftCard{}, "", status.Error(codes.Internal, "failed to issue gift card")
+		}
+
+		arg.CodeHash = s.hashGiftCardCode(code)
+		arg.CodeLast4 = code[len(code)-4:]
+		card, err := s.queries.CreateGiftCard(ctx, arg)
+		if errors.Is(err, db.ErrDuplicateGiftCardCode) {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Failed to create gift card", zap.Error(err))
+			return db.GiftCard{}, "", status.Error(codes.Internal, "failed to issue gift card")
+		}
+		return card, code, nil
+	}
+	return db.GiftCard{}, "", status.Error(codes.Internal, "failed to issue gift card")
+}
+
+// lookupGiftCard finds a card by its code. Codes with a wrong check
+// character are turned away before the database is asked, and every failed
+// lookup counts against clientKey.
+func (s *PaymentService) lookupGiftCard(ctx context.Context, clientKey, input string) (db.GiftCard, error) {
+	if s.giftCards.CodeSecret == "" {
+		return db.GiftCard{}, status.Error(codes.FailedPrecondition, "gift cards are not available")
+	}
+
+	failures, err := s.queries.CountGiftCardLookupFailures(ctx, clientKey, time.Now().Add(-giftCardLookupWindow))
+	if err != nil {
+		s.logger.Error("Failed to count gift card lookup failures", zap.Error(err))
+		return db.GiftCard{}, status.Error(codes.Internal, "failed to look up gift card")
+	}
+	if failures >= maxGiftCardLookupFailures {
+		return db.GiftCard{}, status.Error(codes.ResourceExhausted, "too many failed gift card lookups, try again later")
+	}
+
+	code := normalizeGiftCardCode(input)
+	var card db.GiftCard
+	if validGiftCardCode(code) {
+		card, err = s.queries.GetGiftCardByCodeHash(ctx, s.hashGiftCardCode(code))
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Error("Failed to get gift card", zap.Error(err))
+			return db.GiftCard{}, status.Error(codes.Internal, "failed to look up gift card")
+		}
+	} else {
+		err = pgx.ErrNoRows
+	}
+	if err != nil {
+		if err := s.queries.RecordGiftCardLookupFailure(ctx, clientKey); err != nil {
+			s.logger.Warn("Failed to record gift card lookup failure", zap.Error(err))
+		}
+		s.logger.Info("Gift card lookup failed", zap.String("client_key", clientKey), zap.Int("failures", failures+1))
+		return db.GiftCard{}, errGiftCardNotFound
+	}
+	return card, nil
+}
+
+func (s *PaymentService) getGiftCard(ctx context.Context, giftCardID string) (db.GiftCard, error) {
+	id, err := uuid.Parse(giftCardID)
+	if err != nil {
+		return db.GiftCard{}, status.Error(codes.InvalidArgument, "invalid gift_card_id")
+	}
+
+	card, err := s.queries.GetGiftCard(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.GiftCard{}, errGiftCardNotFound
+		}
+		s.logger.Error("Failed to get gift card", zap.String("gift_card_id", giftCardID), zap.Error(err))
+		return db.GiftCard{}, status.Error(codes.Internal, "failed to get gift card")
+	}
+	return card, nil
+}
+
+func (s *PaymentService) hashGiftCardCode(code string) string {
+	mac := hmac.New(sha256.New, []byte(s.giftCards.CodeSecret))
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// redactGiftCardCode returns payment data safe to store: a gift card code
+// is cut down to its last four characters
+func redactGiftCardCode(paymentData map[string]string) map[string]string {
+	code, ok := paymentData["gift_card_code"]
+	if !ok {
+		return paymentData
+	}
+
+	redacted := make(map[string]string, len(paymentData))
+	for k, v := range paymentData {
+		redacted[k] = v
+	}
+	normalized := normalizeGiftCardCode(code)
+	if len(normalized) > 4 {
+		normalized = normalized[len(normalized)-4:]
+	}
+	redacted["gift_card_code"] = "****" + normalized
+	return redacted
+}
+
+// giftCardExpiry returns when a card issued now expires: at the end of the
+// last valid day in Japan
+func giftCardExpiry(now time.Time, cardType paymentpb.GiftCardType, validDays int) time.Time {
+	if validDays <= 0 {
+		validDays = defaultGiftCardValidityDays
+		if cardType == paymentpb.GiftCardType_GIFT_CARD_TYPE_STORE_CREDIT {
+			validDays = defaultStoreCreditValidityDays
+		}
+	}
+	y, m, d := now.In(tokyo).Date()
+	return time.Date(y, m, d+validDays+1, 0, 0, 0, 0, tokyo).UTC()
+}
+
+// generateGiftCardCode returns 15 random characters followed by their check
+// character. Codes that could be mistaken for a card number are drawn again,
+// as they would be refused as raw card data.
+func generateGiftCardCode() (string, error) {
+	buf := make([]byte, giftCardCodeLength-1)
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		code := make([]byte, 0, giftCardCodeLength)
+		for _, b := range buf {
+			// 256 is a multiple of 32, so every character is equally likely
+			code = append(code, giftCardCodeAlphabet[int(b)%len(giftCardCodeAlphabet)])
+		}
+		code = append(code, giftCardCheckCharacter(string(code)))
+		if !containsCardNumber(formatGiftCardCode(string(code))) {
+			return string(code), nil
+		}
+	}
+}
+
+// giftCardCheckCharacter computes the Luhn mod 32 check character of a code
+func giftCardCheckCharacter(code string) byte {
+	n := len(giftCardCodeAlphabet)
+	factor, sum := 2, 0
+	for i := len(code) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(giftCardCodeAlphabet, code[i])
+		factor = 3 - factor
+		sum += addend/n + addend%n
+	}
+	return giftCardCodeAlphabet[(n-sum%n)%n]
+}
+
+// validGiftCardCode checks a normalized code's length, characters and check
+// character
+func validGiftCardCode(code string) bool {
+	if len(code) != giftCardCodeLength {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if strings.IndexByte(giftCardCodeAlphabet, code[i]) < 0 {
+			return false
+		}
+	}
+	return giftCardCheckCharacter(code[:len(code)-1]) == code[len(code)-1]
+}
+
+// normalizeGiftCardCode uppercases a code as typed by a customer and drops
+// separators. Full-width characters, as typed with a Japanese IME, are read
+// as their ASCII equivalents.
+func normalizeGiftCardCode(input string) string {
+	var b strings.Builder
+	for _, r := range input {
+		if r >= '！' && r <= '～' {
+			r -= '！' - '!'
+		}
+		switch r {
+		case '-', ' ', '　', 'ー', '‐', '−':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// formatGiftCardCode groups a code in fours, as printed on the card
+func formatGiftCardCode(code string) string {
+	var groups []string
+	for i := 0; i < len(code); i += 4 {
+		end := i + 4
+		if end > len(code) {
+			end = len(code)
+		}
+		groups = append(groups, code[i:end])
+	}
+	return strings.Join(groups, "-")
+}
+
+func giftCardToProto(c db.GiftCard) *paymentpb.GiftCard {
+	pb := &paymentpb.GiftCard{
+		Id:             c.ID.String(),
+		Type:           paymentpb.GiftCardType(paymentpb.GiftCardType_value[c.Type]),
+		Status:         paymentpb.GiftCardStatus(paymentpb.GiftCardStatus_value[c.Status]),
+		CodeLast4:      c.CodeLast4,
+		InitialBalance: &sharedpb.Money{Units: int64(c.InitialAmountMinor), Currency: c.Currency},
+		Balance:        &sharedpb.Money{Units: int64(c.BalanceMinor), Currency: c.Currency},
+		ExpiresAt:      timestamppb.New(c.ExpiresAt),
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		UpdatedAt:      timestamppb.New(c.UpdatedAt),
+	}
+	if c.UserID != nil {
+		pb.UserId = c.UserID.String()
+	}
+	return pb
+}
+
+func giftCardTransactionToProto(t db.GiftCardTransaction) *paymentpb.GiftCardTransaction {
+	pb := &paymentpb.GiftCardTransaction{
+		Id:           t.ID.String(),
+		GiftCardId:   t.GiftCardID.String(),
+		Kind:         paymentpb.GiftCardTransactionKind(paymentpb.GiftCardTransactionKind_value[t.Kind]),
+		Amount:       &sharedpb.Money{Units: int64(t.AmountMinor), Currency: t.Currency},
+		BalanceAfter: &sharedpb.Money{Units: int64(t.BalanceAfterMinor), Currency: t.Currency},
+		Note:         t.Note,
+		CreatedAt:    timestamppb.New(t.CreatedAt),
+	}
+	if t.PaymentID != nil {
+		pb.PaymentId = t.PaymentID.String()
+	}
+	if t.RefundID != nil {
+		pb.RefundId = t.RefundID.String()
+	}
+	return pb
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func customerOrder() *orderpb.Order {
+	order := pendingOrder(5000)
+	order.UserId = uuid.New().String()
+	return order
+}
+
+func TestGiftCardCode(t *testing.T) {
+	t.Run("generated codes carry a valid check character", func(t *testing.T) {
+		for i := 0; i < 200; i++ {
+			code, err := generateGiftCardCode()
+			require.NoError(t, err)
+			require.Len(t, code, giftCardCodeLength)
+			assert.True(t, validGiftCardCode(code), code)
+			assert.False(t, containsCardNumber(formatGiftCardCode(code)), code)
+		}
+	})
+
+	t.Run("check character catches typos", func(t *testing.T) {
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+
+		for i := 0; i < len(code); i++ {
+			for _, c := range []byte(giftCardCodeAlphabet) {
+				if c == code[i] {
+					continue
+				}
+				typo := code[:i] + string(c) + code[i+1:]
+				assert.False(t, validGiftCardCode(typo), "substitution %s", typo)
+			}
+		}
+	})
+
+	t.Run("normalizes what customers type", func(t *testing.T) {
+		assert.Equal(t, "ABCD2345EFGH6789", normalizeGiftCardCode("abcd-2345 efgh-6789"))
+		assert.Equal(t, "ABCD2345EFGH6789", normalizeGiftCardCode("ＡＢＣＤ－２３４５　ｅｆｇｈー６７８９"))
+		assert.Equal(t, "ABCD-2345-EFGH-6789", formatGiftCardCode("ABCD2345EFGH6789"))
+	})
+
+	t.Run("rejects foreign characters", func(t *testing.T) {
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+		assert.False(t, validGiftCardCode("0"+code[1:]))
+		assert.False(t, validGiftCardCode(code[:15]))
+	})
+}
+
+func TestRedactGiftCardCode(t *testing.T) {
+	data := map[string]string{"gift_card_code": "abcd-2345-efgh-6789", "note": "gift"}
+
+	redacted := redactGiftCardCode(data)
+
+	assert.Equal(t, "****6789", redacted["gift_card_code"])
+	assert.Equal(t, "gift", redacted["note"])
+	assert.Equal(t, "abcd-2345-efgh-6789", data["gift_card_code"])
+}
+
+func TestPaymentService_CheckGiftCardBalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the balance without the owner", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withGiftCards())
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+		owner := uuid.New()
+		card := db.GiftCard{ID: uuid.New(), Type: "GIFT_CARD_TYPE_STORE_CREDIT", Status: "GIFT_CARD_STATUS_ACTIVE",
+			BalanceMinor: 1200, Currency: "JPY", UserID: &owner, ExpiresAt: time.Now().Add(time.Hour)}
+
+		mockQueries.On("CountGiftCardLookupFailures", mock.Anything, "user-1", mock.Anything).Return(0, nil)
+		mockQueries.On("GetGiftCardByCodeHash", mock.Anything, s.hashGiftCardCode(code)).Return(card, nil)
+
+		resp, err := s.CheckGiftCardBalance(ctx, &paymentpb.CheckGiftCardBalanceRequest{
+			Code:      strings.ToLower(formatGiftCardCode(code)),
+			ClientKey: "user-1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1200), resp.GiftCard.Balance.Units)
+		assert.Empty(t, resp.GiftCard.UserId)
+	})
+
+	t.Run("turns away a mistyped code without a lookup", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withGiftCards())
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+		wrong := "2"
+		if code[15] == '2' {
+			wrong = "3"
+		}
+		typo := code[:15] + wrong
+
+		mockQueries.On("CountGiftCardLookupFailures", mock.Anything, "user-1", mock.Anything).Return(0, nil)
+		mockQueries.On("RecordGiftCardLookupFailure", mock.Anything, "user-1").Return(nil).Once()
+
+		_, err = s.CheckGiftCardBalance(ctx, &paymentpb.CheckGiftCardBalanceRequest{Code: typo, ClientKey: "user-1"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockQueries.AssertNotCalled(t, "GetGiftCardByCodeHash", mock.Anything, mock.Anything)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("unknown codes fail like mistyped ones", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withGiftCards())
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+
+		mockQueries.On("CountGiftCardLookupFailures", mock.Anything, "user-1", mock.Anything).Return(0, nil)
+		mockQueries.On("GetGiftCardByCodeHash", mock.Anything, mock.Anything).Return(db.GiftCard{}, pgx.ErrNoRows)
+		mockQueries.On("RecordGiftCardLookupFailure", mock.Anything, "user-1").Return(nil).Once()
+
+		_, err = s.CheckGiftCardBalance(ctx, &paymentpb.CheckGiftCardBalanceRequest{Code: code, ClientKey: "user-1"})
+
+		assert.Equal(t, errGiftCardNotFound, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("blocks a client after too many failures", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries, withGiftCards())
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+
+		mockQueries.On("CountGiftCardLookupFailures", mock.Anything, "user-1", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) >= giftCardLookupWindow
+		})).Return(maxGiftCardLookupFailures, nil)
+
+		_, err = s.CheckGiftCardBalance(ctx, &paymentpb.CheckGiftCardBalanceRequest{Code: code, ClientKey: "user-1"})
+
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		mockQueries.AssertNotCalled(t, "GetGiftCardByCodeHash", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_ProcessPayment_GiftCard(t *testing.T) {
+	ctx := context.Background()
+
+	giftCardPayment := func(order *orderpb.Order, amount int) db.Payment {
+		return db.Payment{ID: uuid.New(), OrderID: uuid.MustParse(order.Id), Method: "PAYMENT_METHOD_GIFT_CARD",
+			AmountMinor: amount, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+	}
+
+	t.Run("redeems part of a card by its code", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		s := newTestPaymentService(mockQueries, withOrder(order), withGiftCards())
+		payment := giftCardPayment(order, 3000)
+		code, err := generateGiftCardCode()
+		require.NoError(t, err)
+		card := db.GiftCard{ID: uuid.New(), Type: "GIFT_CARD_TYPE_GIFT_CARD", Status: "GIFT_CARD_STATUS_ACTIVE",
+			BalanceMinor: 5000, Currency: "JPY", ExpiresAt: time.Now().Add(time.Hour)}
+		redeemed := card
+		redeemed.BalanceMinor = 2000
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("CountGiftCardLookupFailures", mock.Anything, "user:"+order.UserId, mock.Anything).Return(0, nil)
+		mockQueries.On("GetGiftCardByCodeHash", mock.Anything, s.hashGiftCardCode(code)).Return(card, nil)
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, db.AdjustGiftCardBalanceParams{
+			GiftCardID:  card.ID,
+			Kind:        "GIFT_CARD_TRANSACTION_KIND_REDEEM",
+			AmountMinor: -3000,
+			PaymentID:   &payment.ID,
+		}).Return(redeemed, db.GiftCardTransaction{ID: uuid.New()}, nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentDataParams) bool {
+			var stored map[string]string
+			_ = json.Unmarshal(p.PaymentData, &stored)
+			return stored["gift_card_code"] == "****"+code[12:]
+		})).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == payment.ID && p.Status == "PAYMENT_STATUS_COMPLETED"
+		})).Return(nil)
+
+		resp, err := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			PaymentData: map[string]string{"gift_card_code": formatGiftCardCode(code)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Status)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("reports the balance when it does not cover the payment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		s := newTestPaymentService(mockQueries, withOrder(order), withGiftCards())
+		payment := giftCardPayment(order, 3000)
+		owner := uuid.MustParse(order.UserId)
+		card := db.GiftCard{ID: uuid.New(), Type: "GIFT_CARD_TYPE_STORE_CREDIT", Status: "GIFT_CARD_STATUS_ACTIVE",
+			BalanceMinor: 1200, Currency: "JPY", UserID: &owner, ExpiresAt: time.Now().Add(time.Hour)}
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("GetGiftCard", mock.Anything, card.ID).Return(card, nil)
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, mock.Anything).
+			Return(card, db.GiftCardTransaction{}, db.ErrInsufficientGiftCardBalance)
+
+		_, err := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			PaymentData: map[string]string{"gift_card_id": card.ID.String()},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "1200 JPY")
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports the balance left once the order's other tenders are given back", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		order.TotalAmount.Units = 7000
+		s := newTestPaymentService(mockQueries, withOrder(order), withGiftCards())
+		orderID := uuid.MustParse(order.Id)
+		owner := uuid.MustParse(order.UserId)
+		card := db.GiftCard{ID: uuid.New(), Type: "GIFT_CARD_TYPE_STORE_CREDIT", Status: "GIFT_CARD_STATUS_ACTIVE",
+			BalanceMinor: 5000, Currency: "JPY", UserID: &owner, ExpiresAt: time.Now().Add(time.Hour)}
+		redeemed := card
+		redeemed.BalanceMinor = 2000
+		first := giftCardPayment(order, 3000)
+		second := giftCardPayment(order, 4000)
+		completedFirst := first
+		completedFirst.Status = "PAYMENT_STATUS_COMPLETED"
+		refundID := uuid.New()
+
+		claimID := uuid.New()
+		mockQueries.On("StartOrderTenders", mock.Anything, mock.Anything).Return(claimID, nil)
+		mockQueries.On("FinishOrderTenders", mock.Anything, claimID, "FAILED").Return(nil)
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.OrderID == orderID && p.AmountMinor == 3000
+		})).Return(first.ID, nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.OrderID == orderID && p.AmountMinor == 4000
+		})).Return(second.ID, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, first.ID).Return(first, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, first.ID).Return(completedFirst, nil)
+		mockQueries.On("GetPayment", mock.Anything, second.ID).Return(second, nil)
+		mockQueries.On("GetGiftCard", mock.Anything, card.ID).Return(card, nil).Once()
+		mockQueries.On("GetGiftCard", mock.Anything, card.ID).Return(redeemed, nil).Once()
+		mockQueries.On("GetGiftCard", mock.Anything, card.ID).Return(card, nil).Once()
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, mock.MatchedBy(func(p db.AdjustGiftCardBalanceParams) bool {
+			return p.AmountMinor == -3000
+		})).Return(redeemed, db.GiftCardTransaction{ID: uuid.New()}, nil).Once()
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, mock.MatchedBy(func(p db.AdjustGiftCardBalanceParams) bool {
+			return p.AmountMinor == -4000
+		})).Return(redeemed, db.GiftCardTransaction{}, db.ErrInsufficientGiftCardBalance).Once()
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return (p.ID == first.ID && (p.Status == "PAYMENT_STATUS_COMPLETED" || p.Status == "PAYMENT_STATUS_REFUNDED")) ||
+				(p.ID == second.ID && p.Status == "PAYMENT_STATUS_FAILED")
+		})).Return(nil).Times(3)
+
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, first.ID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.PaymentID == first.ID && p.AmountMinor == 3000
+		})).Return(db.Refund{ID: refundID, PaymentID: first.ID, AmountMinor: 3000, Currency: "JPY"}, 3000, nil)
+		mockQueries.On("ListGiftCardTransactionsByPaymentID", mock.Anything, first.ID).Return([]db.GiftCardTransaction{
+			{GiftCardID: card.ID, Kind: "GIFT_CARD_TRANSACTION_KIND_REDEEM"},
+		}, nil)
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, mock.MatchedBy(func(p db.AdjustGiftCardBalanceParams) bool {
+			return p.AmountMinor == 3000 && p.RefundID != nil && *p.RefundID == refundID
+		})).Return(card, db.GiftCardTransaction{ID: uuid.New()}, nil).Once()
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.Anything).Return(nil)
+
+		giftCardTender := func(amount int64) *paymentpb.Tender {
+			t := tender(paymentpb.PaymentMethod_PAYMENT_METHOD_GIFT_CARD, amount)
+			t.PaymentData = map[string]string{"gift_card_id": card.ID.String()}
+			return t
+		}
+		_, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{giftCardTender(3000), giftCardTender(4000)},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "tender 2 (PAYMENT_METHOD_GIFT_CARD): gift card balance is 5000 JPY, less than the 4000 JPY to pay",
+			status.Convert(err).Message())
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("refuses another customer's store credit", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		s := newTestPaymentService(mockQueries, withOrder(order), withGiftCards())
+		payment := giftCardPayment(order, 3000)
+		someoneElse := uuid.New()
+		card := db.GiftCard{ID: uuid.New(), Type: "GIFT_CARD_TYPE_STORE_CREDIT", Status: "GIFT_CARD_STATUS_ACTIVE",
+			BalanceMinor: 5000, Currency: "JPY", UserID: &someoneElse, ExpiresAt: time.Now().Add(time.Hour)}
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("GetGiftCard", mock.Anything, card.ID).Return(card, nil)
+
+		_, err := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			PaymentData: map[string]string{"gift_card_id": card.ID.String()},
+		})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockQueries.AssertNotCalled(t, "AdjustGiftCardBalance", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_RefundPayment_GiftCard(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("refunds to store credit for the customer", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		s := newTestPaymentService(mockQueries, withOrder(order), withGiftCards())
+		payment := db.Payment{ID: uuid.New(), OrderID: uuid.MustParse(order.Id), Method: "PAYMENT_METHOD_KONBINI_LAWSON",
+			AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+		refund := db.Refund{ID: uuid.New(), PaymentID: payment.ID, AmountMinor: 2000, Currency: "JPY"}
+		credit := db.GiftCard{ID: uuid.New()}
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, payment.ID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.AmountMinor == 2000 && p.Destination == "REFUND_DESTINATION_STORE_CREDIT"
+		})).Return(refund, 2000, nil)
+		mockQueries.On("CreateGiftCard", mock.Anything, mock.MatchedBy(func(p db.CreateGiftCardParams) bool {
+			return p.Type == "GIFT_CARD_TYPE_STORE_CREDIT" && p.AmountMinor == 2000 &&
+				p.UserID != nil && p.UserID.String() == order.UserId && *p.RefundID == refund.ID &&
+				p.ExpiresAt.After(time.Now().AddDate(0, 11, 0)) && len(p.CodeHash) == 64
+		})).Return(credit, nil)
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.MatchedBy(func(p db.UpdateRefundStatusParams) bool {
+			return p.Status == "REFUND_STATUS_SUCCEEDED" && *p.ProviderReference == credit.ID.String()
+		})).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.Status == "PAYMENT_STATUS_PARTIALLY_REFUNDED"
+		})).Return(nil)
+
+		_, err := s.RefundPayment(ctx, &paymentpb.RefundPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			Amount:      &sharedpb.Money{Units: 2000, Currency: "JPY"},
+			Destination: paymentpb.RefundDestination_REFUND_DESTINATION_STORE_CREDIT,
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("fails the refund when the card can no longer be credited", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		s := newTestPaymentService(mockQueries, withOrder(order), withGiftCards())
+		payment := db.Payment{ID: uuid.New(), OrderID: uuid.MustParse(order.Id), Method: "PAYMENT_METHOD_GIFT_CARD",
+			AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+		refund := db.Refund{ID: uuid.New(), PaymentID: payment.ID, AmountMinor: 5000, Currency: "JPY"}
+		cardID := uuid.New()
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, payment.ID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.Destination == "REFUND_DESTINATION_ORIGINAL"
+		})).Return(refund, 5000, nil)
+		mockQueries.On("ListGiftCardTransactionsByPaymentID", mock.Anything, payment.ID).Return([]db.GiftCardTransaction{
+			{GiftCardID: cardID, Kind: "GIFT_CARD_TRANSACTION_KIND_REDEEM", AmountMinor: -5000},
+		}, nil)
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, mock.MatchedBy(func(p db.AdjustGiftCardBalanceParams) bool {
+			return p.GiftCardID == cardID && p.AmountMinor == 5000 && *p.RefundID == refund.ID
+		})).Return(db.GiftCard{}, db.GiftCardTransaction{}, db.ErrGiftCardNotUsable)
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.MatchedBy(func(p db.UpdateRefundStatusParams) bool {
+			return p.Status == "REFUND_STATUS_FAILED"
+		})).Return(nil)
+
+		_, err := s.RefundPayment(ctx, &paymentpb.RefundPaymentRequest{PaymentId: payment.ID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "store credit")
+		mockQueries.AssertExpectations(t)
+	})
+}
@@ -89,8 +89,8 @@ func (s *KonbiniService) CreateKonbiniPayment(
 	// Generate payment code
 	paymentCode := s.generatePaymentCode(store)
 
-	// Calculate expiration (typically 7 days for Konbini payments)
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	// Calculate expiration (end of the 7th day in Japan)
+	expiresAt := konbiniDeadline(time.Now())
 
 	// Generate confirmation number
 	confirmationNumber := s.generateConfirmationNumber(store)
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/pkg/calendar"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// konbiniPaymentDays is how many days after checkout a customer has to pay
+// at the store
+const konbiniPaymentDays = 7
+
+// konbiniDeadline returns the end of the day, in Japan, konbiniPaymentDays
+// after now, in UTC like every other stored time. Stores take payments
+// every day, so holidays do not move it.
+func konbiniDeadline(now time.Time) time.Time {
+	return calendar.EndOfDay(now.AddDate(0, 0, konbiniPaymentDays)).UTC()
+}
+
+// processKonbini issues a store payment and records its deadline. The
+// payment stays processing until the store reports it paid or the deadline
+// passes. Retrying after a failure keeps the deadline first recorded.
+func (s *PaymentService) processKonbini(ctx context.Context, payment db.Payment) (paymentpb.PaymentStatus, string, error) {
+	store, ok := KonbiniStores[paymentpb.PaymentMethod(paymentpb.PaymentMethod_value[payment.Method])]
+	if !ok {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", fmt.Errorf("unsupported Konbini store: %s", payment.Method)
+	}
+
+	_, err := s.queries.GetKonbiniPayment(ctx, payment.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		_, err = s.queries.CreateKonbiniPayment(ctx, db.CreateKonbiniPaymentParams{
+			PaymentID: payment.ID,
+			Store:     store.ID,
+			ExpiresAt: konbiniDeadline(time.Now()),
+		})
+	}
+	if err != nil {
+		s.logger.Error("Failed to record konbini payment", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Internal, "failed to issue konbini payment")
+	}
+
+	transactionID := fmt.Sprintf("KONBINI-%s-%d", uuid.New().String()[:8], time.Now().Unix())
+	return paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, transactionID, nil
+}
+
+// expireKonbiniPayment expires a konbini payment whose deadline passed,
+// reporting false when the store reported it paid first. The event carries
+// the reason order-service records when it expires the order.
+func (s *PaymentService) expireKonbiniPayment(ctx context.Context, konbini db.KonbiniPayment) (bool, error) {
+	payment, err := s.queries.GetPayment(ctx, konbini.PaymentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment.Status != paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING.String() {
+		return false, nil
+	}
+
+	const reason = "konbini payment deadline passed"
+	if err := s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED, statusChange{
+		changedBy: changedByKonbiniSweeper,
+		reason:    reason,
+	}); err != nil {
+		return false, err
+	}
+
+	s.publishPaymentEvent(ctx, PaymentEventExpired, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED, map[string]interface{}{
+		"reason":     reason,
+		"store":      konbini.Store,
+		"expires_at": konbini.ExpiresAt.Format(time.RFC3339),
+	})
+
+	return true, nil
+}
+
+// KonbiniExpirySweeper expires konbini payments that were not paid by their
+// deadline, cancelling the payment code at the provider first so that the
+// store no longer accepts it
+type KonbiniExpirySweeper struct {
+	payments *PaymentService
+	konbini  *KonbiniService
+	logger   *zap.Logger
+}
+
+// NewKonbiniExpirySweeper creates a new konbini expiry sweeper
+func NewKonbiniExpirySweeper(payments *PaymentService, konbini *KonbiniService, logger *zap.Logger) *KonbiniExpirySweeper {
+	return &KonbiniExpirySweeper{
+		payments: payments,
+		konbini:  konbini,
+		logger:   logger,
+	}
+}
+
+// ExpireDue expires every unpaid konbini payment whose deadline has passed.
+// Payments the provider refuses to cancel are left for the next run.
+func (w *KonbiniExpirySweeper) ExpireDue(ctx context.Context) (int, error) {
+	due, err := w.payments.queries.ListExpiredKonbiniPayments(ctx, time.Now().UTC(), sweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, konbini := range due {
+		if err := w.konbini.CancelKonbiniPayment(ctx, konbini.PaymentID.String()); err != nil {
+			w.logger.Warn("Failed to cancel konbini payment at provider",
+				zap.String("payment_id", konbini.PaymentID.String()),
+				zap.Error(err))
+			continue
+		}
+		if err := w.payments.queries.MarkKonbiniPaymentCancelled(ctx, konbini.PaymentID); err != nil {
+			w.logger.Warn("Failed to record konbini payment cancellation",
+				zap.String("payment_id", konbini.PaymentID.String()),
+				zap.Error(err))
+		}
+
+		ok, err := w.payments.expireKonbiniPayment(ctx, konbini)
+		if err != nil {
+			w.logger.Warn("Failed to expire konbini payment",
+				zap.String("payment_id", konbini.PaymentID.String()),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+
+	if len(due) > 0 {
+		w.logger.Info("Expired konbini payments",
+			zap.Int("found", len(due)),
+			zap.Int("expired", expired))
+	}
+
+	return expired, nil
+}
+
+// StartPeriodicSweep starts expiring konbini payments in the background
+func (w *KonbiniExpirySweeper) StartPeriodicSweep(ctx context.Context, interval time.Duration) {
+	w.logger.Info("Starting periodic konbini expiry sweep", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := w.ExpireDue(ctx); err != nil {
+					w.logger.Error("Periodic konbini expiry sweep failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
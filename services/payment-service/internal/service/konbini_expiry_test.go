@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func TestKonbiniDeadline(t *testing.T) {
+	// 23:30 in Tokyo is still the same day there although it is already
+	// 14:30 UTC; the deadline is midnight at the end of the 7th day
+	now := time.Date(2026, 4, 3, 23, 30, 0, 0, tokyo)
+	assert.Equal(t, time.Date(2026, 4, 11, 0, 0, 0, 0, tokyo).UTC(), konbiniDeadline(now))
+	assert.Equal(t, time.Date(2026, 4, 10, 15, 0, 0, 0, time.UTC), konbiniDeadline(now.UTC()))
+}
+
+func TestKonbiniExpirySweeper_ExpireDue(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	s := newTestPaymentService(mockQueries)
+	sweeper := NewKonbiniExpirySweeper(s, NewKonbiniService(mockQueries, zap.NewNop()), zap.NewNop())
+
+	payment := db.Payment{
+		ID:          uuid.New(),
+		OrderID:     uuid.New(),
+		Method:      "PAYMENT_METHOD_KONBINI_LAWSON",
+		AmountMinor: 3000,
+		Currency:    "JPY",
+		Status:      "PAYMENT_STATUS_PROCESSING",
+	}
+	paidMeanwhile := payment
+	paidMeanwhile.ID = uuid.New()
+	paidMeanwhile.Status = "PAYMENT_STATUS_COMPLETED"
+
+	deadline := time.Date(2026, 4, 10, 15, 0, 0, 0, time.UTC)
+	due := []db.KonbiniPayment{
+		{PaymentID: payment.ID, Store: "lawson", ExpiresAt: deadline},
+		{PaymentID: paidMeanwhile.ID, Store: "lawson", ExpiresAt: deadline},
+	}
+
+	mockQueries.On("ListExpiredKonbiniPayments", mock.Anything, mock.Anything, sweepBatchSize).Return(due, nil)
+	mockQueries.On("MarkKonbiniPaymentCancelled", mock.Anything, payment.ID).Return(nil)
+	mockQueries.On("MarkKonbiniPaymentCancelled", mock.Anything, paidMeanwhile.ID).Return(nil)
+	mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+	mockQueries.On("GetPayment", mock.Anything, paidMeanwhile.ID).Return(paidMeanwhile, nil)
+	mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+		return p.ID == payment.ID && p.Status == "PAYMENT_STATUS_EXPIRED" &&
+			p.Change.ChangedBy == changedByKonbiniSweeper
+	})).Return(nil)
+
+	var event PaymentEvent
+	mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+		return p.EventType == "payment.expired" && json.Unmarshal(p.Payload, &event) == nil
+	})).Return(1, nil)
+
+	expired, err := sweeper.ExpireDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, payment.OrderID.String(), event.OrderID)
+	assert.Equal(t, "konbini payment deadline passed", event.Data["reason"])
+	assert.Equal(t, "2026-04-10T15:00:00Z", event.Data["expires_at"])
+	mockQueries.AssertNumberOfCalls(t, "UpdatePaymentStatus", 1)
+	mockQueries.AssertExpectations(t)
+}
@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+const (
+	// maxSavedPaymentMethods is how many cards one user may keep
+	maxSavedPaymentMethods = 10
+
+	defaultCardTokenProvider = "fake"
+
+	// expiringSoonWindow flags cards that should be replaced before checkout
+	// starts declining them
+	expiringSoonWindow = 30 * 24 * time.Hour
+
+	defaultExpiringWithinDays = 30
+	maxExpiringWithinDays     = 365
+	maxExpiringPaymentMethods = 500
+)
+
+var cardBrands = map[string]bool{
+	"visa":       true,
+	"mastercard": true,
+	"jcb":        true,
+	"amex":       true,
+	"diners":     true,
+}
+
+// rawCardFields are payment_data keys that would carry card details the
+// provider's card form is meant to keep away from us
+var rawCardFields = []string{"card_number", "pan", "cvv", "cvc", "security_code"}
+
+// rejectRawCardData fails payment data that carries a card number or
+// security code instead of a provider token
+func rejectRawCardData(paymentData map[string]string) error {
+	for _, field := range rawCardFields {
+		if _, ok := paymentData[field]; ok {
+			return status.Errorf(codes.InvalidArgument, "%s is not accepted; tokenize the card with the provider", field)
+		}
+	}
+	for key, value := range paymentData {
+		if containsCardNumber(value) {
+			return status.Errorf(codes.InvalidArgument, "%s looks like a card number; tokenize the card with the provider", key)
+		}
+	}
+	return nil
+}
+
+// containsCardNumber reports whether s holds a run of 13 to 19 digits,
+// optionally split by spaces or hyphens, that passes the Luhn check
+func containsCardNumber(s string) bool {
+	var digits []byte
+	check := func() bool {
+		found := len(digits) >= 13 && len(digits) <= 19 && luhnValid(digits)
+		digits = digits[:0]
+		return found
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case (c == ' ' || c == '-') && len(digits) > 0:
+		default:
+			if check() {
+				return true
+			}
+		}
+	}
+	return check()
+}
+
+func luhnValid(digits []byte) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// cardExpiresAt is when a card with the given expiry stops working: the start
+// of the following month, Japan time
+func cardExpiresAt(month, year int) time.Time {
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, tokyo)
+}
+
+func validateSavePaymentMethod(req *paymentpb.SavePaymentMethodRequest, now time.Time) error {
+	if req.ProviderToken == "" {
+		return status.Error(codes.InvalidArgument, "provider_token is required")
+	}
+	if len(req.ProviderToken) > 255 || strings.ContainsAny(req.ProviderToken, " \t\r\n") {
+		return status.Error(codes.InvalidArgument, "invalid provider_token")
+	}
+	if containsCardNumber(req.ProviderToken) {
+		return status.Error(codes.InvalidArgument, "provider_token looks like a card number; tokenize the card with the provider")
+	}
+	if !cardBrands[req.Brand] {
+		return status.Errorf(codes.InvalidArgument, "unsupported card brand: %q", req.Brand)
+	}
+	if len(req.Last4) != 4 || strings.Trim(req.Last4, "0123456789") != "" {
+		return status.Error(codes.InvalidArgument, "last4 must be 4 digits")
+	}
+	if req.ExpMonth < 1 || req.ExpMonth > 12 {
+		return status.Error(codes.InvalidArgument, "exp_month must be between 1 and 12")
+	}
+	if req.ExpYear < 2000 || req.ExpYear > 2100 {
+		return status.Error(codes.InvalidArgument, "exp_year must be a four-digit year")
+	}
+	if !cardExpiresAt(int(req.ExpMonth), int(req.ExpYear)).After(now) {
+		return status.Error(codes.InvalidArgument, "card has expired")
+	}
+	return nil
+}
+
+// SavePaymentMethod saves a card tokenized by the provider. Saving the same
+// card again replaces its token.
+func (s *PaymentService) SavePaymentMethod(ctx context.Context, req *paymentpb.SavePaymentMethodRequest) (*paymentpb.SavePaymentMethodResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.SavePaymentMethod",
+		trace.WithAttributes(attribute.String("user.id", req.UserId)),
+	)
+	defer span.End()
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	now := time.Now()
+	if err := validateSavePaymentMethod(req, now); err != nil {
+		return nil, err
+	}
+	provider := req.Provider
+	if provider == "" {
+		provider = defaultCardTokenProvider
+	}
+
+	existing, err := s.queries.ListSavedPaymentMethods(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list saved payment methods", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to save payment method")
+	}
+	if len(existing) >= maxSavedPaymentMethods && !hasSavedCard(existing, req) {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d payment methods can be saved", maxSavedPaymentMethods)
+	}
+
+	method, err := s.queries.SaveSavedPaymentMethod(ctx, db.SaveSavedPaymentMethodParams{
+		UserID:        userID,
+		Provider:      provider,
+		ProviderToken: req.ProviderToken,
+		Brand:         req.Brand,
+		Last4:         req.Last4,
+		ExpMonth:      int(req.ExpMonth),
+		ExpYear:       int(req.ExpYear),
+		ExpiresAt:     cardExpiresAt(int(req.ExpMonth), int(req.ExpYear)),
+		MakeDefault:   req.MakeDefault,
+	})
+	if err != nil {
+		s.logger.Error("Failed to save payment method", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to save payment method")
+	}
+
+	s.logger.Info("Payment method saved",
+		zap.String("user_id", req.UserId),
+		zap.String("payment_method_id", method.ID.String()),
+		zap.String("brand", method.Brand),
+		zap.Bool("default", method.IsDefault))
+
+	return &paymentpb.SavePaymentMethodResponse{PaymentMethod: savedPaymentMethodToProto(method, now)}, nil
+}
+
+func hasSavedCard(methods []db.SavedPaymentMethod, req *paymentpb.SavePaymentMethodRequest) bool {
+	for _, m := range methods {
+		if m.Brand == req.Brand && m.Last4 == req.Last4 && m.ExpMonth == int(req.ExpMonth) && m.ExpYear == int(req.ExpYear) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PaymentService) ListPaymentMethods(ctx context.Context, req *paymentpb.ListPaymentMethodsRequest) (*paymentpb.ListPaymentMethodsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListPaymentMethods",
+		trace.WithAttributes(attribute.String("user.id", req.UserId)),
+	)
+	defer span.End()
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	methods, err := s.queries.ListSavedPaymentMethods(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list saved payment methods", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list payment methods")
+	}
+
+	return &paymentpb.ListPaymentMethodsResponse{PaymentMethods: savedPaymentMethodsToProto(methods, time.Now())}, nil
+}
+
+// SetDefaultPaymentMethod makes one of the user's cards the one preselected
+// at checkout
+func (s *PaymentService) SetDefaultPaymentMethod(ctx context.Context, req *paymentpb.SetDefaultPaymentMethodRequest) (*paymentpb.SetDefaultPaymentMethodResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.SetDefaultPaymentMethod",
+		trace.WithAttributes(attribute.String("payment_method.id", req.PaymentMethodId)),
+	)
+	defer span.End()
+
+	userID, methodID, err := parsePaymentMethodIDs(req.UserId, req.PaymentMethodId)
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := s.getSavedPaymentMethod(ctx, userID, methodID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !method.ExpiresAt.After(now) {
+		return nil, status.Error(codes.FailedPrecondition, "an expired card cannot be the default")
+	}
+
+	method, err = s.queries.SetDefaultSavedPaymentMethod(ctx, userID, methodID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "payment method not found")
+		}
+		s.logger.Error("Failed to set default payment method", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to set default payment method")
+	}
+
+	return &paymentpb.SetDefaultPaymentMethodResponse{PaymentMethod: savedPaymentMethodToProto(method, now)}, nil
+}
+
+// DeletePaymentMethod removes one of the user's cards. Deleting the default
+// makes the most recently used remaining card the default.
+func (s *PaymentService) DeletePaymentMethod(ctx context.Context, req *paymentpb.DeletePaymentMethodRequest) (*sharedpb.Empty, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.DeletePaymentMethod",
+		trace.WithAttributes(attribute.String("payment_method.id", req.PaymentMethodId)),
+	)
+	defer span.End()
+
+	userID, methodID, err := parsePaymentMethodIDs(req.UserId, req.PaymentMethodId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.DeleteSavedPaymentMethod(ctx, userID, methodID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "payment method not found")
+		}
+		s.logger.Error("Failed to delete payment method", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete payment method")
+	}
+
+	s.logger.Info("Payment method deleted",
+		zap.String("user_id", req.UserId),
+		zap.String("payment_method_id", req.PaymentMethodId))
+
+	return &sharedpb.Empty{}, nil
+}
+
+// ListExpiringPaymentMethods returns cards of every user that expire within
+// the next within_days, e.g. to remind their owners to update them
+func (s *PaymentService) ListExpiringPaymentMethods(ctx context.Context, req *paymentpb.ListExpiringPaymentMethodsRequest) (*paymentpb.ListExpiringPaymentMethodsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListExpiringPaymentMethods")
+	defer span.End()
+
+	days := int(req.WithinDays)
+	if days == 0 {
+		days = defaultExpiringWithinDays
+	}
+	if days < 0 || days > maxExpiringWithinDays {
+		return nil, status.Errorf(codes.InvalidArgument, "within_days must be between 1 and %d", maxExpiringWithinDays)
+	}
+
+	now := time.Now()
+	methods, err := s.queries.ListExpiringSavedPaymentMethods(ctx, now, now.AddDate(0, 0, days), maxExpiringPaymentMethods)
+	if err != nil {
+		s.logger.Error("Failed to list expiring payment methods", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list expiring payment methods")
+	}
+
+	return &paymentpb.ListExpiringPaymentMethodsResponse{PaymentMethods: savedPaymentMethodsToProto(methods, now)}, nil
+}
+
+func parsePaymentMethodIDs(userID, methodID string) (uuid.UUID, uuid.UUID, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	mid, err := uuid.Parse(methodID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid payment_method_id")
+	}
+	return uid, mid, nil
+}
+
+// getSavedPaymentMethod loads one of a user's cards. Another user's card is
+// reported as not found.
+func (s *PaymentService) getSavedPaymentMethod(ctx context.Context, userID, methodID uuid.UUID) (db.SavedPaymentMethod, error) {
+	method, err := s.queries.GetSavedPaymentMethod(ctx, methodID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.SavedPaymentMethod{}, status.Error(codes.NotFound, "payment method not found")
+		}
+		s.logger.Error("Failed to get saved payment method", zap.Error(err))
+		return db.SavedPaymentMethod{}, status.Error(codes.Internal, "failed to get payment method")
+	}
+	if method.UserID != userID {
+		return db.SavedPaymentMethod{}, status.Error(codes.NotFound, "payment method not found")
+	}
+	return method, nil
+}
+
+// orderCustomer returns the ID of the customer who placed a payment's order
+func (s *PaymentService) orderCustomer(ctx context.Context, payment db.Payment) (uuid.UUID, error) {
+	if s.orderClient == nil {
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "order lookups are not available")
+	}
+
+	resp, err := s.orderClient.GetOrder(ctx, &orderpb.GetOrderRequest{OrderId: payment.OrderID.String()})
+	if err != nil {
+		s.logger.Error("Failed to get order", zap.String("order_id", payment.OrderID.String()), zap.Error(err))
+		return uuid.Nil, status.Error(codes.Unavailable, "failed to get order")
+	}
+	userID, err := uuid.Parse(resp.Order.UserId)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.FailedPrecondition, "order has no customer")
+	}
+	return userID, nil
+}
+
+// savedCardForPayment loads the saved card a payment is charged to. The card
+// must belong to the customer who placed the order and must not be expired.
+func (s *PaymentService) savedCardForPayment(ctx context.Context, payment db.Payment, methodID string) (db.SavedPaymentMethod, error) {
+	id, err := uuid.Parse(methodID)
+	if err != nil {
+		return db.SavedPaymentMethod{}, status.Error(codes.InvalidArgument, "invalid payment_method_id")
+	}
+	if s.orderClient == nil {
+		return db.SavedPaymentMethod{}, status.Error(codes.FailedPrecondition, "saved payment methods are not available")
+	}
+
+	userID, err := s.orderCustomer(ctx, payment)
+	if err != nil {
+		return db.SavedPaymentMethod{}, err
+	}
+
+	method, err := s.getSavedPaymentMethod(ctx, userID, id)
+	if err != nil {
+		return db.SavedPaymentMethod{}, err
+	}
+	if !method.ExpiresAt.After(time.Now()) {
+		return db.SavedPaymentMethod{}, status.Error(codes.FailedPrecondition, "saved card has expired")
+	}
+
+	if err := s.queries.MarkSavedPaymentMethodUsed(ctx, method.ID); err != nil {
+		s.logger.Warn("Failed to mark payment method used", zap.String("payment_method_id", method.ID.String()), zap.Error(err))
+	}
+	return method, nil
+}
+
+func savedPaymentMethodsToProto(methods []db.SavedPaymentMethod, now time.Time) []*paymentpb.SavedPaymentMethod {
+	pb := make([]*paymentpb.SavedPaymentMethod, len(methods))
+	for i, m := range methods {
+		pb[i] = savedPaymentMethodToProto(m, now)
+	}
+	return pb
+}
+
+// savedPaymentMethodToProto leaves out the provider token, which only
+// payment-service uses
+func savedPaymentMethodToProto(m db.SavedPaymentMethod, now time.Time) *paymentpb.SavedPaymentMethod {
+	expired := !m.ExpiresAt.After(now)
+	pb := &paymentpb.SavedPaymentMethod{
+		Id:           m.ID.String(),
+		UserId:       m.UserID.String(),
+		Provider:     m.Provider,
+		Brand:        m.Brand,
+		Last4:        m.Last4,
+		ExpMonth:     int32(m.ExpMonth),
+		ExpYear:      int32(m.ExpYear),
+		IsDefault:    m.IsDefault,
+		ExpiringSoon: !expired && !m.ExpiresAt.After(now.Add(expiringSoonWindow)),
+		Expired:      expired,
+		CreatedAt:    timestamppb.New(m.CreatedAt),
+	}
+	if m.LastUsedAt != nil {
+		pb.LastUsedAt = timestamppb.New(*m.LastUsedAt)
+	}
+	return pb
+}
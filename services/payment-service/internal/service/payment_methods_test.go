@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func TestContainsCardNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"4111111111111111", true},
+		{"4111 1111 1111 1111", true},
+		{"4111-1111-1111-1111", true},
+		{"card 4111111111111111 exp", true},
+		{"4111111111111112", false},
+		{"tok_1a2b3c4d5e6f", false},
+		{"090-1234-5678", false},
+		{"2026-10-18 12:00", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, containsCardNumber(tt.value))
+		})
+	}
+}
+
+func TestRejectRawCardData(t *testing.T) {
+	assert.NoError(t, rejectRawCardData(map[string]string{"card_token": "tok_visa_4242"}))
+	assert.NoError(t, rejectRawCardData(nil))
+	assert.Equal(t, codes.InvalidArgument, status.Code(rejectRawCardData(map[string]string{"cvv": "123"})))
+	assert.Equal(t, codes.InvalidArgument, status.Code(rejectRawCardData(map[string]string{"card_token": "4242424242424242"})))
+}
+
+func TestCardExpiresAt(t *testing.T) {
+	assert.Equal(t, time.Date(2027, time.January, 1, 0, 0, 0, 0, tokyo), cardExpiresAt(12, 2026))
+	assert.Equal(t, time.Date(2026, time.July, 1, 0, 0, 0, 0, tokyo), cardExpiresAt(6, 2026))
+}
+
+func TestValidateSavePaymentMethod(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, tokyo)
+	valid := func() *paymentpb.SavePaymentMethodRequest {
+		return &paymentpb.SavePaymentMethodRequest{
+			ProviderToken: "tok_1a2b3c4d",
+			Brand:         "visa",
+			Last4:         "4242",
+			ExpMonth:      10,
+			ExpYear:       2026,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*paymentpb.SavePaymentMethodRequest)
+		wantErr bool
+	}{
+		{"valid until the end of this month", func(r *paymentpb.SavePaymentMethodRequest) {}, false},
+		{"missing token", func(r *paymentpb.SavePaymentMethodRequest) { r.ProviderToken = "" }, true},
+		{"card number as token", func(r *paymentpb.SavePaymentMethodRequest) { r.ProviderToken = "4242424242424242" }, true},
+		{"unknown brand", func(r *paymentpb.SavePaymentMethodRequest) { r.Brand = "discover" }, true},
+		{"short last4", func(r *paymentpb.SavePaymentMethodRequest) { r.Last4 = "424" }, true},
+		{"letters in last4", func(r *paymentpb.SavePaymentMethodRequest) { r.Last4 = "42a2" }, true},
+		{"month out of range", func(r *paymentpb.SavePaymentMethodRequest) { r.ExpMonth = 13 }, true},
+		{"two-digit year", func(r *paymentpb.SavePaymentMethodRequest) { r.ExpYear = 26 }, true},
+		{"expired last month", func(r *paymentpb.SavePaymentMethodRequest) { r.ExpMonth = 9 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+			err := validateSavePaymentMethod(req, now)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSavedPaymentMethodToProto(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, tokyo)
+	method := db.SavedPaymentMethod{ID: uuid.New(), UserID: uuid.New(), ProviderToken: "tok_secret", Brand: "jcb", Last4: "0000"}
+
+	method.ExpiresAt = cardExpiresAt(10, 2026)
+	pb := savedPaymentMethodToProto(method, now)
+	assert.True(t, pb.ExpiringSoon)
+	assert.False(t, pb.Expired)
+
+	method.ExpiresAt = cardExpiresAt(9, 2026)
+	pb = savedPaymentMethodToProto(method, now)
+	assert.False(t, pb.ExpiringSoon)
+	assert.True(t, pb.Expired)
+
+	method.ExpiresAt = cardExpiresAt(1, 2027)
+	pb = savedPaymentMethodToProto(method, now)
+	assert.False(t, pb.ExpiringSoon)
+	assert.False(t, pb.Expired)
+	assert.NotContains(t, pb.String(), "tok_secret")
+}
+
+func TestPaymentService_SavePaymentMethod(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	req := &paymentpb.SavePaymentMethodRequest{
+		UserId:        userID.String(),
+		ProviderToken: "tok_1a2b3c4d",
+		Brand:         "visa",
+		Last4:         "4242",
+		ExpMonth:      12,
+		ExpYear:       int32(time.Now().Year() + 2),
+	}
+
+	t.Run("saves the token with the default provider", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+
+		mockQueries.On("ListSavedPaymentMethods", mock.Anything, userID).Return(nil, nil)
+		mockQueries.On("SaveSavedPaymentMethod", mock.Anything, mock.MatchedBy(func(p db.SaveSavedPaymentMethodParams) bool {
+			return p.UserID == userID && p.Provider == defaultCardTokenProvider && p.ProviderToken == "tok_1a2b3c4d" &&
+				p.ExpiresAt.Equal(cardExpiresAt(12, int(req.ExpYear)))
+		})).Return(db.SavedPaymentMethod{
+			ID: uuid.New(), UserID: userID, Provider: defaultCardTokenProvider, ProviderToken: "tok_1a2b3c4d",
+			Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: int(req.ExpYear),
+			ExpiresAt: cardExpiresAt(12, int(req.ExpYear)), IsDefault: true,
+		}, nil)
+
+		resp, err := s.SavePaymentMethod(ctx, req)
+
+		require.NoError(t, err)
+		assert.True(t, resp.PaymentMethod.IsDefault)
+		assert.Equal(t, "4242", resp.PaymentMethod.Last4)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects a new card past the limit", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+
+		saved := make([]db.SavedPaymentMethod, maxSavedPaymentMethods)
+		for i := range saved {
+			saved[i] = db.SavedPaymentMethod{ID: uuid.New(), UserID: userID, Brand: "jcb", Last4: "0000", ExpMonth: i + 1, ExpYear: 2030}
+		}
+		mockQueries.On("ListSavedPaymentMethods", mock.Anything, userID).Return(saved, nil)
+
+		_, err := s.SavePaymentMethod(ctx, req)
+
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		mockQueries.AssertNotCalled(t, "SaveSavedPaymentMethod", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_SetDefaultPaymentMethod(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	methodID := uuid.New()
+
+	t.Run("hides another user's card", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		mockQueries.On("GetSavedPaymentMethod", mock.Anything, methodID).Return(db.SavedPaymentMethod{
+			ID: methodID, UserID: uuid.New(), ExpiresAt: time.Now().AddDate(1, 0, 0),
+		}, nil)
+
+		_, err := s.SetDefaultPaymentMethod(ctx, &paymentpb.SetDefaultPaymentMethodRequest{UserId: userID.String(), PaymentMethodId: methodID.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockQueries.AssertNotCalled(t, "SetDefaultSavedPaymentMethod", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an expired card", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		mockQueries.On("GetSavedPaymentMethod", mock.Anything, methodID).Return(db.SavedPaymentMethod{
+			ID: methodID, UserID: userID, ExpiresAt: time.Now().AddDate(0, -1, 0),
+		}, nil)
+
+		_, err := s.SetDefaultPaymentMethod(ctx, &paymentpb.SetDefaultPaymentMethodRequest{UserId: userID.String(), PaymentMethodId: methodID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestPaymentService_DeletePaymentMethod_NotFound(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	s := newTestPaymentService(mockQueries)
+	userID := uuid.New()
+	methodID := uuid.New()
+	mockQueries.On("DeleteSavedPaymentMethod", mock.Anything, userID, methodID).Return(pgx.ErrNoRows)
+
+	_, err := s.DeletePaymentMethod(context.Background(), &paymentpb.DeletePaymentMethodRequest{UserId: userID.String(), PaymentMethodId: methodID.String()})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPaymentService_ProcessPayment_SavedCard(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	methodID := uuid.New()
+
+	setup := func(owner uuid.UUID) (*PaymentService, *MockQuerier, db.Payment) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		s.SetOrderClient(&fakeOrderClient{order: &orderpb.Order{Id: payment.OrderID.String(), UserId: userID.String()}})
+
+		mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+		mockQueries.On("GetSavedPaymentMethod", mock.Anything, methodID).Return(db.SavedPaymentMethod{
+			ID: methodID, UserID: owner, Brand: "visa", ExpiresAt: time.Now().AddDate(1, 0, 0),
+		}, nil)
+		return s, mockQueries, payment
+	}
+
+	t.Run("authorizes the customer's card", func(t *testing.T) {
+		s, mockQueries, payment := setup(userID)
+		mockQueries.On("MarkSavedPaymentMethodUsed", mock.Anything, methodID).Return(nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.Anything).Return(nil)
+
+		resp, err := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			PaymentData: map[string]string{"payment_method_id": methodID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, resp.Status)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("refuses another customer's card", func(t *testing.T) {
+		s, mockQueries, payment := setup(uuid.New())
+
+		_, err := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			PaymentData: map[string]string{"payment_method_id": methodID.String()},
+		})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockQueries.AssertNotCalled(t, "AuthorizePayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses raw card data", func(t *testing.T) {
+		s, mockQueries, payment := setup(userID)
+
+		_, err := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+			PaymentId:   payment.ID.String(),
+			PaymentData: map[string]string{"card_number": "4111111111111111", "cvv": "123"},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockQueries.AssertNotCalled(t, "UpdatePaymentData", mock.Anything, mock.Anything)
+	})
+}
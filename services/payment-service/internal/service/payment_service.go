@@ -16,6 +16,8 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
 	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
@@ -23,21 +25,54 @@ import (
 	"go.uber.org/zap"
 )
 
+// authorizationTTL is how long a card authorization (与信) is held before it
+// lapses and the sweeper voids it.
+const authorizationTTL = 7 * 24 * time.Hour
+
+// maxRefundStatusAttempts is how often a refund reads its payment again when
+// another refund changes the payment's status under it
+const maxRefundStatusAttempts = 3
+
 type PaymentService struct {
 	paymentpb.UnimplementedPaymentServiceServer
-	queries db.Querier
-	cache   cache.Cache
-	logger  *zap.Logger
+	queries        db.Querier
+	cache          cache.Cache
+	stateMachine   *PaymentStateMachine
+	eventPublisher *PaymentEventPublisher
+	deliveryClient deliverypb.DeliveryServiceClient
+	// orderClient looks up order totals for multi-tender payments
+	orderClient  orderpb.OrderServiceClient
+	bankTransfer BankTransferConfig
+	// deferredProvider runs credit checks for deferred payments; nil
+	// disables the method
+	deferredProvider DeferredPaymentProvider
+	// giftCards holds the key gift card codes are hashed with; gift cards
+	// are unavailable without one
+	giftCards GiftCardConfig
+	logger    *zap.Logger
 }
 
 func NewPaymentService(queries db.Querier, cacheClient cache.Cache, logger *zap.Logger) *PaymentService {
 	return &PaymentService{
-		queries: queries,
-		cache:   cacheClient,
-		logger:  logger,
+		queries:        queries,
+		cache:          cacheClient,
+		stateMachine:   NewPaymentStateMachine(logger),
+		eventPublisher: nil, // Optional - set when Kafka is configured
+		bankTransfer:   BankTransferConfig{DeadlineDays: defaultBankTransferDeadlineDays},
+		logger:         logger,
 	}
 }
 
+// SetEventPublisher sets the event publisher (optional)
+func (s *PaymentService) SetEventPublisher(publisher *PaymentEventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// SetStateMachine sets the state machine (optional)
+func (s *PaymentService) SetStateMachine(stateMachine *PaymentStateMachine) {
+	s.stateMachine = stateMachine
+}
+
 func isDuplicateKeyError(err error) bool {
 	var pgErr *pgconn.PgError
 	return errors.As(err, &pgErr) && pgErr.SQLState() == "23505"
@@ -71,6 +106,8 @@ func (s *PaymentService) CreatePayment(ctx context.Context, req *paymentpb.Creat
 		return nil, status.Error(codes.Internal, "failed to create payment")
 	}
 
+	_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(req.OrderId))
+
 	return &paymentpb.CreatePaymentResponse{
 		PaymentId: paymentID.String(),
 		Status:    paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING,
@@ -128,16 +165,20 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req *paymentpb.Proc
 		return nil, status.Error(codes.NotFound, "payment not found")
 	}
 
-	if payment.Status != "PAYMENT_STATUS_PENDING" {
+	if ParsePaymentStatus(payment.Status) != paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING {
 		return nil, status.Error(codes.FailedPrecondition, "not in pending status")
 	}
 
+	if err := rejectRawCardData(req.PaymentData); err != nil {
+		return nil, err
+	}
+
 	paymentStatus, transactionID, err := s.processWithGateway(ctx, payment, req.PaymentData)
 	if err != nil {
 		return nil, err
 	}
 
-	paymentDataBytes, _ := json.Marshal(req.PaymentData)
+	paymentDataBytes, _ := json.Marshal(redactGiftCardCode(req.PaymentData))
 	if err := s.queries.UpdatePaymentData(ctx, db.UpdatePaymentDataParams{
 		ID:          payment.ID,
 		PaymentData: paymentDataBytes,
@@ -145,60 +186,569 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req *paymentpb.Proc
 		s.logger.Warn("Failed to update payment data", zap.Error(err))
 	}
 
-	if err := s.queries.UpdatePaymentStatus(ctx, db.UpdatePaymentStatusParams{
-		ID:            payment.ID,
-		Status:        paymentStatus.String(),
-		TransactionID: &transactionID,
-	}); err != nil {
-		s.logger.Error("Failed to update payment status", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to update payment status")
+	change := statusChange{
+		transactionID: &transactionID,
+		changedBy:     changedByAPI,
+		providerResponse: map[string]interface{}{
+			"status":         paymentStatus.String(),
+			"transaction_id": transactionID,
+		},
+	}
+	if paymentStatus == paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+		ttl := authorizationTTL
+		if payment.Method == "PAYMENT_METHOD_DEFERRED" {
+			ttl = deferredAuthorizationTTL
+		}
+		err = s.authorizePayment(ctx, payment, transactionID, time.Now().Add(ttl), change)
+	} else {
+		err = s.transitionPayment(ctx, payment, paymentStatus, change)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	_ = s.cache.Delete(ctx, cache.PaymentCacheKey(req.PaymentId))
-	_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(payment.OrderID.String()))
-
-	return &paymentpb.ProcessPaymentResponse{
+	resp := &paymentpb.ProcessPaymentResponse{
 		Status:        paymentStatus,
 		TransactionId: transactionID,
-	}, nil
+	}
+	if payment.Method == "PAYMENT_METHOD_BANK_TRANSFER" {
+		account, err := s.queries.GetBankTransferAccount(ctx, payment.ID)
+		if err != nil {
+			s.logger.Error("Failed to get bank transfer account", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to get bank transfer instructions")
+		}
+		resp.BankTransfer = s.bankTransferInstructions(account)
+	}
+	if payment.Method == "PAYMENT_METHOD_DEFERRED" {
+		deferred, err := s.queries.GetDeferredPayment(ctx, payment.ID)
+		if err != nil {
+			s.logger.Error("Failed to get deferred payment", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to get credit check")
+		}
+		resp.DeferredPayment = deferredPaymentToProto(deferred)
+	}
+
+	return resp, nil
 }
 
 func (s *PaymentService) RefundPayment(ctx context.Context, req *paymentpb.RefundPaymentRequest) (*sharedpb.Empty, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.RefundPayment",
+		trace.WithAttributes(attribute.String("payment.id", req.PaymentId)),
+	)
+	defer span.End()
+
 	s.logger.Info("Refunding payment", zap.String("payment_id", req.PaymentId))
 
 	paymentID, err := uuid.Parse(req.PaymentId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid payment_id: %w", err)
+		return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
 	}
 
 	payment, err := s.queries.GetPayment(ctx, paymentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get payment: %w", err)
+		s.logger.Error("Failed to get payment", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+
+	if !s.stateMachine.IsRefundable(ParsePaymentStatus(payment.Status)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment cannot be refunded: %s", payment.Status)
+	}
+
+	refundable := refundableAmount(payment)
+	refunds, err := s.queries.ListRefundsByPaymentID(ctx, payment.ID)
+	if err != nil {
+		s.logger.Error("Failed to list refunds", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list refunds")
 	}
+	remaining := refundable - refundedAmount(refunds)
 
-	if payment.Status != "PAYMENT_STATUS_COMPLETED" {
-		return nil, fmt.Errorf("payment cannot be refunded: %s", payment.Status)
+	amount := remaining
+	if req.Amount != nil && req.Amount.Units > 0 {
+		amount = int(req.Amount.Units)
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, status.Errorf(codes.InvalidArgument, "refund amount must be between 1 and %d", remaining)
+	}
+
+	destination := req.Destination
+	if destination == paymentpb.RefundDestination_REFUND_DESTINATION_UNSPECIFIED {
+		destination = paymentpb.RefundDestination_REFUND_DESTINATION_ORIGINAL
+	}
+	// Store credit goes to the customer who placed the order; find them
+	// before recording a refund that could not be paid out
+	var customerID uuid.UUID
+	if destination == paymentpb.RefundDestination_REFUND_DESTINATION_STORE_CREDIT {
+		if customerID, err = s.orderCustomer(ctx, payment); err != nil {
+			return nil, err
+		}
 	}
 
-	status, transactionID, err := s.refundWithGateway(ctx, payment, req.Amount)
+	refund, refunded, err := s.queries.CreateRefund(ctx, db.CreateRefundParams{
+		PaymentID:   payment.ID,
+		AmountMinor: amount,
+		Currency:    payment.Currency,
+		Reason:      nullableString(req.Reason),
+		LimitMinor:  refundable,
+		Destination: destination.String(),
+	})
 	if err != nil {
+		if errors.Is(err, db.ErrRefundExceedsCapture) {
+			return nil, status.Error(codes.InvalidArgument, "refund exceeds refundable amount")
+		}
+		s.logger.Error("Failed to create refund", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create refund")
+	}
+
+	var providerReference string
+	if destination == paymentpb.RefundDestination_REFUND_DESTINATION_STORE_CREDIT {
+		providerReference, err = s.refundToStoreCredit(ctx, payment, refund, customerID)
+	} else {
+		providerReference, err = s.refundWithGateway(ctx, payment, refund)
+	}
+	if err != nil {
+		if updateErr := s.queries.UpdateRefundStatus(ctx, db.UpdateRefundStatusParams{
+			ID:     refund.ID,
+			Status: paymentpb.RefundStatus_REFUND_STATUS_FAILED.String(),
+		}); updateErr != nil {
+			s.logger.Error("Failed to mark refund as failed", zap.Error(updateErr))
+		}
 		return nil, err
 	}
 
-	if err := s.queries.UpdatePaymentStatus(ctx, db.UpdatePaymentStatusParams{
+	if err := s.queries.UpdateRefundStatus(ctx, db.UpdateRefundStatusParams{
+		ID:                refund.ID,
+		Status:            paymentpb.RefundStatus_REFUND_STATUS_SUCCEEDED.String(),
+		ProviderReference: &providerReference,
+	}); err != nil {
+		s.logger.Error("Failed to update refund status", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update refund status")
+	}
+
+	// refunded counts the refunds made alongside this one, as it was summed
+	// under the payment's lock
+	paymentStatus := paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED
+	if refunded >= refundable {
+		paymentStatus = paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED
+	}
+
+	if err := s.settleRefundStatus(ctx, payment.ID, paymentStatus, statusChange{
+		changedBy: changedByAPI,
+		reason:    req.Reason,
+		providerResponse: map[string]interface{}{
+			"refund_id":          refund.ID.String(),
+			"provider_reference": providerReference,
+			"amount":             amount,
+			"destination":        destination.String(),
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return &sharedpb.Empty{}, nil
+}
+
+func (s *PaymentService) ListRefunds(ctx context.Context, req *paymentpb.ListRefundsRequest) (*paymentpb.ListRefundsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListRefunds",
+		trace.WithAttributes(attribute.String("payment.id", req.PaymentId)),
+	)
+	defer span.End()
+
+	paymentID, err := uuid.Parse(req.PaymentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
+	}
+
+	payment, err := s.queries.GetPayment(ctx, paymentID)
+	if err != nil {
+		s.logger.Error("Failed to get payment", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+
+	refunds, err := s.queries.ListRefundsByPaymentID(ctx, paymentID)
+	if err != nil {
+		s.logger.Error("Failed to list refunds", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list refunds")
+	}
+
+	pbRefunds := make([]*paymentpb.Refund, len(refunds))
+	for i, r := range refunds {
+		pbRefunds[i] = s.refundToProto(r)
+	}
+
+	refunded := refundedAmount(refunds)
+	return &paymentpb.ListRefundsResponse{
+		Refunds:          pbRefunds,
+		RefundedAmount:   &sharedpb.Money{Units: int64(refunded), Currency: payment.Currency},
+		RefundableAmount: &sharedpb.Money{Units: int64(refundableAmount(payment) - refunded), Currency: payment.Currency},
+	}, nil
+}
+
+// settleRefundStatus moves a payment to the status a refund leaves it in.
+// The payment is read again first, as a refund made alongside this one may
+// have moved it on since it was loaded; a payment another refund already
+// left REFUNDED stays so.
+func (s *PaymentService) settleRefundStatus(ctx context.Context, paymentID uuid.UUID, newStatus paymentpb.PaymentStatus, change statusChange) error {
+	for attempt := 1; ; attempt++ {
+		payment, err := s.queries.GetPayment(ctx, paymentID)
+		if err != nil {
+			s.logger.Error("Failed to get payment", zap.Error(err))
+			return status.Error(codes.Internal, "failed to get payment")
+		}
+		if payment.Status == paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED.String() {
+			return nil
+		}
+
+		auditChange, err := s.checkTransition(payment, newStatus, change)
+		if err != nil {
+			return err
+		}
+		err = s.queries.UpdatePaymentStatus(ctx, db.UpdatePaymentStatusParams{
+			ID:     payment.ID,
+			Status: newStatus.String(),
+			Change: auditChange,
+		})
+		if errors.Is(err, db.ErrPaymentStatusChanged) && attempt < maxRefundStatusAttempts {
+			continue
+		}
+		if err != nil {
+			return s.statusUpdateError(err)
+		}
+
+		_ = s.cache.Delete(ctx, cache.PaymentCacheKey(payment.ID.String()))
+		_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(payment.OrderID.String()))
+		return nil
+	}
+}
+
+// refundableAmount is the captured amount, or the full amount for methods
+// that settle without a separate capture.
+func refundableAmount(p db.Payment) int {
+	if p.CapturedAmountMinor != nil {
+		return *p.CapturedAmountMinor
+	}
+	return p.AmountMinor
+}
+
+// refundedAmount sums refunds that have not failed.
+func refundedAmount(refunds []db.Refund) int {
+	total := 0
+	for _, r := range refunds {
+		if r.Status != paymentpb.RefundStatus_REFUND_STATUS_FAILED.String() {
+			total += r.AmountMinor
+		}
+	}
+	return total
+}
+
+func (s *PaymentService) CapturePayment(ctx context.Context, req *paymentpb.CapturePaymentRequest) (*paymentpb.CapturePaymentResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.CapturePayment",
+		trace.WithAttributes(
+			attribute.String("payment.id", req.PaymentId),
+			attribute.String("payment.order_id", req.OrderId),
+		),
+	)
+	defer span.End()
+
+	s.logger.Info("Capturing payment",
+		zap.String("payment_id", req.PaymentId),
+		zap.String("order_id", req.OrderId))
+
+	var payments []db.Payment
+	switch {
+	case req.PaymentId != "":
+		paymentID, err := uuid.Parse(req.PaymentId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
+		}
+		payment, err := s.queries.GetPayment(ctx, paymentID)
+		if err != nil {
+			s.logger.Error("Failed to get payment", zap.Error(err))
+			return nil, status.Error(codes.NotFound, "payment not found")
+		}
+		payments = []db.Payment{payment}
+	case req.OrderId != "":
+		orderID, err := uuid.Parse(req.OrderId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+		}
+		payments, err = s.authorizedPaymentsForOrder(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "payment_id or order_id is required")
+	}
+
+	if len(payments) > 1 && req.Amount != nil && req.Amount.Units > 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount cannot be given for an order paid with several tenders")
+	}
+
+	resp := &paymentpb.CapturePaymentResponse{Status: paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED}
+	captured := 0
+	for _, payment := range payments {
+		amount := payment.AmountMinor
+		if req.Amount != nil && req.Amount.Units > 0 {
+			if req.Amount.Units > int64(payment.AmountMinor) {
+				return nil, status.Error(codes.InvalidArgument, "capture amount exceeds authorized amount")
+			}
+			amount = int(req.Amount.Units)
+		}
+
+		transactionID, err := s.capturePayment(ctx, payment, amount)
+		if err != nil {
+			return nil, err
+		}
+		resp.TransactionId = transactionID
+		captured += amount
+	}
+	resp.CapturedAmount = &sharedpb.Money{Units: int64(captured), Currency: payments[0].Currency}
+
+	return resp, nil
+}
+
+// errAuthorizationExpired is returned when a capture finds the authorization
+// lapsed. It is Aborted rather than FailedPrecondition so that callers can
+// tell it from an order that simply has nothing to capture.
+var errAuthorizationExpired = status.Error(codes.Aborted, "authorization has expired")
+
+// authorizedPaymentsForOrder returns the authorized payments of an order,
+// one per card tender. An order without any fails the capture.
+func (s *PaymentService) authorizedPaymentsForOrder(ctx context.Context, orderID uuid.UUID) ([]db.Payment, error) {
+	payments, err := s.queries.ListPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("Failed to list payments for order", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list payments")
+	}
+	if len(payments) == 0 {
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+
+	var authorized []db.Payment
+	for _, p := range payments {
+		if ParsePaymentStatus(p.Status) == paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+			authorized = append(authorized, p)
+		}
+	}
+	if len(authorized) == 0 {
+		for _, p := range payments {
+			if authorizationLapsed(p) {
+				return nil, errAuthorizationExpired
+			}
+		}
+		return nil, status.Error(codes.FailedPrecondition, "not in authorized status")
+	}
+	return authorized, nil
+}
+
+// authorizationLapsed reports whether a payment's authorization ran out
+// before it was captured: either still AUTHORIZED past its expiry, or voided
+// by the authorization sweeper once it had expired
+func authorizationLapsed(p db.Payment) bool {
+	if p.AuthorizationExpiresAt == nil {
+		return false
+	}
+	switch ParsePaymentStatus(p.Status) {
+	case paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED:
+		return time.Now().After(*p.AuthorizationExpiresAt)
+	case paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED:
+		return !p.UpdatedAt.Before(*p.AuthorizationExpiresAt)
+	}
+	return false
+}
+
+// capturePayment captures amount of an authorized payment at the provider
+// and returns the capture transaction ID
+func (s *PaymentService) capturePayment(ctx context.Context, payment db.Payment, amount int) (string, error) {
+	if ParsePaymentStatus(payment.Status) != paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+		return "", status.Error(codes.FailedPrecondition, "not in authorized status")
+	}
+	if authorizationLapsed(payment) {
+		return "", errAuthorizationExpired
+	}
+
+	transactionID, err := s.captureWithGateway(ctx, payment, amount)
+	if err != nil {
+		return "", err
+	}
+
+	auditChange, err := s.checkTransition(payment, paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED, statusChange{
+		changedBy: changedByAPI,
+		providerResponse: map[string]interface{}{
+			"transaction_id": transactionID,
+			"amount":         amount,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := s.queries.CapturePayment(ctx, db.CapturePaymentParams{
 		ID:            payment.ID,
-		Status:        status.String(),
+		AmountMinor:   amount,
 		TransactionID: &transactionID,
+		Change:        auditChange,
 	}); err != nil {
-		return nil, fmt.Errorf("failed to update payment status: %w", err)
+		s.logger.Error("Failed to capture payment", zap.Error(err))
+		if errors.Is(err, db.ErrPaymentStatusChanged) {
+			return "", status.Error(codes.FailedPrecondition, "not in authorized status")
+		}
+		return "", status.Error(codes.Internal, "failed to capture payment")
 	}
 
-	_ = s.cache.Delete(ctx, cache.PaymentCacheKey(req.PaymentId))
+	_ = s.cache.Delete(ctx, cache.PaymentCacheKey(payment.ID.String()))
 	_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(payment.OrderID.String()))
 
+	return transactionID, nil
+}
+
+func (s *PaymentService) VoidPayment(ctx context.Context, req *paymentpb.VoidPaymentRequest) (*sharedpb.Empty, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.VoidPayment",
+		trace.WithAttributes(attribute.String("payment.id", req.PaymentId)),
+	)
+	defer span.End()
+
+	s.logger.Info("Voiding payment", zap.String("payment_id", req.PaymentId))
+
+	paymentID, err := uuid.Parse(req.PaymentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
+	}
+
+	payment, err := s.queries.GetPayment(ctx, paymentID)
+	if err != nil {
+		s.logger.Error("Failed to get payment", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+
+	if ParsePaymentStatus(payment.Status) != paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+		return nil, status.Error(codes.FailedPrecondition, "not in authorized status")
+	}
+
+	if err := s.voidAuthorization(ctx, payment, req.Reason, changedByAPI); err != nil {
+		return nil, err
+	}
+
 	return &sharedpb.Empty{}, nil
 }
 
+// voidAuthorization releases an authorization at the provider and cancels the payment.
+func (s *PaymentService) voidAuthorization(ctx context.Context, payment db.Payment, reason string, changedBy string) error {
+	if err := s.voidWithGateway(ctx, payment, reason); err != nil {
+		return err
+	}
+
+	return s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED, statusChange{
+		changedBy: changedBy,
+		reason:    reason,
+		providerResponse: map[string]interface{}{
+			"authorization_id": toStringPtr(payment.TransactionID),
+		},
+	})
+}
+
+// checkTransition validates a status change against the state machine and
+// builds its status history record
+func (s *PaymentService) checkTransition(payment db.Payment, newStatus paymentpb.PaymentStatus, change statusChange) (db.PaymentStatusChange, error) {
+	if err := s.stateMachine.Transition(payment.ID.String(), ParsePaymentStatus(payment.Status), newStatus); err != nil {
+		return db.PaymentStatusChange{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	var providerResponse []byte
+	if change.providerResponse != nil {
+		var err error
+		if providerResponse, err = json.Marshal(change.providerResponse); err != nil {
+			s.logger.Warn("Failed to encode provider response", zap.Error(err))
+		}
+	}
+
+	return db.PaymentStatusChange{
+		FromStatus:       payment.Status,
+		ChangedBy:        change.changedBy,
+		Reason:           nullableString(change.reason),
+		ProviderResponse: providerResponse,
+	}, nil
+}
+
+// statusUpdateError maps a failed status update to a gRPC error
+func (s *PaymentService) statusUpdateError(err error) error {
+	if errors.Is(err, db.ErrPaymentStatusChanged) {
+		return status.Error(codes.FailedPrecondition, "payment status changed concurrently")
+	}
+	s.logger.Error("Failed to update payment status", zap.Error(err))
+	return status.Error(codes.Internal, "failed to update payment status")
+}
+
+// transitionPayment validates a status change against the state machine and
+// persists it together with its status history record
+func (s *PaymentService) transitionPayment(ctx context.Context, payment db.Payment, newStatus paymentpb.PaymentStatus, change statusChange) error {
+	auditChange, err := s.checkTransition(payment, newStatus, change)
+	if err != nil {
+		return err
+	}
+
+	if err := s.queries.UpdatePaymentStatus(ctx, db.UpdatePaymentStatusParams{
+		ID:            payment.ID,
+		Status:        newStatus.String(),
+		TransactionID: change.transactionID,
+		Change:        auditChange,
+	}); err != nil {
+		return s.statusUpdateError(err)
+	}
+
+	_ = s.cache.Delete(ctx, cache.PaymentCacheKey(payment.ID.String()))
+	_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(payment.OrderID.String()))
+
+	return nil
+}
+
+// authorizePayment validates the move to authorized against the state
+// machine and holds the authorization until expiresAt
+func (s *PaymentService) authorizePayment(ctx context.Context, payment db.Payment, transactionID string, expiresAt time.Time, change statusChange) error {
+	auditChange, err := s.checkTransition(payment, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, change)
+	if err != nil {
+		return err
+	}
+
+	if err := s.queries.AuthorizePayment(ctx, db.AuthorizePaymentParams{
+		ID:            payment.ID,
+		TransactionID: transactionID,
+		ExpiresAt:     expiresAt,
+		Change:        auditChange,
+	}); err != nil {
+		return s.statusUpdateError(err)
+	}
+
+	_ = s.cache.Delete(ctx, cache.PaymentCacheKey(payment.ID.String()))
+	_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(payment.OrderID.String()))
+
+	return nil
+}
+
+// publishPaymentEvent publishes a payment event when a publisher is configured
+func (s *PaymentService) publishPaymentEvent(ctx context.Context, eventType string, payment db.Payment, newStatus paymentpb.PaymentStatus, data map[string]interface{}) {
+	event := PaymentEvent{
+		EventID:   uuid.New().String(),
+		EventType: eventType,
+		PaymentID: payment.ID.String(),
+		OrderID:   payment.OrderID.String(),
+		Status:    newStatus.String(),
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+
+	s.enqueueWebhookDeliveries(ctx, event)
+
+	if s.eventPublisher == nil {
+		return
+	}
+
+	if err := s.eventPublisher.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish payment event",
+			zap.String("event_type", eventType),
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err))
+	}
+}
+
 func (s *PaymentService) processWithGateway(ctx context.Context, payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string, error) {
 	s.logger.Info("Processing payment with gateway",
 		zap.String("payment_id", payment.ID.String()),
@@ -209,7 +759,7 @@ func (s *PaymentService) processWithGateway(ctx context.Context, payment db.Paym
 
 	switch payment.Method {
 	case "PAYMENT_METHOD_CREDIT_CARD":
-		status, transactionID = s.processCreditCard(payment, paymentData)
+		return s.processCreditCard(ctx, payment, paymentData)
 	case "PAYMENT_METHOD_PAYPAY":
 		status, transactionID = s.processPayPay(payment, paymentData)
 	case "PAYMENT_METHOD_RAKUTEN_PAY":
@@ -217,7 +767,17 @@ func (s *PaymentService) processWithGateway(ctx context.Context, payment db.Paym
 	case "PAYMENT_METHOD_KONBINI_SEVENELEVEN",
 		"PAYMENT_METHOD_KONBINI_LAWSON",
 		"PAYMENT_METHOD_KONBINI_FAMILYMART":
-		status, transactionID = s.processKonbini(payment, paymentData)
+		return s.processKonbini(ctx, payment)
+	case "PAYMENT_METHOD_BANK_TRANSFER":
+		return s.processBankTransfer(ctx, payment)
+	case "PAYMENT_METHOD_CASH_ON_DELIVERY":
+		return s.processCashOnDelivery(ctx, payment)
+	case "PAYMENT_METHOD_DEFERRED":
+		return s.processDeferred(ctx, payment, paymentData)
+	case "PAYMENT_METHOD_POINTS":
+		return s.processPoints(ctx, payment)
+	case "PAYMENT_METHOD_GIFT_CARD":
+		return s.processGiftCard(ctx, payment, paymentData)
 	default:
 		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", fmt.Errorf("unsupported payment method: %s", payment.Method)
 	}
@@ -225,9 +785,26 @@ func (s *PaymentService) processWithGateway(ctx context.Context, payment db.Paym
 	return status, transactionID, nil
 }
 
-func (s *PaymentService) processCreditCard(payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string) {
+// processCreditCard authorizes a card by its provider token: either a saved
+// card (payment_method_id) or a one-off token from the card form (card_token)
+func (s *PaymentService) processCreditCard(ctx context.Context, payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string, error) {
+	switch {
+	case paymentData["payment_method_id"] != "":
+		method, err := s.savedCardForPayment(ctx, payment, paymentData["payment_method_id"])
+		if err != nil {
+			return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", err
+		}
+		s.logger.Info("Charging saved card",
+			zap.String("payment_id", payment.ID.String()),
+			zap.String("payment_method_id", method.ID.String()),
+			zap.String("brand", method.Brand))
+	case paymentData["card_token"] != "":
+	default:
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.InvalidArgument, "card_token or payment_method_id is required")
+	}
+
 	transactionID := fmt.Sprintf("CC-%s-%d", uuid.New().String()[:8], time.Now().Unix())
-	return paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, transactionID
+	return paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, transactionID, nil
 }
 
 func (s *PaymentService) processPayPay(payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string) {
@@ -240,18 +817,48 @@ func (s *PaymentService) processRakutenPay(payment db.Payment, paymentData map[s
 	return paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, transactionID
 }
 
-func (s *PaymentService) processKonbini(payment db.Payment, paymentData map[string]string) (paymentpb.PaymentStatus, string) {
-	transactionID := fmt.Sprintf("KONBINI-%s-%d", uuid.New().String()[:8], time.Now().Unix())
-	return paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING, transactionID
+func (s *PaymentService) refundWithGateway(ctx context.Context, payment db.Payment, refund db.Refund) (string, error) {
+	s.logger.Info("Processing refund with gateway",
+		zap.String("payment_id", payment.ID.String()),
+		zap.String("original_transaction_id", toStringPtr(payment.TransactionID)),
+		zap.Int("amount", refund.AmountMinor))
+
+	switch payment.Method {
+	case "PAYMENT_METHOD_GIFT_CARD":
+		return s.refundToGiftCard(ctx, payment, refund)
+	case "PAYMENT_METHOD_POINTS":
+		return s.refundToPoints(ctx, payment, refund)
+	}
+
+	providerReference := fmt.Sprintf("REFUND-%s-%d", uuid.New().String()[:8], time.Now().Unix())
+	return providerReference, nil
 }
 
-func (s *PaymentService) refundWithGateway(ctx context.Context, payment db.Payment, amount *sharedpb.Money) (paymentpb.PaymentStatus, string, error) {
-	s.logger.Info("Processing refund with gateway",
+func (s *PaymentService) captureWithGateway(ctx context.Context, payment db.Payment, amount int) (string, error) {
+	s.logger.Info("Processing capture with gateway",
 		zap.String("payment_id", payment.ID.String()),
-		zap.String("original_transaction_id", *payment.TransactionID))
+		zap.String("authorization_id", toStringPtr(payment.TransactionID)),
+		zap.Int("amount", amount))
+
+	if payment.Method == "PAYMENT_METHOD_DEFERRED" {
+		return s.registerDeferredShipment(ctx, payment, amount)
+	}
 
-	transactionID := fmt.Sprintf("REFUND-%s-%d", uuid.New().String()[:8], time.Now().Unix())
-	return paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED, transactionID, nil
+	transactionID := fmt.Sprintf("CAPTURE-%s-%d", uuid.New().String()[:8], time.Now().Unix())
+	return transactionID, nil
+}
+
+func (s *PaymentService) voidWithGateway(ctx context.Context, payment db.Payment, reason string) error {
+	s.logger.Info("Processing void with gateway",
+		zap.String("payment_id", payment.ID.String()),
+		zap.String("authorization_id", toStringPtr(payment.TransactionID)),
+		zap.String("reason", reason))
+
+	if payment.Method == "PAYMENT_METHOD_DEFERRED" {
+		return s.cancelDeferred(ctx, payment)
+	}
+
+	return nil
 }
 
 func (s *PaymentService) paymentToProto(p db.Payment) *paymentpb.Payment {
@@ -262,7 +869,7 @@ func (s *PaymentService) paymentToProto(p db.Payment) *paymentpb.Payment {
 
 	status := paymentpb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
 	if p.Status != "" {
-		status = paymentpb.PaymentStatus(paymentpb.PaymentStatus_value[p.Status])
+		status = ParsePaymentStatus(p.Status)
 	}
 
 	return &paymentpb.Payment{
@@ -274,12 +881,43 @@ func (s *PaymentService) paymentToProto(p db.Payment) *paymentpb.Payment {
 		TransactionId: toStringPtr(p.TransactionID),
 		CreatedAt:     timestamppb.New(p.CreatedAt),
 		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+
+		AuthorizationExpiresAt: toTimestampPtr(p.AuthorizationExpiresAt),
+		CapturedAt:             toTimestampPtr(p.CapturedAt),
 	}
 }
 
+func (s *PaymentService) refundToProto(r db.Refund) *paymentpb.Refund {
+	return &paymentpb.Refund{
+		Id:                r.ID.String(),
+		PaymentId:         r.PaymentID.String(),
+		Amount:            &sharedpb.Money{Units: int64(r.AmountMinor), Currency: r.Currency},
+		Reason:            toStringPtr(r.Reason),
+		Status:            paymentpb.RefundStatus(paymentpb.RefundStatus_value[r.Status]),
+		ProviderReference: toStringPtr(r.ProviderReference),
+		CreatedAt:         timestamppb.New(r.CreatedAt),
+		UpdatedAt:         timestamppb.New(r.UpdatedAt),
+		Destination:       paymentpb.RefundDestination(paymentpb.RefundDestination_value[r.Destination]),
+	}
+}
+
+func toTimestampPtr(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
 func toStringPtr(s *string) string {
 	if s == nil {
 		return ""
 	}
 	return *s
 }
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
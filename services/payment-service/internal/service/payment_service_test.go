@@ -6,11 +6,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	deliverypb "github.com/afasari/shinkansen-commerce/gen/proto/go/delivery"
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
 	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
 	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
 	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
@@ -58,6 +63,558 @@ func (m *MockQuerier) ListPaymentsByOrderID(ctx context.Context, orderID uuid.UU
 	return args.Get(0).([]db.Payment), args.Error(1)
 }
 
+func (m *MockQuerier) StartOrderTenders(ctx context.Context, arg db.StartOrderTendersParams) (uuid.UUID, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockQuerier) FinishOrderTenders(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) OrderTendersInProgress(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, orderID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) AuthorizePayment(ctx context.Context, params db.AuthorizePaymentParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CapturePayment(ctx context.Context, params db.CapturePaymentParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListExpiredAuthorizations(ctx context.Context, before time.Time, limit int) ([]db.Payment, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return []db.Payment{}, args.Error(1)
+	}
+	return args.Get(0).([]db.Payment), args.Error(1)
+}
+
+func (m *MockQuerier) CreateRefund(ctx context.Context, params db.CreateRefundParams) (db.Refund, int, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.Refund), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) RecordProviderRefund(ctx context.Context, params db.RecordProviderRefundParams) (db.Refund, string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.Refund), args.String(1), args.Error(2)
+}
+
+func (m *MockQuerier) UpdateRefundStatus(ctx context.Context, params db.UpdateRefundStatusParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListRefundsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]db.Refund, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return []db.Refund{}, args.Error(1)
+	}
+	return args.Get(0).([]db.Refund), args.Error(1)
+}
+
+func (m *MockQuerier) ListPaymentStatusHistory(ctx context.Context, paymentID uuid.UUID) ([]db.PaymentStatusHistory, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return []db.PaymentStatusHistory{}, args.Error(1)
+	}
+	return args.Get(0).([]db.PaymentStatusHistory), args.Error(1)
+}
+
+func (m *MockQuerier) RecordWebhookEvent(ctx context.Context, eventID string, eventType string) (bool, error) {
+	args := m.Called(ctx, eventID, eventType)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteWebhookEvent(ctx context.Context, eventID string) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CreateWebhookEndpoint(ctx context.Context, params db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockQuerier) GetWebhookEndpoint(ctx context.Context, id uuid.UUID) (db.WebhookEndpoint, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockQuerier) ListWebhookEndpoints(ctx context.Context) ([]db.WebhookEndpoint, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return []db.WebhookEndpoint{}, args.Error(1)
+	}
+	return args.Get(0).([]db.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockQuerier) UpdateWebhookEndpoint(ctx context.Context, params db.UpdateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) EnqueueWebhookDeliveries(ctx context.Context, params db.EnqueueWebhookDeliveriesParams) (int, error) {
+	args := m.Called(ctx, params)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuerier) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]db.DueWebhookDelivery, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return []db.DueWebhookDelivery{}, args.Error(1)
+	}
+	return args.Get(0).([]db.DueWebhookDelivery), args.Error(1)
+}
+
+func (m *MockQuerier) MarkWebhookDeliverySucceeded(ctx context.Context, id uuid.UUID, responseCode int) error {
+	args := m.Called(ctx, id, responseCode)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) MarkWebhookDeliveryFailed(ctx context.Context, params db.MarkWebhookDeliveryFailedParams) (bool, error) {
+	args := m.Called(ctx, params)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) ListWebhookDeliveries(ctx context.Context, params db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, int, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return []db.WebhookDelivery{}, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]db.WebhookDelivery), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) ReplayWebhookDelivery(ctx context.Context, id uuid.UUID) (db.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockQuerier) ListPaymentsByTransactionIDs(ctx context.Context, transactionIDs []string) ([]db.Payment, error) {
+	args := m.Called(ctx, transactionIDs)
+	if args.Get(0) == nil {
+		return []db.Payment{}, args.Error(1)
+	}
+	return args.Get(0).([]db.Payment), args.Error(1)
+}
+
+func (m *MockQuerier) ListSettleablePayments(ctx context.Context, methods []string, from, to time.Time) ([]db.Payment, error) {
+	args := m.Called(ctx, methods, from, to)
+	if args.Get(0) == nil {
+		return []db.Payment{}, args.Error(1)
+	}
+	return args.Get(0).([]db.Payment), args.Error(1)
+}
+
+func (m *MockQuerier) CreateReconciliationRun(ctx context.Context, params db.CreateReconciliationRunParams) (db.ReconciliationRun, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.ReconciliationRun), args.Error(1)
+}
+
+func (m *MockQuerier) GetReconciliationRun(ctx context.Context, id uuid.UUID) (db.ReconciliationRun, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.ReconciliationRun), args.Error(1)
+}
+
+func (m *MockQuerier) ListReconciliationRuns(ctx context.Context, limit, offset int) ([]db.ReconciliationRun, int, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return []db.ReconciliationRun{}, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]db.ReconciliationRun), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) ListReconciliationItems(ctx context.Context, runID uuid.UUID, includeMatched bool) ([]db.ReconciliationItem, error) {
+	args := m.Called(ctx, runID, includeMatched)
+	if args.Get(0) == nil {
+		return []db.ReconciliationItem{}, args.Error(1)
+	}
+	return args.Get(0).([]db.ReconciliationItem), args.Error(1)
+}
+
+func (m *MockQuerier) CreateBankTransferAccount(ctx context.Context, params db.CreateBankTransferAccountParams) (db.BankTransferAccount, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.BankTransferAccount), args.Error(1)
+}
+
+func (m *MockQuerier) GetBankTransferAccount(ctx context.Context, paymentID uuid.UUID) (db.BankTransferAccount, error) {
+	args := m.Called(ctx, paymentID)
+	return args.Get(0).(db.BankTransferAccount), args.Error(1)
+}
+
+func (m *MockQuerier) RecordBankDeposit(ctx context.Context, params db.RecordBankDepositParams) (db.BankDeposit, *db.BankTransferAccount, error) {
+	args := m.Called(ctx, params)
+	var account *db.BankTransferAccount
+	if a := args.Get(1); a != nil {
+		account = a.(*db.BankTransferAccount)
+	}
+	return args.Get(0).(db.BankDeposit), account, args.Error(2)
+}
+
+func (m *MockQuerier) ListOverdueBankTransfers(ctx context.Context, before time.Time, limit int) ([]db.BankTransferAccount, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return []db.BankTransferAccount{}, args.Error(1)
+	}
+	return args.Get(0).([]db.BankTransferAccount), args.Error(1)
+}
+
+func (m *MockQuerier) ListUncompletedBankTransfers(ctx context.Context, limit int) ([]db.BankTransferAccount, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return []db.BankTransferAccount{}, args.Error(1)
+	}
+	return args.Get(0).([]db.BankTransferAccount), args.Error(1)
+}
+
+func (m *MockQuerier) ExpireBankTransferAccount(ctx context.Context, paymentID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, paymentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) ListBankDeposits(ctx context.Context, params db.ListBankDepositsParams) ([]db.BankDeposit, int, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return []db.BankDeposit{}, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]db.BankDeposit), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) CreateDeferredPayment(ctx context.Context, params db.CreateDeferredPaymentParams) (db.DeferredPayment, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.DeferredPayment), args.Error(1)
+}
+
+func (m *MockQuerier) GetDeferredPayment(ctx context.Context, paymentID uuid.UUID) (db.DeferredPayment, error) {
+	args := m.Called(ctx, paymentID)
+	return args.Get(0).(db.DeferredPayment), args.Error(1)
+}
+
+func (m *MockQuerier) UpdateDeferredCreditCheck(ctx context.Context, paymentID uuid.UUID, creditCheck string, reason *string) error {
+	args := m.Called(ctx, paymentID, creditCheck, reason)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) RecordDeferredShipment(ctx context.Context, params db.RecordDeferredShipmentParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) UpdateDeferredInvoiceStatus(ctx context.Context, paymentID uuid.UUID, invoiceStatus string) error {
+	args := m.Called(ctx, paymentID, invoiceStatus)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListDeferredPaymentsInReview(ctx context.Context, limit int) ([]db.DeferredPayment, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return []db.DeferredPayment{}, args.Error(1)
+	}
+	return args.Get(0).([]db.DeferredPayment), args.Error(1)
+}
+
+func (m *MockQuerier) ListOpenDeferredInvoices(ctx context.Context, limit int) ([]db.DeferredPayment, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return []db.DeferredPayment{}, args.Error(1)
+	}
+	return args.Get(0).([]db.DeferredPayment), args.Error(1)
+}
+
+func (m *MockQuerier) SaveSavedPaymentMethod(ctx context.Context, params db.SaveSavedPaymentMethodParams) (db.SavedPaymentMethod, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.SavedPaymentMethod), args.Error(1)
+}
+
+func (m *MockQuerier) GetSavedPaymentMethod(ctx context.Context, id uuid.UUID) (db.SavedPaymentMethod, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.SavedPaymentMethod), args.Error(1)
+}
+
+func (m *MockQuerier) ListSavedPaymentMethods(ctx context.Context, userID uuid.UUID) ([]db.SavedPaymentMethod, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return []db.SavedPaymentMethod{}, args.Error(1)
+	}
+	return args.Get(0).([]db.SavedPaymentMethod), args.Error(1)
+}
+
+func (m *MockQuerier) SetDefaultSavedPaymentMethod(ctx context.Context, userID, id uuid.UUID) (db.SavedPaymentMethod, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(db.SavedPaymentMethod), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteSavedPaymentMethod(ctx context.Context, userID, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) MarkSavedPaymentMethodUsed(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListExpiringSavedPaymentMethods(ctx context.Context, from, to time.Time, limit int) ([]db.SavedPaymentMethod, error) {
+	args := m.Called(ctx, from, to, limit)
+	if args.Get(0) == nil {
+		return []db.SavedPaymentMethod{}, args.Error(1)
+	}
+	return args.Get(0).([]db.SavedPaymentMethod), args.Error(1)
+}
+
+func (m *MockQuerier) CreateDispute(ctx context.Context, arg db.CreateDisputeParams) (db.Dispute, bool, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.Dispute), args.Bool(1), args.Error(2)
+}
+
+func (m *MockQuerier) GetDispute(ctx context.Context, id uuid.UUID) (db.Dispute, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.Dispute), args.Error(1)
+}
+
+func (m *MockQuerier) GetDisputeByProviderID(ctx context.Context, providerDisputeID string) (db.Dispute, error) {
+	args := m.Called(ctx, providerDisputeID)
+	return args.Get(0).(db.Dispute), args.Error(1)
+}
+
+func (m *MockQuerier) ListDisputes(ctx context.Context, arg db.ListDisputesParams) ([]db.Dispute, int, error) {
+	args := m.Called(ctx, arg)
+	if args.Get(0) == nil {
+		return []db.Dispute{}, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]db.Dispute), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) UpdateDispute(ctx context.Context, arg db.UpdateDisputeParams) (db.Dispute, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.Dispute), args.Error(1)
+}
+
+func (m *MockQuerier) MarkDisputeEvidenceSubmitted(ctx context.Context, id uuid.UUID) (db.Dispute, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.Dispute), args.Error(1)
+}
+
+func (m *MockQuerier) CloseDispute(ctx context.Context, arg db.CloseDisputeParams) (db.Dispute, bool, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.Dispute), args.Bool(1), args.Error(2)
+}
+
+func (m *MockQuerier) AddDisputeEvidence(ctx context.Context, arg db.AddDisputeEvidenceParams) (db.DisputeEvidence, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.DisputeEvidence), args.Error(1)
+}
+
+func (m *MockQuerier) ListDisputeEvidence(ctx context.Context, disputeID uuid.UUID, withContent bool) ([]db.DisputeEvidence, error) {
+	args := m.Called(ctx, disputeID, withContent)
+	if args.Get(0) == nil {
+		return []db.DisputeEvidence{}, args.Error(1)
+	}
+	return args.Get(0).([]db.DisputeEvidence), args.Error(1)
+}
+
+func (m *MockQuerier) CountOpenDisputesByOrderID(ctx context.Context, orderID uuid.UUID) (int, error) {
+	args := m.Called(ctx, orderID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuerier) CreateGiftCard(ctx context.Context, arg db.CreateGiftCardParams) (db.GiftCard, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.GiftCard), args.Error(1)
+}
+
+func (m *MockQuerier) GetGiftCard(ctx context.Context, id uuid.UUID) (db.GiftCard, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.GiftCard), args.Error(1)
+}
+
+func (m *MockQuerier) GetGiftCardByCodeHash(ctx context.Context, codeHash string) (db.GiftCard, error) {
+	args := m.Called(ctx, codeHash)
+	return args.Get(0).(db.GiftCard), args.Error(1)
+}
+
+func (m *MockQuerier) ListGiftCards(ctx context.Context, arg db.ListGiftCardsParams) ([]db.GiftCard, int, error) {
+	args := m.Called(ctx, arg)
+	if args.Get(0) == nil {
+		return []db.GiftCard{}, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]db.GiftCard), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) AdjustGiftCardBalance(ctx context.Context, arg db.AdjustGiftCardBalanceParams) (db.GiftCard, db.GiftCardTransaction, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.GiftCard), args.Get(1).(db.GiftCardTransaction), args.Error(2)
+}
+
+func (m *MockQuerier) ListGiftCardTransactions(ctx context.Context, giftCardID uuid.UUID) ([]db.GiftCardTransaction, error) {
+	args := m.Called(ctx, giftCardID)
+	if args.Get(0) == nil {
+		return []db.GiftCardTransaction{}, args.Error(1)
+	}
+	return args.Get(0).([]db.GiftCardTransaction), args.Error(1)
+}
+
+func (m *MockQuerier) ListGiftCardTransactionsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]db.GiftCardTransaction, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return []db.GiftCardTransaction{}, args.Error(1)
+	}
+	return args.Get(0).([]db.GiftCardTransaction), args.Error(1)
+}
+
+func (m *MockQuerier) DisableGiftCard(ctx context.Context, id uuid.UUID) (db.GiftCard, bool, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(db.GiftCard), args.Bool(1), args.Error(2)
+}
+
+func (m *MockQuerier) ListExpiredGiftCards(ctx context.Context, before time.Time, limit int) ([]db.GiftCard, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return []db.GiftCard{}, args.Error(1)
+	}
+	return args.Get(0).([]db.GiftCard), args.Error(1)
+}
+
+func (m *MockQuerier) ExpireGiftCard(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) RecordGiftCardLookupFailure(ctx context.Context, clientKey string) error {
+	args := m.Called(ctx, clientKey)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CountGiftCardLookupFailures(ctx context.Context, clientKey string, since time.Time) (int, error) {
+	args := m.Called(ctx, clientKey, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteGiftCardLookupFailures(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) GetPointBalance(ctx context.Context, userID uuid.UUID) (db.PointBalance, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(db.PointBalance), args.Error(1)
+}
+
+func (m *MockQuerier) AdjustPoints(ctx context.Context, arg db.AdjustPointsParams) (db.PointBalance, db.PointTransaction, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.PointBalance), args.Get(1).(db.PointTransaction), args.Error(2)
+}
+
+func (m *MockQuerier) ListPointTransactions(ctx context.Context, arg db.ListPointTransactionsParams) ([]db.PointTransaction, int, error) {
+	args := m.Called(ctx, arg)
+	if args.Get(0) == nil {
+		return []db.PointTransaction{}, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]db.PointTransaction), args.Int(1), args.Error(2)
+}
+
+func (m *MockQuerier) ListPointTransactionsByPaymentID(ctx context.Context, paymentID uuid.UUID) ([]db.PointTransaction, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return []db.PointTransaction{}, args.Error(1)
+	}
+	return args.Get(0).([]db.PointTransaction), args.Error(1)
+}
+
+func (m *MockQuerier) CreateKonbiniPayment(ctx context.Context, params db.CreateKonbiniPaymentParams) (db.KonbiniPayment, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(db.KonbiniPayment), args.Error(1)
+}
+
+func (m *MockQuerier) GetKonbiniPayment(ctx context.Context, paymentID uuid.UUID) (db.KonbiniPayment, error) {
+	args := m.Called(ctx, paymentID)
+	return args.Get(0).(db.KonbiniPayment), args.Error(1)
+}
+
+func (m *MockQuerier) ListExpiredKonbiniPayments(ctx context.Context, before time.Time, limit int) ([]db.KonbiniPayment, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return []db.KonbiniPayment{}, args.Error(1)
+	}
+	return args.Get(0).([]db.KonbiniPayment), args.Error(1)
+}
+
+func (m *MockQuerier) MarkKonbiniPaymentCancelled(ctx context.Context, paymentID uuid.UUID) error {
+	args := m.Called(ctx, paymentID)
+	return args.Error(0)
+}
+
+// testServiceOption wires a collaborator into a PaymentService built by
+// newTestPaymentService
+type testServiceOption func(*PaymentService)
+
+// withOrder answers GetOrder with order, as the order service would
+func withOrder(order *orderpb.Order) testServiceOption {
+	return func(s *PaymentService) { s.SetOrderClient(&fakeOrderClient{order: order}) }
+}
+
+func withDeliveryClient(deliveryClient deliverypb.DeliveryServiceClient) testServiceOption {
+	return func(s *PaymentService) { s.SetDeliveryClient(deliveryClient) }
+}
+
+func withDeferredProvider(provider DeferredPaymentProvider) testServiceOption {
+	return func(s *PaymentService) { s.SetDeferredPaymentProvider(provider) }
+}
+
+// withBankTransferAccount sets the collection account bank transfers are
+// paid into
+func withBankTransferAccount() testServiceOption {
+	return func(s *PaymentService) {
+		s.SetBankTransferConfig(BankTransferConfig{
+			BankCode:      "0000",
+			BankName:      "テスト銀行",
+			BranchCode:    "001",
+			BranchName:    "本店",
+			AccountType:   "普通",
+			AccountHolder: "ｶ)ｼﾝｶﾝｾﾝｺﾏｰｽ",
+		})
+	}
+}
+
+func withGiftCards() testServiceOption {
+	return func(s *PaymentService) { s.SetGiftCardConfig(GiftCardConfig{CodeSecret: "test-secret"}) }
+}
+
+// newTestPaymentService builds a PaymentService on mockQueries with a cache
+// that accepts any invalidation
+func newTestPaymentService(mockQueries *MockQuerier, opts ...testServiceOption) *PaymentService {
+	mockCache := new(cache.MockCache)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s := NewPaymentService(mockQueries, mockCache, zap.NewNop())
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// testPayment is a JPY payment of amount by method in status
+func testPayment(method paymentpb.PaymentMethod, status string, amount int) db.Payment {
+	return db.Payment{
+		ID:          uuid.New(),
+		OrderID:     uuid.New(),
+		Method:      method.String(),
+		AmountMinor: amount,
+		Currency:    "JPY",
+		Status:      status,
+	}
+}
+
 func TestPaymentService_CreatePayment(t *testing.T) {
 	logger := zap.NewNop()
 
@@ -76,6 +633,7 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		}
 
 		mockQueries.On("CreatePayment", mock.Anything, mock.AnythingOfType("db.CreatePaymentParams")).Return(paymentID, nil)
+		mockCache.On("Delete", mock.Anything, []string{cache.PaymentsByOrderCacheKey(orderID.String())}).Return(nil)
 
 		resp, err := service.CreatePayment(context.Background(), req)
 
@@ -109,6 +667,7 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 				}
 
 				mockQueries.On("CreatePayment", mock.Anything, mock.AnythingOfType("db.CreatePaymentParams")).Return(uuid.New(), nil).Once()
+				mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil)
 
 				resp, err := service.CreatePayment(context.Background(), req)
 
@@ -205,15 +764,13 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 
 		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(mockPayment, nil)
 		mockQueries.On("UpdatePaymentData", mock.Anything, mock.AnythingOfType("db.UpdatePaymentDataParams")).Return(nil)
-		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.AnythingOfType("db.UpdatePaymentStatusParams")).Return(nil)
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.AnythingOfType("db.AuthorizePaymentParams")).Return(nil)
 		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
 
 		req := &paymentpb.ProcessPaymentRequest{
 			PaymentId:  paymentID.String(),
 			PaymentData: map[string]string{
-				"card_number": "4111111111111111",
-				"expiry":     "12/25",
-				"cvv":        "123",
+				"card_token": "tok_visa_4242",
 			},
 		}
 
@@ -221,7 +778,7 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.NotNil(t, resp)
-		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Status)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, resp.Status)
 		assert.NotEmpty(t, resp.TransactionId)
 		assert.Contains(t, resp.TransactionId, "CC-")
 		mockQueries.AssertExpectations(t)
@@ -287,6 +844,10 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 
 		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(mockPayment, nil)
 		mockQueries.On("UpdatePaymentData", mock.Anything, mock.AnythingOfType("db.UpdatePaymentDataParams")).Return(nil)
+		mockQueries.On("GetKonbiniPayment", mock.Anything, paymentID).Return(db.KonbiniPayment{}, pgx.ErrNoRows)
+		mockQueries.On("CreateKonbiniPayment", mock.Anything, mock.MatchedBy(func(p db.CreateKonbiniPaymentParams) bool {
+			return p.PaymentID == paymentID && p.Store == "seven-eleven" && p.ExpiresAt.After(time.Now().Add(7*24*time.Hour))
+		})).Return(db.KonbiniPayment{PaymentID: paymentID}, nil)
 		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.AnythingOfType("db.UpdatePaymentStatusParams")).Return(nil)
 		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
 
@@ -399,7 +960,17 @@ func TestPaymentService_RefundPayment(t *testing.T) {
 		}
 
 		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(mockPayment, nil)
-		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.AnythingOfType("db.UpdatePaymentStatusParams")).Return(nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.PaymentID == paymentID && p.AmountMinor == 10000 && p.LimitMinor == 10000
+		})).Return(db.Refund{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 10000}, 10000, nil)
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.MatchedBy(func(p db.UpdateRefundStatusParams) bool {
+			return p.Status == "REFUND_STATUS_SUCCEEDED" && p.ProviderReference != nil
+		})).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_REFUNDED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByAPI
+		})).Return(nil)
 		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
 
 		req := &paymentpb.RefundPaymentRequest{
@@ -414,6 +985,120 @@ func TestPaymentService_RefundPayment(t *testing.T) {
 		mockQueries.AssertExpectations(t)
 	})
 
+	t.Run("partial refund against earlier refunds", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		paymentID := uuid.New()
+		captured := 8000
+
+		mockPayment := db.Payment{
+			ID:                  paymentID,
+			OrderID:             uuid.New(),
+			Method:              "PAYMENT_METHOD_CREDIT_CARD",
+			AmountMinor:         10000,
+			Currency:            "JPY",
+			Status:              "PAYMENT_STATUS_PARTIALLY_REFUNDED",
+			CapturedAmountMinor: &captured,
+		}
+		previous := []db.Refund{
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 3000, Status: "REFUND_STATUS_SUCCEEDED"},
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 5000, Status: "REFUND_STATUS_FAILED"},
+		}
+
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(mockPayment, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(previous, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.AmountMinor == 2000 && p.LimitMinor == 8000 && *p.Reason == "damaged item"
+		})).Return(db.Refund{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 2000}, 5000, nil)
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.AnythingOfType("db.UpdateRefundStatusParams")).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_PARTIALLY_REFUNDED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByAPI
+		})).Return(nil)
+		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
+
+		_, err := service.RefundPayment(context.Background(), &paymentpb.RefundPaymentRequest{
+			PaymentId: paymentID.String(),
+			Amount:    &sharedpb.Money{Units: 2000, Currency: "JPY"},
+			Reason:    "damaged item",
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("two refunds made together leave the payment refunded", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		paymentID := uuid.New()
+		completed := db.Payment{ID: paymentID, OrderID: uuid.New(), Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 10000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+		partiallyRefunded := completed
+		partiallyRefunded.Status = "PAYMENT_STATUS_PARTIALLY_REFUNDED"
+
+		// Both refunds load the payment before either is recorded; the
+		// second is summed with the first under the payment's lock
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(completed, nil).Times(3)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(partiallyRefunded, nil).Once()
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.AmountMinor == 5000 && p.LimitMinor == 10000
+		})).Return(db.Refund{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 5000}, 5000, nil).Once()
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.AmountMinor == 5000 && p.LimitMinor == 10000
+		})).Return(db.Refund{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 5000}, 10000, nil).Once()
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.Change.FromStatus == "PAYMENT_STATUS_COMPLETED" && p.Status == "PAYMENT_STATUS_PARTIALLY_REFUNDED"
+		})).Return(nil).Once()
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.Change.FromStatus == "PAYMENT_STATUS_PARTIALLY_REFUNDED" && p.Status == "PAYMENT_STATUS_REFUNDED"
+		})).Return(nil).Once()
+		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil)
+
+		for i := 0; i < 2; i++ {
+			_, err := service.RefundPayment(context.Background(), &paymentpb.RefundPaymentRequest{
+				PaymentId: paymentID.String(),
+				Amount:    &sharedpb.Money{Units: 5000, Currency: "JPY"},
+			})
+			require.NoError(t, err)
+		}
+
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("cannot refund more than remaining", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		paymentID := uuid.New()
+		mockPayment := db.Payment{
+			ID:          paymentID,
+			OrderID:     uuid.New(),
+			AmountMinor: 10000,
+			Currency:    "JPY",
+			Status:      "PAYMENT_STATUS_PARTIALLY_REFUNDED",
+		}
+
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(mockPayment, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return([]db.Refund{
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 7000, Status: "REFUND_STATUS_SUCCEEDED"},
+		}, nil)
+
+		_, err := service.RefundPayment(context.Background(), &paymentpb.RefundPaymentRequest{
+			PaymentId: paymentID.String(),
+			Amount:    &sharedpb.Money{Units: 5000, Currency: "JPY"},
+		})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "between 1 and 3000")
+		mockQueries.AssertNotCalled(t, "CreateRefund", mock.Anything, mock.Anything)
+	})
+
 	t.Run("cannot refund non-completed payment", func(t *testing.T) {
 		mockQueries := new(MockQuerier)
 		mockCache := new(cache.MockCache)
@@ -449,6 +1134,256 @@ func TestPaymentService_RefundPayment(t *testing.T) {
 	})
 }
 
+func TestPaymentService_CapturePayment(t *testing.T) {
+	logger := zap.NewNop()
+
+	newAuthorizedPayment := func(expiresAt time.Time) db.Payment {
+		transactionID := "CC-12345-789"
+		return db.Payment{
+			ID:                     uuid.New(),
+			OrderID:                uuid.New(),
+			Method:                 "PAYMENT_METHOD_CREDIT_CARD",
+			AmountMinor:            10000,
+			Currency:               "JPY",
+			Status:                 "PAYMENT_STATUS_AUTHORIZED",
+			TransactionID:          &transactionID,
+			AuthorizationExpiresAt: &expiresAt,
+			CreatedAt:              time.Now(),
+			UpdatedAt:              time.Now(),
+		}
+	}
+
+	t.Run("successful capture by order", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(time.Hour))
+
+		mockQueries.On("ListPaymentsByOrderID", mock.Anything, mockPayment.OrderID).Return([]db.Payment{mockPayment}, nil)
+		mockQueries.On("CapturePayment", mock.Anything, mock.AnythingOfType("db.CapturePaymentParams")).Return(nil)
+		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
+
+		resp, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			OrderId: mockPayment.OrderID.String(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED, resp.Status)
+		assert.Equal(t, int64(10000), resp.CapturedAmount.Units)
+		assert.Contains(t, resp.TransactionId, "CAPTURE-")
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("partial capture", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(time.Hour))
+
+		mockQueries.On("GetPayment", mock.Anything, mockPayment.ID).Return(mockPayment, nil)
+		mockQueries.On("CapturePayment", mock.Anything, mock.MatchedBy(func(p db.CapturePaymentParams) bool {
+			return p.ID == mockPayment.ID && p.AmountMinor == 6000
+		})).Return(nil)
+		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
+
+		resp, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			PaymentId: mockPayment.ID.String(),
+			Amount:    &sharedpb.Money{Units: 6000, Currency: "JPY"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(6000), resp.CapturedAmount.Units)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("cannot capture more than authorized", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(time.Hour))
+
+		mockQueries.On("GetPayment", mock.Anything, mockPayment.ID).Return(mockPayment, nil)
+
+		resp, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			PaymentId: mockPayment.ID.String(),
+			Amount:    &sharedpb.Money{Units: 20000, Currency: "JPY"},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "exceeds authorized amount")
+	})
+
+	t.Run("cannot capture expired authorization", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(-time.Hour))
+
+		mockQueries.On("GetPayment", mock.Anything, mockPayment.ID).Return(mockPayment, nil)
+
+		resp, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			PaymentId: mockPayment.ID.String(),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.Contains(t, err.Error(), "authorization has expired")
+	})
+
+	t.Run("order whose authorization was swept reports it expired", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(-time.Hour))
+		mockPayment.Status = "PAYMENT_STATUS_CANCELLED"
+
+		mockQueries.On("ListPaymentsByOrderID", mock.Anything, mockPayment.OrderID).Return([]db.Payment{mockPayment}, nil)
+
+		_, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			OrderId: mockPayment.OrderID.String(),
+		})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CapturePayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("order without an authorization has nothing to capture", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(time.Hour))
+		mockPayment.Method = "PAYMENT_METHOD_KONBINI_LAWSON"
+		mockPayment.Status = "PAYMENT_STATUS_COMPLETED"
+		mockPayment.AuthorizationExpiresAt = nil
+
+		mockQueries.On("ListPaymentsByOrderID", mock.Anything, mockPayment.OrderID).Return([]db.Payment{mockPayment}, nil)
+
+		_, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			OrderId: mockPayment.OrderID.String(),
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("cannot capture non-authorized payment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		mockPayment := newAuthorizedPayment(time.Now().Add(time.Hour))
+		mockPayment.Status = "PAYMENT_STATUS_CAPTURED"
+
+		mockQueries.On("GetPayment", mock.Anything, mockPayment.ID).Return(mockPayment, nil)
+
+		resp, err := service.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			PaymentId: mockPayment.ID.String(),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "not in authorized status")
+	})
+}
+
+func TestPaymentService_VoidPayment(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful void", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		paymentID := uuid.New()
+		mockPayment := db.Payment{
+			ID:          paymentID,
+			OrderID:     uuid.New(),
+			Method:      "PAYMENT_METHOD_CREDIT_CARD",
+			AmountMinor: 10000,
+			Currency:    "JPY",
+			Status:      "PAYMENT_STATUS_AUTHORIZED",
+		}
+
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(mockPayment, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_CANCELLED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByAPI
+		})).Return(nil)
+		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Twice()
+
+		resp, err := service.VoidPayment(context.Background(), &paymentpb.VoidPaymentRequest{
+			PaymentId: paymentID.String(),
+			Reason:    "customer cancelled",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("sweeper voids expired authorizations", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+		sweeper := NewAuthorizationSweeper(service, logger)
+
+		expired := []db.Payment{
+			{ID: uuid.New(), OrderID: uuid.New(), Status: "PAYMENT_STATUS_AUTHORIZED"},
+			{ID: uuid.New(), OrderID: uuid.New(), Status: "PAYMENT_STATUS_AUTHORIZED"},
+		}
+
+		mockQueries.On("ListExpiredAuthorizations", mock.Anything, mock.AnythingOfType("time.Time"), sweepBatchSize).Return(expired, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.AnythingOfType("db.UpdatePaymentStatusParams")).Return(nil).Twice()
+		mockCache.On("Delete", mock.Anything, mock.AnythingOfType("[]string")).Return(nil).Times(4)
+
+		voided, err := sweeper.VoidExpired(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, voided)
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestPaymentService_ListRefunds(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("lists refunds with totals", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		mockCache := new(cache.MockCache)
+		service := NewPaymentService(mockQueries, mockCache, logger)
+
+		paymentID := uuid.New()
+		reference := "REFUND-1"
+
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID:          paymentID,
+			AmountMinor: 10000,
+			Currency:    "JPY",
+			Status:      "PAYMENT_STATUS_PARTIALLY_REFUNDED",
+		}, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return([]db.Refund{
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 2500, Currency: "JPY", Status: "REFUND_STATUS_SUCCEEDED", ProviderReference: &reference},
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 1000, Currency: "JPY", Status: "REFUND_STATUS_FAILED"},
+		}, nil)
+
+		resp, err := service.ListRefunds(context.Background(), &paymentpb.ListRefundsRequest{PaymentId: paymentID.String()})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Refunds, 2)
+		assert.Equal(t, paymentpb.RefundStatus_REFUND_STATUS_SUCCEEDED, resp.Refunds[0].Status)
+		assert.Equal(t, "REFUND-1", resp.Refunds[0].ProviderReference)
+		assert.Equal(t, int64(2500), resp.RefundedAmount.Units)
+		assert.Equal(t, int64(7500), resp.RefundableAmount.Units)
+	})
+}
+
 func TestPaymentService_paymentToProto(t *testing.T) {
 	logger := zap.NewNop()
 
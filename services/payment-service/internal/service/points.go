@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// pointsCurrency is the currency points are worth 1 unit of
+const pointsCurrency = "JPY"
+
+const defaultPointHistoryPageSize = 20
+
+// PointsService keeps the loyalty point balances of customers. Points are
+// spent at checkout as a PAYMENT_METHOD_POINTS tender of PaymentService.
+type PointsService struct {
+	paymentpb.UnimplementedPointsServiceServer
+	queries db.Querier
+	logger  *zap.Logger
+}
+
+// NewPointsService creates a new points service
+func NewPointsService(queries db.Querier, logger *zap.Logger) *PointsService {
+	return &PointsService{
+		queries: queries,
+		logger:  logger,
+	}
+}
+
+// GetBalance returns the points a customer can spend
+func (s *PointsService) GetBalance(ctx context.Context, req *paymentpb.GetBalanceRequest) (*paymentpb.GetBalanceResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PointsService.GetBalance",
+		trace.WithAttributes(attribute.String("points.user_id", req.UserId)),
+	)
+	defer span.End()
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	balance, err := s.queries.GetPointBalance(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get point balance", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get point balance")
+	}
+
+	resp := &paymentpb.GetBalanceResponse{AvailablePoints: balance.Points}
+	if !balance.UpdatedAt.IsZero() {
+		resp.LastUpdated = timestamppb.New(balance.UpdatedAt)
+	}
+	return resp, nil
+}
+
+// RedeemPoints spends points outside a payment, e.g. for a reward. Points
+// paying for an order are a tender of PayOrder instead, so that they are
+// given back if the order's other tenders fail.
+func (s *PointsService) RedeemPoints(ctx context.Context, req *paymentpb.RedeemPointsRequest) (*paymentpb.RedeemPointsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PointsService.RedeemPoints",
+		trace.WithAttributes(attribute.String("points.user_id", req.UserId)),
+	)
+	defer span.End()
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.Points <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "points must be positive")
+	}
+	var orderID *uuid.UUID
+	if req.OrderId != "" {
+		id, err := uuid.Parse(req.OrderId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+		}
+		orderID = &id
+	}
+
+	balance, t, err := s.queries.AdjustPoints(ctx, db.AdjustPointsParams{
+		UserID:  userID,
+		Type:    paymentpb.PointTransactionType_POINT_TRANSACTION_TYPE_REDEEMED.String(),
+		Points:  -req.Points,
+		OrderID: orderID,
+		Reason:  req.Reason,
+	})
+	if errors.Is(err, db.ErrInsufficientPoints) {
+		return nil, &pointsBalanceError{balance: balance.Points, points: req.Points}
+	}
+	if err != nil {
+		s.logger.Error("Failed to redeem points", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to redeem points")
+	}
+
+	s.logger.Info("Redeemed points",
+		zap.String("user_id", req.UserId),
+		zap.Int64("points", req.Points),
+		zap.Int64("balance", balance.Points))
+
+	return &paymentpb.RedeemPointsResponse{
+		Success:       true,
+		YenValue:      &sharedpb.Money{Units: req.Points, Currency: pointsCurrency},
+		TransactionId: t.ID.String(),
+	}, nil
+}
+
+// IssuePoints credits points to a customer, e.g. earned on an order
+func (s *PointsService) IssuePoints(ctx context.Context, req *paymentpb.IssuePointsRequest) (*sharedpb.Empty, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PointsService.IssuePoints",
+		trace.WithAttributes(attribute.String("points.user_id", req.UserId)),
+	)
+	defer span.End()
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.Points <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "points must be positive")
+	}
+	var orderID *uuid.UUID
+	if req.OrderId != nil && req.OrderId.Value != "" {
+		id, err := uuid.Parse(req.OrderId.Value)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+		}
+		orderID = &id
+	}
+
+	balance, _, err := s.queries.AdjustPoints(ctx, db.AdjustPointsParams{
+		UserID:  userID,
+		Type:    paymentpb.PointTransactionType_POINT_TRANSACTION_TYPE_EARNED.String(),
+		Points:  req.Points,
+		OrderID: orderID,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		s.logger.Error("Failed to issue points", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to issue points")
+	}
+
+	s.logger.Info("Issued points",
+		zap.String("user_id", req.UserId),
+		zap.Int64("points", req.Points),
+		zap.Int64("balance", balance.Points))
+
+	return &sharedpb.Empty{}, nil
+}
+
+// GetHistory returns the ledger of a customer, newest first
+func (s *PointsService) GetHistory(ctx context.Context, req *paymentpb.GetHistoryRequest) (*paymentpb.GetHistoryResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PointsService.GetHistory",
+		trace.WithAttributes(attribute.String("points.user_id", req.UserId)),
+	)
+	defer span.End()
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	page, limit := int32(1), int32(defaultPointHistoryPageSize)
+	if req.Pagination != nil {
+		if req.Pagination.Page > 0 {
+			page = req.Pagination.Page
+		}
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	transactions, total, err := s.queries.ListPointTransactions(ctx, db.ListPointTransactionsParams{
+		UserID: userID,
+		Limit:  int(limit),
+		Offset: int((page - 1) * limit),
+	})
+	if err != nil {
+		s.logger.Error("Failed to list point transactions", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get point history")
+	}
+
+	pbTransactions := make([]*paymentpb.PointTransaction, len(transactions))
+	for i, t := range transactions {
+		pbTransactions[i] = pointTransactionToProto(t)
+	}
+
+	return &paymentpb.GetHistoryResponse{
+		Transactions: pbTransactions,
+		Pagination:   &sharedpb.Pagination{Page: page, Limit: limit, Total: int32(total)},
+	}, nil
+}
+
+// processPoints pays with the points of the customer who placed the order,
+// one point per yen. The points are spent at once, so the payment completes
+// straight away.
+func (s *PaymentService) processPoints(ctx context.Context, payment db.Payment) (paymentpb.PaymentStatus, string, error) {
+	if payment.Currency != pointsCurrency {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Errorf(codes.InvalidArgument, "points can only pay %s amounts", pointsCurrency)
+	}
+
+	userID, err := s.orderCustomer(ctx, payment)
+	if err != nil {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", err
+	}
+
+	points := int64(payment.AmountMinor)
+	balance, t, err := s.queries.AdjustPoints(ctx, db.AdjustPointsParams{
+		UserID:    userID,
+		Type:      paymentpb.PointTransactionType_POINT_TRANSACTION_TYPE_REDEEMED.String(),
+		Points:    -points,
+		OrderID:   &payment.OrderID,
+		PaymentID: &payment.ID,
+	})
+	if errors.Is(err, db.ErrInsufficientPoints) {
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", &pointsBalanceError{balance: balance.Points, points: points}
+	}
+	if err != nil {
+		s.logger.Error("Failed to redeem points", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, "", status.Error(codes.Internal, "failed to redeem points")
+	}
+
+	s.logger.Info("Redeemed points",
+		zap.String("payment_id", payment.ID.String()),
+		zap.String("user_id", userID.String()),
+		zap.Int64("balance", balance.Points))
+
+	transactionID := fmt.Sprintf("POINTS-%s-%d", t.ID.String()[:8], time.Now().Unix())
+	return paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, transactionID, nil
+}
+
+// refundToPoints gives the refunded amount back to the customer whose
+// points paid for the payment
+func (s *PaymentService) refundToPoints(ctx context.Context, payment db.Payment, refund db.Refund) (string, error) {
+	transactions, err := s.queries.ListPointTransactionsByPaymentID(ctx, payment.ID)
+	if err != nil {
+		s.logger.Error("Failed to list point transactions", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		return "", status.Error(codes.Internal, "failed to find points redemption")
+	}
+	var userID uuid.UUID
+	for _, t := range transactions {
+		if t.Type == paymentpb.PointTransactionType_POINT_TRANSACTION_TYPE_REDEEMED.String() {
+			userID = t.UserID
+		}
+	}
+	if userID == uuid.Nil {
+		return "", status.Error(codes.FailedPrecondition, "payment has no points redemption")
+	}
+
+	_, t, err := s.queries.AdjustPoints(ctx, db.AdjustPointsParams{
+		UserID:    userID,
+		Type:      paymentpb.PointTransactionType_POINT_TRANSACTION_TYPE_REFUNDED.String(),
+		Points:    int64(refund.AmountMinor),
+		OrderID:   &payment.OrderID,
+		PaymentID: &payment.ID,
+		RefundID:  &refund.ID,
+	})
+	if err != nil {
+		s.logger.Error("Failed to give back points", zap.String("user_id", userID.String()), zap.Error(err))
+		return "", status.Error(codes.Internal, "failed to give back points")
+	}
+	return t.ID.String(), nil
+}
+
+// pointsBalanceError is a redemption of more points than the customer has.
+// It is a FailedPrecondition that reports the balance.
+type pointsBalanceError struct {
+	balance int64
+	points  int64
+}
+
+func (e *pointsBalanceError) Error() string {
+	return fmt.Sprintf("point balance is %d, less than the %d points to redeem", e.balance, e.points)
+}
+
+func (e *pointsBalanceError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+func pointTransactionToProto(t db.PointTransaction) *paymentpb.PointTransaction {
+	return &paymentpb.PointTransaction{
+		Id:        t.ID.String(),
+		UserId:    t.UserID.String(),
+		Amount:    t.Points,
+		Type:      paymentpb.PointTransactionType(paymentpb.PointTransactionType_value[t.Type]),
+		Reason:    t.Reason,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+}
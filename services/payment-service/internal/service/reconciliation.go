@@ -0,0 +1,442 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+const defaultReconciliationRunsPageSize = 20
+
+// settlementProviders maps a provider name to the payment methods it settles
+var settlementProviders = map[string][]string{
+	"card":    {"PAYMENT_METHOD_CREDIT_CARD"},
+	"paypay":  {"PAYMENT_METHOD_PAYPAY"},
+	"rakuten": {"PAYMENT_METHOD_RAKUTEN_PAY"},
+	"konbini": {
+		"PAYMENT_METHOD_KONBINI_SEVENELEVEN",
+		"PAYMENT_METHOD_KONBINI_LAWSON",
+		"PAYMENT_METHOD_KONBINI_FAMILYMART",
+	},
+}
+
+// settledStatuses are the payment statuses a provider may settle
+var settledStatuses = map[string]bool{
+	paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED.String():          true,
+	paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED.String():           true,
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED.String(): true,
+	paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED.String():           true,
+	paymentpb.PaymentStatus_PAYMENT_STATUS_CHARGED_BACK.String():       true,
+}
+
+// Reconciler matches provider settlement files against recorded payments
+type Reconciler struct {
+	queries db.Querier
+	logger  *zap.Logger
+}
+
+// NewReconciler creates a new settlement reconciler
+func NewReconciler(queries db.Querier, logger *zap.Logger) *Reconciler {
+	return &Reconciler{
+		queries: queries,
+		logger:  logger,
+	}
+}
+
+// ReconcileFile parses a settlement file, choosing the format from its
+// extension, and reconciles it
+func (r *Reconciler) ReconcileFile(ctx context.Context, provider, path string) (db.ReconciliationRun, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return db.ReconciliationRun{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	format := DetectSettlementFormat(path)
+	lines, err := ParseSettlementFile(f, format)
+	if err != nil {
+		return db.ReconciliationRun{}, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return r.Reconcile(ctx, provider, filepath.Base(path), format, lines)
+}
+
+// Reconcile matches settlement lines against payments by transaction_id and
+// amount and stores the results. When the lines carry transaction dates,
+// the provider's payments captured on those days but missing from the file
+// are reported as well.
+func (r *Reconciler) Reconcile(ctx context.Context, provider, fileName, format string, lines []SettlementLine) (db.ReconciliationRun, error) {
+	methods, ok := settlementProviders[provider]
+	if !ok {
+		return db.ReconciliationRun{}, fmt.Errorf("unknown settlement provider %q", provider)
+	}
+
+	transactionIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		transactionIDs = append(transactionIDs, line.TransactionID)
+	}
+	payments, err := r.queries.ListPaymentsByTransactionIDs(ctx, transactionIDs)
+	if err != nil {
+		return db.ReconciliationRun{}, fmt.Errorf("failed to load payments: %w", err)
+	}
+
+	items := matchSettlementLines(lines, payments)
+
+	params := db.CreateReconciliationRunParams{
+		Provider:   provider,
+		FileName:   fileName,
+		FileFormat: format,
+		TotalLines: len(lines),
+	}
+	if from, to, ok := settlementPeriod(lines); ok {
+		params.PeriodStart, params.PeriodEnd = &from, &to
+		captured, err := r.queries.ListSettleablePayments(ctx, methods, from, to)
+		if err != nil {
+			return db.ReconciliationRun{}, fmt.Errorf("failed to load captured payments: %w", err)
+		}
+		items = append(items, unsettledPayments(lines, captured)...)
+	}
+
+	for _, line := range lines {
+		params.SettledAmountMinor += int64(line.AmountMinor)
+	}
+	for _, it := range items {
+		if it.Result == paymentpb.ReconciliationResult_RECONCILIATION_RESULT_MATCHED.String() {
+			params.MatchedCount++
+		} else {
+			params.ExceptionCount++
+		}
+	}
+	params.Items = items
+
+	run, err := r.queries.CreateReconciliationRun(ctx, params)
+	if err != nil {
+		return db.ReconciliationRun{}, fmt.Errorf("failed to store reconciliation run: %w", err)
+	}
+
+	r.logger.Info("Settlement file reconciled",
+		zap.String("run_id", run.ID.String()),
+		zap.String("provider", provider),
+		zap.String("file", fileName),
+		zap.Int("lines", run.TotalLines),
+		zap.Int("matched", run.MatchedCount),
+		zap.Int("exceptions", run.ExceptionCount))
+
+	return run, nil
+}
+
+// matchSettlementLines classifies each settlement line against the payment
+// with the same transaction_id
+func matchSettlementLines(lines []SettlementLine, payments []db.Payment) []db.ReconciliationItem {
+	byTransaction := make(map[string]db.Payment, len(payments))
+	for _, p := range payments {
+		if p.TransactionID != nil {
+			byTransaction[*p.TransactionID] = p
+		}
+	}
+
+	seen := make(map[string]bool, len(lines))
+	items := make([]db.ReconciliationItem, 0, len(lines))
+	for _, line := range lines {
+		lineNumber := line.LineNumber
+		item := db.ReconciliationItem{
+			TransactionID:      &line.TransactionID,
+			LineNumber:         &lineNumber,
+			SettledAmountMinor: &line.AmountMinor,
+			FeeMinor:           line.FeeMinor,
+			Currency:           &line.Currency,
+			SettledOn:          line.SettledOn,
+		}
+
+		payment, found := byTransaction[line.TransactionID]
+		if found {
+			paymentID := payment.ID
+			expected := refundableAmount(payment)
+			item.PaymentID = &paymentID
+			item.ExpectedAmountMinor = &expected
+		}
+
+		var result paymentpb.ReconciliationResult
+		var detail string
+		switch {
+		case seen[line.TransactionID]:
+			result = paymentpb.ReconciliationResult_RECONCILIATION_RESULT_DUPLICATE_SETTLEMENT
+			detail = "transaction settled more than once in this file"
+		case !found:
+			result = paymentpb.ReconciliationResult_RECONCILIATION_RESULT_ORPHAN_SETTLEMENT
+			detail = "no payment with this transaction_id"
+		case !settledStatuses[payment.Status]:
+			result = paymentpb.ReconciliationResult_RECONCILIATION_RESULT_MISSING_CAPTURE
+			detail = "payment is " + payment.Status
+		case line.Currency != payment.Currency:
+			result = paymentpb.ReconciliationResult_RECONCILIATION_RESULT_AMOUNT_MISMATCH
+			detail = fmt.Sprintf("settled in %s, payment is in %s", line.Currency, payment.Currency)
+		case line.AmountMinor != *item.ExpectedAmountMinor:
+			result = paymentpb.ReconciliationResult_RECONCILIATION_RESULT_AMOUNT_MISMATCH
+			detail = fmt.Sprintf("settled %d, captured %d", line.AmountMinor, *item.ExpectedAmountMinor)
+		default:
+			result = paymentpb.ReconciliationResult_RECONCILIATION_RESULT_MATCHED
+		}
+		seen[line.TransactionID] = true
+
+		item.Result = result.String()
+		item.Detail = nullableString(detail)
+		items = append(items, item)
+	}
+	return items
+}
+
+// unsettledPayments reports captured payments that no line settled
+func unsettledPayments(lines []SettlementLine, captured []db.Payment) []db.ReconciliationItem {
+	settled := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		settled[line.TransactionID] = true
+	}
+
+	var items []db.ReconciliationItem
+	for _, p := range captured {
+		if p.TransactionID == nil || settled[*p.TransactionID] {
+			continue
+		}
+		paymentID := p.ID
+		expected := refundableAmount(p)
+		currency := p.Currency
+		items = append(items, db.ReconciliationItem{
+			Result:              paymentpb.ReconciliationResult_RECONCILIATION_RESULT_MISSING_SETTLEMENT.String(),
+			TransactionID:       p.TransactionID,
+			PaymentID:           &paymentID,
+			ExpectedAmountMinor: &expected,
+			Currency:            &currency,
+			Detail:              nullableString("captured but not in settlement file"),
+		})
+	}
+	return items
+}
+
+// settlementPeriod is the range of Japan days covered by the lines'
+// transaction dates, as [first day 00:00, day after last day 00:00)
+func settlementPeriod(lines []SettlementLine) (time.Time, time.Time, bool) {
+	var from, to time.Time
+	for _, line := range lines {
+		if line.TransactionDate == nil {
+			continue
+		}
+		d := *line.TransactionDate
+		if from.IsZero() || d.Before(from) {
+			from = d
+		}
+		if to.IsZero() || d.After(to) {
+			to = d
+		}
+	}
+	if from.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
+
+// ImportInbox reconciles every settlement file in dir. Files must be named
+// <provider>_<anything>, e.g. card_20260401.csv. Reconciled files are moved
+// to dir/processed and files that could not be reconciled to dir/failed.
+func (r *Reconciler) ImportInbox(ctx context.Context, dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	imported := 0
+	for _, name := range names {
+		provider, _, _ := strings.Cut(name, "_")
+		path := filepath.Join(dir, name)
+
+		target := "processed"
+		if _, err := r.ReconcileFile(ctx, strings.ToLower(provider), path); err != nil {
+			r.logger.Error("Failed to reconcile settlement file",
+				zap.String("file", name),
+				zap.Error(err))
+			target = "failed"
+		} else {
+			imported++
+		}
+
+		if err := moveToSubdir(path, target); err != nil {
+			return imported, fmt.Errorf("failed to move %s: %w", name, err)
+		}
+	}
+	return imported, nil
+}
+
+func moveToSubdir(path, subdir string) error {
+	dir := filepath.Join(filepath.Dir(path), subdir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.Rename(path, filepath.Join(dir, filepath.Base(path)))
+}
+
+// StartPeriodicImport starts reconciling files dropped into dir in the
+// background
+func (r *Reconciler) StartPeriodicImport(ctx context.Context, dir string, interval time.Duration) {
+	r.logger.Info("Starting periodic settlement import",
+		zap.String("dir", dir),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := r.ImportInbox(ctx, dir); err != nil {
+					r.logger.Error("Periodic settlement import failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// ListReconciliationRuns lists reconciliation runs, newest first
+func (s *PaymentService) ListReconciliationRuns(ctx context.Context, req *paymentpb.ListReconciliationRunsRequest) (*paymentpb.ListReconciliationRunsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListReconciliationRuns")
+	defer span.End()
+
+	page, limit := int32(1), int32(defaultReconciliationRunsPageSize)
+	if req.Pagination != nil {
+		if req.Pagination.Page > 0 {
+			page = req.Pagination.Page
+		}
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	runs, total, err := s.queries.ListReconciliationRuns(ctx, int(limit), int((page-1)*limit))
+	if err != nil {
+		s.logger.Error("Failed to list reconciliation runs", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list reconciliation runs")
+	}
+
+	pbRuns := make([]*paymentpb.ReconciliationRun, len(runs))
+	for i, run := range runs {
+		pbRuns[i] = reconciliationRunToProto(run)
+	}
+
+	return &paymentpb.ListReconciliationRunsResponse{
+		Runs:       pbRuns,
+		Pagination: &sharedpb.Pagination{Page: page, Limit: limit, Total: int32(total)},
+	}, nil
+}
+
+// GetReconciliationRun returns a run with its exceptions, and its matched
+// items too when include_matched is set
+func (s *PaymentService) GetReconciliationRun(ctx context.Context, req *paymentpb.GetReconciliationRunRequest) (*paymentpb.GetReconciliationRunResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.GetReconciliationRun",
+		trace.WithAttributes(attribute.String("reconciliation.run_id", req.RunId)),
+	)
+	defer span.End()
+
+	runID, err := uuid.Parse(req.RunId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid run_id")
+	}
+
+	run, err := s.queries.GetReconciliationRun(ctx, runID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "reconciliation run not found")
+		}
+		s.logger.Error("Failed to get reconciliation run", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get reconciliation run")
+	}
+
+	items, err := s.queries.ListReconciliationItems(ctx, runID, req.IncludeMatched)
+	if err != nil {
+		s.logger.Error("Failed to list reconciliation items", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get reconciliation run")
+	}
+
+	pbItems := make([]*paymentpb.ReconciliationItem, len(items))
+	for i, it := range items {
+		pbItems[i] = reconciliationItemToProto(it)
+	}
+
+	return &paymentpb.GetReconciliationRunResponse{
+		Run:   reconciliationRunToProto(run),
+		Items: pbItems,
+	}, nil
+}
+
+func reconciliationRunToProto(r db.ReconciliationRun) *paymentpb.ReconciliationRun {
+	return &paymentpb.ReconciliationRun{
+		Id:             r.ID.String(),
+		Provider:       r.Provider,
+		FileName:       r.FileName,
+		FileFormat:     r.FileFormat,
+		PeriodStart:    toTimestampPtr(r.PeriodStart),
+		PeriodEnd:      toTimestampPtr(r.PeriodEnd),
+		TotalLines:     int32(r.TotalLines),
+		MatchedCount:   int32(r.MatchedCount),
+		ExceptionCount: int32(r.ExceptionCount),
+		SettledAmount:  &sharedpb.Money{Units: r.SettledAmountMinor, Currency: "JPY"},
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+	}
+}
+
+func reconciliationItemToProto(it db.ReconciliationItem) *paymentpb.ReconciliationItem {
+	currency := toStringPtr(it.Currency)
+	money := func(v *int) *sharedpb.Money {
+		if v == nil {
+			return nil
+		}
+		return &sharedpb.Money{Units: int64(*v), Currency: currency}
+	}
+
+	pb := &paymentpb.ReconciliationItem{
+		Id:             it.ID.String(),
+		Result:         paymentpb.ReconciliationResult(paymentpb.ReconciliationResult_value[it.Result]),
+		TransactionId:  toStringPtr(it.TransactionID),
+		SettledAmount:  money(it.SettledAmountMinor),
+		ExpectedAmount: money(it.ExpectedAmountMinor),
+		Fee:            money(it.FeeMinor),
+		Detail:         toStringPtr(it.Detail),
+	}
+	if it.PaymentID != nil {
+		pb.PaymentId = it.PaymentID.String()
+	}
+	if it.LineNumber != nil {
+		pb.LineNumber = int32(*it.LineNumber)
+	}
+	if it.SettledOn != nil {
+		pb.SettledOn = it.SettledOn.Format("2006-01-02")
+	}
+	return pb
+}
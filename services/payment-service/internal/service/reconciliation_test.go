@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func settledPayment(transactionID, status string, amount int) db.Payment {
+	return db.Payment{
+		ID:            uuid.New(),
+		OrderID:       uuid.New(),
+		Method:        "PAYMENT_METHOD_CREDIT_CARD",
+		AmountMinor:   amount,
+		Currency:      "JPY",
+		Status:        status,
+		TransactionID: &transactionID,
+	}
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	logger := zap.NewNop()
+	day := time.Date(2026, 4, 1, 0, 0, 0, 0, tokyo)
+
+	t.Run("classifies every line and unsettled captures", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		reconciler := NewReconciler(mockQueries, logger)
+
+		captured := 8000
+		partial := settledPayment("CC-PARTIAL", "PAYMENT_STATUS_CAPTURED", 10000)
+		partial.CapturedAmountMinor = &captured
+		payments := []db.Payment{
+			settledPayment("CC-OK", "PAYMENT_STATUS_CAPTURED", 5000),
+			partial,
+			settledPayment("CC-AUTH", "PAYMENT_STATUS_AUTHORIZED", 3000),
+		}
+		unsettled := settledPayment("CC-UNSETTLED", "PAYMENT_STATUS_CAPTURED", 7000)
+
+		lines := []SettlementLine{
+			{LineNumber: 2, TransactionID: "CC-OK", AmountMinor: 5000, Currency: "JPY", TransactionDate: &day},
+			{LineNumber: 3, TransactionID: "CC-PARTIAL", AmountMinor: 10000, Currency: "JPY", TransactionDate: &day},
+			{LineNumber: 4, TransactionID: "CC-AUTH", AmountMinor: 3000, Currency: "JPY"},
+			{LineNumber: 5, TransactionID: "CC-UNKNOWN", AmountMinor: 100, Currency: "JPY"},
+			{LineNumber: 6, TransactionID: "CC-OK", AmountMinor: 5000, Currency: "JPY"},
+		}
+
+		mockQueries.On("ListPaymentsByTransactionIDs", mock.Anything, mock.Anything).Return(payments, nil)
+		mockQueries.On("ListSettleablePayments", mock.Anything, []string{"PAYMENT_METHOD_CREDIT_CARD"}, day, day.AddDate(0, 0, 1)).
+			Return(append(payments[:2:2], unsettled), nil)
+
+		var stored db.CreateReconciliationRunParams
+		mockQueries.On("CreateReconciliationRun", mock.Anything, mock.MatchedBy(func(p db.CreateReconciliationRunParams) bool {
+			stored = p
+			return true
+		})).Return(db.ReconciliationRun{ID: uuid.New(), TotalLines: 5, MatchedCount: 1, ExceptionCount: 5}, nil)
+
+		run, err := reconciler.Reconcile(context.Background(), "card", "card_20260403.csv", SettlementFormatCSV, lines)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, run.ExceptionCount)
+
+		results := make([]string, len(stored.Items))
+		for i, it := range stored.Items {
+			results[i] = it.Result
+		}
+		assert.Equal(t, []string{
+			"RECONCILIATION_RESULT_MATCHED",
+			"RECONCILIATION_RESULT_AMOUNT_MISMATCH",
+			"RECONCILIATION_RESULT_MISSING_CAPTURE",
+			"RECONCILIATION_RESULT_ORPHAN_SETTLEMENT",
+			"RECONCILIATION_RESULT_DUPLICATE_SETTLEMENT",
+			"RECONCILIATION_RESULT_MISSING_SETTLEMENT",
+		}, results)
+		assert.Equal(t, 8000, *stored.Items[1].ExpectedAmountMinor)
+		assert.Equal(t, "settled 10000, captured 8000", *stored.Items[1].Detail)
+		assert.Equal(t, unsettled.ID, *stored.Items[5].PaymentID)
+		assert.Nil(t, stored.Items[5].LineNumber)
+		assert.Equal(t, 1, stored.MatchedCount)
+		assert.Equal(t, 5, stored.ExceptionCount)
+		assert.Equal(t, int64(23100), stored.SettledAmountMinor)
+		assert.Equal(t, day, *stored.PeriodStart)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("skips the unsettled check without transaction dates", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		reconciler := NewReconciler(mockQueries, logger)
+
+		mockQueries.On("ListPaymentsByTransactionIDs", mock.Anything, []string{"PAYPAY-1"}).
+			Return([]db.Payment{settledPayment("PAYPAY-1", "PAYMENT_STATUS_COMPLETED", 2000)}, nil)
+		mockQueries.On("CreateReconciliationRun", mock.Anything, mock.MatchedBy(func(p db.CreateReconciliationRunParams) bool {
+			return p.PeriodStart == nil && p.MatchedCount == 1 && p.ExceptionCount == 0
+		})).Return(db.ReconciliationRun{ID: uuid.New()}, nil)
+
+		_, err := reconciler.Reconcile(context.Background(), "paypay", "paypay.dat", SettlementFormatFixedWidth, []SettlementLine{
+			{LineNumber: 1, TransactionID: "PAYPAY-1", AmountMinor: 2000, Currency: "JPY"},
+		})
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "ListSettleablePayments", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown provider", func(t *testing.T) {
+		_, err := NewReconciler(new(MockQuerier), logger).Reconcile(context.Background(), "bank", "bank.csv", SettlementFormatCSV, nil)
+		assert.ErrorContains(t, err, "unknown settlement provider")
+	})
+}
+
+func TestReconciler_ImportInbox(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "card_20260403.csv"), []byte("transaction_id,amount\nCC-1,5000\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unknown_20260403.csv"), []byte("transaction_id,amount\nX-1,1\n"), 0o644))
+
+	mockQueries := new(MockQuerier)
+	mockQueries.On("ListPaymentsByTransactionIDs", mock.Anything, []string{"CC-1"}).
+		Return([]db.Payment{settledPayment("CC-1", "PAYMENT_STATUS_CAPTURED", 5000)}, nil)
+	mockQueries.On("CreateReconciliationRun", mock.Anything, mock.Anything).Return(db.ReconciliationRun{ID: uuid.New()}, nil)
+
+	imported, err := NewReconciler(mockQueries, zap.NewNop()).ImportInbox(context.Background(), dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	assert.FileExists(t, filepath.Join(dir, "processed", "card_20260403.csv"))
+	assert.FileExists(t, filepath.Join(dir, "failed", "unknown_20260403.csv"))
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settlement file formats
+const (
+	SettlementFormatCSV        = "csv"
+	SettlementFormatFixedWidth = "fixed_width"
+)
+
+// tokyo is Japan Standard Time. Japan has no daylight saving, so a fixed
+// zone avoids depending on tzdata being installed in the container.
+var tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// SettlementLine is one settled transaction reported by a provider
+type SettlementLine struct {
+	LineNumber    int
+	TransactionID string
+	AmountMinor   int
+	FeeMinor      *int
+	Currency      string
+	SettledOn     *time.Time
+	// TransactionDate is the day the provider captured the transaction
+	TransactionDate *time.Time
+}
+
+// DetectSettlementFormat picks a parser from the file extension: .csv files
+// are CSV and everything else is treated as fixed width.
+func DetectSettlementFormat(name string) string {
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		return SettlementFormatCSV
+	}
+	return SettlementFormatFixedWidth
+}
+
+// ParseSettlementFile parses a settlement file in the given format
+func ParseSettlementFile(r io.Reader, format string) ([]SettlementLine, error) {
+	switch format {
+	case SettlementFormatCSV:
+		return ParseSettlementCSV(r)
+	case SettlementFormatFixedWidth:
+		return ParseSettlementFixedWidth(r)
+	default:
+		return nil, fmt.Errorf("unsupported settlement format %q", format)
+	}
+}
+
+// ParseSettlementCSV parses a CSV settlement file. The first row is a header
+// naming the columns, in any order: transaction_id and amount are required;
+// currency, fee, settled_on and transaction_date are optional. Amounts are
+// in minor units and dates are YYYY-MM-DD, YYYY/MM/DD or YYYYMMDD.
+func ParseSettlementCSV(r io.Reader) ([]SettlementLine, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Files exported from Excel start with a UTF-8 byte order mark
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"transaction_id", "amount"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var lines []SettlementLine
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lineNumber, _ := reader.FieldPos(0)
+
+		line, err := newSettlementLine(
+			lineNumber,
+			field(record, "transaction_id"),
+			field(record, "amount"),
+			field(record, "currency"),
+			field(record, "fee"),
+			field(record, "settled_on"),
+			field(record, "transaction_date"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// Fixed-width record layout. Positions are 1-based and inclusive.
+//
+//	H  header, ignored
+//	D  1 type | 2-33 transaction_id | 34-45 amount | 46-48 currency |
+//	   49-56 settled_on | 57-64 transaction_date | 65-74 fee
+//	T  1 type | 2-9 record count | 10-24 total amount
+//
+// Numbers are zero padded, text is space padded and blank optional fields
+// are allowed.
+const fixedWidthRecordLength = 74
+
+// ParseSettlementFixedWidth parses a fixed-width settlement file. When a
+// trailer record is present its record count and total amount must match the
+// detail records.
+func ParseSettlementFixedWidth(r io.Reader) ([]SettlementLine, error) {
+	scanner := bufio.NewScanner(r)
+
+	var lines []SettlementLine
+	var total int64
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		raw := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		if len(raw) < fixedWidthRecordLength {
+			// Editors and some transfer tools strip trailing blanks
+			raw += strings.Repeat(" ", fixedWidthRecordLength-len(raw))
+		}
+		col := func(from, to int) string {
+			return strings.TrimSpace(raw[from-1 : to])
+		}
+
+		switch raw[0] {
+		case 'H':
+			continue
+		case 'D':
+			line, err := newSettlementLine(
+				lineNumber,
+				col(2, 33),
+				col(34, 45),
+				col(46, 48),
+				col(65, 74),
+				col(49, 56),
+				col(57, 64),
+			)
+			if err != nil {
+				return nil, err
+			}
+			total += int64(line.AmountMinor)
+			lines = append(lines, line)
+		case 'T':
+			count, err := strconv.Atoi(col(2, 9))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid trailer record count", lineNumber)
+			}
+			amount, err := strconv.ParseInt(col(10, 24), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid trailer total", lineNumber)
+			}
+			if count != len(lines) || amount != total {
+				return nil, fmt.Errorf("line %d: trailer reports %d records totalling %d, file has %d totalling %d",
+					lineNumber, count, amount, len(lines), total)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown record type %q", lineNumber, raw[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func newSettlementLine(lineNumber int, transactionID, amount, currency, fee, settledOn, transactionDate string) (SettlementLine, error) {
+	line := SettlementLine{
+		LineNumber:    lineNumber,
+		TransactionID: transactionID,
+		Currency:      strings.ToUpper(currency),
+	}
+	if line.TransactionID == "" {
+		return SettlementLine{}, fmt.Errorf("line %d: missing transaction_id", lineNumber)
+	}
+	if line.Currency == "" {
+		line.Currency = "JPY"
+	}
+
+	var err error
+	if line.AmountMinor, err = parseSettlementAmount(amount); err != nil {
+		return SettlementLine{}, fmt.Errorf("line %d: invalid amount %q", lineNumber, amount)
+	}
+	if fee != "" {
+		v, err := parseSettlementAmount(fee)
+		if err != nil {
+			return SettlementLine{}, fmt.Errorf("line %d: invalid fee %q", lineNumber, fee)
+		}
+		line.FeeMinor = &v
+	}
+	if line.SettledOn, err = parseSettlementDate(settledOn); err != nil {
+		return SettlementLine{}, fmt.Errorf("line %d: invalid settled_on %q", lineNumber, settledOn)
+	}
+	if line.TransactionDate, err = parseSettlementDate(transactionDate); err != nil {
+		return SettlementLine{}, fmt.Errorf("line %d: invalid transaction_date %q", lineNumber, transactionDate)
+	}
+	return line, nil
+}
+
+// parseSettlementAmount accepts whole minor units, optionally with
+// thousands separators
+func parseSettlementAmount(s string) (int, error) {
+	return strconv.Atoi(strings.ReplaceAll(s, ",", ""))
+}
+
+// parseSettlementDate parses a provider date as a day in Japan. Blank and
+// all-zero dates are treated as absent.
+func parseSettlementDate(s string) (*time.Time, error) {
+	if s == "" || strings.Trim(s, "0") == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006/01/02", "20060102"} {
+		if t, err := time.ParseInLocation(layout, s, tokyo); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognised date %q", s)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSettlementCSV(t *testing.T) {
+	t.Run("reads columns by header name", func(t *testing.T) {
+		input := "\ufeffAmount,Transaction_ID,settled_on,fee,transaction_date\n" +
+			"\"12,000\",CC-1,2026-04-03,360,2026/04/01\n" +
+			"5000,PAYPAY-2,20260403,,\n"
+
+		lines, err := ParseSettlementCSV(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "CC-1", lines[0].TransactionID)
+		assert.Equal(t, 12000, lines[0].AmountMinor)
+		assert.Equal(t, "JPY", lines[0].Currency)
+		assert.Equal(t, 360, *lines[0].FeeMinor)
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, tokyo), *lines[0].TransactionDate)
+		assert.Equal(t, 2, lines[0].LineNumber)
+		assert.Nil(t, lines[1].FeeMinor)
+		assert.Nil(t, lines[1].TransactionDate)
+	})
+
+	t.Run("requires transaction_id and amount", func(t *testing.T) {
+		_, err := ParseSettlementCSV(strings.NewReader("transaction_id,settled_on\nCC-1,2026-04-03\n"))
+		assert.ErrorContains(t, err, "missing amount column")
+	})
+
+	t.Run("reports the line of a bad amount", func(t *testing.T) {
+		_, err := ParseSettlementCSV(strings.NewReader("transaction_id,amount\nCC-1,100\nCC-2,abc\n"))
+		assert.ErrorContains(t, err, "line 3")
+	})
+}
+
+func fixedWidthDetail(transactionID string, amount int, settledOn, transactionDate string, fee int) string {
+	return fmt.Sprintf("D%-32s%012d%-3s%-8s%-8s%010d", transactionID, amount, "JPY", settledOn, transactionDate, fee)
+}
+
+func TestParseSettlementFixedWidth(t *testing.T) {
+	t.Run("parses detail records and checks the trailer", func(t *testing.T) {
+		input := strings.Join([]string{
+			"HCARDACQUIRER 20260403",
+			fixedWidthDetail("CC-1", 12000, "20260403", "20260401", 360),
+			fixedWidthDetail("CC-2", 3000, "20260403", "00000000", 90) + "\r",
+			fmt.Sprintf("T%08d%015d", 2, 15000),
+		}, "\n")
+
+		lines, err := ParseSettlementFixedWidth(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "CC-1", lines[0].TransactionID)
+		assert.Equal(t, 12000, lines[0].AmountMinor)
+		assert.Equal(t, 360, *lines[0].FeeMinor)
+		assert.Equal(t, time.Date(2026, 4, 3, 0, 0, 0, 0, tokyo), *lines[0].SettledOn)
+		assert.Nil(t, lines[1].TransactionDate)
+		assert.Equal(t, 3, lines[1].LineNumber)
+	})
+
+	t.Run("rejects a trailer that does not match", func(t *testing.T) {
+		input := fixedWidthDetail("CC-1", 12000, "20260403", "", 0) + "\n" + fmt.Sprintf("T%08d%015d", 1, 11000)
+
+		_, err := ParseSettlementFixedWidth(strings.NewReader(input))
+
+		assert.ErrorContains(t, err, "trailer reports 1 records totalling 11000")
+	})
+
+	t.Run("tolerates stripped trailing blanks", func(t *testing.T) {
+		input := strings.TrimRight(fmt.Sprintf("D%-32s%012d", "CC-1", 500), " ")
+
+		lines, err := ParseSettlementFixedWidth(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, lines, 1)
+		assert.Equal(t, 500, lines[0].AmountMinor)
+		assert.Equal(t, "JPY", lines[0].Currency)
+	})
+}
+
+func TestDetectSettlementFormat(t *testing.T) {
+	assert.Equal(t, SettlementFormatCSV, DetectSettlementFormat("card_20260403.CSV"))
+	assert.Equal(t, SettlementFormatFixedWidth, DetectSettlementFormat("card_20260403.dat"))
+}
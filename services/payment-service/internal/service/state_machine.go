@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"go.uber.org/zap"
+)
+
+// PaymentStateMachine manages payment status transitions
+type PaymentStateMachine struct {
+	logger *zap.Logger
+}
+
+// NewPaymentStateMachine creates a new payment state machine
+func NewPaymentStateMachine(logger *zap.Logger) *PaymentStateMachine {
+	return &PaymentStateMachine{
+		logger: logger,
+	}
+}
+
+// Valid transitions map
+var validPaymentTransitions = map[paymentpb.PaymentStatus][]paymentpb.PaymentStatus{
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING: {
+		paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED,
+	},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING: {
+		paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED,
+	},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED: {
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED,
+	},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED: {
+		paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CHARGED_BACK,
+	},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED: {
+		paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CHARGED_BACK,
+	},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED: {
+		paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED,
+		paymentpb.PaymentStatus_PAYMENT_STATUS_CHARGED_BACK,
+	},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED:       {},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED:    {},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED:     {},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED:      {},
+	paymentpb.PaymentStatus_PAYMENT_STATUS_CHARGED_BACK: {},
+}
+
+// CanTransition checks if a status transition is valid
+func (sm *PaymentStateMachine) CanTransition(from, to paymentpb.PaymentStatus) bool {
+	allowedStates, exists := validPaymentTransitions[from]
+	if !exists {
+		return false
+	}
+
+	for _, allowed := range allowedStates {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transition performs a status transition
+func (sm *PaymentStateMachine) Transition(
+	paymentID string,
+	currentStatus paymentpb.PaymentStatus,
+	newStatus paymentpb.PaymentStatus,
+) error {
+	if !sm.CanTransition(currentStatus, newStatus) {
+		return fmt.Errorf("invalid status transition from %s to %s for payment %s",
+			currentStatus, newStatus, paymentID)
+	}
+
+	sm.logger.Info("Payment status transition",
+		zap.String("payment_id", paymentID),
+		zap.String("from", currentStatus.String()),
+		zap.String("to", newStatus.String()))
+
+	return nil
+}
+
+// IsFinal checks if a status is a final state (no further transitions)
+func (sm *PaymentStateMachine) IsFinal(status paymentpb.PaymentStatus) bool {
+	allowedStates, exists := validPaymentTransitions[status]
+	return exists && len(allowedStates) == 0
+}
+
+// IsRefundable checks if money was taken that can still be refunded
+func (sm *PaymentStateMachine) IsRefundable(status paymentpb.PaymentStatus) bool {
+	return sm.CanTransition(status, paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED)
+}
+
+// ParsePaymentStatus converts a stored status name to the enum; unknown names
+// are PAYMENT_STATUS_UNSPECIFIED
+func ParsePaymentStatus(name string) paymentpb.PaymentStatus {
+	return paymentpb.PaymentStatus(paymentpb.PaymentStatus_value[name])
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+)
+
+// Who made a payment status change, as recorded in the status history
+const (
+	changedByAPI                  = "api"
+	changedByWebhook              = "webhook"
+	changedByAuthorizationSweeper = "authorization-sweeper"
+	changedByBankTransfer         = "bank-transfer"
+	changedByBankTransferSweeper  = "bank-transfer-sweeper"
+	changedByDeliveryService      = "delivery-service"
+	changedByDeferredWorker       = "deferred-payment-worker"
+	changedByKonbiniSweeper       = "konbini-sweeper"
+)
+
+// statusChange describes why a payment changes status
+type statusChange struct {
+	// transactionID is written to the payment when non-nil
+	transactionID *string
+	changedBy     string
+	reason        string
+	// providerResponse is the provider payload behind the change, stored
+	// as JSON
+	providerResponse interface{}
+}
+
+// ListPaymentStatusHistory returns the audit trail of a payment's status changes
+func (s *PaymentService) ListPaymentStatusHistory(ctx context.Context, req *paymentpb.ListPaymentStatusHistoryRequest) (*paymentpb.ListPaymentStatusHistoryResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListPaymentStatusHistory",
+		trace.WithAttributes(attribute.String("payment.id", req.PaymentId)),
+	)
+	defer span.End()
+
+	paymentID, err := uuid.Parse(req.PaymentId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid payment_id")
+	}
+
+	if _, err := s.queries.GetPayment(ctx, paymentID); err != nil {
+		s.logger.Error("Failed to get payment", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+
+	history, err := s.queries.ListPaymentStatusHistory(ctx, paymentID)
+	if err != nil {
+		s.logger.Error("Failed to list payment status history", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list payment status history")
+	}
+
+	changes := make([]*paymentpb.PaymentStatusChange, len(history))
+	for i, h := range history {
+		changes[i] = &paymentpb.PaymentStatusChange{
+			Id:               h.ID.String(),
+			PaymentId:        h.PaymentID.String(),
+			FromStatus:       ParsePaymentStatus(h.FromStatus),
+			ToStatus:         ParsePaymentStatus(h.ToStatus),
+			ChangedBy:        h.ChangedBy,
+			Reason:           toStringPtr(h.Reason),
+			ProviderResponse: string(h.ProviderResponse),
+			CreatedAt:        timestamppb.New(h.CreatedAt),
+		}
+	}
+
+	return &paymentpb.ListPaymentStatusHistoryResponse{Changes: changes}, nil
+}
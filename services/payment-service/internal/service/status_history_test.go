@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func TestPaymentStateMachine_IsRefundable(t *testing.T) {
+	sm := NewPaymentStateMachine(zap.NewNop())
+
+	assert.True(t, sm.IsRefundable(paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED))
+	assert.True(t, sm.IsRefundable(paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED))
+	assert.True(t, sm.IsRefundable(paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED))
+	assert.False(t, sm.IsRefundable(paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED))
+	assert.False(t, sm.IsRefundable(paymentpb.PaymentStatus_PAYMENT_STATUS_REFUNDED))
+	assert.False(t, sm.IsRefundable(ParsePaymentStatus("PAYMENT_STATUS_BOGUS")))
+}
+
+func TestPaymentService_TransitionPayment(t *testing.T) {
+	t.Run("records the change with the provider response", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), Status: "PAYMENT_STATUS_PROCESSING"}
+
+		var params db.UpdatePaymentStatusParams
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { params = args.Get(1).(db.UpdatePaymentStatusParams) }).
+			Return(nil)
+
+		err := s.transitionPayment(context.Background(), payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, statusChange{
+			changedBy:        changedByWebhook,
+			reason:           "paid at the counter",
+			providerResponse: map[string]interface{}{"confirmation_number": "KB-1"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "PAYMENT_STATUS_COMPLETED", params.Status)
+		assert.Equal(t, "PAYMENT_STATUS_PROCESSING", params.Change.FromStatus)
+		assert.Equal(t, changedByWebhook, params.Change.ChangedBy)
+		require.NotNil(t, params.Change.Reason)
+		assert.Equal(t, "paid at the counter", *params.Change.Reason)
+		assert.JSONEq(t, `{"confirmation_number":"KB-1"}`, string(params.Change.ProviderResponse))
+	})
+
+	t.Run("rejects an illegal jump without writing", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), Status: "PAYMENT_STATUS_REFUNDED"}
+
+		err := s.transitionPayment(context.Background(), payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, statusChange{changedBy: changedByAPI})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports a concurrent change", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		s := newTestPaymentService(mockQueries)
+		payment := db.Payment{ID: uuid.New(), OrderID: uuid.New(), Status: "PAYMENT_STATUS_AUTHORIZED"}
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.Anything).Return(db.ErrPaymentStatusChanged)
+
+		err := s.transitionPayment(context.Background(), payment, paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED, statusChange{changedBy: changedByAPI})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestPaymentService_ListPaymentStatusHistory(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	s := newTestPaymentService(mockQueries)
+	paymentID := uuid.New()
+	reason := "authorization expired"
+	response, _ := json.Marshal(map[string]string{"authorization_id": "CC-1"})
+
+	mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{ID: paymentID}, nil)
+	mockQueries.On("ListPaymentStatusHistory", mock.Anything, paymentID).Return([]db.PaymentStatusHistory{
+		{
+			ID:         uuid.New(),
+			PaymentID:  paymentID,
+			FromStatus: "PAYMENT_STATUS_PENDING",
+			ToStatus:   "PAYMENT_STATUS_AUTHORIZED",
+			ChangedBy:  changedByAPI,
+			CreatedAt:  time.Now().Add(-time.Hour),
+		},
+		{
+			ID:               uuid.New(),
+			PaymentID:        paymentID,
+			FromStatus:       "PAYMENT_STATUS_AUTHORIZED",
+			ToStatus:         "PAYMENT_STATUS_CANCELLED",
+			ChangedBy:        changedByAuthorizationSweeper,
+			Reason:           &reason,
+			ProviderResponse: response,
+			CreatedAt:        time.Now(),
+		},
+	}, nil)
+
+	resp, err := s.ListPaymentStatusHistory(context.Background(), &paymentpb.ListPaymentStatusHistoryRequest{PaymentId: paymentID.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 2)
+
+	assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING, resp.Changes[0].FromStatus)
+	assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, resp.Changes[0].ToStatus)
+	assert.Empty(t, resp.Changes[0].ProviderResponse)
+	assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_CANCELLED, resp.Changes[1].ToStatus)
+	assert.Equal(t, "authorization-sweeper", resp.Changes[1].ChangedBy)
+	assert.Equal(t, reason, resp.Changes[1].Reason)
+	assert.JSONEq(t, `{"authorization_id":"CC-1"}`, resp.Changes[1].ProviderResponse)
+}
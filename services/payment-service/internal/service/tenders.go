@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// combinableMethods settle or authorize while the order is being paid, so a
+// later tender failing can still undo them. The other methods complete days
+// later and must be an order's only tender.
+var combinableMethods = map[paymentpb.PaymentMethod]bool{
+	paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD: true,
+	paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY:      true,
+	paymentpb.PaymentMethod_PAYMENT_METHOD_RAKUTEN_PAY: true,
+	paymentpb.PaymentMethod_PAYMENT_METHOD_GIFT_CARD:   true,
+	paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS:      true,
+}
+
+// openPaymentStatuses are the statuses of a payment that still counts
+// towards its order
+var openPaymentStatuses = []string{
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING.String(),
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PROCESSING.String(),
+	paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED.String(),
+	paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED.String(),
+	paymentpb.PaymentStatus_PAYMENT_STATUS_CAPTURED.String(),
+	paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED.String(),
+}
+
+// orderTendersStaleAfter is how long a PayOrder call holds its order. A
+// claim this old belongs to a call that died and is given up.
+const orderTendersStaleAfter = 10 * time.Minute
+
+// Outcomes of a PayOrder call
+const (
+	orderTendersSucceeded = "SUCCEEDED"
+	orderTendersFailed    = "FAILED"
+)
+
+// SetOrderClient sets the order service client used to check multi-tender
+// payments against the order total (optional)
+func (s *PaymentService) SetOrderClient(orderClient orderpb.OrderServiceClient) {
+	s.orderClient = orderClient
+}
+
+// PayOrder pays an order with one or more tenders, e.g. PayPay plus a card.
+// The tenders must add up to the order total. They are processed in order,
+// and if one fails the tenders already taken are voided or refunded. Only
+// one call at a time can pay an order, and the order hears one outcome: a
+// single payment.authorized or payment.completed once every tender is taken.
+func (s *PaymentService) PayOrder(ctx context.Context, req *paymentpb.PayOrderRequest) (*paymentpb.PayOrderResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.PayOrder",
+		trace.WithAttributes(
+			attribute.String("payment.order_id", req.OrderId),
+			attribute.Int("payment.tenders", len(req.Tenders)),
+		),
+	)
+	defer span.End()
+
+	s.logger.Info("Paying order", zap.String("order_id", req.OrderId), zap.Int("tenders", len(req.Tenders)))
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	if err := validateTenders(req.Tenders); err != nil {
+		return nil, err
+	}
+	if err := s.checkTendersCoverOrder(ctx, req.OrderId, req.Tenders); err != nil {
+		return nil, err
+	}
+
+	claimID, err := s.queries.StartOrderTenders(ctx, db.StartOrderTendersParams{
+		OrderID:      orderID,
+		OpenStatuses: openPaymentStatuses,
+		StaleBefore:  time.Now().Add(-orderTendersStaleAfter),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrOrderPaymentInProgress) {
+			return nil, status.Error(codes.AlreadyExists, "order already has a payment in progress")
+		}
+		s.logger.Error("Failed to claim order for payment", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to start payment")
+	}
+
+	var taken []db.Payment
+	for i, tender := range req.Tenders {
+		payment, err := s.processTender(ctx, orderID, tender)
+		if err != nil {
+			s.logger.Warn("Tender failed, rolling back earlier tenders",
+				zap.String("order_id", req.OrderId),
+				zap.Int("tender", i+1),
+				zap.String("method", tender.Method.String()),
+				zap.Error(err))
+			s.rollbackTenders(ctx, taken, fmt.Sprintf("tender %d of the order failed", i+1))
+			s.finishOrderTenders(ctx, claimID, orderTendersFailed)
+			err = s.giftCardBalanceAfterRollback(ctx, err)
+			return nil, status.Errorf(status.Code(err), "tender %d (%s): %s", i+1, tender.Method, status.Convert(err).Message())
+		}
+		taken = append(taken, payment)
+	}
+
+	s.finishOrderTenders(ctx, claimID, orderTendersSucceeded)
+	s.publishOrderPaid(ctx, taken)
+
+	payments := make([]*paymentpb.Payment, len(taken))
+	for i, p := range taken {
+		payments[i] = s.paymentToProto(p)
+	}
+	return &paymentpb.PayOrderResponse{Payments: payments}, nil
+}
+
+// finishOrderTenders releases the order claimed by a PayOrder call
+func (s *PaymentService) finishOrderTenders(ctx context.Context, claimID uuid.UUID, outcome string) {
+	if err := s.queries.FinishOrderTenders(ctx, claimID, outcome); err != nil {
+		// The claim goes stale and is given up by the next PayOrder
+		s.logger.Error("Failed to release order payment claim",
+			zap.String("claim_id", claimID.String()),
+			zap.String("outcome", outcome),
+			zap.Error(err))
+	}
+}
+
+// publishOrderPaid publishes the one event of a PayOrder call that took all
+// its tenders: payment.authorized while any tender still awaits capture,
+// otherwise payment.completed
+func (s *PaymentService) publishOrderPaid(ctx context.Context, taken []db.Payment) {
+	eventType := PaymentEventCompleted
+	eventStatus := paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED
+	paymentIDs := make([]string, len(taken))
+	amount := 0
+	for i, p := range taken {
+		if ParsePaymentStatus(p.Status) == paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+			eventType = PaymentEventAuthorized
+			eventStatus = paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED
+		}
+		paymentIDs[i] = p.ID.String()
+		amount += p.AmountMinor
+	}
+
+	last := taken[len(taken)-1]
+	s.publishPaymentEvent(ctx, eventType, last, eventStatus, map[string]interface{}{
+		"payment_ids": paymentIDs,
+		"amount":      amount,
+		"currency":    last.Currency,
+	})
+}
+
+// publishTenderEvent publishes a provider's update of one payment, unless the
+// payment's order is still being paid by PayOrder: the order must not be
+// confirmed or timed out on one tender while the others are still taken.
+// PayOrder publishes the outcome for the whole order once it is done.
+func (s *PaymentService) publishTenderEvent(ctx context.Context, eventType string, payment db.Payment, newStatus paymentpb.PaymentStatus, data map[string]interface{}) {
+	inProgress, err := s.queries.OrderTendersInProgress(ctx, payment.OrderID)
+	if err != nil {
+		s.logger.Warn("Failed to check order payment in progress", zap.Error(err))
+	}
+	if inProgress {
+		s.logger.Info("Holding back payment event while the order is being paid",
+			zap.String("event_type", eventType),
+			zap.String("payment_id", payment.ID.String()),
+			zap.String("order_id", payment.OrderID.String()))
+		return
+	}
+
+	s.publishPaymentEvent(ctx, eventType, payment, newStatus, data)
+}
+
+// validateTenders checks the tenders on their own: positive amounts in one
+// currency, at most one paid in points, and only combinable methods when
+// there are several
+func validateTenders(tenders []*paymentpb.Tender) error {
+	if len(tenders) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one tender is required")
+	}
+
+	currency := ""
+	points := false
+	for i, t := range tenders {
+		if t.Method == paymentpb.PaymentMethod_PAYMENT_METHOD_UNSPECIFIED {
+			return status.Errorf(codes.InvalidArgument, "tender %d: method is required", i+1)
+		}
+		if t.Method == paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS {
+			if points {
+				return status.Errorf(codes.InvalidArgument, "tender %d: points can only be one tender", i+1)
+			}
+			points = true
+		}
+		if t.Amount == nil || t.Amount.Units <= 0 {
+			return status.Errorf(codes.InvalidArgument, "tender %d: amount must be positive", i+1)
+		}
+		if currency == "" {
+			currency = t.Amount.Currency
+		} else if t.Amount.Currency != currency {
+			return status.Errorf(codes.InvalidArgument, "tender %d: all tenders must be in %s", i+1, currency)
+		}
+		if len(tenders) > 1 && !combinableMethods[t.Method] {
+			return status.Errorf(codes.InvalidArgument, "tender %d: %s cannot be combined with other tenders", i+1, t.Method)
+		}
+	}
+	return nil
+}
+
+// checkTendersCoverOrder checks that the order is awaiting payment and the
+// tenders add up to exactly its total
+func (s *PaymentService) checkTendersCoverOrder(ctx context.Context, orderID string, tenders []*paymentpb.Tender) error {
+	if s.orderClient == nil {
+		return status.Error(codes.FailedPrecondition, "order payments are not available")
+	}
+
+	resp, err := s.orderClient.GetOrder(ctx, &orderpb.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		s.logger.Error("Failed to get order", zap.String("order_id", orderID), zap.Error(err))
+		if status.Code(err) == codes.NotFound {
+			return status.Error(codes.NotFound, "order not found")
+		}
+		return status.Error(codes.Unavailable, "failed to get order")
+	}
+
+	order := resp.Order
+	if order.Status != orderpb.OrderStatus_ORDER_STATUS_PENDING {
+		return status.Errorf(codes.FailedPrecondition, "order is not awaiting payment: %s", order.Status)
+	}
+
+	var sum int64
+	for _, t := range tenders {
+		sum += t.Amount.Units
+	}
+	total := order.TotalAmount
+	if tenders[0].Amount.Currency != total.Currency || sum != total.Units {
+		return status.Errorf(codes.InvalidArgument, "tenders add up to %d %s but the order total is %d %s",
+			sum, tenders[0].Amount.Currency, total.Units, total.Currency)
+	}
+	return nil
+}
+
+// processTender creates and processes the payment of one tender. A tender
+// that fails is left FAILED.
+func (s *PaymentService) processTender(ctx context.Context, orderID uuid.UUID, tender *paymentpb.Tender) (db.Payment, error) {
+	paymentID, err := s.queries.CreatePayment(ctx, db.CreatePaymentParams{
+		OrderID:     orderID,
+		Method:      tender.Method.String(),
+		AmountMinor: int(tender.Amount.Units),
+		Currency:    tender.Amount.Currency,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create payment", zap.Error(err))
+		return db.Payment{}, status.Error(codes.Internal, "failed to create payment")
+	}
+	_ = s.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(orderID.String()))
+
+	resp, processErr := s.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{
+		PaymentId:   paymentID.String(),
+		PaymentData: tender.PaymentData,
+	})
+	if processErr == nil && resp.Status == paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED {
+		return db.Payment{}, status.Error(codes.Aborted, "payment was declined")
+	}
+
+	payment, err := s.queries.GetPayment(ctx, paymentID)
+	if err != nil {
+		s.logger.Error("Failed to get payment", zap.Error(err))
+		return db.Payment{}, status.Error(codes.Internal, "failed to get payment")
+	}
+
+	if processErr != nil {
+		if ParsePaymentStatus(payment.Status) == paymentpb.PaymentStatus_PAYMENT_STATUS_PENDING {
+			if err := s.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, statusChange{
+				changedBy: changedByAPI,
+				reason:    status.Convert(processErr).Message(),
+			}); err != nil {
+				s.logger.Error("Failed to fail tender payment", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+			}
+		}
+		return db.Payment{}, processErr
+	}
+	return payment, nil
+}
+
+// rollbackTenders gives back the tenders already taken, newest first:
+// authorizations are voided and completed payments refunded in full
+func (s *PaymentService) rollbackTenders(ctx context.Context, taken []db.Payment, reason string) {
+	for i := len(taken) - 1; i >= 0; i-- {
+		payment := taken[i]
+
+		var err error
+		switch ParsePaymentStatus(payment.Status) {
+		case paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED:
+			err = s.voidAuthorization(ctx, payment, reason, changedByAPI)
+		case paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED:
+			_, err = s.RefundPayment(ctx, &paymentpb.RefundPaymentRequest{
+				PaymentId: payment.ID.String(),
+				Reason:    reason,
+			})
+		}
+		if err != nil {
+			// Left for an operator; the payment stays in its status history
+			s.logger.Error("Failed to roll back tender",
+				zap.String("payment_id", payment.ID.String()),
+				zap.String("status", payment.Status),
+				zap.Error(err))
+		}
+	}
+}
+
+// ListPaymentsByOrder returns every payment made towards an order
+func (s *PaymentService) ListPaymentsByOrder(ctx context.Context, req *paymentpb.ListPaymentsByOrderRequest) (*paymentpb.ListPaymentsByOrderResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListPaymentsByOrder",
+		trace.WithAttributes(attribute.String("payment.order_id", req.OrderId)),
+	)
+	defer span.End()
+
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+
+	cacheKey := cache.PaymentsByOrderCacheKey(req.OrderId)
+	var payments []db.Payment
+	if err := s.cache.Get(ctx, cacheKey, &payments); err != nil {
+		payments, err = s.queries.ListPaymentsByOrderID(ctx, orderID)
+		if err != nil {
+			s.logger.Error("Failed to list payments for order", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to list payments")
+		}
+		if err := s.cache.Set(ctx, cacheKey, payments, cache.DefaultTTL); err != nil {
+			s.logger.Warn("Failed to cache order payments", zap.Error(err))
+		}
+	}
+
+	resp := &paymentpb.ListPaymentsByOrderResponse{Payments: make([]*paymentpb.Payment, len(payments))}
+	for i, p := range payments {
+		resp.Payments[i] = s.paymentToProto(p)
+	}
+	return resp, nil
+}
@@ -0,0 +1,477 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orderpb "github.com/afasari/shinkansen-commerce/gen/proto/go/order"
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// fakeOrderClient returns one order
+type fakeOrderClient struct {
+	orderpb.OrderServiceClient
+	order *orderpb.Order
+}
+
+func (c *fakeOrderClient) GetOrder(ctx context.Context, req *orderpb.GetOrderRequest, opts ...grpc.CallOption) (*orderpb.GetOrderResponse, error) {
+	if c.order == nil || c.order.Id != req.OrderId {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &orderpb.GetOrderResponse{Order: c.order}, nil
+}
+
+func pendingOrder(total int64) *orderpb.Order {
+	return &orderpb.Order{
+		Id:          uuid.New().String(),
+		Status:      orderpb.OrderStatus_ORDER_STATUS_PENDING,
+		TotalAmount: &sharedpb.Money{Units: total, Currency: "JPY"},
+	}
+}
+
+func tender(method paymentpb.PaymentMethod, amount int64) *paymentpb.Tender {
+	t := &paymentpb.Tender{Method: method, Amount: &sharedpb.Money{Units: amount, Currency: "JPY"}}
+	if method == paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD {
+		t.PaymentData = map[string]string{"card_token": "tok_visa_4242"}
+	}
+	return t
+}
+
+func TestValidateTenders(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenders []*paymentpb.Tender
+		wantErr bool
+	}{
+		{"no tenders", nil, true},
+		{"single card", []*paymentpb.Tender{tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 1000)}, false},
+		{"single konbini", []*paymentpb.Tender{tender(paymentpb.PaymentMethod_PAYMENT_METHOD_KONBINI_LAWSON, 1000)}, false},
+		{"PayPay plus card", []*paymentpb.Tender{
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, 500),
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 1000),
+		}, false},
+		{"missing method", []*paymentpb.Tender{tender(paymentpb.PaymentMethod_PAYMENT_METHOD_UNSPECIFIED, 1000)}, true},
+		{"zero amount", []*paymentpb.Tender{tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 0)}, true},
+		{"mixed currencies", []*paymentpb.Tender{
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, 500),
+			{Method: paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, Amount: &sharedpb.Money{Units: 10, Currency: "USD"}},
+		}, true},
+		{"points plus gift card plus card", []*paymentpb.Tender{
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS, 500),
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_GIFT_CARD, 500),
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 1000),
+		}, false},
+		{"points twice", []*paymentpb.Tender{
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS, 500),
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS, 500),
+		}, true},
+		{"bank transfer combined", []*paymentpb.Tender{
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, 500),
+			tender(paymentpb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER, 1000),
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTenders(tt.tenders)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPaymentService_PayOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects tenders that do not add up to the order total", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := pendingOrder(10000)
+		s := newTestPaymentService(mockQueries, withOrder(order))
+
+		_, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, 2000),
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 7000),
+			},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an order that is no longer pending", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := pendingOrder(10000)
+		order.Status = orderpb.OrderStatus_ORDER_STATUS_CONFIRMED
+		s := newTestPaymentService(mockQueries, withOrder(order))
+
+		_, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 10000)},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("rejects an order already being paid", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := pendingOrder(10000)
+		s := newTestPaymentService(mockQueries, withOrder(order))
+		orderID := uuid.MustParse(order.Id)
+
+		mockQueries.On("StartOrderTenders", mock.Anything, mock.MatchedBy(func(p db.StartOrderTendersParams) bool {
+			return p.OrderID == orderID && len(p.OpenStatuses) > 0
+		})).Return(uuid.Nil, db.ErrOrderPaymentInProgress)
+
+		_, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 10000)},
+		})
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		mockQueries.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pays with PayPay and a card", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := pendingOrder(10000)
+		s := newTestPaymentService(mockQueries, withOrder(order))
+		orderID := uuid.MustParse(order.Id)
+
+		paypay := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_PAYPAY", AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		card := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 8000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		completedPayPay := paypay
+		completedPayPay.Status = "PAYMENT_STATUS_COMPLETED"
+		authorizedCard := card
+		authorizedCard.Status = "PAYMENT_STATUS_AUTHORIZED"
+
+		claimID := uuid.New()
+		mockQueries.On("StartOrderTenders", mock.Anything, mock.Anything).Return(claimID, nil)
+		mockQueries.On("FinishOrderTenders", mock.Anything, claimID, "SUCCEEDED").Return(nil).Once()
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == PaymentEventAuthorized
+		})).Return(0, nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.Method == "PAYMENT_METHOD_PAYPAY" && p.AmountMinor == 2000
+		})).Return(paypay.ID, nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.Method == "PAYMENT_METHOD_CREDIT_CARD" && p.AmountMinor == 8000
+		})).Return(card.ID, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, paypay.ID).Return(paypay, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, paypay.ID).Return(completedPayPay, nil)
+		mockQueries.On("GetPayment", mock.Anything, card.ID).Return(card, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, card.ID).Return(authorizedCard, nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paypay.ID && p.Status == "PAYMENT_STATUS_COMPLETED"
+		})).Return(nil).Once()
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.MatchedBy(func(p db.AuthorizePaymentParams) bool {
+			return p.ID == card.ID
+		})).Return(nil).Once()
+
+		resp, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, 2000),
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 8000),
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Payments, 2)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Payments[0].Status)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, resp.Payments[1].Status)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("refunds PayPay when the card fails", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := pendingOrder(10000)
+		s := newTestPaymentService(mockQueries, withOrder(order))
+		orderID := uuid.MustParse(order.Id)
+
+		paypay := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_PAYPAY", AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		card := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 8000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		completedPayPay := paypay
+		completedPayPay.Status = "PAYMENT_STATUS_COMPLETED"
+		refundID := uuid.New()
+
+		claimID := uuid.New()
+		mockQueries.On("StartOrderTenders", mock.Anything, mock.Anything).Return(claimID, nil)
+		mockQueries.On("FinishOrderTenders", mock.Anything, claimID, "FAILED").Return(nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.Method == "PAYMENT_METHOD_PAYPAY"
+		})).Return(paypay.ID, nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.Method == "PAYMENT_METHOD_CREDIT_CARD"
+		})).Return(card.ID, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, paypay.ID).Return(paypay, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, paypay.ID).Return(completedPayPay, nil)
+		mockQueries.On("GetPayment", mock.Anything, card.ID).Return(card, nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paypay.ID && p.Status == "PAYMENT_STATUS_COMPLETED"
+		})).Return(nil).Once()
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == card.ID && p.Status == "PAYMENT_STATUS_FAILED"
+		})).Return(nil).Once()
+
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paypay.ID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.PaymentID == paypay.ID && p.AmountMinor == 2000
+		})).Return(db.Refund{ID: refundID, PaymentID: paypay.ID, AmountMinor: 2000, Currency: "JPY"}, 2000, nil)
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.MatchedBy(func(p db.UpdateRefundStatusParams) bool {
+			return p.ID == refundID
+		})).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paypay.ID && p.Status == "PAYMENT_STATUS_REFUNDED"
+		})).Return(nil).Once()
+
+		_, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, 2000),
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 8000),
+			},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "tender 2")
+		mockQueries.AssertExpectations(t)
+		mockQueries.AssertNotCalled(t, "EnqueueWebhookDeliveries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pays with points, a gift card and a card", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		order.TotalAmount.Units = 10000
+		s := newTestPaymentService(mockQueries, withOrder(order))
+		orderID := uuid.MustParse(order.Id)
+		owner := uuid.MustParse(order.UserId)
+
+		points := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_POINTS", AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		giftCard := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_GIFT_CARD", AmountMinor: 3000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		card := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		storeCredit := db.GiftCard{ID: uuid.New(), Type: "GIFT_CARD_TYPE_STORE_CREDIT", Status: "GIFT_CARD_STATUS_ACTIVE",
+			BalanceMinor: 3000, Currency: "JPY", UserID: &owner, ExpiresAt: time.Now().Add(time.Hour)}
+
+		claimID := uuid.New()
+		mockQueries.On("StartOrderTenders", mock.Anything, mock.Anything).Return(claimID, nil)
+		mockQueries.On("FinishOrderTenders", mock.Anything, claimID, "SUCCEEDED").Return(nil).Once()
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.Anything).Return(0, nil)
+		for _, p := range []db.Payment{points, giftCard, card} {
+			p := p
+			mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(arg db.CreatePaymentParams) bool {
+				return arg.Method == p.Method && arg.AmountMinor == p.AmountMinor
+			})).Return(p.ID, nil).Once()
+			mockQueries.On("GetPayment", mock.Anything, p.ID).Return(p, nil).Once()
+		}
+		completedPoints := points
+		completedPoints.Status = "PAYMENT_STATUS_COMPLETED"
+		completedGiftCard := giftCard
+		completedGiftCard.Status = "PAYMENT_STATUS_COMPLETED"
+		authorizedCard := card
+		authorizedCard.Status = "PAYMENT_STATUS_AUTHORIZED"
+		mockQueries.On("GetPayment", mock.Anything, points.ID).Return(completedPoints, nil)
+		mockQueries.On("GetPayment", mock.Anything, giftCard.ID).Return(completedGiftCard, nil)
+		mockQueries.On("GetPayment", mock.Anything, card.ID).Return(authorizedCard, nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+
+		mockQueries.On("AdjustPoints", mock.Anything, db.AdjustPointsParams{
+			UserID:    owner,
+			Type:      "POINT_TRANSACTION_TYPE_REDEEMED",
+			Points:    -2000,
+			OrderID:   &orderID,
+			PaymentID: &points.ID,
+		}).Return(db.PointBalance{UserID: owner, Points: 500}, db.PointTransaction{ID: uuid.New()}, nil).Once()
+		mockQueries.On("GetGiftCard", mock.Anything, storeCredit.ID).Return(storeCredit, nil)
+		mockQueries.On("AdjustGiftCardBalance", mock.Anything, mock.MatchedBy(func(p db.AdjustGiftCardBalanceParams) bool {
+			return p.GiftCardID == storeCredit.ID && p.AmountMinor == -3000
+		})).Return(storeCredit, db.GiftCardTransaction{ID: uuid.New()}, nil).Once()
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return (p.ID == points.ID || p.ID == giftCard.ID) && p.Status == "PAYMENT_STATUS_COMPLETED"
+		})).Return(nil).Twice()
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.MatchedBy(func(p db.AuthorizePaymentParams) bool {
+			return p.ID == card.ID
+		})).Return(nil).Once()
+
+		giftCardTender := tender(paymentpb.PaymentMethod_PAYMENT_METHOD_GIFT_CARD, 3000)
+		giftCardTender.PaymentData = map[string]string{"gift_card_id": storeCredit.ID.String()}
+		resp, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS, 2000),
+				giftCardTender,
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 5000),
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Payments, 3)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Payments[0].Status)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, resp.Payments[1].Status)
+		assert.Equal(t, paymentpb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED, resp.Payments[2].Status)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("gives the points back when the card fails", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		order := customerOrder()
+		order.TotalAmount.Units = 10000
+		s := newTestPaymentService(mockQueries, withOrder(order))
+		orderID := uuid.MustParse(order.Id)
+		owner := uuid.MustParse(order.UserId)
+
+		points := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_POINTS", AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		card := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 8000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+		completedPoints := points
+		completedPoints.Status = "PAYMENT_STATUS_COMPLETED"
+		refundID := uuid.New()
+
+		claimID := uuid.New()
+		mockQueries.On("StartOrderTenders", mock.Anything, mock.Anything).Return(claimID, nil)
+		mockQueries.On("FinishOrderTenders", mock.Anything, claimID, "FAILED").Return(nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.Method == "PAYMENT_METHOD_POINTS"
+		})).Return(points.ID, nil).Once()
+		mockQueries.On("CreatePayment", mock.Anything, mock.MatchedBy(func(p db.CreatePaymentParams) bool {
+			return p.Method == "PAYMENT_METHOD_CREDIT_CARD"
+		})).Return(card.ID, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, points.ID).Return(points, nil).Once()
+		mockQueries.On("GetPayment", mock.Anything, points.ID).Return(completedPoints, nil)
+		mockQueries.On("GetPayment", mock.Anything, card.ID).Return(card, nil)
+		mockQueries.On("UpdatePaymentData", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("AdjustPoints", mock.Anything, mock.MatchedBy(func(p db.AdjustPointsParams) bool {
+			return p.UserID == owner && p.Points == -2000
+		})).Return(db.PointBalance{UserID: owner}, db.PointTransaction{ID: uuid.New()}, nil).Once()
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == points.ID && p.Status == "PAYMENT_STATUS_COMPLETED"
+		})).Return(nil).Once()
+		mockQueries.On("AuthorizePayment", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == card.ID && p.Status == "PAYMENT_STATUS_FAILED"
+		})).Return(nil).Once()
+
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, points.ID).Return(nil, nil)
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.PaymentID == points.ID && p.AmountMinor == 2000
+		})).Return(db.Refund{ID: refundID, PaymentID: points.ID, AmountMinor: 2000, Currency: "JPY"}, 2000, nil)
+		mockQueries.On("ListPointTransactionsByPaymentID", mock.Anything, points.ID).Return([]db.PointTransaction{
+			{UserID: owner, Type: "POINT_TRANSACTION_TYPE_REDEEMED", Points: -2000},
+		}, nil)
+		mockQueries.On("AdjustPoints", mock.Anything, db.AdjustPointsParams{
+			UserID:    owner,
+			Type:      "POINT_TRANSACTION_TYPE_REFUNDED",
+			Points:    2000,
+			OrderID:   &orderID,
+			PaymentID: &points.ID,
+			RefundID:  &refundID,
+		}).Return(db.PointBalance{UserID: owner, Points: 2000}, db.PointTransaction{ID: uuid.New()}, nil).Once()
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.MatchedBy(func(p db.UpdateRefundStatusParams) bool {
+			return p.ID == refundID
+		})).Return(nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == points.ID && p.Status == "PAYMENT_STATUS_REFUNDED"
+		})).Return(nil).Once()
+
+		_, err := s.PayOrder(ctx, &paymentpb.PayOrderRequest{
+			OrderId: order.Id,
+			Tenders: []*paymentpb.Tender{
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_POINTS, 2000),
+				tender(paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, 8000),
+			},
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, status.Convert(err).Message(), "tender 2")
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestPaymentService_ProcessPayment_Points(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	order := customerOrder()
+	s := newTestPaymentService(mockQueries, withOrder(order))
+	payment := db.Payment{ID: uuid.New(), OrderID: uuid.MustParse(order.Id), Method: "PAYMENT_METHOD_POINTS", AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_PENDING"}
+
+	mockQueries.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil)
+	mockQueries.On("AdjustPoints", mock.Anything, mock.Anything).
+		Return(db.PointBalance{Points: 1200}, db.PointTransaction{}, db.ErrInsufficientPoints)
+
+	_, err := s.ProcessPayment(context.Background(), &paymentpb.ProcessPaymentRequest{PaymentId: payment.ID.String()})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "point balance is 1200, less than the 5000 points to redeem", status.Convert(err).Message())
+	mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+}
+
+func TestPaymentService_CapturePayment_SeveralTenders(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	s := newTestPaymentService(mockQueries, withOrder(nil))
+	orderID := uuid.New()
+
+	first := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 3000, Currency: "JPY", Status: "PAYMENT_STATUS_AUTHORIZED"}
+	second := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_AUTHORIZED"}
+	paypay := db.Payment{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_PAYPAY", AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+
+	mockQueries.On("ListPaymentsByOrderID", mock.Anything, orderID).Return([]db.Payment{paypay, first, second}, nil)
+	mockQueries.On("CapturePayment", mock.Anything, mock.MatchedBy(func(p db.CapturePaymentParams) bool {
+		return (p.ID == first.ID && p.AmountMinor == 3000) || (p.ID == second.ID && p.AmountMinor == 5000)
+	})).Return(nil).Twice()
+
+	resp, err := s.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{OrderId: orderID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(8000), resp.CapturedAmount.Units)
+	mockQueries.AssertExpectations(t)
+
+	t.Run("rejects a partial amount", func(t *testing.T) {
+		_, err := s.CapturePayment(context.Background(), &paymentpb.CapturePaymentRequest{
+			OrderId: orderID.String(),
+			Amount:  &sharedpb.Money{Units: 1000, Currency: "JPY"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestPaymentService_ListPaymentsByOrder(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	mockCache := new(cache.MockCache)
+	s := NewPaymentService(mockQueries, mockCache, zap.NewNop())
+	orderID := uuid.New()
+
+	mockCache.On("Get", mock.Anything, cache.PaymentsByOrderCacheKey(orderID.String()), mock.Anything).Return(cache.ErrCacheMiss)
+	mockCache.On("Set", mock.Anything, cache.PaymentsByOrderCacheKey(orderID.String()), mock.Anything, cache.DefaultTTL).Return(nil)
+	mockQueries.On("ListPaymentsByOrderID", mock.Anything, orderID).Return([]db.Payment{
+		{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_PAYPAY", AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"},
+		{ID: uuid.New(), OrderID: orderID, Method: "PAYMENT_METHOD_CREDIT_CARD", AmountMinor: 8000, Currency: "JPY", Status: "PAYMENT_STATUS_AUTHORIZED"},
+	}, nil)
+
+	resp, err := s.ListPaymentsByOrder(context.Background(), &paymentpb.ListPaymentsByOrderRequest{OrderId: orderID.String()})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Payments, 2)
+	assert.Equal(t, paymentpb.PaymentMethod_PAYMENT_METHOD_PAYPAY, resp.Payments[0].Method)
+	assert.Equal(t, paymentpb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, resp.Payments[1].Method)
+	mockCache.AssertExpectations(t)
+}
@@ -1,18 +1,43 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+const (
+	// WebhookSignatureHeader carries hex(HMAC-SHA256(secret, timestamp + "." + body))
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookTimestampHeader carries the Unix time the sender signed the request
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+
+	maxWebhookBodyBytes = 1 << 20
+)
+
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrStaleWebhookTimestamp   = errors.New("webhook timestamp outside tolerance")
+	// ErrInvalidWebhookEvent marks an event that can never be processed, such
+	// as one without a payment_id or for an unknown payment, so redelivering
+	// it is pointless
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
 )
 
 // WebhookService handles payment webhook processing
@@ -20,20 +45,24 @@ type WebhookService struct {
 	paymentService *PaymentService
 	konbiniService *KonbiniService
 	secretKey      string
+	tolerance      time.Duration
 	logger         *zap.Logger
 }
 
-// NewWebhookService creates a new webhook service
+// NewWebhookService creates a new webhook service. Requests signed more than
+// tolerance away from the current time are rejected.
 func NewWebhookService(
 	paymentService *PaymentService,
 	konbiniService *KonbiniService,
 	secretKey string,
+	tolerance time.Duration,
 	logger *zap.Logger,
 ) *WebhookService {
 	return &WebhookService{
 		paymentService: paymentService,
 		konbiniService: konbiniService,
 		secretKey:      secretKey,
+		tolerance:      tolerance,
 		logger:         logger,
 	}
 }
@@ -44,20 +73,37 @@ type WebhookEvent struct {
 	Type      string                 `json:"type"`
 	Data      map[string]interface{} `json:"data"`
 	Timestamp time.Time              `json:"timestamp"`
-	Signature string                 `json:"signature"`
 }
 
-// HandleWebhook handles an incoming webhook request
+// HandleWebhook processes a verified webhook event. Events whose ID has
+// already been processed are ignored.
 func (s *WebhookService) HandleWebhook(ctx context.Context, event WebhookEvent) error {
 	s.logger.Info("Received webhook event",
 		zap.String("event_id", event.ID),
 		zap.String("event_type", event.Type))
 
-	// Verify signature
-	if !s.verifySignature(event) {
-		return fmt.Errorf("invalid webhook signature")
+	queries := s.paymentService.queries
+	recorded, err := queries.RecordWebhookEvent(ctx, event.ID, event.Type)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook event: %w", err)
 	}
+	if !recorded {
+		s.logger.Info("Ignoring replayed webhook event", zap.String("event_id", event.ID))
+		return nil
+	}
+
+	if err := s.dispatch(ctx, event); err != nil {
+		// Forget the event so the provider's retry is processed
+		if delErr := queries.DeleteWebhookEvent(ctx, event.ID); delErr != nil {
+			s.logger.Error("Failed to release webhook event", zap.String("event_id", event.ID), zap.Error(delErr))
+		}
+		return err
+	}
+
+	return nil
+}
 
+func (s *WebhookService) dispatch(ctx context.Context, event WebhookEvent) error {
 	// Process event based on type
 	switch event.Type {
 	case "payment.completed":
@@ -70,30 +116,55 @@ func (s *WebhookService) HandleWebhook(ctx context.Context, event WebhookEvent)
 		return s.handleKonbiniPaid(ctx, event)
 	case "konbini.expired":
 		return s.handleKonbiniExpired(ctx, event)
+	case "dispute.created":
+		return s.handleDisputeCreated(ctx, event)
+	case "dispute.updated":
+		return s.handleDisputeUpdated(ctx, event)
+	case "dispute.closed":
+		return s.handleDisputeClosed(ctx, event)
 	default:
 		s.logger.Warn("Unknown webhook event type", zap.String("type", event.Type))
 		return nil
 	}
 }
 
-// verifySignature verifies the webhook signature
-func (s *WebhookService) verifySignature(event WebhookEvent) bool {
-	// Create expected signature
-	data := fmt.Sprintf("%s:%s:%d", event.ID, event.Type, event.Timestamp.Unix())
+// VerifySignature checks the signature over the raw request body and rejects
+// requests signed outside the tolerance window
+func (s *WebhookService) VerifySignature(body []byte, timestamp string, signature string) error {
+	if s.secretKey == "" || timestamp == "" || signature == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	expected, err := hex.DecodeString(s.GenerateSignature(ts, body))
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, expected) {
+		return ErrInvalidWebhookSignature
+	}
 
-	h := hmac.New(sha256.New, []byte(s.secretKey))
-	h.Write([]byte(data))
-	expectedSig := hex.EncodeToString(h.Sum(nil))
+	skew := time.Since(time.Unix(ts, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > s.tolerance {
+		return ErrStaleWebhookTimestamp
+	}
 
-	// Compare signatures
-	return event.Signature == expectedSig
+	return nil
 }
 
 // handlePaymentCompleted handles a payment completed event
 func (s *WebhookService) handlePaymentCompleted(ctx context.Context, event WebhookEvent) error {
 	paymentID, ok := event.Data["payment_id"].(string)
 	if !ok {
-		return fmt.Errorf("missing payment_id in event data")
+		return fmt.Errorf("%w: missing payment_id", ErrInvalidWebhookEvent)
 	}
 
 	transactionID, _ := event.Data["transaction_id"].(string)
@@ -107,17 +178,14 @@ func (s *WebhookService) handlePaymentCompleted(ctx context.Context, event Webho
 		zap.String("transaction_id", transactionID),
 		zap.Int64("amount", amount))
 
-	// Update payment status in database
-	// This would trigger order fulfillment
-
-	return nil
+	return s.completePayment(ctx, event, paymentID, transactionID)
 }
 
 // handlePaymentFailed handles a payment failed event
 func (s *WebhookService) handlePaymentFailed(ctx context.Context, event WebhookEvent) error {
 	paymentID, ok := event.Data["payment_id"].(string)
 	if !ok {
-		return fmt.Errorf("missing payment_id in event data")
+		return fmt.Errorf("%w: missing payment_id", ErrInvalidWebhookEvent)
 	}
 
 	reason, _ := event.Data["reason"].(string)
@@ -126,28 +194,108 @@ func (s *WebhookService) handlePaymentFailed(ctx context.Context, event WebhookE
 		zap.String("payment_id", paymentID),
 		zap.String("reason", reason))
 
-	// Update payment status and notify customer
+	payment, ok, err := s.loadPaymentForTransition(ctx, paymentID, paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := s.paymentService.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED, webhookChange(event, reason)); err != nil {
+		return err
+	}
+
+	s.paymentService.publishTenderEvent(ctx, PaymentEventFailed, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_FAILED,
+		map[string]interface{}{"reason": reason})
 
 	return nil
 }
 
-// handlePaymentRefunded handles a payment refunded event
+// handlePaymentRefunded handles a refund issued at the provider, for example
+// from its merchant dashboard. The refund is recorded in the refund ledger
+// together with the payment's new status.
 func (s *WebhookService) handlePaymentRefunded(ctx context.Context, event WebhookEvent) error {
 	paymentID, ok := event.Data["payment_id"].(string)
 	if !ok {
-		return fmt.Errorf("missing payment_id in event data")
+		return fmt.Errorf("%w: missing payment_id", ErrInvalidWebhookEvent)
 	}
 
 	refundAmount := int64(0)
 	if amountFloat, ok := event.Data["refund_amount"].(float64); ok {
 		refundAmount = int64(amountFloat)
 	}
+	refundID, _ := event.Data["refund_id"].(string)
+	reason, _ := event.Data["reason"].(string)
 
 	s.logger.Info("Payment refunded webhook",
 		zap.String("payment_id", paymentID),
 		zap.Int64("refund_amount", refundAmount))
 
-	// Process refund and update order status
+	id, err := uuid.Parse(paymentID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid payment_id: %v", ErrInvalidWebhookEvent, err)
+	}
+
+	queries := s.paymentService.queries
+	refunds, err := queries.ListRefundsByPaymentID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to list refunds: %w", err)
+	}
+	// A refund made through RefundPayment comes back as a webhook too; it
+	// is already in the ledger under the provider's refund id
+	for _, r := range refunds {
+		if refundID != "" && r.ProviderReference != nil && *r.ProviderReference == refundID {
+			s.logger.Info("Provider refund already recorded",
+				zap.String("payment_id", paymentID),
+				zap.String("refund_id", refundID))
+			return nil
+		}
+	}
+	for _, r := range refunds {
+		if r.Status == paymentpb.RefundStatus_REFUND_STATUS_PENDING.String() {
+			// Its provider reference is not known yet, so it may be this
+			// refund; the provider retries
+			return fmt.Errorf("refund %s of payment %s is still being issued", r.ID, paymentID)
+		}
+	}
+
+	// A payment that can be partially refunded can be refunded in full, so
+	// this checks either
+	payment, ok, err := s.loadPaymentForTransition(ctx, paymentID, paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED)
+	if err != nil || !ok {
+		return err
+	}
+	change, err := s.paymentService.checkTransition(payment, paymentpb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED, webhookChange(event, reason))
+	if err != nil {
+		return err
+	}
+
+	refund, newStatus, err := queries.RecordProviderRefund(ctx, db.RecordProviderRefundParams{
+		PaymentID:         payment.ID,
+		AmountMinor:       int(refundAmount),
+		Currency:          payment.Currency,
+		Reason:            nullableString(reason),
+		LimitMinor:        refundableAmount(payment),
+		ProviderReference: nullableString(refundID),
+		Change:            change,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefundExceedsCapture) {
+			s.logger.Error("Provider refund exceeds refundable amount",
+				zap.String("payment_id", paymentID),
+				zap.Int64("refund_amount", refundAmount),
+				zap.Int("remaining", refundableAmount(payment)-refundedAmount(refunds)))
+			return nil
+		}
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	_ = s.paymentService.cache.Delete(ctx, cache.PaymentCacheKey(payment.ID.String()))
+	_ = s.paymentService.cache.Delete(ctx, cache.PaymentsByOrderCacheKey(payment.OrderID.String()))
+
+	s.paymentService.publishPaymentEvent(ctx, PaymentEventRefunded, payment, ParsePaymentStatus(newStatus), map[string]interface{}{
+		"refund_id":     refund.ID.String(),
+		"refund_amount": refund.AmountMinor,
+		"currency":      payment.Currency,
+	})
 
 	return nil
 }
@@ -156,7 +304,7 @@ func (s *WebhookService) handlePaymentRefunded(ctx context.Context, event Webhoo
 func (s *WebhookService) handleKonbiniPaid(ctx context.Context, event WebhookEvent) error {
 	paymentID, ok := event.Data["payment_id"].(string)
 	if !ok {
-		return fmt.Errorf("missing payment_id in event data")
+		return fmt.Errorf("%w: missing payment_id", ErrInvalidWebhookEvent)
 	}
 
 	confirmationNumber, _ := event.Data["confirmation_number"].(string)
@@ -172,34 +320,102 @@ func (s *WebhookService) handleKonbiniPaid(ctx context.Context, event WebhookEve
 		zap.String("confirmation_number", confirmationNumber))
 
 	if s.konbiniService != nil {
-		return s.konbiniService.ProcessKonbiniWebhook(
+		if err := s.konbiniService.ProcessKonbiniWebhook(
 			ctx,
 			paymentID,
 			"completed",
 			confirmationNumber,
 			paidAt,
-		)
+		); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return s.completePayment(ctx, event, paymentID, confirmationNumber)
 }
 
 // handleKonbiniExpired handles a Konbini payment expired event
 func (s *WebhookService) handleKonbiniExpired(ctx context.Context, event WebhookEvent) error {
 	paymentID, ok := event.Data["payment_id"].(string)
 	if !ok {
-		return fmt.Errorf("missing payment_id in event data")
+		return fmt.Errorf("%w: missing payment_id", ErrInvalidWebhookEvent)
 	}
 
 	s.logger.Info("Konbini payment expired webhook",
 		zap.String("payment_id", paymentID))
 
-	// Update payment status to expired
-	// Cancel associated order
+	payment, ok, err := s.loadPaymentForTransition(ctx, paymentID, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := s.paymentService.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED, webhookChange(event, "")); err != nil {
+		return err
+	}
+
+	s.paymentService.publishPaymentEvent(ctx, PaymentEventExpired, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_EXPIRED, nil)
 
 	return nil
 }
 
+// completePayment marks a payment completed and notifies the order service
+func (s *WebhookService) completePayment(ctx context.Context, event WebhookEvent, paymentID string, transactionID string) error {
+	payment, ok, err := s.loadPaymentForTransition(ctx, paymentID, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED)
+	if err != nil || !ok {
+		return err
+	}
+
+	change := webhookChange(event, "")
+	change.transactionID = nullableString(transactionID)
+	if err := s.paymentService.transitionPayment(ctx, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, change); err != nil {
+		return err
+	}
+
+	s.paymentService.publishTenderEvent(ctx, PaymentEventCompleted, payment, paymentpb.PaymentStatus_PAYMENT_STATUS_COMPLETED, map[string]interface{}{
+		"amount":   payment.AmountMinor,
+		"currency": payment.Currency,
+	})
+
+	return nil
+}
+
+// webhookChange records a provider event as the cause of a status change
+func webhookChange(event WebhookEvent, reason string) statusChange {
+	return statusChange{
+		changedBy:        changedByWebhook,
+		reason:           reason,
+		providerResponse: event,
+	}
+}
+
+// loadPaymentForTransition fetches a payment and reports whether it may move
+// to newStatus. Events that arrive for a payment already past that point are
+// acknowledged without change so the provider stops redelivering them.
+func (s *WebhookService) loadPaymentForTransition(ctx context.Context, paymentID string, newStatus paymentpb.PaymentStatus) (db.Payment, bool, error) {
+	id, err := uuid.Parse(paymentID)
+	if err != nil {
+		return db.Payment{}, false, fmt.Errorf("%w: invalid payment_id: %v", ErrInvalidWebhookEvent, err)
+	}
+
+	payment, err := s.paymentService.queries.GetPayment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Payment{}, false, fmt.Errorf("%w: unknown payment %s", ErrInvalidWebhookEvent, paymentID)
+	}
+	if err != nil {
+		return db.Payment{}, false, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if !s.paymentService.stateMachine.CanTransition(ParsePaymentStatus(payment.Status), newStatus) {
+		s.logger.Warn("Ignoring webhook for payment in incompatible status",
+			zap.String("payment_id", paymentID),
+			zap.String("status", payment.Status),
+			zap.String("target_status", newStatus.String()))
+		return payment, false, nil
+	}
+
+	return payment, true, nil
+}
+
 // WebhookHandler wraps the webhook service for HTTP handling
 type WebhookHandler struct {
 	service *WebhookService
@@ -217,23 +433,39 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var event WebhookEvent
-	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodyBytes))
+	if err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	// Add event ID if not present
-	if event.ID == "" {
-		event.ID = uuid.New().String()
+	if err := h.service.VerifySignature(body, r.Header.Get(WebhookTimestampHeader), r.Header.Get(WebhookSignatureHeader)); err != nil {
+		h.service.logger.Warn("Rejected webhook", zap.Error(err))
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
 	}
 
-	// Add timestamp if not present
-	if event.Timestamp.IsZero() {
-		event.Timestamp = time.Now()
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if event.ID == "" || event.Type == "" {
+		http.Error(w, "Event id and type are required", http.StatusBadRequest)
+		return
 	}
 
-	if err := h.service.HandleWebhook(r.Context(), event); err != nil {
+	err = h.service.HandleWebhook(r.Context(), event)
+	if errors.Is(err, ErrInvalidWebhookEvent) {
+		// Retrying would fail the same way; tell the provider to stop
+		h.service.logger.Warn("Rejected unprocessable webhook",
+			zap.String("event_id", event.ID),
+			zap.Error(err))
+		http.Error(w, "Unprocessable webhook event", http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
 		h.service.logger.Error("Failed to handle webhook",
 			zap.String("event_id", event.ID),
 			zap.Error(err))
@@ -245,71 +477,18 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
-// GenerateSignature generates a signature for a webhook event
-func (s *WebhookService) GenerateSignature(event WebhookEvent) string {
-	data := fmt.Sprintf("%s:%s:%d", event.ID, event.Type, event.Timestamp.Unix())
-
-	h := hmac.New(sha256.New, []byte(s.secretKey))
-	h.Write([]byte(data))
-	return hex.EncodeToString(h.Sum(nil))
+// GenerateSignature signs a request body sent at the given Unix timestamp
+func (s *WebhookService) GenerateSignature(timestamp int64, body []byte) string {
+	return signWebhookPayload(s.secretKey, timestamp, body)
 }
 
-// RetryWebhook retries a failed webhook delivery
-func (s *WebhookService) RetryWebhook(ctx context.Context, event WebhookEvent, webhookURL string, maxRetries int) error {
-	var lastErr error
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		s.logger.Info("Retrying webhook delivery",
-			zap.String("event_id", event.ID),
-			zap.Int("attempt", attempt))
-
-		// Generate signature for this attempt
-		event.Signature = s.GenerateSignature(event)
-
-		// Marshal event
-		body, err := json.Marshal(event)
-		if err != nil {
-			return fmt.Errorf("failed to marshal event: %w", err)
-		}
-
-		// Create HTTP request
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
-		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("X-Webhook-Signature", event.Signature)
-
-		// Send request
-		client := &http.Client{Timeout: 30 * time.Second}
-		resp, err := client.Do(req)
-		if err != nil {
-			lastErr = err
-			s.logger.Warn("Webhook delivery attempt failed",
-				zap.String("event_id", event.ID),
-				zap.Int("attempt", attempt),
-				zap.Error(err))
-			time.Sleep(time.Duration(attempt) * time.Second)
-			continue
-		}
-		_ = resp.Body.Close()
-
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			s.logger.Info("Webhook delivered successfully",
-				zap.String("event_id", event.ID),
-				zap.Int("attempt", attempt),
-				zap.Int("status_code", resp.StatusCode))
-			return nil
-		}
-
-		lastErr = fmt.Errorf("webhook returned status %d", resp.StatusCode)
-		s.logger.Warn("Webhook delivery attempt failed",
-			zap.String("event_id", event.ID),
-			zap.Int("attempt", attempt),
-			zap.Int("status_code", resp.StatusCode))
-
-		time.Sleep(time.Duration(attempt) * time.Second)
-	}
-
-	return fmt.Errorf("failed to deliver webhook after %d attempts: %w", maxRetries, lastErr)
+// signWebhookPayload computes the X-Webhook-Signature value for body signed
+// with secret at the given Unix timestamp. Inbound provider webhooks and
+// outbound merchant webhooks share this scheme.
+func signWebhookPayload(secret string, timestamp int64, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
 }
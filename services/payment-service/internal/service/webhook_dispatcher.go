@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+const (
+	// WebhookEventIDHeader carries the event ID so receivers can deduplicate
+	WebhookEventIDHeader = "X-Webhook-Event-Id"
+
+	// deliveryBatchSize bounds how many deliveries are sent per run
+	deliveryBatchSize = 50
+	// deliveryTimeout bounds a single attempt; claims are leased for longer
+	// so a slow attempt is never picked up twice
+	deliveryTimeout = 15 * time.Second
+	deliveryLease   = 2 * time.Minute
+
+	// maxDeliveryAttempts is how many times a delivery is tried before it is
+	// marked failed and left for a manual replay
+	maxDeliveryAttempts = 10
+	// disableEndpointAfter is how many consecutive failed attempts disable an
+	// endpoint
+	disableEndpointAfter = 20
+
+	deliveryBackoffBase = 30 * time.Second
+	deliveryBackoffMax  = 6 * time.Hour
+)
+
+// WebhookDispatcher sends queued merchant webhook deliveries
+type WebhookDispatcher struct {
+	queries db.Querier
+	client  *http.Client
+	logger  *zap.Logger
+	jitter  func(n int64) int64
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(queries db.Querier, logger *zap.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		queries: queries,
+		client:  &http.Client{Timeout: deliveryTimeout},
+		logger:  logger,
+		jitter:  rand.Int63n,
+	}
+}
+
+// DeliverDue sends every delivery whose next attempt is due and returns how
+// many succeeded
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	due, err := d.queries.ClaimDueWebhookDeliveries(ctx, deliveryBatchSize, deliveryLease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range due {
+		if d.deliver(ctx, delivery) {
+			delivered++
+		}
+	}
+
+	if len(due) > 0 {
+		d.logger.Info("Sent webhook deliveries",
+			zap.Int("due", len(due)),
+			zap.Int("delivered", delivered))
+	}
+
+	return delivered, nil
+}
+
+// deliver makes one attempt and records the outcome
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery db.DueWebhookDelivery) bool {
+	code, err := d.send(ctx, delivery)
+	if err == nil {
+		if err := d.queries.MarkWebhookDeliverySucceeded(ctx, delivery.ID, code); err != nil {
+			d.logger.Error("Failed to record webhook delivery",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(err))
+		}
+		return true
+	}
+
+	attempt := delivery.Attempts + 1
+	params := db.MarkWebhookDeliveryFailedParams{
+		ID:           delivery.ID,
+		Error:        err.Error(),
+		DisableAfter: disableEndpointAfter,
+	}
+	if code != 0 {
+		params.ResponseCode = &code
+	}
+	if attempt < maxDeliveryAttempts {
+		next := time.Now().Add(d.backoff(attempt))
+		params.NextAttemptAt = &next
+	}
+
+	d.logger.Warn("Webhook delivery attempt failed",
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.String("endpoint_id", delivery.EndpointID.String()),
+		zap.Int("attempt", attempt),
+		zap.Error(err))
+
+	disabled, err := d.queries.MarkWebhookDeliveryFailed(ctx, params)
+	if err != nil {
+		d.logger.Error("Failed to record webhook delivery",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.Error(err))
+		return false
+	}
+	if disabled {
+		d.logger.Warn("Webhook endpoint disabled after repeated failures",
+			zap.String("endpoint_id", delivery.EndpointID.String()),
+			zap.Int("consecutive_failures", disableEndpointAfter))
+	}
+	return false
+}
+
+// send posts the payload signed with the endpoint's secret. The response code
+// is returned even when the attempt counts as failed.
+func (d *WebhookDispatcher) send(ctx context.Context, delivery db.DueWebhookDelivery) (int, error) {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventIDHeader, delivery.EventID)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff is the delay before the attempt after the given one: exponential
+// from deliveryBackoffBase, capped at deliveryBackoffMax, with the upper half
+// randomised so that endpoints recovering from an outage are not hit by every
+// retry at once.
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := deliveryBackoffMax
+	if attempt < 32 {
+		if exp := deliveryBackoffBase << (attempt - 1); exp > 0 && exp < deliveryBackoffMax {
+			delay = exp
+		}
+	}
+	half := delay / 2
+	return half + time.Duration(d.jitter(int64(half)+1))
+}
+
+// StartPeriodicDelivery starts sending due deliveries in the background
+func (d *WebhookDispatcher) StartPeriodicDelivery(ctx context.Context, interval time.Duration) {
+	d.logger.Info("Starting periodic webhook delivery", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if _, err := d.DeliverDue(ctx); err != nil {
+					d.logger.Error("Periodic webhook delivery failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
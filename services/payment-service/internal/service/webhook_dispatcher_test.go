@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/cache"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func newDueDelivery(url string, attempts int) db.DueWebhookDelivery {
+	return db.DueWebhookDelivery{
+		WebhookDelivery: db.WebhookDelivery{
+			ID:         uuid.New(),
+			EndpointID: uuid.New(),
+			EventID:    "evt_1",
+			EventType:  PaymentEventCompleted,
+			Payload:    []byte(`{"event_type":"payment.completed"}`),
+			Status:     "WEBHOOK_DELIVERY_STATUS_PENDING",
+			Attempts:   attempts,
+		},
+		URL:    url,
+		Secret: "whsec_test",
+	}
+}
+
+func TestWebhookDispatcher_DeliverDue(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("posts a signed payload and marks it delivered", func(t *testing.T) {
+		var gotSignature, gotTimestamp, gotEventID, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			gotSignature = r.Header.Get(WebhookSignatureHeader)
+			gotTimestamp = r.Header.Get(WebhookTimestampHeader)
+			gotEventID = r.Header.Get(WebhookEventIDHeader)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		mockQueries := new(MockQuerier)
+		delivery := newDueDelivery(server.URL, 0)
+		mockQueries.On("ClaimDueWebhookDeliveries", mock.Anything, deliveryBatchSize, deliveryLease).
+			Return([]db.DueWebhookDelivery{delivery}, nil)
+		mockQueries.On("MarkWebhookDeliverySucceeded", mock.Anything, delivery.ID, http.StatusNoContent).Return(nil)
+
+		delivered, err := NewWebhookDispatcher(mockQueries, logger).DeliverDue(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, string(delivery.Payload), gotBody)
+		assert.Equal(t, "evt_1", gotEventID)
+		ts, err := strconv.ParseInt(gotTimestamp, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, signWebhookPayload("whsec_test", ts, delivery.Payload), gotSignature)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("schedules a retry with backoff on a server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		mockQueries := new(MockQuerier)
+		delivery := newDueDelivery(server.URL, 2)
+		mockQueries.On("ClaimDueWebhookDeliveries", mock.Anything, deliveryBatchSize, deliveryLease).
+			Return([]db.DueWebhookDelivery{delivery}, nil)
+
+		before := time.Now()
+		mockQueries.On("MarkWebhookDeliveryFailed", mock.Anything, mock.MatchedBy(func(p db.MarkWebhookDeliveryFailedParams) bool {
+			if p.ID != delivery.ID || p.ResponseCode == nil || *p.ResponseCode != http.StatusBadGateway {
+				return false
+			}
+			// third attempt: 2 minutes, jittered into [1m, 2m]
+			return p.NextAttemptAt != nil &&
+				!p.NextAttemptAt.Before(before.Add(time.Minute)) &&
+				p.NextAttemptAt.Before(before.Add(2*time.Minute+time.Second)) &&
+				p.DisableAfter == disableEndpointAfter
+		})).Return(false, nil)
+
+		delivered, err := NewWebhookDispatcher(mockQueries, logger).DeliverDue(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		delivery := newDueDelivery("http://127.0.0.1:1/unreachable", maxDeliveryAttempts-1)
+		mockQueries.On("ClaimDueWebhookDeliveries", mock.Anything, deliveryBatchSize, deliveryLease).
+			Return([]db.DueWebhookDelivery{delivery}, nil)
+		mockQueries.On("MarkWebhookDeliveryFailed", mock.Anything, mock.MatchedBy(func(p db.MarkWebhookDeliveryFailedParams) bool {
+			return p.ID == delivery.ID && p.NextAttemptAt == nil && p.ResponseCode == nil && p.Error != ""
+		})).Return(true, nil)
+
+		_, err := NewWebhookDispatcher(mockQueries, logger).DeliverDue(context.Background())
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestWebhookDispatcher_backoff(t *testing.T) {
+	d := NewWebhookDispatcher(new(MockQuerier), zap.NewNop())
+
+	t.Run("without jitter the delay doubles and is capped", func(t *testing.T) {
+		d.jitter = func(n int64) int64 { return n - 1 }
+		assert.Equal(t, deliveryBackoffBase, d.backoff(1))
+		assert.Equal(t, 2*deliveryBackoffBase, d.backoff(2))
+		assert.Equal(t, 8*deliveryBackoffBase, d.backoff(4))
+		assert.Equal(t, deliveryBackoffMax, d.backoff(20))
+		assert.Equal(t, deliveryBackoffMax, d.backoff(100))
+	})
+
+	t.Run("jitter never drops below half the delay", func(t *testing.T) {
+		d.jitter = func(n int64) int64 { return 0 }
+		assert.Equal(t, deliveryBackoffBase/2, d.backoff(1))
+		assert.Equal(t, deliveryBackoffMax/2, d.backoff(20))
+	})
+}
+
+func TestPaymentService_CreateWebhookEndpoint(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("creates an endpoint and returns its secret", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		service := NewPaymentService(mockQueries, new(cache.MockCache), logger)
+
+		var stored string
+		mockQueries.On("CreateWebhookEndpoint", mock.Anything, mock.MatchedBy(func(p db.CreateWebhookEndpointParams) bool {
+			stored = p.Secret
+			return p.URL == "https://partner.example.com/hooks" && len(p.EventTypes) == 1
+		})).Return(db.WebhookEndpoint{
+			ID: uuid.New(), URL: "https://partner.example.com/hooks", EventTypes: []string{PaymentEventCompleted}, Enabled: true,
+		}, nil)
+
+		resp, err := service.CreateWebhookEndpoint(context.Background(), &paymentpb.CreateWebhookEndpointRequest{
+			Url:        "https://partner.example.com/hooks",
+			EventTypes: []string{PaymentEventCompleted},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, resp.Secret)
+		assert.Regexp(t, "^whsec_[0-9a-f]{64}$", resp.Secret)
+		assert.True(t, resp.Endpoint.Enabled)
+	})
+
+	t.Run("rejects invalid endpoints", func(t *testing.T) {
+		service := NewPaymentService(new(MockQuerier), new(cache.MockCache), logger)
+
+		for name, req := range map[string]*paymentpb.CreateWebhookEndpointRequest{
+			"plain http":         {Url: "http://partner.example.com/hooks", EventTypes: []string{PaymentEventCompleted}},
+			"relative url":       {Url: "/hooks", EventTypes: []string{PaymentEventCompleted}},
+			"no event types":     {Url: "https://partner.example.com/hooks"},
+			"unknown event type": {Url: "https://partner.example.com/hooks", EventTypes: []string{"order.created"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := service.CreateWebhookEndpoint(context.Background(), req)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+	})
+}
+
+func TestPaymentService_ListWebhookDeliveries(t *testing.T) {
+	mockQueries := new(MockQuerier)
+	service := NewPaymentService(mockQueries, new(cache.MockCache), zap.NewNop())
+
+	endpointID := uuid.New()
+	mockQueries.On("ListWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.ListWebhookDeliveriesParams) bool {
+		return *p.EndpointID == endpointID && *p.Status == "WEBHOOK_DELIVERY_STATUS_FAILED" &&
+			p.Limit == defaultWebhookDeliveriesPageSize && p.Offset == 0
+	})).Return([]db.WebhookDelivery{{
+		ID: uuid.New(), EndpointID: endpointID, EventID: "evt_1", Status: "WEBHOOK_DELIVERY_STATUS_FAILED", Attempts: maxDeliveryAttempts,
+	}}, 1, nil)
+
+	resp, err := service.ListWebhookDeliveries(context.Background(), &paymentpb.ListWebhookDeliveriesRequest{
+		EndpointId: endpointID.String(),
+		Status:     paymentpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Deliveries, 1)
+	assert.Equal(t, paymentpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED, resp.Deliveries[0].Status)
+	assert.Equal(t, int32(1), resp.Pagination.Total)
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	sharedpb "github.com/afasari/shinkansen-commerce/gen/proto/go/shared"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+// webhookEventAll subscribes an endpoint to every event type
+const webhookEventAll = "*"
+
+const defaultWebhookDeliveriesPageSize = 50
+
+// webhookEventTypes are the event types merchants may subscribe to
+var webhookEventTypes = map[string]bool{
+	webhookEventAll:           true,
+	PaymentEventAuthorized:    true,
+	PaymentEventCompleted:     true,
+	PaymentEventFailed:        true,
+	PaymentEventRefunded:      true,
+	PaymentEventExpired:       true,
+	PaymentEventDisputeOpened: true,
+	PaymentEventDisputeClosed: true,
+}
+
+// CreateWebhookEndpoint registers a merchant endpoint. The signing secret is
+// generated here and only returned in this response.
+func (s *PaymentService) CreateWebhookEndpoint(ctx context.Context, req *paymentpb.CreateWebhookEndpointRequest) (*paymentpb.CreateWebhookEndpointResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.CreateWebhookEndpoint",
+		trace.WithAttributes(attribute.String("webhook.url", req.Url)),
+	)
+	defer span.End()
+
+	if err := validateWebhookURL(req.Url); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookEventTypes(req.EventTypes); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		s.logger.Error("Failed to generate webhook secret", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create webhook endpoint")
+	}
+
+	endpoint, err := s.queries.CreateWebhookEndpoint(ctx, db.CreateWebhookEndpointParams{
+		URL:         req.Url,
+		Description: nullableString(req.Description),
+		EventTypes:  req.EventTypes,
+		Secret:      secret,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create webhook endpoint", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create webhook endpoint")
+	}
+
+	s.logger.Info("Webhook endpoint created",
+		zap.String("endpoint_id", endpoint.ID.String()),
+		zap.Strings("event_types", endpoint.EventTypes))
+
+	return &paymentpb.CreateWebhookEndpointResponse{
+		Endpoint: webhookEndpointToProto(endpoint),
+		Secret:   secret,
+	}, nil
+}
+
+func (s *PaymentService) ListWebhookEndpoints(ctx context.Context, req *paymentpb.ListWebhookEndpointsRequest) (*paymentpb.ListWebhookEndpointsResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListWebhookEndpoints")
+	defer span.End()
+
+	endpoints, err := s.queries.ListWebhookEndpoints(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list webhook endpoints", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list webhook endpoints")
+	}
+
+	pbEndpoints := make([]*paymentpb.WebhookEndpoint, len(endpoints))
+	for i, e := range endpoints {
+		pbEndpoints[i] = webhookEndpointToProto(e)
+	}
+
+	return &paymentpb.ListWebhookEndpointsResponse{Endpoints: pbEndpoints}, nil
+}
+
+// UpdateWebhookEndpoint changes an endpoint's URL, description, subscribed
+// event types or enabled flag. Re-enabling an endpoint that was disabled
+// after repeated failures lets its pending deliveries go out again.
+func (s *PaymentService) UpdateWebhookEndpoint(ctx context.Context, req *paymentpb.UpdateWebhookEndpointRequest) (*paymentpb.UpdateWebhookEndpointResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.UpdateWebhookEndpoint",
+		trace.WithAttributes(attribute.String("webhook.endpoint_id", req.EndpointId)),
+	)
+	defer span.End()
+
+	endpointID, err := uuid.Parse(req.EndpointId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid endpoint_id")
+	}
+
+	params := db.UpdateWebhookEndpointParams{
+		ID:          endpointID,
+		URL:         req.Url,
+		Description: req.Description,
+		Enabled:     req.Enabled,
+	}
+	if req.Url != nil {
+		if err := validateWebhookURL(*req.Url); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.EventTypes) > 0 {
+		if err := validateWebhookEventTypes(req.EventTypes); err != nil {
+			return nil, err
+		}
+		params.EventTypes = req.EventTypes
+	}
+
+	endpoint, err := s.queries.UpdateWebhookEndpoint(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "webhook endpoint not found")
+		}
+		s.logger.Error("Failed to update webhook endpoint", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update webhook endpoint")
+	}
+
+	return &paymentpb.UpdateWebhookEndpointResponse{Endpoint: webhookEndpointToProto(endpoint)}, nil
+}
+
+// DeleteWebhookEndpoint removes an endpoint together with its delivery log
+func (s *PaymentService) DeleteWebhookEndpoint(ctx context.Context, req *paymentpb.DeleteWebhookEndpointRequest) (*sharedpb.Empty, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.DeleteWebhookEndpoint",
+		trace.WithAttributes(attribute.String("webhook.endpoint_id", req.EndpointId)),
+	)
+	defer span.End()
+
+	endpointID, err := uuid.Parse(req.EndpointId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid endpoint_id")
+	}
+
+	if err := s.queries.DeleteWebhookEndpoint(ctx, endpointID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "webhook endpoint not found")
+		}
+		s.logger.Error("Failed to delete webhook endpoint", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete webhook endpoint")
+	}
+
+	return &sharedpb.Empty{}, nil
+}
+
+// ListWebhookDeliveries returns the delivery log, newest first, optionally
+// filtered by endpoint and status.
+func (s *PaymentService) ListWebhookDeliveries(ctx context.Context, req *paymentpb.ListWebhookDeliveriesRequest) (*paymentpb.ListWebhookDeliveriesResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ListWebhookDeliveries",
+		trace.WithAttributes(attribute.String("webhook.endpoint_id", req.EndpointId)),
+	)
+	defer span.End()
+
+	page, limit := int32(1), int32(defaultWebhookDeliveriesPageSize)
+	if req.Pagination != nil {
+		if req.Pagination.Page > 0 {
+			page = req.Pagination.Page
+		}
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+
+	params := db.ListWebhookDeliveriesParams{
+		Limit:  int(limit),
+		Offset: int((page - 1) * limit),
+	}
+	if req.EndpointId != "" {
+		endpointID, err := uuid.Parse(req.EndpointId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid endpoint_id")
+		}
+		params.EndpointID = &endpointID
+	}
+	if req.Status != paymentpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED {
+		st := req.Status.String()
+		params.Status = &st
+	}
+
+	deliveries, total, err := s.queries.ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list webhook deliveries", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list webhook deliveries")
+	}
+
+	pbDeliveries := make([]*paymentpb.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		pbDeliveries[i] = webhookDeliveryToProto(d)
+	}
+
+	return &paymentpb.ListWebhookDeliveriesResponse{
+		Deliveries: pbDeliveries,
+		Pagination: &sharedpb.Pagination{Page: page, Limit: limit, Total: int32(total)},
+	}, nil
+}
+
+// ReplayWebhookDelivery queues an earlier delivery to be sent again. The
+// original delivery is left untouched in the log.
+func (s *PaymentService) ReplayWebhookDelivery(ctx context.Context, req *paymentpb.ReplayWebhookDeliveryRequest) (*paymentpb.ReplayWebhookDeliveryResponse, error) {
+	ctx, span := otel.Tracer("payment-service").Start(ctx, "PaymentService.ReplayWebhookDelivery",
+		trace.WithAttributes(attribute.String("webhook.delivery_id", req.DeliveryId)),
+	)
+	defer span.End()
+
+	deliveryID, err := uuid.Parse(req.DeliveryId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delivery_id")
+	}
+
+	delivery, err := s.queries.ReplayWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "webhook delivery not found")
+		}
+		s.logger.Error("Failed to replay webhook delivery", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to replay webhook delivery")
+	}
+
+	s.logger.Info("Webhook delivery replayed",
+		zap.String("delivery_id", deliveryID.String()),
+		zap.String("replay_id", delivery.ID.String()))
+
+	return &paymentpb.ReplayWebhookDeliveryResponse{Delivery: webhookDeliveryToProto(delivery)}, nil
+}
+
+// enqueueWebhookDeliveries queues the event for every subscribed merchant
+// endpoint. Failures are logged rather than returned so that a queue problem
+// never fails the payment update that raised the event.
+func (s *PaymentService) enqueueWebhookDeliveries(ctx context.Context, event PaymentEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Warn("Failed to marshal webhook payload", zap.Error(err))
+		return
+	}
+
+	queued, err := s.queries.EnqueueWebhookDeliveries(ctx, db.EnqueueWebhookDeliveriesParams{
+		EventID:   event.EventID,
+		EventType: event.EventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to enqueue webhook deliveries",
+			zap.String("event_type", event.EventType),
+			zap.String("payment_id", event.PaymentID),
+			zap.Error(err))
+		return
+	}
+	if queued > 0 {
+		s.logger.Debug("Queued webhook deliveries",
+			zap.String("event_id", event.EventID),
+			zap.Int("count", queued))
+	}
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return status.Error(codes.InvalidArgument, "url must be an absolute URL")
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		// Plain HTTP is only accepted for local development receivers
+		if h := u.Hostname(); h == "localhost" || h == "127.0.0.1" {
+			return nil
+		}
+	}
+	return status.Error(codes.InvalidArgument, "url must use https")
+}
+
+func validateWebhookEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one event type is required")
+	}
+	for _, t := range eventTypes {
+		if !webhookEventTypes[t] {
+			return status.Errorf(codes.InvalidArgument, "unsupported event type %q", t)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func webhookEndpointToProto(e db.WebhookEndpoint) *paymentpb.WebhookEndpoint {
+	return &paymentpb.WebhookEndpoint{
+		Id:                  e.ID.String(),
+		Url:                 e.URL,
+		Description:         toStringPtr(e.Description),
+		EventTypes:          e.EventTypes,
+		Enabled:             e.Enabled,
+		ConsecutiveFailures: int32(e.ConsecutiveFailures),
+		CreatedAt:           timestamppb.New(e.CreatedAt),
+		DisabledAt:          toTimestampPtr(e.DisabledAt),
+		UpdatedAt:           timestamppb.New(e.UpdatedAt),
+	}
+}
+
+func webhookDeliveryToProto(d db.WebhookDelivery) *paymentpb.WebhookDelivery {
+	pb := &paymentpb.WebhookDelivery{
+		Id:            d.ID.String(),
+		EndpointId:    d.EndpointID.String(),
+		EventId:       d.EventID,
+		EventType:     d.EventType,
+		Status:        paymentpb.WebhookDeliveryStatus(paymentpb.WebhookDeliveryStatus_value[d.Status]),
+		Attempts:      int32(d.Attempts),
+		LastError:     toStringPtr(d.LastError),
+		Payload:       string(d.Payload),
+		NextAttemptAt: timestamppb.New(d.NextAttemptAt),
+		LastAttemptAt: toTimestampPtr(d.LastAttemptAt),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+	}
+	if d.ResponseCode != nil {
+		pb.ResponseCode = int32(*d.ResponseCode)
+	}
+	return pb
+}
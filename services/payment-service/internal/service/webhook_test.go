@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	paymentpb "github.com/afasari/shinkansen-commerce/gen/proto/go/payment"
+	"github.com/afasari/shinkansen-commerce/services/payment-service/internal/db"
+)
+
+func newTestWebhookHandler(mockQueries *MockQuerier) (*WebhookHandler, *WebhookService) {
+	payments := newTestPaymentService(mockQueries)
+	webhooks := NewWebhookService(payments, nil, "test-secret", 5*time.Minute, zap.NewNop())
+	return NewWebhookHandler(webhooks), webhooks
+}
+
+func newSignedWebhookRequest(webhooks *WebhookService, body string, signedAt time.Time) *http.Request {
+	ts := signedAt.Unix()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/payments", strings.NewReader(body))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(WebhookSignatureHeader, webhooks.GenerateSignature(ts, []byte(body)))
+	return req
+}
+
+func TestWebhookHandler_ServeHTTP(t *testing.T) {
+	const body = `{"id":"evt_1","type":"charge.updated","data":{"payment_id":"pay_1"}}`
+
+	t.Run("accepts a correctly signed event", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+		mockQueries.On("RecordWebhookEvent", mock.Anything, "evt_1", "charge.updated").Return(true, nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, body, time.Now()))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects a tampered body", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+
+		req := newSignedWebhookRequest(webhooks, body, time.Now())
+		req.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Replace(body, "pay_1", "pay_2", 1))).Body
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockQueries.AssertNotCalled(t, "RecordWebhookEvent", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a timestamp outside tolerance", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, body, time.Now().Add(-10*time.Minute)))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("rejects an event without an id", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, `{"type":"charge.updated"}`, time.Now()))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("ignores a replayed event", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+		replay := `{"id":"evt_1","type":"payment.completed","data":{}}`
+		mockQueries.On("RecordWebhookEvent", mock.Anything, "evt_1", "payment.completed").Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, replay, time.Now()))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockQueries.AssertNotCalled(t, "DeleteWebhookEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("releases the event id when processing fails", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+		paymentID := uuid.New()
+		event := `{"id":"evt_2","type":"payment.completed","data":{"payment_id":"` + paymentID.String() + `"}}`
+		mockQueries.On("RecordWebhookEvent", mock.Anything, "evt_2", "payment.completed").Return(true, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{}, errors.New("connection reset"))
+		mockQueries.On("DeleteWebhookEvent", mock.Anything, "evt_2").Return(nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, event, time.Now()))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("rejects an event without a payment_id as unprocessable", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+		invalid := `{"id":"evt_3","type":"payment.completed","data":{}}`
+		mockQueries.On("RecordWebhookEvent", mock.Anything, "evt_3", "payment.completed").Return(true, nil)
+		mockQueries.On("DeleteWebhookEvent", mock.Anything, "evt_3").Return(nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, invalid, time.Now()))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		mockQueries.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an event with a malformed payment_id as unprocessable", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+		mockQueries.On("RecordWebhookEvent", mock.Anything, "evt_1", "payment.failed").Return(true, nil)
+		mockQueries.On("DeleteWebhookEvent", mock.Anything, "evt_1").Return(nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks,
+			`{"id":"evt_1","type":"payment.failed","data":{"payment_id":"pay_1"}}`, time.Now()))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("rejects an event for an unknown payment as unprocessable", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		handler, webhooks := newTestWebhookHandler(mockQueries)
+		paymentID := uuid.New()
+		event := `{"id":"evt_4","type":"payment.refunded","data":{"payment_id":"` + paymentID.String() + `","refund_amount":1000}}`
+		mockQueries.On("RecordWebhookEvent", mock.Anything, "evt_4", "payment.refunded").Return(true, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(nil, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{}, pgx.ErrNoRows)
+		mockQueries.On("DeleteWebhookEvent", mock.Anything, "evt_4").Return(nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newSignedWebhookRequest(webhooks, event, time.Now()))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_HandleWebhook(t *testing.T) {
+	newEvent := func(eventType string, data map[string]interface{}) WebhookEvent {
+		return WebhookEvent{ID: uuid.New().String(), Type: eventType, Data: data, Timestamp: time.Now()}
+	}
+
+	t.Run("payment.completed completes a processing payment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.completed").Return(true, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: uuid.New(), AmountMinor: 5000, Currency: "JPY", Status: "PAYMENT_STATUS_PROCESSING",
+		}, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_COMPLETED" && *p.TransactionID == "PP-1"
+		})).Return(nil)
+
+		mockQueries.On("OrderTendersInProgress", mock.Anything, mock.Anything).Return(false, nil)
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == "payment.completed"
+		})).Return(1, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.completed", map[string]interface{}{
+			"payment_id":     paymentID.String(),
+			"transaction_id": "PP-1",
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("payment.completed is held back while the order is being paid", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		orderID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.completed").Return(true, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: orderID, AmountMinor: 2000, Currency: "JPY", Status: "PAYMENT_STATUS_PROCESSING",
+		}, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_COMPLETED"
+		})).Return(nil)
+		mockQueries.On("OrderTendersInProgress", mock.Anything, orderID).Return(true, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.completed", map[string]interface{}{
+			"payment_id":     paymentID.String(),
+			"transaction_id": "PP-2",
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockQueries.AssertNotCalled(t, "EnqueueWebhookDeliveries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("late event for a finished payment is acknowledged", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.failed").Return(true, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: uuid.New(), Status: "PAYMENT_STATUS_COMPLETED",
+		}, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.failed", map[string]interface{}{
+			"payment_id": paymentID.String(),
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("konbini.expired expires the payment", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "konbini.expired").Return(true, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: uuid.New(), Status: "PAYMENT_STATUS_PROCESSING",
+		}, nil)
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_EXPIRED" && p.TransactionID == nil &&
+				p.Change.ChangedBy == changedByWebhook
+		})).Return(nil)
+
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == "payment.expired"
+		})).Return(1, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("konbini.expired", map[string]interface{}{
+			"payment_id": paymentID.String(),
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("payment.refunded for a refund made through the API is not recorded twice", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		refundID := uuid.New()
+		payment := db.Payment{ID: paymentID, OrderID: uuid.New(), Method: "PAYMENT_METHOD_PAYPAY", AmountMinor: 10000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED"}
+		refunded := payment
+		refunded.Status = "PAYMENT_STATUS_REFUNDED"
+
+		var providerReference string
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(payment, nil).Twice()
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(refunded, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(nil, nil).Once()
+		mockQueries.On("CreateRefund", mock.Anything, mock.MatchedBy(func(p db.CreateRefundParams) bool {
+			return p.PaymentID == paymentID && p.AmountMinor == 10000
+		})).Return(db.Refund{ID: refundID, PaymentID: paymentID, AmountMinor: 10000, Currency: "JPY", Status: "REFUND_STATUS_PENDING"}, 10000, nil).Once()
+		mockQueries.On("UpdateRefundStatus", mock.Anything, mock.MatchedBy(func(p db.UpdateRefundStatusParams) bool {
+			return p.ID == refundID && p.Status == "REFUND_STATUS_SUCCEEDED"
+		})).Run(func(args mock.Arguments) {
+			providerReference = *args.Get(1).(db.UpdateRefundStatusParams).ProviderReference
+		}).Return(nil).Once()
+		mockQueries.On("UpdatePaymentStatus", mock.Anything, mock.MatchedBy(func(p db.UpdatePaymentStatusParams) bool {
+			return p.ID == paymentID && p.Status == "PAYMENT_STATUS_REFUNDED"
+		})).Return(nil).Once()
+
+		_, err := webhooks.paymentService.RefundPayment(context.Background(), &paymentpb.RefundPaymentRequest{PaymentId: paymentID.String()})
+		require.NoError(t, err)
+		require.NotEmpty(t, providerReference)
+
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.refunded").Return(true, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return([]db.Refund{{
+			ID: refundID, PaymentID: paymentID, AmountMinor: 10000, Currency: "JPY",
+			Status: "REFUND_STATUS_SUCCEEDED", ProviderReference: &providerReference,
+		}}, nil)
+
+		err = webhooks.HandleWebhook(context.Background(), newEvent("payment.refunded", map[string]interface{}{
+			"payment_id":    paymentID.String(),
+			"refund_id":     providerReference,
+			"refund_amount": float64(10000),
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+		mockQueries.AssertNumberOfCalls(t, "CreateRefund", 1)
+		mockQueries.AssertNotCalled(t, "EnqueueWebhookDeliveries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("payment.refunded waits for a refund still being issued", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.refunded").Return(true, nil)
+		mockQueries.On("DeleteWebhookEvent", mock.Anything, mock.Anything).Return(nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: uuid.New(), AmountMinor: 10000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED",
+		}, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return([]db.Refund{{
+			ID: uuid.New(), PaymentID: paymentID, AmountMinor: 10000, Currency: "JPY", Status: "REFUND_STATUS_PENDING",
+		}}, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.refunded", map[string]interface{}{
+			"payment_id":    paymentID.String(),
+			"refund_id":     "re_2",
+			"refund_amount": float64(10000),
+		}))
+
+		require.Error(t, err)
+		mockQueries.AssertNotCalled(t, "RecordProviderRefund", mock.Anything, mock.Anything)
+	})
+
+	t.Run("payment.refunded for a recorded refund is acknowledged while another is being issued", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		providerReference := "re_1"
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.refunded").Return(true, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return([]db.Refund{
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 3000, Currency: "JPY", Status: "REFUND_STATUS_PENDING"},
+			{ID: uuid.New(), PaymentID: paymentID, AmountMinor: 4000, Currency: "JPY", Status: "REFUND_STATUS_SUCCEEDED", ProviderReference: &providerReference},
+		}, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.refunded", map[string]interface{}{
+			"payment_id":    paymentID.String(),
+			"refund_id":     providerReference,
+			"refund_amount": float64(4000),
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+		mockQueries.AssertNotCalled(t, "RecordProviderRefund", mock.Anything, mock.Anything)
+	})
+
+	t.Run("payment.refunded for a payment that cannot be refunded records nothing", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.refunded").Return(true, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(nil, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: uuid.New(), AmountMinor: 10000, Currency: "JPY", Status: "PAYMENT_STATUS_FAILED",
+		}, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.refunded", map[string]interface{}{
+			"payment_id":    paymentID.String(),
+			"refund_id":     "re_1",
+			"refund_amount": float64(4000),
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertNotCalled(t, "RecordProviderRefund", mock.Anything, mock.Anything)
+		mockQueries.AssertNotCalled(t, "CreateRefund", mock.Anything, mock.Anything)
+	})
+
+	t.Run("payment.refunded records a partial refund", func(t *testing.T) {
+		mockQueries := new(MockQuerier)
+		_, webhooks := newTestWebhookHandler(mockQueries)
+
+		paymentID := uuid.New()
+		refundID := uuid.New()
+		mockQueries.On("RecordWebhookEvent", mock.Anything, mock.Anything, "payment.refunded").Return(true, nil)
+		mockQueries.On("GetPayment", mock.Anything, paymentID).Return(db.Payment{
+			ID: paymentID, OrderID: uuid.New(), AmountMinor: 10000, Currency: "JPY", Status: "PAYMENT_STATUS_COMPLETED",
+		}, nil)
+		mockQueries.On("ListRefundsByPaymentID", mock.Anything, paymentID).Return(nil, nil)
+		mockQueries.On("RecordProviderRefund", mock.Anything, mock.MatchedBy(func(p db.RecordProviderRefundParams) bool {
+			return p.PaymentID == paymentID && p.AmountMinor == 4000 && p.LimitMinor == 10000 &&
+				*p.ProviderReference == "re_1" &&
+				p.Change.FromStatus == "PAYMENT_STATUS_COMPLETED" && p.Change.ChangedBy == changedByWebhook
+		})).Return(db.Refund{ID: refundID, PaymentID: paymentID, AmountMinor: 4000}, "PAYMENT_STATUS_PARTIALLY_REFUNDED", nil)
+
+		mockQueries.On("EnqueueWebhookDeliveries", mock.Anything, mock.MatchedBy(func(p db.EnqueueWebhookDeliveriesParams) bool {
+			return p.EventType == "payment.refunded"
+		})).Return(1, nil)
+
+		err := webhooks.HandleWebhook(context.Background(), newEvent("payment.refunded", map[string]interface{}{
+			"payment_id":    paymentID.String(),
+			"refund_id":     "re_1",
+			"refund_amount": float64(4000),
+		}))
+
+		require.NoError(t, err)
+		mockQueries.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// ZenginDeposit is one deposit from a Zengin 振込入金通知 file
+type ZenginDeposit struct {
+	RecordNumber int
+	// Account the deposit was paid into, from the preceding header record
+	BankCode      string
+	BranchCode    string
+	AccountNumber string
+
+	InquiryNumber   string
+	ValueDate       time.Time
+	AmountMinor     int
+	DepositorCode   string
+	DepositorName   string
+	RemittingBank   string
+	RemittingBranch string
+	EDI             string
+	// Cancelled records reverse a deposit reported in an earlier file
+	Cancelled bool
+}
+
+// Zengin 振込入金通知 record layout (種別コード 01). Every record is 200
+// bytes of Shift_JIS; positions are 1-based and inclusive.
+//
+//	1  header   | 2-3 kind | 4 code section | 5-10 created | 11-16 from | 17-22 to |
+//	            | 23-26 bank code | 27-41 bank name | 42-44 branch code |
+//	            | 45-59 branch name | 63 deposit type | 64-70 account number |
+//	            | 71-110 account name
+//	2  data     | 2-7 照会番号 | 8-13 勘定日 | 14-19 起算日 | 20-29 amount |
+//	            | 30-39 other-bank cheques | 40-49 依頼人コード | 50-97 依頼人名 |
+//	            | 98-112 仕向銀行名 | 113-127 仕向店名 | 128 取消区分 | 129-148 EDI
+//	8  trailer  | 2-7 deposit count | 8-19 deposit total | 20-25 cancelled count |
+//	            | 26-37 cancelled total
+//	9  end
+//
+// A file may hold several header/data/trailer groups, one per account.
+const (
+	zenginRecordLength        = 200
+	zenginKindDepositNotice   = "01"
+	zenginCancelledIndicator  = '1'
+	zenginCodeSectionShiftJIS = '0'
+)
+
+// ParseZenginDeposits parses a Zengin 振込入金通知 file. Records may be
+// separated by CRLF or LF, or concatenated without separators as banks
+// transmit them. Each trailer's counts and totals must match its group.
+func ParseZenginDeposits(data []byte) ([]ZenginDeposit, error) {
+	records, err := splitZenginRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var deposits []ZenginDeposit
+	var header []byte
+	var count, cancelled int
+	var total, cancelledTotal int64
+	ended := false
+	for i, rec := range records {
+		n := i + 1
+		if ended {
+			return nil, fmt.Errorf("record %d: data after end record", n)
+		}
+		col := func(from, to int) []byte {
+			return rec[from-1 : to]
+		}
+
+		switch rec[0] {
+		case '1':
+			if header != nil {
+				return nil, fmt.Errorf("record %d: header before trailer of previous group", n)
+			}
+			if kind := string(col(2, 3)); kind != zenginKindDepositNotice {
+				return nil, fmt.Errorf("record %d: kind %s is not a deposit notice", n, kind)
+			}
+			if rec[3] != zenginCodeSectionShiftJIS {
+				return nil, fmt.Errorf("record %d: only Shift_JIS files are supported", n)
+			}
+			header = rec
+			count, cancelled, total, cancelledTotal = 0, 0, 0, 0
+		case '2':
+			if header == nil {
+				return nil, fmt.Errorf("record %d: data record without header", n)
+			}
+			hcol := func(from, to int) string {
+				return strings.TrimSpace(string(header[from-1 : to]))
+			}
+			d := ZenginDeposit{
+				RecordNumber:    n,
+				BankCode:        hcol(23, 26),
+				BranchCode:      hcol(42, 44),
+				AccountNumber:   hcol(64, 70),
+				InquiryNumber:   strings.TrimSpace(string(col(2, 7))),
+				DepositorCode:   strings.TrimSpace(string(col(40, 49))),
+				DepositorName:   decodeZenginText(col(50, 97)),
+				RemittingBank:   decodeZenginText(col(98, 112)),
+				RemittingBranch: decodeZenginText(col(113, 127)),
+				EDI:             decodeZenginText(col(129, 148)),
+				Cancelled:       rec[127] == zenginCancelledIndicator,
+			}
+			if d.ValueDate, err = parseZenginDate(string(col(8, 13))); err != nil {
+				return nil, fmt.Errorf("record %d: invalid 勘定日: %w", n, err)
+			}
+			if d.AmountMinor, err = strconv.Atoi(string(col(20, 29))); err != nil {
+				return nil, fmt.Errorf("record %d: invalid amount %q", n, col(20, 29))
+			}
+			if d.Cancelled {
+				cancelled++
+				cancelledTotal += int64(d.AmountMinor)
+			} else {
+				count++
+				total += int64(d.AmountMinor)
+			}
+			deposits = append(deposits, d)
+		case '8':
+			if header == nil {
+				return nil, fmt.Errorf("record %d: trailer without header", n)
+			}
+			wantCount, err1 := strconv.Atoi(string(col(2, 7)))
+			wantTotal, err2 := strconv.ParseInt(string(col(8, 19)), 10, 64)
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("record %d: invalid trailer totals", n)
+			}
+			if wantCount != count || wantTotal != total {
+				return nil, fmt.Errorf("record %d: trailer reports %d deposits totalling %d, group has %d totalling %d",
+					n, wantCount, wantTotal, count, total)
+			}
+			// Older files leave the cancellation fields blank
+			if c := strings.TrimSpace(string(col(20, 37))); c != "" {
+				wantCancelled, err1 := strconv.Atoi(string(col(20, 25)))
+				wantCancelledTotal, err2 := strconv.ParseInt(string(col(26, 37)), 10, 64)
+				if err1 != nil || err2 != nil || wantCancelled != cancelled || wantCancelledTotal != cancelledTotal {
+					return nil, fmt.Errorf("record %d: trailer cancellation totals do not match the group", n)
+				}
+			}
+			header = nil
+		case '9':
+			if header != nil {
+				return nil, fmt.Errorf("record %d: end record before trailer", n)
+			}
+			ended = true
+		default:
+			return nil, fmt.Errorf("record %d: unknown record type %q", n, rec[0])
+		}
+	}
+	if header != nil {
+		return nil, fmt.Errorf("missing trailer record")
+	}
+	return deposits, nil
+}
+
+// splitZenginRecords cuts a file into 200-byte records
+func splitZenginRecords(data []byte) ([][]byte, error) {
+	// Some banks close the file with an MS-DOS end-of-file marker
+	data = bytes.TrimRight(data, "\x1a")
+
+	var records [][]byte
+	if bytes.ContainsRune(data, '\n') {
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			line = bytes.TrimRight(line, "\r")
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			if len(line) > zenginRecordLength {
+				return nil, fmt.Errorf("line %d: record is %d bytes, want %d", i+1, len(line), zenginRecordLength)
+			}
+			if len(line) < zenginRecordLength {
+				// Transfer tools commonly strip trailing blanks
+				line = append(line, bytes.Repeat([]byte(" "), zenginRecordLength-len(line))...)
+			}
+			records = append(records, line)
+		}
+		return records, nil
+	}
+
+	if len(data)%zenginRecordLength != 0 {
+		return nil, fmt.Errorf("file length %d is not a multiple of %d", len(data), zenginRecordLength)
+	}
+	for i := 0; i < len(data); i += zenginRecordLength {
+		records = append(records, data[i:i+zenginRecordLength])
+	}
+	return records, nil
+}
+
+// decodeZenginText converts a Shift_JIS field to UTF-8. Names are normally
+// half-width katakana and are returned as such.
+func decodeZenginText(b []byte) string {
+	s, err := japanese.ShiftJIS.NewDecoder().Bytes(b)
+	if err != nil {
+		return strings.TrimSpace(string(b))
+	}
+	return strings.TrimRight(string(s), " 　")
+}
+
+// parseZenginDate parses a YYMMDD date. The Zengin format specifies the
+// Japanese era year (Reiwa), but some banks send the last two digits of the
+// Gregorian year instead; a Reiwa year that lies in the future can only be
+// such a Gregorian year.
+func parseZenginDate(s string) (time.Time, error) {
+	if len(s) != 6 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	yy, err1 := strconv.Atoi(s[0:2])
+	mm, err2 := strconv.Atoi(s[2:4])
+	dd, err3 := strconv.Atoi(s[4:6])
+	if err1 != nil || err2 != nil || err3 != nil || mm < 1 || mm > 12 || dd < 1 || dd > 31 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	year := 2018 + yy
+	if year > time.Now().In(tokyo).Year()+1 {
+		year = 2000 + yy
+	}
+	t := time.Date(year, time.Month(mm), dd, 0, 0, 0, 0, tokyo)
+	if t.Day() != dd {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
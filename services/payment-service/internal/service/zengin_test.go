@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// zenginRecord builds a 200-byte record from Shift_JIS encoded fields
+func zenginRecord(fields ...string) []byte {
+	var b bytes.Buffer
+	for _, f := range fields {
+		encoded, err := japanese.ShiftJIS.NewEncoder().String(f)
+		if err != nil {
+			panic(err)
+		}
+		b.WriteString(encoded)
+	}
+	rec := b.Bytes()
+	return append(rec, bytes.Repeat([]byte(" "), zenginRecordLength-len(rec))...)
+}
+
+// pad space-pads s to width Shift_JIS bytes; the test data only uses ASCII
+// and half-width kana, which are one byte each
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+func zenginHeader(account string) []byte {
+	return zenginRecord("1", "01", "0", "080403", "080403", "080403",
+		"0000", pad("ﾃｽﾄ", 15), "001", pad("ﾎﾝﾃﾝ", 15), "   ", "1", account)
+}
+
+func zenginData(inquiry string, amount int, name string, cancelled bool) []byte {
+	cancel := "0"
+	if cancelled {
+		cancel = "1"
+	}
+	return zenginRecord("2", inquiry, "080403", "080403", fmt.Sprintf("%010d", amount), "0000000000",
+		pad("", 10), pad(name, 48), pad("ﾐﾂﾋﾞｼ", 15), pad("ｼﾌﾞﾔ", 15), cancel)
+}
+
+func zenginTrailer(count, total, cancelled, cancelledTotal int) []byte {
+	return zenginRecord("8", fmt.Sprintf("%06d%012d%06d%012d", count, total, cancelled, cancelledTotal))
+}
+
+func TestParseZenginDeposits(t *testing.T) {
+	day := time.Date(2026, 4, 3, 0, 0, 0, 0, tokyo)
+
+	t.Run("parses newline separated groups", func(t *testing.T) {
+		file := bytes.Join([][]byte{
+			zenginHeader("1000001"),
+			zenginData("000001", 12000, "ﾔﾏﾀﾞ ﾀﾛｳ", false),
+			zenginData("000002", 500, "ｶ)ｻﾝﾌﾟﾙ", true),
+			zenginTrailer(1, 12000, 1, 500),
+			zenginHeader("1000002"),
+			zenginData("000003", 3000, "ｽｽﾞｷ ﾊﾅｺ", false),
+			zenginTrailer(1, 3000, 0, 0),
+			zenginRecord("9"),
+		}, []byte("\r\n"))
+
+		deposits, err := ParseZenginDeposits(file)
+
+		require.NoError(t, err)
+		require.Len(t, deposits, 3)
+		assert.Equal(t, ZenginDeposit{
+			RecordNumber:    2,
+			BankCode:        "0000",
+			BranchCode:      "001",
+			AccountNumber:   "1000001",
+			InquiryNumber:   "000001",
+			ValueDate:       day,
+			AmountMinor:     12000,
+			DepositorName:   "ﾔﾏﾀﾞ ﾀﾛｳ",
+			RemittingBank:   "ﾐﾂﾋﾞｼ",
+			RemittingBranch: "ｼﾌﾞﾔ",
+		}, deposits[0])
+		assert.True(t, deposits[1].Cancelled)
+		assert.Equal(t, "1000002", deposits[2].AccountNumber)
+	})
+
+	t.Run("parses records transmitted without separators", func(t *testing.T) {
+		file := bytes.Join([][]byte{
+			zenginHeader("1000001"),
+			zenginData("000001", 12000, "ﾔﾏﾀﾞ ﾀﾛｳ", false),
+			zenginTrailer(1, 12000, 0, 0),
+			zenginRecord("9"),
+			{0x1a},
+		}, nil)
+
+		deposits, err := ParseZenginDeposits(file)
+
+		require.NoError(t, err)
+		require.Len(t, deposits, 1)
+		assert.Equal(t, 12000, deposits[0].AmountMinor)
+	})
+
+	t.Run("rejects a trailer that does not match", func(t *testing.T) {
+		file := bytes.Join([][]byte{
+			zenginHeader("1000001"),
+			zenginData("000001", 12000, "ﾔﾏﾀﾞ ﾀﾛｳ", false),
+			zenginTrailer(1, 11000, 0, 0),
+			zenginRecord("9"),
+		}, []byte("\n"))
+
+		_, err := ParseZenginDeposits(file)
+		assert.ErrorContains(t, err, "trailer reports 1 deposits totalling 11000")
+	})
+
+	t.Run("rejects other Zengin file kinds", func(t *testing.T) {
+		file := bytes.Join([][]byte{
+			zenginRecord("1", "21", "0"),
+			zenginRecord("9"),
+		}, []byte("\n"))
+
+		_, err := ParseZenginDeposits(file)
+		assert.ErrorContains(t, err, "not a deposit notice")
+	})
+
+	t.Run("rejects a truncated file", func(t *testing.T) {
+		_, err := ParseZenginDeposits(bytes.Join([][]byte{
+			zenginHeader("1000001"),
+			zenginData("000001", 12000, "ﾔﾏﾀﾞ ﾀﾛｳ", false),
+		}, []byte("\n")))
+		assert.EqualError(t, err, "missing trailer record")
+	})
+}
+
+func TestParseZenginDate(t *testing.T) {
+	reiwa, err := parseZenginDate("080403")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 4, 3, 0, 0, 0, 0, tokyo), reiwa)
+
+	gregorian, err := parseZenginDate("260403")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 4, 3, 0, 0, 0, 0, tokyo), gregorian)
+
+	_, err = parseZenginDate("080231")
+	assert.Error(t, err)
+}